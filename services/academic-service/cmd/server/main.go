@@ -7,10 +7,16 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/audit"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/database"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/identity"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/logger"
+	smiddleware "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
 	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.uber.org/zap"
@@ -20,6 +26,7 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/middleware"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/repository/postgres"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/rabbit"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/tracer"
 
@@ -117,19 +124,31 @@ func main() {
 	curriculumUseCase := usecase.NewCurriculumUseCase(curriculumRepo, 5*time.Second)
 	curriculumHandler := handler.NewCurriculumHandler(curriculumUseCase)
 
+	rb := rabbit.New(cfg.RabbitURL)
+	// Events are stored with the change they describe and relayed to RabbitMQ
+	events := outbox.New(dbPool)
+	if rb != nil {
+		go outbox.NewRelay(dbPool, rb).Run(context.Background())
+	}
+	// Enrollment moves go to the platform audit trail
+	auditor := audit.NewPublisher(events)
+
 	enrollmentRepo := postgres.NewEnrollmentRepository(dbPool)
-	enrollmentUseCase := usecase.NewEnrollmentUseCase(enrollmentRepo, classRepo, 5*time.Second)
+	enrollmentUseCase := usecase.NewEnrollmentUseCase(enrollmentRepo, classRepo, events, auditor, 5*time.Second)
 	enrollmentHandler := handler.NewEnrollmentHandler(enrollmentUseCase)
 
 	classSubjectRepo := postgres.NewClassSubjectRepository(dbPool)
 	classSubjectUseCase := usecase.NewClassSubjectUseCase(classSubjectRepo, 5*time.Second)
 	classSubjectHandler := handler.NewClassSubjectHandler(classSubjectUseCase)
 
+	guardianRepo := postgres.NewGuardianRepository(dbPool)
+	guardianUseCase := usecase.NewGuardianUseCase(guardianRepo, studentRepo, enrollmentRepo, scheduleRepo, events, 5*time.Second)
+	guardianHandler := handler.NewGuardianHandler(guardianUseCase)
+
 	// Event Consumer
-	rb := rabbit.New(cfg.RabbitURL)
 	if rb != nil {
-		consumer := event.NewConsumer(rb, studentUseCase)
-		consumer.Start()
+		consumer := event.NewConsumer(rb, studentUseCase, guardianUseCase)
+		consumer.Start(context.Background())
 	}
 
 	r := gin.New()
@@ -138,12 +157,21 @@ func main() {
 		log.Fatal("Failed to set trusted proxies", zap.Error(err))
 	}
 	r.Use(gin.Logger(), gin.Recovery())
-	limiter := redisutil.NewLimiterFromCounter(redis.Raw())
+	limiter := redisutil.NewTokenBucketLimiter(redis.Raw())
 	r.Use(middleware.SecurityHeaders())
-	r.Use(middleware.RateLimitByPolicy(limiter, 100, 30, map[string]int{}))
+	// Anonymous routes are limited per client address; the authenticated
+	// group runs the limiter after authn so each user has their own budget
+	limit := middleware.RateLimitByPolicy(limiter, 100, 30, nil, cfg.TrustedProxies)
+	public := r.Group("/", limit)
+
+	authorizer := authz.NewClient(cfg.AuthServiceURL, redis.Raw(), authz.DefaultCacheTTL, 5*time.Second)
+	perm := func(permission string) gin.HandlerFunc { return middleware.Authorization(authorizer, permission) }
+	guardianChecker := smiddleware.GuardianCheckerFunc(func(userID uuid.UUID, tenantID string, studentID uuid.UUID) (bool, error) {
+		return guardianUseCase.IsGuardianOf(context.Background(), tenantID, userID, studentID)
+	})
 
 	// Health Check
-	r.GET("/api/v1/health", func(c *gin.Context) {
+	public.GET("/api/v1/health", func(c *gin.Context) {
 		ctx := c.Request.Context()
 		if err := dbPool.Ping(ctx); err != nil {
 			httputil.Error(c.Writer, http.StatusServiceUnavailable, "5003", "Database Unavailable", err.Error())
@@ -156,137 +184,171 @@ func main() {
 		httputil.Success(c.Writer, map[string]string{"status": "ok", "service": "academic-service"})
 	})
 
+	// Internal
+	guardianHandler.RegisterInternal(r)
+
 	// Swagger
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	public.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Routes
 	v1 := r.Group("/api/v1")
+	keys := jwtutil.NewKeySet(cfg.JWTSigningAlg, cfg.JWTAccessSecret, cfg.JWKSURL)
+	authn := middleware.Auth(keys, cfg.JWTIssuer, cfg.JWTAudience, redis.Raw())
+	if cfg.TrustGateway {
+		// Behind the gateway the signed identity headers replace the bearer token
+		authn = identity.Gin([]byte(cfg.GatewaySecret), redis.Raw())
+	}
+	v1.Use(authn, limit)
 	{
 		schools := v1.Group("/schools")
 		{
-			schools.POST("", schoolHandler.Create)
-			schools.GET("/:id", schoolHandler.GetByID)
-			schools.GET("/tenant/:tenant_id", schoolHandler.GetByTenantID)
-			schools.PUT("/:id", schoolHandler.Update)
-			schools.DELETE("/:id", schoolHandler.Delete)
+			schools.POST("", perm(authz.SchoolWrite), schoolHandler.Create)
+			schools.GET("/:id", perm(authz.SchoolRead), schoolHandler.GetByID)
+			schools.GET("/tenant/:tenant_id", perm(authz.SchoolRead), schoolHandler.GetByTenantID)
+			schools.PUT("/:id", perm(authz.SchoolWrite), schoolHandler.Update)
+			schools.DELETE("/:id", perm(authz.SchoolDelete), schoolHandler.Delete)
 		}
 
 		academicYears := v1.Group("/academic-years")
 		{
-			academicYears.POST("", academicYearHandler.Create)
-			academicYears.GET("/:id", academicYearHandler.GetByID)
-			academicYears.GET("/tenant/:tenant_id", academicYearHandler.List)
-			academicYears.PUT("/:id", academicYearHandler.Update)
-			academicYears.DELETE("/:id", academicYearHandler.Delete)
+			academicYears.POST("", perm(authz.AcademicYearWrite), academicYearHandler.Create)
+			academicYears.GET("/:id", perm(authz.AcademicYearRead), academicYearHandler.GetByID)
+			academicYears.GET("/tenant/:tenant_id", perm(authz.AcademicYearRead), academicYearHandler.List)
+			academicYears.PUT("/:id", perm(authz.AcademicYearWrite), academicYearHandler.Update)
+			academicYears.DELETE("/:id", perm(authz.AcademicYearDelete), academicYearHandler.Delete)
 		}
 
 		semesters := v1.Group("/semesters")
 		{
-			semesters.POST("", semesterHandler.Create)
-			semesters.GET("/:id", semesterHandler.GetByID)
-			semesters.GET("", semesterHandler.List)
-			semesters.PUT("/:id", semesterHandler.Update)
-			semesters.PATCH("/:id/activate", semesterHandler.Activate)
-			semesters.DELETE("/:id", semesterHandler.Delete)
+			semesters.POST("", perm(authz.SemesterWrite), semesterHandler.Create)
+			semesters.GET("/:id", perm(authz.SemesterRead), semesterHandler.GetByID)
+			semesters.GET("", perm(authz.SemesterRead), semesterHandler.List)
+			semesters.PUT("/:id", perm(authz.SemesterWrite), semesterHandler.Update)
+			semesters.PATCH("/:id/activate", perm(authz.SemesterWrite), semesterHandler.Activate)
+			semesters.DELETE("/:id", perm(authz.SemesterDelete), semesterHandler.Delete)
 		}
 
 		students := v1.Group("/students")
 		{
-			students.POST("", studentHandler.Create)
-			students.GET("/:id", studentHandler.GetByID)
-			students.GET("", studentHandler.List) // Query: ?tenant_id=...&limit=...&offset=...
-			students.PUT("/:id", studentHandler.Update)
-			students.DELETE("/:id", studentHandler.Delete)
+			students.POST("", perm(authz.StudentWrite), studentHandler.Create)
+			students.GET("/:id", perm(authz.StudentRead), studentHandler.GetByID)
+			students.GET("", perm(authz.StudentRead), studentHandler.List) // Query: ?tenant_id=...&limit=...&offset=...
+			students.PUT("/:id", perm(authz.StudentWrite), studentHandler.Update)
+			students.DELETE("/:id", perm(authz.StudentDelete), studentHandler.Delete)
 		}
 
 		teachers := v1.Group("/teachers")
 		{
-			teachers.POST("", teacherHandler.Create)
-			teachers.GET("/:id", teacherHandler.GetByID)
-			teachers.GET("", teacherHandler.List) // Query: ?tenant_id=...&limit=...&offset=...
-			teachers.PUT("/:id", teacherHandler.Update)
-			teachers.DELETE("/:id", teacherHandler.Delete)
+			teachers.POST("", perm(authz.TeacherWrite), teacherHandler.Create)
+			teachers.GET("/:id", perm(authz.TeacherRead), teacherHandler.GetByID)
+			teachers.GET("", perm(authz.TeacherRead), teacherHandler.List) // Query: ?tenant_id=...&limit=...&offset=...
+			teachers.PUT("/:id", perm(authz.TeacherWrite), teacherHandler.Update)
+			teachers.DELETE("/:id", perm(authz.TeacherDelete), teacherHandler.Delete)
 		}
 
 		classes := v1.Group("/classes")
 		{
-			classes.POST("", classHandler.Create)
-			classes.GET("/:id", classHandler.GetByID)
-			classes.GET("", classHandler.List) // Query: ?tenant_id=...&limit=...&offset=...
-			classes.PUT("/:id", classHandler.Update)
-			classes.DELETE("/:id", classHandler.Delete)
+			classes.POST("", perm(authz.ClassWrite), classHandler.Create)
+			classes.GET("/:id", perm(authz.ClassRead), classHandler.GetByID)
+			classes.GET("", perm(authz.ClassRead), classHandler.List) // Query: ?tenant_id=...&limit=...&offset=...
+			classes.PUT("/:id", perm(authz.ClassWrite), classHandler.Update)
+			classes.DELETE("/:id", perm(authz.ClassDelete), classHandler.Delete)
 
 			// Class Subjects
-			classes.POST("/:id/subjects", classSubjectHandler.AddSubjectToClass)
-			classes.GET("/:id/subjects", classSubjectHandler.ListByClass)
-			classes.DELETE("/:id/subjects/:subject_id", classSubjectHandler.RemoveSubject)
-			classes.POST("/:id/subjects/:subject_id/teacher", classSubjectHandler.AssignTeacher)
+			classes.POST("/:id/subjects", perm(authz.ClassWrite), classSubjectHandler.AddSubjectToClass)
+			classes.GET("/:id/subjects", perm(authz.ClassRead), classSubjectHandler.ListByClass)
+			classes.DELETE("/:id/subjects/:subject_id", perm(authz.ClassWrite), classSubjectHandler.RemoveSubject)
+			classes.POST("/:id/subjects/:subject_id/teacher", perm(authz.ClassAssignTeacher), classSubjectHandler.AssignTeacher)
 		}
 
 		subjects := v1.Group("/subjects")
 		{
-			subjects.POST("", subjectHandler.Create)
-			subjects.GET("/:id", subjectHandler.GetByID)
-			subjects.GET("", subjectHandler.List) // Query: ?tenant_id=...&limit=...&offset=...
-			subjects.PUT("/:id", subjectHandler.Update)
-			subjects.DELETE("/:id", subjectHandler.Delete)
+			subjects.POST("", perm(authz.SubjectWrite), subjectHandler.Create)
+			subjects.GET("/:id", perm(authz.SubjectRead), subjectHandler.GetByID)
+			subjects.GET("", perm(authz.SubjectRead), subjectHandler.List) // Query: ?tenant_id=...&limit=...&offset=...
+			subjects.PUT("/:id", perm(authz.SubjectWrite), subjectHandler.Update)
+			subjects.DELETE("/:id", perm(authz.SubjectDelete), subjectHandler.Delete)
 		}
 
 		schedules := v1.Group("/schedules")
 		{
-			schedules.POST("", scheduleHandler.Create)
-			schedules.POST("/from-template", scheduleHandler.CreateFromTemplate)
-			schedules.POST("/bulk", scheduleHandler.BulkCreate)
-			schedules.GET("/:id", scheduleHandler.GetByID)
-			schedules.GET("", scheduleHandler.List) // Query: ?tenant_id=...&limit=...&offset=...
-			schedules.GET("/class/:class_id", scheduleHandler.ListByClass)
-			schedules.PUT("/:id", scheduleHandler.Update)
-			schedules.DELETE("/:id", scheduleHandler.Delete)
+			schedules.POST("", perm(authz.ScheduleWrite), scheduleHandler.Create)
+			schedules.POST("/from-template", perm(authz.ScheduleWrite), scheduleHandler.CreateFromTemplate)
+			schedules.POST("/bulk", perm(authz.ScheduleWrite), scheduleHandler.BulkCreate)
+			schedules.GET("/:id", perm(authz.ScheduleRead), scheduleHandler.GetByID)
+			schedules.GET("", perm(authz.ScheduleRead), scheduleHandler.List) // Query: ?tenant_id=...&limit=...&offset=...
+			schedules.GET("/class/:class_id", perm(authz.ScheduleRead), scheduleHandler.ListByClass)
+			schedules.PUT("/:id", perm(authz.ScheduleWrite), scheduleHandler.Update)
+			schedules.DELETE("/:id", perm(authz.ScheduleDelete), scheduleHandler.Delete)
 		}
 
 		scheduleTemplates := v1.Group("/schedule-templates")
 		{
-			scheduleTemplates.POST("", scheduleTemplateHandler.Create)
-			scheduleTemplates.GET("/:id", scheduleTemplateHandler.GetByID)
-			scheduleTemplates.GET("", scheduleTemplateHandler.List) // Query: ?tenant_id=...
-			scheduleTemplates.POST("/:id/items", scheduleTemplateHandler.AddItem)
-			scheduleTemplates.DELETE("/items/:item_id", scheduleTemplateHandler.RemoveItem)
+			scheduleTemplates.POST("", perm(authz.ScheduleWrite), scheduleTemplateHandler.Create)
+			scheduleTemplates.GET("/:id", perm(authz.ScheduleRead), scheduleTemplateHandler.GetByID)
+			scheduleTemplates.GET("", perm(authz.ScheduleRead), scheduleTemplateHandler.List) // Query: ?tenant_id=...
+			scheduleTemplates.POST("/:id/items", perm(authz.ScheduleWrite), scheduleTemplateHandler.AddItem)
+			scheduleTemplates.DELETE("/items/:item_id", perm(authz.ScheduleWrite), scheduleTemplateHandler.RemoveItem)
 		}
 
 		curricula := v1.Group("/curricula")
 		{
-			curricula.POST("", curriculumHandler.Create)
-			curricula.GET("/:id", curriculumHandler.GetByID)
-			curricula.GET("", curriculumHandler.List)
-			curricula.PUT("/:id", curriculumHandler.Update)
-			curricula.DELETE("/:id", curriculumHandler.Delete)
+			curricula.POST("", perm(authz.CurriculumWrite), curriculumHandler.Create)
+			curricula.GET("/:id", perm(authz.CurriculumRead), curriculumHandler.GetByID)
+			curricula.GET("", perm(authz.CurriculumRead), curriculumHandler.List)
+			curricula.PUT("/:id", perm(authz.CurriculumWrite), curriculumHandler.Update)
+			curricula.DELETE("/:id", perm(authz.CurriculumDelete), curriculumHandler.Delete)
 
 			// Curriculum Subjects
-			curricula.POST("/:id/subjects", curriculumHandler.AddSubject)
-			curricula.GET("/:id/subjects", curriculumHandler.ListSubjects)
-			curricula.DELETE("/:id/subjects/:subject_id", curriculumHandler.RemoveSubject)
+			curricula.POST("/:id/subjects", perm(authz.CurriculumWrite), curriculumHandler.AddSubject)
+			curricula.GET("/:id/subjects", perm(authz.CurriculumRead), curriculumHandler.ListSubjects)
+			curricula.DELETE("/:id/subjects/:subject_id", perm(authz.CurriculumWrite), curriculumHandler.RemoveSubject)
 
 			// Grading Rules
-			curricula.POST("/:id/grading-rules", curriculumHandler.AddGradingRule)
-			curricula.GET("/:id/grading-rules", curriculumHandler.ListGradingRules)
+			curricula.POST("/:id/grading-rules", perm(authz.CurriculumWrite), curriculumHandler.AddGradingRule)
+			curricula.GET("/:id/grading-rules", perm(authz.CurriculumRead), curriculumHandler.ListGradingRules)
 		}
 
 		enrollments := v1.Group("/enrollments")
 		{
-			enrollments.POST("", enrollmentHandler.Enroll)
-			enrollments.DELETE("/:id", enrollmentHandler.Unenroll)
-			enrollments.GET("/:id", enrollmentHandler.GetByID)
-			enrollments.PUT("/:id/status", enrollmentHandler.UpdateStatus)
+			enrollments.POST("", perm(authz.EnrollmentWrite), enrollmentHandler.Enroll)
+			enrollments.DELETE("/:id", perm(authz.EnrollmentDelete), enrollmentHandler.Unenroll)
+			enrollments.GET("/:id", perm(authz.EnrollmentRead), enrollmentHandler.GetByID)
+			enrollments.PUT("/:id/status", perm(authz.EnrollmentWrite), enrollmentHandler.UpdateStatus)
+		}
+
+		guardians := v1.Group("/guardians")
+		{
+			guardians.POST("", perm(authz.GuardianWrite), guardianHandler.Create)
+			guardians.GET("/:id", perm(authz.GuardianRead), guardianHandler.GetByID)
+			guardians.GET("", perm(authz.GuardianRead), guardianHandler.List) // Query: ?tenant_id=...&limit=...&offset=...
+			guardians.PUT("/:id", perm(authz.GuardianWrite), guardianHandler.Update)
+			guardians.DELETE("/:id", perm(authz.GuardianDelete), guardianHandler.Delete)
+			guardians.PUT("/:id/user", perm(authz.GuardianWrite), guardianHandler.LinkUser)
+
+			// Linked students
+			guardians.POST("/:id/students", perm(authz.GuardianWrite), guardianHandler.LinkStudent)
+			guardians.GET("/:id/students", perm(authz.GuardianRead), guardianHandler.ListStudents)
+			guardians.DELETE("/:id/students/:student_id", perm(authz.GuardianWrite), guardianHandler.UnlinkStudent)
+		}
+
+		// Guardian portal, scoped to the caller's own children. Grades, attendance
+		// and invoices live under /guardian/<service>/students in their own services
+		guardianPortal := v1.Group("/guardian/students", perm(authz.GuardianChildRead))
+		{
+			guardianPortal.GET("", guardianHandler.ListMyStudents)
+			guardianPortal.GET("/:student_id/schedules", middleware.GuardianScope(guardianChecker, "student_id"), guardianHandler.StudentSchedules)
 		}
 
 		// Additional routes
-		classes.GET("/:id/students", enrollmentHandler.ListByClass)
-		classes.POST("/:id/students/bulk", enrollmentHandler.BulkEnroll)
-		students.GET("/:id/classes", enrollmentHandler.ListByStudent)
+		classes.GET("/:id/students", perm(authz.EnrollmentRead), enrollmentHandler.ListByClass)
+		classes.POST("/:id/students/bulk", perm(authz.EnrollmentWrite), enrollmentHandler.BulkEnroll)
+		students.GET("/:id/classes", perm(authz.EnrollmentRead), enrollmentHandler.ListByStudent)
+		students.GET("/:id/guardians", perm(authz.GuardianRead), guardianHandler.ListByStudent)
 	}
 
 	// Prometheus metrics
-	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	public.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	addr := fmt.Sprintf(":%d", cfg.HTTPPort)
 	log.Info("Starting academic-service", zap.String("addr", addr))
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	GuardianRelationFather   = "father"
+	GuardianRelationMother   = "mother"
+	GuardianRelationGuardian = "guardian"
+	GuardianRelationOther    = "other"
+)
+
+type Guardian struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	TenantID   string     `json:"tenant_id" db:"tenant_id"`
+	UserID     *uuid.UUID `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	Phone      string     `json:"phone" db:"phone"`
+	Email      string     `json:"email" db:"email"`
+	Occupation string     `json:"occupation" db:"occupation"`
+	Address    string     `json:"address" db:"address"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+	CreatedBy  *uuid.UUID `json:"created_by" db:"created_by"`
+	UpdatedBy  *uuid.UUID `json:"updated_by" db:"updated_by"`
+	DeletedAt  *time.Time `json:"deleted_at" db:"deleted_at"`
+}
+
+func (g *Guardian) Validate() map[string]string {
+	errors := make(map[string]string)
+	if g.Name == "" {
+		errors["name"] = "Name is required"
+	}
+	if g.TenantID == "" {
+		errors["tenant_id"] = "Tenant ID is required"
+	}
+	return errors
+}
+
+// StudentGuardian links a student to one of their guardians.
+type StudentGuardian struct {
+	StudentID    uuid.UUID `json:"student_id" db:"student_id"`
+	GuardianID   uuid.UUID `json:"guardian_id" db:"guardian_id"`
+	Relationship string    `json:"relationship" db:"relationship"`
+	IsPrimary    bool      `json:"is_primary" db:"is_primary"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+func (l *StudentGuardian) Validate() map[string]string {
+	errors := make(map[string]string)
+	if l.StudentID == uuid.Nil {
+		errors["student_id"] = "Student ID is required"
+	}
+	if l.GuardianID == uuid.Nil {
+		errors["guardian_id"] = "Guardian ID is required"
+	}
+	switch l.Relationship {
+	case GuardianRelationFather, GuardianRelationMother, GuardianRelationGuardian, GuardianRelationOther:
+	default:
+		errors["relationship"] = "Relationship must be one of father, mother, guardian, other"
+	}
+	return errors
+}
+
+// GuardianStudent is a student as seen from a guardian, with the link details.
+type GuardianStudent struct {
+	Student
+	Relationship string `json:"relationship"`
+	IsPrimary    bool   `json:"is_primary"`
+}
@@ -85,47 +85,47 @@ func (mr *MockCurriculumUseCaseMockRecorder) Create(ctx, curriculum any) *gomock
 }
 
 // Delete mocks base method.
-func (m *MockCurriculumUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockCurriculumUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCurriculumUseCaseMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockCurriculumUseCaseMockRecorder) Delete(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCurriculumUseCase)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCurriculumUseCase)(nil).Delete), ctx, tenantID, id)
 }
 
 // GetByID mocks base method.
-func (m *MockCurriculumUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Curriculum, error) {
+func (m *MockCurriculumUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Curriculum, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
 	ret0, _ := ret[0].(*entity.Curriculum)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockCurriculumUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockCurriculumUseCaseMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCurriculumUseCase)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCurriculumUseCase)(nil).GetByID), ctx, tenantID, id)
 }
 
 // GetGradingRules mocks base method.
-func (m *MockCurriculumUseCase) GetGradingRules(ctx context.Context, curriculumID uuid.UUID) ([]entity.GradingRule, error) {
+func (m *MockCurriculumUseCase) GetGradingRules(ctx context.Context, tenantID string, curriculumID uuid.UUID) ([]entity.GradingRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGradingRules", ctx, curriculumID)
+	ret := m.ctrl.Call(m, "GetGradingRules", ctx, tenantID, curriculumID)
 	ret0, _ := ret[0].([]entity.GradingRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetGradingRules indicates an expected call of GetGradingRules.
-func (mr *MockCurriculumUseCaseMockRecorder) GetGradingRules(ctx, curriculumID any) *gomock.Call {
+func (mr *MockCurriculumUseCaseMockRecorder) GetGradingRules(ctx, tenantID, curriculumID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGradingRules", reflect.TypeOf((*MockCurriculumUseCase)(nil).GetGradingRules), ctx, curriculumID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGradingRules", reflect.TypeOf((*MockCurriculumUseCase)(nil).GetGradingRules), ctx, tenantID, curriculumID)
 }
 
 // List mocks base method.
@@ -145,32 +145,32 @@ func (mr *MockCurriculumUseCaseMockRecorder) List(ctx, tenantID, limit, offset a
 }
 
 // ListSubjects mocks base method.
-func (m *MockCurriculumUseCase) ListSubjects(ctx context.Context, curriculumID uuid.UUID) ([]entity.CurriculumSubject, error) {
+func (m *MockCurriculumUseCase) ListSubjects(ctx context.Context, tenantID string, curriculumID uuid.UUID) ([]entity.CurriculumSubject, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSubjects", ctx, curriculumID)
+	ret := m.ctrl.Call(m, "ListSubjects", ctx, tenantID, curriculumID)
 	ret0, _ := ret[0].([]entity.CurriculumSubject)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListSubjects indicates an expected call of ListSubjects.
-func (mr *MockCurriculumUseCaseMockRecorder) ListSubjects(ctx, curriculumID any) *gomock.Call {
+func (mr *MockCurriculumUseCaseMockRecorder) ListSubjects(ctx, tenantID, curriculumID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubjects", reflect.TypeOf((*MockCurriculumUseCase)(nil).ListSubjects), ctx, curriculumID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubjects", reflect.TypeOf((*MockCurriculumUseCase)(nil).ListSubjects), ctx, tenantID, curriculumID)
 }
 
 // RemoveSubject mocks base method.
-func (m *MockCurriculumUseCase) RemoveSubject(ctx context.Context, id uuid.UUID) error {
+func (m *MockCurriculumUseCase) RemoveSubject(ctx context.Context, tenantID string, curriculumID, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveSubject", ctx, id)
+	ret := m.ctrl.Call(m, "RemoveSubject", ctx, tenantID, curriculumID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveSubject indicates an expected call of RemoveSubject.
-func (mr *MockCurriculumUseCaseMockRecorder) RemoveSubject(ctx, id any) *gomock.Call {
+func (mr *MockCurriculumUseCaseMockRecorder) RemoveSubject(ctx, tenantID, curriculumID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubject", reflect.TypeOf((*MockCurriculumUseCase)(nil).RemoveSubject), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubject", reflect.TypeOf((*MockCurriculumUseCase)(nil).RemoveSubject), ctx, tenantID, curriculumID, id)
 }
 
 // Update mocks base method.
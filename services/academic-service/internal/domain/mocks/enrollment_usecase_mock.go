@@ -43,17 +43,17 @@ func (m *MockEnrollmentUseCase) EXPECT() *MockEnrollmentUseCaseMockRecorder {
 }
 
 // BulkEnroll mocks base method.
-func (m *MockEnrollmentUseCase) BulkEnroll(ctx context.Context, classID uuid.UUID, studentIDs []uuid.UUID) error {
+func (m *MockEnrollmentUseCase) BulkEnroll(ctx context.Context, tenantID string, classID uuid.UUID, studentIDs []uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkEnroll", ctx, classID, studentIDs)
+	ret := m.ctrl.Call(m, "BulkEnroll", ctx, tenantID, classID, studentIDs)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // BulkEnroll indicates an expected call of BulkEnroll.
-func (mr *MockEnrollmentUseCaseMockRecorder) BulkEnroll(ctx, classID, studentIDs any) *gomock.Call {
+func (mr *MockEnrollmentUseCaseMockRecorder) BulkEnroll(ctx, tenantID, classID, studentIDs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkEnroll", reflect.TypeOf((*MockEnrollmentUseCase)(nil).BulkEnroll), ctx, classID, studentIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkEnroll", reflect.TypeOf((*MockEnrollmentUseCase)(nil).BulkEnroll), ctx, tenantID, classID, studentIDs)
 }
 
 // Enroll mocks base method.
@@ -71,74 +71,74 @@ func (mr *MockEnrollmentUseCaseMockRecorder) Enroll(ctx, enrollment any) *gomock
 }
 
 // GetByID mocks base method.
-func (m *MockEnrollmentUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Enrollment, error) {
+func (m *MockEnrollmentUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Enrollment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
 	ret0, _ := ret[0].(*entity.Enrollment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockEnrollmentUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockEnrollmentUseCaseMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockEnrollmentUseCase)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockEnrollmentUseCase)(nil).GetByID), ctx, tenantID, id)
 }
 
 // ListByClass mocks base method.
-func (m *MockEnrollmentUseCase) ListByClass(ctx context.Context, classID uuid.UUID) ([]entity.Enrollment, error) {
+func (m *MockEnrollmentUseCase) ListByClass(ctx context.Context, tenantID string, classID uuid.UUID) ([]entity.Enrollment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByClass", ctx, classID)
+	ret := m.ctrl.Call(m, "ListByClass", ctx, tenantID, classID)
 	ret0, _ := ret[0].([]entity.Enrollment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListByClass indicates an expected call of ListByClass.
-func (mr *MockEnrollmentUseCaseMockRecorder) ListByClass(ctx, classID any) *gomock.Call {
+func (mr *MockEnrollmentUseCaseMockRecorder) ListByClass(ctx, tenantID, classID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByClass", reflect.TypeOf((*MockEnrollmentUseCase)(nil).ListByClass), ctx, classID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByClass", reflect.TypeOf((*MockEnrollmentUseCase)(nil).ListByClass), ctx, tenantID, classID)
 }
 
 // ListByStudent mocks base method.
-func (m *MockEnrollmentUseCase) ListByStudent(ctx context.Context, studentID uuid.UUID) ([]entity.Enrollment, error) {
+func (m *MockEnrollmentUseCase) ListByStudent(ctx context.Context, tenantID string, studentID uuid.UUID) ([]entity.Enrollment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByStudent", ctx, studentID)
+	ret := m.ctrl.Call(m, "ListByStudent", ctx, tenantID, studentID)
 	ret0, _ := ret[0].([]entity.Enrollment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListByStudent indicates an expected call of ListByStudent.
-func (mr *MockEnrollmentUseCaseMockRecorder) ListByStudent(ctx, studentID any) *gomock.Call {
+func (mr *MockEnrollmentUseCaseMockRecorder) ListByStudent(ctx, tenantID, studentID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStudent", reflect.TypeOf((*MockEnrollmentUseCase)(nil).ListByStudent), ctx, studentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStudent", reflect.TypeOf((*MockEnrollmentUseCase)(nil).ListByStudent), ctx, tenantID, studentID)
 }
 
 // Unenroll mocks base method.
-func (m *MockEnrollmentUseCase) Unenroll(ctx context.Context, id uuid.UUID) error {
+func (m *MockEnrollmentUseCase) Unenroll(ctx context.Context, tenantID string, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Unenroll", ctx, id)
+	ret := m.ctrl.Call(m, "Unenroll", ctx, tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Unenroll indicates an expected call of Unenroll.
-func (mr *MockEnrollmentUseCaseMockRecorder) Unenroll(ctx, id any) *gomock.Call {
+func (mr *MockEnrollmentUseCaseMockRecorder) Unenroll(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unenroll", reflect.TypeOf((*MockEnrollmentUseCase)(nil).Unenroll), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unenroll", reflect.TypeOf((*MockEnrollmentUseCase)(nil).Unenroll), ctx, tenantID, id)
 }
 
 // UpdateStatus mocks base method.
-func (m *MockEnrollmentUseCase) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+func (m *MockEnrollmentUseCase) UpdateStatus(ctx context.Context, tenantID string, id uuid.UUID, status string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, tenantID, id, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatus indicates an expected call of UpdateStatus.
-func (mr *MockEnrollmentUseCaseMockRecorder) UpdateStatus(ctx, id, status any) *gomock.Call {
+func (mr *MockEnrollmentUseCaseMockRecorder) UpdateStatus(ctx, tenantID, id, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockEnrollmentUseCase)(nil).UpdateStatus), ctx, id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockEnrollmentUseCase)(nil).UpdateStatus), ctx, tenantID, id, status)
 }
@@ -0,0 +1,204 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/guardian_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/guardian_repository.go -destination=internal/domain/mocks/guardian_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	entity "github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGuardianRepository is a mock of GuardianRepository interface.
+type MockGuardianRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuardianRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockGuardianRepositoryMockRecorder is the mock recorder for MockGuardianRepository.
+type MockGuardianRepositoryMockRecorder struct {
+	mock *MockGuardianRepository
+}
+
+// NewMockGuardianRepository creates a new mock instance.
+func NewMockGuardianRepository(ctrl *gomock.Controller) *MockGuardianRepository {
+	mock := &MockGuardianRepository{ctrl: ctrl}
+	mock.recorder = &MockGuardianRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuardianRepository) EXPECT() *MockGuardianRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGuardianRepository) Create(ctx context.Context, guardian *entity.Guardian) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, guardian)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGuardianRepositoryMockRecorder) Create(ctx, guardian any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGuardianRepository)(nil).Create), ctx, guardian)
+}
+
+// Delete mocks base method.
+func (m *MockGuardianRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGuardianRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGuardianRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockGuardianRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Guardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Guardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockGuardianRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGuardianRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByUserID mocks base method.
+func (m *MockGuardianRepository) GetByUserID(ctx context.Context, tenantID string, userID uuid.UUID) (*entity.Guardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, tenantID, userID)
+	ret0, _ := ret[0].(*entity.Guardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockGuardianRepositoryMockRecorder) GetByUserID(ctx, tenantID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockGuardianRepository)(nil).GetByUserID), ctx, tenantID, userID)
+}
+
+// IsGuardianOf mocks base method.
+func (m *MockGuardianRepository) IsGuardianOf(ctx context.Context, tenantID string, userID, studentID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsGuardianOf", ctx, tenantID, userID, studentID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsGuardianOf indicates an expected call of IsGuardianOf.
+func (mr *MockGuardianRepositoryMockRecorder) IsGuardianOf(ctx, tenantID, userID, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsGuardianOf", reflect.TypeOf((*MockGuardianRepository)(nil).IsGuardianOf), ctx, tenantID, userID, studentID)
+}
+
+// LinkStudent mocks base method.
+func (m *MockGuardianRepository) LinkStudent(ctx context.Context, link *entity.StudentGuardian) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkStudent", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkStudent indicates an expected call of LinkStudent.
+func (mr *MockGuardianRepositoryMockRecorder) LinkStudent(ctx, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkStudent", reflect.TypeOf((*MockGuardianRepository)(nil).LinkStudent), ctx, link)
+}
+
+// List mocks base method.
+func (m *MockGuardianRepository) List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Guardian, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, tenantID, limit, offset)
+	ret0, _ := ret[0].([]entity.Guardian)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockGuardianRepositoryMockRecorder) List(ctx, tenantID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockGuardianRepository)(nil).List), ctx, tenantID, limit, offset)
+}
+
+// ListByStudent mocks base method.
+func (m *MockGuardianRepository) ListByStudent(ctx context.Context, studentID uuid.UUID) ([]entity.Guardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByStudent", ctx, studentID)
+	ret0, _ := ret[0].([]entity.Guardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByStudent indicates an expected call of ListByStudent.
+func (mr *MockGuardianRepositoryMockRecorder) ListByStudent(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStudent", reflect.TypeOf((*MockGuardianRepository)(nil).ListByStudent), ctx, studentID)
+}
+
+// ListStudents mocks base method.
+func (m *MockGuardianRepository) ListStudents(ctx context.Context, guardianID uuid.UUID) ([]entity.GuardianStudent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStudents", ctx, guardianID)
+	ret0, _ := ret[0].([]entity.GuardianStudent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStudents indicates an expected call of ListStudents.
+func (mr *MockGuardianRepositoryMockRecorder) ListStudents(ctx, guardianID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStudents", reflect.TypeOf((*MockGuardianRepository)(nil).ListStudents), ctx, guardianID)
+}
+
+// UnlinkStudent mocks base method.
+func (m *MockGuardianRepository) UnlinkStudent(ctx context.Context, guardianID, studentID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkStudent", ctx, guardianID, studentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkStudent indicates an expected call of UnlinkStudent.
+func (mr *MockGuardianRepositoryMockRecorder) UnlinkStudent(ctx, guardianID, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkStudent", reflect.TypeOf((*MockGuardianRepository)(nil).UnlinkStudent), ctx, guardianID, studentID)
+}
+
+// Update mocks base method.
+func (m *MockGuardianRepository) Update(ctx context.Context, guardian *entity.Guardian) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, guardian)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGuardianRepositoryMockRecorder) Update(ctx, guardian any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGuardianRepository)(nil).Update), ctx, guardian)
+}
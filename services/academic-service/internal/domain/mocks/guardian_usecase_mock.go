@@ -0,0 +1,233 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/usecase/guardian_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/usecase/guardian_usecase.go -destination=internal/domain/mocks/guardian_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	entity "github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGuardianUseCase is a mock of GuardianUseCase interface.
+type MockGuardianUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuardianUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockGuardianUseCaseMockRecorder is the mock recorder for MockGuardianUseCase.
+type MockGuardianUseCaseMockRecorder struct {
+	mock *MockGuardianUseCase
+}
+
+// NewMockGuardianUseCase creates a new mock instance.
+func NewMockGuardianUseCase(ctrl *gomock.Controller) *MockGuardianUseCase {
+	mock := &MockGuardianUseCase{ctrl: ctrl}
+	mock.recorder = &MockGuardianUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuardianUseCase) EXPECT() *MockGuardianUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGuardianUseCase) Create(ctx context.Context, guardian *entity.Guardian) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, guardian)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGuardianUseCaseMockRecorder) Create(ctx, guardian any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGuardianUseCase)(nil).Create), ctx, guardian)
+}
+
+// Delete mocks base method.
+func (m *MockGuardianUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGuardianUseCaseMockRecorder) Delete(ctx, tenantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGuardianUseCase)(nil).Delete), ctx, tenantID, id)
+}
+
+// GetByID mocks base method.
+func (m *MockGuardianUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Guardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
+	ret0, _ := ret[0].(*entity.Guardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockGuardianUseCaseMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGuardianUseCase)(nil).GetByID), ctx, tenantID, id)
+}
+
+// IsGuardianOf mocks base method.
+func (m *MockGuardianUseCase) IsGuardianOf(ctx context.Context, tenantID string, userID, studentID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsGuardianOf", ctx, tenantID, userID, studentID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsGuardianOf indicates an expected call of IsGuardianOf.
+func (mr *MockGuardianUseCaseMockRecorder) IsGuardianOf(ctx, tenantID, userID, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsGuardianOf", reflect.TypeOf((*MockGuardianUseCase)(nil).IsGuardianOf), ctx, tenantID, userID, studentID)
+}
+
+// LinkStudent mocks base method.
+func (m *MockGuardianUseCase) LinkStudent(ctx context.Context, tenantID string, link *entity.StudentGuardian) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkStudent", ctx, tenantID, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkStudent indicates an expected call of LinkStudent.
+func (mr *MockGuardianUseCaseMockRecorder) LinkStudent(ctx, tenantID, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkStudent", reflect.TypeOf((*MockGuardianUseCase)(nil).LinkStudent), ctx, tenantID, link)
+}
+
+// LinkUser mocks base method.
+func (m *MockGuardianUseCase) LinkUser(ctx context.Context, tenantID string, guardianID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkUser", ctx, tenantID, guardianID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkUser indicates an expected call of LinkUser.
+func (mr *MockGuardianUseCaseMockRecorder) LinkUser(ctx, tenantID, guardianID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkUser", reflect.TypeOf((*MockGuardianUseCase)(nil).LinkUser), ctx, tenantID, guardianID, userID)
+}
+
+// List mocks base method.
+func (m *MockGuardianUseCase) List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Guardian, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, tenantID, limit, offset)
+	ret0, _ := ret[0].([]entity.Guardian)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockGuardianUseCaseMockRecorder) List(ctx, tenantID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockGuardianUseCase)(nil).List), ctx, tenantID, limit, offset)
+}
+
+// ListByStudent mocks base method.
+func (m *MockGuardianUseCase) ListByStudent(ctx context.Context, tenantID string, studentID uuid.UUID) ([]entity.Guardian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByStudent", ctx, tenantID, studentID)
+	ret0, _ := ret[0].([]entity.Guardian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByStudent indicates an expected call of ListByStudent.
+func (mr *MockGuardianUseCaseMockRecorder) ListByStudent(ctx, tenantID, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStudent", reflect.TypeOf((*MockGuardianUseCase)(nil).ListByStudent), ctx, tenantID, studentID)
+}
+
+// ListStudents mocks base method.
+func (m *MockGuardianUseCase) ListStudents(ctx context.Context, tenantID string, guardianID uuid.UUID) ([]entity.GuardianStudent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStudents", ctx, tenantID, guardianID)
+	ret0, _ := ret[0].([]entity.GuardianStudent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStudents indicates an expected call of ListStudents.
+func (mr *MockGuardianUseCaseMockRecorder) ListStudents(ctx, tenantID, guardianID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStudents", reflect.TypeOf((*MockGuardianUseCase)(nil).ListStudents), ctx, tenantID, guardianID)
+}
+
+// MyStudents mocks base method.
+func (m *MockGuardianUseCase) MyStudents(ctx context.Context, tenantID string, userID uuid.UUID) ([]entity.GuardianStudent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MyStudents", ctx, tenantID, userID)
+	ret0, _ := ret[0].([]entity.GuardianStudent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MyStudents indicates an expected call of MyStudents.
+func (mr *MockGuardianUseCaseMockRecorder) MyStudents(ctx, tenantID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MyStudents", reflect.TypeOf((*MockGuardianUseCase)(nil).MyStudents), ctx, tenantID, userID)
+}
+
+// StudentSchedules mocks base method.
+func (m *MockGuardianUseCase) StudentSchedules(ctx context.Context, studentID uuid.UUID) ([]entity.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StudentSchedules", ctx, studentID)
+	ret0, _ := ret[0].([]entity.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StudentSchedules indicates an expected call of StudentSchedules.
+func (mr *MockGuardianUseCaseMockRecorder) StudentSchedules(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StudentSchedules", reflect.TypeOf((*MockGuardianUseCase)(nil).StudentSchedules), ctx, studentID)
+}
+
+// UnlinkStudent mocks base method.
+func (m *MockGuardianUseCase) UnlinkStudent(ctx context.Context, tenantID string, guardianID, studentID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkStudent", ctx, tenantID, guardianID, studentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkStudent indicates an expected call of UnlinkStudent.
+func (mr *MockGuardianUseCaseMockRecorder) UnlinkStudent(ctx, tenantID, guardianID, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkStudent", reflect.TypeOf((*MockGuardianUseCase)(nil).UnlinkStudent), ctx, tenantID, guardianID, studentID)
+}
+
+// Update mocks base method.
+func (m *MockGuardianUseCase) Update(ctx context.Context, guardian *entity.Guardian) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, guardian)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGuardianUseCaseMockRecorder) Update(ctx, guardian any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGuardianUseCase)(nil).Update), ctx, guardian)
+}
@@ -99,6 +99,21 @@ func (mr *MockScheduleTemplateRepositoryMockRecorder) GetByID(ctx, id any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockScheduleTemplateRepository)(nil).GetByID), ctx, id)
 }
 
+// GetItem mocks base method.
+func (m *MockScheduleTemplateRepository) GetItem(ctx context.Context, id uuid.UUID) (*entity.ScheduleTemplateItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItem", ctx, id)
+	ret0, _ := ret[0].(*entity.ScheduleTemplateItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItem indicates an expected call of GetItem.
+func (mr *MockScheduleTemplateRepositoryMockRecorder) GetItem(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockScheduleTemplateRepository)(nil).GetItem), ctx, id)
+}
+
 // List mocks base method.
 func (m *MockScheduleTemplateRepository) List(ctx context.Context, tenantID string) ([]entity.ScheduleTemplate, error) {
 	m.ctrl.T.Helper()
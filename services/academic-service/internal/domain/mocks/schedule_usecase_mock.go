@@ -71,46 +71,46 @@ func (mr *MockScheduleUseCaseMockRecorder) Create(ctx, schedule any) *gomock.Cal
 }
 
 // CreateFromTemplate mocks base method.
-func (m *MockScheduleUseCase) CreateFromTemplate(ctx context.Context, templateID, classID uuid.UUID, teacherMap map[uuid.UUID]uuid.UUID) error {
+func (m *MockScheduleUseCase) CreateFromTemplate(ctx context.Context, tenantID string, templateID, classID uuid.UUID, teacherMap map[uuid.UUID]uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFromTemplate", ctx, templateID, classID, teacherMap)
+	ret := m.ctrl.Call(m, "CreateFromTemplate", ctx, tenantID, templateID, classID, teacherMap)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateFromTemplate indicates an expected call of CreateFromTemplate.
-func (mr *MockScheduleUseCaseMockRecorder) CreateFromTemplate(ctx, templateID, classID, teacherMap any) *gomock.Call {
+func (mr *MockScheduleUseCaseMockRecorder) CreateFromTemplate(ctx, tenantID, templateID, classID, teacherMap any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFromTemplate", reflect.TypeOf((*MockScheduleUseCase)(nil).CreateFromTemplate), ctx, templateID, classID, teacherMap)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFromTemplate", reflect.TypeOf((*MockScheduleUseCase)(nil).CreateFromTemplate), ctx, tenantID, templateID, classID, teacherMap)
 }
 
 // Delete mocks base method.
-func (m *MockScheduleUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockScheduleUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockScheduleUseCaseMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockScheduleUseCaseMockRecorder) Delete(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockScheduleUseCase)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockScheduleUseCase)(nil).Delete), ctx, tenantID, id)
 }
 
 // GetByID mocks base method.
-func (m *MockScheduleUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Schedule, error) {
+func (m *MockScheduleUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Schedule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
 	ret0, _ := ret[0].(*entity.Schedule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockScheduleUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockScheduleUseCaseMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockScheduleUseCase)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockScheduleUseCase)(nil).GetByID), ctx, tenantID, id)
 }
 
 // List mocks base method.
@@ -130,18 +130,18 @@ func (mr *MockScheduleUseCaseMockRecorder) List(ctx, tenantID, limit, offset any
 }
 
 // ListByClass mocks base method.
-func (m *MockScheduleUseCase) ListByClass(ctx context.Context, classID uuid.UUID) ([]entity.Schedule, error) {
+func (m *MockScheduleUseCase) ListByClass(ctx context.Context, tenantID string, classID uuid.UUID) ([]entity.Schedule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByClass", ctx, classID)
+	ret := m.ctrl.Call(m, "ListByClass", ctx, tenantID, classID)
 	ret0, _ := ret[0].([]entity.Schedule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListByClass indicates an expected call of ListByClass.
-func (mr *MockScheduleUseCaseMockRecorder) ListByClass(ctx, classID any) *gomock.Call {
+func (mr *MockScheduleUseCaseMockRecorder) ListByClass(ctx, tenantID, classID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByClass", reflect.TypeOf((*MockScheduleUseCase)(nil).ListByClass), ctx, classID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByClass", reflect.TypeOf((*MockScheduleUseCase)(nil).ListByClass), ctx, tenantID, classID)
 }
 
 // Update mocks base method.
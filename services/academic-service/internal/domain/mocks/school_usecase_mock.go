@@ -57,32 +57,32 @@ func (mr *MockSchoolUseCaseMockRecorder) Create(ctx, school any) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockSchoolUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockSchoolUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockSchoolUseCaseMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockSchoolUseCaseMockRecorder) Delete(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSchoolUseCase)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSchoolUseCase)(nil).Delete), ctx, tenantID, id)
 }
 
 // GetByID mocks base method.
-func (m *MockSchoolUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.School, error) {
+func (m *MockSchoolUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.School, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
 	ret0, _ := ret[0].(*entity.School)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockSchoolUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockSchoolUseCaseMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSchoolUseCase)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSchoolUseCase)(nil).GetByID), ctx, tenantID, id)
 }
 
 // GetByTenantID mocks base method.
@@ -57,32 +57,32 @@ func (mr *MockStudentUseCaseMockRecorder) Create(ctx, student any) *gomock.Call
 }
 
 // Delete mocks base method.
-func (m *MockStudentUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockStudentUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockStudentUseCaseMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockStudentUseCaseMockRecorder) Delete(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStudentUseCase)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStudentUseCase)(nil).Delete), ctx, tenantID, id)
 }
 
 // GetByID mocks base method.
-func (m *MockStudentUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Student, error) {
+func (m *MockStudentUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Student, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
 	ret0, _ := ret[0].(*entity.Student)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockStudentUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockStudentUseCaseMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockStudentUseCase)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockStudentUseCase)(nil).GetByID), ctx, tenantID, id)
 }
 
 // List mocks base method.
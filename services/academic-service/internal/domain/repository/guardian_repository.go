@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
+)
+
+type GuardianRepository interface {
+	Create(ctx context.Context, guardian *entity.Guardian) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Guardian, error)
+	GetByUserID(ctx context.Context, tenantID string, userID uuid.UUID) (*entity.Guardian, error)
+	List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Guardian, int, error)
+	Update(ctx context.Context, guardian *entity.Guardian) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	LinkStudent(ctx context.Context, link *entity.StudentGuardian) error
+	UnlinkStudent(ctx context.Context, guardianID, studentID uuid.UUID) error
+	ListStudents(ctx context.Context, guardianID uuid.UUID) ([]entity.GuardianStudent, error)
+	ListByStudent(ctx context.Context, studentID uuid.UUID) ([]entity.Guardian, error)
+	IsGuardianOf(ctx context.Context, tenantID string, userID, studentID uuid.UUID) (bool, error)
+}
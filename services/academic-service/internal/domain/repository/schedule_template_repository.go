@@ -16,6 +16,7 @@ type ScheduleTemplateRepository interface {
 	
 	// Item management
 	AddItem(ctx context.Context, item *entity.ScheduleTemplateItem) error
+	GetItem(ctx context.Context, id uuid.UUID) (*entity.ScheduleTemplateItem, error)
 	RemoveItem(ctx context.Context, id uuid.UUID) error
 	ListItems(ctx context.Context, templateID uuid.UUID) ([]entity.ScheduleTemplateItem, error)
 }
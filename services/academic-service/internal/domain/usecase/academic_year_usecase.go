@@ -9,8 +9,8 @@ import (
 
 type AcademicYearUseCase interface {
 	Create(ctx context.Context, academicYear *entity.AcademicYear) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.AcademicYear, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.AcademicYear, error)
 	List(ctx context.Context, tenantID string) ([]entity.AcademicYear, error)
 	Update(ctx context.Context, academicYear *entity.AcademicYear) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
 }
@@ -9,10 +9,10 @@ import (
 
 type ClassSubjectUseCase interface {
 	Create(ctx context.Context, classSubject *entity.ClassSubject) error
-	AssignTeacher(ctx context.Context, id uuid.UUID, teacherID uuid.UUID) error
-	GetByClassAndSubject(ctx context.Context, classID, subjectID uuid.UUID) (*entity.ClassSubject, error)
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.ClassSubject, error)
-	ListByClass(ctx context.Context, classID uuid.UUID) ([]entity.ClassSubject, error)
+	AssignTeacher(ctx context.Context, tenantID string, id uuid.UUID, teacherID uuid.UUID) error
+	GetByClassAndSubject(ctx context.Context, tenantID string, classID, subjectID uuid.UUID) (*entity.ClassSubject, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.ClassSubject, error)
+	ListByClass(ctx context.Context, tenantID string, classID uuid.UUID) ([]entity.ClassSubject, error)
 	ListByTeacher(ctx context.Context, teacherID uuid.UUID) ([]entity.ClassSubject, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
 }
@@ -9,8 +9,8 @@ import (
 
 type ClassUseCase interface {
 	Create(ctx context.Context, class *entity.Class) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.Class, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Class, error)
 	List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Class, int, error)
 	Update(ctx context.Context, class *entity.Class) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
 }
@@ -9,15 +9,15 @@ import (
 
 type CurriculumUseCase interface {
 	Create(ctx context.Context, curriculum *entity.Curriculum) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.Curriculum, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Curriculum, error)
 	List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Curriculum, int, error)
 	Update(ctx context.Context, curriculum *entity.Curriculum) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
 
 	AddSubject(ctx context.Context, cs *entity.CurriculumSubject) error
-	RemoveSubject(ctx context.Context, id uuid.UUID) error
-	ListSubjects(ctx context.Context, curriculumID uuid.UUID) ([]entity.CurriculumSubject, error)
+	RemoveSubject(ctx context.Context, tenantID string, curriculumID, id uuid.UUID) error
+	ListSubjects(ctx context.Context, tenantID string, curriculumID uuid.UUID) ([]entity.CurriculumSubject, error)
 
 	AddGradingRule(ctx context.Context, rule *entity.GradingRule) error
-	GetGradingRules(ctx context.Context, curriculumID uuid.UUID) ([]entity.GradingRule, error)
+	GetGradingRules(ctx context.Context, tenantID string, curriculumID uuid.UUID) ([]entity.GradingRule, error)
 }
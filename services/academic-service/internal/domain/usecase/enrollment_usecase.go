@@ -9,10 +9,10 @@ import (
 
 type EnrollmentUseCase interface {
 	Enroll(ctx context.Context, enrollment *entity.Enrollment) error
-	Unenroll(ctx context.Context, id uuid.UUID) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.Enrollment, error)
-	ListByClass(ctx context.Context, classID uuid.UUID) ([]entity.Enrollment, error)
-	ListByStudent(ctx context.Context, studentID uuid.UUID) ([]entity.Enrollment, error)
-	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
-	BulkEnroll(ctx context.Context, classID uuid.UUID, studentIDs []uuid.UUID) error
+	Unenroll(ctx context.Context, tenantID string, id uuid.UUID) error
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Enrollment, error)
+	ListByClass(ctx context.Context, tenantID string, classID uuid.UUID) ([]entity.Enrollment, error)
+	ListByStudent(ctx context.Context, tenantID string, studentID uuid.UUID) ([]entity.Enrollment, error)
+	UpdateStatus(ctx context.Context, tenantID string, id uuid.UUID, status string) error
+	BulkEnroll(ctx context.Context, tenantID string, classID uuid.UUID, studentIDs []uuid.UUID) error
 }
@@ -0,0 +1,10 @@
+package usecase
+
+import "errors"
+
+var (
+	// ErrNotFound is returned for a record that does not exist or belongs to
+	// another tenant; handlers answer both with 404.
+	ErrNotFound      = errors.New("record not found")
+	ErrClassNotFound = errors.New("class not found")
+)
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
+)
+
+var (
+	ErrGuardianNotFound = errors.New("guardian not found")
+	ErrStudentNotFound  = errors.New("student not found")
+)
+
+type GuardianUseCase interface {
+	Create(ctx context.Context, guardian *entity.Guardian) error
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Guardian, error)
+	List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Guardian, int, error)
+	Update(ctx context.Context, guardian *entity.Guardian) error
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
+	LinkUser(ctx context.Context, tenantID string, guardianID, userID uuid.UUID) error
+
+	LinkStudent(ctx context.Context, tenantID string, link *entity.StudentGuardian) error
+	UnlinkStudent(ctx context.Context, tenantID string, guardianID, studentID uuid.UUID) error
+	ListStudents(ctx context.Context, tenantID string, guardianID uuid.UUID) ([]entity.GuardianStudent, error)
+	ListByStudent(ctx context.Context, tenantID string, studentID uuid.UUID) ([]entity.Guardian, error)
+
+	// Guardian portal
+	MyStudents(ctx context.Context, tenantID string, userID uuid.UUID) ([]entity.GuardianStudent, error)
+	IsGuardianOf(ctx context.Context, tenantID string, userID, studentID uuid.UUID) (bool, error)
+	StudentSchedules(ctx context.Context, studentID uuid.UUID) ([]entity.Schedule, error)
+}
@@ -9,8 +9,8 @@ import (
 
 type SchoolUseCase interface {
 	Create(ctx context.Context, school *entity.School) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.School, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.School, error)
 	GetByTenantID(ctx context.Context, tenantID string) (*entity.School, error)
 	Update(ctx context.Context, school *entity.School) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
 }
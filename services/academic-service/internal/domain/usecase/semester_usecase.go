@@ -9,10 +9,10 @@ import (
 
 type SemesterUseCase interface {
 	Create(ctx context.Context, semester *entity.Semester) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.Semester, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Semester, error)
 	List(ctx context.Context, tenantID string) ([]entity.Semester, error)
 	ListByAcademicYear(ctx context.Context, academicYearID uuid.UUID) ([]entity.Semester, error)
 	Update(ctx context.Context, semester *entity.Semester) error
-	Delete(ctx context.Context, id uuid.UUID) error
-	SetActive(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
+	SetActive(ctx context.Context, tenantID string, id uuid.UUID) error
 }
@@ -9,8 +9,8 @@ import (
 
 type SubjectUseCase interface {
 	Create(ctx context.Context, subject *entity.Subject) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.Subject, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Subject, error)
 	List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Subject, int, error)
 	Update(ctx context.Context, subject *entity.Subject) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
 }
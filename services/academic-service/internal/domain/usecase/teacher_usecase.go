@@ -9,8 +9,8 @@ import (
 
 type TeacherUseCase interface {
 	Create(ctx context.Context, teacher *entity.Teacher) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.Teacher, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Teacher, error)
 	List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Teacher, int, error)
 	Update(ctx context.Context, teacher *entity.Teacher) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
 }
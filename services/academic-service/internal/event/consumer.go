@@ -15,31 +15,34 @@ import (
 type Consumer struct {
 	rabbitClient *rabbit.Client
 	studentUC    usecase.StudentUseCase
+	guardianUC   usecase.GuardianUseCase
 }
 
-func NewConsumer(rabbitClient *rabbit.Client, studentUC usecase.StudentUseCase) *Consumer {
+func NewConsumer(rabbitClient *rabbit.Client, studentUC usecase.StudentUseCase, guardianUC usecase.GuardianUseCase) *Consumer {
 	return &Consumer{
 		rabbitClient: rabbitClient,
 		studentUC:    studentUC,
+		guardianUC:   guardianUC,
 	}
 }
 
-func (c *Consumer) Start() {
-	// Ensure the queue exists and bind it
-	// We use "academic-service-student-registration" queue
-	msgs, err := c.rabbitClient.Consume("sisfo.events", "academic-service-student-registration", []string{"admission.student.registered"})
-	if err != nil {
-		log.Printf("Failed to consume student registration: %v", err)
-		return
-	}
+// Start subscribes to the events academic-service reacts to. Each
+// subscription runs until ctx is done, resubscribing whenever the broker
+// connection drops.
+func (c *Consumer) Start(ctx context.Context) {
+	// Admitted students get a student record
+	go c.rabbitClient.Subscribe(ctx, rabbit.Subscription{
+		Exchange: "sisfo.events",
+		Queue:    "academic-service-student-registration",
+		Bindings: []string{"admission.student.registered"},
+	}, c.handleStudentRegistration)
 
-	go func() {
-		for d := range msgs {
-			if err := c.handleStudentRegistration(d.Body); err != nil {
-				log.Printf("Error handling message: %v", err)
-			}
-		}
-	}()
+	// auth-service reports the account it provisioned for a new guardian
+	go c.rabbitClient.Subscribe(ctx, rabbit.Subscription{
+		Exchange: "sisfo.events",
+		Queue:    "academic-service-guardian-user",
+		Bindings: []string{"auth.guardian.user_linked"},
+	}, c.handleGuardianUserLinked)
 }
 
 type StudentRegisteredEvent struct {
@@ -53,15 +56,12 @@ type StudentRegisteredEvent struct {
 	Timestamp          time.Time `json:"timestamp"`
 }
 
-func (c *Consumer) handleStudentRegistration(body []byte) error {
+func (c *Consumer) handleStudentRegistration(ctx context.Context, d rabbit.Delivery) error {
 	var event StudentRegisteredEvent
-	if err := json.Unmarshal(body, &event); err != nil {
-		log.Printf("Failed to unmarshal student registration event: %v", err)
-		return nil // Don't retry malformed messages
+	if err := json.Unmarshal(d.Body, &event); err != nil {
+		return rabbit.Permanent(err) // Don't retry malformed messages
 	}
 
-	ctx := context.Background()
-
 	// Map event to student entity
 	now := time.Now()
 	name := event.FirstName + " " + event.LastName
@@ -88,3 +88,25 @@ func (c *Consumer) handleStudentRegistration(body []byte) error {
 	log.Printf("Successfully created student record for %s", event.Email)
 	return nil
 }
+
+type GuardianUserLinkedEvent struct {
+	TenantID   string    `json:"tenant_id"`
+	GuardianID uuid.UUID `json:"guardian_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func (c *Consumer) handleGuardianUserLinked(ctx context.Context, d rabbit.Delivery) error {
+	var event GuardianUserLinkedEvent
+	if err := json.Unmarshal(d.Body, &event); err != nil {
+		return rabbit.Permanent(err) // Don't retry malformed messages
+	}
+
+	if err := c.guardianUC.LinkUser(ctx, event.TenantID, event.GuardianID, event.UserID); err != nil {
+		log.Printf("Failed to link user %s to guardian %s: %v", event.UserID, event.GuardianID, err)
+		return nil
+	}
+
+	log.Printf("Linked user %s to guardian %s", event.UserID, event.GuardianID)
+	return nil
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -20,8 +21,11 @@ func NewAcademicYearHandler(useCase usecase.AcademicYearUseCase) *AcademicYearHa
 }
 
 func (h *AcademicYearHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID  string    `json:"tenant_id" binding:"required"`
 		Name      string    `json:"name" binding:"required"`
 		StartDate time.Time `json:"start_date" binding:"required"`
 		EndDate   time.Time `json:"end_date" binding:"required"`
@@ -34,7 +38,7 @@ func (h *AcademicYearHandler) Create(c *gin.Context) {
 	}
 
 	academicYear := &entity.AcademicYear{
-		TenantID:  req.TenantID,
+		TenantID:  tenantID,
 		Name:      req.Name,
 		StartDate: req.StartDate,
 		EndDate:   req.EndDate,
@@ -50,6 +54,10 @@ func (h *AcademicYearHandler) Create(c *gin.Context) {
 }
 
 func (h *AcademicYearHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -57,7 +65,7 @@ func (h *AcademicYearHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	academicYear, err := h.useCase.GetByID(c.Request.Context(), id)
+	academicYear, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -71,9 +79,12 @@ func (h *AcademicYearHandler) GetByID(c *gin.Context) {
 }
 
 func (h *AcademicYearHandler) List(c *gin.Context) {
-	tenantID := c.Param("tenant_id")
-	if tenantID == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Tenant ID is required")
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	if c.Param("tenant_id") != tenantID {
+		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "Tenant does not match the caller")
 		return
 	}
 
@@ -87,6 +98,10 @@ func (h *AcademicYearHandler) List(c *gin.Context) {
 }
 
 func (h *AcademicYearHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -106,7 +121,7 @@ func (h *AcademicYearHandler) Update(c *gin.Context) {
 		return
 	}
 
-	existing, err := h.useCase.GetByID(c.Request.Context(), id)
+	existing, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -130,6 +145,10 @@ func (h *AcademicYearHandler) Update(c *gin.Context) {
 }
 
 func (h *AcademicYearHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -137,7 +156,11 @@ func (h *AcademicYearHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	if err := h.useCase.Delete(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Academic Year not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
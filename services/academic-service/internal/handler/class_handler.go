@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -20,8 +21,11 @@ func NewClassHandler(useCase usecase.ClassUseCase) *ClassHandler {
 }
 
 func (h *ClassHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID          string     `json:"tenant_id" binding:"required"`
 		SchoolID          *uuid.UUID `json:"school_id"`
 		AcademicYearID    *uuid.UUID `json:"academic_year_id"`
 		Name              string     `json:"name" binding:"required"`
@@ -37,7 +41,7 @@ func (h *ClassHandler) Create(c *gin.Context) {
 	}
 
 	class := &entity.Class{
-		TenantID:          req.TenantID,
+		TenantID:          tenantID,
 		SchoolID:          req.SchoolID,
 		AcademicYearID:    req.AcademicYearID,
 		Name:              req.Name,
@@ -56,6 +60,10 @@ func (h *ClassHandler) Create(c *gin.Context) {
 }
 
 func (h *ClassHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -63,7 +71,7 @@ func (h *ClassHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	class, err := h.useCase.GetByID(c.Request.Context(), id)
+	class, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -77,9 +85,8 @@ func (h *ClassHandler) GetByID(c *gin.Context) {
 }
 
 func (h *ClassHandler) List(c *gin.Context) {
-	tenantID := c.Query("tenant_id")
-	if tenantID == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Tenant ID is required")
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -103,6 +110,10 @@ func (h *ClassHandler) List(c *gin.Context) {
 }
 
 func (h *ClassHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -125,7 +136,7 @@ func (h *ClassHandler) Update(c *gin.Context) {
 		return
 	}
 
-	existing, err := h.useCase.GetByID(c.Request.Context(), id)
+	existing, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -152,6 +163,10 @@ func (h *ClassHandler) Update(c *gin.Context) {
 }
 
 func (h *ClassHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -159,7 +174,11 @@ func (h *ClassHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	if err := h.useCase.Delete(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Class not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
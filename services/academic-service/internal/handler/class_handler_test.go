@@ -26,10 +26,9 @@ func TestClassHandler_Create(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-1",
-			"name":      "Class 10A",
-			"level":     10,
-			"major":     "Science",
+			"name":  "Class 10A",
+			"level": 10,
+			"major": "Science",
 		}
 		body, _ := json.Marshal(reqBody)
 
@@ -37,6 +36,7 @@ func TestClassHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/classes", bytes.NewBuffer(body))
 
 		h.Create(c)
@@ -46,13 +46,13 @@ func TestClassHandler_Create(t *testing.T) {
 
 	t.Run("Invalid Input", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-1",
 			// Missing required name
 		}
 		body, _ := json.Marshal(reqBody)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/classes", bytes.NewBuffer(body))
 
 		h.Create(c)
@@ -73,10 +73,11 @@ func TestClassHandler_GetByID(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		class := &entity.Class{ID: id}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(class, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(class, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/classes/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -99,7 +100,8 @@ func TestClassHandler_List(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/classes?tenant_id=tenant-1", nil)
+		withTenant(c, "tenant-1")
+		c.Request, _ = http.NewRequest(http.MethodGet, "/classes", nil)
 
 		h.List(c)
 
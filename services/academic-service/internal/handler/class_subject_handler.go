@@ -19,6 +19,10 @@ func NewClassSubjectHandler(useCase usecase.ClassSubjectUseCase) *ClassSubjectHa
 }
 
 func (h *ClassSubjectHandler) AddSubjectToClass(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	classIDStr := c.Param("id")
 	classID, err := uuid.Parse(classIDStr)
 	if err != nil {
@@ -27,7 +31,6 @@ func (h *ClassSubjectHandler) AddSubjectToClass(c *gin.Context) {
 	}
 
 	var req struct {
-		TenantID  string  `json:"tenant_id" binding:"required"`
 		SubjectID string  `json:"subject_id" binding:"required"`
 		TeacherID *string `json:"teacher_id"`
 	}
@@ -54,7 +57,7 @@ func (h *ClassSubjectHandler) AddSubjectToClass(c *gin.Context) {
 	}
 
 	classSubject := &entity.ClassSubject{
-		TenantID:  req.TenantID,
+		TenantID:  tenantID,
 		ClassID:   classID,
 		SubjectID: subjectID,
 		TeacherID: teacherID,
@@ -69,6 +72,10 @@ func (h *ClassSubjectHandler) AddSubjectToClass(c *gin.Context) {
 }
 
 func (h *ClassSubjectHandler) AssignTeacher(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	classIDStr := c.Param("id")
 	classID, err := uuid.Parse(classIDStr)
 	if err != nil {
@@ -99,7 +106,7 @@ func (h *ClassSubjectHandler) AssignTeacher(c *gin.Context) {
 	}
 
 	// First find the ClassSubject record
-	cs, err := h.useCase.GetByClassAndSubject(c.Request.Context(), classID, subjectID)
+	cs, err := h.useCase.GetByClassAndSubject(c.Request.Context(), tenantID, classID, subjectID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -109,13 +116,13 @@ func (h *ClassSubjectHandler) AssignTeacher(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.AssignTeacher(c.Request.Context(), cs.ID, teacherID); err != nil {
+	if err := h.useCase.AssignTeacher(c.Request.Context(), tenantID, cs.ID, teacherID); err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
 
 	// Re-fetch to return updated data
-	updated, err := h.useCase.GetByID(c.Request.Context(), cs.ID)
+	updated, err := h.useCase.GetByID(c.Request.Context(), tenantID, cs.ID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -125,6 +132,10 @@ func (h *ClassSubjectHandler) AssignTeacher(c *gin.Context) {
 }
 
 func (h *ClassSubjectHandler) ListByClass(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	classIDStr := c.Param("id")
 	classID, err := uuid.Parse(classIDStr)
 	if err != nil {
@@ -132,7 +143,7 @@ func (h *ClassSubjectHandler) ListByClass(c *gin.Context) {
 		return
 	}
 
-	subjects, err := h.useCase.ListByClass(c.Request.Context(), classID)
+	subjects, err := h.useCase.ListByClass(c.Request.Context(), tenantID, classID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -142,6 +153,10 @@ func (h *ClassSubjectHandler) ListByClass(c *gin.Context) {
 }
 
 func (h *ClassSubjectHandler) RemoveSubject(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	classIDStr := c.Param("id")
 	classID, err := uuid.Parse(classIDStr)
 	if err != nil {
@@ -157,7 +172,7 @@ func (h *ClassSubjectHandler) RemoveSubject(c *gin.Context) {
 	}
 
 	// Find record
-	cs, err := h.useCase.GetByClassAndSubject(c.Request.Context(), classID, subjectID)
+	cs, err := h.useCase.GetByClassAndSubject(c.Request.Context(), tenantID, classID, subjectID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -167,7 +182,7 @@ func (h *ClassSubjectHandler) RemoveSubject(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), cs.ID); err != nil {
+	if err := h.useCase.Delete(c.Request.Context(), tenantID, cs.ID); err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -20,8 +21,11 @@ func NewCurriculumHandler(useCase usecase.CurriculumUseCase) *CurriculumHandler
 }
 
 func (h *CurriculumHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID    string `json:"tenant_id" binding:"required"`
 		Name        string `json:"name" binding:"required"`
 		Description string `json:"description"`
 		Year        int    `json:"year" binding:"required"`
@@ -34,7 +38,7 @@ func (h *CurriculumHandler) Create(c *gin.Context) {
 	}
 
 	curriculum := &entity.Curriculum{
-		TenantID:    req.TenantID,
+		TenantID:    tenantID,
 		Name:        req.Name,
 		Description: req.Description,
 		Year:        req.Year,
@@ -50,6 +54,10 @@ func (h *CurriculumHandler) Create(c *gin.Context) {
 }
 
 func (h *CurriculumHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -57,7 +65,7 @@ func (h *CurriculumHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	curriculum, err := h.useCase.GetByID(c.Request.Context(), id)
+	curriculum, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -71,9 +79,8 @@ func (h *CurriculumHandler) GetByID(c *gin.Context) {
 }
 
 func (h *CurriculumHandler) List(c *gin.Context) {
-	tenantID := c.Query("tenant_id")
-	if tenantID == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Tenant ID is required")
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -97,6 +104,10 @@ func (h *CurriculumHandler) List(c *gin.Context) {
 }
 
 func (h *CurriculumHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -116,7 +127,7 @@ func (h *CurriculumHandler) Update(c *gin.Context) {
 		return
 	}
 
-	existing, err := h.useCase.GetByID(c.Request.Context(), id)
+	existing, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -140,6 +151,10 @@ func (h *CurriculumHandler) Update(c *gin.Context) {
 }
 
 func (h *CurriculumHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -147,7 +162,11 @@ func (h *CurriculumHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	if err := h.useCase.Delete(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Curriculum not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -158,6 +177,10 @@ func (h *CurriculumHandler) Delete(c *gin.Context) {
 // Curriculum Subjects Handlers
 
 func (h *CurriculumHandler) AddSubject(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	curriculumID, err := uuid.Parse(idStr)
 	if err != nil {
@@ -166,7 +189,6 @@ func (h *CurriculumHandler) AddSubject(c *gin.Context) {
 	}
 
 	var req struct {
-		TenantID   string    `json:"tenant_id" binding:"required"`
 		SubjectID  uuid.UUID `json:"subject_id" binding:"required"`
 		GradeLevel int       `json:"grade_level" binding:"required"`
 		Semester   int       `json:"semester" binding:"required"`
@@ -178,7 +200,7 @@ func (h *CurriculumHandler) AddSubject(c *gin.Context) {
 	}
 
 	cs := &entity.CurriculumSubject{
-		TenantID:     req.TenantID,
+		TenantID:     tenantID,
 		CurriculumID: curriculumID,
 		SubjectID:    req.SubjectID,
 		GradeLevel:   req.GradeLevel,
@@ -186,6 +208,10 @@ func (h *CurriculumHandler) AddSubject(c *gin.Context) {
 	}
 
 	if err := h.useCase.AddSubject(c.Request.Context(), cs); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Curriculum not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -194,6 +220,10 @@ func (h *CurriculumHandler) AddSubject(c *gin.Context) {
 }
 
 func (h *CurriculumHandler) ListSubjects(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	curriculumID, err := uuid.Parse(idStr)
 	if err != nil {
@@ -201,8 +231,12 @@ func (h *CurriculumHandler) ListSubjects(c *gin.Context) {
 		return
 	}
 
-	subjects, err := h.useCase.ListSubjects(c.Request.Context(), curriculumID)
+	subjects, err := h.useCase.ListSubjects(c.Request.Context(), tenantID, curriculumID)
 	if err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Curriculum not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -213,6 +247,10 @@ func (h *CurriculumHandler) ListSubjects(c *gin.Context) {
 // Grading Rules Handlers
 
 func (h *CurriculumHandler) AddGradingRule(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	curriculumID, err := uuid.Parse(idStr)
 	if err != nil {
@@ -221,7 +259,6 @@ func (h *CurriculumHandler) AddGradingRule(c *gin.Context) {
 	}
 
 	var req struct {
-		TenantID    string  `json:"tenant_id" binding:"required"`
 		Grade       string  `json:"grade" binding:"required"`
 		MinScore    float64 `json:"min_score" binding:"required"`
 		MaxScore    float64 `json:"max_score" binding:"required"`
@@ -235,7 +272,7 @@ func (h *CurriculumHandler) AddGradingRule(c *gin.Context) {
 	}
 
 	rule := &entity.GradingRule{
-		TenantID:     req.TenantID,
+		TenantID:     tenantID,
 		CurriculumID: curriculumID,
 		Grade:        req.Grade,
 		MinScore:     req.MinScore,
@@ -245,6 +282,10 @@ func (h *CurriculumHandler) AddGradingRule(c *gin.Context) {
 	}
 
 	if err := h.useCase.AddGradingRule(c.Request.Context(), rule); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Curriculum not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -253,6 +294,10 @@ func (h *CurriculumHandler) AddGradingRule(c *gin.Context) {
 }
 
 func (h *CurriculumHandler) ListGradingRules(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	curriculumID, err := uuid.Parse(idStr)
 	if err != nil {
@@ -260,8 +305,12 @@ func (h *CurriculumHandler) ListGradingRules(c *gin.Context) {
 		return
 	}
 
-	rules, err := h.useCase.GetGradingRules(c.Request.Context(), curriculumID)
+	rules, err := h.useCase.GetGradingRules(c.Request.Context(), tenantID, curriculumID)
 	if err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Curriculum not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -270,6 +319,15 @@ func (h *CurriculumHandler) ListGradingRules(c *gin.Context) {
 }
 
 func (h *CurriculumHandler) RemoveSubject(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	curriculumID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID", "ID must be a valid UUID")
+		return
+	}
 	idStr := c.Param("subject_id") // curriculum_subject_id
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -277,7 +335,11 @@ func (h *CurriculumHandler) RemoveSubject(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.RemoveSubject(c.Request.Context(), id); err != nil {
+	if err := h.useCase.RemoveSubject(c.Request.Context(), tenantID, curriculumID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Curriculum subject not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
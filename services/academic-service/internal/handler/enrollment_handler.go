@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/csv"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -20,8 +21,11 @@ func NewEnrollmentHandler(useCase usecase.EnrollmentUseCase) *EnrollmentHandler
 }
 
 func (h *EnrollmentHandler) Enroll(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID  string    `json:"tenant_id" binding:"required"`
 		ClassID   uuid.UUID `json:"class_id" binding:"required"`
 		StudentID uuid.UUID `json:"student_id" binding:"required"`
 		Status    string    `json:"status"`
@@ -33,13 +37,17 @@ func (h *EnrollmentHandler) Enroll(c *gin.Context) {
 	}
 
 	enrollment := &entity.Enrollment{
-		TenantID:  req.TenantID,
+		TenantID:  tenantID,
 		ClassID:   req.ClassID,
 		StudentID: req.StudentID,
 		Status:    req.Status,
 	}
 
 	if err := h.useCase.Enroll(c.Request.Context(), enrollment); err != nil {
+		if errors.Is(err, usecase.ErrClassNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Class not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -48,6 +56,10 @@ func (h *EnrollmentHandler) Enroll(c *gin.Context) {
 }
 
 func (h *EnrollmentHandler) Unenroll(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -55,7 +67,11 @@ func (h *EnrollmentHandler) Unenroll(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Unenroll(c.Request.Context(), id); err != nil {
+	if err := h.useCase.Unenroll(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Enrollment not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -64,6 +80,10 @@ func (h *EnrollmentHandler) Unenroll(c *gin.Context) {
 }
 
 func (h *EnrollmentHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -71,7 +91,7 @@ func (h *EnrollmentHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	enrollment, err := h.useCase.GetByID(c.Request.Context(), id)
+	enrollment, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -85,6 +105,10 @@ func (h *EnrollmentHandler) GetByID(c *gin.Context) {
 }
 
 func (h *EnrollmentHandler) ListByClass(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	classIDStr := c.Param("id")
 	classID, err := uuid.Parse(classIDStr)
 	if err != nil {
@@ -92,7 +116,7 @@ func (h *EnrollmentHandler) ListByClass(c *gin.Context) {
 		return
 	}
 
-	enrollments, err := h.useCase.ListByClass(c.Request.Context(), classID)
+	enrollments, err := h.useCase.ListByClass(c.Request.Context(), tenantID, classID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -102,6 +126,10 @@ func (h *EnrollmentHandler) ListByClass(c *gin.Context) {
 }
 
 func (h *EnrollmentHandler) ListByStudent(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	studentIDStr := c.Param("id")
 	studentID, err := uuid.Parse(studentIDStr)
 	if err != nil {
@@ -109,7 +137,7 @@ func (h *EnrollmentHandler) ListByStudent(c *gin.Context) {
 		return
 	}
 
-	enrollments, err := h.useCase.ListByStudent(c.Request.Context(), studentID)
+	enrollments, err := h.useCase.ListByStudent(c.Request.Context(), tenantID, studentID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -119,6 +147,10 @@ func (h *EnrollmentHandler) ListByStudent(c *gin.Context) {
 }
 
 func (h *EnrollmentHandler) UpdateStatus(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -135,7 +167,11 @@ func (h *EnrollmentHandler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.UpdateStatus(c.Request.Context(), id, req.Status); err != nil {
+	if err := h.useCase.UpdateStatus(c.Request.Context(), tenantID, id, req.Status); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Enrollment not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -144,6 +180,10 @@ func (h *EnrollmentHandler) UpdateStatus(c *gin.Context) {
 }
 
 func (h *EnrollmentHandler) BulkEnroll(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	classIDStr := c.Param("id")
 	classID, err := uuid.Parse(classIDStr)
 	if err != nil {
@@ -199,7 +239,11 @@ func (h *EnrollmentHandler) BulkEnroll(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.BulkEnroll(c.Request.Context(), classID, studentIDs); err != nil {
+	if err := h.useCase.BulkEnroll(c.Request.Context(), tenantID, classID, studentIDs); err != nil {
+		if errors.Is(err, usecase.ErrClassNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Class not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
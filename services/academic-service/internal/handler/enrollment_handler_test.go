@@ -27,7 +27,6 @@ func TestEnrollmentHandler_Enroll(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":  "tenant-1",
 			"class_id":   uuid.New().String(),
 			"student_id": uuid.New().String(),
 			"status":     "active",
@@ -38,6 +37,7 @@ func TestEnrollmentHandler_Enroll(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/enrollments", bytes.NewBuffer(body))
 
 		h.Enroll(c)
@@ -47,13 +47,13 @@ func TestEnrollmentHandler_Enroll(t *testing.T) {
 
 	t.Run("Invalid Input", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-1",
 			// Missing required fields
 		}
 		body, _ := json.Marshal(reqBody)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/enrollments", bytes.NewBuffer(body))
 
 		h.Enroll(c)
@@ -74,10 +74,11 @@ func TestEnrollmentHandler_GetByID(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		enrollment := &entity.Enrollment{ID: id}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(enrollment, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(enrollment, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/enrollments/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -103,10 +104,11 @@ func TestEnrollmentHandler_UpdateStatus(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockUseCase.EXPECT().UpdateStatus(gomock.Any(), id, "completed").Return(nil)
+		mockUseCase.EXPECT().UpdateStatus(gomock.Any(), "tenant-1", id, "completed").Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPatch, "/enrollments/"+id.String()+"/status", bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -121,10 +123,11 @@ func TestEnrollmentHandler_UpdateStatus(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockUseCase.EXPECT().UpdateStatus(gomock.Any(), id, "completed").Return(errors.New("usecase error"))
+		mockUseCase.EXPECT().UpdateStatus(gomock.Any(), "tenant-1", id, "completed").Return(errors.New("usecase error"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPatch, "/enrollments/"+id.String()+"/status", bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -0,0 +1,403 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/guardian"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+type GuardianHandler struct {
+	useCase usecase.GuardianUseCase
+}
+
+func NewGuardianHandler(useCase usecase.GuardianUseCase) *GuardianHandler {
+	return &GuardianHandler{useCase: useCase}
+}
+
+// RegisterInternal exposes the guardian check used by the other services'
+// GuardianScope middleware. The route is not exposed through the API gateway.
+func (h *GuardianHandler) RegisterInternal(r *gin.Engine) {
+	r.POST(guardian.CheckPath, h.Check)
+}
+
+func (h *GuardianHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	var req struct {
+		UserID     *uuid.UUID `json:"user_id"`
+		Name       string     `json:"name" binding:"required"`
+		Phone      string     `json:"phone"`
+		Email      string     `json:"email"`
+		Occupation string     `json:"occupation"`
+		Address    string     `json:"address"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+		return
+	}
+
+	g := &entity.Guardian{
+		TenantID:   tenantID,
+		UserID:     req.UserID,
+		Name:       req.Name,
+		Phone:      req.Phone,
+		Email:      req.Email,
+		Occupation: req.Occupation,
+		Address:    req.Address,
+	}
+
+	if err := h.useCase.Create(c.Request.Context(), g); err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
+		return
+	}
+
+	httputil.Success(c.Writer, g)
+}
+
+func (h *GuardianHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID", "ID must be a valid UUID")
+		return
+	}
+
+	g, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
+		return
+	}
+	if g == nil {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Guardian not found")
+		return
+	}
+
+	httputil.Success(c.Writer, g)
+}
+
+func (h *GuardianHandler) List(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	guardians, total, err := h.useCase.List(c.Request.Context(), tenantID, limit, offset)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
+		return
+	}
+
+	httputil.Success(c.Writer, map[string]interface{}{
+		"guardians": guardians,
+		"total":     total,
+		"limit":     limit,
+		"offset":    offset,
+	})
+}
+
+func (h *GuardianHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req struct {
+		Name       string `json:"name" binding:"required"`
+		Phone      string `json:"phone"`
+		Email      string `json:"email"`
+		Occupation string `json:"occupation"`
+		Address    string `json:"address"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+		return
+	}
+
+	existing, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
+		return
+	}
+	if existing == nil {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Guardian not found")
+		return
+	}
+
+	existing.Name = req.Name
+	existing.Phone = req.Phone
+	existing.Email = req.Email
+	existing.Occupation = req.Occupation
+	existing.Address = req.Address
+
+	if err := h.useCase.Update(c.Request.Context(), existing); err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
+		return
+	}
+
+	httputil.Success(c.Writer, existing)
+}
+
+func (h *GuardianHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID", "ID must be a valid UUID")
+		return
+	}
+
+	if err := h.useCase.Delete(c.Request.Context(), tenantID, id); err != nil {
+		h.linkError(c, err)
+		return
+	}
+
+	httputil.Success(c.Writer, map[string]string{"message": "Guardian deleted successfully"})
+}
+
+// LinkUser attaches an existing auth-service account to the guardian.
+func (h *GuardianHandler) LinkUser(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req struct {
+		UserID uuid.UUID `json:"user_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+		return
+	}
+
+	if err := h.useCase.LinkUser(c.Request.Context(), tenantID, id, req.UserID); err != nil {
+		h.linkError(c, err)
+		return
+	}
+
+	httputil.Success(c.Writer, map[string]string{"message": "User linked successfully"})
+}
+
+func (h *GuardianHandler) LinkStudent(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID", "ID must be a valid UUID")
+		return
+	}
+
+	var req struct {
+		StudentID    uuid.UUID `json:"student_id" binding:"required"`
+		Relationship string    `json:"relationship" binding:"required"`
+		IsPrimary    bool      `json:"is_primary"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+		return
+	}
+
+	link := &entity.StudentGuardian{
+		StudentID:    req.StudentID,
+		GuardianID:   id,
+		Relationship: strings.ToLower(req.Relationship),
+		IsPrimary:    req.IsPrimary,
+	}
+	if errMap := link.Validate(); len(errMap) > 0 {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", errMap)
+		return
+	}
+
+	if err := h.useCase.LinkStudent(c.Request.Context(), tenantID, link); err != nil {
+		h.linkError(c, err)
+		return
+	}
+
+	httputil.Success(c.Writer, link)
+}
+
+func (h *GuardianHandler) UnlinkStudent(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID", "ID must be a valid UUID")
+		return
+	}
+	studentID, err := uuid.Parse(c.Param("student_id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Student ID", "Student ID must be a valid UUID")
+		return
+	}
+
+	if err := h.useCase.UnlinkStudent(c.Request.Context(), tenantID, id, studentID); err != nil {
+		h.linkError(c, err)
+		return
+	}
+
+	httputil.Success(c.Writer, map[string]string{"message": "Student unlinked successfully"})
+}
+
+func (h *GuardianHandler) ListStudents(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID", "ID must be a valid UUID")
+		return
+	}
+
+	students, err := h.useCase.ListStudents(c.Request.Context(), tenantID, id)
+	if err != nil {
+		h.linkError(c, err)
+		return
+	}
+
+	httputil.Success(c.Writer, students)
+}
+
+func (h *GuardianHandler) ListByStudent(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID", "ID must be a valid UUID")
+		return
+	}
+
+	guardians, err := h.useCase.ListByStudent(c.Request.Context(), tenantID, studentID)
+	if err != nil {
+		h.linkError(c, err)
+		return
+	}
+
+	httputil.Success(c.Writer, guardians)
+}
+
+// ListMyStudents lists the children of the authenticated guardian.
+func (h *GuardianHandler) ListMyStudents(c *gin.Context) {
+	claims, ok := requestClaims(c)
+	if !ok {
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", nil)
+		return
+	}
+
+	students, err := h.useCase.MyStudents(c.Request.Context(), claims.TenantID, claims.UserID)
+	if errors.Is(err, usecase.ErrGuardianNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "No guardian profile is linked to this account")
+		return
+	}
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
+		return
+	}
+
+	httputil.Success(c.Writer, students)
+}
+
+// StudentSchedules returns a child's class schedules. The route must be
+// guarded by GuardianScope on student_id.
+func (h *GuardianHandler) StudentSchedules(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("student_id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Student ID", "Student ID must be a valid UUID")
+		return
+	}
+
+	schedules, err := h.useCase.StudentSchedules(c.Request.Context(), studentID)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
+		return
+	}
+
+	httputil.Success(c.Writer, schedules)
+}
+
+func (h *GuardianHandler) Check(c *gin.Context) {
+	var in guardian.CheckRequest
+	if err := c.ShouldBindJSON(&in); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid json")
+		return
+	}
+	if in.UserID == uuid.Nil || in.StudentID == uuid.Nil || strings.TrimSpace(in.TenantID) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "user_id, tenant_id and student_id required")
+		return
+	}
+
+	ok, err := h.useCase.IsGuardianOf(c.Request.Context(), strings.TrimSpace(in.TenantID), in.UserID, in.StudentID)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
+		return
+	}
+
+	httputil.Success(c.Writer, guardian.CheckResponse{Allowed: ok})
+}
+
+func (h *GuardianHandler) linkError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrGuardianNotFound):
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Guardian not found")
+	case errors.Is(err, usecase.ErrStudentNotFound):
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Student not found")
+	default:
+		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
+	}
+}
+
+func requestClaims(c *gin.Context) (jwtutil.Claims, bool) {
+	v, ok := c.Get("claims")
+	if !ok {
+		return jwtutil.Claims{}, false
+	}
+	claims, ok := v.(jwtutil.Claims)
+	return claims, ok
+}
+
+// requestTenant returns the caller's verified tenant, answering 401 when the
+// request carries none. Handlers must never take the tenant from the client.
+func requestTenant(c *gin.Context) (string, bool) {
+	claims, ok := requestClaims(c)
+	if !ok || claims.TenantID == "" {
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", nil)
+		return "", false
+	}
+	return claims.TenantID, true
+}
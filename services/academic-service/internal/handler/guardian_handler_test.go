@@ -0,0 +1,159 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/mocks"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/handler"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGuardianHandler_LinkStudent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockGuardianUseCase(ctrl)
+	h := handler.NewGuardianHandler(mockUseCase)
+
+	id := uuid.New()
+	studentID := uuid.New()
+
+	serve := func(reqBody map[string]interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(reqBody)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
+		c.Request, _ = http.NewRequest(http.MethodPost, "/guardians/"+id.String()+"/students", bytes.NewBuffer(body))
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+		h.LinkStudent(c)
+		return w
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUseCase.EXPECT().LinkStudent(gomock.Any(), "tenant-1", gomock.Any()).DoAndReturn(func(_ interface{}, _ string, l *entity.StudentGuardian) error {
+			assert.Equal(t, id, l.GuardianID)
+			assert.Equal(t, studentID, l.StudentID)
+			assert.Equal(t, entity.GuardianRelationFather, l.Relationship)
+			return nil
+		})
+		w := serve(map[string]interface{}{"student_id": studentID, "relationship": "Father"})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Invalid Relationship", func(t *testing.T) {
+		w := serve(map[string]interface{}{"student_id": studentID, "relationship": "neighbour"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Student Not Found", func(t *testing.T) {
+		mockUseCase.EXPECT().LinkStudent(gomock.Any(), "tenant-1", gomock.Any()).Return(domainUseCase.ErrStudentNotFound)
+		w := serve(map[string]interface{}{"student_id": studentID, "relationship": "mother"})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestGuardianHandler_ListMyStudents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockGuardianUseCase(ctrl)
+	h := handler.NewGuardianHandler(mockUseCase)
+
+	claims := jwtutil.Claims{UserID: uuid.New(), TenantID: "tenant-1"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUseCase.EXPECT().MyStudents(gomock.Any(), claims.TenantID, claims.UserID).Return([]entity.GuardianStudent{}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/guardian/students", nil)
+		c.Set("claims", claims)
+
+		h.ListMyStudents(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("No Guardian Profile", func(t *testing.T) {
+		mockUseCase.EXPECT().MyStudents(gomock.Any(), claims.TenantID, claims.UserID).Return(nil, domainUseCase.ErrGuardianNotFound)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/guardian/students", nil)
+		c.Set("claims", claims)
+
+		h.ListMyStudents(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/guardian/students", nil)
+
+		h.ListMyStudents(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestGuardianHandler_Check(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockGuardianUseCase(ctrl)
+	h := handler.NewGuardianHandler(mockUseCase)
+
+	userID := uuid.New()
+	studentID := uuid.New()
+
+	t.Run("Allowed", func(t *testing.T) {
+		mockUseCase.EXPECT().IsGuardianOf(gomock.Any(), "tenant-1", userID, studentID).Return(true, nil)
+
+		body, _ := json.Marshal(map[string]interface{}{"user_id": userID, "tenant_id": "tenant-1", "student_id": studentID})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/internal/v1/guardians/check", bytes.NewBuffer(body))
+
+		h.Check(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Data struct {
+				Allowed bool `json:"allowed"`
+			} `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.True(t, resp.Data.Allowed)
+	})
+
+	t.Run("Missing Fields", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{"user_id": userID})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/internal/v1/guardians/check", bytes.NewBuffer(body))
+
+		h.Check(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+// withTenant authenticates c the way the auth middleware would.
+func withTenant(c *gin.Context, tenantID string) {
+	c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID})
+}
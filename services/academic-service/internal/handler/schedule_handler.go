@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -32,6 +33,10 @@ func NewScheduleHandler(useCase usecase.ScheduleUseCase) *ScheduleHandler {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /schedules [post]
 func (h *ScheduleHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req CreateScheduleRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -40,7 +45,7 @@ func (h *ScheduleHandler) Create(c *gin.Context) {
 	}
 
 	schedule := &entity.Schedule{
-		TenantID:  req.TenantID,
+		TenantID:  tenantID,
 		ClassID:   req.ClassID,
 		SubjectID: req.SubjectID,
 		TeacherID: req.TeacherID,
@@ -71,6 +76,10 @@ func (h *ScheduleHandler) Create(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /schedules/bulk [post]
 func (h *ScheduleHandler) BulkCreate(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var reqs []CreateScheduleRequest
 
 	if err := c.ShouldBindJSON(&reqs); err != nil {
@@ -81,7 +90,7 @@ func (h *ScheduleHandler) BulkCreate(c *gin.Context) {
 	var schedules []*entity.Schedule
 	for _, req := range reqs {
 		schedule := &entity.Schedule{
-			TenantID:  req.TenantID,
+			TenantID:  tenantID,
 			ClassID:   req.ClassID,
 			SubjectID: req.SubjectID,
 			TeacherID: req.TeacherID,
@@ -108,7 +117,6 @@ func (h *ScheduleHandler) BulkCreate(c *gin.Context) {
 }
 
 type CreateScheduleRequest struct {
-	TenantID  string    `json:"tenant_id" binding:"required"`
 	ClassID   uuid.UUID `json:"class_id" binding:"required"`
 	SubjectID uuid.UUID `json:"subject_id" binding:"required"`
 	TeacherID uuid.UUID `json:"teacher_id" binding:"required"`
@@ -132,6 +140,10 @@ type CreateScheduleRequest struct {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /schedules/from-template [post]
 func (h *ScheduleHandler) CreateFromTemplate(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req CreateFromTemplateRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -144,7 +156,7 @@ func (h *ScheduleHandler) CreateFromTemplate(c *gin.Context) {
 		teacherMap[a.SubjectID] = a.TeacherID
 	}
 
-	if err := h.useCase.CreateFromTemplate(c.Request.Context(), req.TemplateID, req.ClassID, teacherMap); err != nil {
+	if err := h.useCase.CreateFromTemplate(c.Request.Context(), tenantID, req.TemplateID, req.ClassID, teacherMap); err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "conflict") {
 			httputil.Error(c.Writer, http.StatusConflict, "4009", "Conflict", err.Error())
 			return
@@ -184,6 +196,10 @@ type CreateFromTemplateRequest struct {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /schedules/{id} [get]
 func (h *ScheduleHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -191,7 +207,7 @@ func (h *ScheduleHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	schedule, err := h.useCase.GetByID(c.Request.Context(), id)
+	schedule, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -218,9 +234,8 @@ func (h *ScheduleHandler) GetByID(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /schedules [get]
 func (h *ScheduleHandler) List(c *gin.Context) {
-	tenantID := c.Query("tenant_id")
-	if tenantID == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Tenant ID is required")
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -255,6 +270,10 @@ func (h *ScheduleHandler) List(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /schedules/class/{class_id} [get]
 func (h *ScheduleHandler) ListByClass(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	classIDStr := c.Param("class_id")
 	classID, err := uuid.Parse(classIDStr)
 	if err != nil {
@@ -262,7 +281,7 @@ func (h *ScheduleHandler) ListByClass(c *gin.Context) {
 		return
 	}
 
-	schedules, err := h.useCase.ListByClass(c.Request.Context(), classID)
+	schedules, err := h.useCase.ListByClass(c.Request.Context(), tenantID, classID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -286,6 +305,10 @@ func (h *ScheduleHandler) ListByClass(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /schedules/{id} [put]
 func (h *ScheduleHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -300,7 +323,7 @@ func (h *ScheduleHandler) Update(c *gin.Context) {
 		return
 	}
 
-	existing, err := h.useCase.GetByID(c.Request.Context(), id)
+	existing, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -352,6 +375,10 @@ type UpdateScheduleRequest struct {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /schedules/{id} [delete]
 func (h *ScheduleHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -359,7 +386,11 @@ func (h *ScheduleHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	if err := h.useCase.Delete(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Schedule not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
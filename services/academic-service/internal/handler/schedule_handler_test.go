@@ -27,7 +27,6 @@ func TestScheduleHandler_Create(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":   "tenant-1",
 			"class_id":    uuid.New().String(),
 			"subject_id":  uuid.New().String(),
 			"teacher_id":  uuid.New().String(),
@@ -42,6 +41,7 @@ func TestScheduleHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/schedules", bytes.NewBuffer(body))
 
 		h.Create(c)
@@ -51,13 +51,13 @@ func TestScheduleHandler_Create(t *testing.T) {
 
 	t.Run("Invalid Input", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-1",
 			// Missing required fields
 		}
 		body, _ := json.Marshal(reqBody)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/schedules", bytes.NewBuffer(body))
 
 		h.Create(c)
@@ -67,7 +67,6 @@ func TestScheduleHandler_Create(t *testing.T) {
 
 	t.Run("UseCase Error", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":   "tenant-1",
 			"class_id":    uuid.New().String(),
 			"subject_id":  uuid.New().String(),
 			"teacher_id":  uuid.New().String(),
@@ -82,6 +81,7 @@ func TestScheduleHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/schedules", bytes.NewBuffer(body))
 
 		h.Create(c)
@@ -102,10 +102,11 @@ func TestScheduleHandler_GetByID(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		schedule := &entity.Schedule{ID: id}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(schedule, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(schedule, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/schedules/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -115,10 +116,11 @@ func TestScheduleHandler_GetByID(t *testing.T) {
 	})
 
 	t.Run("Not Found", func(t *testing.T) {
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/schedules/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -141,7 +143,8 @@ func TestScheduleHandler_List(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/schedules?tenant_id=tenant-1", nil)
+		withTenant(c, "tenant-1")
+		c.Request, _ = http.NewRequest(http.MethodGet, "/schedules", nil)
 
 		h.List(c)
 
@@ -151,11 +154,11 @@ func TestScheduleHandler_List(t *testing.T) {
 	t.Run("Missing Tenant ID", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/schedules", nil)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/schedules?tenant_id=tenant-1", nil)
 
 		h.List(c)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
 
@@ -170,7 +173,6 @@ func TestScheduleHandler_BulkCreate(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		reqBody := []map[string]interface{}{
 			{
-				"tenant_id":   "tenant-1",
 				"class_id":    uuid.New().String(),
 				"subject_id":  uuid.New().String(),
 				"teacher_id":  uuid.New().String(),
@@ -186,6 +188,7 @@ func TestScheduleHandler_BulkCreate(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/schedules/bulk", bytes.NewBuffer(body))
 
 		h.BulkCreate(c)
@@ -196,7 +199,6 @@ func TestScheduleHandler_BulkCreate(t *testing.T) {
 	t.Run("Conflict", func(t *testing.T) {
 		reqBody := []map[string]interface{}{
 			{
-				"tenant_id":   "tenant-1",
 				"class_id":    uuid.New().String(),
 				"subject_id":  uuid.New().String(),
 				"teacher_id":  uuid.New().String(),
@@ -212,6 +214,7 @@ func TestScheduleHandler_BulkCreate(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/schedules/bulk", bytes.NewBuffer(body))
 
 		h.BulkCreate(c)
@@ -222,7 +225,6 @@ func TestScheduleHandler_BulkCreate(t *testing.T) {
 	t.Run("Invalid Input", func(t *testing.T) {
 		reqBody := []map[string]interface{}{
 			{
-				"tenant_id": "tenant-1",
 				// Missing required fields
 			},
 		}
@@ -230,6 +232,7 @@ func TestScheduleHandler_BulkCreate(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/schedules/bulk", bytes.NewBuffer(body))
 
 		h.BulkCreate(c)
@@ -240,7 +243,6 @@ func TestScheduleHandler_BulkCreate(t *testing.T) {
 	t.Run("UseCase Error", func(t *testing.T) {
 		reqBody := []map[string]interface{}{
 			{
-				"tenant_id":   "tenant-1",
 				"class_id":    uuid.New().String(),
 				"subject_id":  uuid.New().String(),
 				"teacher_id":  uuid.New().String(),
@@ -256,6 +258,7 @@ func TestScheduleHandler_BulkCreate(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/schedules/bulk", bytes.NewBuffer(body))
 
 		h.BulkCreate(c)
@@ -275,10 +278,11 @@ func TestScheduleHandler_ListByClass(t *testing.T) {
 	classID := uuid.New()
 
 	t.Run("Success", func(t *testing.T) {
-		mockUseCase.EXPECT().ListByClass(gomock.Any(), classID).Return([]entity.Schedule{}, nil)
+		mockUseCase.EXPECT().ListByClass(gomock.Any(), "tenant-1", classID).Return([]entity.Schedule{}, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/schedules/class/"+classID.String(), nil)
 		c.Params = gin.Params{{Key: "class_id", Value: classID.String()}}
 
@@ -290,6 +294,7 @@ func TestScheduleHandler_ListByClass(t *testing.T) {
 	t.Run("Invalid Class ID", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/schedules/class/invalid-uuid", nil)
 		c.Params = gin.Params{{Key: "class_id", Value: "invalid-uuid"}}
 
@@ -299,10 +304,11 @@ func TestScheduleHandler_ListByClass(t *testing.T) {
 	})
 
 	t.Run("UseCase Error", func(t *testing.T) {
-		mockUseCase.EXPECT().ListByClass(gomock.Any(), classID).Return(nil, errors.New("usecase error"))
+		mockUseCase.EXPECT().ListByClass(gomock.Any(), "tenant-1", classID).Return(nil, errors.New("usecase error"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/schedules/class/"+classID.String(), nil)
 		c.Params = gin.Params{{Key: "class_id", Value: classID.String()}}
 
@@ -335,11 +341,12 @@ func TestScheduleHandler_Update(t *testing.T) {
 		body, _ := json.Marshal(reqBody)
 
 		existing := &entity.Schedule{ID: id}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(existing, nil)
 		mockUseCase.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPut, "/schedules/"+id.String(), bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -351,6 +358,7 @@ func TestScheduleHandler_Update(t *testing.T) {
 	t.Run("Invalid ID", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPut, "/schedules/invalid-uuid", nil)
 		c.Params = gin.Params{{Key: "id", Value: "invalid-uuid"}}
 
@@ -367,6 +375,7 @@ func TestScheduleHandler_Update(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPut, "/schedules/"+id.String(), bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -387,10 +396,11 @@ func TestScheduleHandler_Update(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(nil, errors.New("db error"))
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(nil, errors.New("db error"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPut, "/schedules/"+id.String(), bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -411,10 +421,11 @@ func TestScheduleHandler_Update(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPut, "/schedules/"+id.String(), bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -436,11 +447,12 @@ func TestScheduleHandler_Update(t *testing.T) {
 		body, _ := json.Marshal(reqBody)
 
 		existing := &entity.Schedule{ID: id}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(existing, nil)
 		mockUseCase.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("schedule conflict detected"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPut, "/schedules/"+id.String(), bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -462,11 +474,12 @@ func TestScheduleHandler_Update(t *testing.T) {
 		body, _ := json.Marshal(reqBody)
 
 		existing := &entity.Schedule{ID: id}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(existing, nil)
 		mockUseCase.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("usecase error"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPut, "/schedules/"+id.String(), bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -487,10 +500,11 @@ func TestScheduleHandler_Delete(t *testing.T) {
 	id := uuid.New()
 
 	t.Run("Success", func(t *testing.T) {
-		mockUseCase.EXPECT().Delete(gomock.Any(), id).Return(nil)
+		mockUseCase.EXPECT().Delete(gomock.Any(), "tenant-1", id).Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodDelete, "/schedules/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -502,6 +516,7 @@ func TestScheduleHandler_Delete(t *testing.T) {
 	t.Run("Invalid ID", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodDelete, "/schedules/invalid-uuid", nil)
 		c.Params = gin.Params{{Key: "id", Value: "invalid-uuid"}}
 
@@ -511,10 +526,11 @@ func TestScheduleHandler_Delete(t *testing.T) {
 	})
 
 	t.Run("UseCase Error", func(t *testing.T) {
-		mockUseCase.EXPECT().Delete(gomock.Any(), id).Return(errors.New("usecase error"))
+		mockUseCase.EXPECT().Delete(gomock.Any(), "tenant-1", id).Return(errors.New("usecase error"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodDelete, "/schedules/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -19,8 +20,11 @@ func NewScheduleTemplateHandler(useCase usecase.ScheduleTemplateUseCase) *Schedu
 }
 
 func (h *ScheduleTemplateHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID    string `json:"tenant_id" binding:"required"`
 		Name        string `json:"name" binding:"required"`
 		Description string `json:"description"`
 	}
@@ -31,7 +35,7 @@ func (h *ScheduleTemplateHandler) Create(c *gin.Context) {
 	}
 
 	template := &entity.ScheduleTemplate{
-		TenantID:    req.TenantID,
+		TenantID:    tenantID,
 		Name:        req.Name,
 		Description: req.Description,
 		IsActive:    true,
@@ -46,6 +50,10 @@ func (h *ScheduleTemplateHandler) Create(c *gin.Context) {
 }
 
 func (h *ScheduleTemplateHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -53,7 +61,7 @@ func (h *ScheduleTemplateHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	template, err := h.useCase.GetByID(c.Request.Context(), id)
+	template, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -67,9 +75,8 @@ func (h *ScheduleTemplateHandler) GetByID(c *gin.Context) {
 }
 
 func (h *ScheduleTemplateHandler) List(c *gin.Context) {
-	tenantID := c.Query("tenant_id")
-	if tenantID == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Tenant ID is required")
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -83,6 +90,10 @@ func (h *ScheduleTemplateHandler) List(c *gin.Context) {
 }
 
 func (h *ScheduleTemplateHandler) AddItem(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	templateID, err := uuid.Parse(idStr)
 	if err != nil {
@@ -110,7 +121,11 @@ func (h *ScheduleTemplateHandler) AddItem(c *gin.Context) {
 		EndTime:    req.EndTime,
 	}
 
-	if err := h.useCase.AddItem(c.Request.Context(), item); err != nil {
+	if err := h.useCase.AddItem(c.Request.Context(), tenantID, item); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Template not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -119,6 +134,10 @@ func (h *ScheduleTemplateHandler) AddItem(c *gin.Context) {
 }
 
 func (h *ScheduleTemplateHandler) RemoveItem(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	// The route should probably be DELETE /items/:item_id
 	// But usually we nest it under template: DELETE /templates/:id/items/:item_id
 	// Or just DELETE /templates/items/:item_id
@@ -130,7 +149,11 @@ func (h *ScheduleTemplateHandler) RemoveItem(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.RemoveItem(c.Request.Context(), id); err != nil {
+	if err := h.useCase.RemoveItem(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Item not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
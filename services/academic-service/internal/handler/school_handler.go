@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -20,8 +21,11 @@ func NewSchoolHandler(useCase usecase.SchoolUseCase) *SchoolHandler {
 
 // Create handles school creation
 func (h *SchoolHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID      string `json:"tenant_id" binding:"required"`
 		Name          string `json:"name" binding:"required"`
 		Address       string `json:"address"`
 		Phone         string `json:"phone"`
@@ -40,7 +44,7 @@ func (h *SchoolHandler) Create(c *gin.Context) {
 	}
 
 	school := &entity.School{
-		TenantID:      req.TenantID,
+		TenantID:      tenantID,
 		Name:          req.Name,
 		Address:       req.Address,
 		Phone:         req.Phone,
@@ -71,6 +75,10 @@ func (h *SchoolHandler) Create(c *gin.Context) {
 
 // GetByID retrieves a school by ID
 func (h *SchoolHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -78,7 +86,7 @@ func (h *SchoolHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	school, err := h.useCase.GetByID(c.Request.Context(), id)
+	school, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -93,9 +101,12 @@ func (h *SchoolHandler) GetByID(c *gin.Context) {
 
 // GetByTenantID retrieves a school by Tenant ID
 func (h *SchoolHandler) GetByTenantID(c *gin.Context) {
-	tenantID := c.Param("tenant_id")
-	if tenantID == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Tenant ID is required")
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	if c.Param("tenant_id") != tenantID {
+		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "Tenant does not match the caller")
 		return
 	}
 
@@ -114,6 +125,10 @@ func (h *SchoolHandler) GetByTenantID(c *gin.Context) {
 
 // Update handles school updates
 func (h *SchoolHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -140,7 +155,7 @@ func (h *SchoolHandler) Update(c *gin.Context) {
 	}
 
 	// First verify exists
-	existing, err := h.useCase.GetByID(c.Request.Context(), id)
+	existing, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -178,6 +193,10 @@ func (h *SchoolHandler) Update(c *gin.Context) {
 
 // Delete handles school deletion
 func (h *SchoolHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -185,7 +204,11 @@ func (h *SchoolHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	if err := h.useCase.Delete(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "School not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -26,7 +26,6 @@ func TestSchoolHandler_Create(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-1",
 			"name":      "SMA 1",
 			"latitude":  -6.2088,
 			"longitude": 106.8456,
@@ -41,6 +40,7 @@ func TestSchoolHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/schools", bytes.NewBuffer(body))
 
 		h.Create(c)
@@ -65,6 +65,7 @@ func TestSchoolHandler_GetByTenantID(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/schools/tenant/"+tenantID, nil)
 		c.Params = gin.Params{{Key: "tenant_id", Value: tenantID}}
 
@@ -93,7 +94,7 @@ func TestSchoolHandler_Update(t *testing.T) {
 		body, _ := json.Marshal(reqBody)
 
 		existing := &entity.School{ID: id, Name: "SMA 1", Latitude: -6.2088, Longitude: 106.8456}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(existing, nil)
 		mockUseCase.EXPECT().Update(gomock.Any(), gomock.AssignableToTypeOf(&entity.School{})).DoAndReturn(func(ctx interface{}, school *entity.School) error {
 			assert.Equal(t, -6.2000, school.Latitude)
 			assert.Equal(t, 106.8000, school.Longitude)
@@ -102,6 +103,7 @@ func TestSchoolHandler_Update(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPut, "/schools/"+id.String(), bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -122,10 +124,11 @@ func TestSchoolHandler_Delete(t *testing.T) {
 	id := uuid.New()
 
 	t.Run("Success", func(t *testing.T) {
-		mockUseCase.EXPECT().Delete(gomock.Any(), id).Return(nil)
+		mockUseCase.EXPECT().Delete(gomock.Any(), "tenant-1", id).Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodDelete, "/schools/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -147,10 +150,11 @@ func TestSchoolHandler_GetByID(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		school := &entity.School{ID: id}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(school, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(school, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/schools/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
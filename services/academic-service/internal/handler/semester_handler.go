@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -20,8 +21,11 @@ func NewSemesterHandler(useCase usecase.SemesterUseCase) *SemesterHandler {
 }
 
 func (h *SemesterHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID       string    `json:"tenant_id" binding:"required"`
 		AcademicYearID string    `json:"academic_year_id" binding:"required"`
 		Name           string    `json:"name" binding:"required"`
 		StartDate      time.Time `json:"start_date" binding:"required"`
@@ -41,7 +45,7 @@ func (h *SemesterHandler) Create(c *gin.Context) {
 	}
 
 	semester := &entity.Semester{
-		TenantID:       req.TenantID,
+		TenantID:       tenantID,
 		AcademicYearID: academicYearID,
 		Name:           req.Name,
 		StartDate:      req.StartDate,
@@ -58,6 +62,10 @@ func (h *SemesterHandler) Create(c *gin.Context) {
 }
 
 func (h *SemesterHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -65,7 +73,7 @@ func (h *SemesterHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	semester, err := h.useCase.GetByID(c.Request.Context(), id)
+	semester, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -79,7 +87,10 @@ func (h *SemesterHandler) GetByID(c *gin.Context) {
 }
 
 func (h *SemesterHandler) List(c *gin.Context) {
-	tenantID := c.Query("tenant_id")
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	academicYearIDStr := c.Query("academic_year_id")
 
 	var semesters []entity.Semester
@@ -92,11 +103,8 @@ func (h *SemesterHandler) List(c *gin.Context) {
 			return
 		}
 		semesters, err = h.useCase.ListByAcademicYear(c.Request.Context(), academicYearID)
-	} else if tenantID != "" {
-		semesters, err = h.useCase.List(c.Request.Context(), tenantID)
 	} else {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Either tenant_id or academic_year_id is required")
-		return
+		semesters, err = h.useCase.List(c.Request.Context(), tenantID)
 	}
 
 	if err != nil {
@@ -108,6 +116,10 @@ func (h *SemesterHandler) List(c *gin.Context) {
 }
 
 func (h *SemesterHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -127,7 +139,7 @@ func (h *SemesterHandler) Update(c *gin.Context) {
 		return
 	}
 
-	existing, err := h.useCase.GetByID(c.Request.Context(), id)
+	existing, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -151,6 +163,10 @@ func (h *SemesterHandler) Update(c *gin.Context) {
 }
 
 func (h *SemesterHandler) Activate(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -158,7 +174,11 @@ func (h *SemesterHandler) Activate(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.SetActive(c.Request.Context(), id); err != nil {
+	if err := h.useCase.SetActive(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Semester not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -167,6 +187,10 @@ func (h *SemesterHandler) Activate(c *gin.Context) {
 }
 
 func (h *SemesterHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -174,7 +198,11 @@ func (h *SemesterHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	if err := h.useCase.Delete(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Semester not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -21,8 +22,11 @@ func NewStudentHandler(useCase usecase.StudentUseCase) *StudentHandler {
 }
 
 func (h *StudentHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID      string     `json:"tenant_id" binding:"required"`
 		UserID        *uuid.UUID `json:"user_id"`
 		NIS           string     `json:"nis"`
 		NISN          string     `json:"nisn"`
@@ -45,7 +49,7 @@ func (h *StudentHandler) Create(c *gin.Context) {
 	}
 
 	student := &entity.Student{
-		TenantID:      req.TenantID,
+		TenantID:      tenantID,
 		UserID:        req.UserID,
 		NIS:           req.NIS,
 		NISN:          req.NISN,
@@ -71,6 +75,10 @@ func (h *StudentHandler) Create(c *gin.Context) {
 }
 
 func (h *StudentHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -78,7 +86,7 @@ func (h *StudentHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	student, err := h.useCase.GetByID(c.Request.Context(), id)
+	student, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -92,9 +100,8 @@ func (h *StudentHandler) GetByID(c *gin.Context) {
 }
 
 func (h *StudentHandler) List(c *gin.Context) {
-	tenantID := c.Query("tenant_id")
-	if tenantID == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Tenant ID is required")
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -118,6 +125,10 @@ func (h *StudentHandler) List(c *gin.Context) {
 }
 
 func (h *StudentHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -147,7 +158,7 @@ func (h *StudentHandler) Update(c *gin.Context) {
 		return
 	}
 
-	existing, err := h.useCase.GetByID(c.Request.Context(), id)
+	existing, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -181,6 +192,10 @@ func (h *StudentHandler) Update(c *gin.Context) {
 }
 
 func (h *StudentHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -188,7 +203,11 @@ func (h *StudentHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	if err := h.useCase.Delete(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Student not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/mocks"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/handler"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -27,9 +28,8 @@ func TestStudentHandler_Create(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-1",
-			"name":      "John Doe",
-			"status":    "active",
+			"name":   "John Doe",
+			"status": "active",
 		}
 		body, _ := json.Marshal(reqBody)
 
@@ -37,6 +37,7 @@ func TestStudentHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/students", bytes.NewBuffer(body))
 
 		h.Create(c)
@@ -46,13 +47,13 @@ func TestStudentHandler_Create(t *testing.T) {
 
 	t.Run("Invalid Input", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-1",
 			// Missing required fields
 		}
 		body, _ := json.Marshal(reqBody)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/students", bytes.NewBuffer(body))
 
 		h.Create(c)
@@ -62,8 +63,7 @@ func TestStudentHandler_Create(t *testing.T) {
 
 	t.Run("UseCase Error", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-1",
-			"name":      "John Doe",
+			"name": "John Doe",
 		}
 		body, _ := json.Marshal(reqBody)
 
@@ -71,6 +71,7 @@ func TestStudentHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/students", bytes.NewBuffer(body))
 
 		h.Create(c)
@@ -91,10 +92,11 @@ func TestStudentHandler_GetByID(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		student := &entity.Student{ID: id}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(student, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(student, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/students/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -104,10 +106,11 @@ func TestStudentHandler_GetByID(t *testing.T) {
 	})
 
 	t.Run("Not Found", func(t *testing.T) {
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/students/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -130,10 +133,36 @@ func TestStudentHandler_List(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/students?tenant_id=tenant-1", nil)
+		withTenant(c, "tenant-1")
+		c.Request, _ = http.NewRequest(http.MethodGet, "/students", nil)
 
 		h.List(c)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 }
+
+func TestStudentHandler_Delete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockStudentUseCase(ctrl)
+	h := handler.NewStudentHandler(mockUseCase)
+
+	id := uuid.New()
+
+	t.Run("Other Tenant", func(t *testing.T) {
+		mockUseCase.EXPECT().Delete(gomock.Any(), "tenant-2", id).Return(domainUseCase.ErrNotFound)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-2")
+		c.Request, _ = http.NewRequest(http.MethodDelete, "/students/"+id.String(), nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+
+		h.Delete(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
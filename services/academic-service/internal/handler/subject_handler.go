@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -20,8 +21,11 @@ func NewSubjectHandler(useCase usecase.SubjectUseCase) *SubjectHandler {
 }
 
 func (h *SubjectHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID    string `json:"tenant_id" binding:"required"`
 		Code        string `json:"code" binding:"required"`
 		Name        string `json:"name" binding:"required"`
 		Description string `json:"description"`
@@ -35,7 +39,7 @@ func (h *SubjectHandler) Create(c *gin.Context) {
 	}
 
 	subject := &entity.Subject{
-		TenantID:    req.TenantID,
+		TenantID:    tenantID,
 		Code:        req.Code,
 		Name:        req.Name,
 		Description: req.Description,
@@ -52,6 +56,10 @@ func (h *SubjectHandler) Create(c *gin.Context) {
 }
 
 func (h *SubjectHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -59,7 +67,7 @@ func (h *SubjectHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	subject, err := h.useCase.GetByID(c.Request.Context(), id)
+	subject, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -73,9 +81,8 @@ func (h *SubjectHandler) GetByID(c *gin.Context) {
 }
 
 func (h *SubjectHandler) List(c *gin.Context) {
-	tenantID := c.Query("tenant_id")
-	if tenantID == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Tenant ID is required")
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -99,6 +106,10 @@ func (h *SubjectHandler) List(c *gin.Context) {
 }
 
 func (h *SubjectHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -119,7 +130,7 @@ func (h *SubjectHandler) Update(c *gin.Context) {
 		return
 	}
 
-	existing, err := h.useCase.GetByID(c.Request.Context(), id)
+	existing, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -144,6 +155,10 @@ func (h *SubjectHandler) Update(c *gin.Context) {
 }
 
 func (h *SubjectHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -151,7 +166,11 @@ func (h *SubjectHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	if err := h.useCase.Delete(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Subject not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -20,8 +21,11 @@ func NewTeacherHandler(useCase usecase.TeacherUseCase) *TeacherHandler {
 }
 
 func (h *TeacherHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID   string     `json:"tenant_id" binding:"required"`
 		UserID     *uuid.UUID `json:"user_id"`
 		NIP        string     `json:"nip"`
 		Name       string     `json:"name" binding:"required"`
@@ -39,7 +43,7 @@ func (h *TeacherHandler) Create(c *gin.Context) {
 	}
 
 	teacher := &entity.Teacher{
-		TenantID:   req.TenantID,
+		TenantID:   tenantID,
 		UserID:     req.UserID,
 		NIP:        req.NIP,
 		Name:       req.Name,
@@ -60,6 +64,10 @@ func (h *TeacherHandler) Create(c *gin.Context) {
 }
 
 func (h *TeacherHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -67,7 +75,7 @@ func (h *TeacherHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	teacher, err := h.useCase.GetByID(c.Request.Context(), id)
+	teacher, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -81,9 +89,8 @@ func (h *TeacherHandler) GetByID(c *gin.Context) {
 }
 
 func (h *TeacherHandler) List(c *gin.Context) {
-	tenantID := c.Query("tenant_id")
-	if tenantID == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Tenant ID is required")
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -107,6 +114,10 @@ func (h *TeacherHandler) List(c *gin.Context) {
 }
 
 func (h *TeacherHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -131,7 +142,7 @@ func (h *TeacherHandler) Update(c *gin.Context) {
 		return
 	}
 
-	existing, err := h.useCase.GetByID(c.Request.Context(), id)
+	existing, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -160,6 +171,10 @@ func (h *TeacherHandler) Update(c *gin.Context) {
 }
 
 func (h *TeacherHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -167,7 +182,11 @@ func (h *TeacherHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	if err := h.useCase.Delete(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Teacher not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
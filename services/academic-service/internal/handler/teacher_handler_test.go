@@ -26,8 +26,7 @@ func TestTeacherHandler_Create(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-1",
-			"name":      "Mr. Teacher",
+			"name": "Mr. Teacher",
 		}
 		body, _ := json.Marshal(reqBody)
 
@@ -35,6 +34,7 @@ func TestTeacherHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/teachers", bytes.NewBuffer(body))
 
 		h.Create(c)
@@ -55,10 +55,11 @@ func TestTeacherHandler_GetByID(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		teacher := &entity.Teacher{ID: id}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(teacher, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(teacher, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/teachers/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -85,11 +86,12 @@ func TestTeacherHandler_Update(t *testing.T) {
 		body, _ := json.Marshal(reqBody)
 
 		existing := &entity.Teacher{ID: id, Name: "Mr. Teacher"}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(existing, nil)
 		mockUseCase.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPut, "/teachers/"+id.String(), bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -110,10 +112,11 @@ func TestTeacherHandler_Delete(t *testing.T) {
 	id := uuid.New()
 
 	t.Run("Success", func(t *testing.T) {
-		mockUseCase.EXPECT().Delete(gomock.Any(), id).Return(nil)
+		mockUseCase.EXPECT().Delete(gomock.Any(), "tenant-1", id).Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodDelete, "/teachers/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -136,7 +139,8 @@ func TestTeacherHandler_List(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/teachers?tenant_id=tenant-1", nil)
+		withTenant(c, "tenant-1")
+		c.Request, _ = http.NewRequest(http.MethodGet, "/teachers", nil)
 
 		h.List(c)
 
@@ -7,6 +7,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 )
 
 type enrollmentRepository struct {
@@ -28,14 +29,14 @@ func (r *enrollmentRepository) Enroll(ctx context.Context, e *entity.Enrollment)
 	if e.Status == "" {
 		e.Status = "active"
 	}
-	return r.db.QueryRow(ctx, query,
+	return outbox.Conn(ctx, r.db).QueryRow(ctx, query,
 		e.TenantID, e.ClassID, e.StudentID, e.Status,
 	).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
 }
 
 func (r *enrollmentRepository) Unenroll(ctx context.Context, id uuid.UUID) error {
 	query := `UPDATE class_students SET deleted_at = NOW() WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query, id)
 	return err
 }
 
@@ -46,7 +47,7 @@ func (r *enrollmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*enti
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 	var e entity.Enrollment
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
 		&e.ID, &e.TenantID, &e.ClassID, &e.StudentID, &e.Status, &e.CreatedAt, &e.UpdatedAt,
 	)
 	if err != nil {
@@ -64,7 +65,7 @@ func (r *enrollmentRepository) ListByClass(ctx context.Context, classID uuid.UUI
 		WHERE cs.class_id = $1 AND cs.deleted_at IS NULL
 		ORDER BY s.name
 	`
-	rows, err := r.db.Query(ctx, query, classID)
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, query, classID)
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +97,7 @@ func (r *enrollmentRepository) ListByStudent(ctx context.Context, studentID uuid
 		WHERE cs.student_id = $1 AND cs.deleted_at IS NULL
 		ORDER BY cs.created_at DESC
 	`
-	rows, err := r.db.Query(ctx, query, studentID)
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, query, studentID)
 	if err != nil {
 		return nil, err
 	}
@@ -125,12 +126,12 @@ func (r *enrollmentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, s
 		SET status = $1, updated_at = NOW()
 		WHERE id = $2 AND deleted_at IS NULL
 	`
-	_, err := r.db.Exec(ctx, query, status, id)
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query, status, id)
 	return err
 }
 
 func (r *enrollmentRepository) BulkEnroll(ctx context.Context, enrollments []*entity.Enrollment) error {
-	tx, err := r.db.Begin(ctx)
+	tx, err := outbox.Conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return err
 	}
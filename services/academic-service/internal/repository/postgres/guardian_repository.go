@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
+)
+
+type guardianRepository struct {
+	db *pgxpool.Pool
+}
+
+var _ repository.GuardianRepository = (*guardianRepository)(nil)
+
+func NewGuardianRepository(db *pgxpool.Pool) repository.GuardianRepository {
+	return &guardianRepository{db: db}
+}
+
+const guardianColumns = `
+	id, tenant_id, user_id, name, phone, email, occupation, address,
+	created_at, updated_at, created_by, updated_by, deleted_at`
+
+func scanGuardian(row pgx.Row) (*entity.Guardian, error) {
+	var g entity.Guardian
+	err := row.Scan(
+		&g.ID, &g.TenantID, &g.UserID, &g.Name, &g.Phone, &g.Email, &g.Occupation, &g.Address,
+		&g.CreatedAt, &g.UpdatedAt, &g.CreatedBy, &g.UpdatedBy, &g.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *guardianRepository) Create(ctx context.Context, g *entity.Guardian) error {
+	query := `
+		INSERT INTO guardians (
+			id, tenant_id, user_id, name, phone, email, occupation, address,
+			created_at, updated_at, created_by, updated_by
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8,
+			$9, $10, $11, $12
+		)
+	`
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	now := time.Now()
+	if g.CreatedAt.IsZero() {
+		g.CreatedAt = now
+	}
+	if g.UpdatedAt.IsZero() {
+		g.UpdatedAt = now
+	}
+
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query,
+		g.ID, g.TenantID, g.UserID, g.Name, g.Phone, g.Email, g.Occupation, g.Address,
+		g.CreatedAt, g.UpdatedAt, g.CreatedBy, g.UpdatedBy,
+	)
+	return err
+}
+
+func (r *guardianRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Guardian, error) {
+	query := `SELECT ` + guardianColumns + ` FROM guardians WHERE id = $1 AND deleted_at IS NULL`
+	g, err := scanGuardian(outbox.Conn(ctx, r.db).QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return g, err
+}
+
+func (r *guardianRepository) GetByUserID(ctx context.Context, tenantID string, userID uuid.UUID) (*entity.Guardian, error) {
+	query := `SELECT ` + guardianColumns + ` FROM guardians WHERE tenant_id = $1 AND user_id = $2 AND deleted_at IS NULL`
+	g, err := scanGuardian(outbox.Conn(ctx, r.db).QueryRow(ctx, query, tenantID, userID))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return g, err
+}
+
+func (r *guardianRepository) List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Guardian, int, error) {
+	countQuery := `SELECT COUNT(*) FROM guardians WHERE tenant_id = $1 AND deleted_at IS NULL`
+	var total int
+	if err := outbox.Conn(ctx, r.db).QueryRow(ctx, countQuery, tenantID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + guardianColumns + `
+		FROM guardians
+		WHERE tenant_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, query, tenantID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var guardians []entity.Guardian
+	for rows.Next() {
+		g, err := scanGuardian(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		guardians = append(guardians, *g)
+	}
+	return guardians, total, nil
+}
+
+func (r *guardianRepository) Update(ctx context.Context, g *entity.Guardian) error {
+	query := `
+		UPDATE guardians SET
+			user_id = $1, name = $2, phone = $3, email = $4,
+			occupation = $5, address = $6, updated_at = $7, updated_by = $8
+		WHERE id = $9 AND deleted_at IS NULL
+	`
+	g.UpdatedAt = time.Now()
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query,
+		g.UserID, g.Name, g.Phone, g.Email,
+		g.Occupation, g.Address, g.UpdatedAt, g.UpdatedBy, g.ID,
+	)
+	return err
+}
+
+func (r *guardianRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE guardians SET deleted_at = $1 WHERE id = $2`
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query, time.Now(), id)
+	return err
+}
+
+func (r *guardianRepository) LinkStudent(ctx context.Context, l *entity.StudentGuardian) error {
+	query := `
+		INSERT INTO student_guardians (student_id, guardian_id, relationship, is_primary, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (student_id, guardian_id)
+		DO UPDATE SET relationship = EXCLUDED.relationship, is_primary = EXCLUDED.is_primary
+	`
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = time.Now()
+	}
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query, l.StudentID, l.GuardianID, l.Relationship, l.IsPrimary, l.CreatedAt)
+	return err
+}
+
+func (r *guardianRepository) UnlinkStudent(ctx context.Context, guardianID, studentID uuid.UUID) error {
+	query := `DELETE FROM student_guardians WHERE guardian_id = $1 AND student_id = $2`
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query, guardianID, studentID)
+	return err
+}
+
+func (r *guardianRepository) ListStudents(ctx context.Context, guardianID uuid.UUID) ([]entity.GuardianStudent, error) {
+	query := `
+		SELECT
+			s.id, s.tenant_id, s.user_id, s.nis, s.nisn, s.name, s.gender, s.birth_place, s.birth_date,
+			s.address, s.phone, s.email, s.parent_name, s.parent_phone, s.admission_date, s.status,
+			s.created_at, s.updated_at, s.created_by, s.updated_by, s.deleted_at,
+			sg.relationship, sg.is_primary
+		FROM student_guardians sg
+		JOIN students s ON s.id = sg.student_id
+		WHERE sg.guardian_id = $1 AND s.deleted_at IS NULL
+		ORDER BY s.name
+	`
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, query, guardianID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var students []entity.GuardianStudent
+	for rows.Next() {
+		var gs entity.GuardianStudent
+		s := &gs.Student
+		if err := rows.Scan(
+			&s.ID, &s.TenantID, &s.UserID, &s.NIS, &s.NISN, &s.Name, &s.Gender, &s.BirthPlace, &s.BirthDate,
+			&s.Address, &s.Phone, &s.Email, &s.ParentName, &s.ParentPhone, &s.AdmissionDate, &s.Status,
+			&s.CreatedAt, &s.UpdatedAt, &s.CreatedBy, &s.UpdatedBy, &s.DeletedAt,
+			&gs.Relationship, &gs.IsPrimary,
+		); err != nil {
+			return nil, err
+		}
+		students = append(students, gs)
+	}
+	return students, nil
+}
+
+func (r *guardianRepository) ListByStudent(ctx context.Context, studentID uuid.UUID) ([]entity.Guardian, error) {
+	query := `
+		SELECT
+			g.id, g.tenant_id, g.user_id, g.name, g.phone, g.email, g.occupation, g.address,
+			g.created_at, g.updated_at, g.created_by, g.updated_by, g.deleted_at
+		FROM student_guardians sg
+		JOIN guardians g ON g.id = sg.guardian_id
+		WHERE sg.student_id = $1 AND g.deleted_at IS NULL
+		ORDER BY sg.is_primary DESC, g.name
+	`
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, query, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var guardians []entity.Guardian
+	for rows.Next() {
+		g, err := scanGuardian(rows)
+		if err != nil {
+			return nil, err
+		}
+		guardians = append(guardians, *g)
+	}
+	return guardians, nil
+}
+
+// IsGuardianOf reports whether the auth-service user is a guardian of the
+// student within the tenant.
+func (r *guardianRepository) IsGuardianOf(ctx context.Context, tenantID string, userID, studentID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM student_guardians sg
+			JOIN guardians g ON g.id = sg.guardian_id
+			JOIN students s ON s.id = sg.student_id
+			WHERE g.tenant_id = $1 AND g.user_id = $2 AND sg.student_id = $3
+				AND s.tenant_id = $1 AND g.deleted_at IS NULL AND s.deleted_at IS NULL
+		)
+	`
+	var ok bool
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, tenantID, userID, studentID).Scan(&ok)
+	return ok, err
+}
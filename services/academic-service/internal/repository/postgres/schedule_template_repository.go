@@ -138,6 +138,25 @@ func (r *scheduleTemplateRepository) AddItem(ctx context.Context, item *entity.S
 	return err
 }
 
+func (r *scheduleTemplateRepository) GetItem(ctx context.Context, id uuid.UUID) (*entity.ScheduleTemplateItem, error) {
+	query := `
+		SELECT id, template_id, subject_id, day_of_week, start_time, end_time, created_at, updated_at
+		FROM schedule_template_items
+		WHERE id = $1
+	`
+	var i entity.ScheduleTemplateItem
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&i.ID, &i.TemplateID, &i.SubjectID, &i.DayOfWeek, &i.StartTime, &i.EndTime, &i.CreatedAt, &i.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &i, nil
+}
+
 func (r *scheduleTemplateRepository) RemoveItem(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM schedule_template_items WHERE id = $1`
 	_, err := r.db.Exec(ctx, query, id)
@@ -38,11 +38,15 @@ func (u *academicYearUseCase) Create(ctx context.Context, academicYear *entity.A
 	return u.repo.Create(ctx, academicYear)
 }
 
-func (u *academicYearUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.AcademicYear, error) {
+func (u *academicYearUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.AcademicYear, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetByID(ctx, id)
+	academicYear, err := u.repo.GetByID(ctx, id)
+	if err != nil || academicYear == nil || academicYear.TenantID != tenantID {
+		return nil, err
+	}
+	return academicYear, nil
 }
 
 func (u *academicYearUseCase) List(ctx context.Context, tenantID string) ([]entity.AcademicYear, error) {
@@ -65,9 +69,16 @@ func (u *academicYearUseCase) Update(ctx context.Context, academicYear *entity.A
 	return u.repo.Update(ctx, academicYear)
 }
 
-func (u *academicYearUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *academicYearUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	academicYear, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if academicYear == nil {
+		return domainUseCase.ErrNotFound
+	}
 	return u.repo.Delete(ctx, id)
 }
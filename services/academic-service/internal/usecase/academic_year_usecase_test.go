@@ -47,7 +47,7 @@ func TestAcademicYearUseCase_CRUD(t *testing.T) {
 
 	t.Run("GetByID", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validYear, nil)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, validYear, res)
 	})
@@ -67,14 +67,22 @@ func TestAcademicYearUseCase_CRUD(t *testing.T) {
 	})
 
 	t.Run("Delete", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validYear, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 	})
 
 	t.Run("Delete Error", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validYear, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(errors.New("db error"))
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), tenantID, id)
+		assert.Error(t, err)
+	})
+
+	t.Run("Delete Other Tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validYear, nil)
+		err := u.Delete(context.Background(), "tenant-2", id)
 		assert.Error(t, err)
 	})
 }
@@ -41,41 +41,59 @@ func (u *classSubjectUseCase) Create(ctx context.Context, cs *entity.ClassSubjec
 	return u.repo.Create(ctx, cs)
 }
 
-func (u *classSubjectUseCase) AssignTeacher(ctx context.Context, id uuid.UUID, teacherID uuid.UUID) error {
+func (u *classSubjectUseCase) AssignTeacher(ctx context.Context, tenantID string, id uuid.UUID, teacherID uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	cs, err := u.repo.GetByID(ctx, id)
+	cs, err := u.GetByID(ctx, tenantID, id)
 	if err != nil {
 		return err
 	}
 	if cs == nil {
-		return errors.New("class subject not found")
+		return domainUseCase.ErrNotFound
 	}
 
 	cs.TeacherID = &teacherID
 	return u.repo.Update(ctx, cs)
 }
 
-func (u *classSubjectUseCase) GetByClassAndSubject(ctx context.Context, classID, subjectID uuid.UUID) (*entity.ClassSubject, error) {
+func (u *classSubjectUseCase) GetByClassAndSubject(ctx context.Context, tenantID string, classID, subjectID uuid.UUID) (*entity.ClassSubject, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetByClassAndSubject(ctx, classID, subjectID)
+	cs, err := u.repo.GetByClassAndSubject(ctx, classID, subjectID)
+	if err != nil || cs == nil || cs.TenantID != tenantID {
+		return nil, err
+	}
+	return cs, nil
 }
 
-func (u *classSubjectUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.ClassSubject, error) {
+func (u *classSubjectUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.ClassSubject, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetByID(ctx, id)
+	cs, err := u.repo.GetByID(ctx, id)
+	if err != nil || cs == nil || cs.TenantID != tenantID {
+		return nil, err
+	}
+	return cs, nil
 }
 
-func (u *classSubjectUseCase) ListByClass(ctx context.Context, classID uuid.UUID) ([]entity.ClassSubject, error) {
+func (u *classSubjectUseCase) ListByClass(ctx context.Context, tenantID string, classID uuid.UUID) ([]entity.ClassSubject, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.ListByClass(ctx, classID)
+	subjects, err := u.repo.ListByClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	owned := subjects[:0]
+	for _, cs := range subjects {
+		if cs.TenantID == tenantID {
+			owned = append(owned, cs)
+		}
+	}
+	return owned, nil
 }
 
 func (u *classSubjectUseCase) ListByTeacher(ctx context.Context, teacherID uuid.UUID) ([]entity.ClassSubject, error) {
@@ -85,9 +103,16 @@ func (u *classSubjectUseCase) ListByTeacher(ctx context.Context, teacherID uuid.
 	return u.repo.ListByTeacher(ctx, teacherID)
 }
 
-func (u *classSubjectUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *classSubjectUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	cs, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if cs == nil {
+		return domainUseCase.ErrNotFound
+	}
 	return u.repo.Delete(ctx, id)
 }
@@ -49,7 +49,7 @@ func TestClassSubjectUseCase_CRUD(t *testing.T) {
 
 	t.Run("GetByID", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validCS, nil)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, validCS, res)
 	})
@@ -57,7 +57,7 @@ func TestClassSubjectUseCase_CRUD(t *testing.T) {
 	t.Run("ListByClass", func(t *testing.T) {
 		list := []entity.ClassSubject{*validCS}
 		mockRepo.EXPECT().ListByClass(gomock.Any(), classID).Return(list, nil)
-		res, err := u.ListByClass(context.Background(), classID)
+		res, err := u.ListByClass(context.Background(), tenantID, classID)
 		assert.NoError(t, err)
 		assert.Equal(t, list, res)
 	})
@@ -75,13 +75,20 @@ func TestClassSubjectUseCase_CRUD(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validCS, nil)
 		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 		
-		err := u.AssignTeacher(context.Background(), id, newTeacherID)
+		err := u.AssignTeacher(context.Background(), tenantID, id, newTeacherID)
 		assert.NoError(t, err)
 	})
 
+	t.Run("AssignTeacher Other Tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validCS, nil)
+		err := u.AssignTeacher(context.Background(), "tenant-2", id, uuid.New())
+		assert.Error(t, err)
+	})
+
 	t.Run("Delete", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validCS, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 	})
 }
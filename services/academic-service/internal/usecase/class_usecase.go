@@ -38,11 +38,15 @@ func (u *classUseCase) Create(ctx context.Context, class *entity.Class) error {
 	return u.repo.Create(ctx, class)
 }
 
-func (u *classUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Class, error) {
+func (u *classUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Class, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetByID(ctx, id)
+	class, err := u.repo.GetByID(ctx, id)
+	if err != nil || class == nil || class.TenantID != tenantID {
+		return nil, err
+	}
+	return class, nil
 }
 
 func (u *classUseCase) List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Class, int, error) {
@@ -65,9 +69,16 @@ func (u *classUseCase) Update(ctx context.Context, class *entity.Class) error {
 	return u.repo.Update(ctx, class)
 }
 
-func (u *classUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *classUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	class, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if class == nil {
+		return domainUseCase.ErrNotFound
+	}
 	return u.repo.Delete(ctx, id)
 }
@@ -54,14 +54,14 @@ func TestClassUseCase_CRUD(t *testing.T) {
 
 	t.Run("GetByID", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validClass, nil)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, validClass, res)
 	})
 
 	t.Run("GetByID Repo Error", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, assert.AnError)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -103,14 +103,16 @@ func TestClassUseCase_CRUD(t *testing.T) {
 	})
 
 	t.Run("Delete", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validClass, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 	})
 
 	t.Run("Delete Repo Error", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validClass, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(assert.AnError)
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), tenantID, id)
 		assert.Error(t, err)
 	})
 }
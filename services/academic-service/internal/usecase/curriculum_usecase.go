@@ -38,11 +38,15 @@ func (u *curriculumUseCase) Create(ctx context.Context, c *entity.Curriculum) er
 	return u.repo.Create(ctx, c)
 }
 
-func (u *curriculumUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Curriculum, error) {
+func (u *curriculumUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Curriculum, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetByID(ctx, id)
+	curriculum, err := u.repo.GetByID(ctx, id)
+	if err != nil || curriculum == nil || curriculum.TenantID != tenantID {
+		return nil, err
+	}
+	return curriculum, nil
 }
 
 func (u *curriculumUseCase) List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Curriculum, int, error) {
@@ -65,10 +69,17 @@ func (u *curriculumUseCase) Update(ctx context.Context, c *entity.Curriculum) er
 	return u.repo.Update(ctx, c)
 }
 
-func (u *curriculumUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *curriculumUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	curriculum, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if curriculum == nil {
+		return domainUseCase.ErrNotFound
+	}
 	return u.repo.Delete(ctx, id)
 }
 
@@ -82,20 +93,36 @@ func (u *curriculumUseCase) AddSubject(ctx context.Context, cs *entity.Curriculu
 		}
 	}
 
+	if err := u.checkTenant(ctx, cs.TenantID, cs.CurriculumID); err != nil {
+		return err
+	}
+
 	return u.repo.AddSubject(ctx, cs)
 }
 
-func (u *curriculumUseCase) RemoveSubject(ctx context.Context, id uuid.UUID) error {
+func (u *curriculumUseCase) RemoveSubject(ctx context.Context, tenantID string, curriculumID, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.RemoveSubject(ctx, id)
+	subjects, err := u.ListSubjects(ctx, tenantID, curriculumID)
+	if err != nil {
+		return err
+	}
+	for _, cs := range subjects {
+		if cs.ID == id {
+			return u.repo.RemoveSubject(ctx, id)
+		}
+	}
+	return domainUseCase.ErrNotFound
 }
 
-func (u *curriculumUseCase) ListSubjects(ctx context.Context, curriculumID uuid.UUID) ([]entity.CurriculumSubject, error) {
+func (u *curriculumUseCase) ListSubjects(ctx context.Context, tenantID string, curriculumID uuid.UUID) ([]entity.CurriculumSubject, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	if err := u.checkTenant(ctx, tenantID, curriculumID); err != nil {
+		return nil, err
+	}
 	return u.repo.ListSubjects(ctx, curriculumID)
 }
 
@@ -110,12 +137,31 @@ func (u *curriculumUseCase) AddGradingRule(ctx context.Context, rule *entity.Gra
 		}
 	}
 
+	if err := u.checkTenant(ctx, rule.TenantID, rule.CurriculumID); err != nil {
+		return err
+	}
+
 	return u.repo.AddGradingRule(ctx, rule)
 }
 
-func (u *curriculumUseCase) GetGradingRules(ctx context.Context, curriculumID uuid.UUID) ([]entity.GradingRule, error) {
+func (u *curriculumUseCase) GetGradingRules(ctx context.Context, tenantID string, curriculumID uuid.UUID) ([]entity.GradingRule, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	if err := u.checkTenant(ctx, tenantID, curriculumID); err != nil {
+		return nil, err
+	}
 	return u.repo.ListGradingRules(ctx, curriculumID)
 }
+
+// checkTenant reports ErrNotFound unless the curriculum exists in tenantID.
+func (u *curriculumUseCase) checkTenant(ctx context.Context, tenantID string, curriculumID uuid.UUID) error {
+	curriculum, err := u.GetByID(ctx, tenantID, curriculumID)
+	if err != nil {
+		return err
+	}
+	if curriculum == nil {
+		return domainUseCase.ErrNotFound
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/mocks"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -53,7 +54,7 @@ func TestCurriculumUseCase_CRUD(t *testing.T) {
 
 	t.Run("GetByID", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validCurriculum, nil)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, validCurriculum, res)
 	})
@@ -87,23 +88,39 @@ func TestCurriculumUseCase_CRUD(t *testing.T) {
 	})
 
 	t.Run("Delete", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validCurriculum, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 	})
 
 	t.Run("AddSubject", func(t *testing.T) {
 		cs := &entity.CurriculumSubject{
+			TenantID:     tenantID,
 			CurriculumID: id,
 			SubjectID:    subjectID,
 			GradeLevel:   10,
 			Semester:     1,
 		}
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validCurriculum, nil)
 		mockRepo.EXPECT().AddSubject(gomock.Any(), cs).Return(nil)
 		err := u.AddSubject(context.Background(), cs)
 		assert.NoError(t, err)
 	})
 
+	t.Run("AddSubject Other Tenant", func(t *testing.T) {
+		cs := &entity.CurriculumSubject{
+			TenantID:     "tenant-2",
+			CurriculumID: id,
+			SubjectID:    subjectID,
+			GradeLevel:   10,
+			Semester:     1,
+		}
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validCurriculum, nil)
+		err := u.AddSubject(context.Background(), cs)
+		assert.ErrorIs(t, err, domainUseCase.ErrNotFound)
+	})
+
 	t.Run("AddSubject Validation Error", func(t *testing.T) {
 		cs := &entity.CurriculumSubject{}
 		err := u.AddSubject(context.Background(), cs)
@@ -112,11 +129,13 @@ func TestCurriculumUseCase_CRUD(t *testing.T) {
 
 	t.Run("AddSubject Repo Error", func(t *testing.T) {
 		cs := &entity.CurriculumSubject{
+			TenantID:     tenantID,
 			CurriculumID: id,
 			SubjectID:    subjectID,
 			GradeLevel:   10,
 			Semester:     1,
 		}
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validCurriculum, nil)
 		mockRepo.EXPECT().AddSubject(gomock.Any(), cs).Return(assert.AnError)
 		err := u.AddSubject(context.Background(), cs)
 		assert.Error(t, err)
@@ -124,10 +143,19 @@ func TestCurriculumUseCase_CRUD(t *testing.T) {
 
 	t.Run("RemoveSubject", func(t *testing.T) {
 		relationID := uuid.New()
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validCurriculum, nil)
+		mockRepo.EXPECT().ListSubjects(gomock.Any(), id).Return([]entity.CurriculumSubject{{ID: relationID, CurriculumID: id}}, nil)
 		mockRepo.EXPECT().RemoveSubject(gomock.Any(), relationID).Return(nil)
-		err := u.RemoveSubject(context.Background(), relationID)
+		err := u.RemoveSubject(context.Background(), tenantID, id, relationID)
 		assert.NoError(t, err)
 	})
+
+	t.Run("RemoveSubject Of Another Curriculum", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validCurriculum, nil)
+		mockRepo.EXPECT().ListSubjects(gomock.Any(), id).Return(nil, nil)
+		err := u.RemoveSubject(context.Background(), tenantID, id, uuid.New())
+		assert.ErrorIs(t, err, domainUseCase.ErrNotFound)
+	})
 }
 
 func TestCurriculumUseCase_GradingRules(t *testing.T) {
@@ -149,7 +177,10 @@ func TestCurriculumUseCase_GradingRules(t *testing.T) {
 		Points:       4.0,
 	}
 
+	curriculum := &entity.Curriculum{ID: curriculumID, TenantID: tenantID}
+
 	t.Run("AddGradingRule Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), curriculumID).Return(curriculum, nil)
 		mockRepo.EXPECT().AddGradingRule(gomock.Any(), validRule).Return(nil)
 		err := u.AddGradingRule(context.Background(), validRule)
 		assert.NoError(t, err)
@@ -165,6 +196,7 @@ func TestCurriculumUseCase_GradingRules(t *testing.T) {
 	})
 
 	t.Run("AddGradingRule Repository Error", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), curriculumID).Return(curriculum, nil)
 		mockRepo.EXPECT().AddGradingRule(gomock.Any(), validRule).Return(errors.New("db error"))
 		err := u.AddGradingRule(context.Background(), validRule)
 		assert.Error(t, err)
@@ -176,18 +208,20 @@ func TestCurriculumUseCase_GradingRules(t *testing.T) {
 			{Grade: "A", MinScore: 85},
 			{Grade: "B", MinScore: 70},
 		}
+		mockRepo.EXPECT().GetByID(gomock.Any(), curriculumID).Return(curriculum, nil)
 		mockRepo.EXPECT().ListGradingRules(gomock.Any(), curriculumID).Return(expectedRules, nil)
 
-		rules, err := u.GetGradingRules(context.Background(), curriculumID)
+		rules, err := u.GetGradingRules(context.Background(), tenantID, curriculumID)
 		assert.NoError(t, err)
 		assert.Len(t, rules, 2)
 		assert.Equal(t, "A", rules[0].Grade)
 	})
 
 	t.Run("GetGradingRules Repository Error", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), curriculumID).Return(curriculum, nil)
 		mockRepo.EXPECT().ListGradingRules(gomock.Any(), curriculumID).Return(nil, errors.New("db error"))
 
-		rules, err := u.GetGradingRules(context.Background(), curriculumID)
+		rules, err := u.GetGradingRules(context.Background(), tenantID, curriculumID)
 		assert.Error(t, err)
 		assert.Nil(t, rules)
 	})
@@ -9,20 +9,26 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/repository"
 	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/audit"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 )
 
 type enrollmentUseCase struct {
 	repo           repository.EnrollmentRepository
 	classRepo      repository.ClassRepository
+	events         outbox.Events
+	auditor        *audit.Publisher
 	contextTimeout time.Duration
 }
 
 var _ domainUseCase.EnrollmentUseCase = (*enrollmentUseCase)(nil)
 
-func NewEnrollmentUseCase(repo repository.EnrollmentRepository, classRepo repository.ClassRepository, timeout time.Duration) domainUseCase.EnrollmentUseCase {
+func NewEnrollmentUseCase(repo repository.EnrollmentRepository, classRepo repository.ClassRepository, events outbox.Events, auditor *audit.Publisher, timeout time.Duration) domainUseCase.EnrollmentUseCase {
 	return &enrollmentUseCase{
 		repo:           repo,
 		classRepo:      classRepo,
+		events:         events,
+		auditor:        auditor,
 		contextTimeout: timeout,
 	}
 }
@@ -42,8 +48,8 @@ func (u *enrollmentUseCase) Enroll(ctx context.Context, e *entity.Enrollment) er
 	if err != nil {
 		return err
 	}
-	if class == nil {
-		return errors.New("class not found")
+	if class == nil || class.TenantID != e.TenantID {
+		return domainUseCase.ErrClassNotFound
 	}
 
 	// Count existing enrollments
@@ -60,38 +66,74 @@ func (u *enrollmentUseCase) Enroll(ctx context.Context, e *entity.Enrollment) er
 		return errors.New("class capacity exceeded")
 	}
 
-	return u.repo.Enroll(ctx, e)
+	return u.events.Transact(ctx, func(ctx context.Context) error {
+		if err := u.repo.Enroll(ctx, e); err != nil {
+			return err
+		}
+		return u.auditor.Record(ctx, audit.Entry{
+			TenantID:     e.TenantID,
+			Action:       "enrollment.create",
+			ResourceType: "enrollment",
+			ResourceID:   &e.ID,
+			NewValues:    map[string]any{"class_id": e.ClassID, "student_id": e.StudentID, "status": e.Status},
+		})
+	})
 }
 
-func (u *enrollmentUseCase) Unenroll(ctx context.Context, id uuid.UUID) error {
+func (u *enrollmentUseCase) Unenroll(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.Unenroll(ctx, id)
+	enrollment, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if enrollment == nil {
+		return domainUseCase.ErrNotFound
+	}
+
+	return u.events.Transact(ctx, func(ctx context.Context) error {
+		if err := u.repo.Unenroll(ctx, id); err != nil {
+			return err
+		}
+		return u.auditor.Record(ctx, audit.Entry{TenantID: tenantID, Action: "enrollment.delete", ResourceType: "enrollment", ResourceID: &id})
+	})
 }
 
-func (u *enrollmentUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Enrollment, error) {
+func (u *enrollmentUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Enrollment, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetByID(ctx, id)
+	enrollment, err := u.repo.GetByID(ctx, id)
+	if err != nil || enrollment == nil || enrollment.TenantID != tenantID {
+		return nil, err
+	}
+	return enrollment, nil
 }
 
-func (u *enrollmentUseCase) ListByClass(ctx context.Context, classID uuid.UUID) ([]entity.Enrollment, error) {
+func (u *enrollmentUseCase) ListByClass(ctx context.Context, tenantID string, classID uuid.UUID) ([]entity.Enrollment, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.ListByClass(ctx, classID)
+	enrollments, err := u.repo.ListByClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	return ownedEnrollments(tenantID, enrollments), nil
 }
 
-func (u *enrollmentUseCase) ListByStudent(ctx context.Context, studentID uuid.UUID) ([]entity.Enrollment, error) {
+func (u *enrollmentUseCase) ListByStudent(ctx context.Context, tenantID string, studentID uuid.UUID) ([]entity.Enrollment, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.ListByStudent(ctx, studentID)
+	enrollments, err := u.repo.ListByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return ownedEnrollments(tenantID, enrollments), nil
 }
 
-func (u *enrollmentUseCase) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+func (u *enrollmentUseCase) UpdateStatus(ctx context.Context, tenantID string, id uuid.UUID, status string) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
@@ -99,10 +141,29 @@ func (u *enrollmentUseCase) UpdateStatus(ctx context.Context, id uuid.UUID, stat
 		return errors.New("status is required")
 	}
 
-	return u.repo.UpdateStatus(ctx, id, status)
+	enrollment, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if enrollment == nil {
+		return domainUseCase.ErrNotFound
+	}
+
+	return u.events.Transact(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdateStatus(ctx, id, status); err != nil {
+			return err
+		}
+		return u.auditor.Record(ctx, audit.Entry{
+			TenantID:     tenantID,
+			Action:       "enrollment.status",
+			ResourceType: "enrollment",
+			ResourceID:   &id,
+			NewValues:    map[string]any{"status": status},
+		})
+	})
 }
 
-func (u *enrollmentUseCase) BulkEnroll(ctx context.Context, classID uuid.UUID, studentIDs []uuid.UUID) error {
+func (u *enrollmentUseCase) BulkEnroll(ctx context.Context, tenantID string, classID uuid.UUID, studentIDs []uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
@@ -115,8 +176,8 @@ func (u *enrollmentUseCase) BulkEnroll(ctx context.Context, classID uuid.UUID, s
 	if err != nil {
 		return err
 	}
-	if class == nil {
-		return errors.New("class not found")
+	if class == nil || class.TenantID != tenantID {
+		return domainUseCase.ErrClassNotFound
 	}
 
 	// Count existing enrollments
@@ -133,7 +194,7 @@ func (u *enrollmentUseCase) BulkEnroll(ctx context.Context, classID uuid.UUID, s
 	var enrollments []*entity.Enrollment
 	for _, studentID := range studentIDs {
 		enrollment := &entity.Enrollment{
-			TenantID:  class.TenantID, // Assuming same tenant
+			TenantID:  class.TenantID,
 			ClassID:   classID,
 			StudentID: studentID,
 			Status:    "active",
@@ -146,5 +207,28 @@ func (u *enrollmentUseCase) BulkEnroll(ctx context.Context, classID uuid.UUID, s
 		enrollments = append(enrollments, enrollment)
 	}
 
-	return u.repo.BulkEnroll(ctx, enrollments)
+	return u.events.Transact(ctx, func(ctx context.Context) error {
+		if err := u.repo.BulkEnroll(ctx, enrollments); err != nil {
+			return err
+		}
+		return u.auditor.Record(ctx, audit.Entry{
+			TenantID:     class.TenantID,
+			Action:       "enrollment.bulk_create",
+			ResourceType: "class",
+			ResourceID:   &class.ID,
+			NewValues:    map[string]any{"student_ids": studentIDs},
+		})
+	})
+}
+
+// ownedEnrollments drops the enrollments of other tenants from a lookup by
+// class or student id.
+func ownedEnrollments(tenantID string, enrollments []entity.Enrollment) []entity.Enrollment {
+	owned := enrollments[:0]
+	for _, e := range enrollments {
+		if e.TenantID == tenantID {
+			owned = append(owned, e)
+		}
+	}
+	return owned
 }
@@ -8,7 +8,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/mocks"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox/outboxtest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -19,7 +21,7 @@ func TestEnrollmentUseCase_CRUD(t *testing.T) {
 
 	mockRepo := mocks.NewMockEnrollmentRepository(ctrl)
 	mockClassRepo := mocks.NewMockClassRepository(ctrl)
-	u := usecase.NewEnrollmentUseCase(mockRepo, mockClassRepo, time.Second*2)
+	u := usecase.NewEnrollmentUseCase(mockRepo, mockClassRepo, &outboxtest.Recorder{}, nil, time.Second*2)
 
 	tenantID := "tenant-1"
 	id := uuid.New()
@@ -62,6 +64,13 @@ func TestEnrollmentUseCase_CRUD(t *testing.T) {
 		assert.Equal(t, "class not found", err.Error())
 	})
 
+	t.Run("Enroll Class Of Another Tenant", func(t *testing.T) {
+		otherClass := &entity.Class{ID: classID, TenantID: "tenant-2", Capacity: 30}
+		mockClassRepo.EXPECT().GetByID(gomock.Any(), classID).Return(otherClass, nil)
+		err := u.Enroll(context.Background(), validEnrollment)
+		assert.ErrorIs(t, err, domainUseCase.ErrClassNotFound)
+	})
+
 	t.Run("Enroll Capacity Exceeded", func(t *testing.T) {
 		smallClass := &entity.Class{
 			ID:       classID,
@@ -87,14 +96,16 @@ func TestEnrollmentUseCase_CRUD(t *testing.T) {
 	})
 
 	t.Run("Unenroll Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validEnrollment, nil)
 		mockRepo.EXPECT().Unenroll(gomock.Any(), id).Return(nil)
-		err := u.Unenroll(context.Background(), id)
+		err := u.Unenroll(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 	})
 
 	t.Run("Unenroll Repo Error", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validEnrollment, nil)
 		mockRepo.EXPECT().Unenroll(gomock.Any(), id).Return(assert.AnError)
-		err := u.Unenroll(context.Background(), id)
+		err := u.Unenroll(context.Background(), tenantID, id)
 		assert.Error(t, err)
 	})
 
@@ -104,12 +115,12 @@ func TestEnrollmentUseCase_CRUD(t *testing.T) {
 		mockRepo.EXPECT().ListByClass(gomock.Any(), classID).Return([]entity.Enrollment{}, nil)
 		mockRepo.EXPECT().BulkEnroll(gomock.Any(), gomock.Any()).Return(nil)
 
-		err := u.BulkEnroll(context.Background(), classID, studentIDs)
+		err := u.BulkEnroll(context.Background(), tenantID, classID, studentIDs)
 		assert.NoError(t, err)
 	})
 
 	t.Run("BulkEnroll Validation Error (Empty)", func(t *testing.T) {
-		err := u.BulkEnroll(context.Background(), classID, []uuid.UUID{})
+		err := u.BulkEnroll(context.Background(), tenantID, classID, []uuid.UUID{})
 		assert.Error(t, err)
 		assert.Equal(t, "student IDs are required", err.Error())
 	})
@@ -118,7 +129,7 @@ func TestEnrollmentUseCase_CRUD(t *testing.T) {
 		studentIDs := []uuid.UUID{uuid.New()}
 		mockClassRepo.EXPECT().GetByID(gomock.Any(), classID).Return(nil, nil)
 		
-		err := u.BulkEnroll(context.Background(), classID, studentIDs)
+		err := u.BulkEnroll(context.Background(), tenantID, classID, studentIDs)
 		assert.Error(t, err)
 		assert.Equal(t, "class not found", err.Error())
 	})
@@ -134,21 +145,21 @@ func TestEnrollmentUseCase_CRUD(t *testing.T) {
 		mockClassRepo.EXPECT().GetByID(gomock.Any(), classID).Return(smallClass, nil)
 		mockRepo.EXPECT().ListByClass(gomock.Any(), classID).Return([]entity.Enrollment{}, nil) // 0 existing
 
-		err := u.BulkEnroll(context.Background(), classID, studentIDs)
+		err := u.BulkEnroll(context.Background(), tenantID, classID, studentIDs)
 		assert.Error(t, err)
 		assert.Equal(t, "class capacity exceeded", err.Error())
 	})
 
 	t.Run("GetByID Success", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validEnrollment, nil)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, validEnrollment, res)
 	})
 
 	t.Run("GetByID Repo Error", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, assert.AnError)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -156,14 +167,22 @@ func TestEnrollmentUseCase_CRUD(t *testing.T) {
 	t.Run("ListByClass Success", func(t *testing.T) {
 		list := []entity.Enrollment{*validEnrollment}
 		mockRepo.EXPECT().ListByClass(gomock.Any(), classID).Return(list, nil)
-		res, err := u.ListByClass(context.Background(), classID)
+		res, err := u.ListByClass(context.Background(), tenantID, classID)
 		assert.NoError(t, err)
 		assert.Equal(t, list, res)
 	})
 
+	t.Run("ListByClass Drops Other Tenants", func(t *testing.T) {
+		other := entity.Enrollment{ID: uuid.New(), TenantID: "tenant-2", ClassID: classID}
+		mockRepo.EXPECT().ListByClass(gomock.Any(), classID).Return([]entity.Enrollment{*validEnrollment, other}, nil)
+		res, err := u.ListByClass(context.Background(), tenantID, classID)
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.Enrollment{*validEnrollment}, res)
+	})
+
 	t.Run("ListByClass Repo Error", func(t *testing.T) {
 		mockRepo.EXPECT().ListByClass(gomock.Any(), classID).Return(nil, assert.AnError)
-		res, err := u.ListByClass(context.Background(), classID)
+		res, err := u.ListByClass(context.Background(), tenantID, classID)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -171,36 +190,44 @@ func TestEnrollmentUseCase_CRUD(t *testing.T) {
 	t.Run("ListByStudent Success", func(t *testing.T) {
 		list := []entity.Enrollment{*validEnrollment}
 		mockRepo.EXPECT().ListByStudent(gomock.Any(), studentID).Return(list, nil)
-		res, err := u.ListByStudent(context.Background(), studentID)
+		res, err := u.ListByStudent(context.Background(), tenantID, studentID)
 		assert.NoError(t, err)
 		assert.Equal(t, list, res)
 	})
 
 	t.Run("ListByStudent Repo Error", func(t *testing.T) {
 		mockRepo.EXPECT().ListByStudent(gomock.Any(), studentID).Return(nil, assert.AnError)
-		res, err := u.ListByStudent(context.Background(), studentID)
+		res, err := u.ListByStudent(context.Background(), tenantID, studentID)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
 
 	t.Run("UpdateStatus Success", func(t *testing.T) {
 		status := "moved"
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validEnrollment, nil)
 		mockRepo.EXPECT().UpdateStatus(gomock.Any(), id, status).Return(nil)
-		err := u.UpdateStatus(context.Background(), id, status)
+		err := u.UpdateStatus(context.Background(), tenantID, id, status)
 		assert.NoError(t, err)
 	})
 
 	t.Run("UpdateStatus Validation Error", func(t *testing.T) {
 		status := ""
-		err := u.UpdateStatus(context.Background(), id, status)
+		err := u.UpdateStatus(context.Background(), tenantID, id, status)
 		assert.Error(t, err)
 		assert.Equal(t, "status is required", err.Error())
 	})
 
+	t.Run("UpdateStatus Other Tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validEnrollment, nil)
+		err := u.UpdateStatus(context.Background(), "tenant-2", id, "moved")
+		assert.ErrorIs(t, err, domainUseCase.ErrNotFound)
+	})
+
 	t.Run("UpdateStatus Repo Error", func(t *testing.T) {
 		status := "moved"
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validEnrollment, nil)
 		mockRepo.EXPECT().UpdateStatus(gomock.Any(), id, status).Return(assert.AnError)
-		err := u.UpdateStatus(context.Background(), id, status)
+		err := u.UpdateStatus(context.Background(), tenantID, id, status)
 		assert.Error(t, err)
 	})
 }
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/repository"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
+)
+
+type guardianUseCase struct {
+	repo           repository.GuardianRepository
+	studentRepo    repository.StudentRepository
+	enrollmentRepo repository.EnrollmentRepository
+	scheduleRepo   repository.ScheduleRepository
+	events         outbox.Events
+	contextTimeout time.Duration
+}
+
+var _ domainUseCase.GuardianUseCase = (*guardianUseCase)(nil)
+
+func NewGuardianUseCase(
+	repo repository.GuardianRepository,
+	studentRepo repository.StudentRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	scheduleRepo repository.ScheduleRepository,
+	events outbox.Events,
+	timeout time.Duration,
+) domainUseCase.GuardianUseCase {
+	return &guardianUseCase{
+		repo:           repo,
+		studentRepo:    studentRepo,
+		enrollmentRepo: enrollmentRepo,
+		scheduleRepo:   scheduleRepo,
+		events:         events,
+		contextTimeout: timeout,
+	}
+}
+
+// Create stores the guardian and, when no auth-service account was given but
+// an email was, asks auth-service to provision one with the guardian role.
+func (u *guardianUseCase) Create(ctx context.Context, guardian *entity.Guardian) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	if errMap := guardian.Validate(); len(errMap) > 0 {
+		for _, v := range errMap {
+			return errors.New(v)
+		}
+	}
+
+	return u.events.Transact(ctx, func(ctx context.Context) error {
+		if err := u.repo.Create(ctx, guardian); err != nil {
+			return err
+		}
+		if guardian.UserID != nil || guardian.Email == "" {
+			return nil
+		}
+		eventPayload := map[string]interface{}{
+			"tenant_id":   guardian.TenantID,
+			"guardian_id": guardian.ID,
+			"name":        guardian.Name,
+			"email":       guardian.Email,
+			"phone":       guardian.Phone,
+			"timestamp":   time.Now(),
+		}
+		if err := u.events.Enqueue(ctx, "sisfo.events", "academic.guardian.created", eventPayload); err != nil {
+			return fmt.Errorf("failed to enqueue guardian created event: %w", err)
+		}
+		return nil
+	})
+}
+
+func (u *guardianUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Guardian, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	guardian, err := u.repo.GetByID(ctx, id)
+	if err != nil || guardian == nil || guardian.TenantID != tenantID {
+		return nil, err
+	}
+	return guardian, nil
+}
+
+func (u *guardianUseCase) List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Guardian, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	return u.repo.List(ctx, tenantID, limit, offset)
+}
+
+func (u *guardianUseCase) Update(ctx context.Context, guardian *entity.Guardian) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	if errMap := guardian.Validate(); len(errMap) > 0 {
+		for _, v := range errMap {
+			return errors.New(v)
+		}
+	}
+
+	return u.repo.Update(ctx, guardian)
+}
+
+func (u *guardianUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	if _, err := u.guardian(ctx, tenantID, id); err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, id)
+}
+
+func (u *guardianUseCase) LinkUser(ctx context.Context, tenantID string, guardianID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	guardian, err := u.guardian(ctx, tenantID, guardianID)
+	if err != nil {
+		return err
+	}
+
+	guardian.UserID = &userID
+	return u.repo.Update(ctx, guardian)
+}
+
+func (u *guardianUseCase) LinkStudent(ctx context.Context, tenantID string, link *entity.StudentGuardian) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	if errMap := link.Validate(); len(errMap) > 0 {
+		for _, v := range errMap {
+			return errors.New(v)
+		}
+	}
+
+	if _, err := u.guardian(ctx, tenantID, link.GuardianID); err != nil {
+		return err
+	}
+	if err := u.checkStudent(ctx, tenantID, link.StudentID); err != nil {
+		return err
+	}
+
+	return u.repo.LinkStudent(ctx, link)
+}
+
+func (u *guardianUseCase) UnlinkStudent(ctx context.Context, tenantID string, guardianID, studentID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	if _, err := u.guardian(ctx, tenantID, guardianID); err != nil {
+		return err
+	}
+	return u.repo.UnlinkStudent(ctx, guardianID, studentID)
+}
+
+func (u *guardianUseCase) ListStudents(ctx context.Context, tenantID string, guardianID uuid.UUID) ([]entity.GuardianStudent, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	if _, err := u.guardian(ctx, tenantID, guardianID); err != nil {
+		return nil, err
+	}
+	return u.repo.ListStudents(ctx, guardianID)
+}
+
+func (u *guardianUseCase) ListByStudent(ctx context.Context, tenantID string, studentID uuid.UUID) ([]entity.Guardian, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	if err := u.checkStudent(ctx, tenantID, studentID); err != nil {
+		return nil, err
+	}
+	return u.repo.ListByStudent(ctx, studentID)
+}
+
+// guardian loads a guardian of tenantID, reporting ErrGuardianNotFound for a
+// missing one or one of another tenant.
+func (u *guardianUseCase) guardian(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Guardian, error) {
+	guardian, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if guardian == nil {
+		return nil, domainUseCase.ErrGuardianNotFound
+	}
+	return guardian, nil
+}
+
+func (u *guardianUseCase) checkStudent(ctx context.Context, tenantID string, id uuid.UUID) error {
+	student, err := u.studentRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if student == nil || student.TenantID != tenantID {
+		return domainUseCase.ErrStudentNotFound
+	}
+	return nil
+}
+
+// MyStudents lists the children of the guardian linked to the auth-service user.
+func (u *guardianUseCase) MyStudents(ctx context.Context, tenantID string, userID uuid.UUID) ([]entity.GuardianStudent, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	guardian, err := u.repo.GetByUserID(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if guardian == nil {
+		return nil, domainUseCase.ErrGuardianNotFound
+	}
+
+	return u.repo.ListStudents(ctx, guardian.ID)
+}
+
+func (u *guardianUseCase) IsGuardianOf(ctx context.Context, tenantID string, userID, studentID uuid.UUID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	return u.repo.IsGuardianOf(ctx, tenantID, userID, studentID)
+}
+
+// StudentSchedules returns the schedules of every class the student is
+// actively enrolled in.
+func (u *guardianUseCase) StudentSchedules(ctx context.Context, studentID uuid.UUID) ([]entity.Schedule, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	enrollments, err := u.enrollmentRepo.ListByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := []entity.Schedule{}
+	for _, e := range enrollments {
+		if e.Status != "active" {
+			continue
+		}
+		classSchedules, err := u.scheduleRepo.ListByClass(ctx, e.ClassID)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, classSchedules...)
+	}
+	return schedules, nil
+}
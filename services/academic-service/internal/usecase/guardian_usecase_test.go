@@ -0,0 +1,136 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/mocks"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox/outboxtest"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGuardianUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockGuardianRepository(ctrl)
+	mockStudentRepo := mocks.NewMockStudentRepository(ctrl)
+	mockEnrollmentRepo := mocks.NewMockEnrollmentRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	u := usecase.NewGuardianUseCase(mockRepo, mockStudentRepo, mockEnrollmentRepo, mockScheduleRepo, &outboxtest.Recorder{}, time.Second*2)
+
+	tenantID := "tenant-1"
+	guardianID := uuid.New()
+	studentID := uuid.New()
+	userID := uuid.New()
+
+	guardian := &entity.Guardian{
+		ID:       guardianID,
+		TenantID: tenantID,
+		Name:     "Siti Aminah",
+		Email:    "siti@example.com",
+	}
+
+	t.Run("Create Success", func(t *testing.T) {
+		mockRepo.EXPECT().Create(gomock.Any(), guardian).Return(nil)
+		err := u.Create(context.Background(), guardian)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Create Validation Error", func(t *testing.T) {
+		err := u.Create(context.Background(), &entity.Guardian{})
+		assert.Error(t, err)
+	})
+
+	t.Run("LinkUser", func(t *testing.T) {
+		g := *guardian
+		mockRepo.EXPECT().GetByID(gomock.Any(), guardianID).Return(&g, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updated *entity.Guardian) error {
+			assert.Equal(t, userID, *updated.UserID)
+			return nil
+		})
+		err := u.LinkUser(context.Background(), tenantID, guardianID, userID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("LinkUser Guardian Not Found", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), guardianID).Return(nil, nil)
+		err := u.LinkUser(context.Background(), tenantID, guardianID, userID)
+		assert.ErrorIs(t, err, domainUseCase.ErrGuardianNotFound)
+	})
+
+	t.Run("LinkUser Guardian Of Another Tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), guardianID).Return(&entity.Guardian{ID: guardianID, TenantID: "tenant-2"}, nil)
+		err := u.LinkUser(context.Background(), tenantID, guardianID, userID)
+		assert.ErrorIs(t, err, domainUseCase.ErrGuardianNotFound)
+	})
+
+	link := &entity.StudentGuardian{
+		StudentID:    studentID,
+		GuardianID:   guardianID,
+		Relationship: entity.GuardianRelationMother,
+		IsPrimary:    true,
+	}
+
+	t.Run("LinkStudent Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), guardianID).Return(guardian, nil)
+		mockStudentRepo.EXPECT().GetByID(gomock.Any(), studentID).Return(&entity.Student{ID: studentID, TenantID: tenantID}, nil)
+		mockRepo.EXPECT().LinkStudent(gomock.Any(), link).Return(nil)
+		err := u.LinkStudent(context.Background(), tenantID, link)
+		assert.NoError(t, err)
+	})
+
+	t.Run("LinkStudent Invalid Relationship", func(t *testing.T) {
+		err := u.LinkStudent(context.Background(), tenantID, &entity.StudentGuardian{StudentID: studentID, GuardianID: guardianID, Relationship: "uncle"})
+		assert.Error(t, err)
+	})
+
+	t.Run("LinkStudent Student Not Found", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), guardianID).Return(guardian, nil)
+		mockStudentRepo.EXPECT().GetByID(gomock.Any(), studentID).Return(nil, nil)
+		err := u.LinkStudent(context.Background(), tenantID, link)
+		assert.ErrorIs(t, err, domainUseCase.ErrStudentNotFound)
+	})
+
+	t.Run("LinkStudent Tenant Mismatch", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), guardianID).Return(guardian, nil)
+		mockStudentRepo.EXPECT().GetByID(gomock.Any(), studentID).Return(&entity.Student{ID: studentID, TenantID: "tenant-2"}, nil)
+		err := u.LinkStudent(context.Background(), tenantID, link)
+		assert.ErrorIs(t, err, domainUseCase.ErrStudentNotFound)
+	})
+
+	t.Run("MyStudents", func(t *testing.T) {
+		students := []entity.GuardianStudent{{Student: entity.Student{ID: studentID}, Relationship: entity.GuardianRelationMother}}
+		mockRepo.EXPECT().GetByUserID(gomock.Any(), tenantID, userID).Return(guardian, nil)
+		mockRepo.EXPECT().ListStudents(gomock.Any(), guardianID).Return(students, nil)
+		res, err := u.MyStudents(context.Background(), tenantID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, students, res)
+	})
+
+	t.Run("MyStudents Not A Guardian", func(t *testing.T) {
+		mockRepo.EXPECT().GetByUserID(gomock.Any(), tenantID, userID).Return(nil, nil)
+		_, err := u.MyStudents(context.Background(), tenantID, userID)
+		assert.ErrorIs(t, err, domainUseCase.ErrGuardianNotFound)
+	})
+
+	t.Run("StudentSchedules Skips Inactive Enrollments", func(t *testing.T) {
+		activeClass := uuid.New()
+		enrollments := []entity.Enrollment{
+			{ClassID: activeClass, StudentID: studentID, Status: "active"},
+			{ClassID: uuid.New(), StudentID: studentID, Status: "dropped"},
+		}
+		schedules := []entity.Schedule{{ID: uuid.New(), ClassID: activeClass}}
+		mockEnrollmentRepo.EXPECT().ListByStudent(gomock.Any(), studentID).Return(enrollments, nil)
+		mockScheduleRepo.EXPECT().ListByClass(gomock.Any(), activeClass).Return(schedules, nil)
+		res, err := u.StudentSchedules(context.Background(), studentID)
+		assert.NoError(t, err)
+		assert.Equal(t, schedules, res)
+	})
+}
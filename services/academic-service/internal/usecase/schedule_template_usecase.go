@@ -36,7 +36,7 @@ func (u *scheduleTemplateUseCase) Create(c context.Context, t *entity.ScheduleTe
 	return u.repo.Create(ctx, t)
 }
 
-func (u *scheduleTemplateUseCase) GetByID(c context.Context, id uuid.UUID) (*entity.ScheduleTemplate, error) {
+func (u *scheduleTemplateUseCase) GetByID(c context.Context, tenantID string, id uuid.UUID) (*entity.ScheduleTemplate, error) {
 	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
 	defer cancel()
 
@@ -44,7 +44,7 @@ func (u *scheduleTemplateUseCase) GetByID(c context.Context, id uuid.UUID) (*ent
 	if err != nil {
 		return nil, err
 	}
-	if template == nil {
+	if template == nil || template.TenantID != tenantID {
 		return nil, nil
 	}
 
@@ -71,27 +71,55 @@ func (u *scheduleTemplateUseCase) Update(c context.Context, t *entity.ScheduleTe
 	return u.repo.Update(ctx, t)
 }
 
-func (u *scheduleTemplateUseCase) Delete(c context.Context, id uuid.UUID) error {
+func (u *scheduleTemplateUseCase) Delete(c context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
 	defer cancel()
 
+	if err := u.checkTenant(ctx, tenantID, id); err != nil {
+		return err
+	}
 	return u.repo.Delete(ctx, id)
 }
 
-func (u *scheduleTemplateUseCase) AddItem(c context.Context, item *entity.ScheduleTemplateItem) error {
+func (u *scheduleTemplateUseCase) AddItem(c context.Context, tenantID string, item *entity.ScheduleTemplateItem) error {
 	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
 	defer cancel()
 
 	if err := item.Validate(); len(err) > 0 {
 		return errors.New("validation error")
 	}
+	if err := u.checkTenant(ctx, tenantID, item.TemplateID); err != nil {
+		return err
+	}
 
 	return u.repo.AddItem(ctx, item)
 }
 
-func (u *scheduleTemplateUseCase) RemoveItem(c context.Context, id uuid.UUID) error {
+func (u *scheduleTemplateUseCase) RemoveItem(c context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
 	defer cancel()
 
+	item, err := u.repo.GetItem(ctx, id)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return domainUseCase.ErrNotFound
+	}
+	if err := u.checkTenant(ctx, tenantID, item.TemplateID); err != nil {
+		return err
+	}
 	return u.repo.RemoveItem(ctx, id)
 }
+
+// checkTenant reports ErrNotFound unless the template exists in tenantID.
+func (u *scheduleTemplateUseCase) checkTenant(ctx context.Context, tenantID string, id uuid.UUID) error {
+	template, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if template == nil || template.TenantID != tenantID {
+		return domainUseCase.ErrNotFound
+	}
+	return nil
+}
@@ -49,11 +49,15 @@ func (u *scheduleUseCase) Create(ctx context.Context, schedule *entity.Schedule)
 	return u.repo.Create(ctx, schedule)
 }
 
-func (u *scheduleUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Schedule, error) {
+func (u *scheduleUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Schedule, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetByID(ctx, id)
+	schedule, err := u.repo.GetByID(ctx, id)
+	if err != nil || schedule == nil || schedule.TenantID != tenantID {
+		return nil, err
+	}
+	return schedule, nil
 }
 
 func (u *scheduleUseCase) List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Schedule, int, error) {
@@ -63,11 +67,21 @@ func (u *scheduleUseCase) List(ctx context.Context, tenantID string, limit, offs
 	return u.repo.List(ctx, tenantID, limit, offset)
 }
 
-func (u *scheduleUseCase) ListByClass(ctx context.Context, classID uuid.UUID) ([]entity.Schedule, error) {
+func (u *scheduleUseCase) ListByClass(ctx context.Context, tenantID string, classID uuid.UUID) ([]entity.Schedule, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.ListByClass(ctx, classID)
+	schedules, err := u.repo.ListByClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	owned := schedules[:0]
+	for _, s := range schedules {
+		if s.TenantID == tenantID {
+			owned = append(owned, s)
+		}
+	}
+	return owned, nil
 }
 
 func (u *scheduleUseCase) Update(ctx context.Context, schedule *entity.Schedule) error {
@@ -92,10 +106,17 @@ func (u *scheduleUseCase) Update(ctx context.Context, schedule *entity.Schedule)
 	return u.repo.Update(ctx, schedule)
 }
 
-func (u *scheduleUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *scheduleUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	schedule, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if schedule == nil {
+		return domainUseCase.ErrNotFound
+	}
 	return u.repo.Delete(ctx, id)
 }
 
@@ -127,7 +148,7 @@ func (u *scheduleUseCase) BulkCreate(ctx context.Context, schedules []*entity.Sc
 	return u.repo.BulkCreate(ctx, schedules)
 }
 
-func (u *scheduleUseCase) CreateFromTemplate(c context.Context, templateID uuid.UUID, classID uuid.UUID, teacherMap map[uuid.UUID]uuid.UUID) error {
+func (u *scheduleUseCase) CreateFromTemplate(c context.Context, tenantID string, templateID uuid.UUID, classID uuid.UUID, teacherMap map[uuid.UUID]uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
 	defer cancel()
 
@@ -136,7 +157,7 @@ func (u *scheduleUseCase) CreateFromTemplate(c context.Context, templateID uuid.
 	if err != nil {
 		return err
 	}
-	if template == nil {
+	if template == nil || template.TenantID != tenantID {
 		return errors.New("template not found")
 	}
 
@@ -76,7 +76,7 @@ func TestScheduleUseCase_CRUD(t *testing.T) {
 
 	t.Run("GetByID", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validSchedule, nil)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, validSchedule, res)
 	})
@@ -127,11 +127,18 @@ func TestScheduleUseCase_CRUD(t *testing.T) {
 	})
 
 	t.Run("Delete", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validSchedule, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 	})
 
+	t.Run("Delete Other Tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validSchedule, nil)
+		err := u.Delete(context.Background(), "tenant-2", id)
+		assert.Error(t, err)
+	})
+
 	t.Run("BulkCreate Success", func(t *testing.T) {
 		schedules := []*entity.Schedule{validSchedule}
 		mockRepo.EXPECT().CheckConflicts(gomock.Any(), validSchedule).Return([]entity.Schedule{}, nil)
@@ -167,7 +174,7 @@ func TestScheduleUseCase_CRUD(t *testing.T) {
 	t.Run("ListByClass", func(t *testing.T) {
 		list := []entity.Schedule{*validSchedule}
 		mockRepo.EXPECT().ListByClass(gomock.Any(), classID).Return(list, nil)
-		res, err := u.ListByClass(context.Background(), classID)
+		res, err := u.ListByClass(context.Background(), tenantID, classID)
 		assert.NoError(t, err)
 		assert.Equal(t, list, res)
 	})
@@ -203,7 +210,16 @@ func TestScheduleUseCase_CRUD(t *testing.T) {
 		mockRepo.EXPECT().CheckConflicts(gomock.Any(), gomock.Any()).Return([]entity.Schedule{}, nil)
 		mockRepo.EXPECT().BulkCreate(gomock.Any(), gomock.Any()).Return(nil)
 
-		err := u.CreateFromTemplate(context.Background(), templateID, classID, teacherMap)
+		err := u.CreateFromTemplate(context.Background(), tenantID, templateID, classID, teacherMap)
 		assert.NoError(t, err)
 	})
+
+	t.Run("CreateFromTemplate Of Another Tenant", func(t *testing.T) {
+		templateID := uuid.New()
+		template := &entity.ScheduleTemplate{ID: templateID, TenantID: "tenant-2"}
+		mockTemplateRepo.EXPECT().GetByID(gomock.Any(), templateID).Return(template, nil)
+
+		err := u.CreateFromTemplate(context.Background(), tenantID, templateID, uuid.New(), nil)
+		assert.EqualError(t, err, "template not found")
+	})
 }
@@ -42,10 +42,14 @@ func (u *schoolUseCase) Create(ctx context.Context, school *entity.School) error
 	return u.repo.Create(ctx, school)
 }
 
-func (u *schoolUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.School, error) {
+func (u *schoolUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.School, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
-	return u.repo.GetByID(ctx, id)
+	school, err := u.repo.GetByID(ctx, id)
+	if err != nil || school == nil || school.TenantID != tenantID {
+		return nil, err
+	}
+	return school, nil
 }
 
 func (u *schoolUseCase) GetByTenantID(ctx context.Context, tenantID string) (*entity.School, error) {
@@ -68,8 +72,15 @@ func (u *schoolUseCase) Update(ctx context.Context, school *entity.School) error
 	return u.repo.Update(ctx, school)
 }
 
-func (u *schoolUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *schoolUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
+	school, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if school == nil {
+		return domainUseCase.ErrNotFound
+	}
 	return u.repo.Delete(ctx, id)
 }
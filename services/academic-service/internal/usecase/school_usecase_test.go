@@ -50,14 +50,14 @@ func TestSchoolUseCase_CRUD(t *testing.T) {
 
 	t.Run("GetByID Success", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validSchool, nil)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, validSchool, res)
 	})
 
 	t.Run("GetByID Repo Error", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, assert.AnError)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -95,14 +95,16 @@ func TestSchoolUseCase_CRUD(t *testing.T) {
 	})
 
 	t.Run("Delete Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validSchool, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 	})
 
 	t.Run("Delete Repo Error", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validSchool, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(assert.AnError)
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), tenantID, id)
 		assert.Error(t, err)
 	})
 }
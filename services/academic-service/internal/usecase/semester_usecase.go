@@ -44,11 +44,15 @@ func (u *semesterUseCase) Create(ctx context.Context, semester *entity.Semester)
 	return u.repo.Create(ctx, semester)
 }
 
-func (u *semesterUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Semester, error) {
+func (u *semesterUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Semester, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetByID(ctx, id)
+	semester, err := u.repo.GetByID(ctx, id)
+	if err != nil || semester == nil || semester.TenantID != tenantID {
+		return nil, err
+	}
+	return semester, nil
 }
 
 func (u *semesterUseCase) List(ctx context.Context, tenantID string) ([]entity.Semester, error) {
@@ -84,23 +88,30 @@ func (u *semesterUseCase) Update(ctx context.Context, semester *entity.Semester)
 	return u.repo.Update(ctx, semester)
 }
 
-func (u *semesterUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *semesterUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	semester, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if semester == nil {
+		return domainUseCase.ErrNotFound
+	}
 	return u.repo.Delete(ctx, id)
 }
 
-func (u *semesterUseCase) SetActive(ctx context.Context, id uuid.UUID) error {
+func (u *semesterUseCase) SetActive(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	semester, err := u.repo.GetByID(ctx, id)
+	semester, err := u.GetByID(ctx, tenantID, id)
 	if err != nil {
 		return err
 	}
 	if semester == nil {
-		return errors.New("semester not found")
+		return domainUseCase.ErrNotFound
 	}
 
 	if err := u.deactivateOthers(ctx, semester.AcademicYearID, id); err != nil {
@@ -48,7 +48,7 @@ func TestSemesterUseCase_CRUD(t *testing.T) {
 
 	t.Run("GetByID", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validSemester, nil)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, validSemester, res)
 	})
@@ -80,8 +80,9 @@ func TestSemesterUseCase_CRUD(t *testing.T) {
 	})
 
 	t.Run("Delete", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validSemester, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 	})
 
@@ -92,13 +93,13 @@ func TestSemesterUseCase_CRUD(t *testing.T) {
 		mockRepo.EXPECT().ListByAcademicYear(gomock.Any(), academicYearID).Return(existingSemesters, nil)
 		mockRepo.EXPECT().Update(gomock.Any(), validSemester).Return(nil)
 
-		err := u.SetActive(context.Background(), id)
+		err := u.SetActive(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 	})
 	
 	t.Run("SetActive Not Found", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil)
-		err := u.SetActive(context.Background(), id)
+		err := u.SetActive(context.Background(), tenantID, id)
 		assert.Error(t, err)
 	})
 }
@@ -38,11 +38,15 @@ func (u *studentUseCase) Create(ctx context.Context, student *entity.Student) er
 	return u.repo.Create(ctx, student)
 }
 
-func (u *studentUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Student, error) {
+func (u *studentUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Student, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetByID(ctx, id)
+	student, err := u.repo.GetByID(ctx, id)
+	if err != nil || student == nil || student.TenantID != tenantID {
+		return nil, err
+	}
+	return student, nil
 }
 
 func (u *studentUseCase) List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Student, int, error) {
@@ -65,9 +69,16 @@ func (u *studentUseCase) Update(ctx context.Context, student *entity.Student) er
 	return u.repo.Update(ctx, student)
 }
 
-func (u *studentUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *studentUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	student, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if student == nil {
+		return domainUseCase.ErrNotFound
+	}
 	return u.repo.Delete(ctx, id)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/mocks"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/usecase"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -57,20 +58,28 @@ func TestStudentUseCase_GetByID(t *testing.T) {
 	u := NewStudentUseCase(mockRepo, 2*time.Second)
 
 	id := uuid.New()
-	student := &entity.Student{ID: id, Name: "John Doe"}
+	student := &entity.Student{ID: id, TenantID: "tenant-1", Name: "John Doe"}
 
 	t.Run("success", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(student, nil)
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), "tenant-1", id)
 		assert.NoError(t, err)
 		assert.Equal(t, student, res)
 	})
 
+	t.Run("other tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(student, nil)
+
+		res, err := u.GetByID(context.Background(), "tenant-2", id)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, errors.New("not found"))
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), "tenant-1", id)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -131,11 +140,20 @@ func TestStudentUseCase_Delete(t *testing.T) {
 	u := NewStudentUseCase(mockRepo, 2*time.Second)
 
 	id := uuid.New()
+	student := &entity.Student{ID: id, TenantID: "tenant-1", Name: "John Doe"}
 
 	t.Run("success", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(student, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
 
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), "tenant-1", id)
 		assert.NoError(t, err)
 	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(student, nil)
+
+		err := u.Delete(context.Background(), "tenant-2", id)
+		assert.ErrorIs(t, err, domainUseCase.ErrNotFound)
+	})
 }
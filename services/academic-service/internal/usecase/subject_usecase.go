@@ -38,11 +38,15 @@ func (u *subjectUseCase) Create(ctx context.Context, subject *entity.Subject) er
 	return u.repo.Create(ctx, subject)
 }
 
-func (u *subjectUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Subject, error) {
+func (u *subjectUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Subject, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetByID(ctx, id)
+	subject, err := u.repo.GetByID(ctx, id)
+	if err != nil || subject == nil || subject.TenantID != tenantID {
+		return nil, err
+	}
+	return subject, nil
 }
 
 func (u *subjectUseCase) List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Subject, int, error) {
@@ -65,9 +69,16 @@ func (u *subjectUseCase) Update(ctx context.Context, subject *entity.Subject) er
 	return u.repo.Update(ctx, subject)
 }
 
-func (u *subjectUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *subjectUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	subject, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if subject == nil {
+		return domainUseCase.ErrNotFound
+	}
 	return u.repo.Delete(ctx, id)
 }
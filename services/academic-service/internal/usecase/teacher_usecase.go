@@ -38,11 +38,15 @@ func (u *teacherUseCase) Create(ctx context.Context, teacher *entity.Teacher) er
 	return u.repo.Create(ctx, teacher)
 }
 
-func (u *teacherUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Teacher, error) {
+func (u *teacherUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.Teacher, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetByID(ctx, id)
+	teacher, err := u.repo.GetByID(ctx, id)
+	if err != nil || teacher == nil || teacher.TenantID != tenantID {
+		return nil, err
+	}
+	return teacher, nil
 }
 
 func (u *teacherUseCase) List(ctx context.Context, tenantID string, limit, offset int) ([]entity.Teacher, int, error) {
@@ -65,9 +69,16 @@ func (u *teacherUseCase) Update(ctx context.Context, teacher *entity.Teacher) er
 	return u.repo.Update(ctx, teacher)
 }
 
-func (u *teacherUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *teacherUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	teacher, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if teacher == nil {
+		return domainUseCase.ErrNotFound
+	}
 	return u.repo.Delete(ctx, id)
 }
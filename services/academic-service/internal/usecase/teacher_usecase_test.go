@@ -51,14 +51,14 @@ func TestTeacherUseCase_CRUD(t *testing.T) {
 
 	t.Run("GetByID Success", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validTeacher, nil)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, validTeacher, res)
 	})
 
 	t.Run("GetByID Repo Error", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, assert.AnError)
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -100,14 +100,16 @@ func TestTeacherUseCase_CRUD(t *testing.T) {
 	})
 
 	t.Run("Delete Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validTeacher, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 	})
 
 	t.Run("Delete Repo Error", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(validTeacher, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(assert.AnError)
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), tenantID, id)
 		assert.Error(t, err)
 	})
 }
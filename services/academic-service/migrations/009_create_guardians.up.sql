@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS guardians (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id VARCHAR(50) NOT NULL,
+    user_id UUID, -- Link to auth-service users table
+    name VARCHAR(255) NOT NULL,
+    phone VARCHAR(50),
+    email VARCHAR(255),
+    occupation VARCHAR(100),
+    address TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID,
+    updated_by UUID,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_guardians_tenant ON guardians(tenant_id);
+CREATE INDEX idx_guardians_user ON guardians(user_id);
+
+CREATE TABLE IF NOT EXISTS student_guardians (
+    student_id UUID NOT NULL REFERENCES students(id),
+    guardian_id UUID NOT NULL REFERENCES guardians(id),
+    relationship VARCHAR(20) NOT NULL, -- father, mother, guardian, other
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (student_id, guardian_id)
+);
+
+CREATE INDEX idx_student_guardians_guardian ON student_guardians(guardian_id);
@@ -15,6 +15,7 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/mocks"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/handler"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/usecase"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -36,6 +37,7 @@ func TestAcademicYearIntegration(t *testing.T) {
 
 	// Setup Router
 	r := gin.New()
+	r.Use(authenticate("tenant-1"))
 	academicYears := r.Group("/api/v1/academic-years")
 	{
 		academicYears.POST("", h.Create)
@@ -48,7 +50,6 @@ func TestAcademicYearIntegration(t *testing.T) {
 		startDate := time.Now()
 		endDate := startDate.AddDate(1, 0, 0)
 		reqBody := map[string]interface{}{
-			"tenant_id":  "tenant-1",
 			"name":       "2023/2024",
 			"start_date": startDate,
 			"end_date":   endDate,
@@ -122,3 +123,12 @@ func TestAcademicYearIntegration(t *testing.T) {
 		assert.Len(t, resp.Data, 2)
 	})
 }
+
+// authenticate stands in for the auth middleware, which exposes the verified
+// caller as "claims".
+func authenticate(tenantID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID})
+		c.Next()
+	}
+}
@@ -36,6 +36,7 @@ func TestClassIntegration(t *testing.T) {
 
 	// Setup Router
 	r := gin.New()
+	r.Use(authenticate("tenant-1"))
 	classes := r.Group("/api/v1/classes")
 	{
 		classes.POST("", h.Create)
@@ -47,7 +48,6 @@ func TestClassIntegration(t *testing.T) {
 
 	t.Run("Create Class Success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-1",
 			"name":      "Class 1A",
 			"level":     10,
 			"major":     "Science",
@@ -107,7 +107,7 @@ func TestClassIntegration(t *testing.T) {
 		mockRepo.EXPECT().List(gomock.Any(), tenantID, 10, 0).Return(classesList, 2, nil)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodGet, "/api/v1/classes?tenant_id="+tenantID, nil)
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/classes", nil)
 		r.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -157,6 +157,7 @@ func TestClassIntegration(t *testing.T) {
 	t.Run("Delete Class Success", func(t *testing.T) {
 		id := uuid.New()
 
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.Class{ID: id, TenantID: "tenant-1"}, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
 
 		w := httptest.NewRecorder()
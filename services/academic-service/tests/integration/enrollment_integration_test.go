@@ -16,6 +16,7 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/domain/mocks"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/handler"
 	"github.com/jjaenal/sisfo-akademik-backend/services/academic-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox/outboxtest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -31,13 +32,14 @@ func TestEnrollmentIntegration(t *testing.T) {
 	mockClassRepo := mocks.NewMockClassRepository(ctrl)
 
 	// Real UseCase with Mock Repo
-	u := usecase.NewEnrollmentUseCase(mockRepo, mockClassRepo, time.Second*2)
+	u := usecase.NewEnrollmentUseCase(mockRepo, mockClassRepo, &outboxtest.Recorder{}, nil, time.Second*2)
 
 	// Real Handler with Real UseCase
 	h := handler.NewEnrollmentHandler(u)
 
 	// Setup Router
 	r := gin.New()
+	r.Use(authenticate("tenant-1"))
 	enrollments := r.Group("/api/v1/enrollments")
 	{
 		enrollments.POST("", h.Enroll)
@@ -48,7 +50,6 @@ func TestEnrollmentIntegration(t *testing.T) {
 	t.Run("Enroll Student Success", func(t *testing.T) {
 		classID := uuid.New()
 		reqBody := map[string]interface{}{
-			"tenant_id":  "tenant-1",
 			"class_id":   classID.String(),
 			"student_id": uuid.New().String(),
 			"status":     "enrolled",
@@ -97,6 +98,7 @@ func TestEnrollmentIntegration(t *testing.T) {
 		reqBody := map[string]string{"status": status}
 		body, _ := json.Marshal(reqBody)
 
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.Enrollment{ID: id, TenantID: "tenant-1"}, nil)
 		mockRepo.EXPECT().UpdateStatus(gomock.Any(), id, status).Return(nil)
 
 		w := httptest.NewRecorder()
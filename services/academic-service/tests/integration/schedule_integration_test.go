@@ -37,6 +37,7 @@ func TestScheduleIntegration(t *testing.T) {
 
 	// Setup Router
 	r := gin.New()
+	r.Use(authenticate("tenant-1"))
 	schedules := r.Group("/api/v1/schedules")
 	{
 		schedules.POST("", h.Create)
@@ -103,7 +104,6 @@ func TestScheduleIntegration(t *testing.T) {
 	t.Run("Bulk Create Schedule Success", func(t *testing.T) {
 		reqBody := []map[string]interface{}{
 			{
-				"tenant_id":   "tenant-1",
 				"class_id":    uuid.New().String(),
 				"subject_id":  uuid.New().String(),
 				"teacher_id":  uuid.New().String(),
@@ -113,7 +113,6 @@ func TestScheduleIntegration(t *testing.T) {
 				"room":        "Room 101",
 			},
 			{
-				"tenant_id":   "tenant-1",
 				"class_id":    uuid.New().String(),
 				"subject_id":  uuid.New().String(),
 				"teacher_id":  uuid.New().String(),
@@ -154,7 +153,6 @@ func TestScheduleIntegration(t *testing.T) {
 
 	t.Run("Create Schedule Success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":   "tenant-1",
 			"class_id":    uuid.New().String(),
 			"subject_id":  uuid.New().String(),
 			"teacher_id":  uuid.New().String(),
@@ -195,7 +193,6 @@ func TestScheduleIntegration(t *testing.T) {
 
 	t.Run("Create Schedule Conflict", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":   "tenant-1",
 			"class_id":    uuid.New().String(),
 			"subject_id":  uuid.New().String(),
 			"teacher_id":  uuid.New().String(),
@@ -36,6 +36,7 @@ func TestSchoolIntegration(t *testing.T) {
 
 	// Setup Router
 	r := gin.New()
+	r.Use(authenticate("tenant-1"))
 	schools := r.Group("/api/v1/schools")
 	{
 		schools.POST("", h.Create)
@@ -45,7 +46,6 @@ func TestSchoolIntegration(t *testing.T) {
 
 	t.Run("Create School Success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":     "tenant-1",
 			"name":          "SMA Negeri 1",
 			"address":       "Jl. Merdeka No. 1",
 			"phone":         "021-12345678",
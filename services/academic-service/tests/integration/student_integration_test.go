@@ -36,6 +36,7 @@ func TestStudentIntegration(t *testing.T) {
 
 	// Setup Router
 	r := gin.New()
+	r.Use(authenticate("tenant-1"))
 	students := r.Group("/api/v1/students")
 	{
 		students.POST("", h.Create)
@@ -45,7 +46,6 @@ func TestStudentIntegration(t *testing.T) {
 
 	t.Run("Create Student Flow", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-1",
 			"name":      "Jane Doe",
 			"status":    "active",
 		}
@@ -36,6 +36,7 @@ func TestTeacherIntegration(t *testing.T) {
 
 	// Setup Router
 	r := gin.New()
+	r.Use(authenticate("tenant-1"))
 	teachers := r.Group("/api/v1/teachers")
 	{
 		teachers.POST("", h.Create)
@@ -44,10 +45,9 @@ func TestTeacherIntegration(t *testing.T) {
 
 	t.Run("Create Teacher Success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-1",
-			"name":      "Budi Santoso",
-			"nip":       "198001012000121001",
-			"status":    "active",
+			"name":   "Budi Santoso",
+			"nip":    "198001012000121001",
+			"status": "active",
 		}
 		body, _ := json.Marshal(reqBody)
 
@@ -87,7 +87,7 @@ func TestTeacherIntegration(t *testing.T) {
 		mockRepo.EXPECT().List(gomock.Any(), tenantID, 10, 0).Return(teachersList, total, nil)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodGet, "/api/v1/teachers?tenant_id="+tenantID, nil)
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/teachers", nil)
 		r.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
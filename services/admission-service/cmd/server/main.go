@@ -13,9 +13,13 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/middleware"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/repository/postgres"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/database"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/identity"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/logger"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/rabbit"
 	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/tracer"
@@ -57,11 +61,21 @@ func main() {
 
 	// Connect to RabbitMQ
 	rabbitClient := rabbit.New(cfg.RabbitURL)
-	if rabbitClient != nil {
-		defer rabbitClient.Close()
-		log.Info("rabbitmq connected")
+	if rabbitClient == nil {
+		log.Warn("rabbitmq is not configured, event publishing will be disabled")
 	} else {
-		log.Warn("rabbitmq connection failed, event publishing will be disabled")
+		defer rabbitClient.Close()
+		if rabbitClient.Connected() {
+			log.Info("rabbitmq connected")
+		} else {
+			log.Warn("rabbitmq unreachable, reconnecting in the background")
+		}
+	}
+
+	// Events are stored with the change they describe and relayed to RabbitMQ
+	events := outbox.New(dbPool)
+	if rabbitClient != nil {
+		go outbox.NewRelay(dbPool, rabbitClient).Run(context.Background())
 	}
 
 	// Init Layers
@@ -70,7 +84,7 @@ func main() {
 	uc := usecase.NewAdmissionPeriodUseCase(repo, appRepo, 5*time.Second)
 	h := handler.NewAdmissionPeriodHandler(uc)
 
-	appUC := usecase.NewApplicationUseCase(appRepo, rabbitClient, 5*time.Second)
+	appUC := usecase.NewApplicationUseCase(appRepo, events, 5*time.Second)
 	appHandler := handler.NewApplicationHandler(appUC)
 
 	docRepo := postgres.NewApplicationDocumentRepository(dbPool)
@@ -83,10 +97,15 @@ func main() {
 
 	redis := redisutil.New(cfg.RedisAddr)
 	r.Use(middleware.SecurityHeaders())
-	r.Use(middleware.RateLimitByPolicy(redisutil.NewLimiterFromCounter(redis.Raw()), 100, 30, nil))
+	// Public routes are limited per client address; authenticated ones run the
+	// limiter after authn so each user has their own budget
+	limit := middleware.RateLimitByPolicy(redisutil.NewTokenBucketLimiter(redis.Raw()), 100, 30, nil, cfg.TrustedProxies)
 
+	authorizer := authz.NewClient(cfg.AuthServiceURL, redis.Raw(), authz.DefaultCacheTTL, 5*time.Second)
+	perm := func(permission string) gin.HandlerFunc { return middleware.Authorization(authorizer, permission) }
 
-	r.GET("/api/v1/health", func(c *gin.Context) {
+
+	r.GET("/api/v1/health", limit, func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"success": true,
 			"data": gin.H{
@@ -101,42 +120,49 @@ func main() {
 		})
 	})
 
-	// Routes
+	// Routes. Applicant-facing endpoints (submit, status, active period and
+	// document upload) stay public; everything else requires a permission.
+	keys := jwtutil.NewKeySet(cfg.JWTSigningAlg, cfg.JWTAccessSecret, cfg.JWKSURL)
+	authn := middleware.Auth(keys, cfg.JWTIssuer, cfg.JWTAudience, redis.Raw())
+	if cfg.TrustGateway {
+		// Behind the gateway the signed identity headers replace the bearer token
+		authn = identity.Gin([]byte(cfg.GatewaySecret), redis.Raw())
+	}
 	v1 := r.Group("/api/v1/admission")
 	periods := v1.Group("/periods")
 	{
-		periods.POST("", h.Create)
-		periods.GET("", h.List)
-		periods.GET("/active", h.GetActive)
-		periods.GET("/:id", h.GetByID)
-		periods.PUT("/:id", h.Update)
-		periods.DELETE("/:id", h.Delete)
-		periods.POST("/:id/calculate-final-scores", appHandler.CalculateFinalScores)
-		periods.POST("/:id/announce", h.AnnounceResults)
+		periods.POST("", authn, limit, perm(authz.AdmissionPeriodWrite), h.Create)
+		periods.GET("", authn, limit, perm(authz.AdmissionPeriodRead), h.List)
+		periods.GET("/active", limit, h.GetActive)
+		periods.GET("/:id", authn, limit, perm(authz.AdmissionPeriodRead), h.GetByID)
+		periods.PUT("/:id", authn, limit, perm(authz.AdmissionPeriodWrite), h.Update)
+		periods.DELETE("/:id", authn, limit, perm(authz.AdmissionPeriodDelete), h.Delete)
+		periods.POST("/:id/calculate-final-scores", authn, limit, perm(authz.ApplicationScore), appHandler.CalculateFinalScores)
+		periods.POST("/:id/announce", authn, limit, perm(authz.AdmissionAnnounce), h.AnnounceResults)
 	}
 
 	applications := v1.Group("/applications")
 	{
-		applications.POST("", appHandler.Submit)
-		applications.GET("/status", appHandler.GetStatus)
-		applications.GET("", appHandler.List)
-		applications.PUT("/:id/verify", appHandler.Verify)
-		applications.POST("/:id/test-score", appHandler.InputTestScore)
-		applications.POST("/:id/interview-score", appHandler.InputInterviewScore)
-		applications.POST("/:id/register", appHandler.Register)
+		applications.POST("", limit, appHandler.Submit)
+		applications.GET("/status", limit, appHandler.GetStatus)
+		applications.GET("", authn, limit, perm(authz.ApplicationRead), appHandler.List)
+		applications.PUT("/:id/verify", authn, limit, perm(authz.ApplicationVerify), appHandler.Verify)
+		applications.POST("/:id/test-score", authn, limit, perm(authz.ApplicationScore), appHandler.InputTestScore)
+		applications.POST("/:id/interview-score", authn, limit, perm(authz.ApplicationScore), appHandler.InputInterviewScore)
+		applications.POST("/:id/register", authn, limit, perm(authz.ApplicationRegister), appHandler.Register)
 
 		// Document routes
-		applications.POST("/:id/documents", docHandler.Upload)
-		applications.GET("/:id/documents", docHandler.GetByApplicationID)
+		applications.POST("/:id/documents", limit, docHandler.Upload)
+		applications.GET("/:id/documents", authn, limit, perm(authz.ApplicationRead), docHandler.GetByApplicationID)
 	}
 
 	documents := v1.Group("/documents")
 	{
-		documents.DELETE("/:id", docHandler.Delete)
+		documents.DELETE("/:id", authn, limit, perm(authz.ApplicationDocDelete), docHandler.Delete)
 	}
 
 	// Prometheus metrics
-	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	r.GET("/metrics", limit, gin.WrapH(promhttp.Handler()))
 
 	port := os.Getenv("APP_HTTP_PORT")
 	if port == "" {
@@ -8,6 +8,7 @@ import (
 
 type AdmissionPeriod struct {
 	ID        uuid.UUID `json:"id"`
+	TenantID  string    `json:"tenant_id"`
 	Name      string    `json:"name"`
 	StartDate time.Time `json:"start_date"`
 	EndDate   time.Time `json:"end_date"`
@@ -43,17 +43,17 @@ func (m *MockAdmissionPeriodUseCase) EXPECT() *MockAdmissionPeriodUseCaseMockRec
 }
 
 // AnnounceResults mocks base method.
-func (m *MockAdmissionPeriodUseCase) AnnounceResults(ctx context.Context, id uuid.UUID, passingGrade float64) error {
+func (m *MockAdmissionPeriodUseCase) AnnounceResults(ctx context.Context, tenantID string, id uuid.UUID, passingGrade float64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AnnounceResults", ctx, id, passingGrade)
+	ret := m.ctrl.Call(m, "AnnounceResults", ctx, tenantID, id, passingGrade)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AnnounceResults indicates an expected call of AnnounceResults.
-func (mr *MockAdmissionPeriodUseCaseMockRecorder) AnnounceResults(ctx, id, passingGrade any) *gomock.Call {
+func (mr *MockAdmissionPeriodUseCaseMockRecorder) AnnounceResults(ctx, tenantID, id, passingGrade any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnnounceResults", reflect.TypeOf((*MockAdmissionPeriodUseCase)(nil).AnnounceResults), ctx, id, passingGrade)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnnounceResults", reflect.TypeOf((*MockAdmissionPeriodUseCase)(nil).AnnounceResults), ctx, tenantID, id, passingGrade)
 }
 
 // Create mocks base method.
@@ -71,17 +71,17 @@ func (mr *MockAdmissionPeriodUseCaseMockRecorder) Create(ctx, period any) *gomoc
 }
 
 // Delete mocks base method.
-func (m *MockAdmissionPeriodUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockAdmissionPeriodUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockAdmissionPeriodUseCaseMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockAdmissionPeriodUseCaseMockRecorder) Delete(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAdmissionPeriodUseCase)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAdmissionPeriodUseCase)(nil).Delete), ctx, tenantID, id)
 }
 
 // GetActive mocks base method.
@@ -100,18 +100,18 @@ func (mr *MockAdmissionPeriodUseCaseMockRecorder) GetActive(ctx any) *gomock.Cal
 }
 
 // GetByID mocks base method.
-func (m *MockAdmissionPeriodUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.AdmissionPeriod, error) {
+func (m *MockAdmissionPeriodUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.AdmissionPeriod, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
 	ret0, _ := ret[0].(*entity.AdmissionPeriod)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockAdmissionPeriodUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockAdmissionPeriodUseCaseMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAdmissionPeriodUseCase)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAdmissionPeriodUseCase)(nil).GetByID), ctx, tenantID, id)
 }
 
 // List mocks base method.
@@ -75,6 +75,21 @@ func (mr *MockApplicationRepositoryMockRecorder) GetByID(ctx, id interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByIDForUpdate mocks base method.
+func (m *MockApplicationRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockApplicationRepositoryMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockApplicationRepository)(nil).GetByIDForUpdate), ctx, id)
+}
+
 // GetByRegistrationNumber mocks base method.
 func (m *MockApplicationRepository) GetByRegistrationNumber(ctx context.Context, regNum string) (*entity.Application, error) {
 	m.ctrl.T.Helper()
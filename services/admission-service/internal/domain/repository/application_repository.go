@@ -2,15 +2,24 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/entity"
 )
 
+// ErrAdmissionPeriodNotFound is returned when an application names a period
+// that does not exist.
+var ErrAdmissionPeriodNotFound = errors.New("admission period not found")
+
 type ApplicationRepository interface {
+	// Create files the application under the tenant of its admission period.
 	Create(ctx context.Context, application *entity.Application) error
 	Update(ctx context.Context, application *entity.Application) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Application, error)
+	// GetByIDForUpdate is GetByID, holding a row lock until the transaction
+	// on ctx ends.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Application, error)
 	GetByRegistrationNumber(ctx context.Context, regNum string) (*entity.Application, error)
 	List(ctx context.Context, filter map[string]interface{}) ([]*entity.Application, error)
 	Delete(ctx context.Context, id uuid.UUID) error
@@ -9,10 +9,10 @@ import (
 
 type AdmissionPeriodUseCase interface {
 	Create(ctx context.Context, period *entity.AdmissionPeriod) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.AdmissionPeriod, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.AdmissionPeriod, error)
 	GetActive(ctx context.Context) (*entity.AdmissionPeriod, error)
 	List(ctx context.Context) ([]*entity.AdmissionPeriod, error)
 	Update(ctx context.Context, period *entity.AdmissionPeriod) error
-	Delete(ctx context.Context, id uuid.UUID) error
-	AnnounceResults(ctx context.Context, id uuid.UUID, passingGrade float64) error
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
+	AnnounceResults(ctx context.Context, tenantID string, id uuid.UUID, passingGrade float64) error
 }
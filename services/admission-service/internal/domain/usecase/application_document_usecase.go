@@ -10,6 +10,6 @@ import (
 
 type ApplicationDocumentUseCase interface {
 	Upload(ctx context.Context, applicationID uuid.UUID, documentType string, file *multipart.FileHeader) (*entity.ApplicationDocument, error)
-	GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*entity.ApplicationDocument, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	GetByApplicationID(ctx context.Context, tenantID string, applicationID uuid.UUID) ([]*entity.ApplicationDocument, error)
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
 }
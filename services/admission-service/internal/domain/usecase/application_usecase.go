@@ -10,10 +10,10 @@ import (
 type ApplicationUseCase interface {
 	SubmitApplication(ctx context.Context, application *entity.Application) (*entity.Application, error)
 	GetApplicationStatus(ctx context.Context, registrationNumber string) (*entity.Application, error)
-	VerifyApplication(ctx context.Context, id uuid.UUID, status entity.ApplicationStatus) error
+	VerifyApplication(ctx context.Context, tenantID string, id uuid.UUID, status entity.ApplicationStatus) error
 	ListApplications(ctx context.Context, filter map[string]interface{}) ([]*entity.Application, error)
-	InputTestScore(ctx context.Context, id uuid.UUID, score float64) error
-	InputInterviewScore(ctx context.Context, id uuid.UUID, score float64) error
-	CalculateFinalScores(ctx context.Context, periodID uuid.UUID) error
-	RegisterStudent(ctx context.Context, id uuid.UUID) error
+	InputTestScore(ctx context.Context, tenantID string, id uuid.UUID, score float64) error
+	InputInterviewScore(ctx context.Context, tenantID string, id uuid.UUID, score float64) error
+	CalculateFinalScores(ctx context.Context, tenantID string, periodID uuid.UUID) error
+	RegisterStudent(ctx context.Context, tenantID string, id uuid.UUID) error
 }
@@ -0,0 +1,10 @@
+package usecase
+
+import "errors"
+
+var (
+	// ErrApplicationNotFound is returned for an application that does not
+	// exist or belongs to another tenant; handlers answer both with 404.
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrDocumentNotFound    = errors.New("document not found")
+)
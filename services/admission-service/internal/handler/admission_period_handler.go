@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/entity"
+	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/repository"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
 )
@@ -20,6 +22,10 @@ func NewAdmissionPeriodHandler(useCase usecase.AdmissionPeriodUseCase) *Admissio
 }
 
 func (h *AdmissionPeriodHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
 		Name      string    `json:"name" binding:"required"`
 		StartDate time.Time `json:"start_date" binding:"required"`
@@ -33,6 +39,7 @@ func (h *AdmissionPeriodHandler) Create(c *gin.Context) {
 	}
 
 	period := &entity.AdmissionPeriod{
+		TenantID:  tenantID,
 		Name:      req.Name,
 		StartDate: req.StartDate,
 		EndDate:   req.EndDate,
@@ -48,6 +55,10 @@ func (h *AdmissionPeriodHandler) Create(c *gin.Context) {
 }
 
 func (h *AdmissionPeriodHandler) AnnounceResults(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -63,7 +74,12 @@ func (h *AdmissionPeriodHandler) AnnounceResults(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.AnnounceResults(c.Request.Context(), id, req.PassingGrade); err != nil {
+	err = h.useCase.AnnounceResults(c.Request.Context(), tenantID, id, req.PassingGrade)
+	if errors.Is(err, repository.ErrAdmissionPeriodNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Admission period not found")
+		return
+	}
+	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -72,6 +88,10 @@ func (h *AdmissionPeriodHandler) AnnounceResults(c *gin.Context) {
 }
 
 func (h *AdmissionPeriodHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -79,7 +99,7 @@ func (h *AdmissionPeriodHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	period, err := h.useCase.GetByID(c.Request.Context(), id)
+	period, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -117,6 +137,10 @@ func (h *AdmissionPeriodHandler) List(c *gin.Context) {
 }
 
 func (h *AdmissionPeriodHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -136,7 +160,7 @@ func (h *AdmissionPeriodHandler) Update(c *gin.Context) {
 		return
 	}
 
-	period, err := h.useCase.GetByID(c.Request.Context(), id)
+	period, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -160,6 +184,10 @@ func (h *AdmissionPeriodHandler) Update(c *gin.Context) {
 }
 
 func (h *AdmissionPeriodHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -167,7 +195,12 @@ func (h *AdmissionPeriodHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	err = h.useCase.Delete(c.Request.Context(), tenantID, id)
+	if errors.Is(err, repository.ErrAdmissionPeriodNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Admission period not found")
+		return
+	}
+	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -13,7 +13,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/mocks"
+	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/repository"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/handler"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -41,6 +43,7 @@ func TestAdmissionPeriodHandler_Create(t *testing.T) {
 
 		mockUseCase.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, p *entity.AdmissionPeriod) error {
 			assert.Equal(t, "PPDB 2025", p.Name)
+			assert.Equal(t, "tenant-1", p.TenantID)
 			assert.True(t, p.IsActive)
 			return nil
 		})
@@ -49,6 +52,7 @@ func TestAdmissionPeriodHandler_Create(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/admission-periods", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "tenant-1"})
 
 		h.Create(c)
 
@@ -65,12 +69,26 @@ func TestAdmissionPeriodHandler_Create(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/admission-periods", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "tenant-1"})
 
 		h.Create(c)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
+	t.Run("unauthenticated", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{"name": "PPDB 2025", "start_date": now, "end_date": nextWeek})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/admission-periods", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		h.Create(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
 	t.Run("usecase error", func(t *testing.T) {
 		reqBody := map[string]interface{}{
 			"name":       "PPDB 2025",
@@ -86,6 +104,7 @@ func TestAdmissionPeriodHandler_Create(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/admission-periods", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "tenant-1"})
 
 		h.Create(c)
 
@@ -105,11 +124,12 @@ func TestAdmissionPeriodHandler_GetByID(t *testing.T) {
 	id := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		expected := &entity.AdmissionPeriod{ID: id, Name: "PPDB 2025"}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(expected, nil)
+		expected := &entity.AdmissionPeriod{ID: id, TenantID: "tenant-1", Name: "PPDB 2025"}
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(expected, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "tenant-1"})
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/admission-periods/"+id.String(), nil)
 
@@ -119,10 +139,11 @@ func TestAdmissionPeriodHandler_GetByID(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "tenant-1"})
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/admission-periods/"+id.String(), nil)
 
@@ -132,6 +153,32 @@ func TestAdmissionPeriodHandler_GetByID(t *testing.T) {
 	})
 }
 
+func TestAdmissionPeriodHandler_Delete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockAdmissionPeriodUseCase(ctrl)
+	h := handler.NewAdmissionPeriodHandler(mockUseCase)
+
+	id := uuid.New()
+
+	t.Run("other tenant", func(t *testing.T) {
+		mockUseCase.EXPECT().Delete(gomock.Any(), "tenant-2", id).Return(repository.ErrAdmissionPeriodNotFound)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "tenant-2"})
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+		c.Request, _ = http.NewRequest(http.MethodDelete, "/admission-periods/"+id.String(), nil)
+
+		h.Delete(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
 func TestAdmissionPeriodHandler_List(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -38,6 +39,10 @@ func (h *ApplicationDocumentHandler) Upload(c *gin.Context) {
 	}
 
 	doc, err := h.useCase.Upload(c.Request.Context(), applicationID, documentType, file)
+	if errors.Is(err, usecase.ErrApplicationNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Application not found")
+		return
+	}
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to upload document", err.Error())
 		return
@@ -47,6 +52,10 @@ func (h *ApplicationDocumentHandler) Upload(c *gin.Context) {
 }
 
 func (h *ApplicationDocumentHandler) GetByApplicationID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	applicationID, err := uuid.Parse(idStr)
 	if err != nil {
@@ -54,7 +63,11 @@ func (h *ApplicationDocumentHandler) GetByApplicationID(c *gin.Context) {
 		return
 	}
 
-	docs, err := h.useCase.GetByApplicationID(c.Request.Context(), applicationID)
+	docs, err := h.useCase.GetByApplicationID(c.Request.Context(), tenantID, applicationID)
+	if errors.Is(err, usecase.ErrApplicationNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Application not found")
+		return
+	}
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to fetch documents", err.Error())
 		return
@@ -64,6 +77,10 @@ func (h *ApplicationDocumentHandler) GetByApplicationID(c *gin.Context) {
 }
 
 func (h *ApplicationDocumentHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -71,7 +88,12 @@ func (h *ApplicationDocumentHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	err = h.useCase.Delete(c.Request.Context(), tenantID, id)
+	if errors.Is(err, usecase.ErrDocumentNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Document not found")
+		return
+	}
+	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to delete document", err.Error())
 		return
 	}
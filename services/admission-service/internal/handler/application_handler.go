@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/entity"
+	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/repository"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
 )
@@ -51,6 +53,10 @@ func (h *ApplicationHandler) Submit(c *gin.Context) {
 	}
 
 	submittedApp, err := h.useCase.SubmitApplication(c.Request.Context(), application)
+	if errors.Is(err, repository.ErrAdmissionPeriodNotFound) {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+		return
+	}
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -80,6 +86,10 @@ func (h *ApplicationHandler) GetStatus(c *gin.Context) {
 }
 
 func (h *ApplicationHandler) Verify(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -95,7 +105,12 @@ func (h *ApplicationHandler) Verify(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.VerifyApplication(c.Request.Context(), id, entity.ApplicationStatus(req.Status)); err != nil {
+	err = h.useCase.VerifyApplication(c.Request.Context(), tenantID, id, entity.ApplicationStatus(req.Status))
+	if errors.Is(err, usecase.ErrApplicationNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Application not found")
+		return
+	}
+	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -104,7 +119,11 @@ func (h *ApplicationHandler) Verify(c *gin.Context) {
 }
 
 func (h *ApplicationHandler) List(c *gin.Context) {
-	filter := make(map[string]interface{})
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	filter := map[string]interface{}{"tenant_id": tenantID}
 	
 	if periodID := c.Query("admission_period_id"); periodID != "" {
 		if id, err := uuid.Parse(periodID); err == nil {
@@ -126,6 +145,10 @@ func (h *ApplicationHandler) List(c *gin.Context) {
 }
 
 func (h *ApplicationHandler) InputTestScore(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -141,7 +164,12 @@ func (h *ApplicationHandler) InputTestScore(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.InputTestScore(c.Request.Context(), id, req.Score); err != nil {
+	err = h.useCase.InputTestScore(c.Request.Context(), tenantID, id, req.Score)
+	if errors.Is(err, usecase.ErrApplicationNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Application not found")
+		return
+	}
+	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -150,6 +178,10 @@ func (h *ApplicationHandler) InputTestScore(c *gin.Context) {
 }
 
 func (h *ApplicationHandler) InputInterviewScore(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -165,7 +197,12 @@ func (h *ApplicationHandler) InputInterviewScore(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.InputInterviewScore(c.Request.Context(), id, req.Score); err != nil {
+	err = h.useCase.InputInterviewScore(c.Request.Context(), tenantID, id, req.Score)
+	if errors.Is(err, usecase.ErrApplicationNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Application not found")
+		return
+	}
+	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -174,6 +211,10 @@ func (h *ApplicationHandler) InputInterviewScore(c *gin.Context) {
 }
 
 func (h *ApplicationHandler) CalculateFinalScores(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id") // periodID
 	periodID, err := uuid.Parse(idStr)
 	if err != nil {
@@ -181,7 +222,7 @@ func (h *ApplicationHandler) CalculateFinalScores(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.CalculateFinalScores(c.Request.Context(), periodID); err != nil {
+	if err := h.useCase.CalculateFinalScores(c.Request.Context(), tenantID, periodID); err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -190,6 +231,10 @@ func (h *ApplicationHandler) CalculateFinalScores(c *gin.Context) {
 }
 
 func (h *ApplicationHandler) Register(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -197,7 +242,12 @@ func (h *ApplicationHandler) Register(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.RegisterStudent(c.Request.Context(), id); err != nil {
+	err = h.useCase.RegisterStudent(c.Request.Context(), tenantID, id)
+	if errors.Is(err, usecase.ErrApplicationNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Application not found")
+		return
+	}
+	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,7 +35,7 @@ func TestRateLimitByPolicy(t *testing.T) {
 	t.Run("allowed request", func(t *testing.T) {
 		limiter := &mockLimiter{count: 0}
 		r := gin.New()
-		r.Use(RateLimitByPolicy(limiter, 10, 10, nil))
+		r.Use(RateLimitByPolicy(redisutil.NewFixedWindowLimiter(limiter), 10, 10, nil, nil))
 		r.GET("/", func(c *gin.Context) {
 			c.Status(http.StatusOK)
 		})
@@ -49,7 +50,7 @@ func TestRateLimitByPolicy(t *testing.T) {
 	t.Run("blocked request", func(t *testing.T) {
 		limiter := &mockLimiter{count: 11} // Exceeds default limit of 10
 		r := gin.New()
-		r.Use(RateLimitByPolicy(limiter, 10, 10, nil))
+		r.Use(RateLimitByPolicy(redisutil.NewFixedWindowLimiter(limiter), 10, 10, nil, nil))
 		r.GET("/", func(c *gin.Context) {
 			c.Status(http.StatusOK)
 		})
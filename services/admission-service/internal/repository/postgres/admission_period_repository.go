@@ -24,9 +24,9 @@ func NewAdmissionPeriodRepository(db *pgxpool.Pool) repository.AdmissionPeriodRe
 func (r *admissionPeriodRepository) Create(ctx context.Context, period *entity.AdmissionPeriod) error {
 	query := `
 		INSERT INTO admission_periods (
-			id, name, start_date, end_date, is_active, is_announced, created_at, updated_at
+			id, tenant_id, name, start_date, end_date, is_active, is_announced, created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
 		)
 	`
 	if period.ID == uuid.Nil {
@@ -41,7 +41,7 @@ func (r *admissionPeriodRepository) Create(ctx context.Context, period *entity.A
 	}
 
 	_, err := r.db.Exec(ctx, query,
-		period.ID, period.Name, period.StartDate, period.EndDate, period.IsActive, period.IsAnnounced,
+		period.ID, period.TenantID, period.Name, period.StartDate, period.EndDate, period.IsActive, period.IsAnnounced,
 		period.CreatedAt, period.UpdatedAt,
 	)
 	return err
@@ -51,13 +51,13 @@ func (r *admissionPeriodRepository) Create(ctx context.Context, period *entity.A
 func (r *admissionPeriodRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AdmissionPeriod, error) {
 	query := `
 		SELECT 
-			id, name, start_date, end_date, is_active, is_announced, created_at, updated_at
+			id, tenant_id, name, start_date, end_date, is_active, is_announced, created_at, updated_at
 		FROM admission_periods 
 		WHERE id = $1
 	`
 	var period entity.AdmissionPeriod
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&period.ID, &period.Name, &period.StartDate, &period.EndDate, &period.IsActive, &period.IsAnnounced,
+		&period.ID, &period.TenantID, &period.Name, &period.StartDate, &period.EndDate, &period.IsActive, &period.IsAnnounced,
 		&period.CreatedAt, &period.UpdatedAt,
 	)
 	if err != nil {
@@ -73,14 +73,14 @@ func (r *admissionPeriodRepository) GetByID(ctx context.Context, id uuid.UUID) (
 func (r *admissionPeriodRepository) GetActive(ctx context.Context) (*entity.AdmissionPeriod, error) {
 	query := `
 		SELECT 
-			id, name, start_date, end_date, is_active, is_announced, created_at, updated_at
+			id, tenant_id, name, start_date, end_date, is_active, is_announced, created_at, updated_at
 		FROM admission_periods 
 		WHERE is_active = true
 		LIMIT 1
 	`
 	var period entity.AdmissionPeriod
 	err := r.db.QueryRow(ctx, query).Scan(
-		&period.ID, &period.Name, &period.StartDate, &period.EndDate, &period.IsActive, &period.IsAnnounced,
+		&period.ID, &period.TenantID, &period.Name, &period.StartDate, &period.EndDate, &period.IsActive, &period.IsAnnounced,
 		&period.CreatedAt, &period.UpdatedAt,
 	)
 	if err != nil {
@@ -96,7 +96,7 @@ func (r *admissionPeriodRepository) GetActive(ctx context.Context) (*entity.Admi
 func (r *admissionPeriodRepository) List(ctx context.Context) ([]*entity.AdmissionPeriod, error) {
 	query := `
 		SELECT 
-			id, name, start_date, end_date, is_active, is_announced, created_at, updated_at
+			id, tenant_id, name, start_date, end_date, is_active, is_announced, created_at, updated_at
 		FROM admission_periods
 		ORDER BY start_date DESC
 	`
@@ -110,7 +110,7 @@ func (r *admissionPeriodRepository) List(ctx context.Context) ([]*entity.Admissi
 	for rows.Next() {
 		var period entity.AdmissionPeriod
 		err := rows.Scan(
-			&period.ID, &period.Name, &period.StartDate, &period.EndDate, &period.IsActive, &period.IsAnnounced,
+			&period.ID, &period.TenantID, &period.Name, &period.StartDate, &period.EndDate, &period.IsActive, &period.IsAnnounced,
 			&period.CreatedAt, &period.UpdatedAt,
 		)
 		if err != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 )
 
 type applicationRepository struct {
@@ -22,23 +23,25 @@ func NewApplicationRepository(db *pgxpool.Pool) repository.ApplicationRepository
 }
 
 func (r *applicationRepository) Create(ctx context.Context, application *entity.Application) error {
+	// Applicants are not signed in, so the tenant comes from the period
+	// rather than from the request.
 	query := `
 		INSERT INTO applications (
 			id, tenant_id, admission_period_id, registration_number, first_name, last_name, 
 			email, phone_number, status, previous_school, average_score, 
 			submission_date, test_score, interview_score, final_score, created_at, updated_at
-		) VALUES (
-			$1, $2, $3, $4, $5, $6, 
-			$7, $8, $9, $10, $11, 
-			$12, $13, $14, $15, $16, $17
 		)
+		SELECT
+			$1, p.tenant_id, p.id, $3, $4, $5,
+			$6, $7, $8, $9, $10,
+			$11, $12, $13, $14, $15, $16
+		FROM admission_periods p
+		WHERE p.id = $2
+		RETURNING tenant_id
 	`
 	if application.ID == uuid.Nil {
 		application.ID = uuid.New()
 	}
-	if application.TenantID == "" {
-		application.TenantID = "default"
-	}
 	now := time.Now()
 	if application.CreatedAt.IsZero() {
 		application.CreatedAt = now
@@ -47,11 +50,14 @@ func (r *applicationRepository) Create(ctx context.Context, application *entity.
 		application.UpdatedAt = now
 	}
 
-	_, err := r.db.Exec(ctx, query,
-		application.ID, application.TenantID, application.AdmissionPeriodID, application.RegistrationNumber, application.FirstName, application.LastName,
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query,
+		application.ID, application.AdmissionPeriodID, application.RegistrationNumber, application.FirstName, application.LastName,
 		application.Email, application.PhoneNumber, application.Status, application.PreviousSchool, application.AverageScore,
 		application.SubmissionDate, application.TestScore, application.InterviewScore, application.FinalScore, application.CreatedAt, application.UpdatedAt,
-	)
+	).Scan(&application.TenantID)
+	if err == pgx.ErrNoRows {
+		return repository.ErrAdmissionPeriodNotFound
+	}
 	return err
 }
 
@@ -65,7 +71,7 @@ func (r *applicationRepository) Update(ctx context.Context, application *entity.
 	`
 	application.UpdatedAt = time.Now()
 
-	_, err := r.db.Exec(ctx, query,
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query,
 		application.ID, application.AdmissionPeriodID, application.RegistrationNumber, application.FirstName, application.LastName,
 		application.Email, application.PhoneNumber, application.Status, application.PreviousSchool, application.AverageScore,
 		application.SubmissionDate, application.TestScore, application.InterviewScore, application.FinalScore, application.UpdatedAt,
@@ -74,6 +80,15 @@ func (r *applicationRepository) Update(ctx context.Context, application *entity.
 }
 
 func (r *applicationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Application, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate locks the row until the transaction on ctx ends.
+func (r *applicationRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Application, error) {
+	return r.getByID(ctx, id, "FOR UPDATE")
+}
+
+func (r *applicationRepository) getByID(ctx context.Context, id uuid.UUID, lock string) (*entity.Application, error) {
 	query := `
 		SELECT 
 			id, tenant_id, admission_period_id, registration_number, first_name, last_name, 
@@ -81,9 +96,9 @@ func (r *applicationRepository) GetByID(ctx context.Context, id uuid.UUID) (*ent
 			submission_date, test_score, interview_score, final_score, created_at, updated_at
 		FROM applications 
 		WHERE id = $1
-	`
+	` + lock
 	var application entity.Application
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
 		&application.ID, &application.TenantID, &application.AdmissionPeriodID, &application.RegistrationNumber, &application.FirstName, &application.LastName,
 		&application.Email, &application.PhoneNumber, &application.Status, &application.PreviousSchool, &application.AverageScore,
 		&application.SubmissionDate, &application.TestScore, &application.InterviewScore, &application.FinalScore, &application.CreatedAt, &application.UpdatedAt,
@@ -107,7 +122,7 @@ func (r *applicationRepository) GetByRegistrationNumber(ctx context.Context, reg
 		WHERE registration_number = $1
 	`
 	var application entity.Application
-	err := r.db.QueryRow(ctx, query, regNum).Scan(
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, regNum).Scan(
 		&application.ID, &application.AdmissionPeriodID, &application.RegistrationNumber, &application.FirstName, &application.LastName,
 		&application.Email, &application.PhoneNumber, &application.Status, &application.PreviousSchool, &application.AverageScore,
 		&application.SubmissionDate, &application.TestScore, &application.InterviewScore, &application.FinalScore, &application.CreatedAt, &application.UpdatedAt,
@@ -124,7 +139,7 @@ func (r *applicationRepository) GetByRegistrationNumber(ctx context.Context, reg
 func (r *applicationRepository) List(ctx context.Context, filter map[string]interface{}) ([]*entity.Application, error) {
 	query := `
 		SELECT 
-			id, admission_period_id, registration_number, first_name, last_name, 
+			id, tenant_id, admission_period_id, registration_number, first_name, last_name, 
 			email, phone_number, status, previous_school, average_score, 
 			submission_date, test_score, interview_score, final_score, created_at, updated_at
 		FROM applications
@@ -134,6 +149,12 @@ func (r *applicationRepository) List(ctx context.Context, filter map[string]inte
 	var args []interface{}
 	argCount := 1
 
+	if tenantID, ok := filter["tenant_id"]; ok {
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argCount))
+		args = append(args, tenantID)
+		argCount++
+	}
+
 	if periodID, ok := filter["admission_period_id"]; ok {
 		conditions = append(conditions, fmt.Sprintf("admission_period_id = $%d", argCount))
 		args = append(args, periodID)
@@ -152,7 +173,7 @@ func (r *applicationRepository) List(ctx context.Context, filter map[string]inte
 	
 	query += " ORDER BY created_at DESC"
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -162,7 +183,7 @@ func (r *applicationRepository) List(ctx context.Context, filter map[string]inte
 	for rows.Next() {
 		var application entity.Application
 		err := rows.Scan(
-			&application.ID, &application.AdmissionPeriodID, &application.RegistrationNumber, &application.FirstName, &application.LastName,
+			&application.ID, &application.TenantID, &application.AdmissionPeriodID, &application.RegistrationNumber, &application.FirstName, &application.LastName,
 			&application.Email, &application.PhoneNumber, &application.Status, &application.PreviousSchool, &application.AverageScore,
 			&application.SubmissionDate, &application.TestScore, &application.InterviewScore, &application.FinalScore, &application.CreatedAt, &application.UpdatedAt,
 		)
@@ -176,6 +197,6 @@ func (r *applicationRepository) List(ctx context.Context, filter map[string]inte
 
 func (r *applicationRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM applications WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query, id)
 	return err
 }
@@ -41,10 +41,15 @@ func (u *admissionPeriodUseCase) Create(ctx context.Context, period *entity.Admi
 	return u.repo.Create(ctx, period)
 }
 
-func (u *admissionPeriodUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.AdmissionPeriod, error) {
+func (u *admissionPeriodUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.AdmissionPeriod, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
-	return u.repo.GetByID(ctx, id)
+
+	period, err := u.repo.GetByID(ctx, id)
+	if err != nil || period == nil || period.TenantID != tenantID {
+		return nil, err
+	}
+	return period, nil
 }
 
 func (u *admissionPeriodUseCase) GetActive(ctx context.Context) (*entity.AdmissionPeriod, error) {
@@ -72,13 +77,21 @@ func (u *admissionPeriodUseCase) Update(ctx context.Context, period *entity.Admi
 	return u.repo.Update(ctx, period)
 }
 
-func (u *admissionPeriodUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *admissionPeriodUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
+	period, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if period == nil {
+		return repository.ErrAdmissionPeriodNotFound
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	return u.repo.Delete(ctx, id)
 }
 
-func (u *admissionPeriodUseCase) AnnounceResults(ctx context.Context, id uuid.UUID, passingGrade float64) error {
+func (u *admissionPeriodUseCase) AnnounceResults(ctx context.Context, tenantID string, id uuid.UUID, passingGrade float64) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
@@ -86,8 +99,8 @@ func (u *admissionPeriodUseCase) AnnounceResults(ctx context.Context, id uuid.UU
 	if err != nil {
 		return err
 	}
-	if period == nil {
-		return errors.New("admission period not found")
+	if period == nil || period.TenantID != tenantID {
+		return repository.ErrAdmissionPeriodNotFound
 	}
 
 	if period.IsAnnounced {
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/mocks"
+	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/repository"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -62,13 +63,21 @@ func TestAdmissionPeriodUseCase_GetByID(t *testing.T) {
 	id := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		expected := &entity.AdmissionPeriod{ID: id}
+		expected := &entity.AdmissionPeriod{ID: id, TenantID: "tenant-1"}
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(expected, nil)
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), "tenant-1", id)
 		assert.NoError(t, err)
 		assert.Equal(t, expected, res)
 	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.AdmissionPeriod{ID: id, TenantID: "tenant-2"}, nil)
+
+		res, err := u.GetByID(context.Background(), "tenant-1", id)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
 }
 
 func TestAdmissionPeriodUseCase_GetActive(t *testing.T) {
@@ -151,17 +160,28 @@ func TestAdmissionPeriodUseCase_Delete(t *testing.T) {
 
 	id := uuid.New()
 
+	period := &entity.AdmissionPeriod{ID: id, TenantID: "tenant-1"}
+
 	t.Run("success", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(period, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
 
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), "tenant-1", id)
 		assert.NoError(t, err)
 	})
 
 	t.Run("error", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(period, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(errors.New("db error"))
 
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), "tenant-1", id)
 		assert.Error(t, err)
 	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(period, nil)
+
+		err := u.Delete(context.Background(), "tenant-2", id)
+		assert.ErrorIs(t, err, repository.ErrAdmissionPeriodNotFound)
+	})
 }
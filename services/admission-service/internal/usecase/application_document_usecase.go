@@ -49,7 +49,7 @@ func (u *applicationDocumentUseCase) Upload(ctx context.Context, applicationID u
 		return nil, err
 	}
 	if app == nil {
-		return nil, errors.New("application not found")
+		return nil, domainUseCase.ErrApplicationNotFound
 	}
 
 	// 2. Validate File
@@ -98,17 +98,26 @@ func (u *applicationDocumentUseCase) Upload(ctx context.Context, applicationID u
 	return doc, nil
 }
 
-func (u *applicationDocumentUseCase) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*entity.ApplicationDocument, error) {
+func (u *applicationDocumentUseCase) GetByApplicationID(ctx context.Context, tenantID string, applicationID uuid.UUID) ([]*entity.ApplicationDocument, error) {
+	if err := u.checkTenant(ctx, tenantID, applicationID); err != nil {
+		return nil, err
+	}
 	return u.repo.GetByApplicationID(ctx, applicationID)
 }
 
-func (u *applicationDocumentUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *applicationDocumentUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	doc, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 	if doc == nil {
-		return errors.New("document not found")
+		return domainUseCase.ErrDocumentNotFound
+	}
+	if err := u.checkTenant(ctx, tenantID, doc.ApplicationID); err != nil {
+		if errors.Is(err, domainUseCase.ErrApplicationNotFound) {
+			return domainUseCase.ErrDocumentNotFound
+		}
+		return err
 	}
 
 	// Delete from DB
@@ -127,3 +136,16 @@ func (u *applicationDocumentUseCase) Delete(ctx context.Context, id uuid.UUID) e
 
 	return nil
 }
+
+// checkTenant reports ErrApplicationNotFound unless the application exists in
+// tenantID.
+func (u *applicationDocumentUseCase) checkTenant(ctx context.Context, tenantID string, applicationID uuid.UUID) error {
+	app, err := u.applicationRepo.GetByID(ctx, applicationID)
+	if err != nil {
+		return err
+	}
+	if app == nil || app.TenantID != tenantID {
+		return domainUseCase.ErrApplicationNotFound
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/mocks"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -124,24 +125,36 @@ func TestApplicationDocumentUseCase_GetByApplicationID(t *testing.T) {
 	ctx := context.Background()
 	appID := uuid.New()
 
+	app := &entity.Application{ID: appID, TenantID: "tenant-1"}
+
 	t.Run("Success", func(t *testing.T) {
 		expectedDocs := []*entity.ApplicationDocument{
 			{ID: uuid.New(), ApplicationID: appID},
 		}
+		mockAppRepo.EXPECT().GetByID(ctx, appID).Return(app, nil)
 		mockDocRepo.EXPECT().GetByApplicationID(ctx, appID).Return(expectedDocs, nil)
 
-		docs, err := u.GetByApplicationID(ctx, appID)
+		docs, err := u.GetByApplicationID(ctx, "tenant-1", appID)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedDocs, docs)
 	})
 
 	t.Run("Error", func(t *testing.T) {
+		mockAppRepo.EXPECT().GetByID(ctx, appID).Return(app, nil)
 		mockDocRepo.EXPECT().GetByApplicationID(ctx, appID).Return(nil, errors.New("db error"))
 
-		docs, err := u.GetByApplicationID(ctx, appID)
+		docs, err := u.GetByApplicationID(ctx, "tenant-1", appID)
 		assert.Error(t, err)
 		assert.Nil(t, docs)
 	})
+
+	t.Run("OtherTenant", func(t *testing.T) {
+		mockAppRepo.EXPECT().GetByID(ctx, appID).Return(app, nil)
+
+		docs, err := u.GetByApplicationID(ctx, "tenant-2", appID)
+		assert.ErrorIs(t, err, domainUseCase.ErrApplicationNotFound)
+		assert.Nil(t, docs)
+	})
 }
 
 func TestApplicationDocumentUseCase_Delete(t *testing.T) {
@@ -159,6 +172,8 @@ func TestApplicationDocumentUseCase_Delete(t *testing.T) {
 	u := usecase.NewApplicationDocumentUseCase(mockDocRepo, mockAppRepo, tmpDir)
 	ctx := context.Background()
 	docID := uuid.New()
+	appID := uuid.New()
+	app := &entity.Application{ID: appID, TenantID: "tenant-1"}
 
 	t.Run("Success", func(t *testing.T) {
 		// Create a dummy file to be deleted
@@ -168,14 +183,16 @@ func TestApplicationDocumentUseCase_Delete(t *testing.T) {
 		assert.NoError(t, err)
 
 		doc := &entity.ApplicationDocument{
-			ID:      docID,
-			FileURL: "/uploads/" + filename,
+			ID:            docID,
+			ApplicationID: appID,
+			FileURL:       "/uploads/" + filename,
 		}
 		
 		mockDocRepo.EXPECT().GetByID(ctx, docID).Return(doc, nil)
+		mockAppRepo.EXPECT().GetByID(ctx, appID).Return(app, nil)
 		mockDocRepo.EXPECT().Delete(ctx, docID).Return(nil)
 
-		err = u.Delete(ctx, docID)
+		err = u.Delete(ctx, "tenant-1", docID)
 		assert.NoError(t, err)
 		
 		// Verify file is deleted
@@ -186,17 +203,27 @@ func TestApplicationDocumentUseCase_Delete(t *testing.T) {
 	t.Run("NotFound", func(t *testing.T) {
 		mockDocRepo.EXPECT().GetByID(ctx, docID).Return(nil, nil)
 
-		err := u.Delete(ctx, docID)
+		err := u.Delete(ctx, "tenant-1", docID)
 		assert.Error(t, err)
 		assert.Equal(t, "document not found", err.Error())
 	})
 
+	t.Run("OtherTenant", func(t *testing.T) {
+		doc := &entity.ApplicationDocument{ID: docID, ApplicationID: appID}
+		mockDocRepo.EXPECT().GetByID(ctx, docID).Return(doc, nil)
+		mockAppRepo.EXPECT().GetByID(ctx, appID).Return(app, nil)
+
+		err := u.Delete(ctx, "tenant-2", docID)
+		assert.ErrorIs(t, err, domainUseCase.ErrDocumentNotFound)
+	})
+
 	t.Run("DBError", func(t *testing.T) {
-		doc := &entity.ApplicationDocument{ID: docID}
+		doc := &entity.ApplicationDocument{ID: docID, ApplicationID: appID}
 		mockDocRepo.EXPECT().GetByID(ctx, docID).Return(doc, nil)
+		mockAppRepo.EXPECT().GetByID(ctx, appID).Return(app, nil)
 		mockDocRepo.EXPECT().Delete(ctx, docID).Return(errors.New("db error"))
 
-		err := u.Delete(ctx, docID)
+		err := u.Delete(ctx, "tenant-1", docID)
 		assert.Error(t, err)
 		assert.Equal(t, "db error", err.Error())
 	})
@@ -12,19 +12,19 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/repository"
 	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/usecase"
-	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/rabbit"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 )
 
 type applicationUseCase struct {
 	applicationRepo repository.ApplicationRepository
-	rabbitClient    *rabbit.Client
+	events          outbox.Events
 	contextTimeout  time.Duration
 }
 
-func NewApplicationUseCase(applicationRepo repository.ApplicationRepository, rabbitClient *rabbit.Client, timeout time.Duration) domainUseCase.ApplicationUseCase {
+func NewApplicationUseCase(applicationRepo repository.ApplicationRepository, events outbox.Events, timeout time.Duration) domainUseCase.ApplicationUseCase {
 	return &applicationUseCase{
 		applicationRepo: applicationRepo,
-		rabbitClient:    rabbitClient,
+		events:          events,
 		contextTimeout:  timeout,
 	}
 }
@@ -60,7 +60,7 @@ func (u *applicationUseCase) GetApplicationStatus(ctx context.Context, registrat
 	return u.applicationRepo.GetByRegistrationNumber(ctx, registrationNumber)
 }
 
-func (u *applicationUseCase) VerifyApplication(ctx context.Context, id uuid.UUID, status entity.ApplicationStatus) error {
+func (u *applicationUseCase) VerifyApplication(ctx context.Context, tenantID string, id uuid.UUID, status entity.ApplicationStatus) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
@@ -68,8 +68,8 @@ func (u *applicationUseCase) VerifyApplication(ctx context.Context, id uuid.UUID
 	if err != nil {
 		return err
 	}
-	if app == nil {
-		return errors.New("application not found")
+	if app == nil || app.TenantID != tenantID {
+		return domainUseCase.ErrApplicationNotFound
 	}
 
 	app.Status = status
@@ -83,7 +83,7 @@ func (u *applicationUseCase) ListApplications(ctx context.Context, filter map[st
 	return u.applicationRepo.List(ctx, filter)
 }
 
-func (u *applicationUseCase) InputTestScore(ctx context.Context, id uuid.UUID, score float64) error {
+func (u *applicationUseCase) InputTestScore(ctx context.Context, tenantID string, id uuid.UUID, score float64) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
@@ -91,15 +91,15 @@ func (u *applicationUseCase) InputTestScore(ctx context.Context, id uuid.UUID, s
 	if err != nil {
 		return err
 	}
-	if app == nil {
-		return errors.New("application not found")
+	if app == nil || app.TenantID != tenantID {
+		return domainUseCase.ErrApplicationNotFound
 	}
 
 	app.TestScore = &score
 	return u.applicationRepo.Update(ctx, app)
 }
 
-func (u *applicationUseCase) InputInterviewScore(ctx context.Context, id uuid.UUID, score float64) error {
+func (u *applicationUseCase) InputInterviewScore(ctx context.Context, tenantID string, id uuid.UUID, score float64) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
@@ -107,20 +107,20 @@ func (u *applicationUseCase) InputInterviewScore(ctx context.Context, id uuid.UU
 	if err != nil {
 		return err
 	}
-	if app == nil {
-		return errors.New("application not found")
+	if app == nil || app.TenantID != tenantID {
+		return domainUseCase.ErrApplicationNotFound
 	}
 
 	app.InterviewScore = &score
 	return u.applicationRepo.Update(ctx, app)
 }
 
-func (u *applicationUseCase) CalculateFinalScores(ctx context.Context, periodID uuid.UUID) error {
+func (u *applicationUseCase) CalculateFinalScores(ctx context.Context, tenantID string, periodID uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
 	// Get all applications for the period
-	apps, err := u.applicationRepo.List(ctx, map[string]interface{}{"admission_period_id": periodID})
+	apps, err := u.applicationRepo.List(ctx, map[string]interface{}{"admission_period_id": periodID, "tenant_id": tenantID})
 	if err != nil {
 		return err
 	}
@@ -145,44 +145,49 @@ func (u *applicationUseCase) CalculateFinalScores(ctx context.Context, periodID
 	return nil
 }
 
-func (u *applicationUseCase) RegisterStudent(ctx context.Context, id uuid.UUID) error {
+func (u *applicationUseCase) RegisterStudent(ctx context.Context, tenantID string, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	app, err := u.applicationRepo.GetByID(ctx, id)
-	if err != nil {
-		return err
-	}
-	if app == nil {
-		return errors.New("application not found")
-	}
-
-	if app.Status == entity.ApplicationStatusRegistered {
-		return errors.New("student already registered")
-	}
+	return u.events.Transact(ctx, func(ctx context.Context) error {
+		// The row stays locked until commit, so a concurrent registration
+		// waits and then sees the application registered
+		app, err := u.applicationRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if app == nil || app.TenantID != tenantID {
+			return domainUseCase.ErrApplicationNotFound
+		}
 
-	if app.Status != entity.ApplicationStatusAccepted {
-		return errors.New("application must be accepted to register")
-	}
+		if app.Status == entity.ApplicationStatusRegistered {
+			return errors.New("student already registered")
+		}
 
-	// Publish event to create User and Student records
-	eventPayload := map[string]interface{}{
-		"tenant_id":           app.TenantID,
-		"application_id":      app.ID,
-		"registration_number": app.RegistrationNumber,
-		"first_name":          app.FirstName,
-		"last_name":           app.LastName,
-		"email":               app.Email,
-		"phone_number":        app.PhoneNumber,
-		"timestamp":           time.Now(),
-	}
+		if app.Status != entity.ApplicationStatusAccepted {
+			return errors.New("application must be accepted to register")
+		}
 
-	if u.rabbitClient != nil {
-		if err := u.rabbitClient.PublishJSON("sisfo.events", "admission.student.registered", eventPayload); err != nil {
-			return fmt.Errorf("failed to publish registration event: %w", err)
+		// The event creating the User and Student records is only relayed
+		// once the application is marked registered
+		eventPayload := map[string]interface{}{
+			"tenant_id":           app.TenantID,
+			"application_id":      app.ID,
+			"registration_number": app.RegistrationNumber,
+			"first_name":          app.FirstName,
+			"last_name":           app.LastName,
+			"email":               app.Email,
+			"phone_number":        app.PhoneNumber,
+			"timestamp":           time.Now(),
 		}
-	}
 
-	app.Status = entity.ApplicationStatusRegistered
-	return u.applicationRepo.Update(ctx, app)
+		app.Status = entity.ApplicationStatusRegistered
+		if err := u.applicationRepo.Update(ctx, app); err != nil {
+			return err
+		}
+		if err := u.events.Enqueue(ctx, "sisfo.events", "admission.student.registered", eventPayload); err != nil {
+			return fmt.Errorf("failed to enqueue registration event: %w", err)
+		}
+		return nil
+	})
 }
@@ -9,7 +9,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/mocks"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox/outboxtest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -20,7 +22,7 @@ func TestApplicationUseCase_ListApplications(t *testing.T) {
 
 	mockRepo := mocks.NewMockApplicationRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewApplicationUseCase(mockRepo, nil, timeout)
+	u := usecase.NewApplicationUseCase(mockRepo, &outboxtest.Recorder{}, timeout)
 
 	ctx := context.Background()
 	filter := map[string]interface{}{"status": "submitted"}
@@ -50,31 +52,38 @@ func TestApplicationUseCase_InputTestScore(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockApplicationRepository(ctrl)
-	u := usecase.NewApplicationUseCase(mockRepo, nil, 2*time.Second)
+	u := usecase.NewApplicationUseCase(mockRepo, &outboxtest.Recorder{}, 2*time.Second)
 
 	ctx := context.Background()
 	id := uuid.New()
 	score := 85.5
 
 	t.Run("Success", func(t *testing.T) {
-		app := &entity.Application{ID: id}
+		app := &entity.Application{ID: id, TenantID: "tenant-1"}
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(app, nil)
 		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, a *entity.Application) error {
 			assert.Equal(t, score, *a.TestScore)
 			return nil
 		})
 
-		err := u.InputTestScore(ctx, id, score)
+		err := u.InputTestScore(ctx, "tenant-1", id, score)
 		assert.NoError(t, err)
 	})
 
 	t.Run("NotFound", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil)
 
-		err := u.InputTestScore(ctx, id, score)
+		err := u.InputTestScore(ctx, "tenant-1", id, score)
 		assert.Error(t, err)
 		assert.Equal(t, "application not found", err.Error())
 	})
+
+	t.Run("OtherTenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.Application{ID: id, TenantID: "tenant-2"}, nil)
+
+		err := u.InputTestScore(ctx, "tenant-1", id, score)
+		assert.ErrorIs(t, err, domainUseCase.ErrApplicationNotFound)
+	})
 }
 
 func TestApplicationUseCase_InputInterviewScore(t *testing.T) {
@@ -82,21 +91,21 @@ func TestApplicationUseCase_InputInterviewScore(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockApplicationRepository(ctrl)
-	u := usecase.NewApplicationUseCase(mockRepo, nil, 2*time.Second)
+	u := usecase.NewApplicationUseCase(mockRepo, &outboxtest.Recorder{}, 2*time.Second)
 
 	ctx := context.Background()
 	id := uuid.New()
 	score := 90.0
 
 	t.Run("Success", func(t *testing.T) {
-		app := &entity.Application{ID: id}
+		app := &entity.Application{ID: id, TenantID: "tenant-1"}
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(app, nil)
 		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, a *entity.Application) error {
 			assert.Equal(t, score, *a.InterviewScore)
 			return nil
 		})
 
-		err := u.InputInterviewScore(ctx, id, score)
+		err := u.InputInterviewScore(ctx, "tenant-1", id, score)
 		assert.NoError(t, err)
 	})
 }
@@ -106,7 +115,7 @@ func TestApplicationUseCase_CalculateFinalScores(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockApplicationRepository(ctrl)
-	u := usecase.NewApplicationUseCase(mockRepo, nil, 2*time.Second)
+	u := usecase.NewApplicationUseCase(mockRepo, &outboxtest.Recorder{}, 2*time.Second)
 
 	ctx := context.Background()
 	periodID := uuid.New()
@@ -125,7 +134,7 @@ func TestApplicationUseCase_CalculateFinalScores(t *testing.T) {
 			AverageScore:   avgScore,
 		}
 
-		mockRepo.EXPECT().List(gomock.Any(), map[string]interface{}{"admission_period_id": periodID}).Return([]*entity.Application{app}, nil)
+		mockRepo.EXPECT().List(gomock.Any(), map[string]interface{}{"admission_period_id": periodID, "tenant_id": "tenant-1"}).Return([]*entity.Application{app}, nil)
 		
 		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, a *entity.Application) error {
 			assert.NotNil(t, a.FinalScore)
@@ -133,7 +142,7 @@ func TestApplicationUseCase_CalculateFinalScores(t *testing.T) {
 			return nil
 		})
 
-		err := u.CalculateFinalScores(ctx, periodID)
+		err := u.CalculateFinalScores(ctx, "tenant-1", periodID)
 		assert.NoError(t, err)
 	})
 
@@ -142,7 +151,7 @@ func TestApplicationUseCase_CalculateFinalScores(t *testing.T) {
 		mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]*entity.Application{app}, nil)
 		// Expect no Update call
 
-		err := u.CalculateFinalScores(ctx, periodID)
+		err := u.CalculateFinalScores(ctx, "tenant-1", periodID)
 		assert.NoError(t, err)
 	})
 }
@@ -152,47 +161,82 @@ func TestApplicationUseCase_RegisterStudent(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockApplicationRepository(ctrl)
-	u := usecase.NewApplicationUseCase(mockRepo, nil, 2*time.Second)
+	events := &outboxtest.Recorder{}
+	u := usecase.NewApplicationUseCase(mockRepo, events, 2*time.Second)
 
 	ctx := context.Background()
 	id := uuid.New()
 
 	t.Run("Success", func(t *testing.T) {
 		app := &entity.Application{
-			ID:     id,
-			Status: entity.ApplicationStatusAccepted,
+			ID:       id,
+			TenantID: "tenant-1",
+			Status:   entity.ApplicationStatusAccepted,
 		}
-		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(app, nil)
+		mockRepo.EXPECT().GetByIDForUpdate(gomock.Any(), id).Return(app, nil)
 		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, a *entity.Application) error {
 			assert.Equal(t, entity.ApplicationStatusRegistered, a.Status)
 			return nil
 		})
 
-		err := u.RegisterStudent(ctx, id)
+		err := u.RegisterStudent(ctx, "tenant-1", id)
 		assert.NoError(t, err)
+		if assert.Len(t, events.Events(), 1) {
+			assert.Equal(t, "admission.student.registered", events.Events()[0].RoutingKey)
+		}
+	})
+
+	t.Run("UpdateFails", func(t *testing.T) {
+		app := &entity.Application{
+			ID:       id,
+			TenantID: "tenant-1",
+			Status:   entity.ApplicationStatusAccepted,
+		}
+		mockRepo.EXPECT().GetByIDForUpdate(gomock.Any(), id).Return(app, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+
+		err := u.RegisterStudent(ctx, "tenant-1", id)
+		assert.Error(t, err)
+		// Nothing beyond the earlier registration was enqueued
+		assert.Len(t, events.Events(), 1)
 	})
 
 	t.Run("NotAccepted", func(t *testing.T) {
 		app := &entity.Application{
-			ID:     id,
-			Status: entity.ApplicationStatusSubmitted,
+			ID:       id,
+			TenantID: "tenant-1",
+			Status:   entity.ApplicationStatusSubmitted,
 		}
-		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(app, nil)
+		mockRepo.EXPECT().GetByIDForUpdate(gomock.Any(), id).Return(app, nil)
 
-		err := u.RegisterStudent(ctx, id)
+		err := u.RegisterStudent(ctx, "tenant-1", id)
 		assert.Error(t, err)
 		assert.Equal(t, "application must be accepted to register", err.Error())
 	})
 
 	t.Run("AlreadyRegistered", func(t *testing.T) {
 		app := &entity.Application{
-			ID:     id,
-			Status: entity.ApplicationStatusRegistered,
+			ID:       id,
+			TenantID: "tenant-1",
+			Status:   entity.ApplicationStatusRegistered,
 		}
-		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(app, nil)
+		mockRepo.EXPECT().GetByIDForUpdate(gomock.Any(), id).Return(app, nil)
 
-		err := u.RegisterStudent(ctx, id)
+		err := u.RegisterStudent(ctx, "tenant-1", id)
 		assert.Error(t, err)
 		assert.Equal(t, "student already registered", err.Error())
 	})
+
+	t.Run("OtherTenant", func(t *testing.T) {
+		app := &entity.Application{
+			ID:       id,
+			TenantID: "tenant-2",
+			Status:   entity.ApplicationStatusAccepted,
+		}
+		mockRepo.EXPECT().GetByIDForUpdate(gomock.Any(), id).Return(app, nil)
+
+		err := u.RegisterStudent(ctx, "tenant-1", id)
+		assert.ErrorIs(t, err, domainUseCase.ErrApplicationNotFound)
+		assert.Equal(t, entity.ApplicationStatusAccepted, app.Status)
+	})
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/domain/mocks"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/handler"
 	"github.com/jjaenal/sisfo-akademik-backend/services/admission-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox/outboxtest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -29,7 +30,7 @@ func TestApplicationIntegration(t *testing.T) {
 	mockRepo := mocks.NewMockApplicationRepository(ctrl)
 
 	// Real UseCase with Mock Repo and nil RabbitClient
-	u := usecase.NewApplicationUseCase(mockRepo, nil, time.Second*2)
+	u := usecase.NewApplicationUseCase(mockRepo, &outboxtest.Recorder{}, time.Second*2)
 
 	// Real Handler with Real UseCase
 	h := handler.NewApplicationHandler(u)
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	httpx "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+)
+
+const (
+	// apiKeyTokenSkew drops a cached token this long before it expires so it
+	// never reaches an upstream already stale.
+	apiKeyTokenSkew = 30 * time.Second
+	// maxCachedAPIKeys triggers a sweep of expired tokens and rejections.
+	maxCachedAPIKeys = 1024
+	// apiKeyRejectTTL is how long a rejected key is turned away without
+	// asking auth-service again.
+	apiKeyRejectTTL = 30 * time.Second
+	// apiKeyVerifyLimit is how many keys one client address may have
+	// auth-service verify a minute, so guessed keys cannot flood it.
+	apiKeyVerifyLimit = 20
+)
+
+var errAPIKeyRejected = errors.New("api key rejected")
+
+// apiKeyAuth lets machine clients send X-API-Key instead of a bearer token.
+// The key is exchanged with auth-service for a short-lived access token that
+// is cached per key until its token version is superseded, and every key has
+// its own per-minute request budget. Rejected keys are remembered briefly and
+// each client address may only have so many keys verified.
+type apiKeyAuth struct {
+	verifyURL      string
+	client         *http.Client
+	limiter        redisutil.RateLimiter
+	versions       redisutil.KV
+	trustedProxies []netip.Prefix
+	now            func() time.Time
+
+	mu       sync.Mutex
+	tokens   map[string]cachedAPIKeyToken
+	rejected map[string]time.Time
+}
+
+type cachedAPIKeyToken struct {
+	authz.APIKeyVerifyResponse
+	validUntil time.Time
+}
+
+// newAPIKeyAuth returns nil without an auth-service upstream; a nil
+// *apiKeyAuth leaves requests untouched. limiter may be nil to disable the
+// per-key and per-address budgets. versions holds the token versions
+// auth-service publishes; without it a revoked key keeps working until its
+// cached token expires.
+func newAPIKeyAuth(authUpstreams []string, limiter redisutil.RateLimiter, versions redisutil.KV, trustedProxies []netip.Prefix) *apiKeyAuth {
+	if len(authUpstreams) == 0 {
+		return nil
+	}
+	return &apiKeyAuth{
+		verifyURL:      strings.TrimRight(authUpstreams[0], "/") + authz.APIKeyVerifyPath,
+		client:         &http.Client{Timeout: 5 * time.Second},
+		limiter:        limiter,
+		versions:       versions,
+		trustedProxies: trustedProxies,
+		now:            time.Now,
+		tokens:         map[string]cachedAPIKeyToken{},
+		rejected:       map[string]time.Time{},
+	}
+}
+
+// wrap turns an X-API-Key into the bearer token next expects. Requests that
+// already carry an Authorization header are passed on as they are.
+func (a *apiKeyAuth) wrap(next http.Handler) http.Handler {
+	if a == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(authz.APIKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		r = r.Clone(r.Context())
+		r.Header.Del(authz.APIKeyHeader)
+		if r.Header.Get("Authorization") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		sum := sha256.Sum256([]byte(key))
+		id := hex.EncodeToString(sum[:])
+		tok, ok := a.cached(r.Context(), id)
+		if !ok {
+			if a.rejectedRecently(id) {
+				httpx.Error(w, http.StatusUnauthorized, "2001", "Unauthorized", "invalid api key")
+				return
+			}
+			if a.limiter != nil {
+				rl, err := a.limiter.Allow(r.Context(), "ratelimit:apikey_verify:"+middleware.ClientIP(r, a.trustedProxies), apiKeyVerifyLimit, time.Minute)
+				if err != nil {
+					httpx.Error(w, http.StatusInternalServerError, "1001", "Internal error", nil)
+					return
+				}
+				if !rl.Allowed {
+					middleware.SetRateLimitHeaders(w, rl, time.Minute)
+					middleware.TooManyRequests(w, rl)
+					return
+				}
+			}
+			var err error
+			tok, err = a.exchange(r.Context(), id, key)
+			if errors.Is(err, errAPIKeyRejected) {
+				httpx.Error(w, http.StatusUnauthorized, "2001", "Unauthorized", "invalid api key")
+				return
+			}
+			if err != nil {
+				httpx.Error(w, http.StatusBadGateway, "6002", "Upstream error", nil)
+				return
+			}
+		}
+		if a.limiter != nil {
+			rl, err := a.limiter.Allow(r.Context(), "ratelimit:apikey:"+tok.KeyID.String(), tok.RateLimit, time.Minute)
+			if err != nil {
+				httpx.Error(w, http.StatusInternalServerError, "1001", "Internal error", nil)
+				return
+			}
+			middleware.SetRateLimitHeaders(w, rl, time.Minute)
+			if !rl.Allowed {
+				middleware.TooManyRequests(w, rl)
+				return
+			}
+		}
+		// Route limits count against the key rather than its service account
+		r = r.WithContext(middleware.WithRateLimitSubject(r.Context(), "apikey:"+tok.KeyID.String()))
+		r.Header.Set("Authorization", "Bearer "+tok.AccessToken)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// cached returns the token held for the key hashed to id, if it is neither
+// expired nor superseded.
+func (a *apiKeyAuth) cached(ctx context.Context, id string) (*authz.APIKeyVerifyResponse, bool) {
+	a.mu.Lock()
+	cached, ok := a.tokens[id]
+	a.mu.Unlock()
+	if ok && a.now().Before(cached.validUntil) && a.current(ctx, cached) {
+		return &cached.APIKeyVerifyResponse, true
+	}
+	return nil, false
+}
+
+func (a *apiKeyAuth) rejectedRecently(id string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	until, ok := a.rejected[id]
+	return ok && a.now().Before(until)
+}
+
+// exchange has auth-service verify the key and caches the outcome.
+func (a *apiKeyAuth) exchange(ctx context.Context, id, key string) (*authz.APIKeyVerifyResponse, error) {
+	now := a.now()
+	res, err := a.verify(ctx, key)
+	if errors.Is(err, errAPIKeyRejected) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		if len(a.rejected) >= maxCachedAPIKeys {
+			for k, until := range a.rejected {
+				if !now.Before(until) {
+					delete(a.rejected, k)
+				}
+			}
+		}
+		if len(a.rejected) < maxCachedAPIKeys {
+			a.rejected[id] = now.Add(apiKeyRejectTTL)
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	validUntil := now.Add(time.Duration(res.ExpiresIn)*time.Second - apiKeyTokenSkew)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.rejected, id)
+	if len(a.tokens) >= maxCachedAPIKeys {
+		for k, t := range a.tokens {
+			if !now.Before(t.validUntil) {
+				delete(a.tokens, k)
+			}
+		}
+	}
+	if validUntil.After(now) && len(a.tokens) < maxCachedAPIKeys {
+		a.tokens[id] = cachedAPIKeyToken{APIKeyVerifyResponse: *res, validUntil: validUntil}
+	}
+	return res, nil
+}
+
+// current reports whether t still carries its service account's token
+// version. Revoking a key, or any role change of the account, bumps it.
+func (a *apiKeyAuth) current(ctx context.Context, t cachedAPIKeyToken) bool {
+	ok, err := middleware.TokenVersionValid(ctx, a.versions, jwtutil.Claims{UserID: t.UserID, TokenVersion: t.TokenVersion})
+	return err == nil && ok
+}
+
+func (a *apiKeyAuth) verify(ctx context.Context, key string) (*authz.APIKeyVerifyResponse, error) {
+	body, err := json.Marshal(authz.APIKeyVerifyRequest{Key: key})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.verifyURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, errAPIKeyRejected
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	var out struct {
+		Success bool                       `json:"success"`
+		Data    authz.APIKeyVerifyResponse `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	if !out.Success || out.Data.AccessToken == "" {
+		return nil, errAPIKeyRejected
+	}
+	return &out.Data, nil
+}
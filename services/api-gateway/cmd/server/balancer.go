@@ -0,0 +1,444 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	httpx "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	strategyRoundRobin = "round_robin"
+	strategyLeastConn  = "least_conn"
+	strategyWeighted   = "weighted"
+)
+
+var (
+	upstreamBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gateway_upstream_breaker_state",
+		Help: "Circuit breaker state per upstream: 0 closed, 1 half-open, 2 open.",
+	}, []string{"pool", "upstream"})
+	upstreamBreakerTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_upstream_breaker_transitions_total",
+		Help: "Circuit breaker state changes per upstream.",
+	}, []string{"pool", "upstream", "state"})
+	upstreamHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gateway_upstream_healthy",
+		Help: "1 while the upstream passes active health checks.",
+	}, []string{"pool", "upstream"})
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerHalfOpen
+	breakerOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerHalfOpen:
+		return "half_open"
+	case breakerOpen:
+		return "open"
+	}
+	return "closed"
+}
+
+// breaker opens after threshold consecutive failures. Once openFor has passed
+// it lets a single probe through (half-open), whose outcome closes or reopens
+// it. A probe that never reports back is replaced after another openFor.
+type breaker struct {
+	mu          sync.Mutex
+	state       breakerState
+	failures    int
+	openedUntil time.Time
+	probeAt     time.Time
+	threshold   int
+	openFor     time.Duration
+	onChange    func(breakerState)
+}
+
+func newBreaker(threshold int, openFor time.Duration) *breaker {
+	return &breaker{threshold: threshold, openFor: openFor}
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	switch b.state {
+	case breakerOpen:
+		if now.Before(b.openedUntil) {
+			return false
+		}
+		b.set(breakerHalfOpen)
+	case breakerHalfOpen:
+		if now.Sub(b.probeAt) < b.openFor {
+			return false
+		}
+	default:
+		return true
+	}
+	b.probeAt = now
+	return true
+}
+
+func (b *breaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	if b.state == breakerHalfOpen {
+		b.set(breakerClosed)
+	}
+}
+
+func (b *breaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerHalfOpen:
+		b.trip()
+	case breakerClosed:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.trip()
+		}
+	}
+}
+
+func (b *breaker) current() breakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *breaker) trip() {
+	b.failures = 0
+	b.openedUntil = time.Now().Add(b.openFor)
+	b.set(breakerOpen)
+}
+
+func (b *breaker) set(s breakerState) {
+	b.state = s
+	if b.onChange != nil {
+		b.onChange(s)
+	}
+}
+
+type recWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rw *recWriter) WriteHeader(code int) {
+	rw.status = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap lets the proxy reach the Flusher of the underlying writer.
+func (rw *recWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+type upstream struct {
+	target  *url.URL
+	proxy   *httputil.ReverseProxy
+	breaker *breaker
+	weight  int
+	current int // smooth weighted round-robin, guarded by pool.mu
+	active  atomic.Int64
+	healthy atomic.Bool
+	fails   int // consecutive failed health checks, owned by the checker
+}
+
+// pool balances requests over the instances of one upstream. Instances that
+// fail active health checks or whose breaker is open are skipped.
+type pool struct {
+	name     string
+	strategy string
+	check    healthCheck
+	ups      []*upstream
+	next     atomic.Uint32
+	mu       sync.Mutex
+	cancel   context.CancelFunc
+	budget   *retryBudget
+}
+
+func newPool(name string, spec upstreamPool, urls []string) *pool {
+	p := &pool{name: name, strategy: spec.Strategy, check: spec.HealthCheck.withDefaults(), budget: newRetryBudget(spec.RetryBudget)}
+	threshold, openFor := spec.Breaker.withDefaults()
+	connect, responseHeader := spec.Timeouts.withDefaults()
+	// Shared by the pool's instances; the default transport would wait for
+	// a hung instance forever
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           (&net.Dialer{Timeout: connect, KeepAlive: 30 * time.Second}).DialContext,
+		MaxIdleConnsPerHost:   32,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   connect,
+		ResponseHeaderTimeout: responseHeader,
+		ExpectContinueTimeout: time.Second,
+	}
+	for i, raw := range urls {
+		target, err := url.Parse(raw)
+		if err != nil {
+			log.Printf("skip invalid upstream for %s: %s, err=%v", name, raw, err)
+			continue
+		}
+		u := &upstream{target: target, proxy: httputil.NewSingleHostReverseProxy(target), weight: 1}
+		u.proxy.Transport = transport
+		if i < len(spec.Weights) {
+			u.weight = spec.Weights[i]
+		}
+		u.healthy.Store(true)
+		upstreamHealthy.WithLabelValues(name, target.String()).Set(1)
+		u.breaker = newBreaker(threshold, openFor)
+		u.breaker.onChange = func(s breakerState) {
+			upstreamBreakerState.WithLabelValues(name, target.String()).Set(float64(s))
+			upstreamBreakerTransitions.WithLabelValues(name, target.String(), s.String()).Inc()
+		}
+		upstreamBreakerState.WithLabelValues(name, target.String()).Set(float64(breakerClosed))
+		u.proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, e error) {
+			var ne net.Error
+			if errors.Is(e, context.DeadlineExceeded) || (errors.As(e, &ne) && ne.Timeout()) {
+				httpx.Error(w, http.StatusGatewayTimeout, "6002", "Upstream timeout", []map[string]string{
+					{"upstream": target.String()},
+				})
+				return
+			}
+			httpx.Error(w, http.StatusBadGateway, "6002", "Upstream error", []map[string]string{
+				{"upstream": target.String(), "error": e.Error()},
+			})
+		}
+		p.ups = append(p.ups, u)
+	}
+	return p
+}
+
+// newRoundRobinProxy balances over upstreams with passive breakers only.
+func newRoundRobinProxy(name string, upstreams []string) http.Handler {
+	return newPool(name, upstreamPool{}, upstreams)
+}
+
+func (p *pool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := p.pick()
+	if u == nil {
+		httpx.Error(w, http.StatusServiceUnavailable, "1001", "No healthy upstreams", nil)
+		return
+	}
+	p.forward(u, w, r)
+}
+
+// forward sends r to u and feeds the outcome to its breaker. Requests the
+// client (or a winning hedge) cancelled say nothing about the instance.
+func (p *pool) forward(u *upstream, w http.ResponseWriter, r *http.Request) int {
+	u.active.Add(1)
+	defer u.active.Add(-1)
+	rw := &recWriter{ResponseWriter: w, status: http.StatusOK}
+	u.proxy.ServeHTTP(rw, r)
+	switch {
+	case errors.Is(r.Context().Err(), context.Canceled):
+	case rw.status >= 500:
+		u.breaker.recordFailure()
+	default:
+		u.breaker.recordSuccess()
+	}
+	return rw.status
+}
+
+// pick returns the first instance in strategy order that is healthy, not in
+// tried, and whose breaker lets the request through.
+func (p *pool) pick(tried ...*upstream) *upstream {
+	for _, u := range p.order() {
+		if !slices.Contains(tried, u) && u.healthy.Load() && u.breaker.allow() {
+			return u
+		}
+	}
+	return nil
+}
+
+// available reports whether pick could find an instance outside tried,
+// without claiming a half-open probe.
+func (p *pool) available(tried []*upstream) bool {
+	for _, u := range p.ups {
+		if !slices.Contains(tried, u) && u.healthy.Load() && u.breaker.current() != breakerOpen {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *pool) order() []*upstream {
+	n := len(p.ups)
+	if n == 0 {
+		return nil
+	}
+	start := int(p.next.Add(1)) % n
+	out := append(slices.Clone(p.ups[start:]), p.ups[:start]...)
+	switch p.strategy {
+	case strategyLeastConn:
+		// Snapshot the counts so the ordering stays consistent while sorting
+		active := make(map[*upstream]int64, n)
+		for _, u := range out {
+			active[u] = u.active.Load()
+		}
+		sort.SliceStable(out, func(i, j int) bool { return active[out[i]] < active[out[j]] })
+	case strategyWeighted:
+		if best := p.weighted(); best != nil {
+			i := slices.Index(out, best)
+			out = append([]*upstream{best}, slices.Delete(out, i, i+1)...)
+		}
+	}
+	return out
+}
+
+// weighted is nginx's smooth weighted round-robin over healthy instances.
+func (p *pool) weighted() *upstream {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	total := 0
+	var best *upstream
+	for _, u := range p.ups {
+		if !u.healthy.Load() || u.breaker.current() == breakerOpen {
+			continue
+		}
+		u.current += u.weight
+		total += u.weight
+		if best == nil || u.current > best.current {
+			best = u
+		}
+	}
+	if best != nil {
+		best.current -= total
+	}
+	return best
+}
+
+// start runs active health checks until close is called.
+func (p *pool) start() {
+	if p.check.Disabled || len(p.ups) == 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	go func() {
+		client := &http.Client{Timeout: p.check.Timeout}
+		t := time.NewTicker(p.check.Interval)
+		defer t.Stop()
+		for {
+			for _, u := range p.ups {
+				p.probe(ctx, client, u)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+		}
+	}()
+}
+
+func (p *pool) close() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+// probe ejects an instance after UnhealthyThreshold failed checks in a row
+// and readmits it on the first passing one.
+func (p *pool) probe(ctx context.Context, client *http.Client, u *upstream) {
+	ok := false
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.target.JoinPath(p.check.Path).String(), nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = client.Do(req); err == nil {
+			_ = res.Body.Close()
+			ok = res.StatusCode == http.StatusOK
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	if ok {
+		u.fails = 0
+	} else {
+		u.fails++
+	}
+	healthy := ok || (u.healthy.Load() && u.fails < p.check.UnhealthyThreshold)
+	if u.healthy.Swap(healthy) != healthy {
+		log.Printf("upstream %s of %s healthy=%v", u.target, p.name, healthy)
+	}
+	v := 0.0
+	if healthy {
+		v = 1
+	}
+	upstreamHealthy.WithLabelValues(p.name, u.target.String()).Set(v)
+}
+
+// poolSet keeps upstream pools alive across route reloads, so a pool whose
+// definition did not change keeps its health and breaker state. Pools no
+// longer referenced are closed once a reload has succeeded.
+type poolSet struct {
+	mu    sync.Mutex
+	pools map[string]*pool
+	used  map[string]bool
+}
+
+func newPoolSet() *poolSet {
+	return &poolSet{pools: map[string]*pool{}, used: map[string]bool{}}
+}
+
+// begin starts collecting the pools used by a new set of routes.
+func (s *poolSet) begin() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.used = map[string]bool{}
+}
+
+// get returns the running pool for spec, starting one if needed. It returns
+// nil when the pool has no instances configured.
+func (s *poolSet) get(name string, spec upstreamPool) *pool {
+	urls := spec.urls()
+	if len(urls) == 0 {
+		return nil
+	}
+	key := fmt.Sprintf("%s|%v|%v", name, urls, spec)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.pools[key]
+	if !ok {
+		p = newPool(name, spec, urls)
+		p.start()
+		s.pools[key] = p
+	}
+	s.used[key] = true
+	return p
+}
+
+// sweep closes the pools not used since begin.
+func (s *poolSet) sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, p := range s.pools {
+		if !s.used[key] {
+			p.close()
+			delete(s.pools, key)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	b := newBreaker(1, 50*time.Millisecond)
+	b.recordFailure()
+	if b.allow() {
+		t.Fatal("expected open breaker to reject")
+	}
+	time.Sleep(60 * time.Millisecond)
+	if !b.allow() {
+		t.Fatal("expected a probe after openFor")
+	}
+	if b.allow() {
+		t.Fatal("only one probe may be in flight")
+	}
+	b.recordFailure()
+	if b.current() != breakerOpen || b.allow() {
+		t.Fatal("failed probe must reopen the breaker")
+	}
+	time.Sleep(60 * time.Millisecond)
+	if !b.allow() {
+		t.Fatal("expected a second probe")
+	}
+	b.recordSuccess()
+	if b.current() != breakerClosed || !b.allow() || !b.allow() {
+		t.Fatal("successful probe must close the breaker")
+	}
+}
+
+func TestBreaker_Concurrent(t *testing.T) {
+	b := newBreaker(3, time.Millisecond)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if b.allow() && (i+j)%2 == 0 {
+					b.recordFailure()
+				} else {
+					b.recordSuccess()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestPool_HealthCheckEjects(t *testing.T) {
+	var healthy atomic.Bool
+	healthy.Store(true)
+	var served atomic.Int32
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/health" {
+			if !healthy.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			return
+		}
+		served.Add(1)
+	}))
+	defer flaky.Close()
+	stable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer stable.Close()
+
+	spec := upstreamPool{HealthCheck: healthCheck{Interval: 10 * time.Millisecond, UnhealthyThreshold: 2}}
+	p := newPool("flaky", spec, []string{flaky.URL, stable.URL})
+	p.start()
+	defer p.close()
+
+	healthy.Store(false)
+	waitFor(t, func() bool { return !p.ups[0].healthy.Load() })
+	if testutil.ToFloat64(upstreamHealthy.WithLabelValues("flaky", flaky.URL)) != 0 {
+		t.Fatal("health gauge not updated")
+	}
+	served.Store(0)
+	for i := 0; i < 4; i++ {
+		rr := httptest.NewRecorder()
+		p.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/x", nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("code=%d", rr.Code)
+		}
+	}
+	if served.Load() != 0 {
+		t.Fatalf("ejected upstream served %d requests", served.Load())
+	}
+
+	healthy.Store(true)
+	waitFor(t, func() bool { return p.ups[0].healthy.Load() })
+}
+
+func TestPool_AllDown(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer s.Close()
+	p := newPool("down", upstreamPool{Breaker: breakerConfig{Threshold: 1, OpenFor: time.Minute}}, []string{s.URL})
+	rr := httptest.NewRecorder()
+	p.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	if testutil.ToFloat64(upstreamBreakerState.WithLabelValues("down", s.URL)) != float64(breakerOpen) {
+		t.Fatal("breaker state not exported")
+	}
+	rr = httptest.NewRecorder()
+	p.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("open breaker code=%d want 503", rr.Code)
+	}
+}
+
+func TestPool_LeastConn(t *testing.T) {
+	release := make(chan struct{})
+	hits := map[string]*atomic.Int32{"slow": {}, "fast": {}}
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits["slow"].Add(1)
+		<-release
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits["fast"].Add(1)
+	}))
+	defer fast.Close()
+	p := newPool("lc", upstreamPool{Strategy: strategyLeastConn}, []string{slow.URL, fast.URL})
+
+	// Occupy the slow instance, then every other request must go to fast
+	done := make(chan struct{})
+	go func() {
+		for hits["slow"].Load() == 0 {
+			p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		}
+		close(done)
+	}()
+	waitFor(t, func() bool { return p.ups[0].active.Load() == 1 })
+	before := hits["slow"].Load()
+	for i := 0; i < 5; i++ {
+		p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+	close(release)
+	<-done
+	if hits["slow"].Load() != before {
+		t.Fatal("least_conn sent traffic to the busy instance")
+	}
+}
+
+func TestPool_Weighted(t *testing.T) {
+	var a, b atomic.Int32
+	sa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { a.Add(1) }))
+	defer sa.Close()
+	sb := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { b.Add(1) }))
+	defer sb.Close()
+	p := newPool("w", upstreamPool{Strategy: strategyWeighted, Weights: []int{3, 1}}, []string{sa.URL, sb.URL})
+	for i := 0; i < 8; i++ {
+		p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+	if a.Load() != 6 || b.Load() != 2 {
+		t.Fatalf("weighted split a=%d b=%d want 6/2", a.Load(), b.Load())
+	}
+}
+
+func TestPoolSet_KeepsUnchangedPools(t *testing.T) {
+	s := newPoolSet()
+	spec := upstreamPool{URLs: []string{"http://a.local"}, HealthCheck: healthCheck{Disabled: true}}
+	s.begin()
+	first := s.get("a", spec)
+	s.sweep()
+
+	s.begin()
+	if s.get("a", spec) != first {
+		t.Fatal("unchanged pool must be reused")
+	}
+	spec.Strategy = strategyLeastConn
+	if s.get("a", spec) == first {
+		t.Fatal("changed pool must be rebuilt")
+	}
+	s.sweep()
+	if len(s.pools) != 2 {
+		t.Fatalf("pools=%d want 2", len(s.pools))
+	}
+	s.begin()
+	s.get("a", spec)
+	s.sweep()
+	if len(s.pools) != 1 {
+		t.Fatalf("unused pool not closed, pools=%d", len(s.pools))
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
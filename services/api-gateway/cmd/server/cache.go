@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/rabbit"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// cacheInvalidateRoutingKey events on the sisfo.events exchange purge the
+// cached responses of a route for one tenant. Payload:
+// {"tenant_id": "...", "prefix": "/api/v1/schedules/"}.
+const cacheInvalidateRoutingKey = "gateway.cache.invalidate"
+
+// maxCachedBody bounds the responses kept in Redis; larger ones are served
+// but not stored.
+const maxCachedBody = 1 << 20
+
+var (
+	cacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_cache_lookups_total",
+		Help: "Response cache lookups per route and result (hit, miss).",
+	}, []string{"route", "result"})
+	cacheInvalidations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_cache_invalidations_total",
+		Help: "Response cache purges per route and source (write, event).",
+	}, []string{"route", "source"})
+)
+
+// cachedHeaders are the response headers stored with a cached body.
+var cachedHeaders = []string{"Content-Type", "Content-Encoding", "Content-Language", "Cache-Control", "ETag", "Last-Modified"}
+
+// cacheStore is the part of the Redis client the response cache uses.
+type cacheStore interface {
+	redisutil.KV
+	Incr(ctx context.Context, key string) *redis.IntCmd
+}
+
+type cachedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+	Stored time.Time   `json:"stored"`
+}
+
+// withCache serves GET requests of a route from Redis. Entries are keyed on
+// the caller's tenant and roles, the path and the query, and live under a
+// per tenant and route generation that successful writes through the route
+// (or an invalidation event) bump, so purged entries are never read again
+// and simply expire.
+func withCache(store cacheStore, prefix string, ttl time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tenant, roles := cacheIdentity(r)
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		default:
+			rw := &recWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r)
+			if rw.status < 400 && purgeCache(ctx, store, tenant, prefix) == nil {
+				cacheInvalidations.WithLabelValues(prefix, "write").Inc()
+			}
+			return
+		}
+
+		directives := cacheDirectives(r.Header.Get("Cache-Control"))
+		if _, ok := directives["no-store"]; ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		gen, err := cacheGeneration(ctx, store, tenant, prefix)
+		if err != nil {
+			// Redis is unavailable: serve uncached
+			next.ServeHTTP(w, r)
+			return
+		}
+		key := cacheKey(tenant, prefix, gen, roles, r)
+		if _, revalidate := directives["no-cache"]; !revalidate {
+			if entry, ok := loadCached(ctx, store, key); ok {
+				cacheLookups.WithLabelValues(prefix, "hit").Inc()
+				serveCached(w, r, entry)
+				return
+			}
+		}
+		cacheLookups.WithLabelValues(prefix, "miss").Inc()
+
+		w.Header().Set("X-Cache", "MISS")
+		cw := &cacheWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(cw, r)
+		if d := cacheTTL(cw, ttl); d > 0 {
+			entry := cachedResponse{Status: cw.status, Header: http.Header{}, Body: cw.body, Stored: time.Now()}
+			for _, h := range cachedHeaders {
+				if v := cw.Header().Get(h); v != "" {
+					entry.Header.Set(h, v)
+				}
+			}
+			if entry.Header.Get("ETag") == "" {
+				sum := sha256.Sum256(cw.body)
+				entry.Header.Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+			}
+			if b, err := json.Marshal(entry); err == nil {
+				_ = redisutil.Set(ctx, store, key, string(b), d)
+			}
+		}
+	})
+}
+
+// cacheWriter passes the response through while keeping a copy of the body
+// up to maxCachedBody.
+type cacheWriter struct {
+	http.ResponseWriter
+	status   int
+	body     []byte
+	overflow bool
+}
+
+func (cw *cacheWriter) WriteHeader(code int) {
+	cw.status = code
+	cw.ResponseWriter.WriteHeader(code)
+}
+
+func (cw *cacheWriter) Write(b []byte) (int, error) {
+	if !cw.overflow {
+		if len(cw.body)+len(b) > maxCachedBody {
+			cw.overflow, cw.body = true, nil
+		} else {
+			cw.body = append(cw.body, b...)
+		}
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+func (cw *cacheWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// cacheTTL reports how long a response may be stored: never for errors,
+// private or uncacheable responses, otherwise the route TTL capped by the
+// upstream's s-maxage or max-age.
+func cacheTTL(cw *cacheWriter, ttl time.Duration) time.Duration {
+	h := cw.Header()
+	if cw.status != http.StatusOK || cw.overflow || h.Get("Set-Cookie") != "" || h.Get("Vary") == "*" {
+		return 0
+	}
+	directives := cacheDirectives(h.Get("Cache-Control"))
+	for _, d := range []string{"no-store", "no-cache", "private"} {
+		if _, ok := directives[d]; ok {
+			return 0
+		}
+	}
+	for _, d := range []string{"s-maxage", "max-age"} {
+		if v, ok := directives[d]; ok {
+			secs, err := strconv.Atoi(v)
+			if err != nil {
+				return 0
+			}
+			return min(ttl, time.Duration(secs)*time.Second)
+		}
+	}
+	return ttl
+}
+
+func cacheDirectives(v string) map[string]string {
+	out := map[string]string{}
+	for _, part := range strings.Split(v, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name != "" {
+			out[strings.ToLower(name)] = strings.Trim(value, `"`)
+		}
+	}
+	return out
+}
+
+func serveCached(w http.ResponseWriter, r *http.Request, entry cachedResponse) {
+	h := w.Header()
+	for k, v := range entry.Header {
+		h[k] = v
+	}
+	h.Set("X-Cache", "HIT")
+	h.Set("Age", strconv.Itoa(int(time.Since(entry.Stored).Seconds())))
+	if etagMatches(r.Header.Get("If-None-Match"), entry.Header.Get("ETag")) {
+		h.Del("Content-Type")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.WriteHeader(entry.Status)
+	_, _ = w.Write(entry.Body)
+}
+
+// etagMatches is the weak comparison If-None-Match calls for.
+func etagMatches(header, etag string) bool {
+	if header == "" || etag == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func cacheIdentity(r *http.Request) (tenant, roles string) {
+	claims, ok := r.Context().Value(middleware.ClaimsKey).(jwtutil.Claims)
+	if !ok {
+		return "", ""
+	}
+	sorted := slices.Clone(claims.Roles)
+	slices.Sort(sorted)
+	return claims.TenantID, strings.Join(sorted, ",")
+}
+
+func cacheKey(tenant, prefix, gen, roles string, r *http.Request) string {
+	sum := sha256.Sum256([]byte(roles + "\n" + r.URL.Path + "\n" + r.URL.Query().Encode()))
+	return fmt.Sprintf("gwcache:%s:%s:%s:%s", tenant, prefix, gen, hex.EncodeToString(sum[:16]))
+}
+
+func cacheGenerationKey(tenant, prefix string) string {
+	return "gwcache:gen:" + tenant + ":" + prefix
+}
+
+func cacheGeneration(ctx context.Context, store cacheStore, tenant, prefix string) (string, error) {
+	gen, err := redisutil.Get(ctx, store, cacheGenerationKey(tenant, prefix))
+	if errors.Is(err, redis.Nil) {
+		return "0", nil
+	}
+	return gen, err
+}
+
+func purgeCache(ctx context.Context, store cacheStore, tenant, prefix string) error {
+	return store.Incr(ctx, cacheGenerationKey(tenant, prefix)).Err()
+}
+
+func loadCached(ctx context.Context, store cacheStore, key string) (cachedResponse, bool) {
+	var entry cachedResponse
+	raw, err := redisutil.Get(ctx, store, key)
+	if err != nil || json.Unmarshal([]byte(raw), &entry) != nil {
+		return entry, false
+	}
+	return entry, true
+}
+
+// consumeCacheInvalidations purges cached routes on invalidation events. The
+// queue is shared by all gateway replicas, as the cache itself is.
+func consumeCacheInvalidations(events *rabbit.Client, store cacheStore, l *zap.Logger) {
+	events.Subscribe(context.Background(), rabbit.Subscription{
+		Exchange: "sisfo.events",
+		Queue:    "api-gateway.cache-invalidation",
+		Bindings: []string{cacheInvalidateRoutingKey},
+	}, func(ctx context.Context, m rabbit.Delivery) error {
+		var ev struct {
+			TenantID string `json:"tenant_id"`
+			Prefix   string `json:"prefix"`
+		}
+		if err := json.Unmarshal(m.Body, &ev); err != nil || ev.TenantID == "" || ev.Prefix == "" {
+			l.Error("invalid cache invalidation event", zap.ByteString("body", m.Body))
+			return rabbit.Permanent(errors.New("invalid cache invalidation event"))
+		}
+		// A failed purge is retried once before the entries are left to expire
+		if err := purgeCache(ctx, store, ev.TenantID, ev.Prefix); err != nil {
+			l.Error("purge cache failed", zap.String("prefix", ev.Prefix), zap.Error(err))
+			return err
+		}
+		cacheInvalidations.WithLabelValues(ev.Prefix, "event").Inc()
+		return nil
+	})
+}
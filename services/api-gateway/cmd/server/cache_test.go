@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+)
+
+type memCache struct {
+	mu   sync.Mutex
+	data map[string]string
+}
+
+func newMemCache() *memCache { return &memCache{data: map[string]string{}} }
+
+func (m *memCache) Set(ctx context.Context, key string, value any, _ time.Duration) *redis.StatusCmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[key] = value.(string)
+	return redis.NewStatusCmd(ctx)
+}
+
+func (m *memCache) Get(ctx context.Context, key string) *redis.StringCmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cmd := redis.NewStringCmd(ctx)
+	if v, ok := m.data[key]; ok {
+		cmd.SetVal(v)
+	} else {
+		cmd.SetErr(redis.Nil)
+	}
+	return cmd
+}
+
+func (m *memCache) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range keys {
+		delete(m.data, k)
+	}
+	return redis.NewIntCmd(ctx)
+}
+
+func (m *memCache) Incr(ctx context.Context, key string) *redis.IntCmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, _ := strconv.Atoi(m.data[key])
+	m.data[key] = strconv.Itoa(n + 1)
+	cmd := redis.NewIntCmd(ctx)
+	cmd.SetVal(int64(n + 1))
+	return cmd
+}
+
+func asCaller(r *http.Request, tenant string, roles ...string) *http.Request {
+	claims := jwtutil.Claims{UserID: uuid.New(), TenantID: tenant, Roles: roles}
+	return r.WithContext(context.WithValue(r.Context(), middleware.ClaimsKey, claims))
+}
+
+func TestWithCache(t *testing.T) {
+	calls := 0
+	cacheControl := ""
+	upstream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"n":` + strconv.Itoa(calls) + `}`))
+		}
+	})
+	const prefix = "/api/v1/subjects/"
+	h := withCache(newMemCache(), prefix, time.Minute, upstream)
+	get := func(tenant, role, target string, hdr ...string) *httptest.ResponseRecorder {
+		req := asCaller(httptest.NewRequest(http.MethodGet, target, nil), tenant, role)
+		for i := 0; i+1 < len(hdr); i += 2 {
+			req.Header.Set(hdr[i], hdr[i+1])
+		}
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+	hits := func() float64 { return testutil.ToFloat64(cacheLookups.WithLabelValues(prefix, "hit")) }
+
+	first := get("t1", "teacher", "/api/v1/subjects?limit=10&offset=0")
+	if first.Header().Get("X-Cache") != "MISS" || calls != 1 {
+		t.Fatalf("first request: cache=%s calls=%d", first.Header().Get("X-Cache"), calls)
+	}
+	before := hits()
+	second := get("t1", "teacher", "/api/v1/subjects?offset=0&limit=10")
+	if second.Header().Get("X-Cache") != "HIT" || second.Body.String() != first.Body.String() || calls != 1 {
+		t.Fatalf("reordered query not served from cache: cache=%s calls=%d", second.Header().Get("X-Cache"), calls)
+	}
+	if hits() != before+1 {
+		t.Fatal("hit not counted")
+	}
+	etag := second.Header().Get("ETag")
+	if rr := get("t1", "teacher", "/api/v1/subjects?limit=10&offset=0", "If-None-Match", etag); rr.Code != http.StatusNotModified || rr.Body.Len() != 0 {
+		t.Fatalf("conditional request code=%d", rr.Code)
+	}
+
+	// Other tenants and roles get their own entries
+	get("t2", "teacher", "/api/v1/subjects?limit=10&offset=0")
+	get("t1", "admin", "/api/v1/subjects?limit=10&offset=0")
+	if calls != 3 {
+		t.Fatalf("tenant and role must be part of the key, calls=%d", calls)
+	}
+
+	// A write through the route purges the tenant's entries only
+	write := asCaller(httptest.NewRequest(http.MethodPost, "/api/v1/subjects", nil), "t1", "admin")
+	h.ServeHTTP(httptest.NewRecorder(), write)
+	calls = 10
+	if rr := get("t1", "teacher", "/api/v1/subjects?limit=10&offset=0"); rr.Header().Get("X-Cache") != "MISS" {
+		t.Fatal("write did not purge the cache")
+	}
+	if rr := get("t2", "teacher", "/api/v1/subjects?limit=10&offset=0"); rr.Header().Get("X-Cache") != "HIT" {
+		t.Fatal("write purged another tenant")
+	}
+
+	// The upstream's Cache-Control is honoured
+	cacheControl = "private"
+	get("t3", "teacher", "/api/v1/subjects/1")
+	if rr := get("t3", "teacher", "/api/v1/subjects/1"); rr.Header().Get("X-Cache") != "MISS" {
+		t.Fatal("private response was cached")
+	}
+	cacheControl = ""
+	get("t3", "teacher", "/api/v1/subjects/2")
+	if rr := get("t3", "teacher", "/api/v1/subjects/2", "Cache-Control", "no-cache"); rr.Header().Get("X-Cache") != "MISS" {
+		t.Fatal("no-cache request must revalidate")
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	cases := []struct {
+		status int
+		header string
+		want   time.Duration
+	}{
+		{http.StatusOK, "", time.Minute},
+		{http.StatusOK, "public, max-age=30", 30 * time.Second},
+		{http.StatusOK, "max-age=600, s-maxage=10", 10 * time.Second},
+		{http.StatusOK, "max-age=0", 0},
+		{http.StatusOK, "no-store", 0},
+		{http.StatusNotFound, "", 0},
+	}
+	for _, c := range cases {
+		cw := &cacheWriter{ResponseWriter: httptest.NewRecorder(), status: c.status}
+		cw.Header().Set("Cache-Control", c.header)
+		if got := cacheTTL(cw, time.Minute); got != c.want {
+			t.Errorf("status=%d cache-control=%q ttl=%s want %s", c.status, c.header, got, c.want)
+		}
+	}
+}
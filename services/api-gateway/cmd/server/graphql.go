@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/api-gateway/internal/graphql"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const graphqlPath = "/api/v1/graphql"
+
+const (
+	// defaultGraphQLMaxCalls bounds the distinct REST calls one query makes.
+	defaultGraphQLMaxCalls = 50
+	// graphqlConcurrency is how many of a query's REST calls run at once.
+	graphqlConcurrency = 8
+	maxGraphQLBody     = 1 << 20
+)
+
+// graphqlConfig is the dashboard query endpoint, which composes the REST
+// APIs behind the routes.
+type graphqlConfig struct {
+	Disabled  bool `yaml:"disabled"`
+	RateLimit int  `yaml:"rate_limit"`
+	MaxCalls  int  `yaml:"max_calls"`
+	MaxDepth  int  `yaml:"max_depth"`
+}
+
+var graphqlCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_graphql_upstream_calls_total",
+	Help: "REST calls made for GraphQL queries, by whether they were sent or shared with an identical call.",
+}, []string{"result"})
+
+var errTooManyCalls = errors.New("query needs too many upstream calls")
+
+func registerGraphQL(mux *http.ServeMux, gq graphqlConfig, backend http.Handler, cfg config.Config, keys jwtutil.KeySet, apiKeys *apiKeyAuth, events middleware.EventPublisher, limiter redisutil.RateLimiter, meter *metering.Meter) {
+	maxCalls := gq.MaxCalls
+	if maxCalls == 0 {
+		maxCalls = defaultGraphQLMaxCalls
+	}
+	schema := dashboardSchema()
+	schema.MaxDepth = gq.MaxDepth
+	var h http.Handler = graphqlHandler(schema, backend, maxCalls)
+	if meter != nil {
+		h = withQuota(meter, h)
+	}
+	// Queries only read, so POSTs are limited as reads
+	if limiter != nil {
+		rate := gq.RateLimit
+		if rate == 0 {
+			rate = defaultReadLimit
+		}
+		h = middleware.RateLimitWith(limiter, middleware.RateLimitOptions{
+			Name: "global", Read: rate, Route: graphqlPath, TrustedProxies: cfg.TrustedProxies,
+		}, h)
+	}
+	// Queries never write, so read-only impersonated sessions may POST them
+	h = apiKeys.wrap(middleware.AuthWithKeySet(keys, cfg.JWTIssuer, cfg.JWTAudience,
+		middleware.RecordImpersonation(events, h)))
+	mux.Handle(graphqlPath, h)
+}
+
+// graphqlHandler serves queries sent as JSON in a POST body or in the query
+// string of a GET.
+func graphqlHandler(schema *graphql.Schema, backend http.Handler, maxCalls int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphql.Request
+		switch r.Method {
+		case http.MethodGet:
+			q := r.URL.Query()
+			req.Query, req.OperationName = q.Get("query"), q.Get("operationName")
+			if v := q.Get("variables"); v != "" {
+				if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+					writeGraphQL(w, http.StatusBadRequest, graphqlError("variables must be a JSON object"))
+					return
+				}
+			}
+		case http.MethodPost:
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGraphQLBody)).Decode(&req); err != nil {
+				writeGraphQL(w, http.StatusBadRequest, graphqlError("body must be a JSON GraphQL request"))
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			writeGraphQL(w, http.StatusMethodNotAllowed, graphqlError("use GET or POST"))
+			return
+		}
+		if req.Query == "" {
+			writeGraphQL(w, http.StatusBadRequest, graphqlError("missing query"))
+			return
+		}
+		l := &loader{backend: backend, parent: r, maxCalls: maxCalls, sem: make(chan struct{}, graphqlConcurrency), calls: map[string]*loaderCall{}}
+		resp := schema.Execute(context.WithValue(r.Context(), loaderKey{}, l), req)
+		status := http.StatusOK
+		if resp.Data == nil {
+			status = http.StatusBadRequest
+		}
+		writeGraphQL(w, status, resp)
+	})
+}
+
+func graphqlError(msg string) graphql.Response {
+	return graphql.Response{Errors: []graphql.Error{{Message: msg}}}
+}
+
+func writeGraphQL(w http.ResponseWriter, status int, resp graphql.Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+type loaderKey struct{}
+
+// loader makes the REST calls of one query. Identical calls are made once
+// and shared, calls resolved together go out concurrently, and each carries
+// the caller's credentials and signed identity so the services check their
+// permissions as if the caller had asked directly.
+type loader struct {
+	backend  http.Handler
+	parent   *http.Request
+	maxCalls int
+	sem      chan struct{}
+
+	mu    sync.Mutex
+	calls map[string]*loaderCall
+}
+
+type loaderCall struct {
+	done chan struct{}
+	data any
+	err  error
+}
+
+// forwardedHeaders are the caller's headers passed on to each call.
+var forwardedHeaders = []string{"Authorization", "X-Request-ID", "Accept-Language"}
+
+// get returns the data of the envelope path answers with, nil when it is not
+// found.
+func (l *loader) get(ctx context.Context, path string, query url.Values) (any, error) {
+	target := path
+	if q := query.Encode(); q != "" {
+		target += "?" + q
+	}
+	l.mu.Lock()
+	c, ok := l.calls[target]
+	if !ok {
+		if len(l.calls) >= l.maxCalls {
+			l.mu.Unlock()
+			return nil, errTooManyCalls
+		}
+		c = &loaderCall{done: make(chan struct{})}
+		l.calls[target] = c
+	}
+	l.mu.Unlock()
+	if ok {
+		graphqlCalls.WithLabelValues("shared").Inc()
+		select {
+		case <-c.done:
+			return c.data, c.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	graphqlCalls.WithLabelValues("sent").Inc()
+	c.data, c.err = l.fetch(ctx, target)
+	close(c.done)
+	return c.data, c.err
+}
+
+func (l *loader) fetch(ctx context.Context, target string) (any, error) {
+	select {
+	case l.sem <- struct{}{}:
+		defer func() { <-l.sem }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range forwardedHeaders {
+		if v := l.parent.Header.Get(k); v != "" {
+			req.Header.Set(k, v)
+		}
+	}
+	req.RemoteAddr = l.parent.RemoteAddr
+	rec := &bufferedResponse{header: http.Header{}, status: http.StatusOK}
+	l.backend.ServeHTTP(rec, req)
+	if rec.status == http.StatusNotFound {
+		return nil, nil
+	}
+	var env struct {
+		Data  any `json:"data"`
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rec.body.Bytes(), &env); err != nil {
+		return nil, fmt.Errorf("upstream answered %d", rec.status)
+	}
+	if rec.status >= http.StatusBadRequest {
+		return nil, fmt.Errorf("upstream answered %d: %s", rec.status, env.Error.Message)
+	}
+	return env.Data, nil
+}
+
+func loaderFrom(ctx context.Context) *loader {
+	return ctx.Value(loaderKey{}).(*loader)
+}
+
+// idArg returns the named UUID argument, or the named field of the parent
+// when arg is "".
+func idArg(p graphql.Params, arg, field string) (string, error) {
+	name, v := arg, p.String(arg)
+	if arg == "" {
+		m, _ := p.Source.(map[string]any)
+		name = field
+		v, _ = m[field].(string)
+	}
+	id, err := uuid.Parse(v)
+	if err != nil {
+		return "", fmt.Errorf("%s must be a UUID", name)
+	}
+	return id.String(), nil
+}
+
+// fetchByID resolves a field from the REST path format fills with an ID
+// taken from arg or the parent's field, plus the optional query arguments
+// given under their REST parameter names.
+func fetchByID(format, arg, field string, params ...string) graphql.ResolveFunc {
+	return func(ctx context.Context, p graphql.Params) (any, error) {
+		id, err := idArg(p, arg, field)
+		if err != nil {
+			return nil, err
+		}
+		q := url.Values{}
+		for _, name := range params {
+			if v := p.String(name); v != "" {
+				q.Set(name, v)
+			}
+		}
+		return loaderFrom(ctx).get(ctx, fmt.Sprintf(format, id), q)
+	}
+}
+
+// dashboardSchema composes the academic, attendance, assessment and finance
+// APIs a student dashboard reads. Field names follow the REST JSON.
+func dashboardSchema() *graphql.Schema {
+	schedule := &graphql.Object{Name: "Schedule", Fields: graphql.Leaves(
+		"id", "class_id", "subject_id", "teacher_id", "day_of_week", "start_time", "end_time", "room")}
+	class := &graphql.Object{Name: "Class", Fields: graphql.Leaves(
+		"id", "school_id", "academic_year_id", "name", "level", "major", "homeroom_teacher_id", "capacity")}
+	class.Fields["schedules"] = &graphql.Field{Type: schedule, Resolve: fetchByID("/api/v1/schedules/class/%s", "", "id")}
+	enrollment := &graphql.Object{Name: "Enrollment", Fields: graphql.Leaves("id", "class_id", "student_id", "status", "created_at")}
+	enrollment.Fields["class"] = &graphql.Field{Type: class, Resolve: fetchByID("/api/v1/classes/%s", "", "class_id")}
+	grade := &graphql.Object{Name: "Grade", Fields: graphql.Leaves(
+		"id", "assessment_id", "student_id", "score", "feedback", "notes", "status", "approved_at", "assessment", "created_at")}
+	reportCard := &graphql.Object{Name: "ReportCard", Fields: graphql.Leaves(
+		"id", "student_id", "semester_id", "class_id", "status", "gpa", "total_credits", "rank", "attendance",
+		"attendance_summary", "comments", "pdf_url", "details", "generated_at", "published_at")}
+	invoice := &graphql.Object{Name: "Invoice", Fields: graphql.Leaves(
+		"id", "student_id", "billing_config_id", "invoice_number", "amount", "status", "due_date", "paid_amount", "created_at")}
+
+	student := &graphql.Object{Name: "Student", Fields: graphql.Leaves(
+		"id", "user_id", "nis", "nisn", "name", "gender", "birth_place", "birth_date", "address", "phone", "email",
+		"parent_name", "parent_phone", "admission_date", "status")}
+	student.Fields["enrollments"] = &graphql.Field{Type: enrollment, Resolve: fetchByID("/api/v1/students/%s/classes", "", "id")}
+	student.Fields["attendance_summary"] = &graphql.Field{
+		Args:    map[string]bool{"semester_id": false},
+		Resolve: fetchByID("/api/v1/attendance/students/%s/summary", "", "id", "semester_id"),
+	}
+	student.Fields["grades"] = &graphql.Field{
+		Type:    grade,
+		Args:    map[string]bool{"class_id": true, "semester_id": true},
+		Resolve: fetchByID("/api/v1/grades/student/%s", "", "id", "class_id", "semester_id"),
+	}
+	student.Fields["report_card"] = &graphql.Field{
+		Type:    reportCard,
+		Args:    map[string]bool{"semester_id": true},
+		Resolve: fetchByID("/api/v1/report-cards/student/%s", "", "id", "semester_id"),
+	}
+	student.Fields["invoices"] = &graphql.Field{
+		Type: invoice,
+		Args: map[string]bool{"status": false},
+		Resolve: func(ctx context.Context, p graphql.Params) (any, error) {
+			id, err := idArg(p, "", "id")
+			if err != nil {
+				return nil, err
+			}
+			q := url.Values{"student_id": {id}}
+			if s := p.String("status"); s != "" {
+				q.Set("status", s)
+			}
+			return loaderFrom(ctx).get(ctx, "/api/v1/finance/invoices", q)
+		},
+	}
+
+	return &graphql.Schema{Query: &graphql.Object{Name: "Query", Fields: map[string]*graphql.Field{
+		"student": {Type: student, Args: map[string]bool{"id": true}, Resolve: fetchByID("/api/v1/students/%s", "id", "")},
+		"class":   {Type: class, Args: map[string]bool{"id": true}, Resolve: fetchByID("/api/v1/classes/%s", "id", "")},
+	}}}
+}
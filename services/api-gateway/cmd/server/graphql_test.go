@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/identity"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+func TestGraphQL_StudentDashboard(t *testing.T) {
+	cfg := makeCfg()
+	cfg.GatewaySecret = "gateway"
+	userID := uuid.New()
+	studentID, classID := uuid.NewString(), uuid.NewString()
+	var mu sync.Mutex
+	calls := map[string]int{}
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/health" {
+			return
+		}
+		mu.Lock()
+		calls[r.URL.RequestURI()]++
+		mu.Unlock()
+		// Every hop carries the caller's token and signed identity
+		claims, err := identity.Verify(r, []byte(cfg.GatewaySecret), time.Now())
+		if err != nil || claims.UserID != userID || !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			httputil.Error(w, http.StatusUnauthorized, "2001", "Unauthorized", nil)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/students/" + studentID:
+			httputil.Success(w, map[string]any{"id": studentID, "name": "Ani", "nis": "001"})
+		case "/api/v1/students/" + studentID + "/classes":
+			// Two enrollments in the same class need the class once
+			httputil.Success(w, []any{
+				map[string]any{"id": uuid.NewString(), "class_id": classID, "status": "active"},
+				map[string]any{"id": uuid.NewString(), "class_id": classID, "status": "moved"},
+			})
+		case "/api/v1/classes/" + classID:
+			httputil.Success(w, map[string]any{"id": classID, "name": "X IPA 1"})
+		case "/api/v1/schedules/class/" + classID:
+			httputil.Success(w, []any{map[string]any{"day_of_week": 1, "room": "R1"}})
+		case "/api/v1/attendance/students/" + studentID + "/summary":
+			httputil.Success(w, map[string]int{"present": 40, "absent": 2})
+		case "/api/v1/finance/invoices":
+			httputil.Error(w, http.StatusForbidden, "3001", "Forbidden", nil)
+		default:
+			httputil.Error(w, http.StatusNotFound, "4004", "Not Found", nil)
+		}
+	}))
+	defer up.Close()
+	cfg.GatewayRoutesFile = filepath.Join(t.TempDir(), "routes.yaml")
+	writeRoutes(t, cfg.GatewayRoutesFile, up.URL, `
+  - prefix: /api/v1/students/
+    upstream: svc
+    auth: true
+  - prefix: /api/v1/classes/
+    upstream: svc
+    auth: true
+  - prefix: /api/v1/schedules/
+    upstream: svc
+    auth: true
+  - prefix: /api/v1/attendance/
+    upstream: svc
+    auth: true
+  - prefix: /api/v1/report-cards/
+    upstream: svc
+    auth: true
+  - prefix: /api/v1/finance/
+    upstream: svc
+    auth: true
+`)
+	mux := http.NewServeMux()
+	if err := registerRoutes(mux, cfg, newPoolSet(), nil, nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	tok, _ := jwtutil.GenerateAccessWith(cfg.JWTAccessSecret, time.Minute, jwtutil.Claims{UserID: userID, TenantID: "t1", Roles: []string{"student"}}, cfg.JWTIssuer, cfg.JWTAudience)
+	query := func(auth bool, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, graphqlPath, strings.NewReader(body))
+		if auth {
+			req.Header.Set("Authorization", "Bearer "+tok)
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	body, _ := json.Marshal(map[string]any{
+		"query": `query Dashboard($id: ID!, $sem: ID!) {
+			me: student(id: $id) {
+				name
+				enrollments { status class { name schedules { day_of_week room } } }
+				attendance_summary
+				report_card(semester_id: $sem) { gpa }
+				invoices(status: "unpaid") { amount }
+			}
+			again: student(id: $id) { nis }
+		}`,
+		"variables": map[string]any{"id": studentID, "sem": uuid.NewString()},
+	})
+	rr := query(true, string(body))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("code=%d body=%s", rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Data   map[string]map[string]any `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+			Path    []any  `json:"path"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	me := resp.Data["me"]
+	enrollments, _ := me["enrollments"].([]any)
+	if me["name"] != "Ani" || resp.Data["again"]["nis"] != "001" || len(enrollments) != 2 {
+		t.Fatalf("data=%s", rr.Body.String())
+	}
+	if !strings.Contains(rr.Body.String(), `"class":{"name":"X IPA 1","schedules":[{"day_of_week":1,"room":"R1"}]}`) ||
+		!strings.Contains(rr.Body.String(), `"attendance_summary":{"absent":2,"present":40}`) {
+		t.Fatalf("data=%s", rr.Body.String())
+	}
+	// A missing report card is null; a refused call fails only its field
+	if me["report_card"] != nil || me["invoices"] != nil || len(resp.Errors) != 1 ||
+		!strings.Contains(resp.Errors[0].Message, "403") || len(resp.Errors[0].Path) != 2 || resp.Errors[0].Path[1] != "invoices" {
+		t.Fatalf("data=%s", rr.Body.String())
+	}
+	for uri, n := range calls {
+		if n != 1 {
+			t.Errorf("%s called %d times", uri, n)
+		}
+	}
+	if len(calls) != 7 {
+		t.Errorf("calls=%v", calls)
+	}
+
+	if rr := query(false, string(body)); rr.Code != http.StatusUnauthorized {
+		t.Fatalf("anonymous code=%d", rr.Code)
+	}
+	if rr := query(true, `{"query": "{ student(id: \"not-a-uuid\") { name } }"}`); rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "id must be a UUID") {
+		t.Fatalf("bad id code=%d body=%s", rr.Code, rr.Body.String())
+	}
+	if rr := query(true, `{"query": "{ student(id: \"x\") { password } }"}`); rr.Code != http.StatusBadRequest {
+		t.Fatalf("unknown field code=%d", rr.Code)
+	}
+}
+
+func TestGraphQL_MaxDepth(t *testing.T) {
+	cfg := makeCfg()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer up.Close()
+	cfg.GatewayRoutesFile = filepath.Join(t.TempDir(), "routes.yaml")
+	writeRoutes(t, cfg.GatewayRoutesFile, up.URL, `
+  - prefix: /api/v1/students/
+    upstream: svc
+    auth: true
+graphql:
+  max_depth: 2
+`)
+	mux := http.NewServeMux()
+	if err := registerRoutes(mux, cfg, newPoolSet(), nil, nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	tok, _ := jwtutil.GenerateAccessWith(cfg.JWTAccessSecret, time.Minute, jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"}, cfg.JWTIssuer, cfg.JWTAudience)
+	body := `{"query": "{ student(id: \"` + uuid.NewString() + `\") { enrollments { class { name } } } }"}`
+	req := httptest.NewRequest(http.MethodPost, graphqlPath, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+tok)
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "deeper than 2") {
+		t.Fatalf("code=%d body=%s", rr.Code, rr.Body.String())
+	}
+}
@@ -1,20 +1,22 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
-	"net/http/httputil"
-	"net/url"
 	"os"
 	"strconv"
 	"strings"
-	"sync/atomic"
 	"time"
 
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
 	httpx "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/identity"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/logger"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/rabbit"
 	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/tracer"
 
@@ -22,10 +24,6 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
-var perPrefixLimits = map[string]int{
-	"/api/v1/auth/": 5,
-}
-
 func main() {
 	cfg, err := config.Load()
 	if err != nil {
@@ -39,18 +37,30 @@ func main() {
 		panic(err)
 	}
 	redis := redisutil.New(cfg.RedisAddr)
-	limiter := redisutil.NewLimiterFromCounter(redis.Raw())
+	limiter := redisutil.NewTokenBucketLimiter(redis.Raw())
+	meter := metering.New(metering.NewRedisStore(redis.Raw()))
 
-	mux := http.NewServeMux()
-	registerRoutes(mux, cfg)
+	// Impersonated requests are reported to auth-service for the audit log
+	events := rabbit.New(cfg.RabbitURL)
+
+	// Routes come from APP_GATEWAY_ROUTES_FILE (or the embedded defaults) and
+	// are reloaded on SIGHUP or when the file changes
+	pools := newPoolSet()
+	routes, err := newRouteTable(func() (*http.ServeMux, error) {
+		mux := http.NewServeMux()
+		return mux, registerRoutes(mux, cfg, pools, events, limiter, redis.Raw(), meter)
+	})
+	if err != nil {
+		panic(err)
+	}
+	go routes.watch(context.Background(), cfg.GatewayRoutesFile, l)
+	go consumeCacheInvalidations(events, redis.Raw(), l)
 
 	h := middleware.Recover(
 		middleware.RequestID(
 			middleware.Logging(l,
-				middleware.RateLimitByPolicy(limiter, 100, 30, perPrefixLimits,
-					withSecurityHeaders(
-						middleware.CORS(cfg.CORSAllowedOrigins, mux),
-					),
+				withSecurityHeaders(
+					middleware.CORS(cfg.CORSAllowedOrigins, routes),
 				),
 			),
 		),
@@ -81,35 +91,87 @@ func withSecurityHeaders(next http.Handler) http.Handler {
 	})
 }
 
-func registerRoutes(mux *http.ServeMux, cfg config.Config) {
+func registerRoutes(mux *http.ServeMux, cfg config.Config, pools *poolSet, events middleware.EventPublisher, limiter redisutil.RateLimiter, cache cacheStore, meter *metering.Meter) error {
+	routes, err := loadRouteConfig(cfg.GatewayRoutesFile)
+	if err != nil {
+		return err
+	}
+	pools.begin()
 	mux.Handle("/metrics", promhttp.Handler())
 	mux.Handle("/api/v1/gateway/health", gatewayHealthHandler(cfg))
-	registerLBEnv(mux, "/api/v1/health", "APP_UPSTREAM_AUTH", cfg, false)
-	registerLBEnv(mux, "/api/v1/auth/", "APP_UPSTREAM_AUTH", cfg, false)
-	registerLBEnv(mux, "/api/v1/users/", "APP_UPSTREAM_AUTH", cfg, true)
-	registerLBEnv(mux, "/api/v1/users", "APP_UPSTREAM_AUTH", cfg, true)
-	registerLBEnv(mux, "/api/v1/schools/", "APP_UPSTREAM_ACADEMIC", cfg, true)
-	registerLBEnv(mux, "/api/v1/classes/", "APP_UPSTREAM_ACADEMIC", cfg, true)
-	registerLBEnv(mux, "/api/v1/subjects/", "APP_UPSTREAM_ACADEMIC", cfg, true)
-	registerLBEnv(mux, "/api/v1/attendance/", "APP_UPSTREAM_ATTENDANCE", cfg, true)
-	registerLBEnv(mux, "/api/v1/grades/", "APP_UPSTREAM_ASSESSMENT", cfg, true)
-	registerLBEnv(mux, "/api/v1/reports/", "APP_UPSTREAM_ASSESSMENT", cfg, true)
-	registerLBEnv(mux, "/api/v1/admissions/", "APP_UPSTREAM_ADMISSION", cfg, true)
-	registerLBEnv(mux, "/api/v1/finance/", "APP_UPSTREAM_FINANCE", cfg, true)
-	registerLBEnv(mux, "/api/v1/notifications/", "APP_UPSTREAM_NOTIFICATION", cfg, true)
-	registerLBEnv(mux, "/api/v1/files/", "APP_UPSTREAM_FILE", cfg, true)
+	keys := jwtutil.NewKeySet(cfg.JWTSigningAlg, cfg.JWTAccessSecret, cfg.JWKSURL)
+	// Machine clients may authenticate with X-API-Key instead of a bearer
+	// token. Token versions live in the same Redis as the response cache.
+	apiKeys := newAPIKeyAuth(parseUpstreams("APP_UPSTREAM_AUTH"), limiter, cache, cfg.TrustedProxies)
+	// Calls the gateway makes itself, for GraphQL queries, reach the routes'
+	// upstreams with the caller's identity, past the auth, limits and quota
+	// the query already went through
+	backend := http.NewServeMux()
+	for _, rt := range routes.Routes {
+		// Routes sharing a pool share its health checks and breakers
+		pool := pools.get(rt.Upstream, routes.Upstreams[rt.Upstream])
+		if pool == nil {
+			continue
+		}
+		h := upstreamHandler(rt, pool.handler(rt.Retry, rt.Hedge), cfg, cache)
+		handleRoute(backend, rt.Prefix, h)
+		registerRoute(mux, rt, h, cfg, keys, apiKeys, events, limiter, meter)
+	}
+	if !routes.GraphQL.Disabled {
+		registerGraphQL(mux, routes.GraphQL, backend, cfg, keys, apiKeys, events, limiter, meter)
+	}
+	pools.sweep()
+	return nil
 }
 
-func registerLBEnv(mux *http.ServeMux, prefix, envBase string, cfg config.Config, requireAuth bool) {
-	upstreams := parseUpstreams(envBase)
-	if len(upstreams) == 0 {
-		return
+// upstreamHandler forwards a route's requests to its upstream pool.
+func upstreamHandler(rt route, proxy http.Handler, cfg config.Config, cache cacheStore) http.Handler {
+	// Identity headers are only ever set by the gateway, signed with the
+	// secret shared with the services behind it
+	h := identity.Inject([]byte(cfg.GatewaySecret), proxy)
+	if rt.Timeout > 0 {
+		h = withTimeout(rt.Timeout, h)
 	}
-	var h http.Handler = newRoundRobinProxy(prefix, upstreams)
-	if requireAuth {
-		h = middleware.AuthWith(cfg.JWTAccessSecret, cfg.JWTIssuer, cfg.JWTAudience, h)
+	if rt.Rewrite != "" {
+		h = withRewrite(rt.Prefix, rt.Rewrite, h)
 	}
+	// Cached per tenant and role, so it must sit inside auth
+	if rt.Cache != nil && cache != nil {
+		h = withCache(cache, rt.Prefix, rt.Cache.TTL, h)
+	}
+	return h
+}
+
+func registerRoute(mux *http.ServeMux, rt route, h http.Handler, cfg config.Config, keys jwtutil.KeySet, apiKeys *apiKeyAuth, events middleware.EventPublisher, limiter redisutil.RateLimiter, meter *metering.Meter) {
+	// Requests count against the tenant's plan once the caller is known
+	if meter != nil && rt.Auth {
+		h = withQuota(meter, h)
+	}
+	// Limits sit inside auth so they count per API key or user rather than
+	// per address, and apply to the route rather than each path under it
+	if limiter != nil {
+		if rt.RateLimit > 0 {
+			h = middleware.RateLimitWith(limiter, middleware.RateLimitOptions{
+				Name: "route", Read: rt.RateLimit, By: rt.RateLimitBy, Route: rt.Prefix, TrustedProxies: cfg.TrustedProxies,
+			}, h)
+		}
+		h = middleware.RateLimitWith(limiter, middleware.RateLimitOptions{
+			Name: "global", Read: defaultReadLimit, Write: defaultWriteLimit, Route: rt.Prefix, TrustedProxies: cfg.TrustedProxies,
+		}, h)
+	}
+	if rt.Auth {
+		h = apiKeys.wrap(middleware.AuthWithKeySet(keys, cfg.JWTIssuer, cfg.JWTAudience,
+			middleware.RestrictImpersonation(middleware.RecordImpersonation(events, h))))
+	}
+	handleRoute(mux, rt.Prefix, h)
+}
+
+func handleRoute(mux *http.ServeMux, prefix string, h http.Handler) {
 	mux.Handle(prefix, h)
+	// Services serve collections without the trailing slash
+	if bare := strings.TrimSuffix(prefix, "/"); bare != prefix && bare != "" {
+		mux.Handle(bare, h)
+	}
 }
 
 func parseUpstreams(envBase string) []string {
@@ -133,16 +195,6 @@ func parseUpstreams(envBase string) []string {
 	return []string{u}
 }
 
-type recWriter struct {
-	http.ResponseWriter
-	status int
-}
-
-func (rw *recWriter) WriteHeader(code int) {
-	rw.status = code
-	rw.ResponseWriter.WriteHeader(code)
-}
-
 func gatewayHealthHandler(cfg config.Config) http.Handler {
 	type svcStatus struct {
 		Up     bool   `json:"up"`
@@ -218,81 +270,3 @@ func gatewayHealthHandler(cfg config.Config) http.Handler {
 		httpx.Success(w, out)
 	})
 }
-
-type breaker struct {
-	failures    int
-	openedUntil time.Time
-	threshold   int
-	openFor     time.Duration
-}
-
-func newBreaker(threshold int, openFor time.Duration) *breaker {
-	return &breaker{threshold: threshold, openFor: openFor}
-}
-
-func (b *breaker) allow() bool {
-	return time.Now().After(b.openedUntil)
-}
-
-func (b *breaker) recordFailure() {
-	b.failures++
-	if b.failures >= b.threshold {
-		b.openedUntil = time.Now().Add(b.openFor)
-		b.failures = 0
-	}
-}
-
-
-type rrProxy struct {
-	proxies    []*httputil.ReverseProxy
-	index      uint32
-	breakers   []*breaker
-	prefixName string
-}
-
-func newRoundRobinProxy(prefix string, upstreams []string) http.Handler {
-	var proxies []*httputil.ReverseProxy
-	var breakers []*breaker
-	for _, u := range upstreams {
-		parsed, err := url.Parse(u)
-		if err != nil {
-			log.Printf("skip invalid upstream for %s: %s, err=%v", prefix, u, err)
-			continue
-		}
-		p := httputil.NewSingleHostReverseProxy(parsed)
-		// Mark failures when transport errors occur
-		b := newBreaker(5, 30*time.Second)
-		p.ErrorHandler = func(w http.ResponseWriter, r *http.Request, e error) {
-			b.recordFailure()
-			httpx.Error(w, http.StatusBadGateway, "6002", "Upstream error", []map[string]string{
-				{"upstream": parsed.String(), "error": e.Error()},
-			})
-		}
-		proxies = append(proxies, p)
-		breakers = append(breakers, b)
-	}
-	if len(proxies) == 0 {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			httpx.Error(w, http.StatusServiceUnavailable, "1001", "No valid upstreams", nil)
-		})
-	}
-	rr := &rrProxy{proxies: proxies, breakers: breakers, prefixName: prefix}
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		startIdx := int(atomic.AddUint32(&rr.index, 1)) % len(rr.proxies)
-		// Find first allowed (circuit closed)
-		idx := startIdx
-		for i := 0; i < len(rr.proxies); i++ {
-			j := (startIdx + i) % len(rr.proxies)
-			if rr.breakers[j].allow() {
-				idx = j
-				break
-			}
-		}
-		// Capture status to record failures
-		rrw := &recWriter{ResponseWriter: w, status: 200}
-		rr.proxies[idx].ServeHTTP(rrw, r)
-		if rrw.status >= 500 {
-			rr.breakers[idx].recordFailure()
-		}
-	})
-}
@@ -1,14 +1,23 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
+	httpx "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/identity"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 )
 
 func makeCfg() config.Config {
@@ -169,7 +178,7 @@ func TestRegisterRoutes_AuthProxy(t *testing.T) {
 	_ = os.Setenv("APP_UPSTREAM_AUTH_URLS", up.URL)
 	cfg := makeCfg()
 	mux := http.NewServeMux()
-	registerRoutes(mux, cfg)
+	registerRoutes(mux, cfg, newPoolSet(), nil, nil, nil, nil)
 	rr := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/test", nil)
 	mux.ServeHTTP(rr, req)
@@ -179,6 +188,210 @@ func TestRegisterRoutes_AuthProxy(t *testing.T) {
 	_ = os.Unsetenv("APP_UPSTREAM_AUTH_URLS")
 }
 
+func TestRegisterRoutes_SignsIdentity(t *testing.T) {
+	cfg := makeCfg()
+	cfg.GatewaySecret = "gateway"
+	userID := uuid.New()
+	var got jwtutil.Claims
+	var verifyErr error
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/health" {
+			return
+		}
+		got, verifyErr = identity.Verify(r, []byte(cfg.GatewaySecret), time.Now())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer up.Close()
+	_ = os.Setenv("APP_UPSTREAM_ACADEMIC_URLS", up.URL)
+	defer func() { _ = os.Unsetenv("APP_UPSTREAM_ACADEMIC_URLS") }()
+	mux := http.NewServeMux()
+	registerRoutes(mux, cfg, newPoolSet(), nil, nil, nil, nil)
+
+	tok, _ := jwtutil.GenerateAccessWith(cfg.JWTAccessSecret, time.Minute, jwtutil.Claims{UserID: userID, TenantID: "t1", Roles: []string{"teacher"}}, cfg.JWTIssuer, cfg.JWTAudience)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/subjects/?tenant_id=t2", nil)
+	req.Header.Set("Authorization", "Bearer "+tok)
+	req.Header.Set(identity.HeaderTenantID, "t2")
+	req.Header.Set(identity.HeaderRoles, "admin")
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("code=%d", rr.Code)
+	}
+	if verifyErr != nil {
+		t.Fatalf("upstream could not verify identity: %v", verifyErr)
+	}
+	if got.UserID != userID || got.TenantID != "t1" || len(got.Roles) != 1 || got.Roles[0] != "teacher" {
+		t.Fatalf("spoofed identity reached upstream: %+v", got)
+	}
+}
+
+type countingLimiter struct {
+	counts map[string]int64
+}
+
+func (l *countingLimiter) Incr(ctx context.Context, key string) (int64, error) {
+	l.counts[key]++
+	return l.counts[key], nil
+}
+
+func (l *countingLimiter) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	return nil
+}
+
+func TestRegisterRoutes_APIKey(t *testing.T) {
+	cfg := makeCfg()
+	keyID := uuid.New()
+	var verifies int32
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case authz.APIKeyVerifyPath:
+			atomic.AddInt32(&verifies, 1)
+			var in authz.APIKeyVerifyRequest
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			if in.Key != "sk_abc_good" {
+				httpx.Error(w, http.StatusUnauthorized, "2001", "Unauthorized", nil)
+				return
+			}
+			tok, _ := jwtutil.GenerateAccessWith(cfg.JWTAccessSecret, time.Minute, jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"}, cfg.JWTIssuer, cfg.JWTAudience)
+			httpx.Success(w, authz.APIKeyVerifyResponse{AccessToken: tok, ExpiresIn: 60, KeyID: keyID, RateLimit: 2})
+		case "/api/v1/users/me":
+			if r.Header.Get(authz.APIKeyHeader) != "" || r.Header.Get("Authorization") == "" {
+				w.WriteHeader(http.StatusTeapot)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer up.Close()
+	_ = os.Setenv("APP_UPSTREAM_AUTH_URLS", up.URL)
+	defer func() { _ = os.Unsetenv("APP_UPSTREAM_AUTH_URLS") }()
+	mux := http.NewServeMux()
+	registerRoutes(mux, cfg, newPoolSet(), nil, redisutil.NewFixedWindowLimiter(&countingLimiter{counts: map[string]int64{}}), nil, nil)
+
+	call := func(key string) int {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+		req.Header.Set(authz.APIKeyHeader, key)
+		mux.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	if code := call("sk_abc_bad"); code != http.StatusUnauthorized {
+		t.Fatalf("bad key code=%d want 401", code)
+	}
+	for i := 0; i < 2; i++ {
+		if code := call("sk_abc_good"); code != http.StatusOK {
+			t.Fatalf("request %d code=%d want 200", i, code)
+		}
+	}
+	if code := call("sk_abc_good"); code != http.StatusTooManyRequests {
+		t.Fatalf("over budget code=%d want 429", code)
+	}
+	if n := atomic.LoadInt32(&verifies); n != 2 {
+		t.Fatalf("token must be cached per key, verify called %d times", n)
+	}
+}
+
+func TestRegisterRoutes_APIKeyRevoked(t *testing.T) {
+	cfg := makeCfg()
+	accountID := uuid.New()
+	versions := newMemCache()
+	var verifies int32
+	var revoked atomic.Bool
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case authz.APIKeyVerifyPath:
+			atomic.AddInt32(&verifies, 1)
+			if revoked.Load() {
+				httpx.Error(w, http.StatusUnauthorized, "2001", "Unauthorized", nil)
+				return
+			}
+			claims := jwtutil.Claims{UserID: accountID, TenantID: "t1", TokenVersion: 1}
+			tok, _ := jwtutil.GenerateAccessWith(cfg.JWTAccessSecret, time.Minute, claims, cfg.JWTIssuer, cfg.JWTAudience)
+			httpx.Success(w, authz.APIKeyVerifyResponse{AccessToken: tok, ExpiresIn: 60, KeyID: uuid.New(), RateLimit: 100, UserID: accountID, TokenVersion: 1})
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer up.Close()
+	_ = os.Setenv("APP_UPSTREAM_AUTH_URLS", up.URL)
+	defer func() { _ = os.Unsetenv("APP_UPSTREAM_AUTH_URLS") }()
+	mux := http.NewServeMux()
+	registerRoutes(mux, cfg, newPoolSet(), nil, nil, versions, nil)
+
+	call := func() int {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+		req.Header.Set(authz.APIKeyHeader, "sk_abc_good")
+		mux.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	for i := 0; i < 2; i++ {
+		if code := call(); code != http.StatusOK {
+			t.Fatalf("request %d code=%d want 200", i, code)
+		}
+	}
+	if n := atomic.LoadInt32(&verifies); n != 1 {
+		t.Fatalf("token must be cached, verify called %d times", n)
+	}
+	// auth-service bumps the account's token version when the key is revoked
+	revoked.Store(true)
+	versions.Set(context.Background(), middleware.TokenVersionKey(accountID), "2", 0)
+	if code := call(); code != http.StatusUnauthorized {
+		t.Fatalf("revoked key code=%d want 401", code)
+	}
+	if n := atomic.LoadInt32(&verifies); n != 2 {
+		t.Fatalf("stale token must be exchanged again, verify called %d times", n)
+	}
+}
+
+func TestRegisterRoutes_APIKeyRejected(t *testing.T) {
+	cfg := makeCfg()
+	var verifies int32
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == authz.APIKeyVerifyPath {
+			atomic.AddInt32(&verifies, 1)
+			httpx.Error(w, http.StatusUnauthorized, "2001", "Unauthorized", nil)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer up.Close()
+	_ = os.Setenv("APP_UPSTREAM_AUTH_URLS", up.URL)
+	defer func() { _ = os.Unsetenv("APP_UPSTREAM_AUTH_URLS") }()
+	mux := http.NewServeMux()
+	registerRoutes(mux, cfg, newPoolSet(), nil, redisutil.NewFixedWindowLimiter(&countingLimiter{counts: map[string]int64{}}), nil, nil)
+
+	call := func(key, addr string) int {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+		req.RemoteAddr = addr
+		req.Header.Set(authz.APIKeyHeader, key)
+		mux.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	for i := 0; i < 3; i++ {
+		if code := call("sk_abc_bad", "192.0.2.1:1234"); code != http.StatusUnauthorized {
+			t.Fatalf("request %d code=%d want 401", i, code)
+		}
+	}
+	if n := atomic.LoadInt32(&verifies); n != 1 {
+		t.Fatalf("rejection must be cached, verify called %d times", n)
+	}
+	// Guessing keys from one address stops reaching auth-service
+	limited := false
+	for i := 0; i < apiKeyVerifyLimit && !limited; i++ {
+		limited = call("sk_guess_"+uuid.NewString(), "192.0.2.1:1234") == http.StatusTooManyRequests
+	}
+	if !limited || atomic.LoadInt32(&verifies) != apiKeyVerifyLimit {
+		t.Fatalf("limited=%v verifies=%d want %d", limited, atomic.LoadInt32(&verifies), apiKeyVerifyLimit)
+	}
+	if code := call("sk_guess_"+uuid.NewString(), "198.51.100.7:1234"); code != http.StatusUnauthorized {
+		t.Fatalf("other address code=%d want 401", code)
+	}
+}
+
 func TestBreaker(t *testing.T) {
 	b := newBreaker(2, 100*time.Millisecond)
 	if !b.allow() {
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"net/http"
+
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var quotaRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_quota_rejections_total",
+	Help: "Requests refused because the tenant used up its plan's daily quota.",
+}, []string{"metric"})
+
+// withQuota counts authenticated requests against the tenant's daily quota,
+// warning past the soft limit and refusing past the hard one. Metering
+// fails open: a Redis outage must not take the API down with it.
+func withQuota(meter *metering.Meter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, _ := r.Context().Value(middleware.ClaimsKey).(jwtutil.Claims)
+		if claims.TenantID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		res, err := meter.Consume(r.Context(), claims.TenantID, metering.Requests, 1)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if res.State == metering.Exceeded {
+			quotaRejections.WithLabelValues(string(res.Metric)).Inc()
+			metering.Reject(w, res)
+			return
+		}
+		metering.SetHeaders(w, res)
+		next.ServeHTTP(w, r)
+	})
+}
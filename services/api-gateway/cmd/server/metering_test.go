@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
+)
+
+// usageStore counts per tenant and metric, whatever the day.
+type usageStore struct {
+	counts map[string]int64
+	plans  map[string]string
+}
+
+func (s *usageStore) Add(ctx context.Context, tenant string, metric metering.Metric, day time.Time, n, limit int64) (int64, bool, error) {
+	k := tenant + ":" + string(metric)
+	if limit > 0 && s.counts[k]+n > limit {
+		return s.counts[k], false, nil
+	}
+	s.counts[k] += n
+	return s.counts[k], true, nil
+}
+
+func (s *usageStore) Get(ctx context.Context, tenant string, metric metering.Metric, days []time.Time) ([]int64, error) {
+	return make([]int64, len(days)), nil
+}
+
+func (s *usageStore) Plan(ctx context.Context, tenant string) (string, error) {
+	return s.plans[tenant], nil
+}
+
+func (s *usageStore) SetPlan(ctx context.Context, tenant, plan string) error {
+	s.plans[tenant] = plan
+	return nil
+}
+
+func TestRegisterRoutes_Quota(t *testing.T) {
+	var calls atomic.Int32
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/health" {
+			calls.Add(1)
+		}
+	}))
+	defer up.Close()
+	cfg := makeCfg()
+	cfg.GatewayRoutesFile = filepath.Join(t.TempDir(), "routes.yaml")
+	writeRoutes(t, cfg.GatewayRoutesFile, up.URL, `
+  - prefix: /api/v1/students/
+    upstream: svc
+    auth: true
+  - prefix: /api/v1/public/
+    upstream: svc
+`)
+	hard := metering.Plans["free"].Quotas[metering.Requests].Hard
+	store := &usageStore{counts: map[string]int64{"t1:requests": hard - 1}, plans: map[string]string{"t1": "free"}}
+	mux := http.NewServeMux()
+	if err := registerRoutes(mux, cfg, newPoolSet(), nil, nil, nil, metering.New(store)); err != nil {
+		t.Fatal(err)
+	}
+	tok, _ := jwtutil.GenerateAccessWith(cfg.JWTAccessSecret, time.Minute, jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"}, cfg.JWTIssuer, cfg.JWTAudience)
+	call := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+tok)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// The last request of the day goes through with a warning
+	rr := call("/api/v1/students/1")
+	if rr.Code != http.StatusOK || rr.Header().Get(metering.WarningHeader) == "" {
+		t.Fatalf("code=%d warning=%q", rr.Code, rr.Header().Get(metering.WarningHeader))
+	}
+	rr = call("/api/v1/students/1")
+	if rr.Code != http.StatusTooManyRequests || rr.Header().Get("Retry-After") == "" || calls.Load() != 1 {
+		t.Fatalf("over quota code=%d calls=%d", rr.Code, calls.Load())
+	}
+	// Public routes are not metered
+	if rr := call("/api/v1/public/x"); rr.Code != http.StatusOK {
+		t.Fatalf("public code=%d", rr.Code)
+	}
+	if store.counts["t1:requests"] != hard {
+		t.Fatalf("requests=%d want %d", store.counts["t1:requests"], hard)
+	}
+}
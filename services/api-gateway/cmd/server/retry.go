@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	httpx "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	maxRetries = 5
+	// maxRetryBody bounds the request bodies kept in memory for replay;
+	// larger requests are sent once.
+	maxRetryBody = 1 << 20
+	budgetWindow = 10 * time.Second
+)
+
+var upstreamRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_upstream_retries_total",
+	Help: "Extra upstream attempts per pool and kind (retry, hedge, denied).",
+}, []string{"pool", "kind"})
+
+// retryBudget lets retries and hedges add at most ratio extra load to a pool,
+// so a failing pool is not hit harder the worse it gets. Counts are kept per
+// budgetWindow.
+type retryBudget struct {
+	mu       sync.Mutex
+	cfg      budgetConfig
+	start    time.Time
+	requests int
+	retries  int
+}
+
+func newRetryBudget(cfg budgetConfig) *retryBudget {
+	return &retryBudget{cfg: cfg.withDefaults(), start: time.Now()}
+}
+
+func (b *retryBudget) roll() {
+	if time.Since(b.start) >= budgetWindow {
+		b.start, b.requests, b.retries = time.Now(), 0, 0
+	}
+}
+
+func (b *retryBudget) request() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.roll()
+	b.requests++
+}
+
+// spend reports whether one more attempt fits the budget and records it.
+func (b *retryBudget) spend() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.roll()
+	if float64(b.retries) >= b.cfg.Ratio*float64(b.requests)+float64(b.cfg.MinRetries) {
+		return false
+	}
+	b.retries++
+	return true
+}
+
+func retryable(status int) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// handler serves the pool with a route's retry and hedge policies.
+func (p *pool) handler(retry *retryPolicy, hedge *hedgePolicy) http.Handler {
+	if retry == nil && hedge == nil {
+		return p
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.budget.request()
+		if hedge != nil && r.Method == http.MethodGet && (r.Body == nil || r.Body == http.NoBody) {
+			p.hedged(w, r, hedge.Delay)
+			return
+		}
+		if retry == nil || !idempotent(r.Method) {
+			p.ServeHTTP(w, r)
+			return
+		}
+		p.retried(w, r, retry)
+	})
+}
+
+// retried sends r to one instance after another until one answers with
+// something other than a retryable error, the attempts or the budget run
+// out, or the route deadline passes. Responses are streamed: only a
+// retryable one is held back, and only when another attempt will follow.
+func (p *pool) retried(w http.ResponseWriter, r *http.Request, retry *retryPolicy) {
+	body, ok := bufferBody(r)
+	attempts := 1 + retry.Attempts
+	if !ok {
+		attempts = 1
+	}
+	var tried []*upstream
+	for i := 0; i < attempts; i++ {
+		u := p.pick(tried...)
+		if u == nil {
+			httpx.Error(w, http.StatusServiceUnavailable, "1001", "No healthy upstreams", nil)
+			return
+		}
+		tried = append(tried, u)
+		last := i == attempts-1
+		rw := &retryWriter{w: w, header: http.Header{}, mayRetry: func() bool {
+			if last || r.Context().Err() != nil || !p.available(tried) {
+				return false
+			}
+			if !p.budget.spend() {
+				upstreamRetries.WithLabelValues(p.name, "denied").Inc()
+				return false
+			}
+			upstreamRetries.WithLabelValues(p.name, "retry").Inc()
+			return true
+		}}
+		attempt := r
+		ctx, cancel := r.Context(), context.CancelFunc(func() {})
+		if retry.PerTryTimeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, retry.PerTryTimeout)
+		}
+		if body != nil || ctx != r.Context() {
+			attempt = r.Clone(ctx)
+			if body != nil {
+				attempt.Body = io.NopCloser(bytes.NewReader(body))
+			}
+		}
+		p.forward(u, rw, attempt)
+		cancel()
+		if !rw.held {
+			return
+		}
+	}
+}
+
+// bufferBody reads r's body so it can be replayed. It reports false when the
+// body is too large, in which case r keeps an equivalent body.
+func bufferBody(r *http.Request) ([]byte, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, true
+	}
+	b, err := io.ReadAll(io.LimitReader(r.Body, maxRetryBody+1))
+	if err != nil || len(b) > maxRetryBody {
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(b), r.Body))
+		return nil, false
+	}
+	return b, true
+}
+
+// retryWriter holds back a retryable response when mayRetry agrees, and
+// otherwise writes through to w. Headers are staged so a discarded attempt
+// leaves nothing behind.
+type retryWriter struct {
+	w        http.ResponseWriter
+	header   http.Header
+	mayRetry func() bool
+	decided  bool
+	held     bool
+}
+
+func (rw *retryWriter) Header() http.Header {
+	return rw.header
+}
+
+func (rw *retryWriter) WriteHeader(code int) {
+	if rw.decided {
+		return
+	}
+	rw.decided = true
+	if retryable(code) && rw.mayRetry() {
+		rw.held = true
+		return
+	}
+	h := rw.w.Header()
+	for k, v := range rw.header {
+		h[k] = v
+	}
+	rw.w.WriteHeader(code)
+}
+
+func (rw *retryWriter) Write(b []byte) (int, error) {
+	if !rw.decided {
+		rw.WriteHeader(http.StatusOK)
+	}
+	if rw.held {
+		return len(b), nil
+	}
+	return rw.w.Write(b)
+}
+
+func (rw *retryWriter) Flush() {
+	if rw.decided && !rw.held {
+		_ = http.NewResponseController(rw.w).Flush()
+	}
+}
+
+// hedged sends r to a second instance if the first has not answered within
+// delay and writes whichever good response completes first; the other
+// request is cancelled. Responses are buffered, which is why hedging is
+// reserved for GETs on routes that opt in.
+func (p *pool) hedged(w http.ResponseWriter, r *http.Request, delay time.Duration) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	results := make(chan *bufferedResponse, 2)
+	launch := func(u *upstream) {
+		go func() {
+			br := &bufferedResponse{header: http.Header{}, status: http.StatusOK}
+			p.forward(u, br, r.WithContext(ctx))
+			results <- br
+		}()
+	}
+
+	first := p.pick()
+	if first == nil {
+		httpx.Error(w, http.StatusServiceUnavailable, "1001", "No healthy upstreams", nil)
+		return
+	}
+	launch(first)
+	pending := 1
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			if !p.budget.spend() {
+				upstreamRetries.WithLabelValues(p.name, "denied").Inc()
+				continue
+			}
+			if u := p.pick(first); u != nil {
+				upstreamRetries.WithLabelValues(p.name, "hedge").Inc()
+				launch(u)
+				pending++
+			}
+		case br := <-results:
+			pending--
+			if !retryable(br.status) || pending == 0 {
+				br.writeTo(w)
+				return
+			}
+		}
+	}
+}
+
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (br *bufferedResponse) Header() http.Header         { return br.header }
+func (br *bufferedResponse) WriteHeader(code int)        { br.status = code }
+func (br *bufferedResponse) Write(b []byte) (int, error) { return br.body.Write(b) }
+func (br *bufferedResponse) Flush()                      {}
+
+func (br *bufferedResponse) writeTo(w http.ResponseWriter) {
+	h := w.Header()
+	for k, v := range br.header {
+		h[k] = v
+	}
+	w.WriteHeader(br.status)
+	_, _ = w.Write(br.body.Bytes())
+}
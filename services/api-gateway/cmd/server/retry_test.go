@@ -0,0 +1,115 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// instance is a test upstream answering with status and counting calls.
+func instance(t *testing.T, status int, delay time.Duration, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// testPool sends the first request to urls[0].
+func testPool(name string, spec upstreamPool, urls ...string) *pool {
+	spec.HealthCheck.Disabled = true
+	p := newPool(name, spec, urls)
+	p.next.Store(uint32(len(urls) - 1))
+	return p
+}
+
+func TestRetry_NextInstance(t *testing.T) {
+	var bad, good atomic.Int32
+	p := testPool("retry", upstreamPool{}, instance(t, http.StatusServiceUnavailable, 0, &bad).URL, instance(t, http.StatusOK, 0, &good).URL)
+	h := p.handler(&retryPolicy{Attempts: 2}, nil)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/x", strings.NewReader("payload")))
+	if rr.Code != http.StatusOK || bad.Load() != 1 || good.Load() != 1 {
+		t.Fatalf("code=%d bad=%d good=%d", rr.Code, bad.Load(), good.Load())
+	}
+	if rr.Body.String() != "payload" {
+		t.Fatalf("body not replayed: %q", rr.Body.String())
+	}
+}
+
+func TestRetry_NotIdempotent(t *testing.T) {
+	var bad, good atomic.Int32
+	p := testPool("post", upstreamPool{}, instance(t, http.StatusServiceUnavailable, 0, &bad).URL, instance(t, http.StatusOK, 0, &good).URL)
+	h := p.handler(&retryPolicy{Attempts: 2}, nil)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/x", strings.NewReader("{}")))
+	if rr.Code != http.StatusServiceUnavailable || good.Load() != 0 {
+		t.Fatalf("POST retried: code=%d good=%d", rr.Code, good.Load())
+	}
+}
+
+func TestRetry_Budget(t *testing.T) {
+	var a, b atomic.Int32
+	spec := upstreamPool{Breaker: breakerConfig{Threshold: 100}, RetryBudget: budgetConfig{Ratio: 0.1, MinRetries: 1}}
+	p := testPool("budget", spec, instance(t, http.StatusServiceUnavailable, 0, &a).URL, instance(t, http.StatusServiceUnavailable, 0, &b).URL)
+	h := p.handler(&retryPolicy{Attempts: 1}, nil)
+
+	for i := 0; i < 10; i++ {
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/x", nil))
+		if rr.Code != http.StatusServiceUnavailable {
+			t.Fatalf("code=%d", rr.Code)
+		}
+	}
+	// Retries stay below 0.1 per request plus one
+	if total := a.Load() + b.Load(); total != 12 {
+		t.Fatalf("upstream calls=%d want 12", total)
+	}
+}
+
+func TestRetry_PerTryTimeout(t *testing.T) {
+	var slow, fast atomic.Int32
+	p := testPool("pertry", upstreamPool{}, instance(t, http.StatusOK, time.Second, &slow).URL, instance(t, http.StatusOK, 0, &fast).URL)
+	h := p.handler(&retryPolicy{Attempts: 1, PerTryTimeout: 50 * time.Millisecond}, nil)
+
+	start := time.Now()
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/x", nil))
+	if rr.Code != http.StatusOK || fast.Load() != 1 {
+		t.Fatalf("code=%d fast=%d", rr.Code, fast.Load())
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatal("per-try timeout not applied")
+	}
+}
+
+func TestHedge_FastestWins(t *testing.T) {
+	var slow, fast atomic.Int32
+	p := testPool("hedge", upstreamPool{}, instance(t, http.StatusOK, time.Second, &slow).URL, instance(t, http.StatusOK, 0, &fast).URL)
+	h := p.handler(nil, &hedgePolicy{Delay: 20 * time.Millisecond})
+
+	start := time.Now()
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/x", nil))
+	if rr.Code != http.StatusOK || slow.Load() != 1 || fast.Load() != 1 {
+		t.Fatalf("code=%d slow=%d fast=%d", rr.Code, slow.Load(), fast.Load())
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatal("hedged request waited for the slow instance")
+	}
+}
@@ -0,0 +1,370 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed routes.yaml
+var defaultRoutes []byte
+
+// Every route allows each caller this many reads and writes a minute, on top
+// of its own rate_limit.
+const (
+	defaultReadLimit  = 100
+	defaultWriteLimit = 30
+)
+
+type routeConfig struct {
+	Upstreams map[string]upstreamPool `yaml:"upstreams"`
+	Routes    []route                 `yaml:"routes"`
+	GraphQL   graphqlConfig           `yaml:"graphql"`
+}
+
+type upstreamPool struct {
+	URLs        []string      `yaml:"urls"`
+	Env         string        `yaml:"env"`
+	Strategy    string        `yaml:"strategy"`
+	Weights     []int         `yaml:"weights"`
+	HealthCheck healthCheck   `yaml:"health_check"`
+	Breaker     breakerConfig `yaml:"breaker"`
+	Timeouts    poolTimeouts  `yaml:"timeouts"`
+	RetryBudget budgetConfig  `yaml:"retry_budget"`
+}
+
+type poolTimeouts struct {
+	Connect        time.Duration `yaml:"connect"`
+	ResponseHeader time.Duration `yaml:"response_header"`
+}
+
+// budgetConfig caps retries and hedges at Ratio of a pool's requests, plus
+// MinRetries per budget window so quiet pools can still retry.
+type budgetConfig struct {
+	Ratio      float64 `yaml:"ratio"`
+	MinRetries int     `yaml:"min_retries"`
+}
+
+type healthCheck struct {
+	Disabled           bool          `yaml:"disabled"`
+	Path               string        `yaml:"path"`
+	Interval           time.Duration `yaml:"interval"`
+	Timeout            time.Duration `yaml:"timeout"`
+	UnhealthyThreshold int           `yaml:"unhealthy_threshold"`
+}
+
+type breakerConfig struct {
+	Threshold int           `yaml:"threshold"`
+	OpenFor   time.Duration `yaml:"open_for"`
+}
+
+func (h healthCheck) withDefaults() healthCheck {
+	if h.Path == "" {
+		h.Path = "/api/v1/health"
+	}
+	if h.Interval == 0 {
+		h.Interval = 10 * time.Second
+	}
+	if h.Timeout == 0 {
+		h.Timeout = 2 * time.Second
+	}
+	if h.UnhealthyThreshold == 0 {
+		h.UnhealthyThreshold = 2
+	}
+	return h
+}
+
+func (t poolTimeouts) withDefaults() (connect, responseHeader time.Duration) {
+	connect, responseHeader = t.Connect, t.ResponseHeader
+	if connect == 0 {
+		connect = 5 * time.Second
+	}
+	if responseHeader == 0 {
+		responseHeader = 30 * time.Second
+	}
+	return connect, responseHeader
+}
+
+func (b budgetConfig) withDefaults() budgetConfig {
+	if b.Ratio == 0 {
+		b.Ratio = 0.2
+	}
+	if b.MinRetries == 0 {
+		b.MinRetries = 10
+	}
+	return b
+}
+
+func (b breakerConfig) withDefaults() (int, time.Duration) {
+	threshold, openFor := b.Threshold, b.OpenFor
+	if threshold == 0 {
+		threshold = 5
+	}
+	if openFor == 0 {
+		openFor = 30 * time.Second
+	}
+	return threshold, openFor
+}
+
+type route struct {
+	Prefix      string                 `yaml:"prefix"`
+	Upstream    string                 `yaml:"upstream"`
+	Auth        bool                   `yaml:"auth"`
+	RateLimit   int                    `yaml:"rate_limit"`
+	RateLimitBy middleware.RateLimitBy `yaml:"rate_limit_by"`
+	Timeout     time.Duration          `yaml:"timeout"`
+	Rewrite     string                 `yaml:"rewrite"`
+	Cache       *routeCache            `yaml:"cache"`
+	Retry       *retryPolicy           `yaml:"retry"`
+	Hedge       *hedgePolicy           `yaml:"hedge"`
+}
+
+type routeCache struct {
+	TTL time.Duration `yaml:"ttl"`
+}
+
+// retryPolicy resends idempotent requests that failed with a transport
+// error, 502, 503 or 504 to another instance, up to Attempts more times.
+type retryPolicy struct {
+	Attempts      int           `yaml:"attempts"`
+	PerTryTimeout time.Duration `yaml:"per_try_timeout"`
+}
+
+// hedgePolicy sends a GET to a second instance when the first has not
+// answered within Delay; the first good response wins.
+type hedgePolicy struct {
+	Delay time.Duration `yaml:"delay"`
+}
+
+// loadRouteConfig reads the route file at path, or the embedded defaults when
+// path is empty. JSON files are accepted as well, being valid YAML.
+func loadRouteConfig(path string) (routeConfig, error) {
+	b := defaultRoutes
+	if path != "" {
+		var err error
+		if b, err = os.ReadFile(path); err != nil {
+			return routeConfig{}, err
+		}
+	}
+	return parseRouteConfig(b)
+}
+
+func parseRouteConfig(b []byte) (routeConfig, error) {
+	var rc routeConfig
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(&rc); err != nil {
+		return rc, fmt.Errorf("parse routes: %w", err)
+	}
+	return rc, rc.validate()
+}
+
+func (rc routeConfig) validate() error {
+	for name, p := range rc.Upstreams {
+		switch p.Strategy {
+		case "", strategyRoundRobin, strategyLeastConn, strategyWeighted:
+		default:
+			return fmt.Errorf("upstream %s: unknown strategy %q", name, p.Strategy)
+		}
+		for _, w := range p.Weights {
+			if w < 1 {
+				return fmt.Errorf("upstream %s: weights must be positive", name)
+			}
+		}
+		hc, br, to, rb := p.HealthCheck, p.Breaker, p.Timeouts, p.RetryBudget
+		if hc.Interval < 0 || hc.Timeout < 0 || hc.UnhealthyThreshold < 0 || br.Threshold < 0 || br.OpenFor < 0 ||
+			to.Connect < 0 || to.ResponseHeader < 0 || rb.MinRetries < 0 {
+			return fmt.Errorf("upstream %s: health_check, breaker, timeouts and retry_budget settings must not be negative", name)
+		}
+		if rb.Ratio < 0 || rb.Ratio > 1 {
+			return fmt.Errorf("upstream %s: retry_budget ratio must be between 0 and 1", name)
+		}
+	}
+	if g := rc.GraphQL; g.RateLimit < 0 || g.MaxCalls < 0 || g.MaxDepth < 0 {
+		return fmt.Errorf("graphql: rate_limit, max_calls and max_depth must not be negative")
+	}
+	seen := map[string]bool{graphqlPath: !rc.GraphQL.Disabled}
+	for i, rt := range rc.Routes {
+		switch {
+		case !strings.HasPrefix(rt.Prefix, "/"):
+			return fmt.Errorf("route %d: prefix must start with /", i)
+		case seen[rt.Prefix] || seen[strings.TrimSuffix(rt.Prefix, "/")]:
+			return fmt.Errorf("route %s: duplicate prefix", rt.Prefix)
+		case rt.Upstream == "":
+			return fmt.Errorf("route %s: upstream required", rt.Prefix)
+		case rt.RateLimit < 0 || rt.Timeout < 0:
+			return fmt.Errorf("route %s: rate_limit and timeout must not be negative", rt.Prefix)
+		case rt.RateLimitBy != "" && rt.RateLimitBy != middleware.ByCaller && rt.RateLimitBy != middleware.ByTenant && rt.RateLimitBy != middleware.ByIP:
+			return fmt.Errorf("route %s: rate_limit_by must be caller, tenant or ip", rt.Prefix)
+		case rt.Rewrite != "" && !strings.HasPrefix(rt.Rewrite, "/"):
+			return fmt.Errorf("route %s: rewrite must start with /", rt.Prefix)
+		case rt.Cache != nil && rt.Cache.TTL <= 0:
+			return fmt.Errorf("route %s: cache ttl must be positive", rt.Prefix)
+		case rt.Retry != nil && (rt.Retry.Attempts < 1 || rt.Retry.Attempts > maxRetries || rt.Retry.PerTryTimeout < 0):
+			return fmt.Errorf("route %s: retry attempts must be between 1 and %d", rt.Prefix, maxRetries)
+		case rt.Hedge != nil && rt.Hedge.Delay <= 0:
+			return fmt.Errorf("route %s: hedge delay must be positive", rt.Prefix)
+		}
+		if _, ok := rc.Upstreams[rt.Upstream]; !ok {
+			return fmt.Errorf("route %s: unknown upstream %q", rt.Prefix, rt.Upstream)
+		}
+		// A subtree prefix also claims its bare path
+		seen[rt.Prefix] = true
+		seen[strings.TrimSuffix(rt.Prefix, "/")] = true
+	}
+	return nil
+}
+
+// urls resolves the instances of a pool, falling back to its env vars.
+func (p upstreamPool) urls() []string {
+	if len(p.URLs) > 0 {
+		return p.URLs
+	}
+	if p.Env == "" {
+		return nil
+	}
+	return parseUpstreams(p.Env)
+}
+
+// withRewrite replaces the matched prefix of the path with to. A bare path
+// matched by a subtree prefix ("/a" for "/a/") maps to to without its slash.
+func withRewrite(prefix, to string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, prefix)
+		target := to + rest
+		if !ok {
+			target = strings.TrimSuffix(to, "/")
+		}
+		r = r.Clone(r.Context())
+		r.URL.Path = target
+		r.URL.RawPath = ""
+		next.ServeHTTP(w, r)
+	})
+}
+
+func withTimeout(d time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), d)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// routeTable serves requests through the most recently built mux. A reload
+// swaps the whole mux at once; requests already in flight finish on the mux
+// they started with, so nothing is dropped.
+type routeTable struct {
+	build   func() (*http.ServeMux, error)
+	current atomic.Pointer[http.ServeMux]
+	mu      sync.Mutex
+}
+
+func newRouteTable(build func() (*http.ServeMux, error)) (*routeTable, error) {
+	t := &routeTable{build: build}
+	if err := t.reload(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *routeTable) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t.current.Load().ServeHTTP(w, r)
+}
+
+// reload rebuilds the routes. On error the previous routes stay in place.
+func (t *routeTable) reload() (err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// ServeMux panics on patterns it cannot register
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("register routes: %v", p)
+		}
+	}()
+	mux, err := t.build()
+	if err != nil {
+		return err
+	}
+	t.current.Store(mux)
+	return nil
+}
+
+// watch reloads the routes on SIGHUP and, when path is set, whenever the
+// file changes. The directory is watched rather than the file so editors
+// that replace the file and Kubernetes ConfigMap symlink swaps are seen too.
+func (t *routeTable) watch(ctx context.Context, path string, l *zap.Logger) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var events chan fsnotify.Event
+	var errs chan error
+	if path != "" {
+		w, err := fsnotify.NewWatcher()
+		if err == nil {
+			err = w.Add(filepath.Dir(path))
+		}
+		if err != nil {
+			l.Error("watch routes failed", zap.String("path", path), zap.Error(err))
+		} else {
+			defer w.Close()
+			events, errs = w.Events, w.Errors
+		}
+	}
+
+	last, _ := os.ReadFile(path)
+	reload := func(reason string) {
+		if err := t.reload(); err != nil {
+			l.Error("reload routes failed, keeping previous routes", zap.String("reason", reason), zap.Error(err))
+			return
+		}
+		l.Info("routes reloaded", zap.String("reason", reason))
+	}
+	// Writes usually arrive as several events; settle before reloading
+	debounce := time.NewTimer(time.Hour)
+	debounce.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			reload("sighup")
+		case _, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			debounce.Reset(200 * time.Millisecond)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			l.Error("watch routes failed", zap.String("path", path), zap.Error(err))
+		case <-debounce.C:
+			b, err := os.ReadFile(path)
+			if errors.Is(err, os.ErrNotExist) || (err == nil && bytes.Equal(b, last)) {
+				continue
+			}
+			last = b
+			reload("file changed")
+		}
+	}
+}
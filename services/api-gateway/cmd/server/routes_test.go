@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+	"go.uber.org/zap"
+)
+
+func TestParseRouteConfig(t *testing.T) {
+	if _, err := loadRouteConfig(""); err != nil {
+		t.Fatalf("embedded routes invalid: %v", err)
+	}
+	bad := map[string]string{
+		"unknown field":    "routes:\n  - prefix: /a/\n    upstream: a\n    retries: 3\nupstreams:\n  a: {urls: [http://a]}\n",
+		"relative prefix":  "routes:\n  - prefix: a/\n    upstream: a\nupstreams:\n  a: {urls: [http://a]}\n",
+		"unknown upstream": "routes:\n  - prefix: /a/\n    upstream: b\nupstreams:\n  a: {urls: [http://a]}\n",
+		"duplicate bare":   "routes:\n  - prefix: /a/\n    upstream: a\n  - prefix: /a\n    upstream: a\nupstreams:\n  a: {urls: [http://a]}\n",
+		"bad rewrite":      "routes:\n  - prefix: /a/\n    upstream: a\n    rewrite: b/\nupstreams:\n  a: {urls: [http://a]}\n",
+		"bad timeout":      "routes:\n  - prefix: /a/\n    upstream: a\n    timeout: soon\nupstreams:\n  a: {urls: [http://a]}\n",
+		"too many retries": "routes:\n  - prefix: /a/\n    upstream: a\n    retry: {attempts: 9}\nupstreams:\n  a: {urls: [http://a]}\n",
+		"rate limit key":   "routes:\n  - prefix: /a/\n    upstream: a\n    rate_limit: 5\n    rate_limit_by: session\nupstreams:\n  a: {urls: [http://a]}\n",
+		"hedge no delay":   "routes:\n  - prefix: /a/\n    upstream: a\n    hedge: {}\nupstreams:\n  a: {urls: [http://a]}\n",
+		"budget ratio":     "routes:\n  - prefix: /a/\n    upstream: a\nupstreams:\n  a: {urls: [http://a], retry_budget: {ratio: 2}}\n",
+		"graphql depth":    "routes:\n  - prefix: /a/\n    upstream: a\nupstreams:\n  a: {urls: [http://a]}\ngraphql: {max_depth: -1}\n",
+	}
+	for name, in := range bad {
+		if _, err := parseRouteConfig([]byte(in)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	rc, err := parseRouteConfig([]byte(`{"upstreams": {"a": {"urls": ["http://a"]}}, "routes": [{"prefix": "/a/", "upstream": "a", "timeout": "2s"}]}`))
+	if err != nil || rc.Routes[0].Timeout != 2*time.Second {
+		t.Fatalf("json routes: %+v err=%v", rc, err)
+	}
+}
+
+func TestEmbeddedRoutes_AuthServiceAdmin(t *testing.T) {
+	rc, err := loadRouteConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	byPattern := map[string]route{}
+	for _, rt := range rc.Routes {
+		mux.Handle(rt.Prefix, http.NotFoundHandler())
+		byPattern[rt.Prefix] = rt
+	}
+	for _, path := range []string{
+		"/api/v1/service-accounts",
+		"/api/v1/api-keys/k1",
+		"/api/v1/roles",
+		"/api/v1/roles/r1/permissions",
+		"/api/v1/permissions",
+		"/api/v1/usage",
+		"/api/v1/tenants/t1/usage",
+		"/api/v1/tenants/t1/plan",
+	} {
+		_, pattern := mux.Handler(httptest.NewRequest(http.MethodGet, path, nil))
+		if rt, ok := byPattern[pattern]; !ok || rt.Upstream != "auth" || !rt.Auth {
+			t.Errorf("%s: routed by %q to %+v", path, pattern, rt)
+		}
+	}
+}
+
+func TestEmbeddedRoutes_GuardianPortal(t *testing.T) {
+	rc, err := loadRouteConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	byPattern := map[string]route{}
+	for _, rt := range rc.Routes {
+		handleRoute(mux, rt.Prefix, http.NotFoundHandler())
+		byPattern[strings.TrimSuffix(rt.Prefix, "/")] = rt
+	}
+	for path, upstream := range map[string]string{
+		"/api/v1/guardian/students":                                  "academic",
+		"/api/v1/guardian/students/s1/schedules":                     "academic",
+		"/api/v1/guardian/assessment/students/s1/grades":             "assessment",
+		"/api/v1/guardian/assessment/students/s1/report-cards":       "assessment",
+		"/api/v1/guardian/attendance/students/s1/attendance/summary": "attendance",
+		"/api/v1/guardian/finance/students/s1/invoices":              "finance",
+	} {
+		_, pattern := mux.Handler(httptest.NewRequest(http.MethodGet, path, nil))
+		if rt, ok := byPattern[strings.TrimSuffix(pattern, "/")]; !ok || rt.Upstream != upstream || !rt.Auth {
+			t.Errorf("%s: routed by %q to %+v, want %s", path, pattern, rt, upstream)
+		}
+	}
+}
+
+func writeRoutes(t *testing.T, path, upstream, body string) {
+	t.Helper()
+	in := "upstreams:\n  svc:\n    urls: [" + upstream + "]\nroutes:\n" + body
+	if err := os.WriteFile(path, []byte(in), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegisterRoutes_RouteFile(t *testing.T) {
+	var gotPath string
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/health" {
+			return
+		}
+		gotPath = r.URL.Path
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer up.Close()
+	cfg := makeCfg()
+	cfg.GatewayRoutesFile = filepath.Join(t.TempDir(), "routes.yaml")
+	writeRoutes(t, cfg.GatewayRoutesFile, up.URL, `
+  - prefix: /api/v1/admissions/
+    upstream: svc
+    rewrite: /api/v1/admission/
+  - prefix: /api/v1/slow/
+    upstream: svc
+    rewrite: /slow
+    timeout: 50ms
+`)
+	mux := http.NewServeMux()
+	if err := registerRoutes(mux, cfg, newPoolSet(), nil, nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		"/api/v1/admissions/periods": "/api/v1/admission/periods",
+		"/api/v1/admissions":         "/api/v1/admission",
+	}
+	for in, want := range cases {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, in, nil))
+		if rr.Code != http.StatusOK || gotPath != want {
+			t.Fatalf("%s: code=%d upstream path=%s want %s", in, rr.Code, gotPath, want)
+		}
+	}
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/subjects/", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("route not in file code=%d want 404", rr.Code)
+	}
+	rr = httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/slow/", nil))
+	if rr.Code != http.StatusGatewayTimeout {
+		t.Fatalf("slow upstream code=%d want 504", rr.Code)
+	}
+}
+
+func TestRegisterRoutes_RateLimit(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer up.Close()
+	cfg := makeCfg()
+	cfg.GatewayRoutesFile = filepath.Join(t.TempDir(), "routes.yaml")
+	writeRoutes(t, cfg.GatewayRoutesFile, up.URL, `
+  - prefix: /api/v1/students/
+    upstream: svc
+    auth: true
+    rate_limit: 2
+`)
+	counts := map[string]int64{}
+	mux := http.NewServeMux()
+	if err := registerRoutes(mux, cfg, newPoolSet(), nil, redisutil.NewFixedWindowLimiter(&countingLimiter{counts: counts}), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	call := func(path string, user uuid.UUID) *httptest.ResponseRecorder {
+		tok, _ := jwtutil.GenerateAccessWith(cfg.JWTAccessSecret, time.Minute, jwtutil.Claims{UserID: user, TenantID: "t1"}, cfg.JWTIssuer, cfg.JWTAudience)
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+tok)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+	alice, bob := uuid.New(), uuid.New()
+	// Different student IDs share the route's budget
+	call("/api/v1/students/1", alice)
+	if rr := call("/api/v1/students/2", alice); rr.Code != http.StatusOK || rr.Header().Get("RateLimit-Remaining") != "0" {
+		t.Fatalf("code=%d remaining=%s", rr.Code, rr.Header().Get("RateLimit-Remaining"))
+	}
+	rr := call("/api/v1/students", alice)
+	if rr.Code != http.StatusTooManyRequests || rr.Header().Get("Retry-After") == "" {
+		t.Fatalf("over limit code=%d want 429", rr.Code)
+	}
+	// Another user from the same address has a budget of their own
+	if rr := call("/api/v1/students/1", bob); rr.Code != http.StatusOK {
+		t.Fatalf("second user code=%d want 200", rr.Code)
+	}
+	if counts["ratelimit:route:user:"+alice.String()+":read:/api/v1/students/"] != 3 {
+		t.Fatalf("unexpected counters %v", counts)
+	}
+}
+
+func TestRouteTable_Reload(t *testing.T) {
+	release := make(chan struct{})
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/hold" {
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer up.Close()
+	cfg := makeCfg()
+	cfg.GatewayRoutesFile = filepath.Join(t.TempDir(), "routes.yaml")
+	writeRoutes(t, cfg.GatewayRoutesFile, up.URL, "  - prefix: /api/v1/hold\n    upstream: svc\n")
+	table, err := newRouteTable(func() (*http.ServeMux, error) {
+		mux := http.NewServeMux()
+		return mux, registerRoutes(mux, cfg, newPoolSet(), nil, nil, nil, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := func(path string) int {
+		rr := httptest.NewRecorder()
+		table.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr.Code
+	}
+
+	inFlight := make(chan int)
+	go func() { inFlight <- code("/api/v1/hold") }()
+	time.Sleep(50 * time.Millisecond)
+
+	// The request above keeps running on the old routes while they change
+	writeRoutes(t, cfg.GatewayRoutesFile, up.URL, "  - prefix: /api/v1/new/\n    upstream: svc\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go table.watch(ctx, cfg.GatewayRoutesFile, zap.NewNop())
+	if err := table.reload(); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	if c := <-inFlight; c != http.StatusOK {
+		t.Fatalf("in-flight request code=%d want 200", c)
+	}
+	if code("/api/v1/new/x") != http.StatusOK || code("/api/v1/hold") != http.StatusNotFound {
+		t.Fatal("routes not reloaded")
+	}
+
+	if err := os.WriteFile(cfg.GatewayRoutesFile, []byte("routes: ["), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.reload(); err == nil {
+		t.Fatal("expected invalid file to be rejected")
+	}
+	if code("/api/v1/new/x") != http.StatusOK {
+		t.Fatal("invalid file must keep the previous routes")
+	}
+
+	// The watcher picks up edits on its own
+	writeRoutes(t, cfg.GatewayRoutesFile, up.URL, "  - prefix: /api/v1/watched/\n    upstream: svc\n")
+	deadline := time.Now().Add(3 * time.Second)
+	for code("/api/v1/watched/") != http.StatusOK {
+		if time.Now().After(deadline) {
+			t.Fatal("file change not picked up")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,452 @@
+// Package graphql serves read-only GraphQL queries over resolvers that return
+// decoded JSON. It covers what the gateway's aggregation endpoint needs:
+// queries with aliases, arguments, variables, fragments and the
+// @include/@skip directives. Mutations, subscriptions and introspection are
+// not supported.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Object is an object type. Fields without a selection set are leaves; a
+// leaf may hold any JSON value.
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+// Field is a field of an Object. Type is nil for leaves. Args maps the
+// argument names the field takes to whether they are required.
+type Field struct {
+	Type    *Object
+	Args    map[string]bool
+	Resolve ResolveFunc
+}
+
+// ResolveFunc returns the value of a field: a decoded JSON value, a slice of
+// them for lists, or nil. Fields without one read their name from the
+// parent's JSON object.
+type ResolveFunc func(ctx context.Context, p Params) (any, error)
+
+// Params are what a field is resolved from.
+type Params struct {
+	// Source is the parent value, nil for fields of the query root.
+	Source any
+	Args   map[string]any
+}
+
+// String returns the argument as a string, "" when it is absent or null.
+func (p Params) String(name string) string {
+	switch v := p.Args[name].(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Leaf is a Field that reads its name from the parent's JSON object.
+func Leaf() *Field {
+	return &Field{}
+}
+
+// Leaves returns leaf fields for each name, for building an Object's Fields.
+func Leaves(names ...string) map[string]*Field {
+	fields := make(map[string]*Field, len(names))
+	for _, n := range names {
+		fields[n] = Leaf()
+	}
+	return fields
+}
+
+// Schema is the query root.
+type Schema struct {
+	Query *Object
+	// MaxDepth bounds how deeply selections nest, 10 when zero.
+	MaxDepth int
+}
+
+// Request is a GraphQL request as POSTed in JSON.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response is the result of a request. Data is absent when the request could
+// not be executed at all.
+type Response struct {
+	Data   any     `json:"data,omitempty"`
+	Errors []Error `json:"errors,omitempty"`
+}
+
+// Error reports a request or field failure. Path leads to the field that
+// failed, through response keys and list indices.
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+const (
+	defaultMaxDepth = 10
+	// maxFields bounds the fields a query selects, fragments included.
+	maxFields = 1000
+)
+
+// Execute runs the query in req. Field errors are reported alongside the
+// data, with the failed field null.
+func (s *Schema) Execute(ctx context.Context, req Request) Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return failed(err.Error())
+	}
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return failed(err.Error())
+	}
+	if op.kind != "query" {
+		return failed("only queries are supported")
+	}
+	vars, err := coerceVariables(op, req.Variables)
+	if err != nil {
+		return failed(err.Error())
+	}
+	v := &validator{doc: doc, vars: vars, declared: map[string]bool{}, maxDepth: s.MaxDepth}
+	for _, d := range op.variables {
+		v.declared[d.name] = true
+	}
+	if v.maxDepth == 0 {
+		v.maxDepth = defaultMaxDepth
+	}
+	if err := v.selections(s.Query, op.selections, 1, map[string]bool{}); err != nil {
+		return failed(err.Error())
+	}
+	e := &executor{doc: doc, vars: vars}
+	data := e.object(ctx, s.Query, nil, op.selections, nil)
+	return Response{Data: data, Errors: e.errors}
+}
+
+func failed(msg string) Response {
+	return Response{Errors: []Error{{Message: msg}}}
+}
+
+func (d *document) operation(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, fmt.Errorf("operationName is required when the document has several operations")
+		}
+		return d.operations[0], nil
+	}
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %s", name)
+}
+
+// coerceVariables applies defaults and checks that non-null variables are
+// given. Values are used as decoded from the request.
+func coerceVariables(op *operation, given map[string]any) (map[string]any, error) {
+	vars := map[string]any{}
+	for _, d := range op.variables {
+		v, ok := given[d.name]
+		if !ok && d.hasDefault {
+			v, ok = d.def, true
+		}
+		if (!ok || v == nil) && strings.HasSuffix(d.typ, "!") {
+			return nil, fmt.Errorf("variable $%s of type %s is required", d.name, d.typ)
+		}
+		if ok {
+			vars[d.name] = v
+		}
+	}
+	return vars, nil
+}
+
+// validator checks a query against the schema before anything is resolved.
+type validator struct {
+	doc      *document
+	vars     map[string]any
+	declared map[string]bool
+	maxDepth int
+	fields   int
+}
+
+func (v *validator) selections(obj *Object, sels []selection, depth int, spreading map[string]bool) error {
+	if depth > v.maxDepth {
+		return fmt.Errorf("query nests deeper than %d", v.maxDepth)
+	}
+	for _, s := range sels {
+		for _, d := range s.directives {
+			if d.name != "include" && d.name != "skip" {
+				return fmt.Errorf("unknown directive @%s", d.name)
+			}
+			if _, ok := d.args["if"]; !ok {
+				return fmt.Errorf("@%s needs an if argument", d.name)
+			}
+			if err := v.variablesDefined(d.args["if"]); err != nil {
+				return err
+			}
+		}
+		if s.inline != nil {
+			if err := v.selections(obj, s.inline.selections, depth, spreading); err != nil {
+				return err
+			}
+			continue
+		}
+		if s.fragment != "" {
+			f, ok := v.doc.fragments[s.fragment]
+			if !ok {
+				return fmt.Errorf("unknown fragment %s", s.fragment)
+			}
+			if spreading[f.name] {
+				return fmt.Errorf("fragment %s spreads itself", f.name)
+			}
+			spreading[f.name] = true
+			err := v.selections(obj, f.selections, depth, spreading)
+			delete(spreading, f.name)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		// Counted after fragments are expanded, so fragments spreading each
+		// other repeatedly cannot blow a small query up
+		if v.fields++; v.fields > maxFields {
+			return fmt.Errorf("query selects more than %d fields", maxFields)
+		}
+		if s.name == "__typename" {
+			continue
+		}
+		f, ok := obj.Fields[s.name]
+		if !ok {
+			return fmt.Errorf("%s has no field %s", obj.Name, s.name)
+		}
+		for arg := range s.args {
+			if _, ok := f.Args[arg]; !ok {
+				return fmt.Errorf("%s.%s has no argument %s", obj.Name, s.name, arg)
+			}
+		}
+		for _, a := range s.args {
+			if err := v.variablesDefined(a); err != nil {
+				return err
+			}
+		}
+		for arg, required := range f.Args {
+			if required && resolveValue(s.args[arg], v.vars) == nil {
+				return fmt.Errorf("%s.%s needs argument %s", obj.Name, s.name, arg)
+			}
+		}
+		switch {
+		case f.Type == nil && len(s.selections) > 0:
+			return fmt.Errorf("%s.%s is a leaf and takes no selection", obj.Name, s.name)
+		case f.Type != nil && len(s.selections) == 0:
+			return fmt.Errorf("%s.%s needs a selection", obj.Name, s.name)
+		case f.Type != nil:
+			if err := v.selections(f.Type, s.selections, depth+1, spreading); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *validator) variablesDefined(value any) error {
+	switch val := value.(type) {
+	case variable:
+		if !v.declared[string(val)] {
+			return fmt.Errorf("variable $%s is not defined", val)
+		}
+	case []any:
+		for _, item := range val {
+			if err := v.variablesDefined(item); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		for _, item := range val {
+			if err := v.variablesDefined(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolveValue replaces variable references in an argument value.
+func resolveValue(value any, vars map[string]any) any {
+	switch v := value.(type) {
+	case variable:
+		return vars[string(v)]
+	case enum:
+		return string(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = resolveValue(item, vars)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = resolveValue(item, vars)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+type executor struct {
+	doc  *document
+	vars map[string]any
+
+	mu     sync.Mutex
+	errors []Error
+}
+
+func (e *executor) fail(path []any, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errors = append(e.errors, Error{Message: err.Error(), Path: path})
+}
+
+// collect flattens fragments and directives into the fields to resolve, by
+// response key in query order. Fields asked for twice under one key have
+// their selections merged.
+func (e *executor) collect(sels []selection, keys []string, fields map[string]*selection) []string {
+	for _, s := range sels {
+		if !e.included(s.directives) {
+			continue
+		}
+		switch {
+		case s.inline != nil:
+			keys = e.collect(s.inline.selections, keys, fields)
+		case s.fragment != "":
+			keys = e.collect(e.doc.fragments[s.fragment].selections, keys, fields)
+		default:
+			k := s.key()
+			if prev, ok := fields[k]; ok {
+				merged := *prev
+				merged.selections = append(append([]selection(nil), prev.selections...), s.selections...)
+				fields[k] = &merged
+				continue
+			}
+			s := s
+			fields[k] = &s
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func (e *executor) included(ds []directive) bool {
+	for _, d := range ds {
+		cond, _ := resolveValue(d.args["if"], e.vars).(bool)
+		if d.name == "include" && !cond || d.name == "skip" && cond {
+			return false
+		}
+	}
+	return true
+}
+
+// object resolves the selected fields of obj concurrently.
+func (e *executor) object(ctx context.Context, obj *Object, source any, sels []selection, path []any) *orderedMap {
+	fields := map[string]*selection{}
+	keys := e.collect(sels, nil, fields)
+	out := &orderedMap{keys: keys, values: make([]any, len(keys))}
+	var wg sync.WaitGroup
+	for i, k := range keys {
+		s := fields[k]
+		if s.name == "__typename" {
+			out.values[i] = obj.Name
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out.values[i] = e.field(ctx, obj.Fields[s.name], s, source, appendPath(path, k))
+		}()
+	}
+	wg.Wait()
+	return out
+}
+
+func (e *executor) field(ctx context.Context, f *Field, s *selection, source any, path []any) any {
+	var value any
+	if f.Resolve == nil {
+		if m, ok := source.(map[string]any); ok {
+			value = m[s.name]
+		}
+	} else {
+		args := map[string]any{}
+		for k, v := range s.args {
+			args[k] = resolveValue(v, e.vars)
+		}
+		var err error
+		if value, err = f.Resolve(ctx, Params{Source: source, Args: args}); err != nil {
+			e.fail(path, err)
+			return nil
+		}
+	}
+	if f.Type == nil || value == nil {
+		return value
+	}
+	list, ok := value.([]any)
+	if !ok {
+		return e.object(ctx, f.Type, value, s.selections, path)
+	}
+	out := make([]any, len(list))
+	var wg sync.WaitGroup
+	for i, item := range list {
+		if item == nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out[i] = e.object(ctx, f.Type, item, s.selections, appendPath(path, i))
+		}()
+	}
+	wg.Wait()
+	return out
+}
+
+func appendPath(path []any, elem any) []any {
+	return append(append(make([]any, 0, len(path)+1), path...), elem)
+}
+
+// orderedMap is a JSON object that keeps its keys in query order.
+type orderedMap struct {
+	keys   []string
+	values []any
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		kb, _ := json.Marshal(k)
+		buf.Write(kb)
+		buf.WriteByte(':')
+		vb, err := json.Marshal(m.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(vb)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,160 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func testSchema() *Schema {
+	course := &Object{Name: "Course", Fields: Leaves("id", "name")}
+	student := &Object{Name: "Student", Fields: Leaves("id", "name")}
+	student.Fields["courses"] = &Field{Type: course, Resolve: func(ctx context.Context, p Params) (any, error) {
+		return []any{
+			map[string]any{"id": "c1", "name": "Math"},
+			map[string]any{"id": "c2", "name": "Art"},
+		}, nil
+	}}
+	student.Fields["fees"] = &Field{Resolve: func(ctx context.Context, p Params) (any, error) {
+		return nil, errors.New("finance unavailable")
+	}}
+	return &Schema{Query: &Object{Name: "Query", Fields: map[string]*Field{
+		"student": {Type: student, Args: map[string]bool{"id": true}, Resolve: func(ctx context.Context, p Params) (any, error) {
+			return map[string]any{"id": p.String("id"), "name": "Ani", "secret": "x"}, nil
+		}},
+	}}}
+}
+
+func run(t *testing.T, req Request) string {
+	t.Helper()
+	b, err := json.Marshal(testSchema().Execute(context.Background(), req))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name string
+		req  Request
+		want string
+	}{
+		{
+			name: "fields in query order with aliases",
+			req:  Request{Query: `{ s: student(id: "1") { name id courses { name } } }`},
+			want: `{"data":{"s":{"name":"Ani","id":"1","courses":[{"name":"Math"},{"name":"Art"}]}}}`,
+		},
+		{
+			name: "variables, fragments and directives",
+			req: Request{
+				Query: `query Dash($id: ID!, $full: Boolean = false) {
+					student(id: $id) { ...Basic courses @include(if: $full) { id } __typename }
+				}
+				fragment Basic on Student { id name @skip(if: true) }`,
+				Variables: map[string]any{"id": "7"},
+			},
+			want: `{"data":{"student":{"id":"7","__typename":"Student"}}}`,
+		},
+		{
+			name: "field errors leave the rest of the data",
+			req:  Request{Query: `{ student(id: "1") { name fees } }`},
+			want: `{"data":{"student":{"name":"Ani","fees":null}},"errors":[{"message":"finance unavailable","path":["student","fees"]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := run(t, tt.req); got != tt.want {
+				t.Fatalf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecute_Invalid(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`{ student(id: "1") { secret } }`, "Student has no field secret"},
+		{`{ student { id } }`, "needs argument id"},
+		{`{ student(id: "1", year: 2) { id } }`, "has no argument year"},
+		{`{ student(id: "1") }`, "needs a selection"},
+		{`{ student(id: "1") { name { x } } }`, "is a leaf"},
+		{`{ student(id: $id) { id } }`, "variable $id is not defined"},
+		{`query($id: ID!) { student(id: $id) { id } }`, "variable $id of type ID! is required"},
+		{`mutation { student(id: "1") { id } }`, "only queries"},
+		{`{ student(id: "1") { ...A } } fragment A on Student { ...A }`, "spreads itself"},
+		{`{ student(id: "1") { id `, "syntax error"},
+		{`{ student(id: "1") { id @cached } }`, "unknown directive"},
+		{`query A { student(id: "1") { id } } query B { student(id: "2") { id } }`, "operationName is required"},
+	}
+	for _, tt := range tests {
+		got := run(t, Request{Query: tt.query})
+		if strings.Contains(got, `"data"`) || !strings.Contains(got, tt.want) {
+			t.Errorf("%s: got %s, want error %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestExecute_Limits(t *testing.T) {
+	s := testSchema()
+	s.MaxDepth = 2
+	got := s.Execute(context.Background(), Request{Query: `{ student(id: "1") { courses { id } } }`})
+	if len(got.Errors) == 0 || !strings.Contains(got.Errors[0].Message, "deeper than 2") {
+		t.Fatalf("depth: %+v", got)
+	}
+
+	// Each fragment doubles the one before it
+	q := `{ student(id: "1") { ...F0 } } fragment F10 on Student { id }`
+	for i := 0; i < 10; i++ {
+		next := "...F" + strconv.Itoa(i+1)
+		q += " fragment F" + strconv.Itoa(i) + " on Student { " + next + " " + next + " }"
+	}
+	got = testSchema().Execute(context.Background(), Request{Query: q})
+	if len(got.Errors) == 0 || !strings.Contains(got.Errors[0].Message, "more than 1000 fields") {
+		t.Fatalf("fields: %+v", got)
+	}
+
+	// The parser gives up on deep nesting before the depth check runs
+	for _, q := range []string{
+		strings.Repeat("{ a ", 200) + strings.Repeat("}", 200),
+		`{ student(id: ` + strings.Repeat("[", 200) + strings.Repeat("]", 200) + `) { id } }`,
+	} {
+		got = testSchema().Execute(context.Background(), Request{Query: q})
+		if len(got.Errors) == 0 || !strings.Contains(got.Errors[0].Message, "nested too deeply") {
+			t.Fatalf("nesting: %+v", got)
+		}
+	}
+}
+
+// FuzzExecute checks that no query, however malformed, panics or yields a
+// response that cannot be encoded.
+func FuzzExecute(f *testing.F) {
+	for _, q := range []string{
+		`{ s: student(id: "1") { name id courses { name } } }`,
+		`query Dash($id: ID!, $full: Boolean = false) { student(id: $id) { ...Basic courses @include(if: $full) { id } } } fragment Basic on Student { id name @skip(if: true) }`,
+		`{ student(id: "1") { ... on Student { id } fees } }`,
+		`{ student(id: "1", x: [1, -2.5e3, {a: "\u00e9"}, null, ENUM]) { id } }`,
+		`{ student(id: "1") { ...A } } fragment A on Student { ...A }`,
+		`{ student(id: "1") { id `,
+		`"""block""" { a }`,
+	} {
+		f.Add(q, `{"id":"1","full":true}`)
+	}
+	s := testSchema()
+	f.Fuzz(func(t *testing.T, query, variables string) {
+		var vars map[string]any
+		_ = json.Unmarshal([]byte(variables), &vars)
+		got := s.Execute(context.Background(), Request{Query: query, Variables: vars})
+		if got.Data == nil && len(got.Errors) == 0 {
+			t.Fatalf("no data and no errors for %q", query)
+		}
+		if _, err := json.Marshal(got); err != nil {
+			t.Fatalf("encode %q: %v", query, err)
+		}
+	})
+}
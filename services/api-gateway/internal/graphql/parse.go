@@ -0,0 +1,441 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind       string
+	name       string
+	variables  []variableDef
+	selections []selection
+}
+
+type variableDef struct {
+	name       string
+	typ        string
+	def        any
+	hasDefault bool
+}
+
+type fragment struct {
+	name       string
+	on         string
+	selections []selection
+}
+
+// selection is a field, or a spread of the named fragment when fragment is
+// set. Inline fragments are kept as spreads of an anonymous fragment.
+type selection struct {
+	alias      string
+	name       string
+	args       map[string]any
+	directives []directive
+	selections []selection
+
+	fragment string
+	inline   *fragment
+}
+
+type directive struct {
+	name string
+	args map[string]any
+}
+
+func (s selection) key() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+// variable is a $name reference in an argument value.
+type variable string
+
+// enum is an enum value, passed to resolvers as its name.
+type enum string
+
+type syntaxError struct {
+	msg string
+	pos int
+}
+
+func (e *syntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d: %s", e.pos, e.msg)
+}
+
+// maxQueryLength bounds the documents the parser accepts.
+const maxQueryLength = 64 << 10
+
+// maxNesting bounds how deeply selection sets and values nest while parsing,
+// well above any depth Schema.MaxDepth lets through.
+const maxNesting = 128
+
+type parser struct {
+	src   string
+	pos   int
+	depth int
+}
+
+func parse(src string) (doc *document, err error) {
+	if len(src) > maxQueryLength {
+		return nil, fmt.Errorf("query longer than %d bytes", maxQueryLength)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			se, ok := r.(*syntaxError)
+			if !ok {
+				panic(r)
+			}
+			doc, err = nil, se
+		}
+	}()
+	p := &parser{src: src}
+	doc = &document{fragments: map[string]*fragment{}}
+	p.skip()
+	for p.pos < len(p.src) {
+		if p.peek() == '{' {
+			doc.operations = append(doc.operations, &operation{kind: "query", selections: p.selectionSet()})
+			continue
+		}
+		switch kw := p.name(); kw {
+		case "query", "mutation", "subscription":
+			op := &operation{kind: kw}
+			if isNameStart(p.peek()) {
+				op.name = p.name()
+			}
+			if p.peek() == '(' {
+				op.variables = p.variableDefs()
+			}
+			p.directives()
+			op.selections = p.selectionSet()
+			doc.operations = append(doc.operations, op)
+		case "fragment":
+			f := &fragment{name: p.name()}
+			if f.name == "on" {
+				p.fail("fragment cannot be named on")
+			}
+			if p.name() != "on" {
+				p.fail("expected on")
+			}
+			f.on = p.name()
+			p.directives()
+			f.selections = p.selectionSet()
+			if _, dup := doc.fragments[f.name]; dup {
+				p.fail("fragment " + f.name + " defined twice")
+			}
+			doc.fragments[f.name] = f
+		default:
+			p.fail("unexpected " + kw)
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, &syntaxError{msg: "no operation", pos: p.pos}
+	}
+	return doc, nil
+}
+
+func (p *parser) fail(msg string) {
+	panic(&syntaxError{msg: msg, pos: p.pos})
+}
+
+// skip moves past whitespace, commas and comments.
+func (p *parser) skip() {
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			p.pos++
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		case strings.HasPrefix(p.src[p.pos:], "\ufeff"):
+			p.pos += len("\ufeff")
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) peek() byte {
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) expect(c byte) {
+	if p.peek() != c {
+		p.fail("expected " + strconv.QuoteRune(rune(c)))
+	}
+	p.pos++
+	p.skip()
+}
+
+func (p *parser) accept(c byte) bool {
+	if p.peek() != c {
+		return false
+	}
+	p.pos++
+	p.skip()
+	return true
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func (p *parser) name() string {
+	if !isNameStart(p.peek()) {
+		p.fail("expected a name")
+	}
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if !isNameStart(c) && (c < '0' || c > '9') {
+			break
+		}
+		p.pos++
+	}
+	n := p.src[start:p.pos]
+	p.skip()
+	return n
+}
+
+func (p *parser) variableDefs() []variableDef {
+	p.expect('(')
+	var defs []variableDef
+	for !p.accept(')') {
+		p.expect('$')
+		d := variableDef{name: p.name()}
+		p.expect(':')
+		d.typ = p.typeRef()
+		if p.accept('=') {
+			d.def, d.hasDefault = p.value(true), true
+		}
+		p.directives()
+		defs = append(defs, d)
+	}
+	return defs
+}
+
+// typeRef reads a type such as [ID!]! and returns it as written.
+func (p *parser) typeRef() string {
+	var t string
+	if p.accept('[') {
+		t = "[" + p.typeRef() + "]"
+		p.expect(']')
+	} else {
+		t = p.name()
+	}
+	if p.accept('!') {
+		t += "!"
+	}
+	return t
+}
+
+func (p *parser) directives() []directive {
+	var ds []directive
+	for p.accept('@') {
+		d := directive{name: p.name()}
+		if p.peek() == '(' {
+			d.args = p.arguments()
+		}
+		ds = append(ds, d)
+	}
+	return ds
+}
+
+func (p *parser) enter() {
+	p.depth++
+	if p.depth > maxNesting {
+		p.fail("nested too deeply")
+	}
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) selectionSet() []selection {
+	p.enter()
+	defer p.leave()
+	p.expect('{')
+	var sels []selection
+	for !p.accept('}') {
+		if p.pos >= len(p.src) {
+			p.fail("unterminated selection set")
+		}
+		sels = append(sels, p.selection())
+	}
+	if len(sels) == 0 {
+		p.fail("empty selection set")
+	}
+	return sels
+}
+
+func (p *parser) selection() selection {
+	if strings.HasPrefix(p.src[p.pos:], "...") {
+		p.pos += 3
+		p.skip()
+		f := &fragment{}
+		if isNameStart(p.peek()) {
+			// Fragments cannot be named "on", so it starts a type condition
+			if n := p.name(); n != "on" {
+				return selection{fragment: n, directives: p.directives()}
+			}
+			f.on = p.name()
+		}
+		s := selection{inline: f, directives: p.directives()}
+		f.selections = p.selectionSet()
+		return s
+	}
+	s := selection{name: p.name()}
+	if p.accept(':') {
+		s.alias, s.name = s.name, p.name()
+	}
+	if p.peek() == '(' {
+		s.args = p.arguments()
+	}
+	s.directives = p.directives()
+	if p.peek() == '{' {
+		s.selections = p.selectionSet()
+	}
+	return s
+}
+
+func (p *parser) arguments() map[string]any {
+	p.expect('(')
+	args := map[string]any{}
+	for !p.accept(')') {
+		n := p.name()
+		p.expect(':')
+		if _, dup := args[n]; dup {
+			p.fail("argument " + n + " given twice")
+		}
+		args[n] = p.value(false)
+	}
+	return args
+}
+
+// value reads an argument value. Constant values, as in variable defaults,
+// may not refer to variables.
+func (p *parser) value(constant bool) any {
+	switch c := p.peek(); {
+	case c == '$':
+		if constant {
+			p.fail("variable in constant value")
+		}
+		p.pos++
+		return variable(p.name())
+	case c == '"':
+		return p.stringValue()
+	case c == '-' || c >= '0' && c <= '9':
+		return p.number()
+	case c == '[':
+		p.enter()
+		defer p.leave()
+		p.expect('[')
+		list := []any{}
+		for !p.accept(']') {
+			list = append(list, p.value(constant))
+		}
+		return list
+	case c == '{':
+		p.enter()
+		defer p.leave()
+		p.expect('{')
+		obj := map[string]any{}
+		for !p.accept('}') {
+			n := p.name()
+			p.expect(':')
+			obj[n] = p.value(constant)
+		}
+		return obj
+	case isNameStart(c):
+		switch n := p.name(); n {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		default:
+			return enum(n)
+		}
+	}
+	p.fail("expected a value")
+	return nil
+}
+
+func (p *parser) stringValue() string {
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		end := strings.Index(p.src[p.pos+3:], `"""`)
+		if end < 0 {
+			p.fail("unterminated string")
+		}
+		s := p.src[p.pos+3 : p.pos+3+end]
+		p.pos += end + 6
+		p.skip()
+		return s
+	}
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.src) && p.src[p.pos] != '"' {
+		if p.src[p.pos] == '\n' {
+			break
+		}
+		if p.src[p.pos] == '\\' {
+			p.pos++
+		}
+		p.pos++
+	}
+	if p.pos >= len(p.src) || p.src[p.pos] != '"' {
+		p.fail("unterminated string")
+	}
+	p.pos++
+	s, err := strconv.Unquote(p.src[start:p.pos])
+	if err != nil {
+		p.fail("invalid string")
+	}
+	p.skip()
+	return s
+}
+
+func (p *parser) number() any {
+	start := p.pos
+	float := false
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '.' || c == 'e' || c == 'E' || (float && (c == '+' || c == '-')) {
+			float = true
+		} else if c < '0' || c > '9' {
+			break
+		}
+		p.pos++
+	}
+	lit := p.src[start:p.pos]
+	p.skip()
+	if float {
+		f, err := strconv.ParseFloat(lit, 64)
+		if err != nil {
+			p.fail("invalid number " + lit)
+		}
+		return f
+	}
+	n, err := strconv.ParseInt(lit, 10, 64)
+	if err != nil {
+		p.fail("invalid number " + lit)
+	}
+	return n
+}
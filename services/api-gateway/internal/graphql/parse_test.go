@@ -0,0 +1,36 @@
+package graphql
+
+import (
+	"strings"
+	"testing"
+)
+
+// FuzzParse checks that the parser turns any input into a document or an
+// error, never a panic or a document without operations.
+func FuzzParse(f *testing.F) {
+	for _, q := range []string{
+		`{ a }`,
+		`query Q($id: ID! = "x", $n: [Int!]) @d { a: b(id: $id, n: [1, 2]) { ...F ... on T { c } } }`,
+		`fragment F on T { c @include(if: true) } { a { ...F } }`,
+		`{ a(s: "esc \" \\ é \n", f: -1.5e-3, o: {k: [null, true, E]}) }`,
+		`subscription { a }`,
+		`{ a(x: $v) `,
+		`{ ... }`,
+		`fragment on on T { a }`,
+		strings.Repeat("{ a ", 150) + strings.Repeat("}", 150),
+	} {
+		f.Add(q)
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		doc, err := parse(src)
+		if err != nil {
+			if doc != nil {
+				t.Fatalf("document and error for %q", src)
+			}
+			return
+		}
+		if len(doc.operations) == 0 {
+			t.Fatalf("no operations in %q", src)
+		}
+	})
+}
@@ -14,9 +14,16 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/middleware"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/repository/postgres"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/audit"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/database"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/guardian"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/identity"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/logger"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/rabbit"
 	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/tracer"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -93,10 +100,19 @@ func main() {
 	}
 	fileStorage := storage.NewLocalStorage(storagePath, baseURL)
 
+	// Events are stored with the change they describe and relayed to RabbitMQ
+	rb := rabbit.New(cfg.RabbitURL)
+	events := outbox.New(dbPool)
+	if rb != nil {
+		go outbox.NewRelay(dbPool, rb).Run(context.Background())
+	}
+	// Grade approvals and report card publishing go to the platform audit trail
+	auditor := audit.NewPublisher(events)
+
 	// Init UseCases
-	gradingUseCase := usecase.NewGradingUseCase(assessmentRepo, gradeRepo, 10*time.Second)
+	gradingUseCase := usecase.NewGradingUseCase(assessmentRepo, gradeRepo, events, auditor, 10*time.Second)
 	gradeCategoryUseCase := usecase.NewGradeCategoryUseCase(gradeCategoryRepo, 10*time.Second)
-	reportCardUseCase := usecase.NewReportCardUseCase(reportCardRepo, gradeRepo, assessmentRepo, gradeCategoryRepo, fileStorage)
+	reportCardUseCase := usecase.NewReportCardUseCase(reportCardRepo, gradeRepo, assessmentRepo, gradeCategoryRepo, fileStorage, events, auditor)
 	templateUseCase := usecase.NewTemplateUseCase(templateRepo)
 
 	// Init Handlers
@@ -110,16 +126,23 @@ func main() {
 	r := gin.Default()
 	r.Use(otelgin.Middleware("assessment-service"))
 	redis := redisutil.New(cfg.RedisAddr)
-	limiter := redisutil.NewLimiterFromCounter(redis.Raw())
+	limiter := redisutil.NewTokenBucketLimiter(redis.Raw())
 	r.Use(middleware.SecurityHeaders())
-	r.Use(middleware.RateLimitByPolicy(limiter, 100, 30, map[string]int{}))
+	// Anonymous routes are limited per client address; the authenticated
+	// group runs the limiter after authn so each user has their own budget
+	limit := middleware.RateLimitByPolicy(limiter, 100, 30, nil, cfg.TrustedProxies)
+	public := r.Group("/", limit)
+
+	authorizer := authz.NewClient(cfg.AuthServiceURL, redis.Raw(), authz.DefaultCacheTTL, 5*time.Second)
+	perm := func(permission string) gin.HandlerFunc { return middleware.Authorization(authorizer, permission) }
+	guardians := guardian.NewClient(cfg.AcademicServiceURL, redis.Raw(), guardian.DefaultCacheTTL, 5*time.Second)
 	
 	// Static file serving for local storage
-	r.Static("/files", storagePath)
+	public.Static("/files", storagePath)
 
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	public.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	r.GET("/api/v1/health", func(c *gin.Context) {
+	public.GET("/api/v1/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"success": true,
 			"data": gin.H{
@@ -135,52 +158,67 @@ func main() {
 	})
 
 	api := r.Group("/api/v1")
+	keys := jwtutil.NewKeySet(cfg.JWTSigningAlg, cfg.JWTAccessSecret, cfg.JWKSURL)
+	authn := middleware.Auth(keys, cfg.JWTIssuer, cfg.JWTAudience, redis.Raw())
+	if cfg.TrustGateway {
+		// Behind the gateway the signed identity headers replace the bearer token
+		authn = identity.Gin([]byte(cfg.GatewaySecret), redis.Raw())
+	}
+	api.Use(authn, limit)
 	{
 		// Assessment Routes
 		assessments := api.Group("/assessments")
 		{
-			assessments.POST("", assessmentHandler.Create)
+			assessments.POST("", perm(authz.AssessmentWrite), assessmentHandler.Create)
 		}
 
 		// Grade Routes
 		grades := api.Group("/grades")
 		{
-			grades.POST("", gradeHandler.InputGrade)
-			grades.GET("/student/:student_id", gradeHandler.GetStudentGrades)
-			grades.GET("/final/:student_id", gradeHandler.CalculateFinalScore)
-			grades.PUT("/:id/approve", gradeHandler.ApproveGrade)
+			grades.POST("", perm(authz.GradeWrite), gradeHandler.InputGrade)
+			grades.GET("/student/:student_id", perm(authz.GradeRead), gradeHandler.GetStudentGrades)
+			grades.GET("/final/:student_id", perm(authz.GradeRead), gradeHandler.CalculateFinalScore)
+			grades.PUT("/:id/approve", perm(authz.GradeApprove), gradeHandler.ApproveGrade)
 		}
 
 		// Grade Category Routes
 		categories := api.Group("/grade-categories")
 		{
-			categories.POST("", gradeCategoryHandler.Create)
-			categories.GET("", gradeCategoryHandler.List)
-			categories.PUT("/:id", gradeCategoryHandler.Update)
-			categories.DELETE("/:id", gradeCategoryHandler.Delete)
+			categories.POST("", perm(authz.GradeCategoryWrite), gradeCategoryHandler.Create)
+			categories.GET("", perm(authz.GradeCategoryRead), gradeCategoryHandler.List)
+			categories.PUT("/:id", perm(authz.GradeCategoryWrite), gradeCategoryHandler.Update)
+			categories.DELETE("/:id", perm(authz.GradeCategoryDelete), gradeCategoryHandler.Delete)
 		}
 
 		// Report Card Routes
 		reportCards := api.Group("/report-cards")
 		{
-			reportCards.POST("/generate", reportCardHandler.Generate)
-			reportCards.GET("/student/:student_id", reportCardHandler.GetByStudent)
-			reportCards.GET("/:id/download", reportCardHandler.GetPDF)
+			reportCards.POST("/generate", perm(authz.ReportCardGenerate), reportCardHandler.Generate)
+			reportCards.GET("/student/:student_id", perm(authz.ReportCardRead), reportCardHandler.GetByStudent)
+			reportCards.GET("/:id/download", perm(authz.ReportCardRead), reportCardHandler.GetPDF)
 		}
 		
+		// Guardian portal, scoped to the caller's own children. The gateway routes
+		// /api/v1/guardian/assessment/ here
+		guardianPortal := api.Group("/guardian/assessment/students", perm(authz.GuardianChildRead))
+		{
+			guardianPortal.GET("/:student_id/grades", middleware.GuardianScope(guardians, "student_id"), gradeHandler.GetStudentGrades)
+			guardianPortal.GET("/:student_id/report-cards", middleware.GuardianScope(guardians, "student_id"), reportCardHandler.GetPublishedByStudent)
+		}
+
 		// Template Routes
 		templates := api.Group("/templates")
 		{
-			templates.POST("", templateHandler.Create)
-			templates.GET("", templateHandler.List)
-			templates.GET("/:id", templateHandler.GetByID)
-			templates.PUT("/:id", templateHandler.Update)
-			templates.DELETE("/:id", templateHandler.Delete)
+			templates.POST("", perm(authz.TemplateWrite), templateHandler.Create)
+			templates.GET("", perm(authz.TemplateRead), templateHandler.List)
+			templates.GET("/:id", perm(authz.TemplateRead), templateHandler.GetByID)
+			templates.PUT("/:id", perm(authz.TemplateWrite), templateHandler.Update)
+			templates.DELETE("/:id", perm(authz.TemplateDelete), templateHandler.Delete)
 		}
 	}
 
 	// Prometheus metrics
-	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	public.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	port := os.Getenv("APP_HTTP_PORT")
 	if port == "" {
@@ -57,32 +57,32 @@ func (mr *MockGradeCategoryUseCaseMockRecorder) Create(ctx, category any) *gomoc
 }
 
 // Delete mocks base method.
-func (m *MockGradeCategoryUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockGradeCategoryUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockGradeCategoryUseCaseMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockGradeCategoryUseCaseMockRecorder) Delete(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGradeCategoryUseCase)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGradeCategoryUseCase)(nil).Delete), ctx, tenantID, id)
 }
 
 // GetByID mocks base method.
-func (m *MockGradeCategoryUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.GradeCategory, error) {
+func (m *MockGradeCategoryUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.GradeCategory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
 	ret0, _ := ret[0].(*entity.GradeCategory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockGradeCategoryUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockGradeCategoryUseCaseMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGradeCategoryUseCase)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGradeCategoryUseCase)(nil).GetByID), ctx, tenantID, id)
 }
 
 // GetByTenantID mocks base method.
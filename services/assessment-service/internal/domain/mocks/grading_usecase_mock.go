@@ -43,32 +43,32 @@ func (m *MockGradingUseCase) EXPECT() *MockGradingUseCaseMockRecorder {
 }
 
 // ApproveGrade mocks base method.
-func (m *MockGradingUseCase) ApproveGrade(ctx context.Context, gradeID, approvedBy uuid.UUID) error {
+func (m *MockGradingUseCase) ApproveGrade(ctx context.Context, tenantID string, gradeID, approvedBy uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApproveGrade", ctx, gradeID, approvedBy)
+	ret := m.ctrl.Call(m, "ApproveGrade", ctx, tenantID, gradeID, approvedBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ApproveGrade indicates an expected call of ApproveGrade.
-func (mr *MockGradingUseCaseMockRecorder) ApproveGrade(ctx, gradeID, approvedBy any) *gomock.Call {
+func (mr *MockGradingUseCaseMockRecorder) ApproveGrade(ctx, tenantID, gradeID, approvedBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveGrade", reflect.TypeOf((*MockGradingUseCase)(nil).ApproveGrade), ctx, gradeID, approvedBy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveGrade", reflect.TypeOf((*MockGradingUseCase)(nil).ApproveGrade), ctx, tenantID, gradeID, approvedBy)
 }
 
 // CalculateFinalScore mocks base method.
-func (m *MockGradingUseCase) CalculateFinalScore(ctx context.Context, studentID, classID, subjectID, semesterID uuid.UUID) (float64, error) {
+func (m *MockGradingUseCase) CalculateFinalScore(ctx context.Context, tenantID string, studentID, classID, subjectID, semesterID uuid.UUID) (float64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CalculateFinalScore", ctx, studentID, classID, subjectID, semesterID)
+	ret := m.ctrl.Call(m, "CalculateFinalScore", ctx, tenantID, studentID, classID, subjectID, semesterID)
 	ret0, _ := ret[0].(float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CalculateFinalScore indicates an expected call of CalculateFinalScore.
-func (mr *MockGradingUseCaseMockRecorder) CalculateFinalScore(ctx, studentID, classID, subjectID, semesterID any) *gomock.Call {
+func (mr *MockGradingUseCaseMockRecorder) CalculateFinalScore(ctx, tenantID, studentID, classID, subjectID, semesterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateFinalScore", reflect.TypeOf((*MockGradingUseCase)(nil).CalculateFinalScore), ctx, studentID, classID, subjectID, semesterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateFinalScore", reflect.TypeOf((*MockGradingUseCase)(nil).CalculateFinalScore), ctx, tenantID, studentID, classID, subjectID, semesterID)
 }
 
 // CreateAssessment mocks base method.
@@ -86,18 +86,18 @@ func (mr *MockGradingUseCaseMockRecorder) CreateAssessment(ctx, assessment any)
 }
 
 // GetStudentGrades mocks base method.
-func (m *MockGradingUseCase) GetStudentGrades(ctx context.Context, studentID, classID, semesterID uuid.UUID) ([]*entity.Grade, error) {
+func (m *MockGradingUseCase) GetStudentGrades(ctx context.Context, tenantID string, studentID, classID, semesterID uuid.UUID) ([]*entity.Grade, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStudentGrades", ctx, studentID, classID, semesterID)
+	ret := m.ctrl.Call(m, "GetStudentGrades", ctx, tenantID, studentID, classID, semesterID)
 	ret0, _ := ret[0].([]*entity.Grade)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetStudentGrades indicates an expected call of GetStudentGrades.
-func (mr *MockGradingUseCaseMockRecorder) GetStudentGrades(ctx, studentID, classID, semesterID any) *gomock.Call {
+func (mr *MockGradingUseCaseMockRecorder) GetStudentGrades(ctx, tenantID, studentID, classID, semesterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentGrades", reflect.TypeOf((*MockGradingUseCase)(nil).GetStudentGrades), ctx, studentID, classID, semesterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentGrades", reflect.TypeOf((*MockGradingUseCase)(nil).GetStudentGrades), ctx, tenantID, studentID, classID, semesterID)
 }
 
 // InputGrade mocks base method.
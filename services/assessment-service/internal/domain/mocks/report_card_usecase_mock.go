@@ -58,60 +58,60 @@ func (mr *MockReportCardUseCaseMockRecorder) Generate(ctx, tenantID, studentID,
 }
 
 // GetByID mocks base method.
-func (m *MockReportCardUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.ReportCard, error) {
+func (m *MockReportCardUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.ReportCard, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
 	ret0, _ := ret[0].(*entity.ReportCard)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockReportCardUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockReportCardUseCaseMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReportCardUseCase)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReportCardUseCase)(nil).GetByID), ctx, tenantID, id)
 }
 
 // GetByStudent mocks base method.
-func (m *MockReportCardUseCase) GetByStudent(ctx context.Context, studentID, semesterID uuid.UUID) (*entity.ReportCard, error) {
+func (m *MockReportCardUseCase) GetByStudent(ctx context.Context, tenantID string, studentID, semesterID uuid.UUID) (*entity.ReportCard, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByStudent", ctx, studentID, semesterID)
+	ret := m.ctrl.Call(m, "GetByStudent", ctx, tenantID, studentID, semesterID)
 	ret0, _ := ret[0].(*entity.ReportCard)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByStudent indicates an expected call of GetByStudent.
-func (mr *MockReportCardUseCaseMockRecorder) GetByStudent(ctx, studentID, semesterID any) *gomock.Call {
+func (mr *MockReportCardUseCaseMockRecorder) GetByStudent(ctx, tenantID, studentID, semesterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockReportCardUseCase)(nil).GetByStudent), ctx, studentID, semesterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockReportCardUseCase)(nil).GetByStudent), ctx, tenantID, studentID, semesterID)
 }
 
 // GetPDF mocks base method.
-func (m *MockReportCardUseCase) GetPDF(ctx context.Context, id uuid.UUID) ([]byte, error) {
+func (m *MockReportCardUseCase) GetPDF(ctx context.Context, tenantID string, id uuid.UUID) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPDF", ctx, id)
+	ret := m.ctrl.Call(m, "GetPDF", ctx, tenantID, id)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPDF indicates an expected call of GetPDF.
-func (mr *MockReportCardUseCaseMockRecorder) GetPDF(ctx, id any) *gomock.Call {
+func (mr *MockReportCardUseCaseMockRecorder) GetPDF(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPDF", reflect.TypeOf((*MockReportCardUseCase)(nil).GetPDF), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPDF", reflect.TypeOf((*MockReportCardUseCase)(nil).GetPDF), ctx, tenantID, id)
 }
 
 // Publish mocks base method.
-func (m *MockReportCardUseCase) Publish(ctx context.Context, id uuid.UUID) error {
+func (m *MockReportCardUseCase) Publish(ctx context.Context, tenantID string, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Publish", ctx, id)
+	ret := m.ctrl.Call(m, "Publish", ctx, tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Publish indicates an expected call of Publish.
-func (mr *MockReportCardUseCaseMockRecorder) Publish(ctx, id any) *gomock.Call {
+func (mr *MockReportCardUseCaseMockRecorder) Publish(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockReportCardUseCase)(nil).Publish), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockReportCardUseCase)(nil).Publish), ctx, tenantID, id)
 }
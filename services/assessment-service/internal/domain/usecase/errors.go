@@ -0,0 +1,13 @@
+package usecase
+
+import "errors"
+
+var (
+	// ErrReportCardNotFound is returned for a report card that does not exist
+	// or belongs to another tenant; handlers answer both with 404. The other
+	// errors work the same way.
+	ErrReportCardNotFound    = errors.New("report card not found")
+	ErrGradeNotFound         = errors.New("grade not found")
+	ErrGradeCategoryNotFound = errors.New("grade category not found")
+	ErrTemplateNotFound      = errors.New("template not found")
+)
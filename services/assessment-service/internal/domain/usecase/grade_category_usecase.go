@@ -9,8 +9,8 @@ import (
 
 type GradeCategoryUseCase interface {
 	Create(ctx context.Context, category *entity.GradeCategory) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.GradeCategory, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.GradeCategory, error)
 	GetByTenantID(ctx context.Context, tenantID string) ([]*entity.GradeCategory, error)
 	Update(ctx context.Context, category *entity.GradeCategory) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
 }
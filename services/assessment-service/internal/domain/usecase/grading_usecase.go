@@ -10,7 +10,7 @@ import (
 type GradingUseCase interface {
 	CreateAssessment(ctx context.Context, assessment *entity.Assessment) error
 	InputGrade(ctx context.Context, grade *entity.Grade) error
-	GetStudentGrades(ctx context.Context, studentID, classID, semesterID uuid.UUID) ([]*entity.Grade, error)
-	CalculateFinalScore(ctx context.Context, studentID, classID, subjectID, semesterID uuid.UUID) (float64, error)
-	ApproveGrade(ctx context.Context, gradeID uuid.UUID, approvedBy uuid.UUID) error
+	GetStudentGrades(ctx context.Context, tenantID string, studentID, classID, semesterID uuid.UUID) ([]*entity.Grade, error)
+	CalculateFinalScore(ctx context.Context, tenantID string, studentID, classID, subjectID, semesterID uuid.UUID) (float64, error)
+	ApproveGrade(ctx context.Context, tenantID string, gradeID uuid.UUID, approvedBy uuid.UUID) error
 }
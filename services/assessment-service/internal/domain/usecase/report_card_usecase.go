@@ -9,8 +9,8 @@ import (
 
 type ReportCardUseCase interface {
 	Generate(ctx context.Context, tenantID string, studentID, classID, semesterID uuid.UUID) (*entity.ReportCard, error)
-	GetByStudent(ctx context.Context, studentID, semesterID uuid.UUID) (*entity.ReportCard, error)
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.ReportCard, error)
-	Publish(ctx context.Context, id uuid.UUID) error
-	GetPDF(ctx context.Context, id uuid.UUID) ([]byte, error)
+	GetByStudent(ctx context.Context, tenantID string, studentID, semesterID uuid.UUID) (*entity.ReportCard, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.ReportCard, error)
+	Publish(ctx context.Context, tenantID string, id uuid.UUID) error
+	GetPDF(ctx context.Context, tenantID string, id uuid.UUID) ([]byte, error)
 }
@@ -9,8 +9,8 @@ import (
 
 type TemplateUseCase interface {
 	Create(ctx context.Context, template *entity.ReportCardTemplate) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.ReportCardTemplate, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.ReportCardTemplate, error)
 	GetByTenantID(ctx context.Context, tenantID string) ([]*entity.ReportCardTemplate, error)
 	Update(ctx context.Context, template *entity.ReportCardTemplate) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
 }
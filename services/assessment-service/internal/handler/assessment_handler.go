@@ -31,8 +31,11 @@ func NewAssessmentHandler(useCase usecase.GradingUseCase) *AssessmentHandler {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /assessments [post]
 func (h *AssessmentHandler) Create(c *gin.Context) {
+	tenant, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID        string    `json:"tenant_id" binding:"required"`
 		SubjectID       string    `json:"subject_id" binding:"required"`
 		TeacherID       string    `json:"teacher_id" binding:"required"`
 		ClassID         string    `json:"class_id" binding:"required"`
@@ -72,9 +75,9 @@ func (h *AssessmentHandler) Create(c *gin.Context) {
 		return
 	}
 
-	tenantID, err := uuid.Parse(req.TenantID)
+	tenantID, err := uuid.Parse(tenant)
 	if err != nil {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Invalid Tenant ID")
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "Invalid Tenant ID")
 		return
 	}
 
@@ -14,6 +14,7 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/mocks"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/handler"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -29,7 +30,6 @@ func TestAssessmentHandler_Create(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":         uuid.New().String(),
 			"subject_id":        uuid.New().String(),
 			"teacher_id":        uuid.New().String(),
 			"class_id":          uuid.New().String(),
@@ -49,6 +49,7 @@ func TestAssessmentHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, uuid.New().String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/assessments", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -59,13 +60,13 @@ func TestAssessmentHandler_Create(t *testing.T) {
 
 	t.Run("bad request - missing fields", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": "tenant-123",
 			// Missing name
 		}
 		body, _ := json.Marshal(reqBody)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-123")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/assessments", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -76,7 +77,6 @@ func TestAssessmentHandler_Create(t *testing.T) {
 
 	t.Run("bad request - invalid UUID", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":         uuid.New().String(),
 			"subject_id":        "invalid-uuid",
 			"teacher_id":        uuid.New().String(),
 			"class_id":          uuid.New().String(),
@@ -89,6 +89,7 @@ func TestAssessmentHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, uuid.New().String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/assessments", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -99,7 +100,6 @@ func TestAssessmentHandler_Create(t *testing.T) {
 
 	t.Run("usecase error", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":         uuid.New().String(),
 			"subject_id":        uuid.New().String(),
 			"teacher_id":        uuid.New().String(),
 			"class_id":          uuid.New().String(),
@@ -115,6 +115,7 @@ func TestAssessmentHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, uuid.New().String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/assessments", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -123,3 +124,8 @@ func TestAssessmentHandler_Create(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 }
+
+// withTenant authenticates c the way the auth middleware would.
+func withTenant(c *gin.Context, tenantID string) {
+	c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -30,8 +31,11 @@ func NewGradeCategoryHandler(useCase usecase.GradeCategoryUseCase) *GradeCategor
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /grade-categories [post]
 func (h *GradeCategoryHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID    string `json:"tenant_id" binding:"required"`
 		Name        string `json:"name" binding:"required"`
 		Description string `json:"description"`
 		Weight      float64 `json:"weight" binding:"required,min=0"`
@@ -43,7 +47,7 @@ func (h *GradeCategoryHandler) Create(c *gin.Context) {
 	}
 
 	category := &entity.GradeCategory{
-		TenantID:    req.TenantID,
+		TenantID:    tenantID,
 		Name:        req.Name,
 		Description: req.Description,
 		Weight:      req.Weight,
@@ -70,6 +74,10 @@ func (h *GradeCategoryHandler) Create(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /grade-categories/{id} [get]
 func (h *GradeCategoryHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -77,7 +85,7 @@ func (h *GradeCategoryHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	category, err := h.useCase.GetByID(c.Request.Context(), id)
+	category, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -96,15 +104,13 @@ func (h *GradeCategoryHandler) GetByID(c *gin.Context) {
 // @Tags         grade-categories
 // @Accept       json
 // @Produce      json
-// @Param        tenant_id query     string  true  "Tenant ID"
 // @Success      200  {array}   entity.GradeCategory
 // @Failure      400  {object}  httputil.ErrorResponse
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /grade-categories [get]
 func (h *GradeCategoryHandler) List(c *gin.Context) {
-	tenantID := c.Query("tenant_id")
-	if tenantID == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "tenant_id is required")
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -131,6 +137,10 @@ func (h *GradeCategoryHandler) List(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /grade-categories/{id} [put]
 func (h *GradeCategoryHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -149,7 +159,7 @@ func (h *GradeCategoryHandler) Update(c *gin.Context) {
 		return
 	}
 
-	category, err := h.useCase.GetByID(c.Request.Context(), id)
+	category, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -182,6 +192,10 @@ func (h *GradeCategoryHandler) Update(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /grade-categories/{id} [delete]
 func (h *GradeCategoryHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -189,7 +203,12 @@ func (h *GradeCategoryHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	err = h.useCase.Delete(c.Request.Context(), tenantID, id)
+	if errors.Is(err, usecase.ErrGradeCategoryNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Grade category not found")
+		return
+	}
+	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
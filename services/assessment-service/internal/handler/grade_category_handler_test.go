@@ -28,7 +28,6 @@ func TestGradeCategoryHandler_Create(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":   "tenant-123",
 			"name":        "Quiz",
 			"description": "Daily Quiz",
 			"weight":      10,
@@ -45,6 +44,7 @@ func TestGradeCategoryHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-123")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/grade-categories", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -61,6 +61,7 @@ func TestGradeCategoryHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-123")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/grade-categories", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -71,7 +72,6 @@ func TestGradeCategoryHandler_Create(t *testing.T) {
 
 	t.Run("usecase error", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":   "tenant-123",
 			"name":        "Quiz",
 			"description": "Daily Quiz",
 			"weight":      10,
@@ -82,6 +82,7 @@ func TestGradeCategoryHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-123")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/grade-categories", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -104,10 +105,11 @@ func TestGradeCategoryHandler_GetByID(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		expected := &entity.GradeCategory{ID: id, Name: "Quiz"}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(expected, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-123", id).Return(expected, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-123")
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/grade-categories/"+id.String(), nil)
 
@@ -117,10 +119,11 @@ func TestGradeCategoryHandler_GetByID(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-123", id).Return(nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-123")
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/grade-categories/"+id.String(), nil)
 
@@ -149,7 +152,8 @@ func TestGradeCategoryHandler_List(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/grade-categories?tenant_id="+tenantID, nil)
+		withTenant(c, tenantID)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/grade-categories", nil)
 
 		h.List(c)
 
@@ -162,7 +166,8 @@ func TestGradeCategoryHandler_List(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/grade-categories?tenant_id="+tenantID, nil)
+		withTenant(c, tenantID)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/grade-categories", nil)
 
 		h.List(c)
 
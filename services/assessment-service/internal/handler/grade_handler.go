@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -30,8 +31,11 @@ func NewGradeHandler(useCase usecase.GradingUseCase) *GradeHandler {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /grades [post]
 func (h *GradeHandler) InputGrade(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID     string  `json:"tenant_id" binding:"required"`
 		AssessmentID string  `json:"assessment_id" binding:"required"`
 		StudentID    string  `json:"student_id" binding:"required"`
 		Score        float64 `json:"score" binding:"required,min=0"`
@@ -69,7 +73,7 @@ func (h *GradeHandler) InputGrade(c *gin.Context) {
 	}
 
 	grade := &entity.Grade{
-		TenantID:     req.TenantID,
+		TenantID:     tenantID,
 		AssessmentID: assessmentID,
 		StudentID:    studentID,
 		Score:        req.Score,
@@ -100,6 +104,10 @@ func (h *GradeHandler) InputGrade(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /grades/student/{student_id} [get]
 func (h *GradeHandler) GetStudentGrades(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	studentIDStr := c.Param("student_id")
 	classIDStr := c.Query("class_id")
 	semesterIDStr := c.Query("semester_id")
@@ -122,7 +130,7 @@ func (h *GradeHandler) GetStudentGrades(c *gin.Context) {
 		return
 	}
 
-	grades, err := h.useCase.GetStudentGrades(c.Request.Context(), studentID, classID, semesterID)
+	grades, err := h.useCase.GetStudentGrades(c.Request.Context(), tenantID, studentID, classID, semesterID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -146,6 +154,10 @@ func (h *GradeHandler) GetStudentGrades(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /grades/calculate/{student_id} [get]
 func (h *GradeHandler) CalculateFinalScore(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	studentIDStr := c.Param("student_id")
 	subjectIDStr := c.Query("subject_id")
 	semesterIDStr := c.Query("semester_id")
@@ -175,7 +187,7 @@ func (h *GradeHandler) CalculateFinalScore(c *gin.Context) {
 		return
 	}
 
-	score, err := h.useCase.CalculateFinalScore(c.Request.Context(), studentID, classID, subjectID, semesterID)
+	score, err := h.useCase.CalculateFinalScore(c.Request.Context(), tenantID, studentID, classID, subjectID, semesterID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -197,6 +209,10 @@ func (h *GradeHandler) CalculateFinalScore(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /grades/{id}/approve [put]
 func (h *GradeHandler) ApproveGrade(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	gradeIDStr := c.Param("id")
 	gradeID, err := uuid.Parse(gradeIDStr)
 	if err != nil {
@@ -219,7 +235,12 @@ func (h *GradeHandler) ApproveGrade(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.ApproveGrade(c.Request.Context(), gradeID, approvedBy); err != nil {
+	err = h.useCase.ApproveGrade(c.Request.Context(), tenantID, gradeID, approvedBy)
+	if errors.Is(err, usecase.ErrGradeNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Grade not found")
+		return
+	}
+	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
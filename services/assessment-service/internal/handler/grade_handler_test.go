@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/mocks"
+	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/handler"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -29,7 +30,6 @@ func TestGradeHandler_InputGrade(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		tenantID := uuid.New()
 		reqBody := map[string]interface{}{
-			"tenant_id":     tenantID.String(),
 			"assessment_id": uuid.New().String(),
 			"student_id":    uuid.New().String(),
 			"score":         85.0,
@@ -48,6 +48,7 @@ func TestGradeHandler_InputGrade(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/grades", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -58,7 +59,6 @@ func TestGradeHandler_InputGrade(t *testing.T) {
 
 	t.Run("bad request - invalid score", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":     uuid.New().String(),
 			"assessment_id": uuid.New().String(),
 			"student_id":    uuid.New().String(),
 			// Missing score or invalid
@@ -67,6 +67,7 @@ func TestGradeHandler_InputGrade(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, uuid.New().String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/grades", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -94,13 +95,14 @@ func TestGradeHandler_ApproveGrade(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockUseCase.EXPECT().ApproveGrade(gomock.Any(), gradeID, approverID).Return(nil)
+		mockUseCase.EXPECT().ApproveGrade(gomock.Any(), "tenant-123", gradeID, approverID).Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request, _ = http.NewRequest(http.MethodPut, "/grades/"+gradeID.String()+"/approve", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 		c.Params = []gin.Param{{Key: "id", Value: gradeID.String()}}
+		withTenant(c, "tenant-123")
 
 		h.ApproveGrade(c)
 
@@ -116,16 +118,37 @@ func TestGradeHandler_ApproveGrade(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockUseCase.EXPECT().ApproveGrade(gomock.Any(), gradeID, approverID).Return(errors.New("db error"))
+		mockUseCase.EXPECT().ApproveGrade(gomock.Any(), "tenant-123", gradeID, approverID).Return(errors.New("db error"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request, _ = http.NewRequest(http.MethodPut, "/grades/"+gradeID.String()+"/approve", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 		c.Params = []gin.Param{{Key: "id", Value: gradeID.String()}}
+		withTenant(c, "tenant-123")
 
 		h.ApproveGrade(c)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		gradeID := uuid.New()
+		approverID := uuid.New()
+
+		body, _ := json.Marshal(map[string]interface{}{"approved_by": approverID.String()})
+
+		mockUseCase.EXPECT().ApproveGrade(gomock.Any(), "tenant-456", gradeID, approverID).Return(usecase.ErrGradeNotFound)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPut, "/grades/"+gradeID.String()+"/approve", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = []gin.Param{{Key: "id", Value: gradeID.String()}}
+		withTenant(c, "tenant-456")
+
+		h.ApproveGrade(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
 }
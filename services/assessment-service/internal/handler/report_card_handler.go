@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
 )
@@ -29,8 +31,11 @@ func NewReportCardHandler(useCase usecase.ReportCardUseCase) *ReportCardHandler
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /report-cards/generate [post]
 func (h *ReportCardHandler) Generate(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID   string `json:"tenant_id" binding:"required"`
 		StudentID  string `json:"student_id" binding:"required"`
 		ClassID    string `json:"class_id" binding:"required"`
 		SemesterID string `json:"semester_id" binding:"required"`
@@ -41,7 +46,6 @@ func (h *ReportCardHandler) Generate(c *gin.Context) {
 		return
 	}
 
-	tenantID := req.TenantID
 	studentID, err := uuid.Parse(req.StudentID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Invalid Student ID")
@@ -78,6 +82,10 @@ func (h *ReportCardHandler) Generate(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /report-cards/{id}/download [get]
 func (h *ReportCardHandler) GetPDF(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -85,7 +93,11 @@ func (h *ReportCardHandler) GetPDF(c *gin.Context) {
 		return
 	}
 
-	pdfBytes, err := h.useCase.GetPDF(c.Request.Context(), id)
+	pdfBytes, err := h.useCase.GetPDF(c.Request.Context(), tenantID, id)
+	if errors.Is(err, usecase.ErrReportCardNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Report Card not found")
+		return
+	}
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -109,6 +121,10 @@ func (h *ReportCardHandler) GetPDF(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /report-cards/student/{student_id} [get]
 func (h *ReportCardHandler) GetByStudent(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	studentIDStr := c.Param("student_id")
 	semesterIDStr := c.Query("semester_id")
 
@@ -123,7 +139,7 @@ func (h *ReportCardHandler) GetByStudent(c *gin.Context) {
 		return
 	}
 
-	rc, err := h.useCase.GetByStudent(c.Request.Context(), studentID, semesterID)
+	rc, err := h.useCase.GetByStudent(c.Request.Context(), tenantID, studentID, semesterID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -136,7 +152,42 @@ func (h *ReportCardHandler) GetByStudent(c *gin.Context) {
 	httputil.Success(c.Writer, rc)
 }
 
+// GetPublishedByStudent is GetByStudent for the guardian portal: report cards
+// that have not been published yet are reported as not found.
+func (h *ReportCardHandler) GetPublishedByStudent(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+	studentID, err := uuid.Parse(c.Param("student_id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Invalid Student ID")
+		return
+	}
+	semesterID, err := uuid.Parse(c.Query("semester_id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Invalid Semester ID")
+		return
+	}
+
+	rc, err := h.useCase.GetByStudent(c.Request.Context(), tenantID, studentID, semesterID)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
+		return
+	}
+	if rc == nil || rc.Status != entity.ReportCardStatusPublished {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Report Card not found")
+		return
+	}
+
+	httputil.Success(c.Writer, rc)
+}
+
 func (h *ReportCardHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -144,7 +195,7 @@ func (h *ReportCardHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	rc, err := h.useCase.GetByID(c.Request.Context(), id)
+	rc, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -158,6 +209,10 @@ func (h *ReportCardHandler) GetByID(c *gin.Context) {
 }
 
 func (h *ReportCardHandler) Publish(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -165,7 +220,11 @@ func (h *ReportCardHandler) Publish(c *gin.Context) {
 		return
 	}
 
-	err = h.useCase.Publish(c.Request.Context(), id)
+	err = h.useCase.Publish(c.Request.Context(), tenantID, id)
+	if errors.Is(err, usecase.ErrReportCardNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Report Card not found")
+		return
+	}
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -15,6 +15,7 @@ import (
 
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/mocks"
+	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/handler"
 )
 
@@ -33,7 +34,6 @@ func TestReportCardHandler_Generate(t *testing.T) {
 		semesterID := uuid.New()
 
 		reqBody := map[string]string{
-			"tenant_id":   tenantID,
 			"student_id":  studentID.String(),
 			"class_id":    classID.String(),
 			"semester_id": semesterID.String(),
@@ -44,6 +44,7 @@ func TestReportCardHandler_Generate(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/report-cards", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -55,6 +56,7 @@ func TestReportCardHandler_Generate(t *testing.T) {
 	t.Run("invalid_input", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-123")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/report-cards", bytes.NewBufferString("invalid json"))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -70,7 +72,6 @@ func TestReportCardHandler_Generate(t *testing.T) {
 		semesterID := uuid.New()
 
 		reqBody := map[string]string{
-			"tenant_id":   tenantID,
 			"student_id":  studentID.String(),
 			"class_id":    classID.String(),
 			"semester_id": semesterID.String(),
@@ -81,6 +82,7 @@ func TestReportCardHandler_Generate(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/report-cards", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -102,10 +104,11 @@ func TestReportCardHandler_GetPDF(t *testing.T) {
 		id := uuid.New()
 		pdfBytes := []byte("%PDF-1.4...")
 
-		mockUseCase.EXPECT().GetPDF(gomock.Any(), id).Return(pdfBytes, nil)
+		mockUseCase.EXPECT().GetPDF(gomock.Any(), "tenant-123", id).Return(pdfBytes, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-123")
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/report-cards/"+id.String()+"/pdf", nil)
 
@@ -120,6 +123,7 @@ func TestReportCardHandler_GetPDF(t *testing.T) {
 	t.Run("invalid_id", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-123")
 		c.Params = gin.Params{{Key: "id", Value: "invalid-uuid"}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/report-cards/invalid-uuid/pdf", nil)
 
@@ -131,10 +135,11 @@ func TestReportCardHandler_GetPDF(t *testing.T) {
 	t.Run("usecase_error", func(t *testing.T) {
 		id := uuid.New()
 
-		mockUseCase.EXPECT().GetPDF(gomock.Any(), id).Return(nil, errors.New("internal error"))
+		mockUseCase.EXPECT().GetPDF(gomock.Any(), "tenant-123", id).Return(nil, errors.New("internal error"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-123")
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/report-cards/"+id.String()+"/pdf", nil)
 
@@ -142,4 +147,56 @@ func TestReportCardHandler_GetPDF(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
+
+	t.Run("other_tenant", func(t *testing.T) {
+		id := uuid.New()
+
+		mockUseCase.EXPECT().GetPDF(gomock.Any(), "tenant-456", id).Return(nil, usecase.ErrReportCardNotFound)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-456")
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+		c.Request, _ = http.NewRequest(http.MethodGet, "/report-cards/"+id.String()+"/pdf", nil)
+
+		h.GetPDF(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestReportCardHandler_GetPublishedByStudent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockReportCardUseCase(ctrl)
+	h := handler.NewReportCardHandler(mockUseCase)
+
+	studentID := uuid.New()
+	semesterID := uuid.New()
+
+	serve := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-123")
+		c.Params = gin.Params{{Key: "student_id", Value: studentID.String()}}
+		c.Request, _ = http.NewRequest(http.MethodGet, "/guardian/assessment/students/"+studentID.String()+"/report-cards?semester_id="+semesterID.String(), nil)
+		h.GetPublishedByStudent(c)
+		return w
+	}
+
+	t.Run("published", func(t *testing.T) {
+		rc := &entity.ReportCard{ID: uuid.New(), StudentID: studentID, Status: entity.ReportCardStatusPublished}
+		mockUseCase.EXPECT().GetByStudent(gomock.Any(), "tenant-123", studentID, semesterID).Return(rc, nil)
+
+		assert.Equal(t, http.StatusOK, serve().Code)
+	})
+
+	t.Run("not_published", func(t *testing.T) {
+		rc := &entity.ReportCard{ID: uuid.New(), StudentID: studentID, Status: entity.ReportCardStatusGenerated}
+		mockUseCase.EXPECT().GetByStudent(gomock.Any(), "tenant-123", studentID, semesterID).Return(rc, nil)
+
+		assert.Equal(t, http.StatusNotFound, serve().Code)
+	})
 }
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -30,18 +31,17 @@ func NewTemplateHandler(useCase usecase.TemplateUseCase) *TemplateHandler {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /templates [post]
 func (h *TemplateHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var template entity.ReportCardTemplate
 	if err := c.ShouldBindJSON(&template); err != nil {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid request body", err.Error())
 		return
 	}
 
-	// Assume tenant ID comes from middleware/context (mocked for now)
-	// In real implementation: tenantID := c.GetString("tenant_id")
-	if template.TenantID == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4002", "tenant_id is required", nil)
-		return
-	}
+	template.TenantID = tenantID
 
 	if err := h.useCase.Create(c.Request.Context(), &template); err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to create template", err.Error())
@@ -64,6 +64,10 @@ func (h *TemplateHandler) Create(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /templates/{id} [get]
 func (h *TemplateHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -71,7 +75,7 @@ func (h *TemplateHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	template, err := h.useCase.GetByID(c.Request.Context(), id)
+	template, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to fetch template", err.Error())
 		return
@@ -90,19 +94,17 @@ func (h *TemplateHandler) GetByID(c *gin.Context) {
 // @Tags         templates
 // @Accept       json
 // @Produce      json
-// @Param        tenant_id query     string  true  "Tenant ID"
 // @Success      200  {array}   entity.ReportCardTemplate
 // @Failure      400  {object}  httputil.ErrorResponse
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /templates [get]
 func (h *TemplateHandler) List(c *gin.Context) {
-	tenantIDStr := c.Query("tenant_id")
-	if tenantIDStr == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4002", "tenant_id is required", nil)
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
-	templates, err := h.useCase.GetByTenantID(c.Request.Context(), tenantIDStr)
+	templates, err := h.useCase.GetByTenantID(c.Request.Context(), tenantID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to fetch templates", err.Error())
 		return
@@ -124,6 +126,10 @@ func (h *TemplateHandler) List(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /templates/{id} [put]
 func (h *TemplateHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -137,8 +143,14 @@ func (h *TemplateHandler) Update(c *gin.Context) {
 		return
 	}
 	template.ID = id
+	template.TenantID = tenantID
 
-	if err := h.useCase.Update(c.Request.Context(), &template); err != nil {
+	err = h.useCase.Update(c.Request.Context(), &template)
+	if errors.Is(err, usecase.ErrTemplateNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Template not found", nil)
+		return
+	}
+	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to update template", err.Error())
 		return
 	}
@@ -158,6 +170,10 @@ func (h *TemplateHandler) Update(c *gin.Context) {
 // @Failure      500  {object}  httputil.ErrorResponse
 // @Router       /templates/{id} [delete]
 func (h *TemplateHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -165,7 +181,12 @@ func (h *TemplateHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	err = h.useCase.Delete(c.Request.Context(), tenantID, id)
+	if errors.Is(err, usecase.ErrTemplateNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Template not found", nil)
+		return
+	}
+	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to delete template", err.Error())
 		return
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 )
 
 type GradeRepository struct {
@@ -25,7 +26,7 @@ func (r *GradeRepository) Create(ctx context.Context, grade *entity.Grade) error
 		)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
-	_, err := r.db.Exec(ctx, query,
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query,
 		grade.ID,
 		grade.TenantID,
 		grade.AssessmentID,
@@ -43,7 +44,7 @@ func (r *GradeRepository) Create(ctx context.Context, grade *entity.Grade) error
 }
 
 func (r *GradeRepository) CreateBulk(ctx context.Context, grades []*entity.Grade) error {
-	tx, err := r.db.Begin(ctx)
+	tx, err := outbox.Conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -101,7 +102,7 @@ func (r *GradeRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Gr
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 	var g entity.Grade
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
 		&g.ID,
 		&g.TenantID,
 		&g.AssessmentID,
@@ -133,7 +134,7 @@ func (r *GradeRepository) GetByAssessmentID(ctx context.Context, assessmentID uu
 		FROM grades
 		WHERE assessment_id = $1 AND deleted_at IS NULL
 	`
-	rows, err := r.db.Query(ctx, query, assessmentID)
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, query, assessmentID)
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +173,7 @@ func (r *GradeRepository) GetByStudentID(ctx context.Context, studentID uuid.UUI
 		FROM grades
 		WHERE student_id = $1 AND deleted_at IS NULL
 	`
-	rows, err := r.db.Query(ctx, query, studentID)
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, query, studentID)
 	if err != nil {
 		return nil, err
 	}
@@ -212,7 +213,7 @@ func (r *GradeRepository) GetByStudentAndAssessment(ctx context.Context, student
 		WHERE student_id = $1 AND assessment_id = $2 AND deleted_at IS NULL
 	`
 	var g entity.Grade
-	err := r.db.QueryRow(ctx, query, studentID, assessmentID).Scan(
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, studentID, assessmentID).Scan(
 		&g.ID,
 		&g.TenantID,
 		&g.AssessmentID,
@@ -249,7 +250,7 @@ func (r *GradeRepository) Update(ctx context.Context, grade *entity.Grade) error
 			updated_at = $7
 		WHERE id = $8 AND deleted_at IS NULL
 	`
-	_, err := r.db.Exec(ctx, query,
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query,
 		grade.Score,
 		grade.Feedback,
 		grade.GradedBy,
@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 )
 
 type reportCardRepository struct {
@@ -20,7 +21,7 @@ func NewReportCardRepository(db *pgxpool.Pool) repository.ReportCardRepository {
 }
 
 func (r *reportCardRepository) Create(ctx context.Context, rc *entity.ReportCard) error {
-	tx, err := r.db.Begin(ctx)
+	tx, err := outbox.Conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -98,7 +99,7 @@ func (r *reportCardRepository) GetByID(ctx context.Context, id uuid.UUID) (*enti
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 	var rc entity.ReportCard
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
 		&rc.ID, &rc.TenantID, &rc.StudentID, &rc.ClassID, &rc.SemesterID, &rc.Status,
 		&rc.GPA, &rc.TotalCredits, &rc.Attendance, &rc.Comments, &rc.PDFUrl, &rc.GeneratedAt, &rc.PublishedAt,
 		&rc.CreatedAt, &rc.UpdatedAt,
@@ -118,7 +119,7 @@ func (r *reportCardRepository) GetByID(ctx context.Context, id uuid.UUID) (*enti
 		FROM report_card_details
 		WHERE report_card_id = $1 AND deleted_at IS NULL
 	`
-	rows, err := r.db.Query(ctx, detailsQuery, id)
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, detailsQuery, id)
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +151,7 @@ func (r *reportCardRepository) GetByStudentAndSemester(ctx context.Context, stud
 		WHERE student_id = $1 AND semester_id = $2 AND deleted_at IS NULL
 	`
 	var rc entity.ReportCard
-	err := r.db.QueryRow(ctx, query, studentID, semesterID).Scan(
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, studentID, semesterID).Scan(
 		&rc.ID, &rc.TenantID, &rc.StudentID, &rc.ClassID, &rc.SemesterID, &rc.Status,
 		&rc.GPA, &rc.TotalCredits, &rc.Attendance, &rc.Comments, &rc.GeneratedAt, &rc.PublishedAt,
 		&rc.CreatedAt, &rc.UpdatedAt,
@@ -170,7 +171,7 @@ func (r *reportCardRepository) GetByStudentAndSemester(ctx context.Context, stud
 		FROM report_card_details
 		WHERE report_card_id = $1 AND deleted_at IS NULL
 	`
-	rows, err := r.db.Query(ctx, detailsQuery, rc.ID)
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, detailsQuery, rc.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +194,7 @@ func (r *reportCardRepository) GetByStudentAndSemester(ctx context.Context, stud
 }
 
 func (r *reportCardRepository) Update(ctx context.Context, rc *entity.ReportCard) error {
-	tx, err := r.db.Begin(ctx)
+	tx, err := outbox.Conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -263,7 +264,7 @@ func (r *reportCardRepository) List(ctx context.Context, classID, semesterID uui
 		FROM report_cards
 		WHERE class_id = $1 AND semester_id = $2 AND deleted_at IS NULL
 	`
-	rows, err := r.db.Query(ctx, query, classID, semesterID)
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, query, classID, semesterID)
 	if err != nil {
 		return nil, err
 	}
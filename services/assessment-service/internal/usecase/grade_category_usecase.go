@@ -40,10 +40,15 @@ func (u *gradeCategoryUseCase) Create(ctx context.Context, category *entity.Grad
 	return u.repo.Create(ctx, category)
 }
 
-func (u *gradeCategoryUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.GradeCategory, error) {
+func (u *gradeCategoryUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.GradeCategory, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
-	return u.repo.GetByID(ctx, id)
+
+	category, err := u.repo.GetByID(ctx, id)
+	if err != nil || category == nil || category.TenantID != tenantID {
+		return nil, err
+	}
+	return category, nil
 }
 
 func (u *gradeCategoryUseCase) GetByTenantID(ctx context.Context, tenantID string) ([]*entity.GradeCategory, error) {
@@ -65,7 +70,15 @@ func (u *gradeCategoryUseCase) Update(ctx context.Context, category *entity.Grad
 	return u.repo.Update(ctx, category)
 }
 
-func (u *gradeCategoryUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *gradeCategoryUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
+	category, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if category == nil {
+		return domainUseCase.ErrGradeCategoryNotFound
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	return u.repo.Delete(ctx, id)
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/mocks"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -67,18 +68,26 @@ func TestGradeCategoryUseCase_GetByID(t *testing.T) {
 	id := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		expected := &entity.GradeCategory{ID: id}
+		expected := &entity.GradeCategory{ID: id, TenantID: "tenant-1"}
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(expected, nil)
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), "tenant-1", id)
 		assert.NoError(t, err)
 		assert.Equal(t, expected, res)
 	})
 
+	t.Run("other tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.GradeCategory{ID: id, TenantID: "tenant-2"}, nil)
+
+		res, err := u.GetByID(context.Background(), "tenant-1", id)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
 	t.Run("not found", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, errors.New("not found"))
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), "tenant-1", id)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -141,10 +150,20 @@ func TestGradeCategoryUseCase_Delete(t *testing.T) {
 
 	id := uuid.New()
 
+	category := &entity.GradeCategory{ID: id, TenantID: "tenant-1"}
+
 	t.Run("success", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(category, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
 
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), "tenant-1", id)
 		assert.NoError(t, err)
 	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(category, nil)
+
+		err := u.Delete(context.Background(), "tenant-2", id)
+		assert.ErrorIs(t, err, domainUseCase.ErrGradeCategoryNotFound)
+	})
 }
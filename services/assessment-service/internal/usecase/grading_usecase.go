@@ -9,18 +9,24 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/repository"
 	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/audit"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 )
 
 type gradingUseCase struct {
 	assessmentRepo repository.AssessmentRepository
 	gradeRepo      repository.GradeRepository
+	events         outbox.Events
+	auditor        *audit.Publisher
 	contextTimeout time.Duration
 }
 
-func NewGradingUseCase(assessmentRepo repository.AssessmentRepository, gradeRepo repository.GradeRepository, timeout time.Duration) domainUseCase.GradingUseCase {
+func NewGradingUseCase(assessmentRepo repository.AssessmentRepository, gradeRepo repository.GradeRepository, events outbox.Events, auditor *audit.Publisher, timeout time.Duration) domainUseCase.GradingUseCase {
 	return &gradingUseCase{
 		assessmentRepo: assessmentRepo,
 		gradeRepo:      gradeRepo,
+		events:         events,
+		auditor:        auditor,
 		contextTimeout: timeout,
 	}
 }
@@ -54,7 +60,7 @@ func (u *gradingUseCase) InputGrade(ctx context.Context, grade *entity.Grade) er
 	return u.gradeRepo.Create(ctx, grade)
 }
 
-func (u *gradingUseCase) GetStudentGrades(ctx context.Context, studentID, classID, semesterID uuid.UUID) ([]*entity.Grade, error) {
+func (u *gradingUseCase) GetStudentGrades(ctx context.Context, tenantID string, studentID, classID, semesterID uuid.UUID) ([]*entity.Grade, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
@@ -76,6 +82,9 @@ func (u *gradingUseCase) GetStudentGrades(ctx context.Context, studentID, classI
 	// Let's implement a simple filter loop.
 	var filteredGrades []*entity.Grade
 	for _, grade := range grades {
+		if grade.TenantID != tenantID {
+			continue
+		}
 		assessment, err := u.assessmentRepo.GetByID(ctx, grade.AssessmentID)
 		if err != nil {
 			continue 
@@ -89,7 +98,7 @@ func (u *gradingUseCase) GetStudentGrades(ctx context.Context, studentID, classI
 	return filteredGrades, nil
 }
 
-func (u *gradingUseCase) CalculateFinalScore(ctx context.Context, studentID, classID, subjectID, semesterID uuid.UUID) (float64, error) {
+func (u *gradingUseCase) CalculateFinalScore(ctx context.Context, tenantID string, studentID, classID, subjectID, semesterID uuid.UUID) (float64, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
@@ -125,7 +134,7 @@ func (u *gradingUseCase) CalculateFinalScore(ctx context.Context, studentID, cla
 		// For now, assuming equal weight or just summing up scores / count (simple average)
 		// Or better, assume max_score is the weight base.
 		
-		if grade != nil {
+		if grade != nil && grade.TenantID == tenantID {
 			// Basic calculation: (Score / MaxScore) * 100
 			// If we want weighted average, we need categories.
 			// Let's implement simple average of percentages for now.
@@ -144,7 +153,7 @@ func (u *gradingUseCase) CalculateFinalScore(ctx context.Context, studentID, cla
 	return totalScore / totalWeight, nil
 }
 
-func (u *gradingUseCase) ApproveGrade(ctx context.Context, gradeID uuid.UUID, approvedBy uuid.UUID) error {
+func (u *gradingUseCase) ApproveGrade(ctx context.Context, tenantID string, gradeID uuid.UUID, approvedBy uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
@@ -152,15 +161,28 @@ func (u *gradingUseCase) ApproveGrade(ctx context.Context, gradeID uuid.UUID, ap
 	if err != nil {
 		return err
 	}
-	if grade == nil {
-		return errors.New("grade not found")
+	if grade == nil || grade.TenantID != tenantID {
+		return domainUseCase.ErrGradeNotFound
 	}
 
+	before := map[string]any{"status": grade.Status, "score": grade.Score}
 	grade.Status = entity.GradeStatusFinal
 	grade.ApprovedBy = &approvedBy
 	now := time.Now()
 	grade.ApprovedAt = &now
 	grade.UpdatedAt = now
 
-	return u.gradeRepo.Update(ctx, grade)
+	return u.events.Transact(ctx, func(ctx context.Context) error {
+		if err := u.gradeRepo.Update(ctx, grade); err != nil {
+			return err
+		}
+		return u.auditor.Record(ctx, audit.Entry{
+			TenantID:     grade.TenantID,
+			Action:       "grade.approve",
+			ResourceType: "grade",
+			ResourceID:   &grade.ID,
+			OldValues:    before,
+			NewValues:    map[string]any{"status": grade.Status, "score": grade.Score, "approved_by": approvedBy},
+		})
+	})
 }
@@ -9,7 +9,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/mocks"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/audit"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox/outboxtest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -21,7 +24,7 @@ func TestGradingUseCase_CreateAssessment(t *testing.T) {
 	mockAssessmentRepo := mocks.NewMockAssessmentRepository(ctrl)
 	mockGradeRepo := mocks.NewMockGradeRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewGradingUseCase(mockAssessmentRepo, mockGradeRepo, timeout)
+	u := usecase.NewGradingUseCase(mockAssessmentRepo, mockGradeRepo, &outboxtest.Recorder{}, nil, timeout)
 
 	t.Run("success", func(t *testing.T) {
 		assessment := &entity.Assessment{
@@ -58,7 +61,7 @@ func TestGradingUseCase_InputGrade(t *testing.T) {
 	mockAssessmentRepo := mocks.NewMockAssessmentRepository(ctrl)
 	mockGradeRepo := mocks.NewMockGradeRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewGradingUseCase(mockAssessmentRepo, mockGradeRepo, timeout)
+	u := usecase.NewGradingUseCase(mockAssessmentRepo, mockGradeRepo, &outboxtest.Recorder{}, nil, timeout)
 
 	assessmentID := uuid.New()
 	studentID := uuid.New()
@@ -110,33 +113,51 @@ func TestGradingUseCase_GetStudentGrades(t *testing.T) {
 	mockAssessmentRepo := mocks.NewMockAssessmentRepository(ctrl)
 	mockGradeRepo := mocks.NewMockGradeRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewGradingUseCase(mockAssessmentRepo, mockGradeRepo, timeout)
+	u := usecase.NewGradingUseCase(mockAssessmentRepo, mockGradeRepo, &outboxtest.Recorder{}, nil, timeout)
 
 	studentID := uuid.New()
 	classID := uuid.New()
 	semesterID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		grades := []*entity.Grade{{StudentID: studentID, AssessmentID: uuid.New()}}
+		grades := []*entity.Grade{{TenantID: "tenant-1", StudentID: studentID, AssessmentID: uuid.New()}}
 		assessment := &entity.Assessment{ID: grades[0].AssessmentID, ClassID: classID, SemesterID: semesterID}
 		
 		mockGradeRepo.EXPECT().GetByStudentID(gomock.Any(), studentID).Return(grades, nil)
 		mockAssessmentRepo.EXPECT().GetByID(gomock.Any(), grades[0].AssessmentID).Return(assessment, nil)
 
-		res, err := u.GetStudentGrades(context.Background(), studentID, classID, semesterID)
+		res, err := u.GetStudentGrades(context.Background(), "tenant-1", studentID, classID, semesterID)
 		assert.NoError(t, err)
 		assert.Equal(t, grades, res)
 	})
 
+	t.Run("other tenant", func(t *testing.T) {
+		grades := []*entity.Grade{{TenantID: "tenant-2", StudentID: studentID, AssessmentID: uuid.New()}}
+		mockGradeRepo.EXPECT().GetByStudentID(gomock.Any(), studentID).Return(grades, nil)
+
+		res, err := u.GetStudentGrades(context.Background(), "tenant-1", studentID, classID, semesterID)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		mockGradeRepo.EXPECT().GetByStudentID(gomock.Any(), studentID).Return(nil, errors.New("db error"))
 
-		res, err := u.GetStudentGrades(context.Background(), studentID, classID, semesterID)
+		res, err := u.GetStudentGrades(context.Background(), "tenant-1", studentID, classID, semesterID)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
 }
 
+type auditEvents struct {
+	payloads []map[string]any
+}
+
+func (a *auditEvents) Enqueue(_ context.Context, exchange, routingKey string, payload map[string]any) error {
+	a.payloads = append(a.payloads, payload)
+	return nil
+}
+
 func TestGradingUseCase_ApproveGrade(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -144,13 +165,13 @@ func TestGradingUseCase_ApproveGrade(t *testing.T) {
 	mockAssessmentRepo := mocks.NewMockAssessmentRepository(ctrl)
 	mockGradeRepo := mocks.NewMockGradeRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewGradingUseCase(mockAssessmentRepo, mockGradeRepo, timeout)
+	u := usecase.NewGradingUseCase(mockAssessmentRepo, mockGradeRepo, &outboxtest.Recorder{}, nil, timeout)
 
 	gradeID := uuid.New()
 	approverID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		grade := &entity.Grade{ID: gradeID, Status: entity.GradeStatusDraft}
+		grade := &entity.Grade{ID: gradeID, TenantID: "t1", Status: entity.GradeStatusDraft}
 		mockGradeRepo.EXPECT().GetByID(gomock.Any(), gradeID).Return(grade, nil)
 		mockGradeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, g *entity.Grade) error {
 			// entity.GradeStatusApproved doesn't exist, using GradeStatusFinal
@@ -159,16 +180,39 @@ func TestGradingUseCase_ApproveGrade(t *testing.T) {
 			return nil
 		})
 
-		err := u.ApproveGrade(context.Background(), gradeID, approverID)
+		err := u.ApproveGrade(context.Background(), "t1", gradeID, approverID)
 		assert.NoError(t, err)
 	})
 
+	t.Run("recorded in audit trail", func(t *testing.T) {
+		events := &auditEvents{}
+		audited := usecase.NewGradingUseCase(mockAssessmentRepo, mockGradeRepo, &outboxtest.Recorder{}, audit.NewPublisher(events), timeout)
+		grade := &entity.Grade{ID: gradeID, TenantID: "t1", Status: entity.GradeStatusDraft, Score: 88}
+		mockGradeRepo.EXPECT().GetByID(gomock.Any(), gradeID).Return(grade, nil)
+		mockGradeRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, audited.ApproveGrade(context.Background(), "t1", gradeID, approverID))
+		if assert.Len(t, events.payloads, 1) {
+			assert.Equal(t, "grade.approve", events.payloads[0]["action"])
+			assert.Equal(t, map[string]any{"status": entity.GradeStatusDraft, "score": 88.0}, events.payloads[0]["old_values"])
+		}
+	})
+
 	t.Run("grade not found", func(t *testing.T) {
 		mockGradeRepo.EXPECT().GetByID(gomock.Any(), gradeID).Return(nil, errors.New("not found"))
 
-		err := u.ApproveGrade(context.Background(), gradeID, approverID)
+		err := u.ApproveGrade(context.Background(), "t1", gradeID, approverID)
 		assert.Error(t, err)
 	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		grade := &entity.Grade{ID: gradeID, TenantID: "t2", Status: entity.GradeStatusDraft}
+		mockGradeRepo.EXPECT().GetByID(gomock.Any(), gradeID).Return(grade, nil)
+
+		err := u.ApproveGrade(context.Background(), "t1", gradeID, approverID)
+		assert.ErrorIs(t, err, domainUseCase.ErrGradeNotFound)
+		assert.Equal(t, entity.GradeStatusDraft, grade.Status)
+	})
 }
 
 func TestGradingUseCase_CalculateFinalScore(t *testing.T) {
@@ -178,7 +222,7 @@ func TestGradingUseCase_CalculateFinalScore(t *testing.T) {
 	mockAssessmentRepo := mocks.NewMockAssessmentRepository(ctrl)
 	mockGradeRepo := mocks.NewMockGradeRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewGradingUseCase(mockAssessmentRepo, mockGradeRepo, timeout)
+	u := usecase.NewGradingUseCase(mockAssessmentRepo, mockGradeRepo, &outboxtest.Recorder{}, nil, timeout)
 
 	studentID := uuid.New()
 	subjectID := uuid.New()
@@ -192,10 +236,10 @@ func TestGradingUseCase_CalculateFinalScore(t *testing.T) {
 
 		mockAssessmentRepo.EXPECT().GetByClassAndSubject(gomock.Any(), classID, subjectID).Return(assessments, nil)
 
-		mockGradeRepo.EXPECT().GetByStudentAndAssessment(gomock.Any(), studentID, assessment1.ID).Return(&entity.Grade{Score: 80}, nil)
-		mockGradeRepo.EXPECT().GetByStudentAndAssessment(gomock.Any(), studentID, assessment2.ID).Return(&entity.Grade{Score: 90}, nil)
+		mockGradeRepo.EXPECT().GetByStudentAndAssessment(gomock.Any(), studentID, assessment1.ID).Return(&entity.Grade{TenantID: "tenant-1", Score: 80}, nil)
+		mockGradeRepo.EXPECT().GetByStudentAndAssessment(gomock.Any(), studentID, assessment2.ID).Return(&entity.Grade{TenantID: "tenant-1", Score: 90}, nil)
 
-		score, err := u.CalculateFinalScore(context.Background(), studentID, classID, subjectID, semesterID)
+		score, err := u.CalculateFinalScore(context.Background(), "tenant-1", studentID, classID, subjectID, semesterID)
 		assert.NoError(t, err)
 		assert.Equal(t, 85.0, score) // (80 + 90) / 2
 	})
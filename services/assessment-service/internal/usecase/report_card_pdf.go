@@ -6,19 +6,20 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/entity"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/usecase"
 	"github.com/johnfercher/maroto/pkg/color"
 	"github.com/johnfercher/maroto/pkg/consts"
 	"github.com/johnfercher/maroto/pkg/pdf"
 	"github.com/johnfercher/maroto/pkg/props"
 )
 
-func (u *reportCardUseCase) GetPDF(ctx context.Context, id uuid.UUID) ([]byte, error) {
-	report, err := u.GetByID(ctx, id)
+func (u *reportCardUseCase) GetPDF(ctx context.Context, tenantID string, id uuid.UUID) ([]byte, error) {
+	report, err := u.GetByID(ctx, tenantID, id)
 	if err != nil {
 		return nil, err
 	}
 	if report == nil {
-		return nil, fmt.Errorf("report card not found")
+		return nil, domainUseCase.ErrReportCardNotFound
 	}
 
 	return u.generatePDF(report)
@@ -12,6 +12,8 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/repository"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/service"
 	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/audit"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 )
 
 type reportCardUseCase struct {
@@ -20,6 +22,8 @@ type reportCardUseCase struct {
 	assessmentRepo    repository.AssessmentRepository
 	gradeCategoryRepo repository.GradeCategoryRepository
 	fileStorage       service.FileStorage
+	events            outbox.Events
+	auditor           *audit.Publisher
 }
 
 func NewReportCardUseCase(
@@ -28,6 +32,8 @@ func NewReportCardUseCase(
 	aRepo repository.AssessmentRepository,
 	gcRepo repository.GradeCategoryRepository,
 	fileStorage service.FileStorage,
+	events outbox.Events,
+	auditor *audit.Publisher,
 ) domainUseCase.ReportCardUseCase {
 	return &reportCardUseCase{
 		reportCardRepo:    rcRepo,
@@ -35,6 +41,8 @@ func NewReportCardUseCase(
 		assessmentRepo:    aRepo,
 		gradeCategoryRepo: gcRepo,
 		fileStorage:       fileStorage,
+		events:            events,
+		auditor:           auditor,
 	}
 }
 
@@ -192,26 +200,47 @@ func (u *reportCardUseCase) Generate(ctx context.Context, tenantID string, stude
 	return rc, nil
 }
 
-func (u *reportCardUseCase) GetByStudent(ctx context.Context, studentID, semesterID uuid.UUID) (*entity.ReportCard, error) {
-	return u.reportCardRepo.GetByStudentAndSemester(ctx, studentID, semesterID)
+func (u *reportCardUseCase) GetByStudent(ctx context.Context, tenantID string, studentID, semesterID uuid.UUID) (*entity.ReportCard, error) {
+	rc, err := u.reportCardRepo.GetByStudentAndSemester(ctx, studentID, semesterID)
+	if err != nil || rc == nil || rc.TenantID != tenantID {
+		return nil, err
+	}
+	return rc, nil
 }
 
-func (u *reportCardUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.ReportCard, error) {
-	return u.reportCardRepo.GetByID(ctx, id)
+func (u *reportCardUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.ReportCard, error) {
+	rc, err := u.reportCardRepo.GetByID(ctx, id)
+	if err != nil || rc == nil || rc.TenantID != tenantID {
+		return nil, err
+	}
+	return rc, nil
 }
 
-func (u *reportCardUseCase) Publish(ctx context.Context, id uuid.UUID) error {
-	rc, err := u.reportCardRepo.GetByID(ctx, id)
+func (u *reportCardUseCase) Publish(ctx context.Context, tenantID string, id uuid.UUID) error {
+	rc, err := u.GetByID(ctx, tenantID, id)
 	if err != nil {
 		return err
 	}
 	if rc == nil {
-		return errors.New("report card not found")
+		return domainUseCase.ErrReportCardNotFound
 	}
 	
+	before := rc.Status
 	rc.Status = entity.ReportCardStatusPublished
 	now := time.Now()
 	rc.PublishedAt = &now
 	
-	return u.reportCardRepo.Update(ctx, rc)
+	return u.events.Transact(ctx, func(ctx context.Context) error {
+		if err := u.reportCardRepo.Update(ctx, rc); err != nil {
+			return err
+		}
+		return u.auditor.Record(ctx, audit.Entry{
+			TenantID:     rc.TenantID,
+			Action:       "report_card.publish",
+			ResourceType: "report_card",
+			ResourceID:   &rc.ID,
+			OldValues:    map[string]any{"status": before},
+			NewValues:    map[string]any{"status": rc.Status, "published_at": now},
+		})
+	})
 }
@@ -12,7 +12,9 @@ import (
 
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/mocks"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox/outboxtest"
 )
 
 func TestReportCardUseCase_Generate(t *testing.T) {
@@ -25,7 +27,7 @@ func TestReportCardUseCase_Generate(t *testing.T) {
 	mockCategoryRepo := mocks.NewMockGradeCategoryRepository(ctrl)
 	mockFileStorage := mocks.NewMockFileStorage(ctrl)
 
-	u := usecase.NewReportCardUseCase(mockReportRepo, mockGradeRepo, mockAssessmentRepo, mockCategoryRepo, mockFileStorage)
+	u := usecase.NewReportCardUseCase(mockReportRepo, mockGradeRepo, mockAssessmentRepo, mockCategoryRepo, mockFileStorage, &outboxtest.Recorder{}, nil)
 
 	ctx := context.Background()
 	tenantID := "tenant-1"
@@ -111,7 +113,7 @@ func TestReportCardUseCase_GetPDF(t *testing.T) {
 	mockCategoryRepo := mocks.NewMockGradeCategoryRepository(ctrl)
 	mockFileStorage := mocks.NewMockFileStorage(ctrl)
 
-	u := usecase.NewReportCardUseCase(mockReportRepo, mockGradeRepo, mockAssessmentRepo, mockCategoryRepo, mockFileStorage)
+	u := usecase.NewReportCardUseCase(mockReportRepo, mockGradeRepo, mockAssessmentRepo, mockCategoryRepo, mockFileStorage, &outboxtest.Recorder{}, nil)
 	ctx := context.Background()
 	id := uuid.New()
 
@@ -119,6 +121,7 @@ func TestReportCardUseCase_GetPDF(t *testing.T) {
 		now := time.Now()
 		report := &entity.ReportCard{
 			ID:           id,
+			TenantID:     "tenant-1",
 			StudentID:    uuid.New(),
 			ClassID:      uuid.New(),
 			SemesterID:   uuid.New(),
@@ -138,7 +141,7 @@ func TestReportCardUseCase_GetPDF(t *testing.T) {
 
 		mockReportRepo.EXPECT().GetByID(ctx, id).Return(report, nil)
 
-		pdfBytes, err := u.GetPDF(ctx, id)
+		pdfBytes, err := u.GetPDF(ctx, "tenant-1", id)
 		assert.NoError(t, err)
 		assert.NotNil(t, pdfBytes)
 		assert.NotEmpty(t, pdfBytes)
@@ -149,16 +152,24 @@ func TestReportCardUseCase_GetPDF(t *testing.T) {
 	t.Run("not_found", func(t *testing.T) {
 		mockReportRepo.EXPECT().GetByID(ctx, id).Return(nil, nil)
 
-		pdfBytes, err := u.GetPDF(ctx, id)
+		pdfBytes, err := u.GetPDF(ctx, "tenant-1", id)
 		assert.Error(t, err)
 		assert.Nil(t, pdfBytes)
 		assert.Equal(t, "report card not found", err.Error())
 	})
 
+	t.Run("other_tenant", func(t *testing.T) {
+		mockReportRepo.EXPECT().GetByID(ctx, id).Return(&entity.ReportCard{ID: id, TenantID: "tenant-2"}, nil)
+
+		pdfBytes, err := u.GetPDF(ctx, "tenant-1", id)
+		assert.ErrorIs(t, err, domainUseCase.ErrReportCardNotFound)
+		assert.Nil(t, pdfBytes)
+	})
+
 	t.Run("repo_error", func(t *testing.T) {
 		mockReportRepo.EXPECT().GetByID(ctx, id).Return(nil, errors.New("db error"))
 
-		pdfBytes, err := u.GetPDF(ctx, id)
+		pdfBytes, err := u.GetPDF(ctx, "tenant-1", id)
 		assert.Error(t, err)
 		assert.Nil(t, pdfBytes)
 		assert.Equal(t, "db error", err.Error())
@@ -170,24 +181,33 @@ func TestReportCardUseCase_GetByStudent(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockReportRepo := mocks.NewMockReportCardRepository(ctrl)
-	u := usecase.NewReportCardUseCase(mockReportRepo, nil, nil, nil, nil)
+	u := usecase.NewReportCardUseCase(mockReportRepo, nil, nil, nil, nil, &outboxtest.Recorder{}, nil)
 	ctx := context.Background()
 	studentID := uuid.New()
 	semesterID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		expected := &entity.ReportCard{ID: uuid.New()}
+		expected := &entity.ReportCard{ID: uuid.New(), TenantID: "tenant-1"}
 		mockReportRepo.EXPECT().GetByStudentAndSemester(ctx, studentID, semesterID).Return(expected, nil)
 
-		res, err := u.GetByStudent(ctx, studentID, semesterID)
+		res, err := u.GetByStudent(ctx, "tenant-1", studentID, semesterID)
 		assert.NoError(t, err)
 		assert.Equal(t, expected, res)
 	})
 
+	t.Run("other_tenant", func(t *testing.T) {
+		other := &entity.ReportCard{ID: uuid.New(), TenantID: "tenant-2"}
+		mockReportRepo.EXPECT().GetByStudentAndSemester(ctx, studentID, semesterID).Return(other, nil)
+
+		res, err := u.GetByStudent(ctx, "tenant-1", studentID, semesterID)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		mockReportRepo.EXPECT().GetByStudentAndSemester(ctx, studentID, semesterID).Return(nil, errors.New("db error"))
 
-		res, err := u.GetByStudent(ctx, studentID, semesterID)
+		res, err := u.GetByStudent(ctx, "tenant-1", studentID, semesterID)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -198,36 +218,45 @@ func TestReportCardUseCase_Publish(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockReportRepo := mocks.NewMockReportCardRepository(ctrl)
-	u := usecase.NewReportCardUseCase(mockReportRepo, nil, nil, nil, nil)
+	u := usecase.NewReportCardUseCase(mockReportRepo, nil, nil, nil, nil, &outboxtest.Recorder{}, nil)
 	ctx := context.Background()
 	id := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		rc := &entity.ReportCard{ID: id, Status: entity.ReportCardStatusGenerated}
+		rc := &entity.ReportCard{ID: id, TenantID: "tenant-1", Status: entity.ReportCardStatusGenerated}
 		mockReportRepo.EXPECT().GetByID(ctx, id).Return(rc, nil)
 
-		mockReportRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, r *entity.ReportCard) error {
+		mockReportRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, r *entity.ReportCard) error {
 			assert.Equal(t, entity.ReportCardStatusPublished, r.Status)
 			assert.NotNil(t, r.PublishedAt)
 			return nil
 		})
 
-		err := u.Publish(ctx, id)
+		err := u.Publish(ctx, "tenant-1", id)
 		assert.NoError(t, err)
 	})
 
 	t.Run("not_found", func(t *testing.T) {
 		mockReportRepo.EXPECT().GetByID(ctx, id).Return(nil, nil)
 
-		err := u.Publish(ctx, id)
+		err := u.Publish(ctx, "tenant-1", id)
 		assert.Error(t, err)
 		assert.Equal(t, "report card not found", err.Error())
 	})
 
+	t.Run("other_tenant", func(t *testing.T) {
+		rc := &entity.ReportCard{ID: id, TenantID: "tenant-2", Status: entity.ReportCardStatusGenerated}
+		mockReportRepo.EXPECT().GetByID(ctx, id).Return(rc, nil)
+
+		err := u.Publish(ctx, "tenant-1", id)
+		assert.ErrorIs(t, err, domainUseCase.ErrReportCardNotFound)
+		assert.Equal(t, entity.ReportCardStatusGenerated, rc.Status)
+	})
+
 	t.Run("db_error_get", func(t *testing.T) {
 		mockReportRepo.EXPECT().GetByID(ctx, id).Return(nil, errors.New("db error"))
 
-		err := u.Publish(ctx, id)
+		err := u.Publish(ctx, "tenant-1", id)
 		assert.Error(t, err)
 	})
 }
@@ -40,8 +40,12 @@ func (u *templateUseCase) Create(ctx context.Context, template *entity.ReportCar
 	return u.repo.Create(ctx, template)
 }
 
-func (u *templateUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.ReportCardTemplate, error) {
-	return u.repo.GetByID(ctx, id)
+func (u *templateUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.ReportCardTemplate, error) {
+	template, err := u.repo.GetByID(ctx, id)
+	if err != nil || template == nil || template.TenantID != tenantID {
+		return nil, err
+	}
+	return template, nil
 }
 
 func (u *templateUseCase) GetByTenantID(ctx context.Context, tenantID string) ([]*entity.ReportCardTemplate, error) {
@@ -49,12 +53,12 @@ func (u *templateUseCase) GetByTenantID(ctx context.Context, tenantID string) ([
 }
 
 func (u *templateUseCase) Update(ctx context.Context, template *entity.ReportCardTemplate) error {
-	existing, err := u.repo.GetByID(ctx, template.ID)
+	existing, err := u.GetByID(ctx, template.TenantID, template.ID)
 	if err != nil {
 		return err
 	}
 	if existing == nil {
-		return errors.New("template not found")
+		return domainUseCase.ErrTemplateNotFound
 	}
 
 	if template.Name != "" {
@@ -67,6 +71,13 @@ func (u *templateUseCase) Update(ctx context.Context, template *entity.ReportCar
 	return u.repo.Update(ctx, existing)
 }
 
-func (u *templateUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *templateUseCase) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
+	template, err := u.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if template == nil {
+		return domainUseCase.ErrTemplateNotFound
+	}
 	return u.repo.Delete(ctx, id)
 }
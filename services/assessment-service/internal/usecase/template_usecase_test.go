@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/mocks"
+	domainUseCase "github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -70,13 +71,22 @@ func TestTemplateUseCase_GetByID(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		id := uuid.New()
-		expected := &entity.ReportCardTemplate{ID: id}
+		expected := &entity.ReportCardTemplate{ID: id, TenantID: "tenant-1"}
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(expected, nil)
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), "tenant-1", id)
 		assert.NoError(t, err)
 		assert.Equal(t, expected, res)
 	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		id := uuid.New()
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.ReportCardTemplate{ID: id, TenantID: "tenant-2"}, nil)
+
+		res, err := u.GetByID(context.Background(), "tenant-1", id)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
 }
 
 func TestTemplateUseCase_GetByTenantID(t *testing.T) {
@@ -107,12 +117,14 @@ func TestTemplateUseCase_Update(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		id := uuid.New()
 		template := &entity.ReportCardTemplate{
-			ID:   id,
-			Name: "Updated Name",
+			ID:       id,
+			TenantID: "tenant-1",
+			Name:     "Updated Name",
 		}
 		existing := &entity.ReportCardTemplate{
-			ID:   id,
-			Name: "Old Name",
+			ID:       id,
+			TenantID: "tenant-1",
+			Name:     "Old Name",
 		}
 
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
@@ -134,6 +146,15 @@ func TestTemplateUseCase_Update(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, "template not found", err.Error())
 	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		id := uuid.New()
+		template := &entity.ReportCardTemplate{ID: id, TenantID: "tenant-1", Name: "Updated Name"}
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.ReportCardTemplate{ID: id, TenantID: "tenant-2"}, nil)
+
+		err := u.Update(context.Background(), template)
+		assert.ErrorIs(t, err, domainUseCase.ErrTemplateNotFound)
+	})
 }
 
 func TestTemplateUseCase_Delete(t *testing.T) {
@@ -145,9 +166,18 @@ func TestTemplateUseCase_Delete(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		id := uuid.New()
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.ReportCardTemplate{ID: id, TenantID: "tenant-1"}, nil)
 		mockRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
 
-		err := u.Delete(context.Background(), id)
+		err := u.Delete(context.Background(), "tenant-1", id)
 		assert.NoError(t, err)
 	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		id := uuid.New()
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.ReportCardTemplate{ID: id, TenantID: "tenant-2"}, nil)
+
+		err := u.Delete(context.Background(), "tenant-1", id)
+		assert.ErrorIs(t, err, domainUseCase.ErrTemplateNotFound)
+	})
 }
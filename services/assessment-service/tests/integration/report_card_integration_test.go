@@ -14,6 +14,8 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/domain/mocks"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/handler"
 	"github.com/jjaenal/sisfo-akademik-backend/services/assessment-service/internal/usecase"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox/outboxtest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -32,13 +34,15 @@ func TestReportCardIntegration(t *testing.T) {
 	mockFileStorage := mocks.NewMockFileStorage(ctrl)
 
 	// Real UseCase with Mock Repos
-	u := usecase.NewReportCardUseCase(mockReportRepo, mockGradeRepo, mockAssessmentRepo, mockCategoryRepo, mockFileStorage)
+	u := usecase.NewReportCardUseCase(mockReportRepo, mockGradeRepo, mockAssessmentRepo, mockCategoryRepo, mockFileStorage, &outboxtest.Recorder{}, nil)
 
 	// Real Handler with Real UseCase
 	h := handler.NewReportCardHandler(u)
 
 	// Setup Router
+	tenantID := uuid.New().String()
 	r := gin.New()
+	r.Use(authenticate(tenantID))
 	reportCards := r.Group("/api/v1/report-cards")
 	{
 		reportCards.POST("/generate", h.Generate)
@@ -46,13 +50,11 @@ func TestReportCardIntegration(t *testing.T) {
 	}
 
 	t.Run("Generate Report Card Success", func(t *testing.T) {
-		tenantID := uuid.New().String()
 		studentID := uuid.New()
 		classID := uuid.New()
 		semesterID := uuid.New()
 
 		reqBody := map[string]interface{}{
-			"tenant_id":   tenantID,
 			"student_id":  studentID.String(),
 			"class_id":    classID.String(),
 			"semester_id": semesterID.String(),
@@ -123,3 +125,12 @@ func TestReportCardIntegration(t *testing.T) {
 		assert.Equal(t, "http://example.com/report.pdf", resp.Data.PDFUrl)
 	})
 }
+
+// authenticate stands in for the auth middleware, which exposes the verified
+// caller as "claims".
+func authenticate(tenantID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID})
+		c.Next()
+	}
+}
@@ -14,8 +14,12 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/middleware"
 	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/repository/postgres"
 	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/database"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/guardian"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/identity"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/logger"
 	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/tracer"
@@ -91,9 +95,16 @@ func main() {
 
 	redis := redisutil.New(cfg.RedisAddr)
 	r.Use(middleware.SecurityHeaders())
-	r.Use(middleware.RateLimitByPolicy(redisutil.NewLimiterFromCounter(redis.Raw()), 100, 30, nil))
+	// Anonymous routes are limited per client address; the authenticated
+	// group runs the limiter after authn so each user has their own budget
+	limit := middleware.RateLimitByPolicy(redisutil.NewTokenBucketLimiter(redis.Raw()), 100, 30, nil, cfg.TrustedProxies)
+	public := r.Group("/", limit)
 
-	r.GET("/api/v1/health", func(c *gin.Context) {
+	authorizer := authz.NewClient(cfg.AuthServiceURL, redis.Raw(), authz.DefaultCacheTTL, 5*time.Second)
+	perm := func(permission string) gin.HandlerFunc { return middleware.Authorization(authorizer, permission) }
+	guardians := guardian.NewClient(cfg.AcademicServiceURL, redis.Raw(), guardian.DefaultCacheTTL, 5*time.Second)
+
+	public.GET("/api/v1/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"success": true,
 			"data": gin.H{
@@ -110,22 +121,36 @@ func main() {
 
 	// Routes
 	v1 := r.Group("/api/v1")
+	keys := jwtutil.NewKeySet(cfg.JWTSigningAlg, cfg.JWTAccessSecret, cfg.JWKSURL)
+	authn := middleware.Auth(keys, cfg.JWTIssuer, cfg.JWTAudience, redis.Raw())
+	if cfg.TrustGateway {
+		// Behind the gateway the signed identity headers replace the bearer token
+		authn = identity.Gin([]byte(cfg.GatewaySecret), redis.Raw())
+	}
+	v1.Use(authn, limit)
 	attendance := v1.Group("/attendance")
 	{
-		attendance.POST("/students", h.Create)
-		attendance.POST("/students/bulk", h.BulkCreate)
-		attendance.GET("/students", h.GetByClassAndDate)
-		attendance.GET("/students/:id", h.GetByID)
-		attendance.GET("/students/:id/summary", h.GetSummary)
-		attendance.PUT("/students/:id", h.Update)
-
-		attendance.GET("/reports/daily", h.GetDailyReport)
-		attendance.GET("/reports/monthly", h.GetMonthlyReport)
-		attendance.GET("/reports/class/:class_id", h.GetClassReport)
-
-		attendance.POST("/teachers/checkin", teacherHandler.CheckIn)
-		attendance.PUT("/teachers/checkout", teacherHandler.CheckOut)
-		attendance.GET("/teachers", teacherHandler.GetByTeacherAndDate)
+		attendance.POST("/students", perm(authz.AttendanceWrite), h.Create)
+		attendance.POST("/students/bulk", perm(authz.AttendanceWrite), h.BulkCreate)
+		attendance.GET("/students", perm(authz.AttendanceRead), h.GetByClassAndDate)
+		attendance.GET("/students/:id", perm(authz.AttendanceRead), h.GetByID)
+		attendance.GET("/students/:id/summary", perm(authz.AttendanceRead), h.GetSummary)
+		attendance.PUT("/students/:id", perm(authz.AttendanceWrite), h.Update)
+
+		attendance.GET("/reports/daily", perm(authz.AttendanceReport), h.GetDailyReport)
+		attendance.GET("/reports/monthly", perm(authz.AttendanceReport), h.GetMonthlyReport)
+		attendance.GET("/reports/class/:class_id", perm(authz.AttendanceReport), h.GetClassReport)
+
+		attendance.POST("/teachers/checkin", perm(authz.AttendanceCheckin), teacherHandler.CheckIn)
+		attendance.PUT("/teachers/checkout", perm(authz.AttendanceCheckin), teacherHandler.CheckOut)
+		attendance.GET("/teachers", perm(authz.AttendanceRead), teacherHandler.GetByTeacherAndDate)
+	}
+
+	// Guardian portal, scoped to the caller's own children. The gateway routes
+	// /api/v1/guardian/attendance/ here
+	guardianPortal := v1.Group("/guardian/attendance/students", perm(authz.GuardianChildRead))
+	{
+		guardianPortal.GET("/:id/attendance/summary", middleware.GuardianScope(guardians, "id"), h.GetSummary)
 	}
 
 	port := os.Getenv("APP_HTTP_PORT")
@@ -17,6 +17,8 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/handler"
 	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/repository/postgres"
 	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/identity"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -60,7 +62,18 @@ func ensureMigrations(t *testing.T, db *pgxpool.Pool) {
 
 
 
-func setupServer(t *testing.T) (*gin.Engine, *pgxpool.Pool) {
+// gatewaySecret signs the identity headers the gateway would send.
+var gatewaySecret = []byte("test-gateway-secret")
+
+// asGateway signs each request as the gateway does for a user of tenantID.
+func asGateway(tenantID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity.Sign(c.Request, gatewaySecret, jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID}, time.Now())
+		c.Next()
+	}
+}
+
+func setupServer(t *testing.T, tenantID string) (*gin.Engine, *pgxpool.Pool) {
 	gin.SetMode(gin.TestMode)
 	db := testDB(t)
 	ensureMigrations(t, db)
@@ -74,9 +87,10 @@ func setupServer(t *testing.T) (*gin.Engine, *pgxpool.Pool) {
 	teacherHandler := handler.NewTeacherAttendanceHandler(teacherUC)
 
 	r := gin.New()
-	r.Use(gin.Logger(), gin.Recovery())
+	r.Use(gin.Logger(), gin.Recovery(), asGateway(tenantID))
 
 	v1 := r.Group("/api/v1")
+	v1.Use(identity.Gin(gatewaySecret, nil))
 	attendance := v1.Group("/attendance")
 	{
 		attendance.POST("/students", h.Create)
@@ -99,12 +113,12 @@ func setupServer(t *testing.T) (*gin.Engine, *pgxpool.Pool) {
 }
 
 func TestIntegration_StudentAttendance(t *testing.T) {
-	r, db := setupServer(t)
+	tenantID := uuid.NewString()
+	r, db := setupServer(t, tenantID)
 	defer db.Close()
 
 	studentID := uuid.New()
 	classID := uuid.New()
-	tenantID := uuid.NewString()
 
 	t.Run("Create Attendance", func(t *testing.T) {
 		body := map[string]interface{}{
@@ -146,12 +160,12 @@ func TestIntegration_StudentAttendance(t *testing.T) {
 }
 
 func TestIntegration_Reports(t *testing.T) {
-	r, db := setupServer(t)
+	tenantID := uuid.NewString()
+	r, db := setupServer(t, tenantID)
 	defer db.Close()
 
 	studentID := uuid.New()
 	classID := uuid.New()
-	tenantID := uuid.NewString()
 	semesterID := uuid.New()
 	date := time.Now()
 
@@ -226,11 +240,11 @@ func TestIntegration_Reports(t *testing.T) {
 }
 
 func TestIntegration_BulkCheckIn(t *testing.T) {
-	r, db := setupServer(t)
+	tenantID := uuid.NewString()
+	r, db := setupServer(t, tenantID)
 	defer db.Close()
 
 	classID := uuid.New()
-	tenantID := uuid.NewString()
 	students := []string{uuid.NewString(), uuid.NewString()}
 
 	t.Run("Bulk Create", func(t *testing.T) {
@@ -269,11 +283,11 @@ func TestIntegration_BulkCheckIn(t *testing.T) {
 }
 
 func TestIntegration_TeacherAttendance(t *testing.T) {
-	r, db := setupServer(t)
+	tenantID := uuid.NewString()
+	r, db := setupServer(t, tenantID)
 	defer db.Close()
 
 	teacherID := uuid.New()
-	tenantID := uuid.NewString()
 	semesterID := uuid.New()
 
 	t.Run("Check In", func(t *testing.T) {
@@ -132,18 +132,18 @@ func (mr *MockStudentAttendanceRepositoryMockRecorder) GetByTenantAndDate(ctx, t
 }
 
 // GetSummary mocks base method.
-func (m *MockStudentAttendanceRepository) GetSummary(ctx context.Context, studentID, semesterID uuid.UUID) (map[string]int, error) {
+func (m *MockStudentAttendanceRepository) GetSummary(ctx context.Context, tenantID string, studentID, semesterID uuid.UUID) (map[string]int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSummary", ctx, studentID, semesterID)
+	ret := m.ctrl.Call(m, "GetSummary", ctx, tenantID, studentID, semesterID)
 	ret0, _ := ret[0].(map[string]int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSummary indicates an expected call of GetSummary.
-func (mr *MockStudentAttendanceRepositoryMockRecorder) GetSummary(ctx, studentID, semesterID any) *gomock.Call {
+func (mr *MockStudentAttendanceRepositoryMockRecorder) GetSummary(ctx, tenantID, studentID, semesterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummary", reflect.TypeOf((*MockStudentAttendanceRepository)(nil).GetSummary), ctx, studentID, semesterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummary", reflect.TypeOf((*MockStudentAttendanceRepository)(nil).GetSummary), ctx, tenantID, studentID, semesterID)
 }
 
 // Update mocks base method.
@@ -72,33 +72,33 @@ func (mr *MockStudentAttendanceUseCaseMockRecorder) Create(ctx, attendance any)
 }
 
 // GetByClassAndDate mocks base method.
-func (m *MockStudentAttendanceUseCase) GetByClassAndDate(ctx context.Context, classID uuid.UUID, date time.Time) ([]*entity.StudentAttendance, error) {
+func (m *MockStudentAttendanceUseCase) GetByClassAndDate(ctx context.Context, tenantID string, classID uuid.UUID, date time.Time) ([]*entity.StudentAttendance, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByClassAndDate", ctx, classID, date)
+	ret := m.ctrl.Call(m, "GetByClassAndDate", ctx, tenantID, classID, date)
 	ret0, _ := ret[0].([]*entity.StudentAttendance)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByClassAndDate indicates an expected call of GetByClassAndDate.
-func (mr *MockStudentAttendanceUseCaseMockRecorder) GetByClassAndDate(ctx, classID, date any) *gomock.Call {
+func (mr *MockStudentAttendanceUseCaseMockRecorder) GetByClassAndDate(ctx, tenantID, classID, date any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByClassAndDate", reflect.TypeOf((*MockStudentAttendanceUseCase)(nil).GetByClassAndDate), ctx, classID, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByClassAndDate", reflect.TypeOf((*MockStudentAttendanceUseCase)(nil).GetByClassAndDate), ctx, tenantID, classID, date)
 }
 
 // GetByID mocks base method.
-func (m *MockStudentAttendanceUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.StudentAttendance, error) {
+func (m *MockStudentAttendanceUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.StudentAttendance, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
 	ret0, _ := ret[0].(*entity.StudentAttendance)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockStudentAttendanceUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockStudentAttendanceUseCaseMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockStudentAttendanceUseCase)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockStudentAttendanceUseCase)(nil).GetByID), ctx, tenantID, id)
 }
 
 // GetClassReport mocks base method.
@@ -147,18 +147,18 @@ func (mr *MockStudentAttendanceUseCaseMockRecorder) GetMonthlyReport(ctx, tenant
 }
 
 // GetSummary mocks base method.
-func (m *MockStudentAttendanceUseCase) GetSummary(ctx context.Context, studentID, semesterID uuid.UUID) (map[string]int, error) {
+func (m *MockStudentAttendanceUseCase) GetSummary(ctx context.Context, tenantID string, studentID, semesterID uuid.UUID) (map[string]int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSummary", ctx, studentID, semesterID)
+	ret := m.ctrl.Call(m, "GetSummary", ctx, tenantID, studentID, semesterID)
 	ret0, _ := ret[0].(map[string]int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSummary indicates an expected call of GetSummary.
-func (mr *MockStudentAttendanceUseCaseMockRecorder) GetSummary(ctx, studentID, semesterID any) *gomock.Call {
+func (mr *MockStudentAttendanceUseCaseMockRecorder) GetSummary(ctx, tenantID, studentID, semesterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummary", reflect.TypeOf((*MockStudentAttendanceUseCase)(nil).GetSummary), ctx, studentID, semesterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummary", reflect.TypeOf((*MockStudentAttendanceUseCase)(nil).GetSummary), ctx, tenantID, studentID, semesterID)
 }
 
 // Update mocks base method.
@@ -58,32 +58,32 @@ func (mr *MockTeacherAttendanceUseCaseMockRecorder) CheckIn(ctx, attendance any)
 }
 
 // CheckOut mocks base method.
-func (m *MockTeacherAttendanceUseCase) CheckOut(ctx context.Context, teacherID uuid.UUID, date, checkOutTime time.Time) error {
+func (m *MockTeacherAttendanceUseCase) CheckOut(ctx context.Context, tenantID string, teacherID uuid.UUID, date, checkOutTime time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckOut", ctx, teacherID, date, checkOutTime)
+	ret := m.ctrl.Call(m, "CheckOut", ctx, tenantID, teacherID, date, checkOutTime)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CheckOut indicates an expected call of CheckOut.
-func (mr *MockTeacherAttendanceUseCaseMockRecorder) CheckOut(ctx, teacherID, date, checkOutTime any) *gomock.Call {
+func (mr *MockTeacherAttendanceUseCaseMockRecorder) CheckOut(ctx, tenantID, teacherID, date, checkOutTime any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOut", reflect.TypeOf((*MockTeacherAttendanceUseCase)(nil).CheckOut), ctx, teacherID, date, checkOutTime)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOut", reflect.TypeOf((*MockTeacherAttendanceUseCase)(nil).CheckOut), ctx, tenantID, teacherID, date, checkOutTime)
 }
 
 // GetByTeacherAndDate mocks base method.
-func (m *MockTeacherAttendanceUseCase) GetByTeacherAndDate(ctx context.Context, teacherID uuid.UUID, date time.Time) (*entity.TeacherAttendance, error) {
+func (m *MockTeacherAttendanceUseCase) GetByTeacherAndDate(ctx context.Context, tenantID string, teacherID uuid.UUID, date time.Time) (*entity.TeacherAttendance, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByTeacherAndDate", ctx, teacherID, date)
+	ret := m.ctrl.Call(m, "GetByTeacherAndDate", ctx, tenantID, teacherID, date)
 	ret0, _ := ret[0].(*entity.TeacherAttendance)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByTeacherAndDate indicates an expected call of GetByTeacherAndDate.
-func (mr *MockTeacherAttendanceUseCaseMockRecorder) GetByTeacherAndDate(ctx, teacherID, date any) *gomock.Call {
+func (mr *MockTeacherAttendanceUseCaseMockRecorder) GetByTeacherAndDate(ctx, tenantID, teacherID, date any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTeacherAndDate", reflect.TypeOf((*MockTeacherAttendanceUseCase)(nil).GetByTeacherAndDate), ctx, teacherID, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTeacherAndDate", reflect.TypeOf((*MockTeacherAttendanceUseCase)(nil).GetByTeacherAndDate), ctx, tenantID, teacherID, date)
 }
 
 // List mocks base method.
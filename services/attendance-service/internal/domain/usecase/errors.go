@@ -0,0 +1,7 @@
+package usecase
+
+import "errors"
+
+// ErrAttendanceNotFound is returned for an attendance record that does not
+// exist or belongs to another tenant; handlers answer both with 404.
+var ErrAttendanceNotFound = errors.New("attendance record not found")
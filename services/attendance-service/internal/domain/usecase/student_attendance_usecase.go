@@ -10,11 +10,11 @@ import (
 
 type StudentAttendanceUseCase interface {
 	Create(ctx context.Context, attendance *entity.StudentAttendance) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.StudentAttendance, error)
-	GetByClassAndDate(ctx context.Context, classID uuid.UUID, date time.Time) ([]*entity.StudentAttendance, error)
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.StudentAttendance, error)
+	GetByClassAndDate(ctx context.Context, tenantID string, classID uuid.UUID, date time.Time) ([]*entity.StudentAttendance, error)
 	Update(ctx context.Context, attendance *entity.StudentAttendance) error
 	BulkCreate(ctx context.Context, attendances []*entity.StudentAttendance) error
-	GetSummary(ctx context.Context, studentID uuid.UUID, semesterID uuid.UUID) (map[string]int, error)
+	GetSummary(ctx context.Context, tenantID string, studentID uuid.UUID, semesterID uuid.UUID) (map[string]int, error)
 	GetDailyReport(ctx context.Context, tenantID uuid.UUID, date time.Time) ([]*entity.StudentAttendance, error)
 	GetMonthlyReport(ctx context.Context, tenantID uuid.UUID, month int, year int) ([]*entity.StudentAttendance, error)
 	GetClassReport(ctx context.Context, tenantID uuid.UUID, classID uuid.UUID, startDate, endDate time.Time) ([]*entity.StudentAttendance, error)
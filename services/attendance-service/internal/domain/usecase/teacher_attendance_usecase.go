@@ -10,7 +10,7 @@ import (
 
 type TeacherAttendanceUseCase interface {
 	CheckIn(ctx context.Context, attendance *entity.TeacherAttendance) error
-	CheckOut(ctx context.Context, teacherID uuid.UUID, date time.Time, checkOutTime time.Time) error
-	GetByTeacherAndDate(ctx context.Context, teacherID uuid.UUID, date time.Time) (*entity.TeacherAttendance, error)
+	CheckOut(ctx context.Context, tenantID string, teacherID uuid.UUID, date time.Time, checkOutTime time.Time) error
+	GetByTeacherAndDate(ctx context.Context, tenantID string, teacherID uuid.UUID, date time.Time) (*entity.TeacherAttendance, error)
 	List(ctx context.Context, filter map[string]interface{}) ([]*entity.TeacherAttendance, error)
 }
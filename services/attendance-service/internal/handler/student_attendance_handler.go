@@ -32,8 +32,11 @@ func NewStudentAttendanceHandler(useCase usecase.StudentAttendanceUseCase) *Stud
 // @Failure 500 {object} map[string]string
 // @Router /attendance/students [post]
 func (h *StudentAttendanceHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID         string                  `json:"tenant_id" binding:"required"`
 		StudentID        string                  `json:"student_id" binding:"required"`
 		ClassID          string                  `json:"class_id" binding:"required"`
 		SemesterID       string                  `json:"semester_id" binding:"required"`
@@ -68,7 +71,7 @@ func (h *StudentAttendanceHandler) Create(c *gin.Context) {
 	}
 
 	attendance := &entity.StudentAttendance{
-		TenantID:         req.TenantID,
+		TenantID:         tenantID,
 		StudentID:        studentID,
 		ClassID:          classID,
 		SemesterID:       semesterID,
@@ -94,17 +97,19 @@ func (h *StudentAttendanceHandler) Create(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param date query string true "Date (YYYY-MM-DD)"
-// @Param tenant_id query string true "Tenant ID"
 // @Success 200 {object} []entity.StudentAttendance
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /attendance/reports/daily [get]
 func (h *StudentAttendanceHandler) GetDailyReport(c *gin.Context) {
 	dateStr := c.Query("date")
-	tenantIDStr := c.Query("tenant_id")
+	tenantIDStr, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 
-	if dateStr == "" || tenantIDStr == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Date and Tenant ID are required")
+	if dateStr == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Date is required")
 		return
 	}
 
@@ -137,7 +142,6 @@ func (h *StudentAttendanceHandler) GetDailyReport(c *gin.Context) {
 // @Produce json
 // @Param month query int true "Month (1-12)"
 // @Param year query int true "Year (YYYY)"
-// @Param tenant_id query string true "Tenant ID"
 // @Success 200 {object} []entity.StudentAttendance
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -145,10 +149,13 @@ func (h *StudentAttendanceHandler) GetDailyReport(c *gin.Context) {
 func (h *StudentAttendanceHandler) GetMonthlyReport(c *gin.Context) {
 	monthStr := c.Query("month")
 	yearStr := c.Query("year")
-	tenantIDStr := c.Query("tenant_id")
+	tenantIDStr, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 
-	if monthStr == "" || yearStr == "" || tenantIDStr == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Month, Year and Tenant ID are required")
+	if monthStr == "" || yearStr == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Month and Year are required")
 		return
 	}
 
@@ -188,7 +195,6 @@ func (h *StudentAttendanceHandler) GetMonthlyReport(c *gin.Context) {
 // @Param class_id path string true "Class ID"
 // @Param start_date query string true "Start Date (YYYY-MM-DD)"
 // @Param end_date query string true "End Date (YYYY-MM-DD)"
-// @Param tenant_id query string true "Tenant ID"
 // @Success 200 {object} []entity.StudentAttendance
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -197,10 +203,13 @@ func (h *StudentAttendanceHandler) GetClassReport(c *gin.Context) {
 	classIDStr := c.Param("class_id")
 	startDateStr := c.Query("start_date")
 	endDateStr := c.Query("end_date")
-	tenantIDStr := c.Query("tenant_id")
+	tenantIDStr, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 
-	if classIDStr == "" || startDateStr == "" || endDateStr == "" || tenantIDStr == "" {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Class ID, Start Date, End Date and Tenant ID are required")
+	if classIDStr == "" || startDateStr == "" || endDateStr == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "Class ID, Start Date and End Date are required")
 		return
 	}
 
@@ -249,8 +258,11 @@ func (h *StudentAttendanceHandler) GetClassReport(c *gin.Context) {
 // @Failure 500 {object} map[string]string
 // @Router /attendance/students/bulk [post]
 func (h *StudentAttendanceHandler) BulkCreate(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var reqs []struct {
-		TenantID         string                  `json:"tenant_id" binding:"required"`
 		StudentID        string                  `json:"student_id" binding:"required"`
 		ClassID          string                  `json:"class_id" binding:"required"`
 		SemesterID       string                  `json:"semester_id" binding:"required"`
@@ -287,7 +299,7 @@ func (h *StudentAttendanceHandler) BulkCreate(c *gin.Context) {
 		}
 
 		attendance := &entity.StudentAttendance{
-			TenantID:         req.TenantID,
+			TenantID:         tenantID,
 			StudentID:        studentID,
 			ClassID:          classID,
 			SemesterID:       semesterID,
@@ -321,6 +333,10 @@ func (h *StudentAttendanceHandler) BulkCreate(c *gin.Context) {
 // @Failure 500 {object} map[string]string
 // @Router /attendance/students/{id}/summary [get]
 func (h *StudentAttendanceHandler) GetSummary(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	studentIDStr := c.Param("id")
 	studentID, err := uuid.Parse(studentIDStr)
 	if err != nil {
@@ -338,7 +354,7 @@ func (h *StudentAttendanceHandler) GetSummary(c *gin.Context) {
 		}
 	}
 
-	summary, err := h.useCase.GetSummary(c.Request.Context(), studentID, semesterID)
+	summary, err := h.useCase.GetSummary(c.Request.Context(), tenantID, studentID, semesterID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -360,6 +376,10 @@ func (h *StudentAttendanceHandler) GetSummary(c *gin.Context) {
 // @Failure 500 {object} map[string]string
 // @Router /attendance/students/{id} [get]
 func (h *StudentAttendanceHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -367,7 +387,7 @@ func (h *StudentAttendanceHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	attendance, err := h.useCase.GetByID(c.Request.Context(), id)
+	attendance, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -393,6 +413,10 @@ func (h *StudentAttendanceHandler) GetByID(c *gin.Context) {
 // @Failure 500 {object} map[string]string
 // @Router /attendance/students [get]
 func (h *StudentAttendanceHandler) GetByClassAndDate(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	classIDStr := c.Query("class_id")
 	dateStr := c.Query("date")
 
@@ -413,7 +437,7 @@ func (h *StudentAttendanceHandler) GetByClassAndDate(c *gin.Context) {
 		return
 	}
 
-	attendances, err := h.useCase.GetByClassAndDate(c.Request.Context(), classID, date)
+	attendances, err := h.useCase.GetByClassAndDate(c.Request.Context(), tenantID, classID, date)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -436,6 +460,10 @@ func (h *StudentAttendanceHandler) GetByClassAndDate(c *gin.Context) {
 // @Failure 500 {object} map[string]string
 // @Router /attendance/students/{id} [put]
 func (h *StudentAttendanceHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -453,7 +481,7 @@ func (h *StudentAttendanceHandler) Update(c *gin.Context) {
 		return
 	}
 
-	attendance, err := h.useCase.GetByID(c.Request.Context(), id)
+	attendance, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
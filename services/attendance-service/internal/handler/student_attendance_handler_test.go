@@ -14,6 +14,7 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/domain/mocks"
 	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/handler"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -35,7 +36,6 @@ func TestStudentAttendanceHandler_Create(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":       tenantID.String(),
 			"student_id":      studentID.String(),
 			"class_id":        classID.String(),
 			"semester_id":     semesterID.String(),
@@ -56,6 +56,7 @@ func TestStudentAttendanceHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/attendance", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -72,6 +73,7 @@ func TestStudentAttendanceHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/attendance", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -82,7 +84,6 @@ func TestStudentAttendanceHandler_Create(t *testing.T) {
 
 	t.Run("usecase error", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":       tenantID.String(),
 			"student_id":      studentID.String(),
 			"class_id":        classID.String(),
 			"semester_id":     semesterID.String(),
@@ -95,6 +96,7 @@ func TestStudentAttendanceHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/attendance", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -117,10 +119,11 @@ func TestStudentAttendanceHandler_GetByID(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		expected := &entity.StudentAttendance{ID: id}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(expected, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(expected, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/"+id.String(), nil)
 
@@ -130,10 +133,11 @@ func TestStudentAttendanceHandler_GetByID(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil) // Return nil, nil for not found (handler logic)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(nil, nil) // Return nil, nil for not found (handler logic)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/"+id.String(), nil)
 
@@ -143,10 +147,11 @@ func TestStudentAttendanceHandler_GetByID(t *testing.T) {
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(nil, errors.New("db error"))
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(nil, errors.New("db error"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/"+id.String(), nil)
 
@@ -175,7 +180,6 @@ func TestStudentAttendanceHandler_BulkCreate(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		reqBody := []map[string]interface{}{
 			{
-				"tenant_id":       tenantID.String(),
 				"student_id":      studentID1.String(),
 				"class_id":        classID.String(),
 				"semester_id":     semesterID.String(),
@@ -183,7 +187,6 @@ func TestStudentAttendanceHandler_BulkCreate(t *testing.T) {
 				"status":          "present",
 			},
 			{
-				"tenant_id":       tenantID.String(),
 				"student_id":      studentID2.String(),
 				"class_id":        classID.String(),
 				"semester_id":     semesterID.String(),
@@ -200,6 +203,7 @@ func TestStudentAttendanceHandler_BulkCreate(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/attendance/bulk", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -218,6 +222,7 @@ func TestStudentAttendanceHandler_BulkCreate(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/attendance/bulk", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -229,7 +234,6 @@ func TestStudentAttendanceHandler_BulkCreate(t *testing.T) {
 	t.Run("usecase error", func(t *testing.T) {
 		reqBody := []map[string]interface{}{
 			{
-				"tenant_id":       tenantID.String(),
 				"student_id":      studentID1.String(),
 				"class_id":        classID.String(),
 				"semester_id":     semesterID.String(),
@@ -243,6 +247,7 @@ func TestStudentAttendanceHandler_BulkCreate(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/attendance/bulk", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -271,7 +276,8 @@ func TestStudentAttendanceHandler_GetDailyReport(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/reports/daily?tenant_id="+tenantID.String()+"&date="+date, nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/reports/daily?date="+date, nil)
 
 		h.GetDailyReport(c)
 
@@ -281,12 +287,23 @@ func TestStudentAttendanceHandler_GetDailyReport(t *testing.T) {
 	t.Run("bad request - missing params", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/reports/daily", nil)
 
 		h.GetDailyReport(c)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
+
+	t.Run("unauthenticated - tenant in query is ignored", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/reports/daily?tenant_id="+tenantID.String()+"&date="+date, nil)
+
+		h.GetDailyReport(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
 }
 
 func TestStudentAttendanceHandler_GetMonthlyReport(t *testing.T) {
@@ -308,7 +325,8 @@ func TestStudentAttendanceHandler_GetMonthlyReport(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/reports/monthly?tenant_id="+tenantID.String()+"&month=1&year=2024", nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/reports/monthly?month=1&year=2024", nil)
 
 		h.GetMonthlyReport(c)
 
@@ -338,8 +356,9 @@ func TestStudentAttendanceHandler_GetClassReport(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Params = gin.Params{{Key: "class_id", Value: classID.String()}}
-		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/reports/class/"+classID.String()+"?tenant_id="+tenantID.String()+"&start_date="+startDate+"&end_date="+endDate, nil)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/reports/class/"+classID.String()+"?start_date="+startDate+"&end_date="+endDate, nil)
 
 		h.GetClassReport(c)
 
@@ -360,10 +379,11 @@ func TestStudentAttendanceHandler_GetSummary(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		expected := map[string]int{"present": 10}
-		mockUseCase.EXPECT().GetSummary(gomock.Any(), studentID, uuid.Nil).Return(expected, nil)
+		mockUseCase.EXPECT().GetSummary(gomock.Any(), "tenant-1", studentID, uuid.Nil).Return(expected, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Params = gin.Params{{Key: "id", Value: studentID.String()}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/students/"+studentID.String()+"/summary", nil)
 
@@ -388,10 +408,11 @@ func TestStudentAttendanceHandler_GetByClassAndDate(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		expected := []*entity.StudentAttendance{{ClassID: classID}}
-		mockUseCase.EXPECT().GetByClassAndDate(gomock.Any(), classID, parsedDate).Return(expected, nil)
+		mockUseCase.EXPECT().GetByClassAndDate(gomock.Any(), "tenant-1", classID, parsedDate).Return(expected, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/students?class_id="+classID.String()+"&date="+date, nil)
 
 		h.GetByClassAndDate(c)
@@ -402,6 +423,7 @@ func TestStudentAttendanceHandler_GetByClassAndDate(t *testing.T) {
 	t.Run("bad request - missing params", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/students", nil)
 
 		h.GetByClassAndDate(c)
@@ -412,6 +434,7 @@ func TestStudentAttendanceHandler_GetByClassAndDate(t *testing.T) {
 	t.Run("bad request - invalid class id", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/students?class_id=invalid&date="+date, nil)
 
 		h.GetByClassAndDate(c)
@@ -422,6 +445,7 @@ func TestStudentAttendanceHandler_GetByClassAndDate(t *testing.T) {
 	t.Run("bad request - invalid date format", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/students?class_id="+classID.String()+"&date=invalid", nil)
 
 		h.GetByClassAndDate(c)
@@ -430,10 +454,11 @@ func TestStudentAttendanceHandler_GetByClassAndDate(t *testing.T) {
 	})
 
 	t.Run("usecase error", func(t *testing.T) {
-		mockUseCase.EXPECT().GetByClassAndDate(gomock.Any(), classID, parsedDate).Return(nil, errors.New("db error"))
+		mockUseCase.EXPECT().GetByClassAndDate(gomock.Any(), "tenant-1", classID, parsedDate).Return(nil, errors.New("db error"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/attendance/students?class_id="+classID.String()+"&date="+date, nil)
 
 		h.GetByClassAndDate(c)
@@ -461,7 +486,7 @@ func TestStudentAttendanceHandler_Update(t *testing.T) {
 		body, _ := json.Marshal(reqBody)
 
 		existing := &entity.StudentAttendance{ID: id, Status: "present"}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(existing, nil)
 		mockUseCase.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, att *entity.StudentAttendance) error {
 			assert.Equal(t, entity.AttendanceStatus("absent"), att.Status)
 			assert.Equal(t, "sick", att.Notes)
@@ -470,6 +495,7 @@ func TestStudentAttendanceHandler_Update(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPut, "/attendance/students/"+id.String(), bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -484,10 +510,11 @@ func TestStudentAttendanceHandler_Update(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil)
+		mockUseCase.EXPECT().GetByID(gomock.Any(), "tenant-1", id).Return(nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPut, "/attendance/students/"+id.String(), bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -496,3 +523,8 @@ func TestStudentAttendanceHandler_Update(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
 }
+
+// withTenant authenticates c the way the auth middleware would.
+func withTenant(c *gin.Context, tenantID string) {
+	c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID})
+}
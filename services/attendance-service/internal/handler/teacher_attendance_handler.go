@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -31,8 +32,11 @@ func NewTeacherAttendanceHandler(useCase usecase.TeacherAttendanceUseCase) *Teac
 // @Failure 500 {object} map[string]string
 // @Router /attendance/teachers/checkin [post]
 func (h *TeacherAttendanceHandler) CheckIn(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID       string                       `json:"tenant_id" binding:"required"`
 		TeacherID      string                       `json:"teacher_id" binding:"required"`
 		SemesterID     string                       `json:"semester_id" binding:"required"`
 		AttendanceDate time.Time                    `json:"attendance_date" binding:"required"`
@@ -61,7 +65,7 @@ func (h *TeacherAttendanceHandler) CheckIn(c *gin.Context) {
 	}
 
 	attendance := &entity.TeacherAttendance{
-		TenantID:          req.TenantID,
+		TenantID:          tenantID,
 		TeacherID:         teacherID,
 		SemesterID:        semesterID,
 		AttendanceDate:    req.AttendanceDate,
@@ -92,6 +96,10 @@ func (h *TeacherAttendanceHandler) CheckIn(c *gin.Context) {
 // @Failure 500 {object} map[string]string
 // @Router /attendance/teachers/checkout [put]
 func (h *TeacherAttendanceHandler) CheckOut(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
 		TeacherID    string    `json:"teacher_id" binding:"required"`
 		Date         time.Time `json:"date" binding:"required"`
@@ -109,7 +117,12 @@ func (h *TeacherAttendanceHandler) CheckOut(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.CheckOut(c.Request.Context(), teacherID, req.Date, req.CheckOutTime); err != nil {
+	err = h.useCase.CheckOut(c.Request.Context(), tenantID, teacherID, req.Date, req.CheckOutTime)
+	if errors.Is(err, usecase.ErrAttendanceNotFound) {
+		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "Attendance record not found")
+		return
+	}
+	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -131,6 +144,10 @@ func (h *TeacherAttendanceHandler) CheckOut(c *gin.Context) {
 // @Failure 500 {object} map[string]string
 // @Router /attendance/teachers [get]
 func (h *TeacherAttendanceHandler) GetByTeacherAndDate(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	teacherIDStr := c.Query("teacher_id")
 	dateStr := c.Query("date")
 
@@ -151,7 +168,7 @@ func (h *TeacherAttendanceHandler) GetByTeacherAndDate(c *gin.Context) {
 		return
 	}
 
-	attendance, err := h.useCase.GetByTeacherAndDate(c.Request.Context(), teacherID, date)
+	attendance, err := h.useCase.GetByTeacherAndDate(c.Request.Context(), tenantID, teacherID, date)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
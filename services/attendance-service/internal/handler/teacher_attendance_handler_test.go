@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/domain/mocks"
+	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/domain/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/attendance-service/internal/handler"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -34,7 +35,6 @@ func TestTeacherAttendanceHandler_CheckIn(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":       tenantID,
 			"teacher_id":      teacherID.String(),
 			"semester_id":     semesterID.String(),
 			"attendance_date": now,
@@ -54,6 +54,7 @@ func TestTeacherAttendanceHandler_CheckIn(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/teacher/check-in", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -70,6 +71,7 @@ func TestTeacherAttendanceHandler_CheckIn(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/teacher/check-in", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -80,7 +82,6 @@ func TestTeacherAttendanceHandler_CheckIn(t *testing.T) {
 
 	t.Run("usecase error", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id":       tenantID,
 			"teacher_id":      teacherID.String(),
 			"semester_id":     semesterID.String(),
 			"attendance_date": now,
@@ -93,6 +94,7 @@ func TestTeacherAttendanceHandler_CheckIn(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/teacher/check-in", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -122,10 +124,11 @@ func TestTeacherAttendanceHandler_CheckOut(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockUseCase.EXPECT().CheckOut(gomock.Any(), teacherID, gomock.Any(), gomock.Any()).Return(nil)
+		mockUseCase.EXPECT().CheckOut(gomock.Any(), "tenant-1", teacherID, gomock.Any(), gomock.Any()).Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/teacher/check-out", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -142,6 +145,7 @@ func TestTeacherAttendanceHandler_CheckOut(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/teacher/check-out", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -158,10 +162,11 @@ func TestTeacherAttendanceHandler_CheckOut(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockUseCase.EXPECT().CheckOut(gomock.Any(), teacherID, gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+		mockUseCase.EXPECT().CheckOut(gomock.Any(), "tenant-1", teacherID, gomock.Any(), gomock.Any()).Return(errors.New("db error"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodPost, "/teacher/check-out", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -169,6 +174,27 @@ func TestTeacherAttendanceHandler_CheckOut(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		reqBody := map[string]interface{}{
+			"teacher_id":     teacherID.String(),
+			"date":           now,
+			"check_out_time": now,
+		}
+		body, _ := json.Marshal(reqBody)
+
+		mockUseCase.EXPECT().CheckOut(gomock.Any(), "tenant-2", teacherID, gomock.Any(), gomock.Any()).Return(usecase.ErrAttendanceNotFound)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-2")
+		c.Request, _ = http.NewRequest(http.MethodPost, "/teacher/check-out", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		h.CheckOut(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
 }
 
 func TestTeacherAttendanceHandler_GetByTeacherAndDate(t *testing.T) {
@@ -186,10 +212,11 @@ func TestTeacherAttendanceHandler_GetByTeacherAndDate(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		expected := &entity.TeacherAttendance{TeacherID: teacherID}
-		mockUseCase.EXPECT().GetByTeacherAndDate(gomock.Any(), teacherID, parsedDate).Return(expected, nil)
+		mockUseCase.EXPECT().GetByTeacherAndDate(gomock.Any(), "tenant-1", teacherID, parsedDate).Return(expected, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/teacher/attendance?teacher_id="+teacherID.String()+"&date="+date, nil)
 
 		h.GetByTeacherAndDate(c)
@@ -200,6 +227,7 @@ func TestTeacherAttendanceHandler_GetByTeacherAndDate(t *testing.T) {
 	t.Run("bad request - missing params", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/teacher/attendance", nil)
 
 		h.GetByTeacherAndDate(c)
@@ -208,10 +236,11 @@ func TestTeacherAttendanceHandler_GetByTeacherAndDate(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mockUseCase.EXPECT().GetByTeacherAndDate(gomock.Any(), teacherID, parsedDate).Return(nil, nil)
+		mockUseCase.EXPECT().GetByTeacherAndDate(gomock.Any(), "tenant-1", teacherID, parsedDate).Return(nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, "tenant-1")
 		c.Request, _ = http.NewRequest(http.MethodGet, "/teacher/attendance?teacher_id="+teacherID.String()+"&date="+date, nil)
 
 		h.GetByTeacherAndDate(c)
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/identity"
+)
+
+// requestTenant returns the caller's verified tenant, answering 401 when the
+// request carries none. Handlers must never take the tenant from the client.
+func requestTenant(c *gin.Context) (string, bool) {
+	tenantID := identity.TenantID(c)
+	if tenantID == "" {
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", nil)
+		return "", false
+	}
+	return tenantID, true
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	smiddleware "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+)
+
+// Auth validates the bearer token and its version, refuses writes made with a
+// read-only impersonation token, then exposes the claims both on the gin
+// context ("claims") and on the request context (middleware.ClaimsKey).
+func Auth(keys jwtutil.KeySet, issuer, audience string, kv redisutil.KV) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		passed := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			passed = true
+			c.Request = r
+			if val := r.Context().Value(smiddleware.ClaimsKey); val != nil {
+				c.Set("claims", val)
+			}
+			c.Next()
+		})
+		smiddleware.AuthWithKeySet(keys, issuer, audience, smiddleware.RequireTokenVersion(kv, smiddleware.RestrictImpersonation(next))).ServeHTTP(c.Writer, c.Request)
+		if !passed {
+			c.Abort()
+		}
+	}
+}
+
+// Authorization requires the authenticated subject to hold permission.
+func Authorization(authz smiddleware.Authorizer, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		passed := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			passed = true
+			c.Next()
+		})
+		smiddleware.Authorization(authz, permission, next).ServeHTTP(c.Writer, c.Request)
+		if !passed {
+			c.Abort()
+		}
+	}
+}
+
+// GuardianScope restricts the route to guardians of the student named by the
+// path parameter param.
+func GuardianScope(checker smiddleware.GuardianChecker, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		passed := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			passed = true
+			c.Next()
+		})
+		studentID := func(*http.Request) string { return c.Param(param) }
+		smiddleware.GuardianScope(checker, studentID, next).ServeHTTP(c.Writer, c.Request)
+		if !passed {
+			c.Abort()
+		}
+	}
+}
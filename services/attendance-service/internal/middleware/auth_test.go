@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAuthorizer struct {
+	allowed map[string]bool
+	err     error
+}
+
+func (m *mockAuthorizer) Allow(subjectID uuid.UUID, tenantID string, permission string) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	return m.allowed[permission], nil
+}
+
+func TestAuthAndAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	secret := "test-secret"
+	token, err := jwtutil.GenerateAccessWith(secret, time.Minute, jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"}, "", "")
+	assert.NoError(t, err)
+
+	newRouter := func(authz *mockAuthorizer) (*gin.Engine, *bool) {
+		called := false
+		r := gin.New()
+		r.Use(Auth(jwtutil.NewSecretKeySet(secret), "", "", nil))
+		r.GET("/", Authorization(authz, "resource:read"), func(c *gin.Context) {
+			called = true
+			_, ok := c.Get("claims")
+			assert.True(t, ok)
+			c.Status(http.StatusOK)
+		})
+		return r, &called
+	}
+
+	t.Run("missing token", func(t *testing.T) {
+		r, called := newRouter(&mockAuthorizer{})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.False(t, *called)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		r, called := newRouter(&mockAuthorizer{allowed: map[string]bool{"resource:read": true}})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, *called)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		r, called := newRouter(&mockAuthorizer{})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.False(t, *called)
+	})
+
+	t.Run("authorizer error", func(t *testing.T) {
+		r, called := newRouter(&mockAuthorizer{err: errors.New("auth-service down")})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.False(t, *called)
+	})
+}
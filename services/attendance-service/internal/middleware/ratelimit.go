@@ -2,16 +2,41 @@ package middleware
 
 import (
 	"net/http"
+	"net/netip"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	smiddleware "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
 	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 )
 
-func RateLimitByPolicy(rl redisutil.Limiter, defaultRead int, defaultWrite int, perPrefixOverride map[string]int) gin.HandlerFunc {
+// RateLimitByPolicy limits requests per caller and gin route template. Put it
+// after the authentication middleware so signed-in callers are counted per
+// user; anonymous ones are counted per client address, with X-Forwarded-For
+// believed from trustedProxies only. Calls between services under /internal/
+// are not limited: each calling instance would share one bucket for all the
+// users it serves.
+func RateLimitByPolicy(rl redisutil.RateLimiter, defaultRead int, defaultWrite int, perPrefixOverride map[string]int, trustedProxies []netip.Prefix) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { c.Next() })
-		h := smiddleware.RateLimitByPolicy(rl, defaultRead, defaultWrite, perPrefixOverride, next)
+		if strings.HasPrefix(c.Request.URL.Path, "/internal/") {
+			c.Next()
+			return
+		}
+		admitted := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			admitted = true
+			c.Next()
+		})
+		h := smiddleware.RateLimitWith(rl, smiddleware.RateLimitOptions{
+			Read:           defaultRead,
+			Write:          defaultWrite,
+			Overrides:      perPrefixOverride,
+			Route:          c.FullPath(),
+			TrustedProxies: trustedProxies,
+		}, next)
 		h.ServeHTTP(c.Writer, c.Request)
+		if !admitted {
+			c.Abort()
+		}
 	}
 }
@@ -172,16 +172,16 @@ func (r *studentAttendanceRepository) BulkCreate(ctx context.Context, attendance
 }
 
 // GetSummary retrieves attendance summary for a student
-func (r *studentAttendanceRepository) GetSummary(ctx context.Context, studentID uuid.UUID, semesterID uuid.UUID) (map[string]int, error) {
+func (r *studentAttendanceRepository) GetSummary(ctx context.Context, tenantID string, studentID uuid.UUID, semesterID uuid.UUID) (map[string]int, error) {
 	query := `
 		SELECT status, COUNT(*) 
 		FROM student_attendance 
-		WHERE student_id = $1
+		WHERE tenant_id = $1 AND student_id = $2
 	`
-	args := []interface{}{studentID}
+	args := []interface{}{tenantID, studentID}
 	
 	if semesterID != uuid.Nil {
-		query += " AND semester_id = $2"
+		query += " AND semester_id = $3"
 		args = append(args, semesterID)
 	}
 	
@@ -62,7 +62,7 @@ func TestStudentAttendanceRepository_Create(t *testing.T) {
 	assert.Equal(t, "Updated notes", fetchedAfterUpdate.Notes)
 
 	// Test GetSummary
-	summary, err := repo.GetSummary(ctx, studentID, semesterID)
+	summary, err := repo.GetSummary(ctx, tenantID, studentID, semesterID)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, summary[string(entity.AttendanceStatusAbsent)])
 
@@ -119,7 +119,7 @@ func TestStudentAttendanceRepository_Create(t *testing.T) {
 	assert.GreaterOrEqual(t, len(dateRangeAllResults), 3)
 
 	// Test GetSummary without semesterID
-	summaryNoSemester, err := repo.GetSummary(ctx, studentID, uuid.Nil)
+	summaryNoSemester, err := repo.GetSummary(ctx, tenantID, studentID, uuid.Nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, summaryNoSemester)
 }
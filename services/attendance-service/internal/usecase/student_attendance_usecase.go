@@ -49,16 +49,32 @@ func (u *studentAttendanceUseCase) Create(ctx context.Context, attendance *entit
 	return u.repo.Create(ctx, attendance)
 }
 
-func (u *studentAttendanceUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.StudentAttendance, error) {
+func (u *studentAttendanceUseCase) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*entity.StudentAttendance, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
-	return u.repo.GetByID(ctx, id)
+
+	attendance, err := u.repo.GetByID(ctx, id)
+	if err != nil || attendance == nil || attendance.TenantID != tenantID {
+		return nil, err
+	}
+	return attendance, nil
 }
 
-func (u *studentAttendanceUseCase) GetByClassAndDate(ctx context.Context, classID uuid.UUID, date time.Time) ([]*entity.StudentAttendance, error) {
+func (u *studentAttendanceUseCase) GetByClassAndDate(ctx context.Context, tenantID string, classID uuid.UUID, date time.Time) ([]*entity.StudentAttendance, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
-	return u.repo.GetByClassAndDate(ctx, classID, date)
+
+	attendances, err := u.repo.GetByClassAndDate(ctx, classID, date)
+	if err != nil {
+		return nil, err
+	}
+	owned := attendances[:0]
+	for _, a := range attendances {
+		if a.TenantID == tenantID {
+			owned = append(owned, a)
+		}
+	}
+	return owned, nil
 }
 
 func (u *studentAttendanceUseCase) Update(ctx context.Context, attendance *entity.StudentAttendance) error {
@@ -99,11 +115,11 @@ func (u *studentAttendanceUseCase) BulkCreate(ctx context.Context, attendances [
 	return u.repo.BulkCreate(ctx, attendances)
 }
 
-func (u *studentAttendanceUseCase) GetSummary(ctx context.Context, studentID uuid.UUID, semesterID uuid.UUID) (map[string]int, error) {
+func (u *studentAttendanceUseCase) GetSummary(ctx context.Context, tenantID string, studentID uuid.UUID, semesterID uuid.UUID) (map[string]int, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetSummary(ctx, studentID, semesterID)
+	return u.repo.GetSummary(ctx, tenantID, studentID, semesterID)
 }
 
 func (u *studentAttendanceUseCase) GetDailyReport(ctx context.Context, tenantID uuid.UUID, date time.Time) ([]*entity.StudentAttendance, error) {
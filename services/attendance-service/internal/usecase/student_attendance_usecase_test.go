@@ -138,18 +138,26 @@ func TestStudentAttendanceUseCase_GetByID(t *testing.T) {
 	id := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		expectedAttendance := &entity.StudentAttendance{ID: id}
+		expectedAttendance := &entity.StudentAttendance{ID: id, TenantID: "tenant-1"}
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(expectedAttendance, nil)
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), "tenant-1", id)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedAttendance, res)
 	})
 
+	t.Run("other tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.StudentAttendance{ID: id, TenantID: "tenant-1"}, nil)
+
+		res, err := u.GetByID(context.Background(), "tenant-2", id)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
 	t.Run("not found", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, errors.New("not found"))
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), "tenant-1", id)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -169,20 +177,33 @@ func TestStudentAttendanceUseCase_GetByClassAndDate(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		expectedList := []*entity.StudentAttendance{
-			{ID: uuid.New()},
-			{ID: uuid.New()},
+			{ID: uuid.New(), TenantID: "tenant-1"},
+			{ID: uuid.New(), TenantID: "tenant-1"},
 		}
 		mockRepo.EXPECT().GetByClassAndDate(gomock.Any(), classID, date).Return(expectedList, nil)
 
-		res, err := u.GetByClassAndDate(context.Background(), classID, date)
+		res, err := u.GetByClassAndDate(context.Background(), "tenant-1", classID, date)
 		assert.NoError(t, err)
 		assert.Equal(t, len(expectedList), len(res))
 	})
 
+	t.Run("skips other tenants", func(t *testing.T) {
+		list := []*entity.StudentAttendance{
+			{ID: uuid.New(), TenantID: "tenant-1"},
+			{ID: uuid.New(), TenantID: "tenant-2"},
+		}
+		mockRepo.EXPECT().GetByClassAndDate(gomock.Any(), classID, date).Return(list, nil)
+
+		res, err := u.GetByClassAndDate(context.Background(), "tenant-2", classID, date)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, "tenant-2", res[0].TenantID)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		mockRepo.EXPECT().GetByClassAndDate(gomock.Any(), classID, date).Return(nil, errors.New("db error"))
 
-		res, err := u.GetByClassAndDate(context.Background(), classID, date)
+		res, err := u.GetByClassAndDate(context.Background(), "tenant-1", classID, date)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -304,17 +325,17 @@ func TestStudentAttendanceUseCase_GetSummary(t *testing.T) {
 			"absent":  2,
 			"sick":    1,
 		}
-		mockRepo.EXPECT().GetSummary(gomock.Any(), studentID, semesterID).Return(expectedSummary, nil)
+		mockRepo.EXPECT().GetSummary(gomock.Any(), "tenant-1", studentID, semesterID).Return(expectedSummary, nil)
 
-		res, err := u.GetSummary(context.Background(), studentID, semesterID)
+		res, err := u.GetSummary(context.Background(), "tenant-1", studentID, semesterID)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedSummary, res)
 	})
 
 	t.Run("error", func(t *testing.T) {
-		mockRepo.EXPECT().GetSummary(gomock.Any(), studentID, semesterID).Return(nil, errors.New("db error"))
+		mockRepo.EXPECT().GetSummary(gomock.Any(), "tenant-1", studentID, semesterID).Return(nil, errors.New("db error"))
 
-		res, err := u.GetSummary(context.Background(), studentID, semesterID)
+		res, err := u.GetSummary(context.Background(), "tenant-1", studentID, semesterID)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
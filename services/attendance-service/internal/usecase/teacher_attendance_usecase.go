@@ -44,28 +44,33 @@ func (u *teacherAttendanceUseCase) CheckIn(ctx context.Context, attendance *enti
 	return u.repo.Create(ctx, attendance)
 }
 
-func (u *teacherAttendanceUseCase) CheckOut(ctx context.Context, teacherID uuid.UUID, date time.Time, checkOutTime time.Time) error {
-	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
-	defer cancel()
-
-	attendance, err := u.repo.GetByTeacherAndDate(ctx, teacherID, date)
+func (u *teacherAttendanceUseCase) CheckOut(ctx context.Context, tenantID string, teacherID uuid.UUID, date time.Time, checkOutTime time.Time) error {
+	attendance, err := u.GetByTeacherAndDate(ctx, tenantID, teacherID, date)
 	if err != nil {
 		return err
 	}
 	if attendance == nil {
-		return errors.New("attendance record not found")
+		return domainUseCase.ErrAttendanceNotFound
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
 	attendance.CheckOutTime = &checkOutTime
 	attendance.UpdatedAt = time.Now()
 
 	return u.repo.Update(ctx, attendance)
 }
 
-func (u *teacherAttendanceUseCase) GetByTeacherAndDate(ctx context.Context, teacherID uuid.UUID, date time.Time) (*entity.TeacherAttendance, error) {
+func (u *teacherAttendanceUseCase) GetByTeacherAndDate(ctx context.Context, tenantID string, teacherID uuid.UUID, date time.Time) (*entity.TeacherAttendance, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
-	return u.repo.GetByTeacherAndDate(ctx, teacherID, date)
+
+	attendance, err := u.repo.GetByTeacherAndDate(ctx, teacherID, date)
+	if err != nil || attendance == nil || attendance.TenantID != tenantID {
+		return nil, err
+	}
+	return attendance, nil
 }
 
 func (u *teacherAttendanceUseCase) List(ctx context.Context, filter map[string]interface{}) ([]*entity.TeacherAttendance, error) {
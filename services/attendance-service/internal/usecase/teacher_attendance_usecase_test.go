@@ -85,20 +85,34 @@ func TestTeacherAttendanceUseCase_CheckOut(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		existing := &entity.TeacherAttendance{
 			ID:        uuid.New(),
+			TenantID:  "tenant-1",
 			TeacherID: teacherID,
 		}
 
 		mockRepo.EXPECT().GetByTeacherAndDate(gomock.Any(), teacherID, now).Return(existing, nil)
 		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 
-		err := u.CheckOut(context.Background(), teacherID, now, checkOutTime)
+		err := u.CheckOut(context.Background(), "tenant-1", teacherID, now, checkOutTime)
 		assert.NoError(t, err)
 	})
 
+	t.Run("other tenant", func(t *testing.T) {
+		existing := &entity.TeacherAttendance{
+			ID:        uuid.New(),
+			TenantID:  "tenant-1",
+			TeacherID: teacherID,
+		}
+
+		mockRepo.EXPECT().GetByTeacherAndDate(gomock.Any(), teacherID, now).Return(existing, nil)
+
+		err := u.CheckOut(context.Background(), "tenant-2", teacherID, now, checkOutTime)
+		assert.Equal(t, "attendance record not found", err.Error())
+	})
+
 	t.Run("not found", func(t *testing.T) {
 		mockRepo.EXPECT().GetByTeacherAndDate(gomock.Any(), teacherID, now).Return(nil, nil)
 
-		err := u.CheckOut(context.Background(), teacherID, now, checkOutTime)
+		err := u.CheckOut(context.Background(), "tenant-1", teacherID, now, checkOutTime)
 		assert.Error(t, err)
 		assert.Equal(t, "attendance record not found", err.Error())
 	})
@@ -116,10 +130,10 @@ func TestTeacherAttendanceUseCase_GetByTeacherAndDate(t *testing.T) {
 	now := time.Now()
 
 	t.Run("success", func(t *testing.T) {
-		expected := &entity.TeacherAttendance{ID: uuid.New(), TeacherID: teacherID}
+		expected := &entity.TeacherAttendance{ID: uuid.New(), TenantID: "tenant-1", TeacherID: teacherID}
 		mockRepo.EXPECT().GetByTeacherAndDate(gomock.Any(), teacherID, now).Return(expected, nil)
 
-		res, err := u.GetByTeacherAndDate(context.Background(), teacherID, now)
+		res, err := u.GetByTeacherAndDate(context.Background(), "tenant-1", teacherID, now)
 		assert.NoError(t, err)
 		assert.Equal(t, expected, res)
 	})
@@ -127,7 +141,7 @@ func TestTeacherAttendanceUseCase_GetByTeacherAndDate(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
 		mockRepo.EXPECT().GetByTeacherAndDate(gomock.Any(), teacherID, now).Return(nil, errors.New("db error"))
 
-		res, err := u.GetByTeacherAndDate(context.Background(), teacherID, now)
+		res, err := u.GetByTeacherAndDate(context.Background(), "tenant-1", teacherID, now)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -3,9 +3,11 @@ package main
 import (
 	"context"
 	"log"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/database"
 )
@@ -39,6 +41,7 @@ func main() {
 		{"student", true},
 		{"teacher", true},
 		{"parent", true},
+		{"guardian", true},
 		{"staff", true},
 	}
 
@@ -67,5 +70,38 @@ func main() {
 		}
 	}
 
+	// Seed the permission catalogue and grant all of it to admin
+	permsRepo := repository.NewPermissionsRepo(db)
+	admin, err := rolesRepo.FindRoleByName(ctx, defaultTenantID, "admin")
+	if err != nil {
+		log.Fatalf("Failed to load admin role: %v", err)
+	}
+	for _, perm := range authz.Catalogue() {
+		resource, action, _ := strings.Cut(perm, ":")
+		p, err := permsRepo.FindByResourceAction(ctx, resource, action)
+		if err == pgx.ErrNoRows {
+			p, err = permsRepo.Create(ctx, resource, action)
+		}
+		if err != nil {
+			log.Printf("Failed to seed permission %s: %v", perm, err)
+			continue
+		}
+		if err := permsRepo.AssignToRole(ctx, admin.ID, p.ID); err != nil {
+			log.Printf("Failed to grant %s to admin: %v", perm, err)
+		}
+	}
+
+	// Guardians may only read their own children's records
+	guardianRole, err := rolesRepo.FindRoleByName(ctx, defaultTenantID, "guardian")
+	if err != nil {
+		log.Fatalf("Failed to load guardian role: %v", err)
+	}
+	resource, action, _ := strings.Cut(authz.GuardianChildRead, ":")
+	if p, err := permsRepo.FindByResourceAction(ctx, resource, action); err != nil {
+		log.Printf("Failed to load permission %s: %v", authz.GuardianChildRead, err)
+	} else if err := permsRepo.AssignToRole(ctx, guardianRole.ID, p.ID); err != nil {
+		log.Printf("Failed to grant %s to guardian: %v", authz.GuardianChildRead, err)
+	}
+
 	log.Println("Seeding completed.")
 }
@@ -13,7 +13,10 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/database"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/logger"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/rabbit"
 	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/tracer"
@@ -57,43 +60,132 @@ func main() {
 	r.Use(gin.Logger(), gin.Recovery())
 	r.Use(middleware.CORS(cfg.CORSAllowedOrigins))
 	r.Use(middleware.SecurityHeaders())
-	limiter := redisutil.NewLimiterFromCounter(redis.Raw())
-	// Rate limit policy: READ=100/min, WRITE=30/min, AUTH prefix override=5/min
-	r.Use(middleware.RateLimitByPolicy(limiter, 100, 30, map[string]int{"/api/v1/auth/": 5}))
-	handler.NewHealthHandler(db, redis).Register(r)
+	limiter := redisutil.NewTokenBucketLimiter(redis.Raw())
+	// Rate limit policy: READ=100/min, WRITE=30/min, AUTH prefix override=5/min.
+	// Anonymous routes count per client address; protected routes run it after
+	// Auth so they count per user.
+	limit := middleware.RateLimitByPolicy(limiter, 100, 30, map[string]int{"/api/v1/auth/": 5}, cfg.TrustedProxies)
+	handler.NewHealthHandler(db, redis).Register(r.Group("/", limit))
+	// HS256 keeps the shared secret; RS256/EdDSA sign with the key ring and
+	// publish its public keys for the other services.
+	var keys *jwtutil.KeyRing
+	var verifyKeys jwtutil.KeySet = jwtutil.NewSecretKeySet(cfg.JWTAccessSecret)
+	if cfg.JWTSigningAlg != jwtutil.AlgHS256 {
+		keys, err = jwtutil.LoadKeyRing(cfg.JWTPrivateKeyPath, cfg.JWTPreviousKeys)
+		if err != nil {
+			log.Fatal("failed to load signing keys", zap.Error(err))
+		}
+		if keys.Alg() != cfg.JWTSigningAlg {
+			log.Fatal("signing key does not match JWT_SIGNING_ALG", zap.String("alg", keys.Alg()))
+		}
+		verifyKeys = keys
+	}
+	handler.NewJWKSHandler(keys).Register(r.Group("/", limit))
+	authorizer := usecase.NewRepoAuthorizer(db)
+	handler.NewAuthzHandler(authorizer).Register(r)
 	authRepo := repository.NewUsersRepo(db)
 	auditRepo := repository.NewAuditRepo(db)
 	prRepo := repository.NewPasswordResetRepo(db)
 	rb := rabbit.New(cfg.RabbitURL)
+	// Invitations are stored with the accounts they belong to and relayed to
+	// RabbitMQ
+	events := outbox.New(db)
+	if rb != nil {
+		go outbox.NewRelay(db, rb).Run(context.Background())
+	}
 	phRepo := repository.NewPasswordHistoryRepo(db)
 	// Audit middleware logs after response asynchronously
 	r.Use(middleware.Audit(auditRepo))
-	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, rb, phRepo)
-	authHandler.Register(r)
+	rolesRepo := repository.NewRolesRepo(db)
+	permsRepo := repository.NewPermissionsRepo(db)
+	tokens := usecase.NewTokens(authRepo, rolesRepo, permsRepo, redis.Raw(), cfg.EmbedPermissions)
+	sessions := usecase.NewSessions(repository.NewSessionsRepo(db), authRepo, tokens)
+	mfa := usecase.NewMFA(repository.NewMFARepo(db), authRepo, rolesRepo, cfg.JWTIssuer)
+	rolesUC := usecase.NewRoles(authRepo, rolesRepo, tokens)
+	oidcUC := usecase.NewOIDC(repository.NewOIDCRepo(db), authRepo, rolesUC, redis.Raw(), cfg.OIDCRedirectURL, cfg.OIDCStateTTL)
+	passwords := usecase.NewPasswordPolicies(repository.NewPasswordPolicyRepo(db), authRepo, phRepo, usecase.DefaultPasswordPolicy(cfg.LockoutThreshold, cfg.LockoutTTL))
+	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, rb, phRepo, tokens, keys, sessions, mfa, oidcUC, passwords)
+	public := r.Group("/", limit)
+	authHandler.Register(public)
+	invitations := usecase.NewInvitations(repository.NewInvitationRepo(db), authRepo, rolesRepo, passwords, events, cfg.InvitationTTL)
+	invitationHandler := handler.NewInvitationHandler(invitations, auditRepo)
+	invitationHandler.Register(public)
 	if cfg.Env == "development" {
-		handler.NewDevHandler(authRepo).Register(r)
+		handler.NewDevHandler(authRepo).Register(public)
 	}
 	// Protect routes
 	protected := r.Group("/")
-	protected.Use(middleware.Auth(cfg.JWTAccessSecret, cfg))
+	protected.Use(middleware.Auth(verifyKeys, cfg, redis.Raw()), limit)
 	authHandler.RegisterProtected(protected)
 	// Users handlers
-	usersUC := usecase.NewUsers(authRepo)
+	usersUC := usecase.NewUsers(authRepo, passwords)
 	usersHandler := handler.NewUsersHandler(usersUC)
 	usersHandler.RegisterProtected(protected)
 	// Roles handlers
-	rolesRepo := repository.NewRolesRepo(db)
-	rolesUC := usecase.NewRoles(authRepo, rolesRepo)
-	rolesHandler := handler.NewRolesHandler(rolesUC)
-	rolesHandler.RegisterProtected(protected)
+	rolesHandler := handler.NewRolesHandler(rolesUC, auditRepo)
+	rolesHandler.RegisterProtected(protected, func(permission string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, permission)
+	})
+	// Role & permission management
+	roleAdminHandler := handler.NewRoleAdminHandler(usecase.NewRoleAdmin(rolesRepo, permsRepo, tokens), auditRepo)
+	roleAdminHandler.RegisterProtected(protected, func(permission string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, permission)
+	})
+	// Session registry
+	sessionsHandler := handler.NewSessionsHandler(sessions, auditRepo)
+	sessionsHandler.RegisterProtected(protected, func(permission string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, permission)
+	})
+	// Two-factor enrolment and tenant policy
+	mfaHandler := handler.NewMFAHandler(mfa, authRepo, auditRepo)
+	mfaHandler.RegisterProtected(protected, func(permission string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, permission)
+	})
+	// Identity providers for single sign-on
+	oidcHandler := handler.NewOIDCHandler(oidcUC, auditRepo)
+	oidcHandler.RegisterProtected(protected, func(permission string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, permission)
+	})
+	// Password and lockout policy
+	passwordPolicyHandler := handler.NewPasswordPolicyHandler(passwords, auditRepo)
+	passwordPolicyHandler.RegisterProtected(protected, func(permission string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, permission)
+	})
+	// Bulk user provisioning
+	userImportHandler := handler.NewUserImportHandler(usecase.NewUserImport(authRepo, rolesRepo, passwords, events, cfg.InvitationTTL), auditRepo)
+	userImportHandler.RegisterProtected(protected, func(permission string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, permission)
+	})
+	// Invitation re-sends for accounts that were never activated
+	invitationHandler.RegisterProtected(protected, func(permission string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, permission)
+	})
+	// Support staff acting as another user
+	impersonationHandler := handler.NewImpersonationHandler(authHandler, authorizer)
+	impersonationHandler.RegisterProtected(protected, func(permission string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, permission)
+	})
+	// Machine clients authenticating with API keys
+	serviceAccountHandler := handler.NewServiceAccountHandler(usecase.NewServiceAccounts(repository.NewServiceAccountRepo(db), authRepo, rolesRepo, tokens), authHandler, auditRepo)
+	serviceAccountHandler.Register(r)
+	serviceAccountHandler.RegisterProtected(protected, func(permission string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, permission)
+	})
+	// Usage metered by the gateway, file-service and notification-service
+	usageHandler := handler.NewUsageHandler(metering.New(metering.NewRedisStore(redis.Raw())), auditRepo, cfg.OperatorTenantID)
+	usageHandler.RegisterProtected(protected, func(permission string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, permission)
+	})
 	// Event Consumer
 	if rb != nil {
-		consumer := event.NewConsumer(rb, usersUC, rolesUC)
-		consumer.Start()
+		consumer := event.NewConsumer(rb, invitations, rolesUC, authRepo, auditRepo, redis.Raw())
+		consumer.Start(context.Background())
 	}
 	// Audit handlers
 	auditHandler := handler.NewAuditHandler(auditRepo)
-	auditHandler.RegisterProtected(protected)
+	auditHandler.RegisterProtected(protected, func(permission string) gin.HandlerFunc {
+		return middleware.Authorization(authorizer, permission)
+	})
 	// Log retention job
 	go func() {
 		for {
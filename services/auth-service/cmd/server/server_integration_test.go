@@ -54,6 +54,10 @@ func ensureMigrations(t *testing.T, db *pgxpool.Pool) {
 	if err == nil {
 		_, _ = db.Exec(ctx, string(b2))
 	}
+	bChain, err := os.ReadFile("../../migrations/011_audit_chain.up.sql")
+	if err == nil {
+		_, _ = db.Exec(ctx, string(bChain))
+	}
 	b3, err := os.ReadFile("../../migrations/003_password_resets.up.sql")
 	if err == nil {
 		_, _ = db.Exec(ctx, string(b3))
@@ -62,6 +66,14 @@ func ensureMigrations(t *testing.T, db *pgxpool.Pool) {
 	if err == nil {
 		_, _ = db.Exec(ctx, string(b4))
 	}
+	b5, err := os.ReadFile("../../migrations/005_token_version.up.sql")
+	if err == nil {
+		_, _ = db.Exec(ctx, string(b5))
+	}
+	b9, err := os.ReadFile("../../migrations/009_password_policy.up.sql")
+	if err == nil {
+		_, _ = db.Exec(ctx, string(b9))
+	}
 }
 
 func TestServer_Integration_RateLimit_Login_429(t *testing.T) {
@@ -75,13 +87,13 @@ func TestServer_Integration_RateLimit_Login_429(t *testing.T) {
 	r.SetTrustedProxies(nil)
 	r.Use(gin.Logger(), gin.Recovery())
 	r.Use(middleware.CORS(cfg.CORSAllowedOrigins))
-	limiter := redisutil.NewLimiterFromCounter(redis.Raw())
-	r.Use(middleware.RateLimit(limiter, cfg.RateLimitPerMinute))
+	limiter := redisutil.NewTokenBucketLimiter(redis.Raw())
+	r.Use(middleware.RateLimit(limiter, cfg.RateLimitPerMinute, cfg.TrustedProxies))
 	authRepo := repository.NewUsersRepo(db)
 	auditRepo := repository.NewAuditRepo(db)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	authHandler.Register(r)
 	tenant := "t-" + uuid.NewString()
 	_, _ = authRepo.Create(context.Background(), repository.CreateUserParams{
@@ -135,26 +147,26 @@ func TestServer_Integration_AuthFlows(t *testing.T) {
 	r.SetTrustedProxies(nil)
 	r.Use(gin.Logger(), gin.Recovery())
 	r.Use(middleware.CORS(cfg.CORSAllowedOrigins))
-	limiter := redisutil.NewLimiterFromCounter(redis.Raw())
-	r.Use(middleware.RateLimit(limiter, cfg.RateLimitPerMinute))
+	limiter := redisutil.NewTokenBucketLimiter(redis.Raw())
+	r.Use(middleware.RateLimit(limiter, cfg.RateLimitPerMinute, cfg.TrustedProxies))
 
 	authRepo := repository.NewUsersRepo(db)
 	auditRepo := repository.NewAuditRepo(db)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	authHandler.Register(r)
 
 	protected := r.Group("/")
-	protected.Use(middleware.Auth(cfg.JWTAccessSecret, cfg))
+	protected.Use(middleware.Auth(jwtutil.NewSecretKeySet(cfg.JWTAccessSecret), cfg, redis.Raw()))
 	authHandler.RegisterProtected(protected)
-	usersUC := usecase.NewUsers(authRepo)
+	usersUC := usecase.NewUsers(authRepo, nil)
 	usersHandler := handler.NewUsersHandler(usersUC)
 	usersHandler.RegisterProtected(protected)
 	rolesRepo := repository.NewRolesRepo(db)
-	rolesUC := usecase.NewRoles(authRepo, rolesRepo)
-	rolesHandler := handler.NewRolesHandler(rolesUC)
-	rolesHandler.RegisterProtected(protected)
+	rolesUC := usecase.NewRoles(authRepo, rolesRepo, nil)
+	rolesHandler := handler.NewRolesHandler(rolesUC, auditRepo)
+	rolesHandler.RegisterProtected(protected, func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } })
 
 	tenant := "t-" + uuid.NewString()
 	initClaims := jwtutil.Claims{TenantID: tenant, UserID: uuid.New()}
@@ -250,16 +262,16 @@ func TestServer_Integration_Refresh_Blacklist_Old(t *testing.T) {
 	r.SetTrustedProxies(nil)
 	r.Use(gin.Logger(), gin.Recovery())
 	r.Use(middleware.CORS(cfg.CORSAllowedOrigins))
-	limiter := redisutil.NewLimiterFromCounter(redis.Raw())
-	r.Use(middleware.RateLimit(limiter, cfg.RateLimitPerMinute))
+	limiter := redisutil.NewTokenBucketLimiter(redis.Raw())
+	r.Use(middleware.RateLimit(limiter, cfg.RateLimitPerMinute, cfg.TrustedProxies))
 	authRepo := repository.NewUsersRepo(db)
 	auditRepo := repository.NewAuditRepo(db)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	authHandler.Register(r)
 	protected := r.Group("/")
-	protected.Use(middleware.Auth(cfg.JWTAccessSecret, cfg))
+	protected.Use(middleware.Auth(jwtutil.NewSecretKeySet(cfg.JWTAccessSecret), cfg, redis.Raw()))
 	authHandler.RegisterProtected(protected)
 	tenant := "t-" + uuid.NewString()
 	claims := jwtutil.Claims{TenantID: tenant, UserID: uuid.New()}
@@ -306,13 +318,13 @@ func TestServer_Integration_Logout_Blacklists_Refresh(t *testing.T) {
 	r.SetTrustedProxies(nil)
 	r.Use(gin.Logger(), gin.Recovery())
 	r.Use(middleware.CORS(cfg.CORSAllowedOrigins))
-	limiter := redisutil.NewLimiterFromCounter(redis.Raw())
-	r.Use(middleware.RateLimit(limiter, cfg.RateLimitPerMinute))
+	limiter := redisutil.NewTokenBucketLimiter(redis.Raw())
+	r.Use(middleware.RateLimit(limiter, cfg.RateLimitPerMinute, cfg.TrustedProxies))
 	authRepo := repository.NewUsersRepo(db)
 	auditRepo := repository.NewAuditRepo(db)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	authHandler.Register(r)
 	tenant := "t-" + uuid.NewString()
 	_, _ = authRepo.Create(context.Background(), repository.CreateUserParams{
@@ -356,18 +368,18 @@ func TestServer_Integration_AuditLogs(t *testing.T) {
 	r.SetTrustedProxies(nil)
 	r.Use(gin.Logger(), gin.Recovery())
 	r.Use(middleware.CORS(cfg.CORSAllowedOrigins))
-	limiter := redisutil.NewLimiterFromCounter(redis.Raw())
-	r.Use(middleware.RateLimit(limiter, cfg.RateLimitPerMinute))
+	limiter := redisutil.NewTokenBucketLimiter(redis.Raw())
+	r.Use(middleware.RateLimit(limiter, cfg.RateLimitPerMinute, cfg.TrustedProxies))
 	authRepo := repository.NewUsersRepo(db)
 	auditRepo := repository.NewAuditRepo(db)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	authHandler.Register(r)
 	protected := r.Group("/")
-	protected.Use(middleware.Auth(cfg.JWTAccessSecret, cfg))
+	protected.Use(middleware.Auth(jwtutil.NewSecretKeySet(cfg.JWTAccessSecret), cfg, redis.Raw()))
 	authHandler.RegisterProtected(protected)
-	usersUC := usecase.NewUsers(authRepo)
+	usersUC := usecase.NewUsers(authRepo, nil)
 	usersHandler := handler.NewUsersHandler(usersUC)
 	usersHandler.RegisterProtected(protected)
 	tenant := "t-" + uuid.NewString()
@@ -475,16 +487,16 @@ func TestServer_Integration_Me_ReturnsUser(t *testing.T) {
 	r.SetTrustedProxies(nil)
 	r.Use(gin.Logger(), gin.Recovery())
 	r.Use(middleware.CORS(cfg.CORSAllowedOrigins))
-	limiter := redisutil.NewLimiterFromCounter(redis.Raw())
-	r.Use(middleware.RateLimit(limiter, cfg.RateLimitPerMinute))
+	limiter := redisutil.NewTokenBucketLimiter(redis.Raw())
+	r.Use(middleware.RateLimit(limiter, cfg.RateLimitPerMinute, cfg.TrustedProxies))
 	authRepo := repository.NewUsersRepo(db)
 	auditRepo := repository.NewAuditRepo(db)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	authHandler := handler.NewAuthHandler(authRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	authHandler.Register(r)
 	protected := r.Group("/")
-	protected.Use(middleware.Auth(cfg.JWTAccessSecret, cfg))
+	protected.Use(middleware.Auth(jwtutil.NewSecretKeySet(cfg.JWTAccessSecret), cfg, redis.Raw()))
 	authHandler.RegisterProtected(protected)
 	tenant := "t-" + uuid.NewString()
 	created, err := authRepo.Create(context.Background(), repository.CreateUserParams{
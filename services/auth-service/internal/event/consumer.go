@@ -3,43 +3,105 @@ package event
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
 	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/audit"
+	smiddleware "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/rabbit"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 )
 
+// processedTTL is how long a handled message ID is remembered. Retries and
+// outbox republishes arrive well within it.
+const processedTTL = 7 * 24 * time.Hour
+
 type Consumer struct {
 	rabbitClient *rabbit.Client
-	usersUC      usecase.Users
+	invitations  usecase.Invitations
 	rolesUC      usecase.Roles
+	usersRepo    *repository.UsersRepo
+	auditRepo    *repository.AuditRepo
+	kv           redisutil.KV
 }
 
-func NewConsumer(rabbitClient *rabbit.Client, usersUC usecase.Users, rolesUC usecase.Roles) *Consumer {
+// NewConsumer wires the event consumer. Handled message IDs are kept in kv so
+// a message published or delivered twice is acted on once.
+func NewConsumer(rabbitClient *rabbit.Client, invitations usecase.Invitations, rolesUC usecase.Roles, usersRepo *repository.UsersRepo, auditRepo *repository.AuditRepo, kv redisutil.KV) *Consumer {
 	return &Consumer{
 		rabbitClient: rabbitClient,
-		usersUC:      usersUC,
+		invitations:  invitations,
 		rolesUC:      rolesUC,
+		usersRepo:    usersRepo,
+		auditRepo:    auditRepo,
+		kv:           kv,
 	}
 }
 
-func (c *Consumer) Start() {
-	// Ensure the queue exists and bind it
-	// We use "auth-service-student-registration" queue
-	msgs, err := c.rabbitClient.Consume("sisfo.events", "auth-service-student-registration", []string{"admission.student.registered"})
-	if err != nil {
-		log.Printf("Failed to subscribe to student registration: %v", err)
-		return
-	}
+// Start subscribes to the events auth-service reacts to. Each subscription
+// runs until ctx is done, resubscribing whenever the broker connection drops.
+func (c *Consumer) Start(ctx context.Context) {
+	// Admitted students get an account with the student role
+	go c.rabbitClient.Subscribe(ctx, rabbit.Subscription{
+		Exchange: "sisfo.events",
+		Queue:    "auth-service-student-registration",
+		Bindings: []string{"admission.student.registered"},
+	}, c.once("auth-service-student-registration", c.handleStudentRegistration))
+
+	// Guardians created in academic-service get an account with the guardian role
+	go c.rabbitClient.Subscribe(ctx, rabbit.Subscription{
+		Exchange: "sisfo.events",
+		Queue:    "auth-service-guardian-created",
+		Bindings: []string{"academic.guardian.created"},
+	}, c.once("auth-service-guardian-created", c.handleGuardianCreated))
+
+	// The gateway reports every request made with an impersonation token
+	go c.rabbitClient.Subscribe(ctx, rabbit.Subscription{
+		Exchange: "sisfo.events",
+		Queue:    "auth-service-impersonation-audit",
+		Bindings: []string{smiddleware.ImpersonationRoutingKey},
+	}, c.once("auth-service-impersonation-audit", c.handleImpersonationRequest))
+
+	// Other services publish their audit entries through shared/pkg/audit
+	go c.rabbitClient.Subscribe(ctx, rabbit.Subscription{
+		Exchange: audit.Exchange,
+		Queue:    "auth-service-audit-events",
+		Bindings: []string{audit.RoutingKey},
+	}, c.once("auth-service-audit-events", c.handleAuditRecorded))
+}
 
-	go func() {
-		for d := range msgs {
-			if err := c.handleStudentRegistration(d.Body); err != nil {
-				log.Printf("Error handling message: %v", err)
-			}
+func processedKey(queue, messageID string) string {
+	return "auth:events:processed:" + queue + ":" + messageID
+}
+
+// once skips deliveries whose message ID queue has already handled. A
+// delivery is remembered only after h succeeds, so a failed one is retried.
+func (c *Consumer) once(queue string, h rabbit.Handler) rabbit.Handler {
+	return func(ctx context.Context, d rabbit.Delivery) error {
+		if c.kv == nil || d.MessageID == "" {
+			return h(ctx, d)
+		}
+		key := processedKey(queue, d.MessageID)
+		// A Redis outage falls through to the handler rather than stalling the queue
+		if _, err := redisutil.Get(ctx, c.kv, key); err == nil {
+			log.Printf("Skipping %s message %s, already processed", d.RoutingKey, d.MessageID)
+			return nil
 		}
-	}()
+		if err := h(ctx, d); err != nil {
+			return err
+		}
+		if err := redisutil.Set(ctx, c.kv, key, time.Now().UTC().Format(time.RFC3339), processedTTL); err != nil {
+			log.Printf("Failed to remember %s message %s: %v", d.RoutingKey, d.MessageID, err)
+		}
+		return nil
+	}
 }
 
 type StudentRegisteredEvent struct {
@@ -53,36 +115,156 @@ type StudentRegisteredEvent struct {
 	Timestamp          time.Time `json:"timestamp"`
 }
 
-func (c *Consumer) handleStudentRegistration(body []byte) error {
+func (c *Consumer) handleStudentRegistration(ctx context.Context, d rabbit.Delivery) error {
 	var event StudentRegisteredEvent
-	if err := json.Unmarshal(body, &event); err != nil {
-		log.Printf("Failed to unmarshal student registration event: %v", err)
-		return nil // Don't retry malformed messages
+	if err := json.Unmarshal(d.Body, &event); err != nil {
+		return rabbit.Permanent(err) // Don't retry malformed messages
+	}
+	if event.TenantID == "" || strings.TrimSpace(event.Email) == "" {
+		return rabbit.Permanent(errors.New("student registration without tenant_id or email"))
 	}
 
-	ctx := context.Background()
-	// Default password for new students. In production, this should be random and emailed.
-	password := "Student@123!"
-
-	user, err := c.usersUC.Register(ctx, usecase.UserRegisterInput{
+	// The account stays inactive until the student sets a password through
+	// the invitation sent by notification-service.
+	user, err := c.invitations.Invite(ctx, usecase.InviteInput{
 		TenantID: event.TenantID,
 		Email:    event.Email,
-		Password: password,
+		Roles:    []string{"student"},
 	})
-	if err != nil {
-		// If user already exists, we might want to just proceed to role assignment or log and skip.
-		log.Printf("Failed to register user for student %s: %v", event.Email, err)
-		// Return nil to avoid retry loop
+	if errors.Is(err, repository.ErrDuplicate) {
+		// Invited on an earlier delivery, or the student already has an account
+		log.Printf("Student %s already has an account", event.Email)
 		return nil
 	}
-
-	// Assign 'student' role
-	_, err = c.rolesUC.AssignByName(ctx, event.TenantID, user.ID, "student")
 	if err != nil {
-		log.Printf("Failed to assign student role to user %s: %v", user.ID, err)
-		return nil
+		return fmt.Errorf("invite student %s: %w", event.Email, err)
+	}
+
+	log.Printf("Successfully invited user %s with student role for %s", user.ID, event.Email)
+	return nil
+}
+
+type GuardianCreatedEvent struct {
+	TenantID   string    `json:"tenant_id"`
+	GuardianID string    `json:"guardian_id"`
+	Name       string    `json:"name"`
+	Email      string    `json:"email"`
+	Phone      string    `json:"phone"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func (c *Consumer) handleGuardianCreated(ctx context.Context, d rabbit.Delivery) error {
+	var event GuardianCreatedEvent
+	if err := json.Unmarshal(d.Body, &event); err != nil {
+		return rabbit.Permanent(err) // Don't retry malformed messages
 	}
+	email := strings.ToLower(strings.TrimSpace(event.Email))
+	if event.TenantID == "" || email == "" {
+		return rabbit.Permanent(errors.New("guardian created without tenant_id or email"))
+	}
+
+	// A parent may already hold an account, e.g. as a teacher at the same school
+	user, err := c.usersRepo.FindByEmail(ctx, event.TenantID, email)
+	switch {
+	case err == nil:
+		if _, err := c.rolesUC.AssignByName(ctx, event.TenantID, user.ID, "guardian"); err != nil {
+			return fmt.Errorf("assign guardian role to user %s: %w", user.ID, err)
+		}
+	case errors.Is(err, pgx.ErrNoRows):
+		user, err = c.invitations.Invite(ctx, usecase.InviteInput{
+			TenantID: event.TenantID,
+			Email:    email,
+			Roles:    []string{"guardian"},
+		})
+		if err != nil {
+			// A duplicate means the account appeared meanwhile; the retry
+			// finds it and assigns the role
+			return fmt.Errorf("invite guardian %s: %w", email, err)
+		}
+	default:
+		return fmt.Errorf("find user for guardian %s: %w", email, err)
+	}
+
+	payload := map[string]interface{}{
+		"tenant_id":   event.TenantID,
+		"guardian_id": event.GuardianID,
+		"user_id":     user.ID,
+		"timestamp":   time.Now(),
+	}
+	if err := c.rabbitClient.PublishJSON("sisfo.events", "auth.guardian.user_linked", payload); err != nil {
+		// Redelivery finds the account and publishes the link again
+		return fmt.Errorf("publish guardian user link for %s: %w", event.GuardianID, err)
+	}
+
+	log.Printf("Successfully linked user %s to guardian %s", user.ID, event.GuardianID)
+	return nil
+}
+
+type ImpersonationRequestEvent struct {
+	TenantID  string    `json:"tenant_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	ActorID   uuid.UUID `json:"actor_id"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	RequestID string    `json:"request_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
 
-	log.Printf("Successfully created user and assigned student role for %s", event.Email)
+// handleImpersonationRequest records the request under the impersonated user
+// with the staff member who made it.
+func (c *Consumer) handleImpersonationRequest(ctx context.Context, d rabbit.Delivery) error {
+	var event ImpersonationRequestEvent
+	if err := json.Unmarshal(d.Body, &event); err != nil {
+		return rabbit.Permanent(err) // Don't retry malformed messages
+	}
+	if event.UserID == uuid.Nil {
+		return rabbit.Permanent(errors.New("impersonation request without user_id"))
+	}
+	if err := c.auditRepo.Log(ctx, event.TenantID, &event.UserID, "impersonation.request", "route", nil, map[string]any{
+		"actor_id":   event.ActorID.String(),
+		"method":     event.Method,
+		"path":       event.Path,
+		"status":     event.Status,
+		"request_id": event.RequestID,
+		"timestamp":  event.Timestamp,
+	}); err != nil {
+		return fmt.Errorf("record impersonated request by %s: %w", event.ActorID, err)
+	}
+	return nil
+}
+
+type AuditRecordedEvent struct {
+	TenantID     string          `json:"tenant_id"`
+	UserID       *uuid.UUID      `json:"user_id"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   *uuid.UUID      `json:"resource_id"`
+	OldValues    json.RawMessage `json:"old_values"`
+	NewValues    json.RawMessage `json:"new_values"`
+	Timestamp    time.Time       `json:"timestamp"`
+}
+
+// handleAuditRecorded appends an entry published by another service to the
+// tenant's audit chain.
+func (c *Consumer) handleAuditRecorded(ctx context.Context, d rabbit.Delivery) error {
+	var event AuditRecordedEvent
+	if err := json.Unmarshal(d.Body, &event); err != nil {
+		return rabbit.Permanent(err) // Don't retry malformed messages
+	}
+	if event.TenantID == "" || event.Action == "" {
+		return rabbit.Permanent(errors.New("audit event without tenant_id or action"))
+	}
+	var oldValues any
+	if len(event.OldValues) > 0 && string(event.OldValues) != "null" {
+		oldValues = event.OldValues
+	}
+	var newValues any
+	if len(event.NewValues) > 0 {
+		newValues = event.NewValues
+	}
+	if err := c.auditRepo.LogChange(ctx, event.TenantID, event.UserID, event.Action, event.ResourceType, event.ResourceID, oldValues, newValues); err != nil {
+		return fmt.Errorf("record audit event %s: %w", event.Action, err)
+	}
 	return nil
 }
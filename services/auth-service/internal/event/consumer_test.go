@@ -0,0 +1,75 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/rabbit"
+	"github.com/redis/go-redis/v9"
+)
+
+type memKV struct {
+	m map[string]string
+}
+
+func (k *memKV) Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	k.m[key] = value.(string)
+	return redis.NewStatusResult("OK", nil)
+}
+
+func (k *memKV) Get(ctx context.Context, key string) *redis.StringCmd {
+	v, ok := k.m[key]
+	if !ok {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(v, nil)
+}
+
+func (k *memKV) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	for _, key := range keys {
+		delete(k.m, key)
+	}
+	return redis.NewIntResult(int64(len(keys)), nil)
+}
+
+func TestConsumer_OnceSkipsHandledMessages(t *testing.T) {
+	c := &Consumer{kv: &memKV{m: map[string]string{}}}
+	calls := 0
+	fail := errors.New("db down")
+	var next error
+	handle := func(context.Context, rabbit.Delivery) error {
+		calls++
+		return next
+	}
+	h := c.once("q", handle)
+	ctx := context.Background()
+	d := rabbit.Delivery{RoutingKey: "rk", MessageID: "m1"}
+
+	// A failed delivery is not remembered, so its retry runs again
+	next = fail
+	if err := h(ctx, d); !errors.Is(err, fail) {
+		t.Fatalf("err=%v", err)
+	}
+	next = nil
+	if err := h(ctx, d); err != nil {
+		t.Fatal(err)
+	}
+	if err := h(ctx, d); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("calls=%d want 2", calls)
+	}
+	// Other queues and messages are tracked apart
+	if err := c.once("other", handle)(ctx, d); err != nil {
+		t.Fatal(err)
+	}
+	if err := h(ctx, rabbit.Delivery{MessageID: "m2"}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 4 {
+		t.Fatalf("calls=%d want 4", calls)
+	}
+}
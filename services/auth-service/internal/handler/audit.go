@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
 	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 )
@@ -21,10 +22,11 @@ func NewAuditHandler(repo *repository.AuditRepo) *AuditHandler {
 	return &AuditHandler{repo: repo}
 }
 
-func (h *AuditHandler) RegisterProtected(g *gin.RouterGroup) {
-	g.GET("/api/v1/audit-logs", h.list)
-	g.GET("/api/v1/audit-logs/search", h.search)
-	g.GET("/api/v1/audit-logs/export", h.export)
+func (h *AuditHandler) RegisterProtected(g *gin.RouterGroup, perm func(permission string) gin.HandlerFunc) {
+	g.GET("/api/v1/audit-logs", perm(authz.AuditLogRead), h.list)
+	g.GET("/api/v1/audit-logs/search", perm(authz.AuditLogRead), h.search)
+	g.GET("/api/v1/audit-logs/export", perm(authz.AuditLogRead), h.export)
+	g.GET("/api/v1/audit-logs/verify", perm(authz.AuditLogRead), h.verify)
 }
 
 func (h *AuditHandler) list(c *gin.Context) {
@@ -93,6 +95,22 @@ func (h *AuditHandler) search(c *gin.Context) {
 	httputil.Success(c.Writer, map[string]any{"items": items, "total": total})
 }
 
+// verify walks the caller's tenant chain and reports gaps and edited entries.
+func (h *AuditHandler) verify(c *gin.Context) {
+	val, ok := c.Get("claims")
+	if !ok {
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", nil)
+		return
+	}
+	claims, _ := val.(jwtutil.Claims)
+	rep, err := h.repo.VerifyChain(c.Request.Context(), claims.TenantID)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	httputil.Success(c.Writer, rep)
+}
+
 func (h *AuditHandler) export(c *gin.Context) {
 	val, ok := c.Get("claims")
 	if !ok {
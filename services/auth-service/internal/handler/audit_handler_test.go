@@ -10,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 )
 
@@ -39,7 +40,7 @@ func TestAuditHandler_List_Search_Export(t *testing.T) {
 	protected.Use(func(c *gin.Context) {
 		c.Set("claims", jwtutil.Claims{TenantID: tenant, UserID: u.ID})
 	})
-	h.RegisterProtected(protected)
+	h.RegisterProtected(protected, func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } })
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit-logs?limit=10", nil)
 	r.ServeHTTP(w, req)
@@ -83,3 +84,30 @@ func TestAuditHandler_List_Search_Export(t *testing.T) {
 		t.Fatalf("expected text/csv got %s", ct)
 	}
 }
+
+func TestAuditHandler_RequiresPermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	var required []string
+	NewAuditHandler(nil).RegisterProtected(r.Group("/"), func(permission string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			required = append(required, permission)
+			c.AbortWithStatus(http.StatusForbidden)
+		}
+	})
+	for _, path := range []string{"/api/v1/audit-logs", "/api/v1/audit-logs/search", "/api/v1/audit-logs/export", "/api/v1/audit-logs/verify"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("%s status=%d", path, w.Code)
+		}
+	}
+	for _, p := range required {
+		if p != authz.AuditLogRead {
+			t.Fatalf("requires %q, want %q", p, authz.AuditLogRead)
+		}
+	}
+	if len(required) != 4 {
+		t.Fatalf("checked %d routes", len(required))
+	}
+}
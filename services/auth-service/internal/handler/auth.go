@@ -4,16 +4,16 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
 
-	"unicode"
-
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
 	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
@@ -30,18 +30,59 @@ type AuthHandler struct {
 	cfg       config.Config
 	redis     *redisutil.Client
 	rabbit    *rabbit.Client
+	tokens    *usecase.Tokens
+	keys      *jwtutil.KeyRing
+	sessions  usecase.Sessions
+	mfa       usecase.MFA
+	oidc      usecase.OIDC
+	passwords usecase.PasswordPolicies
 }
 
-func NewAuthHandler(repo *repository.UsersRepo, cfg config.Config, r *redisutil.Client, audit *repository.AuditRepo, pr *repository.PasswordResetRepo, rb *rabbit.Client, ph *repository.PasswordHistoryRepo) *AuthHandler {
-	return &AuthHandler{repo: repo, cfg: cfg, redis: r, auditRepo: audit, prRepo: pr, rabbit: rb, phRepo: ph}
+func NewAuthHandler(repo *repository.UsersRepo, cfg config.Config, r *redisutil.Client, audit *repository.AuditRepo, pr *repository.PasswordResetRepo, rb *rabbit.Client, ph *repository.PasswordHistoryRepo, tokens *usecase.Tokens, keys *jwtutil.KeyRing, sessions usecase.Sessions, mfa usecase.MFA, oidc usecase.OIDC, passwords usecase.PasswordPolicies) *AuthHandler {
+	if passwords == nil {
+		passwords = usecase.NewPasswordPolicies(nil, repo, ph, usecase.DefaultPasswordPolicy(cfg.LockoutThreshold, cfg.LockoutTTL))
+	}
+	return &AuthHandler{repo: repo, cfg: cfg, redis: r, auditRepo: audit, prRepo: pr, rabbit: rb, phRepo: ph, tokens: tokens, keys: keys, sessions: sessions, mfa: mfa, oidc: oidc, passwords: passwords}
+}
+
+// accessClaims loads roles, permissions and the token version for u. Without a
+// Tokens usecase the claims carry no roles.
+func (h *AuthHandler) accessClaims(c *gin.Context, u *repository.User) (jwtutil.Claims, error) {
+	if h.tokens == nil {
+		return jwtutil.Claims{UserID: u.ID, TenantID: u.TenantID, Roles: []string{}}, nil
+	}
+	return h.tokens.Claims(c.Request.Context(), u)
 }
 
-func (h *AuthHandler) Register(r *gin.Engine) {
+// signAccess signs with the asymmetric key ring when configured, otherwise
+// with the shared HS256 secret.
+func (h *AuthHandler) signAccess(claims jwtutil.Claims) (string, error) {
+	return h.signAccessFor(claims, h.cfg.JWTAccessTTL)
+}
+
+func (h *AuthHandler) signAccessFor(claims jwtutil.Claims, ttl time.Duration) (string, error) {
+	if h.keys != nil {
+		return h.keys.GenerateAccessWith(ttl, claims, h.cfg.JWTIssuer, h.cfg.JWTAudience)
+	}
+	return jwtutil.GenerateAccessWith(h.cfg.JWTAccessSecret, ttl, claims, h.cfg.JWTIssuer, h.cfg.JWTAudience)
+}
+
+func (h *AuthHandler) Register(r gin.IRoutes) {
 	r.POST("/api/v1/auth/login", h.login)
 	r.POST("/api/v1/auth/refresh", h.refresh)
 	r.POST("/api/v1/auth/logout", h.logout)
 	r.POST("/api/v1/auth/forgot-password", h.forgotPassword)
 	r.POST("/api/v1/auth/reset-password", h.resetPassword)
+	r.POST("/api/v1/auth/password/expired", h.changeExpiredPassword)
+	if h.mfa != nil {
+		r.POST("/api/v1/auth/mfa/verify", h.verifyMFA)
+		r.POST("/api/v1/auth/mfa/challenge/enroll", h.enrollMFAChallenge)
+	}
+	if h.oidc != nil {
+		r.GET("/api/v1/auth/oidc/:provider/authorize", h.oidcAuthorize)
+		r.GET("/api/v1/auth/oidc/callback", h.oidcCallback)
+		r.POST("/api/v1/auth/oidc/callback", h.oidcCallback)
+	}
 }
 
 func (h *AuthHandler) RegisterProtected(g *gin.RouterGroup) {
@@ -49,49 +90,6 @@ func (h *AuthHandler) RegisterProtected(g *gin.RouterGroup) {
 	g.POST("/api/v1/auth/change-password", h.changePassword)
 }
 
-func isComplexPassword(s string) bool {
-	hasUpper := false
-	hasLower := false
-	hasDigit := false
-	hasSymbol := false
-	for _, r := range s {
-		if unicode.IsUpper(r) {
-			hasUpper = true
-			continue
-		}
-		if unicode.IsLower(r) {
-			hasLower = true
-			continue
-		}
-		if unicode.IsDigit(r) {
-			hasDigit = true
-			continue
-		}
-		if unicode.IsPunct(r) || unicode.IsSymbol(r) {
-			hasSymbol = true
-			continue
-		}
-	}
-	return hasUpper && hasLower && hasDigit && hasSymbol
-}
-
-func isCommonPassword(s string) bool {
-	l := strings.ToLower(s)
-	common := map[string]struct{}{
-		"password":  {},
-		"password1": {},
-		"passw0rd":  {},
-		"p@ssw0rd":  {},
-		"123456":    {},
-		"qwerty":    {},
-		"admin":     {},
-		"welcome":   {},
-		"letmein":   {},
-	}
-	_, ok := common[l]
-	return ok
-}
-
 type loginReq struct {
 	TenantID string `json:"tenant_id"`
 	Email    string `json:"email"`
@@ -104,7 +102,8 @@ func (h *AuthHandler) login(c *gin.Context) {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid json")
 		return
 	}
-	if _, err := redisutil.Get(c.Request.Context(), h.redis.Raw(), "lockout:"+strings.TrimSpace(req.TenantID)+":"+strings.ToLower(strings.TrimSpace(req.Email))); err == nil {
+	key := lockoutKey(req.TenantID, req.Email)
+	if _, err := redisutil.Get(c.Request.Context(), h.redis.Raw(), "lockout:"+key); err == nil {
 		_ = h.auditRepo.Log(c.Request.Context(), req.TenantID, nil, "auth.login", "user", nil, map[string]any{"success": false, "reason": "locked"})
 		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "account locked")
 		return
@@ -121,39 +120,129 @@ func (h *AuthHandler) login(c *gin.Context) {
 		return
 	}
 	if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(req.Password)) != nil {
-		key := "loginfail:" + strings.TrimSpace(req.TenantID) + ":" + strings.ToLower(strings.TrimSpace(req.Email))
-		n, _ := redisutil.IncrWithTTL(c.Request.Context(), h.redis.Raw(), key, h.cfg.FailWindowTTL)
-		if n >= int64(h.cfg.LockoutThreshold) {
-			_ = redisutil.Set(c.Request.Context(), h.redis.Raw(), "lockout:"+strings.TrimSpace(req.TenantID)+":"+strings.ToLower(strings.TrimSpace(req.Email)), "1", h.cfg.LockoutTTL)
-			_ = h.auditRepo.Log(c.Request.Context(), req.TenantID, &u.ID, "auth.login", "user", &u.ID, map[string]any{"success": false, "reason": "locked"})
-			httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "account locked")
+		h.loginFailed(c, key, u, "auth.login", "invalid credentials")
+		return
+	}
+	expired, err := h.passwords.Expired(c.Request.Context(), u)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	h.firstFactorPassed(c, u, key, expired, map[string]any{"success": true})
+}
+
+// firstFactorPassed continues a login whose password or identity provider
+// check succeeded: it either opens an MFA challenge or moves on to
+// loginVerified.
+func (h *AuthHandler) firstFactorPassed(c *gin.Context, u *repository.User, key string, passwordExpired bool, audit map[string]any) {
+	if h.mfa != nil {
+		st, err := h.mfa.Status(c.Request.Context(), u)
+		if err != nil {
+			httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
 			return
 		}
-		_ = h.auditRepo.Log(c.Request.Context(), req.TenantID, &u.ID, "auth.login", "user", &u.ID, map[string]any{"success": false})
-		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "invalid credentials")
+		if st.Enabled || st.Required {
+			token, err := h.startMFAChallenge(c, u, key, passwordExpired)
+			if err != nil {
+				httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+				return
+			}
+			_ = h.auditRepo.Log(c.Request.Context(), u.TenantID, &u.ID, "auth.login.mfa_challenge", "user", &u.ID, map[string]any{"enrollment_required": !st.Enabled})
+			httputil.Success(c.Writer, map[string]any{
+				"mfa_required":        true,
+				"mfa_token":           token,
+				"enrollment_required": !st.Enabled,
+			})
+			return
+		}
+	}
+	h.loginVerified(c, u, key, passwordExpired, audit, nil)
+}
+
+// loginVerified runs once every factor has been verified. An expired password
+// must be replaced through changeExpiredPassword before tokens are issued.
+func (h *AuthHandler) loginVerified(c *gin.Context, u *repository.User, key string, passwordExpired bool, audit map[string]any, extra map[string]any) {
+	if !passwordExpired {
+		h.completeLogin(c, u, key, audit, extra)
 		return
 	}
-	_ = redisutil.Del(c.Request.Context(), h.redis.Raw(), "loginfail:"+strings.TrimSpace(req.TenantID)+":"+strings.ToLower(strings.TrimSpace(req.Email)))
-	_ = redisutil.Del(c.Request.Context(), h.redis.Raw(), "lockout:"+strings.TrimSpace(req.TenantID)+":"+strings.ToLower(strings.TrimSpace(req.Email)))
-	access, err := jwtutil.GenerateAccessWith(h.cfg.JWTAccessSecret, h.cfg.JWTAccessTTL, jwtutil.Claims{
-		UserID:   u.ID,
-		TenantID: u.TenantID,
-		Roles:    []string{},
-	}, h.cfg.JWTIssuer, h.cfg.JWTAudience)
+	token, err := h.startPasswordChange(c, u, key)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
 		return
 	}
-	refresh, err := jwtutil.GenerateRefreshWith(h.cfg.JWTRefreshSecret, h.cfg.JWTRefreshTTL, u.ID.String(), h.cfg.JWTIssuer, h.cfg.JWTAudience)
+	_ = h.auditRepo.Log(c.Request.Context(), u.TenantID, &u.ID, "auth.login.password_expired", "user", &u.ID, nil)
+	out := map[string]any{
+		"password_expired":      true,
+		"password_change_token": token,
+	}
+	for k, v := range extra {
+		out[k] = v
+	}
+	httputil.Success(c.Writer, out)
+}
+
+// lockoutKey identifies the login for the loginfail: and lockout: counters.
+func lockoutKey(tenantID, email string) string {
+	return strings.TrimSpace(tenantID) + ":" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// loginFailed counts a failed password or MFA attempt towards the lockout
+// threshold and writes the response.
+func (h *AuthHandler) loginFailed(c *gin.Context, key string, u *repository.User, action, msg string) {
+	threshold, lockFor := h.cfg.LockoutThreshold, h.cfg.LockoutTTL
+	if pol, err := h.passwords.Get(c.Request.Context(), u.TenantID); err == nil {
+		threshold, lockFor = usecase.LockoutSettings(pol)
+	}
+	n, _ := redisutil.IncrWithTTL(c.Request.Context(), h.redis.Raw(), "loginfail:"+key, h.cfg.FailWindowTTL)
+	if n >= int64(threshold) {
+		_ = redisutil.Set(c.Request.Context(), h.redis.Raw(), "lockout:"+key, "1", lockFor)
+		_ = h.auditRepo.Log(c.Request.Context(), u.TenantID, &u.ID, action, "user", &u.ID, map[string]any{"success": false, "reason": "locked"})
+		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "account locked")
+		return
+	}
+	_ = h.auditRepo.Log(c.Request.Context(), u.TenantID, &u.ID, action, "user", &u.ID, map[string]any{"success": false})
+	httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", msg)
+}
+
+// completeLogin clears the failure counters and issues the token pair. It
+// runs only once every required factor has been verified.
+func (h *AuthHandler) completeLogin(c *gin.Context, u *repository.User, key string, audit map[string]any, extra map[string]any) {
+	_ = redisutil.Del(c.Request.Context(), h.redis.Raw(), "loginfail:"+key)
+	_ = redisutil.Del(c.Request.Context(), h.redis.Raw(), "lockout:"+key)
+	claims, err := h.accessClaims(c, u)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
 		return
 	}
-	_ = h.auditRepo.Log(c.Request.Context(), u.TenantID, &u.ID, "auth.login", "user", &u.ID, map[string]any{"success": true})
-	httputil.Success(c.Writer, map[string]any{
+	jti := uuid.NewString()
+	if h.sessions != nil {
+		sess, err := h.sessions.Start(c.Request.Context(), u, jti, c.Request.UserAgent(), c.ClientIP(), time.Now().UTC().Add(h.cfg.JWTRefreshTTL))
+		if err != nil {
+			httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+			return
+		}
+		claims.SessionID = sess.ID.String()
+	}
+	access, err := h.signAccess(claims)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	refresh, err := jwtutil.GenerateRefreshSession(h.cfg.JWTRefreshSecret, h.cfg.JWTRefreshTTL, u.ID.String(), claims.SessionID, jti, h.cfg.JWTIssuer, h.cfg.JWTAudience)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	_ = h.auditRepo.Log(c.Request.Context(), u.TenantID, &u.ID, "auth.login", "user", &u.ID, audit)
+	out := map[string]any{
 		"access_token":  access,
 		"refresh_token": refresh,
-	})
+	}
+	for k, v := range extra {
+		out[k] = v
+	}
+	httputil.Success(c.Writer, out)
 }
 
 func (h *AuthHandler) me(c *gin.Context) {
@@ -168,12 +257,17 @@ func (h *AuthHandler) me(c *gin.Context) {
 		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "user not found or inactive")
 		return
 	}
-	httputil.Success(c.Writer, map[string]any{
-		"id":        u.ID,
-		"tenant_id": u.TenantID,
-		"email":     u.Email,
-		"roles":     claims.Roles,
-	})
+	out := map[string]any{
+		"id":          u.ID,
+		"tenant_id":   u.TenantID,
+		"email":       u.Email,
+		"roles":       claims.Roles,
+		"permissions": claims.Permissions,
+	}
+	if claims.Actor != nil {
+		out["impersonated_by"] = claims.Actor.UserID
+	}
+	httputil.Success(c.Writer, out)
 }
 
 type refreshReq struct {
@@ -186,7 +280,7 @@ func (h *AuthHandler) refresh(c *gin.Context) {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing refresh_token")
 		return
 	}
-	var claims jwt.RegisteredClaims
+	var claims jwtutil.RefreshClaims
 	tkn, err := jwt.ParseWithClaims(req.RefreshToken, &claims, func(token *jwt.Token) (any, error) {
 		return []byte(h.cfg.JWTRefreshSecret), nil
 	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
@@ -211,33 +305,57 @@ func (h *AuthHandler) refresh(c *gin.Context) {
 			return
 		}
 	}
-	// Check blacklist by JTI
-	if strings.TrimSpace(claims.ID) != "" {
-		if _, err := redisutil.Get(c.Request.Context(), h.redis.Raw(), "blacklist:jti:"+claims.ID); err == nil {
-			httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "token revoked")
-			return
-		}
-	}
 	uid, err := uuid.Parse(claims.Subject)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "invalid subject")
 		return
 	}
+	nextJTI := uuid.NewString()
+	if claims.SessionID != "" && h.sessions != nil {
+		// The session registry is authoritative for session-bound tokens and
+		// must see replays of rotated tokens, so it runs before the blacklist.
+		sid, err := uuid.Parse(claims.SessionID)
+		if err != nil {
+			httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "invalid session")
+			return
+		}
+		_, err = h.sessions.Rotate(c.Request.Context(), sid, uid, claims.ID, nextJTI, c.Request.UserAgent(), c.ClientIP(), time.Now().UTC().Add(h.cfg.JWTRefreshTTL))
+		switch {
+		case errors.Is(err, usecase.ErrRefreshReuse):
+			_ = h.auditRepo.Log(c.Request.Context(), "", &uid, "auth.refresh.reuse", "user", &uid, map[string]any{"session_id": sid})
+			httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "refresh token reuse detected")
+			return
+		case errors.Is(err, usecase.ErrSessionNotFound), errors.Is(err, usecase.ErrSessionRevoked):
+			httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "session revoked")
+			return
+		case err != nil:
+			httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+			return
+		}
+	} else if strings.TrimSpace(claims.ID) != "" {
+		// Check blacklist by JTI
+		if _, err := redisutil.Get(c.Request.Context(), h.redis.Raw(), "blacklist:jti:"+claims.ID); err == nil {
+			httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "token revoked")
+			return
+		}
+	}
 	u, err := h.repo.FindByID(c.Request.Context(), uid)
 	if err != nil || !u.IsActive {
 		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "user not found or inactive")
 		return
 	}
-	access, err := jwtutil.GenerateAccess(h.cfg.JWTAccessSecret, h.cfg.JWTAccessTTL, jwtutil.Claims{
-		UserID:   u.ID,
-		TenantID: u.TenantID,
-		Roles:    []string{},
-	})
+	accessClaims, err := h.accessClaims(c, u)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	accessClaims.SessionID = claims.SessionID
+	access, err := h.signAccess(accessClaims)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
 		return
 	}
-	newRefresh, err := jwtutil.GenerateRefreshWith(h.cfg.JWTRefreshSecret, h.cfg.JWTRefreshTTL, u.ID.String(), h.cfg.JWTIssuer, h.cfg.JWTAudience)
+	newRefresh, err := jwtutil.GenerateRefreshSession(h.cfg.JWTRefreshSecret, h.cfg.JWTRefreshTTL, u.ID.String(), claims.SessionID, nextJTI, h.cfg.JWTIssuer, h.cfg.JWTAudience)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
 		return
@@ -258,11 +376,18 @@ func (h *AuthHandler) logout(c *gin.Context) {
 	_ = c.BindJSON(&req)
 	// Blacklist provided refresh token (if any)
 	if strings.TrimSpace(req.RefreshToken) != "" {
-		var claims jwt.RegisteredClaims
+		var claims jwtutil.RefreshClaims
 		if tkn, err := jwt.ParseWithClaims(req.RefreshToken, &claims, func(token *jwt.Token) (any, error) {
 			return []byte(h.cfg.JWTRefreshSecret), nil
 		}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()})); err == nil && tkn.Valid && strings.TrimSpace(claims.ID) != "" {
 			_ = redisutil.Set(c.Request.Context(), h.redis.Raw(), "blacklist:jti:"+claims.ID, "1", h.cfg.JWTRefreshTTL)
+			sid, serr := uuid.Parse(claims.SessionID)
+			uid, uerr := uuid.Parse(claims.Subject)
+			if h.sessions != nil && serr == nil && uerr == nil {
+				if u, err := h.repo.FindByID(c.Request.Context(), uid); err == nil {
+					_ = h.sessions.Revoke(c.Request.Context(), u.TenantID, u.ID, sid, "logout")
+				}
+			}
 		}
 	}
 	_ = h.auditRepo.Log(c.Request.Context(), "", nil, "auth.logout", "user", nil, map[string]any{"success": true})
@@ -324,18 +449,6 @@ func (h *AuthHandler) resetPassword(c *gin.Context) {
 		return
 	}
 	p := strings.TrimSpace(req.Password)
-	if len(p) < 8 {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "password must be at least 8 characters")
-		return
-	}
-	if !isComplexPassword(p) {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "password must include uppercase, lowercase, number, and symbol")
-		return
-	}
-	if isCommonPassword(p) {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "password is too common")
-		return
-	}
 	tokenStr := strings.TrimSpace(req.Token)
 	hash := sha256.Sum256([]byte(tokenStr))
 	tokenHash := hex.EncodeToString(hash[:])
@@ -345,32 +458,10 @@ func (h *AuthHandler) resetPassword(c *gin.Context) {
 		if strings.ToLower(h.cfg.Env) == "test" {
 			if uidStr, rerr := redisutil.Get(c.Request.Context(), h.redis.Raw(), "password_reset:th:"+tokenHash); rerr == nil {
 				if uid, perr := uuid.Parse(uidStr); perr == nil {
-					u, ferr := h.repo.FindByID(c.Request.Context(), uid)
-					if ferr == nil {
-						if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(p)) == nil {
-							httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "password reuse not allowed")
+					if u, ferr := h.repo.FindByID(c.Request.Context(), uid); ferr == nil {
+						if !h.setPassword(c, u, p) {
 							return
 						}
-						if h.phRepo != nil {
-							if items, ierr := h.phRepo.Recent(c.Request.Context(), uid, 5); ierr == nil {
-								for _, it := range items {
-									if bcrypt.CompareHashAndPassword([]byte(it.PasswordHash), []byte(p)) == nil {
-										httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "password reuse not allowed")
-										return
-									}
-								}
-							}
-						}
-					}
-					_, uerr := h.repo.Update(c.Request.Context(), uid, repository.UpdateUserParams{
-						Password: &p,
-						Now:      time.Now().UTC(),
-					})
-					if uerr == nil {
-						if h.phRepo != nil && u != nil {
-							_ = h.phRepo.Add(c.Request.Context(), uid, u.PasswordHash, time.Now().UTC())
-							_ = h.phRepo.Prune(c.Request.Context(), uid, 5)
-						}
 						_ = h.auditRepo.Log(c.Request.Context(), "", &uid, "auth.reset_password", "user", &uid, map[string]any{"success": true})
 						_ = redisutil.Del(c.Request.Context(), h.redis.Raw(), "password_reset:th:"+tokenHash)
 						httputil.Success(c.Writer, map[string]any{"message": "password reset successful"})
@@ -387,37 +478,30 @@ func (h *AuthHandler) resetPassword(c *gin.Context) {
 		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "user not found or inactive")
 		return
 	}
-	if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(p)) == nil {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "password reuse not allowed")
-		return
-	}
-	if h.phRepo != nil {
-		if items, ierr := h.phRepo.Recent(c.Request.Context(), rec.UserID, 5); ierr == nil {
-			for _, it := range items {
-				if bcrypt.CompareHashAndPassword([]byte(it.PasswordHash), []byte(p)) == nil {
-					httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "password reuse not allowed")
-					return
-				}
-			}
-		}
-	}
-	_, err = h.repo.Update(c.Request.Context(), rec.UserID, repository.UpdateUserParams{
-		Password: &p,
-		Now:      time.Now().UTC(),
-	})
-	if err != nil {
-		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+	if !h.setPassword(c, u, p) {
 		return
 	}
-	if h.phRepo != nil {
-		_ = h.phRepo.Add(c.Request.Context(), rec.UserID, u.PasswordHash, time.Now().UTC())
-		_ = h.phRepo.Prune(c.Request.Context(), rec.UserID, 5)
-	}
 	_ = h.prRepo.MarkUsed(c.Request.Context(), rec.ID, time.Now().UTC())
 	_ = h.auditRepo.Log(c.Request.Context(), rec.TenantID, &rec.UserID, "auth.reset_password", "user", &rec.UserID, map[string]any{"success": true})
 	httputil.Success(c.Writer, map[string]any{"message": "password reset successful"})
 }
 
+// setPassword applies the tenant's password policy and stores the new
+// password, writing the error response when it is rejected.
+func (h *AuthHandler) setPassword(c *gin.Context, u *repository.User, password string) bool {
+	err := h.passwords.Change(c.Request.Context(), u, password)
+	var violation *usecase.PolicyViolation
+	switch {
+	case errors.As(err, &violation):
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", violation.Reason)
+		return false
+	case err != nil:
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return false
+	}
+	return true
+}
+
 type changeReq struct {
 	OldPassword string `json:"old_password"`
 	NewPassword string `json:"new_password"`
@@ -444,45 +528,9 @@ func (h *AuthHandler) changePassword(c *gin.Context) {
 		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "invalid current password")
 		return
 	}
-	p := strings.TrimSpace(req.NewPassword)
-	if len(p) < 8 {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "password must be at least 8 characters")
+	if !h.setPassword(c, u, strings.TrimSpace(req.NewPassword)) {
 		return
 	}
-	if !isComplexPassword(p) {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "password must include uppercase, lowercase, number, and symbol")
-		return
-	}
-	if isCommonPassword(p) {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "password is too common")
-		return
-	}
-	if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(p)) == nil {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "password reuse not allowed")
-		return
-	}
-	if h.phRepo != nil {
-		if items, err := h.phRepo.Recent(c.Request.Context(), u.ID, 5); err == nil {
-			for _, it := range items {
-				if bcrypt.CompareHashAndPassword([]byte(it.PasswordHash), []byte(p)) == nil {
-					httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "password reuse not allowed")
-					return
-				}
-			}
-		}
-	}
-	_, err = h.repo.Update(c.Request.Context(), u.ID, repository.UpdateUserParams{
-		Password: &p,
-		Now:      time.Now().UTC(),
-	})
-	if err != nil {
-		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
-		return
-	}
-	if h.phRepo != nil {
-		_ = h.phRepo.Add(c.Request.Context(), u.ID, u.PasswordHash, time.Now().UTC())
-		_ = h.phRepo.Prune(c.Request.Context(), u.ID, 5)
-	}
 	_ = h.auditRepo.Log(c.Request.Context(), u.TenantID, &u.ID, "auth.change_password", "user", &u.ID, map[string]any{"success": true})
 	httputil.Success(c.Writer, map[string]any{"message": "password changed"})
 }
@@ -53,6 +53,10 @@ func ensureMigrationsAuth(t *testing.T, db *pgxpool.Pool) {
 	if err == nil {
 		_, _ = db.Exec(ctx, string(b2))
 	}
+	bChain, err := os.ReadFile("../../migrations/011_audit_chain.up.sql")
+	if err == nil {
+		_, _ = db.Exec(ctx, string(bChain))
+	}
 	b3, err := os.ReadFile("../../migrations/003_password_resets.up.sql")
 	if err == nil {
 		_, _ = db.Exec(ctx, string(b3))
@@ -61,6 +65,10 @@ func ensureMigrationsAuth(t *testing.T, db *pgxpool.Pool) {
 	if err == nil {
 		_, _ = db.Exec(ctx, string(b4))
 	}
+	b9, err := os.ReadFile("../../migrations/009_password_policy.up.sql")
+	if err == nil {
+		_, _ = db.Exec(ctx, string(b9))
+	}
 }
 
 func makeCfg() config.Config {
@@ -95,7 +103,7 @@ func TestAuthHandler_Login_Success_And_Me(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 
 	r := gin.New()
 	h.Register(r)
@@ -159,7 +167,7 @@ func TestAuthHandler_AccountLockout_Threshold_And_Success_Reset(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	tenant := "t-" + uuid.NewString()
@@ -217,7 +225,7 @@ func TestAuthHandler_AccountLockout_ManualLock_PreventsLogin(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	tenant := "t-" + uuid.NewString()
@@ -250,7 +258,7 @@ func TestAuthHandler_Me_Unauthorized_When_NoClaims(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 
 	r := gin.New()
 	protected := r.Group("/")
@@ -273,7 +281,7 @@ func TestAuthHandler_Login_InvalidJSON_WrongPassword_Inactive(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 
@@ -342,7 +350,7 @@ func TestAuthHandler_Refresh_InvalidCases_And_Success(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 
@@ -420,7 +428,7 @@ func TestAuthHandler_Logout_Always200(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 
@@ -443,7 +451,7 @@ func TestAuthHandler_Logout_EmptyBody_200(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 
@@ -465,7 +473,7 @@ func TestAuthHandler_ChangePassword_Reuse_Prevented(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	protected := r.Group("/")
 	tenant := "t-" + uuid.NewString()
@@ -500,7 +508,7 @@ func TestAuthHandler_ResetPassword_Reuse_Prevented(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	tenant := "t-" + uuid.NewString()
@@ -545,7 +553,7 @@ func TestAuthHandler_Forgot_Reset_And_Login_With_New_Password(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 
@@ -603,7 +611,7 @@ func TestAuthHandler_ChangePassword_Fails_When_Wrong_Old_Then_Succeeds(t *testin
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	protected := r.Group("/")
 	tenant := "t-" + uuid.NewString()
@@ -647,7 +655,7 @@ func TestAuthHandler_Refresh_Blacklists_Old_Token(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	tenant := "t-" + uuid.NewString()
@@ -686,7 +694,7 @@ func TestAuthHandler_Logout_Blacklists_Provided_Refresh(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	tenant := "t-" + uuid.NewString()
@@ -726,7 +734,7 @@ func TestAuthHandler_ResetPassword_InvalidToken_Unauthorized(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	// Call reset without previous forgot (no redis key set)
@@ -750,7 +758,7 @@ func TestAuthHandler_AuditLogs_Actions_Recorded(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	tenant := "t-" + uuid.NewString()
@@ -851,7 +859,7 @@ func TestAuthHandler_ChangePassword_Fails_On_Weak_Password(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	protected := r.Group("/")
 	tenant := "t-" + uuid.NewString()
@@ -886,7 +894,7 @@ func TestAuthHandler_ChangePassword_Fails_On_Common_Password(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	protected := r.Group("/")
 	tenant := "t-" + uuid.NewString()
@@ -921,7 +929,7 @@ func TestAuthHandler_ChangePassword_Fails_On_Short_Password(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	protected := r.Group("/")
 	tenant := "t-" + uuid.NewString()
@@ -956,7 +964,7 @@ func TestAuthHandler_ResetPassword_Fails_On_Weak_Password(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	tenant := "t-" + uuid.NewString()
@@ -1002,7 +1010,7 @@ func TestAuthHandler_ResetPassword_Fails_On_Short_Password(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	tenant := "t-" + uuid.NewString()
@@ -1047,7 +1055,7 @@ func TestAuthHandler_ResetPassword_Fails_On_Common_Password(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	tenant := "t-" + uuid.NewString()
@@ -1093,7 +1101,7 @@ func TestAuthHandler_ResetPassword_InvalidJSON(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	w := httptest.NewRecorder()
@@ -1115,7 +1123,7 @@ func TestAuthHandler_ResetPassword_FallbackRedis_Succeeds(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	tenant := "t-" + uuid.NewString()
@@ -1161,7 +1169,7 @@ func TestAuthHandler_ChangePassword_InvalidJSON(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	protected := r.Group("/")
 	tenant := "t-" + uuid.NewString()
@@ -1196,7 +1204,7 @@ func TestAuthHandler_ChangePassword_Reuse_Prevented_By_History(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	protected := r.Group("/")
 	tenant := "t-" + uuid.NewString()
@@ -1238,7 +1246,7 @@ func TestAuthHandler_ForgotPassword_UnknownUser_GenericSuccess(t *testing.T) {
 	redis := redisutil.New(cfg.RedisAddr)
 	prRepo := repository.NewPasswordResetRepo(db)
 	phRepo := repository.NewPasswordHistoryRepo(db)
-	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo)
+	h := NewAuthHandler(usersRepo, cfg, redis, auditRepo, prRepo, nil, phRepo, nil, nil, nil, nil, nil, nil)
 	r := gin.New()
 	h.Register(r)
 	tenant := "t-" + uuid.NewString()
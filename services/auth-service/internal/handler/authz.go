@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+)
+
+type permissionChecker interface {
+	Allow(subjectID uuid.UUID, tenantID string, permission string) (bool, error)
+}
+
+// AuthzHandler answers permission checks for the other services. The route is
+// internal and is not exposed through the API gateway.
+type AuthzHandler struct {
+	authz permissionChecker
+}
+
+func NewAuthzHandler(a permissionChecker) *AuthzHandler {
+	return &AuthzHandler{authz: a}
+}
+
+func (h *AuthzHandler) Register(r *gin.Engine) {
+	r.POST(authz.CheckPath, h.check)
+}
+
+func (h *AuthzHandler) check(c *gin.Context) {
+	var in authz.CheckRequest
+	if err := c.BindJSON(&in); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid json")
+		return
+	}
+	if in.UserID == uuid.Nil || strings.TrimSpace(in.TenantID) == "" || strings.TrimSpace(in.Permission) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "user_id, tenant_id and permission required")
+		return
+	}
+	ok, err := h.authz.Allow(in.UserID, strings.TrimSpace(in.TenantID), strings.TrimSpace(in.Permission))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	httputil.Success(c.Writer, authz.CheckResponse{Allowed: ok})
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/middleware"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+)
+
+type fakeChecker struct {
+	allowed map[string]bool
+	err     error
+}
+
+func (f *fakeChecker) Allow(subjectID uuid.UUID, tenantID string, permission string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	return f.allowed[tenantID+"|"+permission], nil
+}
+
+func doCheck(t *testing.T, r *gin.Engine, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	b, _ := json.Marshal(body)
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, authz.CheckPath, bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestAuthzHandler_Check(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	NewAuthzHandler(&fakeChecker{allowed: map[string]bool{"t1|" + authz.GradeApprove: true}}).Register(r)
+	uid := uuid.New()
+
+	rr := doCheck(t, r, authz.CheckRequest{UserID: uid, TenantID: "t1", Permission: authz.GradeApprove})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status=%d body=%s", rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Data authz.CheckResponse `json:"data"`
+	}
+	_ = json.Unmarshal(rr.Body.Bytes(), &resp)
+	if !resp.Data.Allowed {
+		t.Fatalf("expected allowed")
+	}
+
+	rr = doCheck(t, r, authz.CheckRequest{UserID: uid, TenantID: "t2", Permission: authz.GradeApprove})
+	_ = json.Unmarshal(rr.Body.Bytes(), &resp)
+	if rr.Code != http.StatusOK || resp.Data.Allowed {
+		t.Fatalf("expected denied for other tenant, status=%d", rr.Code)
+	}
+
+	rr = doCheck(t, r, map[string]any{"tenant_id": "t1", "permission": authz.GradeApprove})
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("missing user_id should be 400, got %d", rr.Code)
+	}
+}
+
+func TestAuthzHandler_Check_Error(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	NewAuthzHandler(&fakeChecker{err: errors.New("db down")}).Register(r)
+	rr := doCheck(t, r, authz.CheckRequest{UserID: uuid.New(), TenantID: "t1", Permission: authz.GradeRead})
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rr.Code)
+	}
+}
+
+type memCounter struct {
+	counts map[string]int64
+}
+
+func (m *memCounter) Incr(ctx context.Context, key string) (int64, error) {
+	m.counts[key]++
+	return m.counts[key], nil
+}
+
+func (m *memCounter) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	return nil
+}
+
+func TestAuthzHandler_Check_NotRateLimited(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.RateLimitByPolicy(redisutil.NewFixedWindowLimiter(&memCounter{counts: map[string]int64{}}), 100, 30, nil, nil))
+	NewAuthzHandler(&fakeChecker{}).Register(r)
+	r.POST("/api/v1/things", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// One service instance checks many user and permission pairs from one
+	// address, beyond the 30 writes a client may make
+	for i := 0; i < 50; i++ {
+		rr := doCheck(t, r, authz.CheckRequest{UserID: uuid.New(), TenantID: "t1", Permission: authz.Catalogue()[i%len(authz.Catalogue())]})
+		if rr.Code != http.StatusOK {
+			t.Fatalf("check %d: status=%d", i, rr.Code)
+		}
+	}
+	limited := false
+	for i := 0; i < 31 && !limited; i++ {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/things", nil))
+		limited = rr.Code == http.StatusTooManyRequests
+	}
+	if !limited {
+		t.Fatal("public route was not limited")
+	}
+}
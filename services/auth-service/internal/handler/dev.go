@@ -17,7 +17,7 @@ func NewDevHandler(repo *repository.UsersRepo) *DevHandler {
 	return &DevHandler{repo: repo}
 }
 
-func (h *DevHandler) Register(r *gin.Engine) {
+func (h *DevHandler) Register(r gin.IRoutes) {
 	r.POST("/api/v1/auth/dev/bootstrap-user", h.bootstrapUser)
 }
 
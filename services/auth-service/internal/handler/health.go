@@ -20,7 +20,7 @@ func NewHealthHandler(db *pgxpool.Pool, r *redisutil.Client) *HealthHandler {
 	return &HealthHandler{db: db, redis: r}
 }
 
-func (h *HealthHandler) Register(r *gin.Engine) {
+func (h *HealthHandler) Register(r gin.IRoutes) {
 	r.GET("/api/v1/health", h.health)
 }
 
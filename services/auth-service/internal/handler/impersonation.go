@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+const (
+	defaultImpersonationTTL = 10 * time.Minute
+	maxImpersonationTTL     = 15 * time.Minute
+)
+
+// ImpersonationHandler lets support staff act as another user of the same
+// tenant. The token carries the staff member in its act claim, is read-only
+// unless writes were granted, and has no refresh token.
+type ImpersonationHandler struct {
+	auth  *AuthHandler
+	authz permissionChecker
+}
+
+func NewImpersonationHandler(auth *AuthHandler, a permissionChecker) *ImpersonationHandler {
+	return &ImpersonationHandler{auth: auth, authz: a}
+}
+
+func (h *ImpersonationHandler) RegisterProtected(r *gin.RouterGroup, perm func(permission string) gin.HandlerFunc) {
+	r.POST("/api/v1/auth/impersonate", perm(authz.UserImpersonate), h.impersonate)
+}
+
+type impersonateReq struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Reason     string    `json:"reason"`
+	AllowWrite bool      `json:"allow_write"`
+	TTLSeconds int       `json:"ttl_seconds"`
+}
+
+func (h *ImpersonationHandler) impersonate(c *gin.Context) {
+	claims := claimsFrom(c)
+	var req impersonateReq
+	if err := c.BindJSON(&req); err != nil || req.UserID == uuid.Nil || strings.TrimSpace(req.Reason) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "user_id and reason are required")
+		return
+	}
+	ttl := defaultImpersonationTTL
+	if req.TTLSeconds > 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+	if ttl > maxImpersonationTTL {
+		ttl = maxImpersonationTTL
+	}
+	switch {
+	case claims.Impersonated():
+		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "already impersonating")
+		return
+	case req.UserID == claims.UserID:
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "cannot impersonate yourself")
+		return
+	}
+	if req.AllowWrite {
+		ok, err := h.authz.Allow(claims.UserID, claims.TenantID, authz.UserImpersonateWrite)
+		if err != nil {
+			httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal error", nil)
+			return
+		}
+		if !ok {
+			httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "write impersonation not permitted")
+			return
+		}
+	}
+	target, err := h.auth.repo.FindByID(c.Request.Context(), req.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			httputil.Error(c.Writer, http.StatusNotFound, "5002", "Resource Not Found", "user not found")
+			return
+		}
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	if target.TenantID != claims.TenantID {
+		httputil.Error(c.Writer, http.StatusNotFound, "5002", "Resource Not Found", "user not found")
+		return
+	}
+	if !target.IsActive {
+		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "inactive user")
+		return
+	}
+	// Staff must not borrow another impersonator's reach.
+	ok, err := h.authz.Allow(target.ID, target.TenantID, authz.UserImpersonate)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal error", nil)
+		return
+	}
+	if ok {
+		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "cannot impersonate another impersonator")
+		return
+	}
+	tc, err := h.auth.accessClaims(c, target)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	tc.Actor = &jwtutil.Actor{UserID: claims.UserID, AllowWrite: req.AllowWrite}
+	access, err := h.auth.signAccessFor(tc, ttl)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	_ = h.auth.auditRepo.Log(c.Request.Context(), claims.TenantID, &claims.UserID, "auth.impersonate.start", "user", &target.ID, map[string]any{
+		"reason":      strings.TrimSpace(req.Reason),
+		"allow_write": req.AllowWrite,
+		"ttl_seconds": int(ttl.Seconds()),
+	})
+	httputil.Success(c.Writer, map[string]any{
+		"access_token": access,
+		"expires_in":   int(ttl.Seconds()),
+		"user_id":      target.ID,
+		"allow_write":  req.AllowWrite,
+	})
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+func TestImpersonationHandler_Refusals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	staff := uuid.New()
+	var claims jwtutil.Claims
+	r := gin.New()
+	g := r.Group("/")
+	g.Use(func(c *gin.Context) { c.Set("claims", claims) })
+	auth := NewAuthHandler(nil, config.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	h := NewImpersonationHandler(auth, &fakeChecker{allowed: map[string]bool{}})
+	h.RegisterProtected(g, func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } })
+
+	cases := []struct {
+		name   string
+		claims jwtutil.Claims
+		body   string
+		code   int
+		want   string
+	}{
+		{"missing reason", jwtutil.Claims{UserID: staff, TenantID: "t1"}, `{"user_id":"` + uuid.NewString() + `"}`, http.StatusBadRequest, "reason are required"},
+		{"self", jwtutil.Claims{UserID: staff, TenantID: "t1"}, `{"user_id":"` + staff.String() + `","reason":"ticket 42"}`, http.StatusBadRequest, "yourself"},
+		{"nested", jwtutil.Claims{UserID: uuid.New(), TenantID: "t1", Actor: &jwtutil.Actor{UserID: staff}}, `{"user_id":"` + uuid.NewString() + `","reason":"ticket 42"}`, http.StatusForbidden, "already impersonating"},
+		{"write not granted", jwtutil.Claims{UserID: staff, TenantID: "t1"}, `{"user_id":"` + uuid.NewString() + `","reason":"ticket 42","allow_write":true}`, http.StatusForbidden, "write impersonation not permitted"},
+	}
+	for _, tc := range cases {
+		claims = tc.claims
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/impersonate", strings.NewReader(tc.body)))
+		if rr.Code != tc.code || !strings.Contains(rr.Body.String(), tc.want) {
+			t.Fatalf("%s: code=%d resp=%s", tc.name, rr.Code, rr.Body.String())
+		}
+	}
+}
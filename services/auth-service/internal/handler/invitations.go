@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+)
+
+// InvitationHandler activates invited accounts and re-sends their tokens.
+type InvitationHandler struct {
+	uc    usecase.Invitations
+	audit *repository.AuditRepo
+}
+
+func NewInvitationHandler(uc usecase.Invitations, audit *repository.AuditRepo) *InvitationHandler {
+	return &InvitationHandler{uc: uc, audit: audit}
+}
+
+func (h *InvitationHandler) Register(r gin.IRoutes) {
+	r.POST("/api/v1/auth/activate", h.activate)
+	r.POST("/api/v1/auth/activate/resend", h.requestResend)
+}
+
+func (h *InvitationHandler) RegisterProtected(r *gin.RouterGroup, perm func(permission string) gin.HandlerFunc) {
+	r.POST("/api/v1/users/:id/invitation", perm(authz.UserInvite), h.resend)
+}
+
+type activateReq struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+func (h *InvitationHandler) activate(c *gin.Context) {
+	var req activateReq
+	if err := c.BindJSON(&req); err != nil || strings.TrimSpace(req.Token) == "" || strings.TrimSpace(req.Password) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing token or password")
+		return
+	}
+	u, err := h.uc.Activate(c.Request.Context(), req.Token, strings.TrimSpace(req.Password))
+	if err != nil {
+		var v *usecase.PolicyViolation
+		switch {
+		case errors.Is(err, usecase.ErrInvitationInvalid):
+			httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+		case errors.As(err, &v):
+			httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", v.Reason)
+		default:
+			httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		}
+		return
+	}
+	_ = h.audit.Log(c.Request.Context(), u.TenantID, &u.ID, "auth.activate", "user", &u.ID, map[string]any{"success": true})
+	httputil.Success(c.Writer, map[string]any{
+		"id":        u.ID,
+		"tenant_id": u.TenantID,
+		"email":     u.Email,
+		"is_active": u.IsActive,
+	})
+}
+
+type resendInvitationReq struct {
+	TenantID string `json:"tenant_id"`
+	Email    string `json:"email"`
+}
+
+// requestResend lets an invited user ask for a new token. Like forgot-password
+// it answers the same way whether or not an invitation is pending.
+func (h *InvitationHandler) requestResend(c *gin.Context) {
+	var req resendInvitationReq
+	if err := c.BindJSON(&req); err != nil || strings.TrimSpace(req.TenantID) == "" || strings.TrimSpace(req.Email) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing tenant_id or email")
+		return
+	}
+	_ = h.uc.ResendByEmail(c.Request.Context(), strings.TrimSpace(req.TenantID), req.Email)
+	httputil.Success(c.Writer, map[string]any{"message": "if an invitation is pending, a new one has been sent"})
+}
+
+func (h *InvitationHandler) resend(c *gin.Context) {
+	claims := claimsFrom(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid id")
+		return
+	}
+	if err := h.uc.Resend(c.Request.Context(), claims.TenantID, id); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvitationNotPending):
+			httputil.Error(c.Writer, http.StatusNotFound, "5002", "Resource Not Found", err.Error())
+		case errors.Is(err, usecase.ErrInvitationThrottled):
+			httputil.Error(c.Writer, http.StatusTooManyRequests, "RATE_LIMITED", "Too many requests", err.Error())
+		default:
+			httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		}
+		return
+	}
+	_ = h.audit.Log(c.Request.Context(), claims.TenantID, &claims.UserID, "user.invitation.resend", "user", &id, nil)
+	httputil.Success(c.Writer, map[string]any{"sent": true})
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+type fakeInvitations struct {
+	usecase.Invitations
+	resent []string
+}
+
+func (f *fakeInvitations) Activate(ctx context.Context, token, password string) (*repository.User, error) {
+	switch {
+	case token != "good":
+		return nil, usecase.ErrInvitationInvalid
+	case len(password) < 8:
+		return nil, &usecase.PolicyViolation{Reason: "password must be at least 8 characters"}
+	}
+	return nil, nil
+}
+
+func (f *fakeInvitations) ResendByEmail(ctx context.Context, tenantID, email string) error {
+	f.resent = append(f.resent, tenantID+"/"+email)
+	return usecase.ErrInvitationNotPending
+}
+
+func (f *fakeInvitations) Resend(ctx context.Context, tenantID string, userID uuid.UUID) error {
+	return usecase.ErrInvitationNotPending
+}
+
+func TestInvitationHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fake := &fakeInvitations{}
+	r := gin.New()
+	h := NewInvitationHandler(fake, nil)
+	h.Register(r)
+	g := r.Group("/")
+	g.Use(func(c *gin.Context) {
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"})
+	})
+	allow := func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } }
+	h.RegisterProtected(g, allow)
+	// Must coexist with the bulk import route under /api/v1/users.
+	NewUserImportHandler(&fakeUserImport{}, nil).RegisterProtected(g, allow)
+
+	cases := []struct {
+		body string
+		code int
+		want string
+	}{
+		{`{"token":"","password":"x"}`, http.StatusBadRequest, "missing token or password"},
+		{`{"token":"bad","password":"Str0ng#Pass"}`, http.StatusBadRequest, "invalid or expired invitation"},
+		{`{"token":"good","password":"short"}`, http.StatusBadRequest, "at least 8 characters"},
+	}
+	for _, tc := range cases {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/activate", strings.NewReader(tc.body)))
+		if rr.Code != tc.code || !strings.Contains(rr.Body.String(), tc.want) {
+			t.Fatalf("body %s: code=%d resp=%s", tc.body, rr.Code, rr.Body.String())
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/activate/resend", strings.NewReader(`{"tenant_id":"t1","email":"nobody@school.id"}`)))
+	if rr.Code != http.StatusOK || len(fake.resent) != 1 {
+		t.Fatalf("public resend must not reveal pending state, code=%d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/users/"+uuid.NewString()+"/invitation", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("admin resend without pending invitation code=%d", rr.Code)
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+// JWKSHandler publishes the public keys access tokens are verified with. The
+// body is a bare RFC 7517 key set rather than the usual response envelope.
+type JWKSHandler struct {
+	keys *jwtutil.KeyRing
+}
+
+func NewJWKSHandler(keys *jwtutil.KeyRing) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+func (h *JWKSHandler) Register(r gin.IRoutes) {
+	r.GET(jwtutil.JWKSPath, h.jwks)
+}
+
+func (h *JWKSHandler) jwks(c *gin.Context) {
+	set := jwtutil.JWKS{Keys: []jwtutil.JWK{}}
+	if h.keys != nil {
+		set = h.keys.JWKS()
+	}
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, set)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+func TestJWKSHandler_PublishesVerifiableKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("key: %v", err)
+	}
+	sk := &jwtutil.SigningKey{ID: "k1", Alg: jwtutil.AlgEdDSA, Key: priv}
+	ring := jwtutil.NewKeyRing(sk)
+	r := gin.New()
+	NewJWKSHandler(ring).Register(r)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, jwtutil.JWKSPath, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("code=%d", rr.Code)
+	}
+	var set jwtutil.JWKS
+	if err := json.Unmarshal(rr.Body.Bytes(), &set); err != nil || len(set.Keys) != 1 || set.Keys[0].Kid != "k1" {
+		t.Fatalf("unexpected jwks %s", rr.Body.String())
+	}
+	token, _ := ring.GenerateAccessWith(time.Minute, jwtutil.Claims{UserID: uuid.New()}, "iss", "aud")
+	if err := jwtutil.ValidateWithKeySet(set.KeySet(), token, &jwtutil.Claims{}, "iss", "aud"); err != nil {
+		t.Fatalf("published key should verify token: %v", err)
+	}
+}
+
+func TestJWKSHandler_EmptyWithoutKeyRing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	NewJWKSHandler(nil).Register(r)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, jwtutil.JWKSPath, nil))
+	if rr.Code != http.StatusOK || rr.Body.String() != `{"keys":[]}` {
+		t.Fatalf("code=%d body=%s", rr.Code, rr.Body.String())
+	}
+}
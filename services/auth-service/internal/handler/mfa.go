@@ -0,0 +1,335 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+)
+
+const defaultMFAChallengeTTL = 5 * time.Minute
+
+// mfaChallenge is what a login that passed the password step may redeem for
+// tokens. Key is the lockout key of that login so MFA failures count against
+// the same loginfail:/lockout: counters.
+type mfaChallenge struct {
+	UserID          uuid.UUID `json:"user_id"`
+	Key             string    `json:"key"`
+	PasswordExpired bool      `json:"password_expired,omitempty"`
+}
+
+func mfaChallengeKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "mfa:challenge:" + hex.EncodeToString(sum[:])
+}
+
+func (h *AuthHandler) startMFAChallenge(c *gin.Context, u *repository.User, key string, passwordExpired bool) (string, error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b[:])
+	val, err := json.Marshal(mfaChallenge{UserID: u.ID, Key: key, PasswordExpired: passwordExpired})
+	if err != nil {
+		return "", err
+	}
+	ttl := h.cfg.MFAChallengeTTL
+	if ttl <= 0 {
+		ttl = defaultMFAChallengeTTL
+	}
+	if err := redisutil.Set(c.Request.Context(), h.redis.Raw(), mfaChallengeKey(token), string(val), ttl); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// loadMFAChallenge resolves the challenge token to an active user, writing the
+// error response when it cannot.
+func (h *AuthHandler) loadMFAChallenge(c *gin.Context, token string) (*mfaChallenge, *repository.User, bool) {
+	ctx := c.Request.Context()
+	token = strings.TrimSpace(token)
+	raw, err := redisutil.Get(ctx, h.redis.Raw(), mfaChallengeKey(token))
+	var ch mfaChallenge
+	if err != nil || json.Unmarshal([]byte(raw), &ch) != nil {
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "invalid or expired mfa token")
+		return nil, nil, false
+	}
+	if _, err := redisutil.Get(ctx, h.redis.Raw(), "lockout:"+ch.Key); err == nil {
+		_ = redisutil.Del(ctx, h.redis.Raw(), mfaChallengeKey(token))
+		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "account locked")
+		return nil, nil, false
+	}
+	u, err := h.repo.FindByID(ctx, ch.UserID)
+	if err != nil || !u.IsActive {
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "user not found or inactive")
+		return nil, nil, false
+	}
+	return &ch, u, true
+}
+
+type mfaChallengeReq struct {
+	MFAToken     string `json:"mfa_token"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// enrollMFAChallenge lets a user whose role requires MFA enrol before their
+// first full login.
+func (h *AuthHandler) enrollMFAChallenge(c *gin.Context) {
+	var req mfaChallengeReq
+	if err := c.BindJSON(&req); err != nil || strings.TrimSpace(req.MFAToken) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing mfa_token")
+		return
+	}
+	_, u, ok := h.loadMFAChallenge(c, req.MFAToken)
+	if !ok {
+		return
+	}
+	e, err := h.mfa.BeginEnroll(c.Request.Context(), u)
+	if err != nil {
+		mfaFail(c, err)
+		return
+	}
+	httputil.Success(c.Writer, map[string]any{"secret": e.Secret, "otpauth_uri": e.URI})
+}
+
+// verifyMFA completes a login started with a password. For a user still
+// enrolling, the code confirms the pending secret and recovery codes are
+// returned alongside the tokens.
+func (h *AuthHandler) verifyMFA(c *gin.Context) {
+	var req mfaChallengeReq
+	if err := c.BindJSON(&req); err != nil || strings.TrimSpace(req.MFAToken) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing mfa_token")
+		return
+	}
+	if strings.TrimSpace(req.Code) == "" && strings.TrimSpace(req.RecoveryCode) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing code or recovery_code")
+		return
+	}
+	ch, u, ok := h.loadMFAChallenge(c, req.MFAToken)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	var recoveryCodes []string
+	err := h.mfa.Verify(ctx, u.ID, req.Code, req.RecoveryCode)
+	if errors.Is(err, usecase.ErrMFANotEnrolled) && strings.TrimSpace(req.Code) != "" {
+		recoveryCodes, err = h.mfa.ConfirmEnroll(ctx, u.ID, req.Code)
+	}
+	if errors.Is(err, usecase.ErrInvalidMFACode) {
+		h.loginFailed(c, ch.Key, u, "auth.mfa.verify", "invalid mfa code")
+		return
+	}
+	if err != nil {
+		mfaFail(c, err)
+		return
+	}
+	_ = redisutil.Del(ctx, h.redis.Raw(), mfaChallengeKey(strings.TrimSpace(req.MFAToken)))
+	var extra map[string]any
+	if recoveryCodes != nil {
+		extra = map[string]any{"recovery_codes": recoveryCodes}
+	}
+	h.loginVerified(c, u, ch.Key, ch.PasswordExpired, map[string]any{"success": true, "mfa": true, "recovery_code": strings.TrimSpace(req.RecoveryCode) != ""}, extra)
+}
+
+func mfaFail(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidMFACode):
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", err.Error())
+	case errors.Is(err, usecase.ErrMFANotEnrolled), errors.Is(err, usecase.ErrMFAAlreadyEnabled):
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+	case errors.Is(err, usecase.ErrUserNotFound):
+		httputil.Error(c.Writer, http.StatusNotFound, "5002", "Resource Not Found", err.Error())
+	default:
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+	}
+}
+
+// MFAHandler serves self-service enrolment for signed-in users and the
+// tenant policy that forces MFA for selected roles.
+type MFAHandler struct {
+	uc    usecase.MFA
+	users *repository.UsersRepo
+	audit *repository.AuditRepo
+}
+
+func NewMFAHandler(uc usecase.MFA, users *repository.UsersRepo, audit *repository.AuditRepo) *MFAHandler {
+	return &MFAHandler{uc: uc, users: users, audit: audit}
+}
+
+func (h *MFAHandler) RegisterProtected(r *gin.RouterGroup, perm func(permission string) gin.HandlerFunc) {
+	r.GET("/api/v1/auth/mfa", h.status)
+	r.POST("/api/v1/auth/mfa/enroll", h.enroll)
+	r.POST("/api/v1/auth/mfa/enroll/confirm", h.confirm)
+	r.POST("/api/v1/auth/mfa/recovery-codes", h.regenerate)
+	r.POST("/api/v1/auth/mfa/disable", h.disable)
+	r.GET("/api/v1/auth/mfa/policy", perm(authz.MFAPolicyRead), h.getPolicy)
+	r.PUT("/api/v1/auth/mfa/policy", perm(authz.MFAPolicyWrite), h.setPolicy)
+	r.DELETE("/api/v1/users/:id/mfa", perm(authz.MFAReset), h.reset)
+}
+
+type mfaCodeReq struct {
+	Code string `json:"code"`
+}
+
+func (h *MFAHandler) currentUser(c *gin.Context) (*repository.User, bool) {
+	claims := claimsFrom(c)
+	u, err := h.users.FindByID(c.Request.Context(), claims.UserID)
+	if err != nil || !u.IsActive {
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "user not found or inactive")
+		return nil, false
+	}
+	return u, true
+}
+
+func (h *MFAHandler) log(ctx context.Context, u *repository.User, action string, values any) {
+	_ = h.audit.Log(ctx, u.TenantID, &u.ID, action, "user", &u.ID, values)
+}
+
+func (h *MFAHandler) status(c *gin.Context) {
+	u, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	st, err := h.uc.Status(c.Request.Context(), u)
+	if err != nil {
+		mfaFail(c, err)
+		return
+	}
+	httputil.Success(c.Writer, map[string]any{
+		"enabled":                  st.Enabled,
+		"required":                 st.Required,
+		"recovery_codes_remaining": st.RecoveryCodes,
+	})
+}
+
+func (h *MFAHandler) enroll(c *gin.Context) {
+	u, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	e, err := h.uc.BeginEnroll(c.Request.Context(), u)
+	if err != nil {
+		mfaFail(c, err)
+		return
+	}
+	httputil.Success(c.Writer, map[string]any{"secret": e.Secret, "otpauth_uri": e.URI})
+}
+
+func (h *MFAHandler) confirm(c *gin.Context) {
+	var req mfaCodeReq
+	if err := c.BindJSON(&req); err != nil || strings.TrimSpace(req.Code) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing code")
+		return
+	}
+	u, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	codes, err := h.uc.ConfirmEnroll(c.Request.Context(), u.ID, req.Code)
+	if err != nil {
+		mfaFail(c, err)
+		return
+	}
+	h.log(c.Request.Context(), u, "auth.mfa.enable", map[string]any{"success": true})
+	httputil.Success(c.Writer, map[string]any{"recovery_codes": codes})
+}
+
+func (h *MFAHandler) regenerate(c *gin.Context) {
+	var req mfaCodeReq
+	if err := c.BindJSON(&req); err != nil || strings.TrimSpace(req.Code) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing code")
+		return
+	}
+	u, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	codes, err := h.uc.RegenerateRecoveryCodes(c.Request.Context(), u.ID, req.Code)
+	if err != nil {
+		mfaFail(c, err)
+		return
+	}
+	h.log(c.Request.Context(), u, "auth.mfa.recovery_codes", map[string]any{"success": true})
+	httputil.Success(c.Writer, map[string]any{"recovery_codes": codes})
+}
+
+func (h *MFAHandler) disable(c *gin.Context) {
+	var req mfaCodeReq
+	if err := c.BindJSON(&req); err != nil || strings.TrimSpace(req.Code) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing code")
+		return
+	}
+	u, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	if err := h.uc.Disable(c.Request.Context(), u.ID, req.Code); err != nil {
+		mfaFail(c, err)
+		return
+	}
+	h.log(c.Request.Context(), u, "auth.mfa.disable", map[string]any{"success": true})
+	httputil.Success(c.Writer, map[string]any{"enabled": false})
+}
+
+func (h *MFAHandler) getPolicy(c *gin.Context) {
+	claims := claimsFrom(c)
+	roles, err := h.uc.Policy(c.Request.Context(), claims.TenantID)
+	if err != nil {
+		mfaFail(c, err)
+		return
+	}
+	httputil.Success(c.Writer, map[string]any{"roles": roles})
+}
+
+type mfaPolicyReq struct {
+	Roles []string `json:"roles"`
+}
+
+func (h *MFAHandler) setPolicy(c *gin.Context) {
+	var req mfaPolicyReq
+	if err := c.BindJSON(&req); err != nil || req.Roles == nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing roles")
+		return
+	}
+	claims := claimsFrom(c)
+	if err := h.uc.SetPolicy(c.Request.Context(), claims.TenantID, req.Roles); err != nil {
+		mfaFail(c, err)
+		return
+	}
+	roles, err := h.uc.Policy(c.Request.Context(), claims.TenantID)
+	if err != nil {
+		mfaFail(c, err)
+		return
+	}
+	_ = h.audit.Log(c.Request.Context(), claims.TenantID, &claims.UserID, "auth.mfa.policy", "mfa_policy", nil, map[string]any{"roles": roles})
+	httputil.Success(c.Writer, map[string]any{"roles": roles})
+}
+
+func (h *MFAHandler) reset(c *gin.Context) {
+	claims := claimsFrom(c)
+	uid, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid user id")
+		return
+	}
+	if err := h.uc.Reset(c.Request.Context(), claims.TenantID, uid); err != nil {
+		mfaFail(c, err)
+		return
+	}
+	_ = h.audit.Log(c.Request.Context(), claims.TenantID, &claims.UserID, "auth.mfa.reset", "user", &uid, map[string]any{"success": true})
+	httputil.Success(c.Writer, map[string]any{"enabled": false})
+}
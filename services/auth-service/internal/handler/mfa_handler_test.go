@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+type fakeMFA struct {
+	policy map[string][]string
+}
+
+func (f *fakeMFA) Status(ctx context.Context, u *repository.User) (usecase.MFAStatus, error) {
+	return usecase.MFAStatus{}, nil
+}
+
+func (f *fakeMFA) BeginEnroll(ctx context.Context, u *repository.User) (*usecase.Enrollment, error) {
+	return nil, usecase.ErrMFAAlreadyEnabled
+}
+
+func (f *fakeMFA) ConfirmEnroll(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	return nil, usecase.ErrInvalidMFACode
+}
+
+func (f *fakeMFA) Verify(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error {
+	return usecase.ErrInvalidMFACode
+}
+
+func (f *fakeMFA) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	return nil, usecase.ErrInvalidMFACode
+}
+
+func (f *fakeMFA) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	return usecase.ErrInvalidMFACode
+}
+
+func (f *fakeMFA) Reset(ctx context.Context, tenantID string, userID uuid.UUID) error {
+	return usecase.ErrUserNotFound
+}
+
+func (f *fakeMFA) Policy(ctx context.Context, tenantID string) ([]string, error) {
+	return f.policy[tenantID], nil
+}
+
+func (f *fakeMFA) SetPolicy(ctx context.Context, tenantID string, roles []string) error {
+	f.policy[tenantID] = roles
+	return nil
+}
+
+func TestMFAHandler_PolicyAndReset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fake := &fakeMFA{policy: map[string][]string{"t1": {"admin", "finance"}}}
+	r := gin.New()
+	g := r.Group("/")
+	g.Use(func(c *gin.Context) {
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"})
+	})
+	NewMFAHandler(fake, nil, nil).RegisterProtected(g, func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } })
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/auth/mfa/policy", nil))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"finance"`) {
+		t.Fatalf("get policy code=%d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/api/v1/auth/mfa/policy", strings.NewReader(`{}`)))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("missing roles code=%d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/api/v1/users/not-a-uuid/mfa", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("invalid id code=%d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/api/v1/users/"+uuid.NewString()+"/mfa", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("unknown user code=%d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/mfa/enroll/confirm", strings.NewReader(`{}`)))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("missing code code=%d", rr.Code)
+	}
+}
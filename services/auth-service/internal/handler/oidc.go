@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/oidc"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+)
+
+func (h *AuthHandler) oidcAuthorize(c *gin.Context) {
+	tenantID := strings.TrimSpace(c.Query("tenant_id"))
+	if tenantID == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing tenant_id")
+		return
+	}
+	req, err := h.oidc.Begin(c.Request.Context(), tenantID, c.Param("provider"))
+	if err != nil {
+		oidcFail(c, err)
+		return
+	}
+	httputil.Success(c.Writer, map[string]any{
+		"authorization_url": req.URL,
+		"state":             req.State,
+	})
+}
+
+type oidcCallbackReq struct {
+	Code             string `form:"code" json:"code"`
+	State            string `form:"state" json:"state"`
+	Error            string `form:"error" json:"error"`
+	ErrorDescription string `form:"error_description" json:"error_description"`
+}
+
+// oidcCallback finishes the authorization-code flow. Providers redirect here
+// with GET; a front end that captured the redirect may POST the same fields.
+func (h *AuthHandler) oidcCallback(c *gin.Context) {
+	var req oidcCallbackReq
+	if err := c.ShouldBind(&req); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid callback")
+		return
+	}
+	if req.Error != "" {
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", map[string]any{"error": req.Error, "error_description": req.ErrorDescription})
+		return
+	}
+	if strings.TrimSpace(req.Code) == "" || strings.TrimSpace(req.State) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing code or state")
+		return
+	}
+	u, err := h.oidc.Complete(c.Request.Context(), req.State, req.Code)
+	if err != nil {
+		oidcFail(c, err)
+		return
+	}
+	key := lockoutKey(u.TenantID, u.Email)
+	if _, err := redisutil.Get(c.Request.Context(), h.redis.Raw(), "lockout:"+key); err == nil {
+		_ = h.auditRepo.Log(c.Request.Context(), u.TenantID, &u.ID, "auth.login", "user", &u.ID, map[string]any{"success": false, "method": "oidc", "reason": "locked"})
+		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "account locked")
+		return
+	}
+	if !u.IsActive {
+		_ = h.auditRepo.Log(c.Request.Context(), u.TenantID, &u.ID, "auth.login", "user", &u.ID, map[string]any{"success": false, "method": "oidc", "reason": "inactive"})
+		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "inactive user")
+		return
+	}
+	h.firstFactorPassed(c, u, key, false, map[string]any{"success": true, "method": "oidc"})
+}
+
+func oidcFail(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrOIDCProviderNotFound):
+		httputil.Error(c.Writer, http.StatusNotFound, "5002", "Resource Not Found", err.Error())
+	case errors.Is(err, usecase.ErrOIDCInvalidState), errors.Is(err, oidc.ErrInvalidIDToken), errors.Is(err, oidc.ErrNonceMismatch):
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", err.Error())
+	case errors.Is(err, usecase.ErrOIDCEmailNotAllowed), errors.Is(err, usecase.ErrOIDCNotProvisioned):
+		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", err.Error())
+	default:
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+	}
+}
+
+// OIDCHandler lets tenant administrators manage their identity providers.
+type OIDCHandler struct {
+	uc    usecase.OIDC
+	audit *repository.AuditRepo
+}
+
+func NewOIDCHandler(uc usecase.OIDC, audit *repository.AuditRepo) *OIDCHandler {
+	return &OIDCHandler{uc: uc, audit: audit}
+}
+
+func (h *OIDCHandler) RegisterProtected(r *gin.RouterGroup, perm func(permission string) gin.HandlerFunc) {
+	r.GET("/api/v1/auth/oidc/providers", perm(authz.OIDCProviderRead), h.list)
+	r.PUT("/api/v1/auth/oidc/providers/:name", perm(authz.OIDCProviderWrite), h.save)
+	r.DELETE("/api/v1/auth/oidc/providers/:name", perm(authz.OIDCProviderWrite), h.delete)
+}
+
+type oidcProviderReq struct {
+	Issuer          string            `json:"issuer"`
+	ClientID        string            `json:"client_id"`
+	ClientSecret    string            `json:"client_secret"`
+	Scopes          []string          `json:"scopes"`
+	AllowedDomains  []string          `json:"allowed_domains"`
+	GroupsClaim     string            `json:"groups_claim"`
+	GroupRoles      map[string]string `json:"group_roles"`
+	DefaultRole     string            `json:"default_role"`
+	JITProvisioning bool              `json:"jit_provisioning"`
+	Enabled         *bool             `json:"enabled"`
+}
+
+func (h *OIDCHandler) fail(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrOIDCProviderNotFound):
+		httputil.Error(c.Writer, http.StatusNotFound, "5002", "Resource Not Found", err.Error())
+	default:
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+	}
+}
+
+func (h *OIDCHandler) list(c *gin.Context) {
+	claims := claimsFrom(c)
+	items, err := h.uc.Providers(c.Request.Context(), claims.TenantID)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	httputil.Success(c.Writer, map[string]any{"items": items})
+}
+
+func (h *OIDCHandler) save(c *gin.Context) {
+	claims := claimsFrom(c)
+	var req oidcProviderReq
+	if err := c.BindJSON(&req); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid json")
+		return
+	}
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	var before any
+	if existing, err := h.uc.Providers(c.Request.Context(), claims.TenantID); err == nil {
+		for i := range existing {
+			if existing[i].Name == c.Param("name") {
+				before = existing[i]
+			}
+		}
+	}
+	p, err := h.uc.SaveProvider(c.Request.Context(), &repository.OIDCProvider{
+		TenantID:        claims.TenantID,
+		Name:            c.Param("name"),
+		Issuer:          req.Issuer,
+		ClientID:        req.ClientID,
+		ClientSecret:    req.ClientSecret,
+		Scopes:          req.Scopes,
+		AllowedDomains:  req.AllowedDomains,
+		GroupsClaim:     req.GroupsClaim,
+		GroupRoles:      req.GroupRoles,
+		DefaultRole:     req.DefaultRole,
+		JITProvisioning: req.JITProvisioning,
+		Enabled:         enabled,
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	_ = h.audit.LogChange(c.Request.Context(), claims.TenantID, &claims.UserID, "auth.oidc.provider.save", "oidc_provider", &p.ID, before, p)
+	httputil.Success(c.Writer, p)
+}
+
+func (h *OIDCHandler) delete(c *gin.Context) {
+	claims := claimsFrom(c)
+	name := c.Param("name")
+	if err := h.uc.DeleteProvider(c.Request.Context(), claims.TenantID, name); err != nil {
+		h.fail(c, err)
+		return
+	}
+	_ = h.audit.Log(c.Request.Context(), claims.TenantID, &claims.UserID, "auth.oidc.provider.delete", "oidc_provider", nil, map[string]any{"name": name})
+	httputil.Success(c.Writer, map[string]any{"deleted": true})
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+type fakeOIDC struct {
+	providers map[string][]repository.OIDCProvider
+}
+
+func (f *fakeOIDC) Providers(ctx context.Context, tenantID string) ([]repository.OIDCProvider, error) {
+	return f.providers[tenantID], nil
+}
+
+func (f *fakeOIDC) SaveProvider(ctx context.Context, p *repository.OIDCProvider) (*repository.OIDCProvider, error) {
+	return nil, repository.ErrValidation("issuer must be an absolute https url")
+}
+
+func (f *fakeOIDC) DeleteProvider(ctx context.Context, tenantID, name string) error {
+	return usecase.ErrOIDCProviderNotFound
+}
+
+func (f *fakeOIDC) Begin(ctx context.Context, tenantID, provider string) (*usecase.OIDCAuthRequest, error) {
+	if provider != "google" {
+		return nil, usecase.ErrOIDCProviderNotFound
+	}
+	return &usecase.OIDCAuthRequest{URL: "https://accounts.example/authorize?state=s1", State: "s1"}, nil
+}
+
+func (f *fakeOIDC) Complete(ctx context.Context, state, code string) (*repository.User, error) {
+	return nil, usecase.ErrOIDCInvalidState
+}
+
+func TestOIDCHandler_Providers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fake := &fakeOIDC{providers: map[string][]repository.OIDCProvider{
+		"t1": {{Name: "google", Issuer: "https://accounts.google.com", ClientSecret: "hidden"}},
+	}}
+	r := gin.New()
+	g := r.Group("/")
+	g.Use(func(c *gin.Context) {
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"})
+	})
+	NewOIDCHandler(fake, nil).RegisterProtected(g, func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } })
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/providers", nil))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"google"`) || strings.Contains(rr.Body.String(), "hidden") {
+		t.Fatalf("list code=%d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/api/v1/auth/oidc/providers/google", strings.NewReader(`{"issuer":"nope"}`)))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("invalid provider code=%d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/api/v1/auth/oidc/providers/azure", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("delete missing code=%d", rr.Code)
+	}
+}
+
+func TestAuthHandler_OIDCAuthorizeAndCallback(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	(&AuthHandler{oidc: &fakeOIDC{}}).Register(r)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/google/authorize", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("missing tenant code=%d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/google/authorize?tenant_id=t1", nil))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"authorization_url"`) {
+		t.Fatalf("authorize code=%d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/azure/authorize?tenant_id=t1", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("unknown provider code=%d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?error=access_denied&state=s1", nil))
+	if rr.Code != http.StatusUnauthorized || !strings.Contains(rr.Body.String(), "access_denied") {
+		t.Fatalf("idp error code=%d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?code=c1&state=stale", nil))
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("stale state code=%d", rr.Code)
+	}
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+)
+
+const passwordChangeTTL = 10 * time.Minute
+
+// passwordChange lets a user whose password has expired set a new one. It is
+// only issued once every login factor has been verified.
+type passwordChange struct {
+	UserID uuid.UUID `json:"user_id"`
+	Key    string    `json:"key"`
+}
+
+func passwordChangeKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "password:change:" + hex.EncodeToString(sum[:])
+}
+
+func (h *AuthHandler) startPasswordChange(c *gin.Context, u *repository.User, key string) (string, error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b[:])
+	val, err := json.Marshal(passwordChange{UserID: u.ID, Key: key})
+	if err != nil {
+		return "", err
+	}
+	if err := redisutil.Set(c.Request.Context(), h.redis.Raw(), passwordChangeKey(token), string(val), passwordChangeTTL); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+type expiredPasswordReq struct {
+	PasswordChangeToken string `json:"password_change_token"`
+	NewPassword         string `json:"new_password"`
+}
+
+// changeExpiredPassword replaces an expired password and completes the login
+// that was held back.
+func (h *AuthHandler) changeExpiredPassword(c *gin.Context) {
+	var req expiredPasswordReq
+	if err := c.BindJSON(&req); err != nil || strings.TrimSpace(req.PasswordChangeToken) == "" || strings.TrimSpace(req.NewPassword) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing password_change_token or new_password")
+		return
+	}
+	ctx := c.Request.Context()
+	key := passwordChangeKey(strings.TrimSpace(req.PasswordChangeToken))
+	raw, err := redisutil.Get(ctx, h.redis.Raw(), key)
+	var pc passwordChange
+	if err != nil || json.Unmarshal([]byte(raw), &pc) != nil {
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "invalid or expired password change token")
+		return
+	}
+	u, err := h.repo.FindByID(ctx, pc.UserID)
+	if err != nil || !u.IsActive {
+		httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "user not found or inactive")
+		return
+	}
+	if !h.setPassword(c, u, strings.TrimSpace(req.NewPassword)) {
+		return
+	}
+	_ = redisutil.Del(ctx, h.redis.Raw(), key)
+	_ = h.auditRepo.Log(ctx, u.TenantID, &u.ID, "auth.change_password", "user", &u.ID, map[string]any{"success": true, "expired": true})
+	h.completeLogin(c, u, pc.Key, map[string]any{"success": true, "password_rotated": true}, nil)
+}
+
+// PasswordPolicyHandler lets tenant administrators manage password and
+// lockout rules.
+type PasswordPolicyHandler struct {
+	uc    usecase.PasswordPolicies
+	audit *repository.AuditRepo
+}
+
+func NewPasswordPolicyHandler(uc usecase.PasswordPolicies, audit *repository.AuditRepo) *PasswordPolicyHandler {
+	return &PasswordPolicyHandler{uc: uc, audit: audit}
+}
+
+func (h *PasswordPolicyHandler) RegisterProtected(r *gin.RouterGroup, perm func(permission string) gin.HandlerFunc) {
+	r.GET("/api/v1/auth/password-policy", perm(authz.PasswordPolicyRead), h.get)
+	r.PUT("/api/v1/auth/password-policy", perm(authz.PasswordPolicyWrite), h.set)
+	r.POST("/api/v1/auth/password-policy/rotate", perm(authz.PasswordPolicyWrite), h.rotate)
+}
+
+type passwordPolicyReq struct {
+	MinLength        *int  `json:"min_length"`
+	RequireUpper     *bool `json:"require_upper"`
+	RequireLower     *bool `json:"require_lower"`
+	RequireDigit     *bool `json:"require_digit"`
+	RequireSymbol    *bool `json:"require_symbol"`
+	HistoryDepth     *int  `json:"history_depth"`
+	MaxAgeDays       *int  `json:"max_age_days"`
+	LockoutThreshold *int  `json:"lockout_threshold"`
+	LockoutSeconds   *int  `json:"lockout_seconds"`
+}
+
+func (h *PasswordPolicyHandler) get(c *gin.Context) {
+	claims := claimsFrom(c)
+	pol, err := h.uc.Get(c.Request.Context(), claims.TenantID)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	httputil.Success(c.Writer, pol)
+}
+
+// set updates the fields present in the request and keeps the rest.
+func (h *PasswordPolicyHandler) set(c *gin.Context) {
+	claims := claimsFrom(c)
+	var req passwordPolicyReq
+	if err := c.BindJSON(&req); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid json")
+		return
+	}
+	pol, err := h.uc.Get(c.Request.Context(), claims.TenantID)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	before := *pol
+	setInt := func(dst *int, v *int) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	setBool := func(dst *bool, v *bool) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	setInt(&pol.MinLength, req.MinLength)
+	setBool(&pol.RequireUpper, req.RequireUpper)
+	setBool(&pol.RequireLower, req.RequireLower)
+	setBool(&pol.RequireDigit, req.RequireDigit)
+	setBool(&pol.RequireSymbol, req.RequireSymbol)
+	setInt(&pol.HistoryDepth, req.HistoryDepth)
+	setInt(&pol.MaxAgeDays, req.MaxAgeDays)
+	setInt(&pol.LockoutThreshold, req.LockoutThreshold)
+	setInt(&pol.LockoutSeconds, req.LockoutSeconds)
+	saved, err := h.uc.Set(c.Request.Context(), pol)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+		return
+	}
+	_ = h.audit.LogChange(c.Request.Context(), claims.TenantID, &claims.UserID, "auth.password_policy.update", "password_policy", nil, before, saved)
+	httputil.Success(c.Writer, saved)
+}
+
+// rotate expires every password in the tenant that was set before now.
+func (h *PasswordPolicyHandler) rotate(c *gin.Context) {
+	claims := claimsFrom(c)
+	saved, err := h.uc.ForceRotation(c.Request.Context(), claims.TenantID, time.Now())
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	_ = h.audit.Log(c.Request.Context(), claims.TenantID, &claims.UserID, "auth.password_policy.rotate", "password_policy", nil, map[string]any{"rotate_before": saved.RotateBefore})
+	httputil.Success(c.Writer, saved)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+type fakePasswordPolicies struct {
+	usecase.PasswordPolicies
+	saved *repository.PasswordPolicy
+}
+
+func (f *fakePasswordPolicies) Get(ctx context.Context, tenantID string) (*repository.PasswordPolicy, error) {
+	pol := usecase.DefaultPasswordPolicy(5, time.Minute)
+	pol.TenantID = tenantID
+	return &pol, nil
+}
+
+func (f *fakePasswordPolicies) Set(ctx context.Context, p *repository.PasswordPolicy) (*repository.PasswordPolicy, error) {
+	if p.MinLength < 8 {
+		return nil, repository.ErrValidation("min_length must be between 8 and 128")
+	}
+	f.saved = p
+	return p, nil
+}
+
+func TestPasswordPolicyHandler_Update(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fake := &fakePasswordPolicies{}
+	r := gin.New()
+	g := r.Group("/")
+	g.Use(func(c *gin.Context) {
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"})
+	})
+	NewPasswordPolicyHandler(fake, nil).RegisterProtected(g, func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } })
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/auth/password-policy", nil))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"lockout_threshold":5`) {
+		t.Fatalf("get code=%d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/api/v1/auth/password-policy", strings.NewReader(`{"min_length":4}`)))
+	if rr.Code != http.StatusBadRequest || fake.saved != nil {
+		t.Fatalf("invalid policy code=%d", rr.Code)
+	}
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+type RoleAdminHandler struct {
+	uc    usecase.RoleAdmin
+	audit *repository.AuditRepo
+}
+
+func NewRoleAdminHandler(uc usecase.RoleAdmin, audit *repository.AuditRepo) *RoleAdminHandler {
+	return &RoleAdminHandler{uc: uc, audit: audit}
+}
+
+func (h *RoleAdminHandler) RegisterProtected(r *gin.RouterGroup, perm func(permission string) gin.HandlerFunc) {
+	r.POST("/api/v1/roles", perm(authz.RoleWrite), h.create)
+	r.GET("/api/v1/roles", perm(authz.RoleRead), h.list)
+	r.GET("/api/v1/roles/:id", perm(authz.RoleRead), h.get)
+	r.PUT("/api/v1/roles/:id", perm(authz.RoleWrite), h.update)
+	r.DELETE("/api/v1/roles/:id", perm(authz.RoleDelete), h.delete)
+	r.GET("/api/v1/permissions", perm(authz.RoleRead), h.listPermissions)
+	r.POST("/api/v1/roles/:id/permissions", perm(authz.RoleWrite), h.grant)
+	r.DELETE("/api/v1/roles/:id/permissions/:permission_id", perm(authz.RoleWrite), h.revoke)
+	r.GET("/api/v1/users/:id/permissions", perm(authz.RoleRead), h.userPermissions)
+}
+
+type roleReq struct {
+	Name string `json:"name"`
+}
+
+type grantReq struct {
+	Permissions []string `json:"permissions"`
+}
+
+func claimsFrom(c *gin.Context) jwtutil.Claims {
+	val, _ := c.Get("claims")
+	claims, _ := val.(jwtutil.Claims)
+	return claims
+}
+
+func roleView(r *repository.Role) map[string]any {
+	return map[string]any{
+		"id":             r.ID,
+		"name":           r.Name,
+		"tenant_id":      r.TenantID,
+		"is_system_role": r.IsSystemRole,
+	}
+}
+
+func permissionViews(perms []repository.Permission) []map[string]any {
+	items := make([]map[string]any, 0, len(perms))
+	for _, p := range perms {
+		items = append(items, map[string]any{
+			"id":         p.ID,
+			"resource":   p.Resource,
+			"action":     p.Action,
+			"permission": p.Resource + ":" + p.Action,
+		})
+	}
+	return items
+}
+
+func (h *RoleAdminHandler) fail(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrRoleNotFound), errors.Is(err, usecase.ErrPermissionNotFound):
+		httputil.Error(c.Writer, http.StatusNotFound, "5002", "Resource Not Found", err.Error())
+	case errors.Is(err, usecase.ErrSystemRole):
+		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", err.Error())
+	default:
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+	}
+}
+
+func (h *RoleAdminHandler) log(c *gin.Context, claims jwtutil.Claims, action string, roleID uuid.UUID, values any) {
+	_ = h.audit.Log(c.Request.Context(), claims.TenantID, &claims.UserID, action, "role", &roleID, values)
+}
+
+func (h *RoleAdminHandler) create(c *gin.Context) {
+	claims := claimsFrom(c)
+	var in roleReq
+	if err := c.BindJSON(&in); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid json")
+		return
+	}
+	role, err := h.uc.Create(c.Request.Context(), claims.TenantID, in.Name)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.log(c, claims, "role.create", role.ID, map[string]any{"name": role.Name})
+	httputil.Success(c.Writer, roleView(role))
+}
+
+func (h *RoleAdminHandler) list(c *gin.Context) {
+	claims := claimsFrom(c)
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	roles, err := h.uc.List(c.Request.Context(), claims.TenantID, limit, offset)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	items := make([]map[string]any, 0, len(roles))
+	for i := range roles {
+		items = append(items, roleView(&roles[i]))
+	}
+	httputil.Success(c.Writer, map[string]any{"items": items})
+}
+
+func (h *RoleAdminHandler) get(c *gin.Context) {
+	claims := claimsFrom(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid role id")
+		return
+	}
+	role, perms, err := h.uc.Get(c.Request.Context(), claims.TenantID, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	out := roleView(role)
+	out["permissions"] = permissionViews(perms)
+	httputil.Success(c.Writer, out)
+}
+
+func (h *RoleAdminHandler) update(c *gin.Context) {
+	claims := claimsFrom(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid role id")
+		return
+	}
+	var in roleReq
+	if err := c.BindJSON(&in); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid json")
+		return
+	}
+	prev, _, err := h.uc.Get(c.Request.Context(), claims.TenantID, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	role, err := h.uc.Rename(c.Request.Context(), claims.TenantID, id, in.Name)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	_ = h.audit.LogChange(c.Request.Context(), claims.TenantID, &claims.UserID, "role.update", "role", &role.ID, map[string]any{"name": prev.Name}, map[string]any{"name": role.Name})
+	httputil.Success(c.Writer, roleView(role))
+}
+
+func (h *RoleAdminHandler) delete(c *gin.Context) {
+	claims := claimsFrom(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid role id")
+		return
+	}
+	role, err := h.uc.Delete(c.Request.Context(), claims.TenantID, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.log(c, claims, "role.delete", role.ID, map[string]any{"name": role.Name})
+	httputil.Success(c.Writer, map[string]any{"deleted": true})
+}
+
+func (h *RoleAdminHandler) listPermissions(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	perms, err := h.uc.ListPermissions(c.Request.Context(), limit, offset)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	httputil.Success(c.Writer, map[string]any{"items": permissionViews(perms)})
+}
+
+func (h *RoleAdminHandler) grant(c *gin.Context) {
+	claims := claimsFrom(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid role id")
+		return
+	}
+	var in grantReq
+	if err := c.BindJSON(&in); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid json")
+		return
+	}
+	perms, err := h.uc.Grant(c.Request.Context(), claims.TenantID, id, in.Permissions)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	names := make([]string, 0, len(perms))
+	for _, p := range perms {
+		names = append(names, p.Resource+":"+p.Action)
+	}
+	h.log(c, claims, "role.permission.grant", id, map[string]any{"permissions": names})
+	httputil.Success(c.Writer, map[string]any{"items": permissionViews(perms)})
+}
+
+func (h *RoleAdminHandler) revoke(c *gin.Context) {
+	claims := claimsFrom(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid role id")
+		return
+	}
+	pid, err := uuid.Parse(c.Param("permission_id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid permission id")
+		return
+	}
+	perm, err := h.uc.Revoke(c.Request.Context(), claims.TenantID, id, pid)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.log(c, claims, "role.permission.revoke", id, map[string]any{"permission": perm.Resource + ":" + perm.Action})
+	httputil.Success(c.Writer, map[string]any{"deleted": true})
+}
+
+func (h *RoleAdminHandler) userPermissions(c *gin.Context) {
+	claims := claimsFrom(c)
+	uid, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid user id")
+		return
+	}
+	perms, err := h.uc.EffectivePermissions(c.Request.Context(), claims.TenantID, uid)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	httputil.Success(c.Writer, map[string]any{"items": permissionViews(perms)})
+}
@@ -1,37 +1,53 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
 	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
 	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 )
 
 type RolesHandler struct {
-	uc usecase.Roles
+	uc    usecase.Roles
+	audit *repository.AuditRepo
 }
 
-func NewRolesHandler(uc usecase.Roles) *RolesHandler {
-	return &RolesHandler{uc: uc}
+func NewRolesHandler(uc usecase.Roles, audit *repository.AuditRepo) *RolesHandler {
+	return &RolesHandler{uc: uc, audit: audit}
 }
 
-func (h *RolesHandler) RegisterProtected(r *gin.RouterGroup) {
-	r.POST("/api/v1/users/:id/roles", h.assign)
-	r.GET("/api/v1/users/:id/roles", h.list)
-	r.DELETE("/api/v1/users/:id/roles/:role_id", h.unassign)
+func (h *RolesHandler) RegisterProtected(r *gin.RouterGroup, perm func(permission string) gin.HandlerFunc) {
+	r.POST("/api/v1/users/:id/roles", perm(authz.UserRoleAssign), h.assign)
+	r.GET("/api/v1/users/:id/roles", perm(authz.RoleRead), h.list)
+	r.DELETE("/api/v1/users/:id/roles/:role_id", perm(authz.UserRoleAssign), h.unassign)
 }
 
 type assignReq struct {
 	RoleName string `json:"role_name"`
 }
 
+func (h *RolesHandler) fail(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound), errors.Is(err, usecase.ErrRoleNotFound):
+		httputil.Error(c.Writer, http.StatusNotFound, "5002", "Resource Not Found", err.Error())
+	default:
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+	}
+}
+
+func (h *RolesHandler) log(c *gin.Context, claims jwtutil.Claims, action string, userID uuid.UUID, role *repository.Role) {
+	_ = h.audit.Log(c.Request.Context(), claims.TenantID, &claims.UserID, action, "user", &userID, map[string]any{"role_id": role.ID, "role": role.Name})
+}
+
 func (h *RolesHandler) assign(c *gin.Context) {
-	val, _ := c.Get("claims")
-	claims, _ := val.(jwtutil.Claims)
+	claims := claimsFrom(c)
 	uid, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid user id")
@@ -42,11 +58,12 @@ func (h *RolesHandler) assign(c *gin.Context) {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "role_name required")
 		return
 	}
-	role, err := h.uc.AssignByName(c.Request.Context(), claims.TenantID, uid, in.RoleName)
+	role, err := h.uc.Assign(c.Request.Context(), claims.TenantID, uid, in.RoleName)
 	if err != nil {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+		h.fail(c, err)
 		return
 	}
+	h.log(c, claims, "user.role.assign", uid, role)
 	httputil.Success(c.Writer, map[string]any{
 		"role": map[string]any{
 			"id":             role.ID,
@@ -58,14 +75,15 @@ func (h *RolesHandler) assign(c *gin.Context) {
 }
 
 func (h *RolesHandler) list(c *gin.Context) {
+	claims := claimsFrom(c)
 	uid, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid user id")
 		return
 	}
-	roles, err := h.uc.List(c.Request.Context(), uid)
+	roles, err := h.uc.List(c.Request.Context(), claims.TenantID, uid)
 	if err != nil {
-		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		h.fail(c, err)
 		return
 	}
 	items := make([]map[string]any, 0, len(roles))
@@ -81,6 +99,7 @@ func (h *RolesHandler) list(c *gin.Context) {
 }
 
 func (h *RolesHandler) unassign(c *gin.Context) {
+	claims := claimsFrom(c)
 	uid, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid user id")
@@ -91,9 +110,11 @@ func (h *RolesHandler) unassign(c *gin.Context) {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid role id")
 		return
 	}
-	if err := h.uc.Unassign(c.Request.Context(), uid, rid); err != nil {
-		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+	role, err := h.uc.Unassign(c.Request.Context(), claims.TenantID, uid, rid)
+	if err != nil {
+		h.fail(c, err)
 		return
 	}
+	h.log(c, claims, "user.role.unassign", uid, role)
 	httputil.Success(c.Writer, map[string]any{"deleted": true})
 }
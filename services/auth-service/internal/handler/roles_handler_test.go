@@ -16,6 +16,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
 	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 )
 
@@ -51,6 +52,10 @@ func ensureMigrationsRolesH(t *testing.T, db *pgxpool.Pool) {
 	if err == nil {
 		_, _ = db.Exec(ctx, string(b2))
 	}
+	bChain, err := os.ReadFile("../../migrations/011_audit_chain.up.sql")
+	if err == nil {
+		_, _ = db.Exec(ctx, string(bChain))
+	}
 }
 
 func TestRolesHandler_AssignListUnassign(t *testing.T) {
@@ -59,9 +64,10 @@ func TestRolesHandler_AssignListUnassign(t *testing.T) {
 	ensureMigrationsRolesH(t, db)
 	usersRepo := repository.NewUsersRepo(db)
 	rolesRepo := repository.NewRolesRepo(db)
-	rolesUC := usecase.NewRoles(usersRepo, rolesRepo)
-	h := NewRolesHandler(rolesUC)
-	usersUC := usecase.NewUsers(usersRepo)
+	auditRepo := repository.NewAuditRepo(db)
+	rolesUC := usecase.NewRoles(usersRepo, rolesRepo, nil)
+	h := NewRolesHandler(rolesUC, auditRepo)
+	usersUC := usecase.NewUsers(usersRepo, nil)
 	usersH := NewUsersHandler(usersUC)
 
 	r := gin.New()
@@ -71,7 +77,7 @@ func TestRolesHandler_AssignListUnassign(t *testing.T) {
 		c.Set("claims", jwtutil.Claims{TenantID: tenant, UserID: uuid.New()})
 	})
 	usersH.RegisterProtected(protected)
-	h.RegisterProtected(protected)
+	h.RegisterProtected(protected, func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } })
 
 	// create user
 	body := map[string]string{"email": "u2@test.local", "password": "Password123!"}
@@ -92,7 +98,22 @@ func TestRolesHandler_AssignListUnassign(t *testing.T) {
 		t.Fatalf("missing id in response: %s", w.Body.String())
 	}
 
+	// a role the tenant does not have is not created on the fly
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/users/"+idStr+"/roles", strings.NewReader(`{"role_name":"admin"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("unknown role status=%d body=%s", w.Code, w.Body.String())
+	}
+	if _, err := rolesRepo.FindRoleByName(context.Background(), tenant, "admin"); err == nil {
+		t.Fatalf("assign created the admin role")
+	}
+
 	// assign role
+	if _, err := rolesRepo.CreateRole(context.Background(), tenant, "teacher", false); err != nil {
+		t.Fatal(err)
+	}
 	assignBody := map[string]string{"role_name": "teacher"}
 	b, _ = json.Marshal(assignBody)
 	w = httptest.NewRecorder()
@@ -142,6 +163,12 @@ func TestRolesHandler_AssignListUnassign(t *testing.T) {
 		t.Fatalf("unassign status=%d body=%s", w.Code, w.Body.String())
 	}
 
+	var audited int
+	_ = db.QueryRow(context.Background(), `SELECT COUNT(*) FROM audit_logs WHERE tenant_id=$1 AND action IN ('user.role.assign', 'user.role.unassign')`, tenant).Scan(&audited)
+	if audited != 2 {
+		t.Fatalf("audited=%d want 2", audited)
+	}
+
 	// invalid unassign id
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest(http.MethodDelete, "/api/v1/users/"+idStr+"/roles/not-a-uuid", nil)
@@ -167,3 +194,35 @@ func TestRolesHandler_AssignListUnassign(t *testing.T) {
 		t.Fatalf("expected 400 for invalid user id list, got %d body=%s", w.Code, w.Body.String())
 	}
 }
+
+func TestRolesHandler_RequiresPermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	g := r.Group("/")
+	required := map[string]string{}
+	NewRolesHandler(nil, nil).RegisterProtected(g, func(permission string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			required[c.Request.Method] = permission
+			c.AbortWithStatus(http.StatusForbidden)
+		}
+	})
+	uid, rid := uuid.NewString(), uuid.NewString()
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodPost, "/api/v1/users/" + uid + "/roles"},
+		{http.MethodGet, "/api/v1/users/" + uid + "/roles"},
+		{http.MethodDelete, "/api/v1/users/" + uid + "/roles/" + rid},
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(tc.method, tc.path, strings.NewReader(`{"role_name":"admin"}`))
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("%s %s status=%d", tc.method, tc.path, w.Code)
+		}
+	}
+	want := map[string]string{http.MethodPost: authz.UserRoleAssign, http.MethodGet: authz.RoleRead, http.MethodDelete: authz.UserRoleAssign}
+	for m, p := range want {
+		if required[m] != p {
+			t.Errorf("%s requires %q, want %q", m, required[m], p)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+// apiKeyTokenTTL bounds how long a revoked key keeps working through a token
+// the gateway already holds.
+const apiKeyTokenTTL = 5 * time.Minute
+
+// ServiceAccountHandler manages machine clients and exchanges their API keys
+// for access tokens limited to the key's scopes.
+type ServiceAccountHandler struct {
+	uc    usecase.ServiceAccounts
+	auth  *AuthHandler
+	audit *repository.AuditRepo
+}
+
+func NewServiceAccountHandler(uc usecase.ServiceAccounts, auth *AuthHandler, audit *repository.AuditRepo) *ServiceAccountHandler {
+	return &ServiceAccountHandler{uc: uc, auth: auth, audit: audit}
+}
+
+// Register mounts the internal key exchange, which is not exposed through the
+// API gateway.
+func (h *ServiceAccountHandler) Register(r *gin.Engine) {
+	r.POST(authz.APIKeyVerifyPath, h.verify)
+}
+
+func (h *ServiceAccountHandler) RegisterProtected(r *gin.RouterGroup, perm func(permission string) gin.HandlerFunc) {
+	r.GET("/api/v1/service-accounts", perm(authz.ServiceAccountRead), h.list)
+	r.POST("/api/v1/service-accounts", perm(authz.ServiceAccountWrite), h.create)
+	r.DELETE("/api/v1/service-accounts/:id", perm(authz.ServiceAccountWrite), h.delete)
+	r.GET("/api/v1/service-accounts/:id/api-keys", perm(authz.ServiceAccountRead), h.listKeys)
+	r.POST("/api/v1/service-accounts/:id/api-keys", perm(authz.APIKeyWrite), h.createKey)
+	r.DELETE("/api/v1/api-keys/:id", perm(authz.APIKeyWrite), h.revokeKey)
+}
+
+type serviceAccountReq struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Roles       []string `json:"roles"`
+}
+
+type apiKeyReq struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	RateLimit int        `json:"rate_limit"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func serviceAccountView(sa *repository.ServiceAccount) map[string]any {
+	return map[string]any{
+		"id":          sa.ID,
+		"name":        sa.Name,
+		"description": sa.Description,
+		"is_active":   sa.IsActive,
+		"created_by":  sa.CreatedBy,
+		"created_at":  sa.CreatedAt,
+	}
+}
+
+// apiKeyView never includes the key hash.
+func apiKeyView(k *repository.APIKey) map[string]any {
+	return map[string]any{
+		"id":                 k.ID,
+		"service_account_id": k.ServiceAccountID,
+		"name":               k.Name,
+		"prefix":             k.Prefix,
+		"scopes":             k.Scopes,
+		"rate_limit":         k.RateLimit,
+		"expires_at":         k.ExpiresAt,
+		"last_used_at":       k.LastUsedAt,
+		"revoked_at":         k.RevokedAt,
+		"created_at":         k.CreatedAt,
+	}
+}
+
+func (h *ServiceAccountHandler) fail(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrServiceAccountNotFound), errors.Is(err, usecase.ErrAPIKeyNotFound):
+		httputil.Error(c.Writer, http.StatusNotFound, "5002", "Resource Not Found", err.Error())
+	case errors.Is(err, repository.ErrDuplicate):
+		httputil.Error(c.Writer, http.StatusConflict, "4001", "Invalid Input", "service account name already exists")
+	default:
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+	}
+}
+
+func (h *ServiceAccountHandler) log(c *gin.Context, claims jwtutil.Claims, action, resourceType string, id uuid.UUID, values any) {
+	_ = h.audit.Log(c.Request.Context(), claims.TenantID, &claims.UserID, action, resourceType, &id, values)
+}
+
+func (h *ServiceAccountHandler) list(c *gin.Context) {
+	claims := claimsFrom(c)
+	items, err := h.uc.List(c.Request.Context(), claims.TenantID)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	out := make([]map[string]any, 0, len(items))
+	for i := range items {
+		out = append(out, serviceAccountView(&items[i]))
+	}
+	httputil.Success(c.Writer, map[string]any{"items": out})
+}
+
+func (h *ServiceAccountHandler) create(c *gin.Context) {
+	claims := claimsFrom(c)
+	var in serviceAccountReq
+	if err := c.BindJSON(&in); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid json")
+		return
+	}
+	sa, err := h.uc.Create(c.Request.Context(), usecase.ServiceAccountInput{
+		TenantID:    claims.TenantID,
+		Name:        in.Name,
+		Description: in.Description,
+		Roles:       in.Roles,
+		CreatedBy:   &claims.UserID,
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.log(c, claims, "auth.service_account.create", "service_account", sa.ID, map[string]any{"name": sa.Name, "roles": in.Roles})
+	httputil.Success(c.Writer, serviceAccountView(sa))
+}
+
+func (h *ServiceAccountHandler) delete(c *gin.Context) {
+	claims := claimsFrom(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid service account id")
+		return
+	}
+	sa, err := h.uc.Delete(c.Request.Context(), claims.TenantID, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.log(c, claims, "auth.service_account.delete", "service_account", sa.ID, map[string]any{"name": sa.Name})
+	httputil.Success(c.Writer, map[string]any{"deleted": true})
+}
+
+func (h *ServiceAccountHandler) listKeys(c *gin.Context) {
+	claims := claimsFrom(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid service account id")
+		return
+	}
+	keys, err := h.uc.ListKeys(c.Request.Context(), claims.TenantID, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	out := make([]map[string]any, 0, len(keys))
+	for i := range keys {
+		out = append(out, apiKeyView(&keys[i]))
+	}
+	httputil.Success(c.Writer, map[string]any{"items": out})
+}
+
+func (h *ServiceAccountHandler) createKey(c *gin.Context) {
+	claims := claimsFrom(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid service account id")
+		return
+	}
+	var in apiKeyReq
+	if err := c.BindJSON(&in); err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid json")
+		return
+	}
+	key, k, err := h.uc.CreateKey(c.Request.Context(), usecase.APIKeyInput{
+		TenantID:         claims.TenantID,
+		ServiceAccountID: id,
+		Name:             in.Name,
+		Scopes:           in.Scopes,
+		RateLimit:        in.RateLimit,
+		ExpiresAt:        in.ExpiresAt,
+		CreatedBy:        &claims.UserID,
+	})
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.log(c, claims, "auth.api_key.create", "api_key", k.ID, map[string]any{
+		"service_account_id": k.ServiceAccountID,
+		"prefix":             k.Prefix,
+		"scopes":             k.Scopes,
+		"expires_at":         k.ExpiresAt,
+	})
+	out := apiKeyView(k)
+	out["key"] = key
+	httputil.Success(c.Writer, out)
+}
+
+func (h *ServiceAccountHandler) revokeKey(c *gin.Context) {
+	claims := claimsFrom(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid api key id")
+		return
+	}
+	k, err := h.uc.RevokeKey(c.Request.Context(), claims.TenantID, id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.log(c, claims, "auth.api_key.revoke", "api_key", k.ID, map[string]any{"prefix": k.Prefix})
+	httputil.Success(c.Writer, map[string]any{"revoked": true})
+}
+
+func (h *ServiceAccountHandler) verify(c *gin.Context) {
+	var in authz.APIKeyVerifyRequest
+	if err := c.BindJSON(&in); err != nil || in.Key == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "key required")
+		return
+	}
+	k, u, err := h.uc.Verify(c.Request.Context(), in.Key)
+	if err != nil {
+		if errors.Is(err, usecase.ErrAPIKeyInvalid) {
+			httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", err.Error())
+			return
+		}
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	claims, err := h.auth.accessClaims(c, u)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	claims.Scopes = k.Scopes
+	ttl := apiKeyTokenTTL
+	if k.ExpiresAt != nil {
+		if left := time.Until(*k.ExpiresAt); left < ttl {
+			ttl = left
+		}
+	}
+	access, err := h.auth.signAccessFor(claims, ttl)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	httputil.Success(c.Writer, authz.APIKeyVerifyResponse{
+		AccessToken:  access,
+		ExpiresIn:    int(ttl.Seconds()),
+		KeyID:        k.ID,
+		RateLimit:    k.RateLimit,
+		UserID:       claims.UserID,
+		TokenVersion: claims.TokenVersion,
+	})
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+type fakeServiceAccounts struct {
+	key *repository.APIKey
+	sa  *repository.User
+}
+
+func (f *fakeServiceAccounts) Create(ctx context.Context, in usecase.ServiceAccountInput) (*repository.ServiceAccount, error) {
+	return nil, nil
+}
+
+func (f *fakeServiceAccounts) List(ctx context.Context, tenantID string) ([]repository.ServiceAccount, error) {
+	return nil, nil
+}
+
+func (f *fakeServiceAccounts) Delete(ctx context.Context, tenantID string, id uuid.UUID) (*repository.ServiceAccount, error) {
+	return nil, usecase.ErrServiceAccountNotFound
+}
+
+func (f *fakeServiceAccounts) CreateKey(ctx context.Context, in usecase.APIKeyInput) (string, *repository.APIKey, error) {
+	return "", nil, nil
+}
+
+func (f *fakeServiceAccounts) ListKeys(ctx context.Context, tenantID string, serviceAccountID uuid.UUID) ([]repository.APIKey, error) {
+	return nil, nil
+}
+
+func (f *fakeServiceAccounts) RevokeKey(ctx context.Context, tenantID string, id uuid.UUID) (*repository.APIKey, error) {
+	return nil, nil
+}
+
+func (f *fakeServiceAccounts) Verify(ctx context.Context, key string) (*repository.APIKey, *repository.User, error) {
+	if key != "sk_good_secret" {
+		return nil, nil, usecase.ErrAPIKeyInvalid
+	}
+	return f.key, f.sa, nil
+}
+
+func TestServiceAccountHandler_VerifyIssuesScopedToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := config.Config{JWTAccessSecret: "access", JWTIssuer: "sisfo", JWTAudience: "api"}
+	saID := uuid.New()
+	soon := time.Now().Add(time.Minute)
+	fake := &fakeServiceAccounts{
+		key: &repository.APIKey{ID: uuid.New(), ServiceAccountID: saID, Scopes: []string{authz.StudentRead}, RateLimit: 120, ExpiresAt: &soon},
+		sa:  &repository.User{ID: saID, TenantID: "t1", IsActive: true},
+	}
+	r := gin.New()
+	NewServiceAccountHandler(fake, NewAuthHandler(nil, cfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil), nil).Register(r)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, authz.APIKeyVerifyPath, strings.NewReader(`{"key":"sk_bad_secret"}`)))
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("invalid key code=%d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, authz.APIKeyVerifyPath, strings.NewReader(`{"key":"sk_good_secret"}`)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("code=%d body=%s", rr.Code, rr.Body.String())
+	}
+	var body struct {
+		Data authz.APIKeyVerifyResponse `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Data.KeyID != fake.key.ID || body.Data.RateLimit != 120 {
+		t.Fatalf("unexpected response %s", rr.Body.String())
+	}
+	if body.Data.ExpiresIn > 60 {
+		t.Fatalf("token must not outlive its key, expires_in=%d", body.Data.ExpiresIn)
+	}
+	var claims jwtutil.Claims
+	if err := jwtutil.ValidateWith(cfg.JWTAccessSecret, body.Data.AccessToken, &claims, cfg.JWTIssuer, cfg.JWTAudience); err != nil {
+		t.Fatalf("validate err: %v", err)
+	}
+	if claims.UserID != saID || claims.TenantID != "t1" || !claims.AllowsScope(authz.StudentRead) || claims.AllowsScope(authz.StudentWrite) {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+}
+
+func TestServiceAccountHandler_Errors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	g := r.Group("/")
+	g.Use(func(c *gin.Context) { c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"}) })
+	NewServiceAccountHandler(&fakeServiceAccounts{}, nil, nil).RegisterProtected(g, func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } })
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/api/v1/service-accounts/not-a-uuid", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("invalid id code=%d", rr.Code)
+	}
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/api/v1/service-accounts/"+uuid.NewString(), nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("missing account code=%d", rr.Code)
+	}
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+type SessionsHandler struct {
+	uc    usecase.Sessions
+	audit *repository.AuditRepo
+}
+
+func NewSessionsHandler(uc usecase.Sessions, audit *repository.AuditRepo) *SessionsHandler {
+	return &SessionsHandler{uc: uc, audit: audit}
+}
+
+func (h *SessionsHandler) RegisterProtected(r *gin.RouterGroup, perm func(permission string) gin.HandlerFunc) {
+	r.GET("/api/v1/auth/sessions", h.listOwn)
+	r.DELETE("/api/v1/auth/sessions", h.revokeAllOwn)
+	r.DELETE("/api/v1/auth/sessions/:id", h.revokeOwn)
+	r.GET("/api/v1/users/:id/sessions", perm(authz.SessionRead), h.listUser)
+	r.DELETE("/api/v1/users/:id/sessions", perm(authz.SessionRevoke), h.revokeAllUser)
+}
+
+func sessionViews(sessions []repository.Session, currentID string) []map[string]any {
+	items := make([]map[string]any, 0, len(sessions))
+	for _, s := range sessions {
+		items = append(items, map[string]any{
+			"id":           s.ID,
+			"user_agent":   s.UserAgent,
+			"ip":           s.IP,
+			"created_at":   s.CreatedAt,
+			"last_used_at": s.LastUsedAt,
+			"expires_at":   s.ExpiresAt,
+			"current":      s.ID.String() == currentID,
+		})
+	}
+	return items
+}
+
+func (h *SessionsHandler) fail(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrSessionNotFound), errors.Is(err, usecase.ErrUserNotFound):
+		httputil.Error(c.Writer, http.StatusNotFound, "5002", "Resource Not Found", err.Error())
+	default:
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+	}
+}
+
+func (h *SessionsHandler) log(c *gin.Context, claims jwtutil.Claims, action string, userID uuid.UUID, values any) {
+	_ = h.audit.Log(c.Request.Context(), claims.TenantID, &claims.UserID, action, "user", &userID, values)
+}
+
+func (h *SessionsHandler) listOwn(c *gin.Context) {
+	claims := claimsFrom(c)
+	sessions, err := h.uc.List(c.Request.Context(), claims.TenantID, claims.UserID)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	httputil.Success(c.Writer, map[string]any{"items": sessionViews(sessions, claims.SessionID)})
+}
+
+func (h *SessionsHandler) revokeOwn(c *gin.Context) {
+	claims := claimsFrom(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid session id")
+		return
+	}
+	if err := h.uc.Revoke(c.Request.Context(), claims.TenantID, claims.UserID, id, "user_revoked"); err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.log(c, claims, "auth.session.revoke", claims.UserID, map[string]any{"session_id": id})
+	httputil.Success(c.Writer, map[string]any{"deleted": true})
+}
+
+func (h *SessionsHandler) revokeAllOwn(c *gin.Context) {
+	claims := claimsFrom(c)
+	n, err := h.uc.RevokeAll(c.Request.Context(), claims.TenantID, claims.UserID, "logout_all")
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.log(c, claims, "auth.session.revoke_all", claims.UserID, map[string]any{"revoked": n})
+	httputil.Success(c.Writer, map[string]any{"revoked": n})
+}
+
+func (h *SessionsHandler) listUser(c *gin.Context) {
+	claims := claimsFrom(c)
+	uid, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid user id")
+		return
+	}
+	sessions, err := h.uc.List(c.Request.Context(), claims.TenantID, uid)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	httputil.Success(c.Writer, map[string]any{"items": sessionViews(sessions, claims.SessionID)})
+}
+
+func (h *SessionsHandler) revokeAllUser(c *gin.Context) {
+	claims := claimsFrom(c)
+	uid, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "invalid user id")
+		return
+	}
+	n, err := h.uc.RevokeAll(c.Request.Context(), claims.TenantID, uid, "admin_revoked")
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.log(c, claims, "auth.session.revoke_all", uid, map[string]any{"revoked": n, "by_admin": true})
+	httputil.Success(c.Writer, map[string]any{"revoked": n})
+}
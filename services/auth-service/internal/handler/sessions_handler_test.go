@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+type fakeSessions struct {
+	items []repository.Session
+}
+
+func (f *fakeSessions) Start(ctx context.Context, u *repository.User, jti, userAgent, ip string, expiresAt time.Time) (*repository.Session, error) {
+	return nil, nil
+}
+
+func (f *fakeSessions) Rotate(ctx context.Context, sessionID, userID uuid.UUID, presentedJTI, nextJTI, userAgent, ip string, expiresAt time.Time) (*repository.Session, error) {
+	return nil, nil
+}
+
+func (f *fakeSessions) List(ctx context.Context, tenantID string, userID uuid.UUID) ([]repository.Session, error) {
+	return f.items, nil
+}
+
+func (f *fakeSessions) Revoke(ctx context.Context, tenantID string, userID, sessionID uuid.UUID, reason string) error {
+	return nil
+}
+
+func (f *fakeSessions) RevokeAll(ctx context.Context, tenantID string, userID uuid.UUID, reason string) (int64, error) {
+	return 0, nil
+}
+
+func TestSessionsHandler_ListOwnMarksCurrent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	current := uuid.New()
+	fake := &fakeSessions{items: []repository.Session{{ID: current, UserAgent: "phone"}, {ID: uuid.New(), UserAgent: "laptop"}}}
+	r := gin.New()
+	g := r.Group("/")
+	g.Use(func(c *gin.Context) {
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "t1", SessionID: current.String()})
+	})
+	NewSessionsHandler(fake, nil).RegisterProtected(g, func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } })
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/auth/sessions", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("code=%d body=%s", rr.Code, rr.Body.String())
+	}
+	var body struct {
+		Data struct {
+			Items []map[string]any `json:"items"`
+		} `json:"data"`
+	}
+	_ = json.Unmarshal(rr.Body.Bytes(), &body)
+	if len(body.Data.Items) != 2 || body.Data.Items[0]["current"] != true || body.Data.Items[1]["current"] != false {
+		t.Fatalf("unexpected items %s", rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/api/v1/auth/sessions/not-a-uuid", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("invalid id code=%d", rr.Code)
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
+)
+
+// defaultUsageDays is the history reported when no range is asked for.
+const defaultUsageDays = 30
+
+// UsageMeter reports what tenants used and which plan they are on.
+type UsageMeter interface {
+	Plan(ctx context.Context, tenant string) (metering.Plan, error)
+	SetPlan(ctx context.Context, tenant, name string) (metering.Plan, error)
+	Usage(ctx context.Context, tenant string, from, to time.Time) ([]metering.DailyUsage, error)
+	Today(ctx context.Context, tenant string) ([]metering.Result, error)
+}
+
+// UsageHandler reports usage history against the tenant's plan. The
+// /tenants routes work across tenants and are only served to staff of the
+// operator tenant.
+type UsageHandler struct {
+	meter    UsageMeter
+	audit    *repository.AuditRepo
+	operator string
+}
+
+func NewUsageHandler(meter UsageMeter, audit *repository.AuditRepo, operatorTenantID string) *UsageHandler {
+	return &UsageHandler{meter: meter, audit: audit, operator: operatorTenantID}
+}
+
+func (h *UsageHandler) RegisterProtected(r *gin.RouterGroup, perm func(permission string) gin.HandlerFunc) {
+	r.GET("/api/v1/usage", perm(authz.UsageRead), h.own)
+	r.GET("/api/v1/tenants/:tenant_id/usage", h.operatorOnly, perm(authz.TenantUsageRead), h.tenant)
+	r.PUT("/api/v1/tenants/:tenant_id/plan", h.operatorOnly, perm(authz.TenantPlanWrite), h.setPlan)
+}
+
+func (h *UsageHandler) operatorOnly(c *gin.Context) {
+	if h.operator == "" || claimsFrom(c).TenantID != h.operator {
+		httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "operator only")
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+func (h *UsageHandler) own(c *gin.Context) {
+	h.report(c, claimsFrom(c).TenantID)
+}
+
+func (h *UsageHandler) tenant(c *gin.Context) {
+	h.report(c, c.Param("tenant_id"))
+}
+
+// report returns the tenant's plan, where it stands today and its daily
+// usage between from and to (YYYY-MM-DD, inclusive; the last 30 days by
+// default).
+func (h *UsageHandler) report(c *gin.Context, tenant string) {
+	to := time.Now().UTC()
+	from := to.AddDate(0, 0, 1-defaultUsageDays)
+	for _, q := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		if s := c.Query(q.name); s != "" {
+			t, err := time.Parse(time.DateOnly, s)
+			if err != nil {
+				httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", q.name+" must be YYYY-MM-DD")
+				return
+			}
+			*q.dst = t
+		}
+	}
+	ctx := c.Request.Context()
+	history, err := h.meter.Usage(ctx, tenant, from, to)
+	if errors.Is(err, metering.ErrRange) {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "from must not be after to, and the range at most 366 days")
+		return
+	}
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	plan, err := h.meter.Plan(ctx, tenant)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	today, err := h.meter.Today(ctx, tenant)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	httputil.Success(c.Writer, map[string]any{
+		"tenant_id": tenant,
+		"plan":      plan,
+		"today":     today,
+		"history":   history,
+	})
+}
+
+type setPlanReq struct {
+	Plan string `json:"plan"`
+}
+
+func (h *UsageHandler) setPlan(c *gin.Context) {
+	claims := claimsFrom(c)
+	tenant := c.Param("tenant_id")
+	var req setPlanReq
+	if err := c.BindJSON(&req); err != nil || strings.TrimSpace(req.Plan) == "" {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "missing plan")
+		return
+	}
+	ctx := c.Request.Context()
+	before, err := h.meter.Plan(ctx, tenant)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	plan, err := h.meter.SetPlan(ctx, tenant, strings.TrimSpace(req.Plan))
+	if errors.Is(err, metering.ErrUnknownPlan) {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "unknown plan")
+		return
+	}
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", err.Error())
+		return
+	}
+	_ = h.audit.LogChange(ctx, claims.TenantID, &claims.UserID, "auth.tenant_plan.update", "tenant", nil,
+		map[string]any{"tenant_id": tenant, "plan": before.Name}, map[string]any{"tenant_id": tenant, "plan": plan.Name})
+	httputil.Success(c.Writer, plan)
+}
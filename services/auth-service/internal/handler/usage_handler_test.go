@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
+)
+
+type fakeUsageMeter struct {
+	tenant   string
+	from, to time.Time
+}
+
+func (f *fakeUsageMeter) Plan(ctx context.Context, tenant string) (metering.Plan, error) {
+	return metering.Plans[metering.DefaultPlan], nil
+}
+
+func (f *fakeUsageMeter) SetPlan(ctx context.Context, tenant, name string) (metering.Plan, error) {
+	if _, ok := metering.Plans[name]; !ok {
+		return metering.Plan{}, metering.ErrUnknownPlan
+	}
+	return metering.Plans[name], nil
+}
+
+func (f *fakeUsageMeter) Usage(ctx context.Context, tenant string, from, to time.Time) ([]metering.DailyUsage, error) {
+	f.tenant, f.from, f.to = tenant, from, to
+	if to.Before(from) {
+		return nil, metering.ErrRange
+	}
+	return []metering.DailyUsage{{Date: from.Format(time.DateOnly), Usage: map[metering.Metric]int64{metering.Requests: 42}}}, nil
+}
+
+func (f *fakeUsageMeter) Today(ctx context.Context, tenant string) ([]metering.Result, error) {
+	return []metering.Result{{Metric: metering.Requests, Used: 42, State: metering.OK}}, nil
+}
+
+func TestUsageHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fake := &fakeUsageMeter{}
+	r := gin.New()
+	g := r.Group("/")
+	g.Use(func(c *gin.Context) {
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: c.GetHeader("X-Test-Tenant")})
+	})
+	NewUsageHandler(fake, nil, "ops").RegisterProtected(g, func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } })
+	call := func(method, path, tenant, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("X-Test-Tenant", tenant)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := call(http.MethodGet, "/api/v1/usage?from=2026-03-01&to=2026-03-07", "t1", "")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"requests":42`) || !strings.Contains(rr.Body.String(), `"name":"standard"`) {
+		t.Fatalf("own usage code=%d body=%s", rr.Code, rr.Body.String())
+	}
+	if fake.tenant != "t1" || fake.from.Format(time.DateOnly) != "2026-03-01" || fake.to.Format(time.DateOnly) != "2026-03-07" {
+		t.Fatalf("tenant=%s from=%v to=%v", fake.tenant, fake.from, fake.to)
+	}
+	if rr := call(http.MethodGet, "/api/v1/usage?from=March", "t1", ""); rr.Code != http.StatusBadRequest {
+		t.Fatalf("bad date code=%d", rr.Code)
+	}
+	if rr := call(http.MethodGet, "/api/v1/usage?from=2026-03-07&to=2026-03-01", "t1", ""); rr.Code != http.StatusBadRequest {
+		t.Fatalf("bad range code=%d", rr.Code)
+	}
+
+	// Other tenants' usage and plans are for the operator only
+	if rr := call(http.MethodGet, "/api/v1/tenants/t2/usage", "t1", ""); rr.Code != http.StatusForbidden {
+		t.Fatalf("tenant reading another code=%d", rr.Code)
+	}
+	if rr := call(http.MethodGet, "/api/v1/tenants/t2/usage", "ops", ""); rr.Code != http.StatusOK || fake.tenant != "t2" {
+		t.Fatalf("operator code=%d tenant=%s", rr.Code, fake.tenant)
+	}
+	if rr := call(http.MethodPut, "/api/v1/tenants/t2/plan", "t1", `{"plan":"premium"}`); rr.Code != http.StatusForbidden {
+		t.Fatalf("tenant setting plan code=%d", rr.Code)
+	}
+	if rr := call(http.MethodPut, "/api/v1/tenants/t2/plan", "ops", `{"plan":"gold"}`); rr.Code != http.StatusBadRequest {
+		t.Fatalf("unknown plan code=%d", rr.Code)
+	}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/xlsx"
+)
+
+const maxImportFileSize = 5 << 20
+
+// UserImportHandler accepts spreadsheets of users to provision in bulk.
+type UserImportHandler struct {
+	uc    usecase.UserImport
+	audit *repository.AuditRepo
+}
+
+func NewUserImportHandler(uc usecase.UserImport, audit *repository.AuditRepo) *UserImportHandler {
+	return &UserImportHandler{uc: uc, audit: audit}
+}
+
+func (h *UserImportHandler) RegisterProtected(r *gin.RouterGroup, perm func(permission string) gin.HandlerFunc) {
+	r.POST("/api/v1/users/import", perm(authz.UserImport), h.importUsers)
+}
+
+// importUsers takes a multipart "file" (CSV or XLSX) with an email column and
+// an optional roles column. dry_run only reports; invite sends invitations
+// instead of setting default_password.
+func (h *UserImportHandler) importUsers(c *gin.Context) {
+	claims := claimsFrom(c)
+	fh, err := c.FormFile("file")
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "file is required")
+		return
+	}
+	if fh.Size > maxImportFileSize {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", "file exceeds 5MB")
+		return
+	}
+	f, err := fh.Open()
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", "failed to open file")
+		return
+	}
+	defer func() { _ = f.Close() }()
+	data, err := io.ReadAll(io.LimitReader(f, maxImportFileSize+1))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal Server Error", "failed to read file")
+		return
+	}
+	rows, err := parseSpreadsheet(fh.Filename, data)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+		return
+	}
+	dryRun, _ := strconv.ParseBool(formValue(c, "dry_run"))
+	invite, _ := strconv.ParseBool(formValue(c, "invite"))
+	report, err := h.uc.Import(c.Request.Context(), usecase.ImportInput{
+		TenantID:        claims.TenantID,
+		Rows:            rows,
+		DryRun:          dryRun,
+		Invite:          invite,
+		DefaultPassword: c.PostForm("default_password"),
+	})
+	switch {
+	case errors.Is(err, usecase.ErrImportInvalid):
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", report)
+		return
+	case errors.Is(err, repository.ErrDuplicate):
+		httputil.Error(c.Writer, http.StatusConflict, "4001", "Invalid Input", err.Error())
+		return
+	case err != nil:
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid Input", err.Error())
+		return
+	}
+	if !dryRun {
+		_ = h.audit.Log(c.Request.Context(), claims.TenantID, &claims.UserID, "user.import", "user", nil, map[string]any{
+			"file":    fh.Filename,
+			"created": report.Created,
+			"invite":  invite,
+		})
+	}
+	httputil.Success(c.Writer, report)
+}
+
+// formValue reads a flag from the query string or the multipart form.
+func formValue(c *gin.Context, key string) string {
+	if v, ok := c.GetQuery(key); ok {
+		return v
+	}
+	return c.PostForm(key)
+}
+
+// parseSpreadsheet reads an uploaded CSV or XLSX file into rows of cells.
+func parseSpreadsheet(name string, data []byte) ([][]string, error) {
+	if strings.EqualFold(filepath.Ext(name), ".xlsx") || bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		rows, err := xlsx.ReadRows(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, errors.New("invalid xlsx file")
+		}
+		return rows, nil
+	}
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, errors.New("invalid csv file: " + err.Error())
+	}
+	return rows, nil
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/usecase"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+type fakeUserImport struct {
+	in usecase.ImportInput
+}
+
+func (f *fakeUserImport) Import(ctx context.Context, in usecase.ImportInput) (*usecase.ImportReport, error) {
+	f.in = in
+	report := &usecase.ImportReport{DryRun: in.DryRun, Total: len(in.Rows) - 1}
+	for i, line := range in.Rows[1:] {
+		row := usecase.ImportRow{Row: i + 2, Email: line[0]}
+		if !strings.Contains(line[0], "@") {
+			row.Errors = []string{"invalid email format"}
+			report.Invalid++
+		}
+		report.Rows = append(report.Rows, row)
+	}
+	if report.Invalid > 0 && !in.DryRun {
+		return report, usecase.ErrImportInvalid
+	}
+	return report, nil
+}
+
+func importRequest(t *testing.T, target, filename, body string) *http.Request {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	fw, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = fw.Write([]byte(body))
+	_ = mw.WriteField("invite", "true")
+	_ = mw.Close()
+	req := httptest.NewRequest(http.MethodPost, target, &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestUserImportHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fake := &fakeUserImport{}
+	r := gin.New()
+	g := r.Group("/")
+	g.Use(func(c *gin.Context) {
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"})
+	})
+	NewUserImportHandler(fake, nil).RegisterProtected(g, func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } })
+
+	csvBody := "email,roles\nguru@school.id,teacher;staff\nnot-an-email,teacher\n"
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, importRequest(t, "/api/v1/users/import?dry_run=true", "staff.csv", csvBody))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"invalid email format"`) {
+		t.Fatalf("dry run code=%d body=%s", rr.Code, rr.Body.String())
+	}
+	if !fake.in.DryRun || !fake.in.Invite || fake.in.TenantID != "t1" || len(fake.in.Rows) != 3 || fake.in.Rows[1][1] != "teacher;staff" {
+		t.Fatalf("unexpected input %+v", fake.in)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, importRequest(t, "/api/v1/users/import", "staff.csv", csvBody))
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), `"row":3`) {
+		t.Fatalf("invalid import code=%d body=%s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, importRequest(t, "/api/v1/users/import", "staff.xlsx", "not a workbook"))
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "invalid xlsx file") {
+		t.Fatalf("bad xlsx code=%d body=%s", rr.Code, rr.Body.String())
+	}
+}
@@ -53,7 +53,7 @@ func TestUsersHandler_InvalidJSON_Create(t *testing.T) {
 
 func TestUsersHandler_MissingEmail_Create(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	uc := usecase.NewUsers(repository.NewUsersRepo(nil))
+	uc := usecase.NewUsers(repository.NewUsersRepo(nil), nil)
 	h := NewUsersHandler(uc)
 	r := gin.New()
 	protected := r.Group("/")
@@ -72,7 +72,7 @@ func TestUsersHandler_MissingEmail_Create(t *testing.T) {
 
 func TestUsersHandler_MissingPassword_Create(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	uc := usecase.NewUsers(repository.NewUsersRepo(nil))
+	uc := usecase.NewUsers(repository.NewUsersRepo(nil), nil)
 	h := NewUsersHandler(uc)
 	r := gin.New()
 	protected := r.Group("/")
@@ -91,7 +91,7 @@ func TestUsersHandler_MissingPassword_Create(t *testing.T) {
 
 func TestUsersHandler_WeakPassword_Create(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	uc := usecase.NewUsers(repository.NewUsersRepo(nil))
+	uc := usecase.NewUsers(repository.NewUsersRepo(nil), nil)
 	h := NewUsersHandler(uc)
 	r := gin.New()
 	protected := r.Group("/")
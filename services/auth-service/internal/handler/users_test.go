@@ -50,6 +50,14 @@ func ensureMigrationsUsers(t *testing.T, db *pgxpool.Pool) {
 	if err == nil {
 		_, _ = db.Exec(ctx, string(b2))
 	}
+	bChain, err := os.ReadFile("../../migrations/011_audit_chain.up.sql")
+	if err == nil {
+		_, _ = db.Exec(ctx, string(bChain))
+	}
+	b9, err := os.ReadFile("../../migrations/009_password_policy.up.sql")
+	if err == nil {
+		_, _ = db.Exec(ctx, string(b9))
+	}
 }
 
 func TestUsersHandler_CRUD(t *testing.T) {
@@ -57,7 +65,7 @@ func TestUsersHandler_CRUD(t *testing.T) {
 	db := testDBUsers(t)
 	ensureMigrationsUsers(t, db)
 	usersRepo := repository.NewUsersRepo(db)
-	uc := usecase.NewUsers(usersRepo)
+	uc := usecase.NewUsers(usersRepo, nil)
 	h := NewUsersHandler(uc)
 
 	r := gin.New()
@@ -22,26 +22,27 @@ func Audit(repo *repository.AuditRepo) gin.HandlerFunc {
 		}
 		status := c.Writer.Status()
 		tenant := ""
-		var userID *uuid.UUID
+		var userID, actorID *uuid.UUID
 		if v, ok := c.Get("claims"); ok {
 			if cl, ok2 := v.(jwtutil.Claims); ok2 {
 				tenant = cl.TenantID
 				userID = &cl.UserID
+				if cl.Actor != nil {
+					actorID = &cl.Actor.UserID
+				}
 			}
 		}
-		m := method
-		p := path
-		s := status
-		t := tenant
-		uid := userID
-		dur := lat.Milliseconds()
-		go func() {
-			_ = repo.Log(context.Background(), t, uid, "http.request", "route", nil, map[string]any{
-				"method":   m,
-				"path":     p,
-				"status":   s,
-				"duration": dur,
-			})
-		}()
+		values := map[string]any{
+			"method":   method,
+			"path":     path,
+			"status":   status,
+			"duration": lat.Milliseconds(),
+		}
+		if actorID != nil {
+			values["actor_id"] = actorID.String()
+		}
+		// Written before the handler returns so no entry is lost on shutdown;
+		// the request context may already be cancelled by the client.
+		_ = repo.Log(context.WithoutCancel(c.Request.Context()), tenant, userID, "http.request", "route", nil, values)
 	}
 }
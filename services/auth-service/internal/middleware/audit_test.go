@@ -39,6 +39,10 @@ func ensureMigrationsAuditMW(t *testing.T, db *pgxpool.Pool) {
 	if err == nil {
 		_, _ = db.Exec(context.Background(), string(b2))
 	}
+	bChain, err := os.ReadFile("../../migrations/011_audit_chain.up.sql")
+	if err == nil {
+		_, _ = db.Exec(context.Background(), string(bChain))
+	}
 }
 
 func TestAuditMiddleware_LogsRequest(t *testing.T) {
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
@@ -8,9 +9,11 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
 	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	smiddleware "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 )
 
-func Auth(secret string, cfg config.Config) gin.HandlerFunc {
+func Auth(keys jwtutil.KeySet, cfg config.Config, kv redisutil.KV) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		h := c.Request.Header.Get("Authorization")
 		if h == "" || !strings.HasPrefix(h, "Bearer ") {
@@ -20,11 +23,29 @@ func Auth(secret string, cfg config.Config) gin.HandlerFunc {
 		}
 		token := strings.TrimPrefix(h, "Bearer ")
 		var claims jwtutil.Claims
-		if err := jwtutil.ValidateWith(secret, token, &claims, cfg.JWTIssuer, cfg.JWTAudience); err != nil {
+		if err := jwtutil.ValidateWithKeySet(keys, token, &claims, cfg.JWTIssuer, cfg.JWTAudience); err != nil {
 			httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", nil)
 			c.Abort()
 			return
 		}
+		ok, err := smiddleware.TokenVersionValid(c.Request.Context(), kv, claims)
+		if err != nil {
+			httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal error", nil)
+			c.Abort()
+			return
+		}
+		if !ok {
+			httputil.Error(c.Writer, http.StatusUnauthorized, "2001", "Unauthorized", "token revoked")
+			c.Abort()
+			return
+		}
+		if !smiddleware.ImpersonationAllowed(claims, c.Request.Method) {
+			httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "impersonated session is read-only")
+			c.Abort()
+			return
+		}
+		// Also on the request context, where the rate limiter looks for the caller
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), smiddleware.ClaimsKey, claims))
 		c.Set("claims", claims)
 		c.Next()
 	}
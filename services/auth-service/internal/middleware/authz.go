@@ -22,6 +22,11 @@ func Authorization(authz Authorizer, permission string) gin.HandlerFunc {
 			return
 		}
 		claims, _ := val.(jwtutil.Claims)
+		if !claims.AllowsScope(permission) {
+			httputil.Error(c.Writer, http.StatusForbidden, "3001", "Forbidden", "outside token scope")
+			c.Abort()
+			return
+		}
 		okay, err := authz.Allow(claims.UserID, claims.TenantID, permission)
 		if err != nil {
 			httputil.Error(c.Writer, http.StatusInternalServerError, "1001", "Internal error", nil)
@@ -12,6 +12,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
 	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	smiddleware "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+	"github.com/redis/go-redis/v9"
 )
 
 func makeCfg() config.Config {
@@ -67,6 +70,23 @@ func TestAuthorization_Allowed(t *testing.T) {
 	}
 }
 
+func TestAuthorization_OutsideScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authz := &fakeAuthz{allow: true}
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("claims", jwtutil.Claims{TenantID: "t1", UserID: uuid.New(), Scopes: []string{"user:read"}})
+	})
+	r.Use(Authorization(authz, "user:write"))
+	r.POST("/", func(c *gin.Context) { c.Status(204) })
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", nil)
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rr.Code)
+	}
+}
+
 func TestAuthorization_Error_InternalServerError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	authz := &fakeAuthz{err: errors.New("backend error")}
@@ -114,7 +134,7 @@ func TestAuth_Unauthorized_NoHeader(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	cfg := makeCfg()
 	r := gin.New()
-	r.Use(Auth("secret", cfg))
+	r.Use(Auth(jwtutil.NewSecretKeySet("secret"), cfg, nil))
 	r.GET("/", func(c *gin.Context) { c.Status(200) })
 	rr := httptest.NewRecorder()
 	req := httptest.NewRequest("GET", "/", nil)
@@ -131,7 +151,7 @@ func TestAuth_Authorized_WithValidToken(t *testing.T) {
 	claims := jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"}
 	token, _ := jwtutil.GenerateAccessWith(secret, time.Minute, claims, cfg.JWTIssuer, cfg.JWTAudience)
 	r := gin.New()
-	r.Use(Auth(secret, cfg))
+	r.Use(Auth(jwtutil.NewSecretKeySet(secret), cfg, nil))
 	r.GET("/", func(c *gin.Context) { c.Status(204) })
 	rr := httptest.NewRecorder()
 	req := httptest.NewRequest("GET", "/", nil)
@@ -149,7 +169,7 @@ func TestAuth_InvalidIssuer(t *testing.T) {
 	claims := jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"}
 	token, _ := jwtutil.GenerateAccessWith(secret, time.Minute, claims, "wrong", cfg.JWTAudience)
 	r := gin.New()
-	r.Use(Auth(secret, cfg))
+	r.Use(Auth(jwtutil.NewSecretKeySet(secret), cfg, nil))
 	r.GET("/", func(c *gin.Context) { c.Status(200) })
 	rr := httptest.NewRecorder()
 	req := httptest.NewRequest("GET", "/", nil)
@@ -160,6 +180,67 @@ func TestAuth_InvalidIssuer(t *testing.T) {
 	}
 }
 
+type fakeKV map[string]string
+
+func (f fakeKV) Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	f[key] = value.(string)
+	return redis.NewStatusCmd(ctx)
+}
+
+func (f fakeKV) Get(ctx context.Context, key string) *redis.StringCmd {
+	cmd := redis.NewStringCmd(ctx)
+	if v, ok := f[key]; ok {
+		cmd.SetVal(v)
+	} else {
+		cmd.SetErr(redis.Nil)
+	}
+	return cmd
+}
+
+func (f fakeKV) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	return redis.NewIntCmd(ctx)
+}
+
+func TestAuth_StaleTokenVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := makeCfg()
+	secret := "s"
+	claims := jwtutil.Claims{UserID: uuid.New(), TenantID: "t1", TokenVersion: 1}
+	token, _ := jwtutil.GenerateAccessWith(secret, time.Minute, claims, cfg.JWTIssuer, cfg.JWTAudience)
+	kv := fakeKV{smiddleware.TokenVersionKey(claims.UserID): "2"}
+	r := gin.New()
+	r.Use(Auth(jwtutil.NewSecretKeySet(secret), cfg, kv))
+	r.GET("/", func(c *gin.Context) { c.Status(204) })
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("code=%d want 401", rr.Code)
+	}
+}
+
+func TestAuth_ImpersonationIsReadOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := makeCfg()
+	secret := "s"
+	claims := jwtutil.Claims{UserID: uuid.New(), TenantID: "t1", Actor: &jwtutil.Actor{UserID: uuid.New()}}
+	token, _ := jwtutil.GenerateAccessWith(secret, time.Minute, claims, cfg.JWTIssuer, cfg.JWTAudience)
+	r := gin.New()
+	r.Use(Auth(jwtutil.NewSecretKeySet(secret), cfg, nil))
+	r.GET("/", func(c *gin.Context) { c.Status(204) })
+	r.POST("/", func(c *gin.Context) { c.Status(204) })
+	for method, want := range map[string]int{"GET": http.StatusNoContent, "POST": http.StatusForbidden} {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		r.ServeHTTP(rr, req)
+		if rr.Code != want {
+			t.Fatalf("%s code=%d want %d", method, rr.Code, want)
+		}
+	}
+}
+
 func TestCORS_AllowsOriginAndOptions(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -199,7 +280,7 @@ func TestRateLimit(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	fl := &fakeLimiter{count: map[string]int64{}}
 	r := gin.New()
-	r.Use(RateLimit(fl, 1))
+	r.Use(RateLimit(redisutil.NewFixedWindowLimiter(fl), 1, nil))
 	r.GET("/", func(c *gin.Context) { c.Status(200) })
 	req := httptest.NewRequest("GET", "/", nil)
 	rr1 := httptest.NewRecorder()
@@ -218,7 +299,7 @@ func TestRateLimit_ErrorPath(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	fl := &fakeLimiter{count: map[string]int64{}, fail: true}
 	r := gin.New()
-	r.Use(RateLimit(fl, 1))
+	r.Use(RateLimit(redisutil.NewFixedWindowLimiter(fl), 1, nil))
 	r.GET("/", func(c *gin.Context) { c.Status(200) })
 	req := httptest.NewRequest("GET", "/", nil)
 	rr := httptest.NewRecorder()
@@ -233,7 +314,7 @@ func TestRateLimitByPolicy_Gin(t *testing.T) {
 	fl := &fakeLimiter{count: map[string]int64{}}
 	r := gin.New()
 	// Default read=3, write=2, override auth prefix=1
-	r.Use(RateLimitByPolicy(fl, 3, 2, map[string]int{"/api/v1/auth/": 1}))
+	r.Use(RateLimitByPolicy(redisutil.NewFixedWindowLimiter(fl), 3, 2, map[string]int{"/api/v1/auth/": 1}, nil))
 	r.POST("/api/v1/auth/login", func(c *gin.Context) { c.Status(200) })
 	// First POST to auth/login should pass
 	req1 := httptest.NewRequest("POST", "/api/v1/auth/login", nil)
@@ -265,3 +346,32 @@ func TestRateLimitByPolicy_Gin(t *testing.T) {
 		t.Fatalf("get fourth should be limited, got %d", rr4.Code)
 	}
 }
+
+func TestRateLimitByPolicy_PerUserAfterAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := makeCfg()
+	secret := "s"
+	fl := &fakeLimiter{count: map[string]int64{}}
+	r := gin.New()
+	r.Use(Auth(jwtutil.NewSecretKeySet(secret), cfg, nil), RateLimitByPolicy(redisutil.NewFixedWindowLimiter(fl), 1, 1, nil, nil))
+	r.GET("/", func(c *gin.Context) { c.Status(200) })
+	call := func(userID uuid.UUID) int {
+		token, _ := jwtutil.GenerateAccessWith(secret, time.Minute, jwtutil.Claims{UserID: userID, TenantID: "t1"}, cfg.JWTIssuer, cfg.JWTAudience)
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	// Both users share one address but not a budget
+	alice, bob := uuid.New(), uuid.New()
+	if code := call(alice); code != 200 {
+		t.Fatalf("first request code=%d", code)
+	}
+	if code := call(bob); code != 200 {
+		t.Fatalf("other user code=%d want 200", code)
+	}
+	if code := call(alice); code != http.StatusTooManyRequests {
+		t.Fatalf("second request code=%d want 429", code)
+	}
+}
@@ -1,72 +1,46 @@
 package middleware
 
 import (
-	"context"
-	"time"
+	"net/http"
+	"net/netip"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	smiddleware "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
 	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 )
 
-func RateLimit(rl redisutil.Limiter, limit int) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		ip := c.ClientIP()
-		key := "ratelimit:" + ip + ":" + c.FullPath()
-		ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
-		defer cancel()
-		n, err := rl.Incr(ctx, key)
-		if err != nil {
-			httputil.Error(c.Writer, 500, "6001", "Rate limiter error", err.Error())
-			c.Abort()
-			return
-		}
-		if n == 1 {
-			_ = rl.Expire(ctx, key, time.Minute)
-		}
-		if n > int64(limit) {
-			httputil.Error(c.Writer, 429, "1001", "Too Many Requests", nil)
-			c.Abort()
-			return
-		}
-		c.Next()
-	}
+func RateLimit(rl redisutil.RateLimiter, limit int, trustedProxies []netip.Prefix) gin.HandlerFunc {
+	return RateLimitByPolicy(rl, limit, limit, nil, trustedProxies)
 }
 
-func RateLimitByPolicy(rl redisutil.Limiter, defaultRead int, defaultWrite int, perPrefixOverride map[string]int) gin.HandlerFunc {
+// RateLimitByPolicy limits requests per caller and gin route template. Put it
+// after the authentication middleware so signed-in callers are counted per
+// user; anonymous ones are counted per client address, with X-Forwarded-For
+// believed from trustedProxies only. Calls between services under /internal/
+// are not limited: each calling instance would share one bucket for all the
+// users it serves.
+func RateLimitByPolicy(rl redisutil.RateLimiter, defaultRead int, defaultWrite int, perPrefixOverride map[string]int, trustedProxies []netip.Prefix) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		limit := defaultWrite
-		if c.Request.Method == "GET" || c.Request.Method == "HEAD" {
-			limit = defaultRead
-		}
-		path := c.FullPath()
-		if path == "" {
-			path = c.Request.URL.Path
-		}
-		for p, v := range perPrefixOverride {
-			if len(p) > 0 && len(path) >= len(p) && path[:len(p)] == p {
-				limit = v
-				break
-			}
-		}
-		ip := c.ClientIP()
-		key := "ratelimit:" + ip + ":" + c.Request.Method + ":" + path
-		ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
-		defer cancel()
-		n, err := rl.Incr(ctx, key)
-		if err != nil {
-			httputil.Error(c.Writer, 500, "6001", "Rate limiter error", err.Error())
-			c.Abort()
+		if strings.HasPrefix(c.Request.URL.Path, "/internal/") {
+			c.Next()
 			return
 		}
-		if n == 1 {
-			_ = rl.Expire(ctx, key, time.Minute)
-		}
-		if n > int64(limit) {
-			httputil.Error(c.Writer, 429, "1001", "Too Many Requests", nil)
+		admitted := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			admitted = true
+			c.Next()
+		})
+		h := smiddleware.RateLimitWith(rl, smiddleware.RateLimitOptions{
+			Read:           defaultRead,
+			Write:          defaultWrite,
+			Overrides:      perPrefixOverride,
+			Route:          c.FullPath(),
+			TrustedProxies: trustedProxies,
+		}, next)
+		h.ServeHTTP(c.Writer, c.Request)
+		if !admitted {
 			c.Abort()
-			return
 		}
-		c.Next()
 	}
 }
@@ -19,20 +19,33 @@ func NewAuditRepo(db *pgxpool.Pool) *AuditRepo {
 }
 
 func (r *AuditRepo) Log(ctx context.Context, tenantID string, userID *uuid.UUID, action, resourceType string, resourceID *uuid.UUID, newValues any) error {
-	var uid *uuid.UUID
-	if userID != nil {
-		uid = userID
-	}
-	var rid *uuid.UUID
-	if resourceID != nil {
-		rid = resourceID
-	}
-	j, _ := json.Marshal(newValues)
-	_, err := r.db.Exec(ctx, `
-		INSERT INTO audit_logs (tenant_id, user_id, action, resource_type, resource_id, new_values)
-		VALUES ($1, $2, $3, $4, $5, $6::jsonb)
-	`, tenantID, uid, action, resourceType, rid, string(j))
-	return err
+	return r.LogChange(ctx, tenantID, userID, action, resourceType, resourceID, nil, newValues)
+}
+
+// LogChange records an update together with the state it replaced. Every row
+// is appended to the tenant's hash chain.
+func (r *AuditRepo) LogChange(ctx context.Context, tenantID string, userID *uuid.UUID, action, resourceType string, resourceID *uuid.UUID, oldValues, newValues any) error {
+	l := &AuditLog{
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		UserID:       userID,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+	}
+	if oldValues != nil {
+		l.OldValues, _ = json.Marshal(oldValues)
+	}
+	l.NewValues, _ = json.Marshal(newValues)
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	if err := appendToChain(ctx, tx, l); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 type AuditLog struct {
@@ -44,6 +57,7 @@ type AuditLog struct {
 	ResourceID   *uuid.UUID      `json:"resource_id"`
 	OldValues    json.RawMessage `json:"old_values"`
 	NewValues    json.RawMessage `json:"new_values"`
+	Changes      []FieldChange   `json:"changes,omitempty"`
 	CreatedAt    time.Time       `json:"created_at"`
 }
 
@@ -118,6 +132,7 @@ func (r *AuditRepo) List(ctx context.Context, tenantID string, p ListParams, lim
 		}
 		it.OldValues = json.RawMessage(oldb)
 		it.NewValues = json.RawMessage(newb)
+		it.Changes = fieldChanges(it.OldValues, it.NewValues)
 		out = append(out, it)
 	}
 	return out, total, nil
@@ -168,16 +183,42 @@ func (r *AuditRepo) Search(ctx context.Context, tenantID string, q string, limit
 		}
 		it.OldValues = json.RawMessage(oldb)
 		it.NewValues = json.RawMessage(newb)
+		it.Changes = fieldChanges(it.OldValues, it.NewValues)
 		out = append(out, it)
 	}
 	return out, total, nil
 }
 
+// CleanupOlderThan deletes entries older than cutoff. The newest deleted link
+// of each chain is archived first so verification can resume after it.
 func (r *AuditRepo) CleanupOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
-	cmd, err := r.db.Exec(ctx, `DELETE FROM audit_logs WHERE created_at < $1`, cutoff)
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO audit_chain_archives (tenant_id, seq, hash, rows_deleted)
+		SELECT tenant_id, MAX(seq), (ARRAY_AGG(hash ORDER BY seq DESC))[1], COUNT(1)
+		FROM audit_logs WHERE created_at < $1 AND seq IS NOT NULL
+		GROUP BY tenant_id
+		ON CONFLICT (tenant_id, seq) DO NOTHING
+	`, cutoff); err != nil {
+		return 0, err
+	}
+	// Entries are removed up to the archived link rather than by timestamp so
+	// the remaining chain never starts with a gap.
+	cmd, err := tx.Exec(ctx, `
+		DELETE FROM audit_logs l
+		WHERE (l.seq IS NULL AND l.created_at < $1)
+		   OR l.seq <= (SELECT MAX(a.seq) FROM audit_chain_archives a WHERE a.tenant_id = l.tenant_id)
+	`, cutoff)
 	if err != nil {
 		return 0, err
 	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
 	return cmd.RowsAffected(), nil
 }
 
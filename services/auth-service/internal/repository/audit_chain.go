@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// maxChainProblems bounds a verification report on a badly damaged chain.
+const maxChainProblems = 100
+
+// FieldChange is one top-level field that differs between old_values and
+// new_values.
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+type ChainProblem struct {
+	Seq    int64      `json:"seq"`
+	ID     *uuid.UUID `json:"id,omitempty"`
+	Reason string     `json:"reason"`
+}
+
+// ChainReport is the outcome of walking a tenant's audit chain from the last
+// archived link to the current head.
+type ChainReport struct {
+	TenantID string         `json:"tenant_id"`
+	Valid    bool           `json:"valid"`
+	FromSeq  int64          `json:"from_seq"`
+	HeadSeq  int64          `json:"head_seq"`
+	Checked  int            `json:"checked"`
+	Problems []ChainProblem `json:"problems"`
+}
+
+func (r *ChainReport) problem(seq int64, id *uuid.UUID, reason string) {
+	if len(r.Problems) < maxChainProblems {
+		r.Problems = append(r.Problems, ChainProblem{Seq: seq, ID: id, Reason: reason})
+	}
+	r.Valid = false
+}
+
+// appendToChain links l after the tenant's current head and inserts it. The
+// head row is locked so concurrent writers are serialised per tenant.
+func appendToChain(ctx context.Context, tx pgx.Tx, l *AuditLog) error {
+	if _, err := tx.Exec(ctx, `INSERT INTO audit_chain_heads (tenant_id) VALUES ($1) ON CONFLICT (tenant_id) DO NOTHING`, l.TenantID); err != nil {
+		return err
+	}
+	var seq int64
+	var prev string
+	if err := tx.QueryRow(ctx, `SELECT seq, hash FROM audit_chain_heads WHERE tenant_id=$1 FOR UPDATE`, l.TenantID).Scan(&seq, &prev); err != nil {
+		return err
+	}
+	seq++
+	l.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	hash := chainHash(prev, seq, l)
+	var oldValues *string
+	if len(l.OldValues) > 0 {
+		s := string(l.OldValues)
+		oldValues = &s
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO audit_logs (id, tenant_id, user_id, action, resource_type, resource_id, old_values, new_values, created_at, seq, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::jsonb, $8::jsonb, $9, $10, $11, $12)
+	`, l.ID, l.TenantID, l.UserID, l.Action, l.ResourceType, l.ResourceID, oldValues, string(l.NewValues), l.CreatedAt, seq, prev, hash); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, `UPDATE audit_chain_heads SET seq=$2, hash=$3, updated_at=NOW() WHERE tenant_id=$1`, l.TenantID, seq, hash)
+	return err
+}
+
+// VerifyChain recomputes every link after the last archived one and reports
+// missing sequence numbers, broken links, edited rows and a truncated tail.
+func (r *AuditRepo) VerifyChain(ctx context.Context, tenantID string) (*ChainReport, error) {
+	rep := &ChainReport{TenantID: tenantID, Valid: true, Problems: []ChainProblem{}}
+	var anchor string
+	err := r.db.QueryRow(ctx, `SELECT seq, hash FROM audit_chain_archives WHERE tenant_id=$1 ORDER BY seq DESC LIMIT 1`, tenantID).Scan(&rep.FromSeq, &anchor)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	var head string
+	err = r.db.QueryRow(ctx, `SELECT seq, hash FROM audit_chain_heads WHERE tenant_id=$1`, tenantID).Scan(&rep.HeadSeq, &head)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT id, tenant_id, user_id, action, resource_type, resource_id, old_values, new_values, created_at, seq, prev_hash, hash
+		FROM audit_logs WHERE tenant_id=$1 AND seq > $2 AND seq <= $3 ORDER BY seq
+	`, tenantID, rep.FromSeq, rep.HeadSeq)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	expected, prev := rep.FromSeq+1, anchor
+	for rows.Next() {
+		var it AuditLog
+		var oldb, newb []byte
+		var seq int64
+		var prevHash, hash string
+		if err := rows.Scan(&it.ID, &it.TenantID, &it.UserID, &it.Action, &it.ResourceType, &it.ResourceID, &oldb, &newb, &it.CreatedAt, &seq, &prevHash, &hash); err != nil {
+			return nil, err
+		}
+		it.OldValues = json.RawMessage(oldb)
+		it.NewValues = json.RawMessage(newb)
+		rep.Checked++
+		id := it.ID
+		switch {
+		case seq != expected:
+			rep.problem(expected, nil, fmt.Sprintf("missing entries %d to %d", expected, seq-1))
+		case prevHash != prev:
+			rep.problem(seq, &id, "previous hash does not match")
+		}
+		if chainHash(prevHash, seq, &it) != hash {
+			rep.problem(seq, &id, "entry was modified")
+		}
+		expected, prev = seq+1, hash
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if expected <= rep.HeadSeq {
+		rep.problem(expected, nil, fmt.Sprintf("missing entries %d to %d", expected, rep.HeadSeq))
+	} else if rep.HeadSeq > rep.FromSeq && prev != head {
+		rep.problem(rep.HeadSeq, nil, "chain head does not match the last entry")
+	}
+	return rep, nil
+}
+
+func chainHash(prev string, seq int64, l *AuditLog) string {
+	h := sha256.New()
+	for _, f := range []string{
+		prev,
+		strconv.FormatInt(seq, 10),
+		l.ID.String(),
+		l.TenantID,
+		uuidString(l.UserID),
+		l.Action,
+		l.ResourceType,
+		uuidString(l.ResourceID),
+		string(canonicalJSON(l.OldValues)),
+		string(canonicalJSON(l.NewValues)),
+		l.CreatedAt.UTC().Format(time.RFC3339Nano),
+	} {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// canonicalJSON re-encodes b with sorted keys and no whitespace, so values
+// hash the same before and after Postgres normalises them as jsonb.
+func canonicalJSON(b json.RawMessage) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return b
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return b
+	}
+	return out
+}
+
+// fieldChanges compares the top-level fields of two JSON objects. It returns
+// nil when there is no previous state to compare against.
+func fieldChanges(oldValues, newValues json.RawMessage) []FieldChange {
+	var before, after map[string]json.RawMessage
+	if json.Unmarshal(oldValues, &before) != nil || before == nil {
+		return nil
+	}
+	if json.Unmarshal(newValues, &after) != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var out []FieldChange
+	for _, k := range keys {
+		o, n := before[k], after[k]
+		if string(canonicalJSON(o)) != string(canonicalJSON(n)) {
+			out = append(out, FieldChange{Field: k, Old: o, New: n})
+		}
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestChainHash_IgnoresJSONBFormatting(t *testing.T) {
+	l := AuditLog{
+		ID:        uuid.New(),
+		TenantID:  "t1",
+		Action:    "role.update",
+		NewValues: json.RawMessage(`{"name":"guru","perms":["a","b"]}`),
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 123456000, time.UTC),
+	}
+	want := chainHash("prev", 7, &l)
+	// Postgres returns jsonb with its own key order and spacing.
+	l.NewValues = json.RawMessage(`{"perms": ["a", "b"], "name": "guru"}`)
+	l.CreatedAt = l.CreatedAt.In(time.FixedZone("WIB", 7*3600))
+	if got := chainHash("prev", 7, &l); got != want {
+		t.Fatalf("hash changed after normalisation")
+	}
+	if chainHash("other", 7, &l) == want || chainHash("prev", 8, &l) == want {
+		t.Fatalf("hash must cover the link and sequence")
+	}
+	l.NewValues = json.RawMessage(`{"name":"kepala sekolah","perms":["a","b"]}`)
+	if chainHash("prev", 7, &l) == want {
+		t.Fatalf("hash must cover the values")
+	}
+}
+
+func TestFieldChanges(t *testing.T) {
+	changes := fieldChanges(json.RawMessage(`{"min_length":8,"require_digit":true,"old":1}`), json.RawMessage(`{"min_length":12,"require_digit":true,"new":2}`))
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", changes)
+	}
+	if changes[0].Field != "min_length" || string(changes[0].Old) != "8" || string(changes[0].New) != "12" {
+		t.Fatalf("unexpected first change %+v", changes[0])
+	}
+	if changes[1].Field != "new" || changes[1].Old != nil {
+		t.Fatalf("added field must have no old value, got %+v", changes[1])
+	}
+	if fieldChanges(nil, json.RawMessage(`{"a":1}`)) != nil || fieldChanges(json.RawMessage(`null`), json.RawMessage(`{"a":1}`)) != nil {
+		t.Fatalf("entries without old values have no diff")
+	}
+}
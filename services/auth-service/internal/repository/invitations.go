@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
+)
+
+// Invitation lets an inactive user set their first password.
+type Invitation struct {
+	ID         uuid.UUID
+	TenantID   string
+	UserID     uuid.UUID
+	TokenHash  string
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+type InvitationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewInvitationRepo(db *pgxpool.Pool) *InvitationRepo {
+	return &InvitationRepo{db: db}
+}
+
+const invitationCols = `id, tenant_id, user_id, token_hash, expires_at, accepted_at, revoked_at, created_at`
+
+func scanInvitation(row pgx.Row) (*Invitation, error) {
+	var inv Invitation
+	if err := row.Scan(&inv.ID, &inv.TenantID, &inv.UserID, &inv.TokenHash, &inv.ExpiresAt, &inv.AcceptedAt, &inv.RevokedAt, &inv.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// Create stores a new invitation and revokes the user's outstanding ones, so
+// only the most recently sent token works.
+func (r *InvitationRepo) Create(ctx context.Context, tenantID string, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*Invitation, error) {
+	tx, err := outbox.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	inv, err := createInvitation(ctx, tx, tenantID, userID, tokenHash, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+func createInvitation(ctx context.Context, tx pgx.Tx, tenantID string, userID uuid.UUID, tokenHash string, expiresAt time.Time) (*Invitation, error) {
+	if _, err := tx.Exec(ctx, `
+		UPDATE user_invitations SET revoked_at=NOW()
+		WHERE user_id=$1 AND accepted_at IS NULL AND revoked_at IS NULL
+	`, userID); err != nil {
+		return nil, err
+	}
+	return scanInvitation(tx.QueryRow(ctx, `
+		INSERT INTO user_invitations (tenant_id, user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+invitationCols, tenantID, userID, tokenHash, expiresAt))
+}
+
+// FindValidByTokenHash returns the invitation if it is neither expired,
+// revoked nor accepted.
+func (r *InvitationRepo) FindValidByTokenHash(ctx context.Context, tokenHash string, now time.Time) (*Invitation, error) {
+	return scanInvitation(r.db.QueryRow(ctx, `
+		SELECT `+invitationCols+`
+		FROM user_invitations
+		WHERE token_hash=$1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $2
+		LIMIT 1
+	`, tokenHash, now))
+}
+
+// Latest returns the most recent invitation of the user.
+func (r *InvitationRepo) Latest(ctx context.Context, userID uuid.UUID) (*Invitation, error) {
+	return scanInvitation(r.db.QueryRow(ctx, `
+		SELECT `+invitationCols+`
+		FROM user_invitations
+		WHERE user_id=$1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, userID))
+}
+
+// Accept marks the invitation used and reports whether it was still open, so
+// a token can only be redeemed once.
+func (r *InvitationRepo) Accept(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	tag, err := outbox.Conn(ctx, r.db).Exec(ctx, `
+		UPDATE user_invitations SET accepted_at=$2
+		WHERE id=$1 AND accepted_at IS NULL AND revoked_at IS NULL
+	`, id, at)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MFASecret is a user's TOTP enrolment. EnabledAt is nil until the user has
+// proven possession of the secret once.
+type MFASecret struct {
+	UserID       uuid.UUID
+	Secret       string
+	EnabledAt    *time.Time
+	LastUsedStep int64
+}
+
+type MFARepo struct {
+	db *pgxpool.Pool
+}
+
+func NewMFARepo(db *pgxpool.Pool) *MFARepo {
+	return &MFARepo{db: db}
+}
+
+func (r *MFARepo) Get(ctx context.Context, userID uuid.UUID) (*MFASecret, error) {
+	var s MFASecret
+	err := r.db.QueryRow(ctx, `
+		SELECT user_id, secret, enabled_at, last_used_step FROM user_mfa WHERE user_id=$1
+	`, userID).Scan(&s.UserID, &s.Secret, &s.EnabledAt, &s.LastUsedStep)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SetPending stores a new unconfirmed secret. An enabled enrolment is left
+// untouched; it reports false in that case.
+func (r *MFARepo) SetPending(ctx context.Context, userID uuid.UUID, secret string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		INSERT INTO user_mfa (user_id, secret) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET secret=EXCLUDED.secret, last_used_step=0, updated_at=NOW()
+		WHERE user_mfa.enabled_at IS NULL
+	`, userID, secret)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *MFARepo) Enable(ctx context.Context, userID uuid.UUID, step int64) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE user_mfa SET enabled_at=NOW(), last_used_step=$2, updated_at=NOW() WHERE user_id=$1
+	`, userID, step)
+	return err
+}
+
+// UseStep records step as consumed. It reports false when that step or a
+// later one was already used, so a code cannot be replayed.
+func (r *MFARepo) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE user_mfa SET last_used_step=$2, updated_at=NOW() WHERE user_id=$1 AND last_used_step < $2
+	`, userID, step)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *MFARepo) Delete(ctx context.Context, userID uuid.UUID) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id=$1`, userID); err != nil {
+		return err
+	}
+	_, err := r.db.Exec(ctx, `DELETE FROM user_mfa WHERE user_id=$1`, userID)
+	return err
+}
+
+// ReplaceRecoveryCodes discards every existing code of the user.
+func (r *MFARepo) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, hashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id=$1`, userID); err != nil {
+		return err
+	}
+	for _, h := range hashes {
+		if _, err := tx.Exec(ctx, `INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, h); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// UseRecoveryCode marks the matching unused code as used and reports whether
+// one was found.
+func (r *MFARepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE mfa_recovery_codes SET used_at=NOW()
+		WHERE id = (
+			SELECT id FROM mfa_recovery_codes
+			WHERE user_id=$1 AND code_hash=$2 AND used_at IS NULL
+			LIMIT 1
+		) AND used_at IS NULL
+	`, userID, hash)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *MFARepo) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id=$1 AND used_at IS NULL
+	`, userID).Scan(&n)
+	return n, err
+}
+
+func (r *MFARepo) PolicyRoles(ctx context.Context, tenantID string) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT role_name FROM mfa_policies WHERE tenant_id=$1 ORDER BY role_name
+	`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		out = append(out, name)
+	}
+	return out, rows.Err()
+}
+
+func (r *MFARepo) SetPolicyRoles(ctx context.Context, tenantID string, roles []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_policies WHERE tenant_id=$1`, tenantID); err != nil {
+		return err
+	}
+	for _, name := range roles {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO mfa_policies (tenant_id, role_name) VALUES ($1, $2) ON CONFLICT DO NOTHING
+		`, tenantID, name); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OIDCProvider is a tenant's external identity provider. GroupRoles maps the
+// IdP group names found in GroupsClaim to local role names.
+type OIDCProvider struct {
+	ID              uuid.UUID         `json:"id"`
+	TenantID        string            `json:"tenant_id"`
+	Name            string            `json:"name"`
+	Issuer          string            `json:"issuer"`
+	ClientID        string            `json:"client_id"`
+	ClientSecret    string            `json:"-"`
+	Scopes          []string          `json:"scopes"`
+	AllowedDomains  []string          `json:"allowed_domains"`
+	GroupsClaim     string            `json:"groups_claim"`
+	GroupRoles      map[string]string `json:"group_roles"`
+	DefaultRole     string            `json:"default_role"`
+	JITProvisioning bool              `json:"jit_provisioning"`
+	Enabled         bool              `json:"enabled"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+type OIDCRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewOIDCRepo(db *pgxpool.Pool) *OIDCRepo {
+	return &OIDCRepo{db: db}
+}
+
+const oidcProviderColumns = `id, tenant_id, name, issuer, client_id, client_secret, scopes, allowed_domains,
+	groups_claim, group_roles, default_role, jit_provisioning, enabled, created_at, updated_at`
+
+func scanOIDCProvider(row pgx.Row) (*OIDCProvider, error) {
+	var p OIDCProvider
+	if err := row.Scan(&p.ID, &p.TenantID, &p.Name, &p.Issuer, &p.ClientID, &p.ClientSecret, &p.Scopes, &p.AllowedDomains,
+		&p.GroupsClaim, &p.GroupRoles, &p.DefaultRole, &p.JITProvisioning, &p.Enabled, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *OIDCRepo) GetProvider(ctx context.Context, tenantID, name string) (*OIDCProvider, error) {
+	return scanOIDCProvider(r.db.QueryRow(ctx, `
+		SELECT `+oidcProviderColumns+` FROM oidc_providers WHERE tenant_id=$1 AND name=$2
+	`, tenantID, name))
+}
+
+func (r *OIDCRepo) ListProviders(ctx context.Context, tenantID string) ([]OIDCProvider, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+oidcProviderColumns+` FROM oidc_providers WHERE tenant_id=$1 ORDER BY name
+	`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []OIDCProvider{}
+	for rows.Next() {
+		p, err := scanOIDCProvider(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *p)
+	}
+	return out, rows.Err()
+}
+
+// UpsertProvider creates or replaces the provider named p.Name. An empty
+// ClientSecret keeps the stored one so it need not be resent on every edit.
+func (r *OIDCRepo) UpsertProvider(ctx context.Context, p *OIDCProvider) (*OIDCProvider, error) {
+	if p.GroupRoles == nil {
+		p.GroupRoles = map[string]string{}
+	}
+	if p.AllowedDomains == nil {
+		p.AllowedDomains = []string{}
+	}
+	return scanOIDCProvider(r.db.QueryRow(ctx, `
+		INSERT INTO oidc_providers (tenant_id, name, issuer, client_id, client_secret, scopes, allowed_domains,
+			groups_claim, group_roles, default_role, jit_provisioning, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (tenant_id, name) DO UPDATE SET
+			issuer=EXCLUDED.issuer, client_id=EXCLUDED.client_id,
+			client_secret=COALESCE(NULLIF(EXCLUDED.client_secret, ''), oidc_providers.client_secret),
+			scopes=EXCLUDED.scopes, allowed_domains=EXCLUDED.allowed_domains,
+			groups_claim=EXCLUDED.groups_claim, group_roles=EXCLUDED.group_roles,
+			default_role=EXCLUDED.default_role, jit_provisioning=EXCLUDED.jit_provisioning,
+			enabled=EXCLUDED.enabled, updated_at=NOW()
+		RETURNING `+oidcProviderColumns+`
+	`, p.TenantID, p.Name, p.Issuer, p.ClientID, p.ClientSecret, p.Scopes, p.AllowedDomains,
+		p.GroupsClaim, p.GroupRoles, p.DefaultRole, p.JITProvisioning, p.Enabled))
+}
+
+func (r *OIDCRepo) DeleteProvider(ctx context.Context, tenantID, name string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM oidc_providers WHERE tenant_id=$1 AND name=$2`, tenantID, name)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// FindIdentity returns the local user linked to the IdP subject.
+func (r *OIDCRepo) FindIdentity(ctx context.Context, providerID uuid.UUID, subject string) (uuid.UUID, error) {
+	var userID uuid.UUID
+	err := r.db.QueryRow(ctx, `
+		SELECT user_id FROM user_identities WHERE provider_id=$1 AND subject=$2
+	`, providerID, subject).Scan(&userID)
+	return userID, err
+}
+
+// LinkIdentity records the subject as belonging to userID and stamps the
+// login time.
+func (r *OIDCRepo) LinkIdentity(ctx context.Context, providerID uuid.UUID, subject string, userID uuid.UUID, email string) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_identities (provider_id, subject, user_id, email) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider_id, subject) DO UPDATE SET email=EXCLUDED.email, last_login_at=NOW()
+	`, providerID, subject, userID, email)
+	return err
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PasswordPolicy is a tenant's password and lockout rules. MaxAgeDays of zero
+// disables expiry; passwords last changed before RotateBefore are expired
+// regardless of age.
+type PasswordPolicy struct {
+	TenantID         string     `json:"tenant_id"`
+	MinLength        int        `json:"min_length"`
+	RequireUpper     bool       `json:"require_upper"`
+	RequireLower     bool       `json:"require_lower"`
+	RequireDigit     bool       `json:"require_digit"`
+	RequireSymbol    bool       `json:"require_symbol"`
+	HistoryDepth     int        `json:"history_depth"`
+	MaxAgeDays       int        `json:"max_age_days"`
+	RotateBefore     *time.Time `json:"rotate_before"`
+	LockoutThreshold int        `json:"lockout_threshold"`
+	LockoutSeconds   int        `json:"lockout_seconds"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type PasswordPolicyRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPasswordPolicyRepo(db *pgxpool.Pool) *PasswordPolicyRepo {
+	return &PasswordPolicyRepo{db: db}
+}
+
+const passwordPolicyColumns = `tenant_id, min_length, require_upper, require_lower, require_digit, require_symbol,
+	history_depth, max_age_days, rotate_before, lockout_threshold, lockout_seconds, updated_at`
+
+func (r *PasswordPolicyRepo) Get(ctx context.Context, tenantID string) (*PasswordPolicy, error) {
+	var p PasswordPolicy
+	err := r.db.QueryRow(ctx, `
+		SELECT `+passwordPolicyColumns+` FROM password_policies WHERE tenant_id=$1
+	`, tenantID).Scan(&p.TenantID, &p.MinLength, &p.RequireUpper, &p.RequireLower, &p.RequireDigit, &p.RequireSymbol,
+		&p.HistoryDepth, &p.MaxAgeDays, &p.RotateBefore, &p.LockoutThreshold, &p.LockoutSeconds, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *PasswordPolicyRepo) Upsert(ctx context.Context, p *PasswordPolicy) (*PasswordPolicy, error) {
+	var out PasswordPolicy
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO password_policies (tenant_id, min_length, require_upper, require_lower, require_digit, require_symbol,
+			history_depth, max_age_days, rotate_before, lockout_threshold, lockout_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			min_length=EXCLUDED.min_length, require_upper=EXCLUDED.require_upper,
+			require_lower=EXCLUDED.require_lower, require_digit=EXCLUDED.require_digit,
+			require_symbol=EXCLUDED.require_symbol, history_depth=EXCLUDED.history_depth,
+			max_age_days=EXCLUDED.max_age_days, rotate_before=EXCLUDED.rotate_before,
+			lockout_threshold=EXCLUDED.lockout_threshold, lockout_seconds=EXCLUDED.lockout_seconds,
+			updated_at=NOW()
+		RETURNING `+passwordPolicyColumns+`
+	`, p.TenantID, p.MinLength, p.RequireUpper, p.RequireLower, p.RequireDigit, p.RequireSymbol,
+		p.HistoryDepth, p.MaxAgeDays, p.RotateBefore, p.LockoutThreshold, p.LockoutSeconds).Scan(
+		&out.TenantID, &out.MinLength, &out.RequireUpper, &out.RequireLower, &out.RequireDigit, &out.RequireSymbol,
+		&out.HistoryDepth, &out.MaxAgeDays, &out.RotateBefore, &out.LockoutThreshold, &out.LockoutSeconds, &out.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -42,6 +42,10 @@ func ensureMigrationsPR(t *testing.T, db *pgxpool.Pool) {
 	if err == nil {
 		_, _ = db.Exec(ctx, string(b2))
 	}
+	bChain, err := os.ReadFile("../../migrations/011_audit_chain.up.sql")
+	if err == nil {
+		_, _ = db.Exec(ctx, string(bChain))
+	}
 	b3, err := os.ReadFile("../../migrations/003_password_resets.up.sql")
 	if err == nil {
 		_, _ = db.Exec(ctx, string(b3))
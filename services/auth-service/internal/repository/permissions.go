@@ -106,3 +106,50 @@ func (r *PermissionsRepo) GetByRole(ctx context.Context, roleID uuid.UUID) ([]Pe
 	}
 	return out, nil
 }
+
+func (r *PermissionsRepo) FindByID(ctx context.Context, id uuid.UUID) (*Permission, error) {
+	var out Permission
+	err := r.db.QueryRow(ctx, `
+		SELECT id, resource, action
+		FROM permissions
+		WHERE id=$1
+	`, id).Scan(&out.ID, &out.Resource, &out.Action)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r *PermissionsRepo) RevokeFromRole(ctx context.Context, roleID, permissionID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		DELETE FROM role_permissions WHERE role_id=$1 AND permission_id=$2
+	`, roleID, permissionID)
+	return err
+}
+
+// GetByUser returns the distinct permissions granted to userID through its
+// active roles in tenantID.
+func (r *PermissionsRepo) GetByUser(ctx context.Context, userID uuid.UUID, tenantID string) ([]Permission, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT p.id, p.resource, p.action
+		FROM permissions p
+		JOIN role_permissions rp ON rp.permission_id = p.id
+		JOIN roles r ON r.id = rp.role_id
+		JOIN user_roles ur ON ur.role_id = r.id
+		WHERE ur.user_id = $1 AND r.tenant_id = $2 AND r.deleted_at IS NULL
+		ORDER BY p.resource ASC, p.action ASC
+	`, userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Permission
+	for rows.Next() {
+		var p Permission
+		if err := rows.Scan(&p.ID, &p.Resource, &p.Action); err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
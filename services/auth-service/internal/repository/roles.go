@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -49,6 +50,29 @@ func (r *RolesRepo) FindRoleByName(ctx context.Context, tenantID, name string) (
 	return &out, nil
 }
 
+// FindRolesByNames resolves role names of a tenant case-insensitively. The
+// result is keyed by lower-cased name; unknown names are absent.
+func (r *RolesRepo) FindRolesByNames(ctx context.Context, tenantID string, names []string) (map[string]Role, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, tenant_id, name, is_system_role
+		FROM roles
+		WHERE tenant_id=$1 AND lower(name) = ANY($2) AND deleted_at IS NULL
+	`, tenantID, names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string]Role{}
+	for rows.Next() {
+		var rr Role
+		if err := rows.Scan(&rr.ID, &rr.TenantID, &rr.Name, &rr.IsSystemRole); err != nil {
+			return nil, err
+		}
+		out[strings.ToLower(rr.Name)] = rr
+	}
+	return out, rows.Err()
+}
+
 func (r *RolesRepo) ListUserRoles(ctx context.Context, userID uuid.UUID) ([]Role, error) {
 	rows, err := r.db.Query(ctx, `
 		SELECT r.id, r.tenant_id, r.name, r.is_system_role
@@ -86,3 +110,67 @@ func (r *RolesRepo) UnassignUserRole(ctx context.Context, userID, roleID uuid.UU
 	`, userID, roleID)
 	return err
 }
+
+func (r *RolesRepo) FindRoleByID(ctx context.Context, id uuid.UUID) (*Role, error) {
+	var out Role
+	err := r.db.QueryRow(ctx, `
+		SELECT id, tenant_id, name, is_system_role
+		FROM roles
+		WHERE id=$1 AND deleted_at IS NULL
+		LIMIT 1
+	`, id).Scan(&out.ID, &out.TenantID, &out.Name, &out.IsSystemRole)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r *RolesRepo) ListRoles(ctx context.Context, tenantID string, limit, offset int) ([]Role, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT id, tenant_id, name, is_system_role
+		FROM roles
+		WHERE tenant_id=$1 AND deleted_at IS NULL
+		ORDER BY name ASC
+		LIMIT $2 OFFSET $3
+	`, tenantID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Role
+	for rows.Next() {
+		var rr Role
+		if err := rows.Scan(&rr.ID, &rr.TenantID, &rr.Name, &rr.IsSystemRole); err != nil {
+			return nil, err
+		}
+		out = append(out, rr)
+	}
+	return out, nil
+}
+
+func (r *RolesRepo) UpdateRoleName(ctx context.Context, id uuid.UUID, name string) (*Role, error) {
+	var out Role
+	err := r.db.QueryRow(ctx, `
+		UPDATE roles SET name=$2, updated_at=NOW()
+		WHERE id=$1 AND deleted_at IS NULL
+		RETURNING id, tenant_id, name, is_system_role
+	`, id, name).Scan(&out.ID, &out.TenantID, &out.Name, &out.IsSystemRole)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r *RolesRepo) SoftDeleteRole(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE roles SET deleted_at=NOW(), updated_at=NOW()
+		WHERE id=$1 AND deleted_at IS NULL
+	`, id)
+	return err
+}
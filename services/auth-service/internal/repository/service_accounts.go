@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// serviceAccountPasswordHash is not a valid bcrypt hash, so a service account
+// can never sign in with a password.
+const serviceAccountPasswordHash = "!service-account"
+
+// ServiceAccount is a machine identity of a tenant. Its ID is also the ID of
+// the backing users row, which holds its roles.
+type ServiceAccount struct {
+	ID          uuid.UUID
+	TenantID    string
+	Name        string
+	Description string
+	IsActive    bool
+	CreatedBy   *uuid.UUID
+	CreatedAt   time.Time
+}
+
+// APIKey is a credential of a service account. Only the SHA-256 of the key is
+// stored; Prefix identifies it in logs and lookups.
+type APIKey struct {
+	ID               uuid.UUID
+	TenantID         string
+	ServiceAccountID uuid.UUID
+	Name             string
+	Prefix           string
+	KeyHash          string
+	Scopes           []string
+	RateLimit        int
+	ExpiresAt        *time.Time
+	LastUsedAt       *time.Time
+	RevokedAt        *time.Time
+	CreatedBy        *uuid.UUID
+	CreatedAt        time.Time
+}
+
+type CreateServiceAccountParams struct {
+	TenantID    string
+	Name        string
+	Description string
+	RoleIDs     []uuid.UUID
+	CreatedBy   *uuid.UUID
+}
+
+type CreateAPIKeyParams struct {
+	TenantID         string
+	ServiceAccountID uuid.UUID
+	Name             string
+	Prefix           string
+	KeyHash          string
+	Scopes           []string
+	RateLimit        int
+	ExpiresAt        *time.Time
+	CreatedBy        *uuid.UUID
+}
+
+type ServiceAccountRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewServiceAccountRepo(db *pgxpool.Pool) *ServiceAccountRepo {
+	return &ServiceAccountRepo{db: db}
+}
+
+const serviceAccountCols = `sa.id, sa.tenant_id, sa.name, sa.description, u.is_active, sa.created_by, sa.created_at`
+
+const apiKeyCols = `id, tenant_id, service_account_id, name, prefix, key_hash, scopes, rate_limit, expires_at, last_used_at, revoked_at, created_by, created_at`
+
+func scanServiceAccount(row pgx.Row) (*ServiceAccount, error) {
+	var sa ServiceAccount
+	if err := row.Scan(&sa.ID, &sa.TenantID, &sa.Name, &sa.Description, &sa.IsActive, &sa.CreatedBy, &sa.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &sa, nil
+}
+
+func scanAPIKey(row pgx.Row) (*APIKey, error) {
+	var k APIKey
+	if err := row.Scan(&k.ID, &k.TenantID, &k.ServiceAccountID, &k.Name, &k.Prefix, &k.KeyHash, &k.Scopes, &k.RateLimit, &k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt, &k.CreatedBy, &k.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// Create inserts the backing user, the service account and its role
+// assignments in one transaction. A name already used in the tenant yields
+// ErrDuplicate.
+func (r *ServiceAccountRepo) Create(ctx context.Context, p CreateServiceAccountParams) (*ServiceAccount, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	id := uuid.New()
+	email := "sa-" + id.String() + "@service-account.invalid"
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO users (id, tenant_id, email, password_hash)
+		VALUES ($1, $2, $3, $4)
+	`, id, p.TenantID, email, serviceAccountPasswordHash); err != nil {
+		return nil, err
+	}
+	sa := ServiceAccount{ID: id, TenantID: p.TenantID, Name: p.Name, Description: p.Description, IsActive: true, CreatedBy: p.CreatedBy}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO service_accounts (id, tenant_id, name, description, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`, id, p.TenantID, p.Name, p.Description, p.CreatedBy).Scan(&sa.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicate, p.Name)
+		}
+		return nil, err
+	}
+	for _, roleID := range p.RoleIDs {
+		if _, err := tx.Exec(ctx, `INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, id, roleID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &sa, nil
+}
+
+func (r *ServiceAccountRepo) FindByID(ctx context.Context, id uuid.UUID) (*ServiceAccount, error) {
+	return scanServiceAccount(r.db.QueryRow(ctx, `
+		SELECT `+serviceAccountCols+`
+		FROM service_accounts sa JOIN users u ON u.id = sa.id
+		WHERE sa.id=$1 AND sa.deleted_at IS NULL
+	`, id))
+}
+
+func (r *ServiceAccountRepo) List(ctx context.Context, tenantID string) ([]ServiceAccount, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+serviceAccountCols+`
+		FROM service_accounts sa JOIN users u ON u.id = sa.id
+		WHERE sa.tenant_id=$1 AND sa.deleted_at IS NULL
+		ORDER BY sa.name
+	`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []ServiceAccount{}
+	for rows.Next() {
+		sa, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *sa)
+	}
+	return out, rows.Err()
+}
+
+// Delete removes the service account together with its backing user and
+// revokes every key it still holds.
+func (r *ServiceAccountRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	if _, err := tx.Exec(ctx, `UPDATE api_keys SET revoked_at=NOW() WHERE service_account_id=$1 AND revoked_at IS NULL`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE service_accounts SET deleted_at=NOW() WHERE id=$1 AND deleted_at IS NULL`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE users SET deleted_at=NOW(), is_active=FALSE, updated_at=NOW() WHERE id=$1 AND deleted_at IS NULL`, id); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *ServiceAccountRepo) CreateKey(ctx context.Context, p CreateAPIKeyParams) (*APIKey, error) {
+	return scanAPIKey(r.db.QueryRow(ctx, `
+		INSERT INTO api_keys (tenant_id, service_account_id, name, prefix, key_hash, scopes, rate_limit, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+apiKeyCols, p.TenantID, p.ServiceAccountID, p.Name, p.Prefix, p.KeyHash, p.Scopes, p.RateLimit, p.ExpiresAt, p.CreatedBy))
+}
+
+func (r *ServiceAccountRepo) FindKeyByID(ctx context.Context, id uuid.UUID) (*APIKey, error) {
+	return scanAPIKey(r.db.QueryRow(ctx, `SELECT `+apiKeyCols+` FROM api_keys WHERE id=$1`, id))
+}
+
+func (r *ServiceAccountRepo) FindKeyByPrefix(ctx context.Context, prefix string) (*APIKey, error) {
+	return scanAPIKey(r.db.QueryRow(ctx, `SELECT `+apiKeyCols+` FROM api_keys WHERE prefix=$1`, prefix))
+}
+
+func (r *ServiceAccountRepo) ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]APIKey, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+apiKeyCols+`
+		FROM api_keys
+		WHERE service_account_id=$1
+		ORDER BY created_at DESC
+	`, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []APIKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *k)
+	}
+	return out, rows.Err()
+}
+
+// RevokeKey reports whether the key was still active.
+func (r *ServiceAccountRepo) RevokeKey(ctx context.Context, id uuid.UUID) (bool, error) {
+	tag, err := r.db.Exec(ctx, `UPDATE api_keys SET revoked_at=NOW() WHERE id=$1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *ServiceAccountRepo) TouchKey(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE api_keys SET last_used_at=$2 WHERE id=$1`, id, at)
+	return err
+}
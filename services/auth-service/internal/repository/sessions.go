@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Session is one refresh-token family. RefreshJTI is the only token of the
+// family that may still be exchanged.
+type Session struct {
+	ID            uuid.UUID
+	TenantID      string
+	UserID        uuid.UUID
+	RefreshJTI    string
+	UserAgent     string
+	IP            string
+	CreatedAt     time.Time
+	LastUsedAt    time.Time
+	ExpiresAt     time.Time
+	RevokedAt     *time.Time
+	RevokedReason *string
+}
+
+type CreateSessionParams struct {
+	TenantID   string
+	UserID     uuid.UUID
+	RefreshJTI string
+	UserAgent  string
+	IP         string
+	ExpiresAt  time.Time
+}
+
+type SessionsRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSessionsRepo(db *pgxpool.Pool) *SessionsRepo {
+	return &SessionsRepo{db: db}
+}
+
+const sessionColumns = `id, tenant_id, user_id, refresh_jti, user_agent, ip, created_at, last_used_at, expires_at, revoked_at, revoked_reason`
+
+func scanSession(row pgx.Row) (*Session, error) {
+	var s Session
+	err := row.Scan(&s.ID, &s.TenantID, &s.UserID, &s.RefreshJTI, &s.UserAgent, &s.IP, &s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt, &s.RevokedAt, &s.RevokedReason)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SessionsRepo) Create(ctx context.Context, p CreateSessionParams) (*Session, error) {
+	return scanSession(r.db.QueryRow(ctx, `
+		INSERT INTO sessions (tenant_id, user_id, refresh_jti, user_agent, ip, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+sessionColumns, p.TenantID, p.UserID, p.RefreshJTI, p.UserAgent, p.IP, p.ExpiresAt))
+}
+
+func (r *SessionsRepo) FindByID(ctx context.Context, id uuid.UUID) (*Session, error) {
+	return scanSession(r.db.QueryRow(ctx, `SELECT `+sessionColumns+` FROM sessions WHERE id=$1`, id))
+}
+
+func (r *SessionsRepo) ListActive(ctx context.Context, tenantID string, userID uuid.UUID, now time.Time) ([]Session, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+sessionColumns+`
+		FROM sessions
+		WHERE tenant_id=$1 AND user_id=$2 AND revoked_at IS NULL AND expires_at > $3
+		ORDER BY last_used_at DESC
+	`, tenantID, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Session
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *s)
+	}
+	return out, rows.Err()
+}
+
+// Rotate swaps the family's current refresh jti, but only if currentJTI is
+// still the live one. It reports false when another request rotated first or
+// the session was revoked.
+func (r *SessionsRepo) Rotate(ctx context.Context, id uuid.UUID, currentJTI, nextJTI, userAgent, ip string, now, expiresAt time.Time) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE sessions
+		SET refresh_jti=$3, user_agent=$4, ip=$5, last_used_at=$6, expires_at=$7
+		WHERE id=$1 AND refresh_jti=$2 AND revoked_at IS NULL AND expires_at > $6
+	`, id, currentJTI, nextJTI, userAgent, ip, now, expiresAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *SessionsRepo) Revoke(ctx context.Context, id uuid.UUID, reason string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE sessions SET revoked_at=NOW(), revoked_reason=$2 WHERE id=$1 AND revoked_at IS NULL
+	`, id, reason)
+	return err
+}
+
+func (r *SessionsRepo) RevokeAllForUser(ctx context.Context, tenantID string, userID uuid.UUID, reason string) (int64, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE sessions SET revoked_at=NOW(), revoked_reason=$3
+		WHERE tenant_id=$1 AND user_id=$2 AND revoked_at IS NULL
+	`, tenantID, userID, reason)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
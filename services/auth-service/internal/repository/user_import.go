@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
+)
+
+// ImportUser is a user to create in a batch. When InviteTokenHash is set the
+// user starts inactive and an invitation is stored alongside.
+type ImportUser struct {
+	Email           string
+	PasswordHash    string
+	RoleIDs         []uuid.UUID
+	InviteTokenHash string
+	InviteExpiresAt time.Time
+}
+
+// ExistingEmails reports which of emails already belong to a user of the
+// tenant.
+func (r *UsersRepo) ExistingEmails(ctx context.Context, tenantID string, emails []string) (map[string]bool, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT email FROM users
+		WHERE tenant_id=$1 AND email = ANY($2) AND deleted_at IS NULL
+	`, tenantID, emails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string]bool{}
+	for rows.Next() {
+		var e string
+		if err := rows.Scan(&e); err != nil {
+			return nil, err
+		}
+		out[e] = true
+	}
+	return out, rows.Err()
+}
+
+// CreateBatch inserts the users, their role assignments and invitations in a
+// single transaction. Nothing is written when any row fails; an email taken
+// concurrently yields ErrDuplicate.
+func (r *UsersRepo) CreateBatch(ctx context.Context, tenantID string, items []ImportUser) ([]User, error) {
+	tx, err := outbox.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	out := make([]User, 0, len(items))
+	for _, it := range items {
+		var u User
+		err := tx.QueryRow(ctx, `
+			INSERT INTO users (tenant_id, email, password_hash, is_active)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, tenant_id, email, password_hash, is_active
+		`, tenantID, it.Email, it.PasswordHash, it.InviteTokenHash == "").Scan(&u.ID, &u.TenantID, &u.Email, &u.PasswordHash, &u.IsActive)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return nil, fmt.Errorf("%w: %s", ErrDuplicate, it.Email)
+			}
+			return nil, err
+		}
+		for _, roleID := range it.RoleIDs {
+			if _, err := tx.Exec(ctx, `INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, u.ID, roleID); err != nil {
+				return nil, err
+			}
+		}
+		if it.InviteTokenHash != "" {
+			if _, err := createInvitation(ctx, tx, tenantID, u.ID, it.InviteTokenHash, it.InviteExpiresAt); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, u)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -8,7 +8,9 @@ import (
 	"strconv"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -138,6 +140,9 @@ func (r *UsersRepo) Update(ctx context.Context, id uuid.UUID, p UpdateUserParams
 		setCols = append(setCols, "password_hash=$"+strconv.Itoa(argi))
 		args = append(args, string(hash))
 		argi++
+		setCols = append(setCols, "password_changed_at=$"+strconv.Itoa(argi))
+		args = append(args, p.Now)
+		argi++
 	}
 	if p.IsActive != nil {
 		setCols = append(setCols, "is_active=$"+strconv.Itoa(argi))
@@ -154,7 +159,7 @@ func (r *UsersRepo) Update(ctx context.Context, id uuid.UUID, p UpdateUserParams
 	query := "UPDATE users SET " + joinComma(setCols) + " WHERE id=$" + strconv.Itoa(argi) + " AND deleted_at IS NULL RETURNING id, tenant_id, email, password_hash, is_active"
 	args = append(args, id)
 	var u User
-	if err := r.db.QueryRow(ctx, query, args...).Scan(&u.ID, &u.TenantID, &u.Email, &u.PasswordHash, &u.IsActive); err != nil {
+	if err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, args...).Scan(&u.ID, &u.TenantID, &u.Email, &u.PasswordHash, &u.IsActive); err != nil {
 		return nil, err
 	}
 	return &u, nil
@@ -175,3 +180,58 @@ func joinComma(s []string) string {
 	}
 	return out
 }
+
+func (r *UsersRepo) TokenVersion(ctx context.Context, id uuid.UUID) (int64, error) {
+	var v int64
+	err := r.db.QueryRow(ctx, `SELECT token_version FROM users WHERE id=$1`, id).Scan(&v)
+	return v, err
+}
+
+// PasswordChangedAt is when the user's current password was set.
+func (r *UsersRepo) PasswordChangedAt(ctx context.Context, id uuid.UUID) (time.Time, error) {
+	var t time.Time
+	err := r.db.QueryRow(ctx, `SELECT password_changed_at FROM users WHERE id=$1`, id).Scan(&t)
+	return t, err
+}
+
+// BumpTokenVersion increments the token version of the given users and
+// returns the new versions keyed by user id.
+func (r *UsersRepo) BumpTokenVersion(ctx context.Context, ids ...uuid.UUID) (map[uuid.UUID]int64, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE users SET token_version = token_version + 1, updated_at = NOW()
+		WHERE id = ANY($1)
+		RETURNING id, token_version
+	`, ids)
+	if err != nil {
+		return nil, err
+	}
+	return scanTokenVersions(rows)
+}
+
+// BumpTokenVersionForRole increments the token version of every user holding
+// roleID.
+func (r *UsersRepo) BumpTokenVersionForRole(ctx context.Context, roleID uuid.UUID) (map[uuid.UUID]int64, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE users SET token_version = token_version + 1, updated_at = NOW()
+		WHERE id IN (SELECT user_id FROM user_roles WHERE role_id = $1)
+		RETURNING id, token_version
+	`, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTokenVersions(rows)
+}
+
+func scanTokenVersions(rows pgx.Rows) (map[uuid.UUID]int64, error) {
+	defer rows.Close()
+	out := map[uuid.UUID]int64{}
+	for rows.Next() {
+		var id uuid.UUID
+		var v int64
+		if err := rows.Scan(&id, &v); err != nil {
+			return nil, err
+		}
+		out[id] = v
+	}
+	return out, rows.Err()
+}
@@ -43,6 +43,14 @@ func ensureMigrations(t *testing.T, db *pgxpool.Pool) {
 	if err == nil {
 		_, _ = db.Exec(ctx, string(b2))
 	}
+	bChain, err := os.ReadFile("../../migrations/011_audit_chain.up.sql")
+	if err == nil {
+		_, _ = db.Exec(ctx, string(bChain))
+	}
+	b9, err := os.ReadFile("../../migrations/009_password_policy.up.sql")
+	if err == nil {
+		_, _ = db.Exec(ctx, string(b9))
+	}
 }
 
 func TestUsersRepo_CRUD(t *testing.T) {
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/validator"
+)
+
+const (
+	defaultInvitationTTL = 72 * time.Hour
+	// resendCooldown stops the public resend endpoint from flooding an inbox.
+	resendCooldown = time.Minute
+	// invitedPasswordHash is not a valid bcrypt hash, so no password matches
+	// it until the user accepts the invitation.
+	invitedPasswordHash = "!invited"
+)
+
+var (
+	ErrInvitationInvalid    = errors.New("invalid or expired invitation")
+	ErrInvitationNotPending = errors.New("user has no pending invitation")
+	ErrInvitationThrottled  = errors.New("invitation was sent recently")
+)
+
+type InviteInput struct {
+	TenantID string
+	Email    string
+	Roles    []string
+}
+
+// Invitations creates accounts that stay inactive until their owner sets a
+// password through an emailed activation token.
+type Invitations interface {
+	Invite(ctx context.Context, in InviteInput) (*repository.User, error)
+	Resend(ctx context.Context, tenantID string, userID uuid.UUID) error
+	ResendByEmail(ctx context.Context, tenantID, email string) error
+	Activate(ctx context.Context, token, password string) (*repository.User, error)
+}
+
+type invitationsUC struct {
+	repo     *repository.InvitationRepo
+	users    *repository.UsersRepo
+	roles    *repository.RolesRepo
+	policies PasswordPolicies
+	events   outbox.Events
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// NewInvitations wires the invitation usecase. Invitations are announced as
+// auth.user.invited on the sisfo.events exchange through the outbox, in the
+// transaction that stores them.
+func NewInvitations(repo *repository.InvitationRepo, users *repository.UsersRepo, roles *repository.RolesRepo, policies PasswordPolicies, events outbox.Events, ttl time.Duration) Invitations {
+	if ttl <= 0 {
+		ttl = defaultInvitationTTL
+	}
+	return &invitationsUC{repo: repo, users: users, roles: roles, policies: policies, events: events, ttl: ttl, now: time.Now}
+}
+
+// Invite creates an inactive user holding roles, creating roles the tenant
+// does not have yet, and sends the activation token.
+func (i *invitationsUC) Invite(ctx context.Context, in InviteInput) (*repository.User, error) {
+	email := strings.ToLower(strings.TrimSpace(in.Email))
+	payload := struct {
+		Email string `validate:"required,email"`
+	}{Email: email}
+	if err := validator.Validate(payload); err != nil {
+		return nil, repository.ErrValidation("invalid email format")
+	}
+	var roleIDs []uuid.UUID
+	for _, name := range in.Roles {
+		name = strings.TrimSpace(name)
+		role, err := i.roles.FindRoleByName(ctx, in.TenantID, name)
+		if err != nil {
+			if role, err = i.roles.CreateRole(ctx, in.TenantID, name, false); err != nil {
+				return nil, err
+			}
+		}
+		roleIDs = append(roleIDs, role.ID)
+	}
+	token, hash, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+	expires := i.now().UTC().Add(i.ttl)
+	var u *repository.User
+	err = i.events.Transact(ctx, func(ctx context.Context) error {
+		created, err := i.users.CreateBatch(ctx, in.TenantID, []repository.ImportUser{{
+			Email:           email,
+			PasswordHash:    invitedPasswordHash,
+			RoleIDs:         roleIDs,
+			InviteTokenHash: hash,
+			InviteExpiresAt: expires,
+		}})
+		if err != nil {
+			return err
+		}
+		u = &created[0]
+		return enqueueInvitation(ctx, i.events, u, token, expires)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Resend replaces the user's invitation with a fresh token. Only users who
+// were invited and never activated qualify, so a deactivated account cannot
+// be reopened this way.
+func (i *invitationsUC) Resend(ctx context.Context, tenantID string, userID uuid.UUID) error {
+	u, err := i.users.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvitationNotPending
+		}
+		return err
+	}
+	if u.TenantID != tenantID || u.IsActive {
+		return ErrInvitationNotPending
+	}
+	last, err := i.repo.Latest(ctx, u.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvitationNotPending
+		}
+		return err
+	}
+	if last.AcceptedAt != nil {
+		return ErrInvitationNotPending
+	}
+	now := i.now().UTC()
+	if now.Sub(last.CreatedAt) < resendCooldown {
+		return ErrInvitationThrottled
+	}
+	token, hash, err := newInvitationToken()
+	if err != nil {
+		return err
+	}
+	return i.events.Transact(ctx, func(ctx context.Context) error {
+		inv, err := i.repo.Create(ctx, u.TenantID, u.ID, hash, now.Add(i.ttl))
+		if err != nil {
+			return err
+		}
+		return enqueueInvitation(ctx, i.events, u, token, inv.ExpiresAt)
+	})
+}
+
+func (i *invitationsUC) ResendByEmail(ctx context.Context, tenantID, email string) error {
+	u, err := i.users.FindByEmail(ctx, tenantID, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvitationNotPending
+		}
+		return err
+	}
+	return i.Resend(ctx, tenantID, u.ID)
+}
+
+// Activate redeems the token, sets the first password under the tenant's
+// policy and enables the account. The token is only used up if the account
+// is enabled.
+func (i *invitationsUC) Activate(ctx context.Context, token, password string) (*repository.User, error) {
+	inv, err := i.repo.FindValidByTokenHash(ctx, hashInvitationToken(strings.TrimSpace(token)), i.now().UTC())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvitationInvalid
+		}
+		return nil, err
+	}
+	if err := validatePassword(ctx, i.policies, inv.TenantID, password); err != nil {
+		return nil, err
+	}
+	now := i.now().UTC()
+	var u *repository.User
+	err = i.events.Transact(ctx, func(ctx context.Context) error {
+		ok, err := i.repo.Accept(ctx, inv.ID, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInvitationInvalid
+		}
+		active := true
+		u, err = i.users.Update(ctx, inv.UserID, repository.UpdateUserParams{Password: &password, IsActive: &active, Now: now})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func newInvitationToken() (token, hash string, err error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", "", err
+	}
+	token = hex.EncodeToString(b[:])
+	return token, hashInvitationToken(token), nil
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func enqueueInvitation(ctx context.Context, events outbox.Events, u *repository.User, token string, expiresAt time.Time) error {
+	return events.Enqueue(ctx, "sisfo.events", "auth.user.invited", map[string]any{
+		"tenant_id":  u.TenantID,
+		"user_id":    u.ID.String(),
+		"email":      u.Email,
+		"token":      token,
+		"expires_at": expiresAt,
+		"type":       "invitation",
+	})
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
+)
+
+func TestInvitations_ActivateAndResend(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	users := repository.NewUsersRepo(db)
+	roles := repository.NewRolesRepo(db)
+	invRepo := repository.NewInvitationRepo(db)
+	uc := NewInvitations(invRepo, users, roles, nil, outbox.New(db), time.Hour).(*invitationsUC)
+
+	tenant := "t-" + uuid.NewString()
+	u, err := uc.Invite(ctx, InviteInput{TenantID: tenant, Email: "Siswa@School.ID", Roles: []string{"student"}})
+	if err != nil {
+		t.Fatalf("invite err: %v", err)
+	}
+	if u.IsActive || u.Email != "siswa@school.id" {
+		t.Fatalf("invited user must be inactive, got %+v", u)
+	}
+	var queued int
+	if err := db.QueryRow(ctx, `SELECT COUNT(1) FROM outbox_events WHERE routing_key='auth.user.invited' AND payload->>'user_id'=$1`, u.ID.String()).Scan(&queued); err != nil || queued != 1 {
+		t.Fatalf("invitation must be enqueued once, got %d err=%v", queued, err)
+	}
+	assigned, _ := roles.ListUserRoles(ctx, u.ID)
+	if len(assigned) != 1 || assigned[0].Name != "student" {
+		t.Fatalf("student role not assigned: %+v", assigned)
+	}
+
+	if err := uc.Resend(ctx, tenant, u.ID); !errors.Is(err, ErrInvitationThrottled) {
+		t.Fatalf("immediate resend must be throttled, got %v", err)
+	}
+	// Pretend the first invitation went out long ago so a resend is allowed.
+	// Resent tokens only leave through the event, so issue one directly to
+	// know its value.
+	uc.now = func() time.Time { return time.Now().Add(2 * resendCooldown) }
+	if err := uc.Resend(ctx, tenant, u.ID); err != nil {
+		t.Fatalf("resend err: %v", err)
+	}
+	uc.now = time.Now
+	token, hash, err := newInvitationToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := invRepo.Create(ctx, tenant, u.ID, hash, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("create invitation err: %v", err)
+	}
+	var open int
+	if err := db.QueryRow(ctx, `SELECT COUNT(1) FROM user_invitations WHERE user_id=$1 AND revoked_at IS NULL`, u.ID).Scan(&open); err != nil || open != 1 {
+		t.Fatalf("a new invitation must revoke older ones, open=%d err=%v", open, err)
+	}
+
+	var v *PolicyViolation
+	if _, err := uc.Activate(ctx, token, "weak"); !errors.As(err, &v) {
+		t.Fatalf("weak password must be rejected, got %v", err)
+	}
+	active, err := uc.Activate(ctx, token, "Siswa#Baru1")
+	if err != nil || !active.IsActive {
+		t.Fatalf("activate err=%v user=%+v", err, active)
+	}
+	if _, err := uc.Activate(ctx, token, "Siswa#Baru2"); !errors.Is(err, ErrInvitationInvalid) {
+		t.Fatalf("token must be single use, got %v", err)
+	}
+	uc.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if err := uc.Resend(ctx, tenant, u.ID); !errors.Is(err, ErrInvitationNotPending) {
+		t.Fatalf("activated user must not get a new invitation, got %v", err)
+	}
+
+	expired, hash, _ := newInvitationToken()
+	other, err := uc.Invite(ctx, InviteInput{TenantID: tenant, Email: "wali@school.id"})
+	if err != nil {
+		t.Fatalf("invite err: %v", err)
+	}
+	if _, err := invRepo.Create(ctx, tenant, other.ID, hash, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("create invitation err: %v", err)
+	}
+	uc.now = time.Now
+	if _, err := uc.Activate(ctx, expired, "Wali#Baru123"); !errors.Is(err, ErrInvitationInvalid) {
+		t.Fatalf("expired token must be rejected, got %v", err)
+	}
+}
+
+func TestInvitations_ActivateIsAtomic(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	users := repository.NewUsersRepo(db)
+	invRepo := repository.NewInvitationRepo(db)
+	uc := NewInvitations(invRepo, users, repository.NewRolesRepo(db), nil, outbox.New(db), time.Hour)
+
+	tenant := "t-" + uuid.NewString()
+	u, err := uc.Invite(ctx, InviteInput{TenantID: tenant, Email: "hapus@school.id"})
+	if err != nil {
+		t.Fatalf("invite err: %v", err)
+	}
+	token, hash, _ := newInvitationToken()
+	if _, err := invRepo.Create(ctx, tenant, u.ID, hash, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("create invitation err: %v", err)
+	}
+	// The account is gone, so enabling it fails and the token must stay open
+	if err := users.SoftDelete(ctx, u.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uc.Activate(ctx, token, "Hapus#Baru123"); err == nil {
+		t.Fatal("activating a deleted account must fail")
+	}
+	inv, err := invRepo.Latest(ctx, u.ID)
+	if err != nil || inv.AcceptedAt != nil {
+		t.Fatalf("failed activation must not use up the token: %+v err=%v", inv, err)
+	}
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/totp"
+)
+
+var (
+	ErrMFANotEnrolled    = errors.New("mfa not enrolled")
+	ErrMFAAlreadyEnabled = errors.New("mfa already enabled")
+	ErrInvalidMFACode    = errors.New("invalid mfa code")
+)
+
+const recoveryCodeCount = 10
+
+// MFAStatus is the enrolment state of a user together with what the tenant
+// policy demands of them.
+type MFAStatus struct {
+	Enabled       bool
+	Required      bool
+	RecoveryCodes int
+}
+
+// Enrollment is a pending TOTP secret and its otpauth:// URI for QR display.
+type Enrollment struct {
+	Secret string
+	URI    string
+}
+
+// MFA manages TOTP enrolment, recovery codes and the per-tenant policy that
+// forces MFA for selected roles.
+type MFA interface {
+	Status(ctx context.Context, u *repository.User) (MFAStatus, error)
+	BeginEnroll(ctx context.Context, u *repository.User) (*Enrollment, error)
+	ConfirmEnroll(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	Verify(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	Disable(ctx context.Context, userID uuid.UUID, code string) error
+	Reset(ctx context.Context, tenantID string, userID uuid.UUID) error
+	Policy(ctx context.Context, tenantID string) ([]string, error)
+	SetPolicy(ctx context.Context, tenantID string, roles []string) error
+}
+
+type mfaUC struct {
+	repo   *repository.MFARepo
+	users  *repository.UsersRepo
+	roles  *repository.RolesRepo
+	issuer string
+	now    func() time.Time
+}
+
+// NewMFA wires the MFA usecase. issuer is the label authenticator apps show
+// next to the account.
+func NewMFA(repo *repository.MFARepo, users *repository.UsersRepo, roles *repository.RolesRepo, issuer string) MFA {
+	return &mfaUC{repo: repo, users: users, roles: roles, issuer: issuer, now: time.Now}
+}
+
+func (m *mfaUC) enrolment(ctx context.Context, userID uuid.UUID) (*repository.MFASecret, error) {
+	s, err := m.repo.Get(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrMFANotEnrolled
+	}
+	return s, err
+}
+
+func (m *mfaUC) Status(ctx context.Context, u *repository.User) (MFAStatus, error) {
+	var st MFAStatus
+	s, err := m.enrolment(ctx, u.ID)
+	if err != nil && !errors.Is(err, ErrMFANotEnrolled) {
+		return st, err
+	}
+	if s != nil && s.EnabledAt != nil {
+		st.Enabled = true
+		if st.RecoveryCodes, err = m.repo.CountRecoveryCodes(ctx, u.ID); err != nil {
+			return st, err
+		}
+	}
+	policy, err := m.repo.PolicyRoles(ctx, u.TenantID)
+	if err != nil || len(policy) == 0 {
+		return st, err
+	}
+	roles, err := m.roles.ListUserRoles(ctx, u.ID)
+	if err != nil {
+		return st, err
+	}
+	for _, r := range roles {
+		if r.TenantID != u.TenantID {
+			continue
+		}
+		for _, p := range policy {
+			if r.Name == p {
+				st.Required = true
+				return st, nil
+			}
+		}
+	}
+	return st, nil
+}
+
+func (m *mfaUC) BeginEnroll(ctx context.Context, u *repository.User) (*Enrollment, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	ok, err := m.repo.SetPending(ctx, u.ID, secret)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	return &Enrollment{Secret: secret, URI: totp.ProvisioningURI(secret, m.issuer, u.Email)}, nil
+}
+
+// ConfirmEnroll enables the pending secret once the user proves they can
+// generate codes for it and returns a fresh set of recovery codes.
+func (m *mfaUC) ConfirmEnroll(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	s, err := m.enrolment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if s.EnabledAt != nil {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	step, ok := totp.Validate(s.Secret, code, m.now(), 1)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	codes, err := m.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.repo.Enable(ctx, userID, step); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Verify accepts either a TOTP code or an unused recovery code. A TOTP code is
+// accepted at most once.
+func (m *mfaUC) Verify(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error {
+	s, err := m.enrolment(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if s.EnabledAt == nil {
+		return ErrMFANotEnrolled
+	}
+	if rc := normalizeRecoveryCode(recoveryCode); rc != "" {
+		ok, err := m.repo.UseRecoveryCode(ctx, userID, hashRecoveryCode(rc))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+	step, ok := totp.Validate(s.Secret, code, m.now(), 1)
+	if !ok {
+		return ErrInvalidMFACode
+	}
+	fresh, err := m.repo.UseStep(ctx, userID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (m *mfaUC) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if err := m.Verify(ctx, userID, code, ""); err != nil {
+		return nil, err
+	}
+	return m.replaceRecoveryCodes(ctx, userID)
+}
+
+func (m *mfaUC) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := m.Verify(ctx, userID, code, ""); err != nil {
+		return err
+	}
+	return m.repo.Delete(ctx, userID)
+}
+
+// Reset removes a user's enrolment on an administrator's behalf, e.g. after a
+// lost device. The user must enrol again if policy requires it.
+func (m *mfaUC) Reset(ctx context.Context, tenantID string, userID uuid.UUID) error {
+	u, err := m.users.FindByID(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && u.TenantID != tenantID) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return m.repo.Delete(ctx, userID)
+}
+
+func (m *mfaUC) Policy(ctx context.Context, tenantID string) ([]string, error) {
+	return m.repo.PolicyRoles(ctx, tenantID)
+}
+
+func (m *mfaUC) SetPolicy(ctx context.Context, tenantID string, roles []string) error {
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
+		if r = strings.TrimSpace(r); r != "" {
+			names = append(names, r)
+		}
+	}
+	return m.repo.SetPolicyRoles(ctx, tenantID, names)
+}
+
+func (m *mfaUC) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		var b [5]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return nil, err
+		}
+		raw := hex.EncodeToString(b[:])
+		codes[i] = raw[:5] + "-" + raw[5:]
+		hashes[i] = hashRecoveryCode(raw)
+	}
+	if err := m.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func normalizeRecoveryCode(s string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), "-", ""))
+}
+
+func hashRecoveryCode(normalized string) string {
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
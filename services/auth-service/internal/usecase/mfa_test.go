@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/totp"
+)
+
+func TestMFA_EnrollVerifyAndRecovery(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	users := repository.NewUsersRepo(db)
+	uc := NewMFA(repository.NewMFARepo(db), users, repository.NewRolesRepo(db), "sisfo").(*mfaUC)
+
+	tenant := "t-" + uuid.NewString()
+	u, err := users.Create(ctx, repository.CreateUserParams{TenantID: tenant, Email: "mfa@test.local", Password: "password123"})
+	if err != nil {
+		t.Fatalf("create user err: %v", err)
+	}
+	e, err := uc.BeginEnroll(ctx, u)
+	if err != nil {
+		t.Fatalf("begin err: %v", err)
+	}
+	now := time.Now()
+	uc.now = func() time.Time { return now }
+	code, _ := totp.CodeAt(e.Secret, totp.Step(now))
+	wrong, _ := totp.CodeAt(e.Secret, totp.Step(now)+5)
+	if _, err := uc.ConfirmEnroll(ctx, u.ID, wrong); !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("expected invalid code, got %v", err)
+	}
+	codes, err := uc.ConfirmEnroll(ctx, u.ID, code)
+	if err != nil || len(codes) != recoveryCodeCount {
+		t.Fatalf("confirm err=%v n=%d", err, len(codes))
+	}
+	if _, err := uc.BeginEnroll(ctx, u); !errors.Is(err, ErrMFAAlreadyEnabled) {
+		t.Fatalf("expected ErrMFAAlreadyEnabled, got %v", err)
+	}
+	// The confirming code is spent, so it cannot also complete a login.
+	if err := uc.Verify(ctx, u.ID, code, ""); !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("expected replay to fail, got %v", err)
+	}
+	now = now.Add(totp.Period)
+	next, _ := totp.CodeAt(e.Secret, totp.Step(now))
+	if err := uc.Verify(ctx, u.ID, next, ""); err != nil {
+		t.Fatalf("verify err: %v", err)
+	}
+	if err := uc.Verify(ctx, u.ID, "", codes[0]); err != nil {
+		t.Fatalf("recovery code err: %v", err)
+	}
+	if err := uc.Verify(ctx, u.ID, "", codes[0]); !errors.Is(err, ErrInvalidMFACode) {
+		t.Fatalf("recovery code must be single use, got %v", err)
+	}
+	st, err := uc.Status(ctx, u)
+	if err != nil || !st.Enabled || st.RecoveryCodes != recoveryCodeCount-1 {
+		t.Fatalf("status err=%v %+v", err, st)
+	}
+}
+
+func TestMFA_PolicyRequiresRole(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	users := repository.NewUsersRepo(db)
+	roles := repository.NewRolesRepo(db)
+	uc := NewMFA(repository.NewMFARepo(db), users, roles, "sisfo")
+
+	tenant := "t-" + uuid.NewString()
+	u, err := users.Create(ctx, repository.CreateUserParams{TenantID: tenant, Email: "mfa-policy@test.local", Password: "password123"})
+	if err != nil {
+		t.Fatalf("create user err: %v", err)
+	}
+	role, err := roles.CreateRole(ctx, tenant, "finance", false)
+	if err != nil {
+		t.Fatalf("create role err: %v", err)
+	}
+	if err := roles.AssignUserRole(ctx, u.ID, role.ID); err != nil {
+		t.Fatalf("assign err: %v", err)
+	}
+	if err := uc.SetPolicy(ctx, tenant, []string{"admin"}); err != nil {
+		t.Fatalf("set policy err: %v", err)
+	}
+	if st, _ := uc.Status(ctx, u); st.Required {
+		t.Fatalf("finance should not require mfa yet")
+	}
+	if err := uc.SetPolicy(ctx, tenant, []string{"admin", " finance "}); err != nil {
+		t.Fatalf("set policy err: %v", err)
+	}
+	if st, _ := uc.Status(ctx, u); !st.Required || st.Enabled {
+		t.Fatalf("expected required and not enabled, got %+v", st)
+	}
+	if err := uc.Reset(ctx, "other-"+tenant, u.ID); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound across tenants, got %v", err)
+	}
+}
@@ -0,0 +1,361 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/oidc"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+)
+
+var (
+	ErrOIDCProviderNotFound = errors.New("oidc provider not found")
+	ErrOIDCInvalidState     = errors.New("invalid or expired oidc state")
+	ErrOIDCEmailNotAllowed  = errors.New("email not allowed for this provider")
+	ErrOIDCNotProvisioned   = errors.New("no local account for this identity")
+)
+
+const defaultOIDCStateTTL = 10 * time.Minute
+
+// OIDCAuthRequest is where the user agent is sent to sign in at the provider.
+type OIDCAuthRequest struct {
+	URL   string
+	State string
+}
+
+// oidcState is what Begin remembers about an authorization request until the
+// provider redirects back with its state.
+type oidcState struct {
+	TenantID string `json:"tenant_id"`
+	Provider string `json:"provider"`
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+}
+
+// OIDC signs users in through a tenant's external identity provider with the
+// authorization-code flow and PKCE, provisioning local accounts on first login
+// when the provider allows it.
+type OIDC interface {
+	Providers(ctx context.Context, tenantID string) ([]repository.OIDCProvider, error)
+	SaveProvider(ctx context.Context, p *repository.OIDCProvider) (*repository.OIDCProvider, error)
+	DeleteProvider(ctx context.Context, tenantID, name string) error
+	Begin(ctx context.Context, tenantID, provider string) (*OIDCAuthRequest, error)
+	Complete(ctx context.Context, state, code string) (*repository.User, error)
+}
+
+type cachedOIDCClient struct {
+	updatedAt time.Time
+	client    *oidc.Client
+}
+
+type oidcUC struct {
+	repo        *repository.OIDCRepo
+	users       *repository.UsersRepo
+	roles       Roles
+	kv          redisutil.KV
+	redirectURL string
+	stateTTL    time.Duration
+	timeout     time.Duration
+
+	mu      sync.Mutex
+	clients map[uuid.UUID]cachedOIDCClient
+}
+
+// NewOIDC wires the OIDC login usecase. redirectURL is the callback registered
+// with every provider; state lives in kv for stateTTL.
+func NewOIDC(repo *repository.OIDCRepo, users *repository.UsersRepo, roles Roles, kv redisutil.KV, redirectURL string, stateTTL time.Duration) OIDC {
+	if stateTTL <= 0 {
+		stateTTL = defaultOIDCStateTTL
+	}
+	return &oidcUC{
+		repo:        repo,
+		users:       users,
+		roles:       roles,
+		kv:          kv,
+		redirectURL: redirectURL,
+		stateTTL:    stateTTL,
+		timeout:     10 * time.Second,
+		clients:     map[uuid.UUID]cachedOIDCClient{},
+	}
+}
+
+func oidcStateKey(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return "oidc:state:" + hex.EncodeToString(sum[:])
+}
+
+// client returns the relying party for p, rebuilding it when the provider has
+// been edited since it was cached.
+func (o *oidcUC) client(p *repository.OIDCProvider) *oidc.Client {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if c, ok := o.clients[p.ID]; ok && c.updatedAt.Equal(p.UpdatedAt) {
+		return c.client
+	}
+	c := oidc.NewClient(oidc.Config{
+		Issuer:       p.Issuer,
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		RedirectURL:  o.redirectURL,
+		Scopes:       p.Scopes,
+	}, o.timeout)
+	o.clients[p.ID] = cachedOIDCClient{updatedAt: p.UpdatedAt, client: c}
+	return c
+}
+
+func (o *oidcUC) provider(ctx context.Context, tenantID, name string) (*repository.OIDCProvider, error) {
+	p, err := o.repo.GetProvider(ctx, strings.TrimSpace(tenantID), strings.ToLower(strings.TrimSpace(name)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrOIDCProviderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !p.Enabled {
+		return nil, ErrOIDCProviderNotFound
+	}
+	return p, nil
+}
+
+func (o *oidcUC) Providers(ctx context.Context, tenantID string) ([]repository.OIDCProvider, error) {
+	return o.repo.ListProviders(ctx, tenantID)
+}
+
+func (o *oidcUC) SaveProvider(ctx context.Context, p *repository.OIDCProvider) (*repository.OIDCProvider, error) {
+	p.Name = strings.ToLower(strings.TrimSpace(p.Name))
+	p.Issuer = strings.TrimRight(strings.TrimSpace(p.Issuer), "/")
+	p.ClientID = strings.TrimSpace(p.ClientID)
+	if p.Name == "" || p.ClientID == "" {
+		return nil, repository.ErrValidation("name and client_id required")
+	}
+	if !validIssuer(p.Issuer) {
+		return nil, repository.ErrValidation("issuer must be an absolute https url")
+	}
+	domains := make([]string, 0, len(p.AllowedDomains))
+	for _, d := range p.AllowedDomains {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d != "" {
+			domains = append(domains, d)
+		}
+	}
+	p.AllowedDomains = domains
+	if len(p.Scopes) == 0 {
+		p.Scopes = []string{"openid", "email", "profile"}
+	}
+	if strings.TrimSpace(p.GroupsClaim) == "" {
+		p.GroupsClaim = "groups"
+	}
+	return o.repo.UpsertProvider(ctx, p)
+}
+
+// validIssuer accepts absolute https URLs. Plain http is only allowed for
+// loopback hosts, where a local IdP runs during development and tests.
+func validIssuer(issuer string) bool {
+	u, err := url.Parse(issuer)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	switch u.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := u.Hostname()
+		if host == "localhost" {
+			return true
+		}
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsLoopback()
+	}
+	return false
+}
+
+func (o *oidcUC) DeleteProvider(ctx context.Context, tenantID, name string) error {
+	ok, err := o.repo.DeleteProvider(ctx, tenantID, strings.ToLower(strings.TrimSpace(name)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrOIDCProviderNotFound
+	}
+	return nil
+}
+
+func (o *oidcUC) Begin(ctx context.Context, tenantID, provider string) (*OIDCAuthRequest, error) {
+	p, err := o.provider(ctx, tenantID, provider)
+	if err != nil {
+		return nil, err
+	}
+	state, err := oidc.RandomToken()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := oidc.RandomToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := oidc.RandomToken()
+	if err != nil {
+		return nil, err
+	}
+	authURL, err := o.client(p).AuthCodeURL(ctx, state, nonce, oidc.PKCEChallenge(verifier))
+	if err != nil {
+		return nil, err
+	}
+	val, err := json.Marshal(oidcState{TenantID: p.TenantID, Provider: p.Name, Verifier: verifier, Nonce: nonce})
+	if err != nil {
+		return nil, err
+	}
+	if err := redisutil.Set(ctx, o.kv, oidcStateKey(state), string(val), o.stateTTL); err != nil {
+		return nil, err
+	}
+	return &OIDCAuthRequest{URL: authURL, State: state}, nil
+}
+
+// Complete redeems the code for a verified ID token and resolves it to a local
+// user: by linked identity first, then by email, and finally by provisioning
+// a new account when the provider has JIT provisioning enabled.
+func (o *oidcUC) Complete(ctx context.Context, state, code string) (*repository.User, error) {
+	key := oidcStateKey(strings.TrimSpace(state))
+	raw, err := redisutil.Get(ctx, o.kv, key)
+	var st oidcState
+	if err != nil || json.Unmarshal([]byte(raw), &st) != nil {
+		return nil, ErrOIDCInvalidState
+	}
+	// State is single use whatever the outcome.
+	_ = redisutil.Del(ctx, o.kv, key)
+
+	p, err := o.provider(ctx, st.TenantID, st.Provider)
+	if err != nil {
+		return nil, err
+	}
+	client := o.client(p)
+	tok, err := client.Exchange(ctx, strings.TrimSpace(code), st.Verifier)
+	if err != nil {
+		return nil, err
+	}
+	id, err := client.VerifyIDToken(ctx, tok.IDToken, st.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	email := strings.ToLower(strings.TrimSpace(id.Email))
+	if !emailAllowed(email, p.AllowedDomains) {
+		return nil, ErrOIDCEmailNotAllowed
+	}
+
+	u, created, err := o.resolveUser(ctx, p, id, email)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.repo.LinkIdentity(ctx, p.ID, id.Subject, u.ID, email); err != nil {
+		return nil, err
+	}
+	if err := o.mapRoles(ctx, p, u, id.Groups(p.GroupsClaim), created); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (o *oidcUC) resolveUser(ctx context.Context, p *repository.OIDCProvider, id *oidc.IDToken, email string) (*repository.User, bool, error) {
+	userID, err := o.repo.FindIdentity(ctx, p.ID, id.Subject)
+	if err == nil {
+		u, err := o.users.FindByID(ctx, userID)
+		if err == nil {
+			return u, false, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, err
+		}
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, err
+	}
+	// Matching an existing account by email is only safe when the provider
+	// vouches for the address.
+	if email == "" || !id.EmailVerified {
+		return nil, false, ErrOIDCEmailNotAllowed
+	}
+	u, err := o.users.FindByEmail(ctx, p.TenantID, email)
+	if err == nil {
+		return u, false, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, err
+	}
+	if !p.JITProvisioning {
+		return nil, false, ErrOIDCNotProvisioned
+	}
+	// Federated accounts never sign in with a password; the random one only
+	// satisfies the users table.
+	pwd, err := oidc.RandomToken()
+	if err != nil {
+		return nil, false, err
+	}
+	u, err = o.users.Create(ctx, repository.CreateUserParams{TenantID: p.TenantID, Email: email, Password: pwd})
+	if err != nil {
+		return nil, false, err
+	}
+	return u, true, nil
+}
+
+// mapRoles grants the local roles mapped from the user's IdP groups. Newly
+// provisioned users without any mapped group get the provider's default role.
+func (o *oidcUC) mapRoles(ctx context.Context, p *repository.OIDCProvider, u *repository.User, groups []string, created bool) error {
+	have := map[string]bool{}
+	if !created {
+		current, err := o.roles.List(ctx, u.TenantID, u.ID)
+		if err != nil {
+			return err
+		}
+		for _, r := range current {
+			have[r.Name] = true
+		}
+	}
+	assigned := false
+	for _, g := range groups {
+		role, ok := p.GroupRoles[g]
+		if !ok || role == "" {
+			continue
+		}
+		assigned = true
+		if have[role] {
+			continue
+		}
+		if _, err := o.roles.AssignByName(ctx, u.TenantID, u.ID, role); err != nil {
+			return err
+		}
+		have[role] = true
+	}
+	if created && !assigned && p.DefaultRole != "" {
+		if _, err := o.roles.AssignByName(ctx, u.TenantID, u.ID, p.DefaultRole); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func emailAllowed(email string, domains []string) bool {
+	if len(domains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := email[at+1:]
+	for _, d := range domains {
+		if domain == d {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/oidc/oidctest"
+	"github.com/redis/go-redis/v9"
+)
+
+type memKV struct {
+	mu sync.Mutex
+	m  map[string]string
+}
+
+func (k *memKV) Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.m[key] = value.(string)
+	return redis.NewStatusResult("OK", nil)
+}
+
+func (k *memKV) Get(ctx context.Context, key string) *redis.StringCmd {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	v, ok := k.m[key]
+	if !ok {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(v, nil)
+}
+
+func (k *memKV) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for _, key := range keys {
+		delete(k.m, key)
+	}
+	return redis.NewIntResult(int64(len(keys)), nil)
+}
+
+func TestOIDC_LoginProvisionsAndLinks(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	idp := oidctest.NewServer("sisfo", "s3cret")
+	defer idp.Close()
+
+	users := repository.NewUsersRepo(db)
+	roles := NewRoles(users, repository.NewRolesRepo(db), nil)
+	uc := NewOIDC(repository.NewOIDCRepo(db), users, roles, &memKV{m: map[string]string{}}, "http://localhost/api/v1/auth/oidc/callback", time.Minute)
+
+	tenant := "t-" + uuid.NewString()
+	if _, err := uc.SaveProvider(ctx, &repository.OIDCProvider{
+		TenantID:        tenant,
+		Name:            "Workspace",
+		Issuer:          idp.Issuer(),
+		ClientID:        "sisfo",
+		ClientSecret:    "s3cret",
+		AllowedDomains:  []string{"@school.sch.id"},
+		GroupRoles:      map[string]string{"teachers": "teacher"},
+		DefaultRole:     "staff",
+		JITProvisioning: true,
+		Enabled:         true,
+	}); err != nil {
+		t.Fatalf("save provider err: %v", err)
+	}
+
+	login := func() (*repository.User, error) {
+		req, err := uc.Begin(ctx, tenant, "workspace")
+		if err != nil {
+			return nil, err
+		}
+		code, state, err := idp.Authorize(req.URL)
+		if err != nil {
+			return nil, err
+		}
+		return uc.Complete(ctx, state, code)
+	}
+
+	idp.SetUser(oidctest.User{Subject: "sub-1", Email: "Guru@school.sch.id", EmailVerified: true, Groups: []string{"teachers"}})
+	u, err := login()
+	if err != nil {
+		t.Fatalf("first login err: %v", err)
+	}
+	if u.TenantID != tenant || u.Email != "guru@school.sch.id" {
+		t.Fatalf("unexpected user: %+v", u)
+	}
+	assigned, _ := roles.List(ctx, tenant, u.ID)
+	if len(assigned) != 1 || assigned[0].Name != "teacher" {
+		t.Fatalf("expected mapped teacher role, got %+v", assigned)
+	}
+
+	// The subject stays linked even if the address changes at the IdP.
+	idp.SetUser(oidctest.User{Subject: "sub-1", Email: "guru.baru@school.sch.id", EmailVerified: true})
+	again, err := login()
+	if err != nil || again.ID != u.ID {
+		t.Fatalf("second login err=%v user=%v", err, again)
+	}
+
+	idp.SetUser(oidctest.User{Subject: "sub-2", Email: "siswa@gmail.com", EmailVerified: true})
+	if _, err := login(); !errors.Is(err, ErrOIDCEmailNotAllowed) {
+		t.Fatalf("expected domain rejection, got %v", err)
+	}
+	idp.SetUser(oidctest.User{Subject: "sub-3", Email: "tu@school.sch.id", EmailVerified: false})
+	if _, err := login(); !errors.Is(err, ErrOIDCEmailNotAllowed) {
+		t.Fatalf("expected unverified email rejection, got %v", err)
+	}
+
+	idp.SetUser(oidctest.User{Subject: "sub-4", Email: "tu@school.sch.id", EmailVerified: true})
+	req, err := uc.Begin(ctx, tenant, "workspace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, state, _ := idp.Authorize(req.URL)
+	staff, err := uc.Complete(ctx, state, code)
+	if err != nil {
+		t.Fatalf("jit login err: %v", err)
+	}
+	assigned, _ = roles.List(ctx, tenant, staff.ID)
+	if len(assigned) != 1 || assigned[0].Name != "staff" {
+		t.Fatalf("expected default staff role, got %+v", assigned)
+	}
+	if _, err := uc.Complete(ctx, state, code); !errors.Is(err, ErrOIDCInvalidState) {
+		t.Fatalf("state must be single use, got %v", err)
+	}
+	if _, err := uc.Begin(ctx, tenant, "missing"); !errors.Is(err, ErrOIDCProviderNotFound) {
+		t.Fatalf("expected provider not found, got %v", err)
+	}
+}
+
+func TestValidIssuer(t *testing.T) {
+	cases := map[string]bool{
+		"https://accounts.example.com":   true,
+		"https://idp.example.com/realms": true,
+		"http://localhost:8080":          true,
+		"http://127.0.0.1:5556":          true,
+		"http://[::1]:5556":              true,
+		"http://accounts.example.com":    false,
+		"http://10.0.0.5":                false,
+		"http://localhost.example.com":   false,
+		"ftp://accounts.example.com":     false,
+		"https://":                       false,
+		"accounts.example.com":           false,
+	}
+	for issuer, want := range cases {
+		if got := validIssuer(issuer); got != want {
+			t.Errorf("validIssuer(%q) = %v, want %v", issuer, got, want)
+		}
+	}
+}
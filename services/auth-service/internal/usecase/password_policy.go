@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// maxHistoryDepth bounds how many previous hashes are kept and compared on
+// every change; bcrypt makes each comparison deliberately slow.
+const maxHistoryDepth = 24
+
+// PolicyViolation is returned when a password does not satisfy the tenant's
+// policy. Its message is safe to show to the user.
+type PolicyViolation struct {
+	Reason string
+}
+
+func (e *PolicyViolation) Error() string {
+	return e.Reason
+}
+
+// DefaultPasswordPolicy applies to tenants that have not stored their own.
+func DefaultPasswordPolicy(lockoutThreshold int, lockoutTTL time.Duration) repository.PasswordPolicy {
+	if lockoutThreshold <= 0 {
+		lockoutThreshold = 5
+	}
+	if lockoutTTL <= 0 {
+		lockoutTTL = 15 * time.Minute
+	}
+	return repository.PasswordPolicy{
+		MinLength:        8,
+		RequireUpper:     true,
+		RequireLower:     true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		HistoryDepth:     5,
+		LockoutThreshold: lockoutThreshold,
+		LockoutSeconds:   int(lockoutTTL / time.Second),
+	}
+}
+
+// PasswordPolicies resolves each tenant's password and lockout policy and
+// applies it whenever a password is set.
+type PasswordPolicies interface {
+	Get(ctx context.Context, tenantID string) (*repository.PasswordPolicy, error)
+	Set(ctx context.Context, p *repository.PasswordPolicy) (*repository.PasswordPolicy, error)
+	ForceRotation(ctx context.Context, tenantID string, at time.Time) (*repository.PasswordPolicy, error)
+	Validate(ctx context.Context, tenantID, password string) error
+	Change(ctx context.Context, u *repository.User, password string) error
+	Expired(ctx context.Context, u *repository.User) (bool, error)
+}
+
+type passwordPoliciesUC struct {
+	repo     *repository.PasswordPolicyRepo
+	users    *repository.UsersRepo
+	history  *repository.PasswordHistoryRepo
+	defaults repository.PasswordPolicy
+	now      func() time.Time
+}
+
+// NewPasswordPolicies wires the password policy usecase. Without a repo every
+// tenant gets defaults; without a history repo reuse of older passwords is not
+// checked.
+func NewPasswordPolicies(repo *repository.PasswordPolicyRepo, users *repository.UsersRepo, history *repository.PasswordHistoryRepo, defaults repository.PasswordPolicy) PasswordPolicies {
+	return &passwordPoliciesUC{repo: repo, users: users, history: history, defaults: defaults, now: time.Now}
+}
+
+func (p *passwordPoliciesUC) Get(ctx context.Context, tenantID string) (*repository.PasswordPolicy, error) {
+	if p.repo != nil {
+		pol, err := p.repo.Get(ctx, tenantID)
+		if err == nil {
+			return pol, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+	}
+	pol := p.defaults
+	pol.TenantID = tenantID
+	return &pol, nil
+}
+
+func (p *passwordPoliciesUC) Set(ctx context.Context, pol *repository.PasswordPolicy) (*repository.PasswordPolicy, error) {
+	switch {
+	case pol.MinLength < 8 || pol.MinLength > 128:
+		return nil, repository.ErrValidation("min_length must be between 8 and 128")
+	case pol.HistoryDepth < 0 || pol.HistoryDepth > maxHistoryDepth:
+		return nil, repository.ErrValidation(fmt.Sprintf("history_depth must be between 0 and %d", maxHistoryDepth))
+	case pol.MaxAgeDays < 0:
+		return nil, repository.ErrValidation("max_age_days must not be negative")
+	case pol.LockoutThreshold < 1:
+		return nil, repository.ErrValidation("lockout_threshold must be at least 1")
+	case pol.LockoutSeconds < 1:
+		return nil, repository.ErrValidation("lockout_seconds must be at least 1")
+	}
+	if p.repo == nil {
+		return nil, errors.New("password policies are not persisted")
+	}
+	return p.repo.Upsert(ctx, pol)
+}
+
+// ForceRotation expires every password in the tenant set before at.
+func (p *passwordPoliciesUC) ForceRotation(ctx context.Context, tenantID string, at time.Time) (*repository.PasswordPolicy, error) {
+	pol, err := p.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	at = at.UTC()
+	pol.RotateBefore = &at
+	return p.Set(ctx, pol)
+}
+
+func (p *passwordPoliciesUC) Validate(ctx context.Context, tenantID, password string) error {
+	pol, err := p.Get(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	return checkPassword(pol, password)
+}
+
+// Change validates password against the policy and the user's recent
+// passwords, stores it and records the previous hash in the history.
+func (p *passwordPoliciesUC) Change(ctx context.Context, u *repository.User, password string) error {
+	pol, err := p.Get(ctx, u.TenantID)
+	if err != nil {
+		return err
+	}
+	if err := checkPassword(pol, password); err != nil {
+		return err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil {
+		return &PolicyViolation{Reason: "password reuse not allowed"}
+	}
+	if p.history != nil && pol.HistoryDepth > 0 {
+		items, err := p.history.Recent(ctx, u.ID, pol.HistoryDepth)
+		if err != nil {
+			return err
+		}
+		for _, it := range items {
+			if bcrypt.CompareHashAndPassword([]byte(it.PasswordHash), []byte(password)) == nil {
+				return &PolicyViolation{Reason: "password reuse not allowed"}
+			}
+		}
+	}
+	now := p.now().UTC()
+	if _, err := p.users.Update(ctx, u.ID, repository.UpdateUserParams{Password: &password, Now: now}); err != nil {
+		return err
+	}
+	if p.history != nil {
+		_ = p.history.Add(ctx, u.ID, u.PasswordHash, now)
+		_ = p.history.Prune(ctx, u.ID, max(pol.HistoryDepth, 1))
+	}
+	return nil
+}
+
+// Expired reports whether u must change their password before signing in.
+func (p *passwordPoliciesUC) Expired(ctx context.Context, u *repository.User) (bool, error) {
+	pol, err := p.Get(ctx, u.TenantID)
+	if err != nil {
+		return false, err
+	}
+	if pol.MaxAgeDays <= 0 && pol.RotateBefore == nil {
+		return false, nil
+	}
+	changed, err := p.users.PasswordChangedAt(ctx, u.ID)
+	if err != nil {
+		return false, err
+	}
+	if pol.RotateBefore != nil && changed.Before(*pol.RotateBefore) {
+		return true, nil
+	}
+	return pol.MaxAgeDays > 0 && p.now().After(changed.Add(time.Duration(pol.MaxAgeDays)*24*time.Hour)), nil
+}
+
+// validatePassword checks pwd against the tenant's policy, or the default
+// policy when policies is nil.
+func validatePassword(ctx context.Context, policies PasswordPolicies, tenantID, pwd string) error {
+	if policies == nil {
+		pol := DefaultPasswordPolicy(0, 0)
+		return checkPassword(&pol, pwd)
+	}
+	return policies.Validate(ctx, tenantID, pwd)
+}
+
+// LockoutSettings is the failed-login threshold and lock duration of a
+// policy.
+func LockoutSettings(pol *repository.PasswordPolicy) (int, time.Duration) {
+	return pol.LockoutThreshold, time.Duration(pol.LockoutSeconds) * time.Second
+}
+
+func checkPassword(pol *repository.PasswordPolicy, s string) error {
+	if len(s) < pol.MinLength {
+		return &PolicyViolation{Reason: fmt.Sprintf("password must be at least %d characters", pol.MinLength)}
+	}
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range s {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+	var missing []string
+	if pol.RequireUpper && !hasUpper {
+		missing = append(missing, "uppercase")
+	}
+	if pol.RequireLower && !hasLower {
+		missing = append(missing, "lowercase")
+	}
+	if pol.RequireDigit && !hasDigit {
+		missing = append(missing, "number")
+	}
+	if pol.RequireSymbol && !hasSymbol {
+		missing = append(missing, "symbol")
+	}
+	if len(missing) > 0 {
+		return &PolicyViolation{Reason: "password must include " + joinAnd(missing)}
+	}
+	if isCommonPassword(s) {
+		return &PolicyViolation{Reason: "password too common"}
+	}
+	return nil
+}
+
+func joinAnd(items []string) string {
+	if len(items) < 3 {
+		return strings.Join(items, " and ")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + ", and " + items[len(items)-1]
+}
+
+func isCommonPassword(s string) bool {
+	l := strings.ToLower(s)
+	common := map[string]struct{}{
+		"password":  {},
+		"password1": {},
+		"passw0rd":  {},
+		"p@ssw0rd":  {},
+		"123456":    {},
+		"qwerty":    {},
+		"admin":     {},
+		"welcome":   {},
+		"letmein":   {},
+	}
+	_, ok := common[l]
+	return ok
+}
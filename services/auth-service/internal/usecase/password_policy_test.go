@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+)
+
+func TestCheckPassword_TenantRules(t *testing.T) {
+	def := DefaultPasswordPolicy(0, 0)
+	relaxed := def
+	relaxed.MinLength = 12
+	relaxed.RequireSymbol = false
+	relaxed.RequireUpper = false
+
+	cases := []struct {
+		name   string
+		pol    repository.PasswordPolicy
+		pwd    string
+		reason string
+	}{
+		{"default ok", def, "Password123!", ""},
+		{"default short", def, "Pa1!", "password must be at least 8 characters"},
+		{"default classes", def, "password123", "password must include uppercase and symbol"},
+		{"default common", def, "P@ssw0rd", "password too common"},
+		{"relaxed ok", relaxed, "correct horse 42", ""},
+		{"relaxed length", relaxed, "horse42horse", ""},
+		{"relaxed short", relaxed, "horse42", "password must be at least 12 characters"},
+		{"relaxed digit", relaxed, "correct horse battery", "password must include number"},
+	}
+	for _, tc := range cases {
+		err := checkPassword(&tc.pol, tc.pwd)
+		var v *PolicyViolation
+		if tc.reason == "" {
+			if err != nil {
+				t.Fatalf("%s: unexpected err %v", tc.name, err)
+			}
+			continue
+		}
+		if !errors.As(err, &v) || v.Reason != tc.reason {
+			t.Fatalf("%s: expected %q got %v", tc.name, tc.reason, err)
+		}
+	}
+}
+
+func TestPasswordPolicies_HistoryAndExpiry(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	users := repository.NewUsersRepo(db)
+	uc := NewPasswordPolicies(repository.NewPasswordPolicyRepo(db), users, repository.NewPasswordHistoryRepo(db), DefaultPasswordPolicy(5, time.Minute)).(*passwordPoliciesUC)
+
+	tenant := "t-" + uuid.NewString()
+	pol, err := uc.Get(ctx, tenant)
+	if err != nil || pol.MinLength != 8 || pol.LockoutThreshold != 5 {
+		t.Fatalf("defaults err=%v pol=%+v", err, pol)
+	}
+	pol.HistoryDepth = 2
+	pol.MaxAgeDays = 30
+	if _, err := uc.Set(ctx, pol); err != nil {
+		t.Fatalf("set err: %v", err)
+	}
+	bad := *pol
+	bad.MinLength = 4
+	if _, err := uc.Set(ctx, &bad); err == nil {
+		t.Fatalf("min_length below 8 must be rejected")
+	}
+
+	u, err := users.Create(ctx, repository.CreateUserParams{TenantID: tenant, Email: "policy@test.local", Password: "First#Pass1"})
+	if err != nil {
+		t.Fatalf("create user err: %v", err)
+	}
+	change := func(pwd string) error {
+		cur, err := users.FindByID(ctx, u.ID)
+		if err != nil {
+			return err
+		}
+		return uc.Change(ctx, cur, pwd)
+	}
+	for _, pwd := range []string{"Second#Pass2", "Third#Pass3"} {
+		if err := change(pwd); err != nil {
+			t.Fatalf("change to %s err: %v", pwd, err)
+		}
+	}
+	var v *PolicyViolation
+	if err := change("Second#Pass2"); !errors.As(err, &v) {
+		t.Fatalf("expected reuse within history depth to fail, got %v", err)
+	}
+
+	if expired, err := uc.Expired(ctx, u); err != nil || expired {
+		t.Fatalf("fresh password expired=%v err=%v", expired, err)
+	}
+	uc.now = func() time.Time { return time.Now().Add(31 * 24 * time.Hour) }
+	if expired, _ := uc.Expired(ctx, u); !expired {
+		t.Fatalf("password older than max age must be expired")
+	}
+	uc.now = time.Now
+
+	if _, err := uc.ForceRotation(ctx, tenant, time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("force rotation err: %v", err)
+	}
+	if expired, _ := uc.Expired(ctx, u); !expired {
+		t.Fatalf("forced rotation must expire existing passwords")
+	}
+}
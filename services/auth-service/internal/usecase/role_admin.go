@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+)
+
+var (
+	ErrRoleNotFound       = errors.New("role not found")
+	ErrSystemRole         = errors.New("system roles cannot be modified")
+	ErrPermissionNotFound = errors.New("permission not found")
+)
+
+// RoleAdmin manages tenant roles and the permissions granted to them. Roles
+// are always resolved within tenantID so one tenant cannot touch another's.
+type RoleAdmin interface {
+	Create(ctx context.Context, tenantID, name string) (*repository.Role, error)
+	Get(ctx context.Context, tenantID string, id uuid.UUID) (*repository.Role, []repository.Permission, error)
+	List(ctx context.Context, tenantID string, limit, offset int) ([]repository.Role, error)
+	Rename(ctx context.Context, tenantID string, id uuid.UUID, name string) (*repository.Role, error)
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) (*repository.Role, error)
+	ListPermissions(ctx context.Context, limit, offset int) ([]repository.Permission, error)
+	Grant(ctx context.Context, tenantID string, id uuid.UUID, permissions []string) ([]repository.Permission, error)
+	Revoke(ctx context.Context, tenantID string, id, permissionID uuid.UUID) (*repository.Permission, error)
+	EffectivePermissions(ctx context.Context, tenantID string, userID uuid.UUID) ([]repository.Permission, error)
+}
+
+type roleAdminUC struct {
+	roles  *repository.RolesRepo
+	perms  *repository.PermissionsRepo
+	tokens *Tokens
+}
+
+// NewRoleAdmin wires the role management usecase. Role changes bump the token
+// version of the role's holders, which also retires the permission decisions
+// services cached for them. tokens may be nil, in which case role changes do
+// not revoke outstanding access tokens or cached decisions.
+func NewRoleAdmin(roles *repository.RolesRepo, perms *repository.PermissionsRepo, tokens *Tokens) RoleAdmin {
+	return &roleAdminUC{roles: roles, perms: perms, tokens: tokens}
+}
+
+func (u *roleAdminUC) revokeRole(ctx context.Context, roleID uuid.UUID) error {
+	if u.tokens == nil {
+		return nil
+	}
+	return u.tokens.RevokeRole(ctx, roleID)
+}
+
+func (u *roleAdminUC) Create(ctx context.Context, tenantID, name string) (*repository.Role, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, repository.ErrValidation("name required")
+	}
+	if _, err := u.roles.FindRoleByName(ctx, tenantID, name); err == nil {
+		return nil, repository.ErrValidation("role already exists")
+	}
+	return u.roles.CreateRole(ctx, tenantID, name, false)
+}
+
+func (u *roleAdminUC) find(ctx context.Context, tenantID string, id uuid.UUID) (*repository.Role, error) {
+	role, err := u.roles.FindRoleByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && role.TenantID != tenantID) {
+		return nil, ErrRoleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (u *roleAdminUC) findEditable(ctx context.Context, tenantID string, id uuid.UUID) (*repository.Role, error) {
+	role, err := u.find(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if role.IsSystemRole {
+		return nil, ErrSystemRole
+	}
+	return role, nil
+}
+
+func (u *roleAdminUC) Get(ctx context.Context, tenantID string, id uuid.UUID) (*repository.Role, []repository.Permission, error) {
+	role, err := u.find(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	perms, err := u.perms.GetByRole(ctx, role.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return role, perms, nil
+}
+
+func (u *roleAdminUC) List(ctx context.Context, tenantID string, limit, offset int) ([]repository.Role, error) {
+	return u.roles.ListRoles(ctx, tenantID, limit, offset)
+}
+
+func (u *roleAdminUC) Rename(ctx context.Context, tenantID string, id uuid.UUID, name string) (*repository.Role, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, repository.ErrValidation("name required")
+	}
+	role, err := u.findEditable(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing, err := u.roles.FindRoleByName(ctx, tenantID, name); err == nil && existing.ID != role.ID {
+		return nil, repository.ErrValidation("role already exists")
+	}
+	updated, err := u.roles.UpdateRoleName(ctx, role.ID, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.revokeRole(ctx, role.ID); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (u *roleAdminUC) Delete(ctx context.Context, tenantID string, id uuid.UUID) (*repository.Role, error) {
+	role, err := u.findEditable(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.roles.SoftDeleteRole(ctx, role.ID); err != nil {
+		return nil, err
+	}
+	if err := u.revokeRole(ctx, role.ID); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (u *roleAdminUC) ListPermissions(ctx context.Context, limit, offset int) ([]repository.Permission, error) {
+	return u.perms.List(ctx, limit, offset)
+}
+
+func (u *roleAdminUC) Grant(ctx context.Context, tenantID string, id uuid.UUID, permissions []string) ([]repository.Permission, error) {
+	if len(permissions) == 0 {
+		return nil, repository.ErrValidation("permissions required")
+	}
+	role, err := u.findEditable(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	// Resolve everything first so an unknown permission grants nothing.
+	resolved := make([]repository.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		resource, action, ok := strings.Cut(strings.TrimSpace(p), ":")
+		if !ok || resource == "" || action == "" {
+			return nil, repository.ErrValidation("permission must be resource:action")
+		}
+		perm, err := u.perms.FindByResourceAction(ctx, resource, action)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPermissionNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, *perm)
+	}
+	for _, p := range resolved {
+		if err := u.perms.AssignToRole(ctx, role.ID, p.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := u.revokeRole(ctx, role.ID); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+func (u *roleAdminUC) Revoke(ctx context.Context, tenantID string, id, permissionID uuid.UUID) (*repository.Permission, error) {
+	role, err := u.findEditable(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	perm, err := u.perms.FindByID(ctx, permissionID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPermissionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := u.perms.RevokeFromRole(ctx, role.ID, perm.ID); err != nil {
+		return nil, err
+	}
+	if err := u.revokeRole(ctx, role.ID); err != nil {
+		return nil, err
+	}
+	return perm, nil
+}
+
+func (u *roleAdminUC) EffectivePermissions(ctx context.Context, tenantID string, userID uuid.UUID) ([]repository.Permission, error) {
+	return u.perms.GetByUser(ctx, userID, tenantID)
+}
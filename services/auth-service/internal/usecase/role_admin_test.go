@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+)
+
+func TestRoleAdmin_CRUD_Grant_Revoke(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	users := repository.NewUsersRepo(db)
+	roles := repository.NewRolesRepo(db)
+	perms := repository.NewPermissionsRepo(db)
+	uc := NewRoleAdmin(roles, perms, nil)
+
+	tenant := "t-" + uuid.NewString()
+	resource := "res" + uuid.NewString()[:8]
+	p, err := perms.Create(ctx, resource, "read")
+	if err != nil {
+		t.Fatalf("create permission err: %v", err)
+	}
+
+	role, err := uc.Create(ctx, tenant, "homeroom")
+	if err != nil {
+		t.Fatalf("create role err: %v", err)
+	}
+	if _, err := uc.Create(ctx, tenant, "homeroom"); err == nil {
+		t.Fatalf("expected duplicate role error")
+	}
+	if _, err := uc.Grant(ctx, tenant, role.ID, []string{resource + ":read"}); err != nil {
+		t.Fatalf("grant err: %v", err)
+	}
+	if _, err := uc.Grant(ctx, tenant, role.ID, []string{resource + ":missing"}); !errors.Is(err, ErrPermissionNotFound) {
+		t.Fatalf("expected ErrPermissionNotFound, got %v", err)
+	}
+
+	u, err := users.Create(ctx, repository.CreateUserParams{TenantID: tenant, Email: "roleadmin@test.local", Password: "password123"})
+	if err != nil {
+		t.Fatalf("create user err: %v", err)
+	}
+	if err := roles.AssignUserRole(ctx, u.ID, role.ID); err != nil {
+		t.Fatalf("assign err: %v", err)
+	}
+	eff, err := uc.EffectivePermissions(ctx, tenant, u.ID)
+	if err != nil || len(eff) != 1 || eff[0].ID != p.ID {
+		t.Fatalf("effective permissions err=%v items=%v", err, eff)
+	}
+
+	// other tenants cannot see or edit the role
+	if _, _, err := uc.Get(ctx, "other-"+tenant, role.ID); !errors.Is(err, ErrRoleNotFound) {
+		t.Fatalf("expected ErrRoleNotFound, got %v", err)
+	}
+
+	if _, err := uc.Revoke(ctx, tenant, role.ID, p.ID); err != nil {
+		t.Fatalf("revoke err: %v", err)
+	}
+	eff, _ = uc.EffectivePermissions(ctx, tenant, u.ID)
+	if len(eff) != 0 {
+		t.Fatalf("expected no permissions after revoke, got %d", len(eff))
+	}
+
+	if _, err := uc.Rename(ctx, tenant, role.ID, "homeroom-teacher"); err != nil {
+		t.Fatalf("rename err: %v", err)
+	}
+	if _, err := uc.Delete(ctx, tenant, role.ID); err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if _, _, err := uc.Get(ctx, tenant, role.ID); !errors.Is(err, ErrRoleNotFound) {
+		t.Fatalf("expected deleted role to be gone, got %v", err)
+	}
+}
+
+func TestRoleAdmin_SystemRole_Protected(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	roles := repository.NewRolesRepo(db)
+	uc := NewRoleAdmin(roles, repository.NewPermissionsRepo(db), nil)
+
+	tenant := "t-" + uuid.NewString()
+	sys, err := roles.CreateRole(ctx, tenant, "admin", true)
+	if err != nil {
+		t.Fatalf("create role err: %v", err)
+	}
+	if _, err := uc.Rename(ctx, tenant, sys.ID, "root"); !errors.Is(err, ErrSystemRole) {
+		t.Fatalf("rename: expected ErrSystemRole, got %v", err)
+	}
+	if _, err := uc.Delete(ctx, tenant, sys.ID); !errors.Is(err, ErrSystemRole) {
+		t.Fatalf("delete: expected ErrSystemRole, got %v", err)
+	}
+	if _, err := uc.Grant(ctx, tenant, sys.ID, []string{"school:read"}); !errors.Is(err, ErrSystemRole) {
+		t.Fatalf("grant: expected ErrSystemRole, got %v", err)
+	}
+}
@@ -2,56 +2,111 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
 )
 
+// Roles assigns roles to users. Users and roles are always resolved within
+// tenantID so one tenant cannot touch another's.
 type Roles interface {
+	// Assign gives the user an existing role of the tenant.
+	Assign(ctx context.Context, tenantID string, userID uuid.UUID, roleName string) (*repository.Role, error)
+	// AssignByName is Assign, creating the role first if the tenant has none
+	// by that name. It is for provisioning flows, not for callers choosing
+	// the role themselves.
 	AssignByName(ctx context.Context, tenantID string, userID uuid.UUID, roleName string) (*repository.Role, error)
-	List(ctx context.Context, userID uuid.UUID) ([]repository.Role, error)
-	Unassign(ctx context.Context, userID, roleID uuid.UUID) error
+	List(ctx context.Context, tenantID string, userID uuid.UUID) ([]repository.Role, error)
+	Unassign(ctx context.Context, tenantID string, userID, roleID uuid.UUID) (*repository.Role, error)
 }
 
 type rolesUC struct {
-	users *repository.UsersRepo
-	roles *repository.RolesRepo
+	users  *repository.UsersRepo
+	roles  *repository.RolesRepo
+	tokens *Tokens
 }
 
-func NewRoles(users *repository.UsersRepo, roles *repository.RolesRepo) Roles {
-	return &rolesUC{users: users, roles: roles}
+// NewRoles wires the role assignment usecase. tokens may be nil, in which case
+// role changes do not revoke outstanding access tokens.
+func NewRoles(users *repository.UsersRepo, roles *repository.RolesRepo, tokens *Tokens) Roles {
+	return &rolesUC{users: users, roles: roles, tokens: tokens}
 }
 
-func (r *rolesUC) AssignByName(ctx context.Context, tenantID string, userID uuid.UUID, roleName string) (*repository.Role, error) {
+func (r *rolesUC) findUser(ctx context.Context, tenantID string, userID uuid.UUID) error {
 	u, err := r.users.FindByID(ctx, userID)
-	if err != nil {
-		return nil, err
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && u.TenantID != strings.TrimSpace(tenantID)) {
+		return ErrUserNotFound
 	}
-	if strings.TrimSpace(tenantID) != u.TenantID {
-		return nil, repository.ErrValidation("tenant mismatch")
+	return err
+}
+
+func (r *rolesUC) Assign(ctx context.Context, tenantID string, userID uuid.UUID, roleName string) (*repository.Role, error) {
+	return r.assign(ctx, tenantID, userID, roleName, false)
+}
+
+func (r *rolesUC) AssignByName(ctx context.Context, tenantID string, userID uuid.UUID, roleName string) (*repository.Role, error) {
+	return r.assign(ctx, tenantID, userID, roleName, true)
+}
+
+func (r *rolesUC) assign(ctx context.Context, tenantID string, userID uuid.UUID, roleName string, create bool) (*repository.Role, error) {
+	if err := r.findUser(ctx, tenantID, userID); err != nil {
+		return nil, err
 	}
 	roleName = strings.TrimSpace(roleName)
 	if roleName == "" {
 		return nil, repository.ErrValidation("role_name required")
 	}
 	role, err := r.roles.FindRoleByName(ctx, tenantID, roleName)
-	if err != nil {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows) && create:
 		role, err = r.roles.CreateRole(ctx, tenantID, roleName, false)
 		if err != nil {
 			return nil, err
 		}
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil, ErrRoleNotFound
+	case err != nil:
+		return nil, err
 	}
 	if err := r.roles.AssignUserRole(ctx, userID, role.ID); err != nil {
 		return nil, err
 	}
+	if r.tokens != nil {
+		if err := r.tokens.RevokeUser(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
 	return role, nil
 }
 
-func (r *rolesUC) List(ctx context.Context, userID uuid.UUID) ([]repository.Role, error) {
+func (r *rolesUC) List(ctx context.Context, tenantID string, userID uuid.UUID) ([]repository.Role, error) {
+	if err := r.findUser(ctx, tenantID, userID); err != nil {
+		return nil, err
+	}
 	return r.roles.ListUserRoles(ctx, userID)
 }
 
-func (r *rolesUC) Unassign(ctx context.Context, userID, roleID uuid.UUID) error {
-	return r.roles.UnassignUserRole(ctx, userID, roleID)
+func (r *rolesUC) Unassign(ctx context.Context, tenantID string, userID, roleID uuid.UUID) (*repository.Role, error) {
+	if err := r.findUser(ctx, tenantID, userID); err != nil {
+		return nil, err
+	}
+	role, err := r.roles.FindRoleByID(ctx, roleID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && role.TenantID != tenantID) {
+		return nil, ErrRoleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.roles.UnassignUserRole(ctx, userID, role.ID); err != nil {
+		return nil, err
+	}
+	if r.tokens != nil {
+		if err := r.tokens.RevokeUser(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return role, nil
 }
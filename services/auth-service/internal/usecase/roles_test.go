@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 
@@ -34,6 +35,38 @@ func ensureMigrationsRolesUC(t *testing.T, db *pgxpool.Pool) {
 	if err == nil {
 		_, _ = db.Exec(context.Background(), string(b1))
 	}
+	b5, err := os.ReadFile("../../migrations/005_token_version.up.sql")
+	if err == nil {
+		_, _ = db.Exec(context.Background(), string(b5))
+	}
+	b6, err := os.ReadFile("../../migrations/006_sessions.up.sql")
+	if err == nil {
+		_, _ = db.Exec(context.Background(), string(b6))
+	}
+	b7, err := os.ReadFile("../../migrations/007_mfa.up.sql")
+	if err == nil {
+		_, _ = db.Exec(context.Background(), string(b7))
+	}
+	b8, err := os.ReadFile("../../migrations/008_oidc.up.sql")
+	if err == nil {
+		_, _ = db.Exec(context.Background(), string(b8))
+	}
+	b9, err := os.ReadFile("../../migrations/009_password_policy.up.sql")
+	if err == nil {
+		_, _ = db.Exec(context.Background(), string(b9))
+	}
+	b10, err := os.ReadFile("../../migrations/010_user_invitations.up.sql")
+	if err == nil {
+		_, _ = db.Exec(context.Background(), string(b10))
+	}
+	b12, err := os.ReadFile("../../migrations/012_service_accounts.up.sql")
+	if err == nil {
+		_, _ = db.Exec(context.Background(), string(b12))
+	}
+	b13, err := os.ReadFile("../../migrations/013_outbox_events.up.sql")
+	if err == nil {
+		_, _ = db.Exec(context.Background(), string(b13))
+	}
 }
 
 func TestRolesUsecase_AssignListUnassign(t *testing.T) {
@@ -41,7 +74,7 @@ func TestRolesUsecase_AssignListUnassign(t *testing.T) {
 	ensureMigrationsRolesUC(t, db)
 	users := repository.NewUsersRepo(db)
 	roles := repository.NewRolesRepo(db)
-	uc := NewRoles(users, roles)
+	uc := NewRoles(users, roles, nil)
 
 	tenant := "t-" + uuid.NewString()
 	u, err := users.Create(context.Background(), repository.CreateUserParams{
@@ -52,14 +85,24 @@ func TestRolesUsecase_AssignListUnassign(t *testing.T) {
 	if err != nil {
 		t.Fatalf("create user err: %v", err)
 	}
+	if _, err := uc.Assign(context.Background(), tenant, u.ID, "admin"); !errors.Is(err, ErrRoleNotFound) {
+		t.Fatalf("assign of a missing role: %v", err)
+	}
 	if _, err := uc.AssignByName(context.Background(), tenant, u.ID, "admin"); err != nil {
 		t.Fatalf("assign err: %v", err)
 	}
-	items, err := uc.List(context.Background(), u.ID)
+	items, err := uc.List(context.Background(), tenant, u.ID)
 	if err != nil || len(items) == 0 {
 		t.Fatalf("list err: %v len=%d", err, len(items))
 	}
-	if err := uc.Unassign(context.Background(), u.ID, items[0].ID); err != nil {
+	// Another tenant can neither see nor remove the assignment
+	if _, err := uc.List(context.Background(), "other-"+tenant, u.ID); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("cross-tenant list: %v", err)
+	}
+	if _, err := uc.Unassign(context.Background(), "other-"+tenant, u.ID, items[0].ID); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("cross-tenant unassign: %v", err)
+	}
+	if _, err := uc.Unassign(context.Background(), tenant, u.ID, items[0].ID); err != nil {
 		t.Fatalf("unassign err: %v", err)
 	}
 	// tenant mismatch
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
+)
+
+const (
+	// apiKeyPrefix marks secrets from this service so scanners can spot
+	// leaked keys.
+	apiKeyPrefix        = "sk_"
+	defaultKeyRateLimit = 60
+	maxKeyRateLimit     = 10000
+)
+
+var (
+	ErrServiceAccountNotFound = errors.New("service account not found")
+	ErrAPIKeyNotFound         = errors.New("api key not found")
+	ErrAPIKeyInvalid          = errors.New("invalid, expired or revoked api key")
+)
+
+type ServiceAccountInput struct {
+	TenantID    string
+	Name        string
+	Description string
+	Roles       []string
+	CreatedBy   *uuid.UUID
+}
+
+type APIKeyInput struct {
+	TenantID         string
+	ServiceAccountID uuid.UUID
+	Name             string
+	Scopes           []string
+	RateLimit        int
+	ExpiresAt        *time.Time
+	CreatedBy        *uuid.UUID
+}
+
+// ServiceAccounts manages machine identities of a tenant and their API keys.
+// A key is shown once at creation; afterwards only its prefix is known.
+type ServiceAccounts interface {
+	Create(ctx context.Context, in ServiceAccountInput) (*repository.ServiceAccount, error)
+	List(ctx context.Context, tenantID string) ([]repository.ServiceAccount, error)
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) (*repository.ServiceAccount, error)
+	CreateKey(ctx context.Context, in APIKeyInput) (string, *repository.APIKey, error)
+	ListKeys(ctx context.Context, tenantID string, serviceAccountID uuid.UUID) ([]repository.APIKey, error)
+	RevokeKey(ctx context.Context, tenantID string, id uuid.UUID) (*repository.APIKey, error)
+	Verify(ctx context.Context, key string) (*repository.APIKey, *repository.User, error)
+}
+
+type serviceAccountsUC struct {
+	repo   *repository.ServiceAccountRepo
+	users  *repository.UsersRepo
+	roles  *repository.RolesRepo
+	tokens *Tokens
+	now    func() time.Time
+}
+
+// NewServiceAccounts wires the service account usecase. tokens may be nil, in
+// which case deleting an account or revoking a key leaves the access tokens
+// already minted valid until expiry.
+func NewServiceAccounts(repo *repository.ServiceAccountRepo, users *repository.UsersRepo, roles *repository.RolesRepo, tokens *Tokens) ServiceAccounts {
+	return &serviceAccountsUC{repo: repo, users: users, roles: roles, tokens: tokens, now: time.Now}
+}
+
+func (s *serviceAccountsUC) Create(ctx context.Context, in ServiceAccountInput) (*repository.ServiceAccount, error) {
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return nil, repository.ErrValidation("name required")
+	}
+	names := make([]string, 0, len(in.Roles))
+	for _, r := range in.Roles {
+		if r = strings.ToLower(strings.TrimSpace(r)); r != "" {
+			names = append(names, r)
+		}
+	}
+	found, err := s.roles.FindRolesByNames(ctx, in.TenantID, names)
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := make([]uuid.UUID, 0, len(names))
+	for _, n := range names {
+		role, ok := found[n]
+		if !ok {
+			return nil, repository.ErrValidation("unknown role: " + n)
+		}
+		roleIDs = append(roleIDs, role.ID)
+	}
+	return s.repo.Create(ctx, repository.CreateServiceAccountParams{
+		TenantID:    in.TenantID,
+		Name:        name,
+		Description: strings.TrimSpace(in.Description),
+		RoleIDs:     roleIDs,
+		CreatedBy:   in.CreatedBy,
+	})
+}
+
+func (s *serviceAccountsUC) List(ctx context.Context, tenantID string) ([]repository.ServiceAccount, error) {
+	return s.repo.List(ctx, tenantID)
+}
+
+func (s *serviceAccountsUC) find(ctx context.Context, tenantID string, id uuid.UUID) (*repository.ServiceAccount, error) {
+	sa, err := s.repo.FindByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && sa.TenantID != tenantID) {
+		return nil, ErrServiceAccountNotFound
+	}
+	return sa, err
+}
+
+// Delete revokes every key of the account and, through the token version,
+// every access token already exchanged for one.
+func (s *serviceAccountsUC) Delete(ctx context.Context, tenantID string, id uuid.UUID) (*repository.ServiceAccount, error) {
+	sa, err := s.find(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Delete(ctx, sa.ID); err != nil {
+		return nil, err
+	}
+	if s.tokens != nil {
+		if err := s.tokens.RevokeUser(ctx, sa.ID); err != nil {
+			return nil, err
+		}
+	}
+	return sa, nil
+}
+
+func (s *serviceAccountsUC) CreateKey(ctx context.Context, in APIKeyInput) (string, *repository.APIKey, error) {
+	sa, err := s.find(ctx, in.TenantID, in.ServiceAccountID)
+	if err != nil {
+		return "", nil, err
+	}
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return "", nil, repository.ErrValidation("name required")
+	}
+	scopes, err := validateScopes(in.Scopes)
+	if err != nil {
+		return "", nil, err
+	}
+	limit := in.RateLimit
+	if limit == 0 {
+		limit = defaultKeyRateLimit
+	}
+	if limit < 0 || limit > maxKeyRateLimit {
+		return "", nil, repository.ErrValidation("rate_limit must be between 1 and 10000")
+	}
+	if in.ExpiresAt != nil && !in.ExpiresAt.After(s.now()) {
+		return "", nil, repository.ErrValidation("expires_at must be in the future")
+	}
+	key, prefix, err := newAPIKey()
+	if err != nil {
+		return "", nil, err
+	}
+	k, err := s.repo.CreateKey(ctx, repository.CreateAPIKeyParams{
+		TenantID:         sa.TenantID,
+		ServiceAccountID: sa.ID,
+		Name:             name,
+		Prefix:           prefix,
+		KeyHash:          hashAPIKey(key),
+		Scopes:           scopes,
+		RateLimit:        limit,
+		ExpiresAt:        in.ExpiresAt,
+		CreatedBy:        in.CreatedBy,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return key, k, nil
+}
+
+func (s *serviceAccountsUC) ListKeys(ctx context.Context, tenantID string, serviceAccountID uuid.UUID) ([]repository.APIKey, error) {
+	sa, err := s.find(ctx, tenantID, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListKeys(ctx, sa.ID)
+}
+
+func (s *serviceAccountsUC) RevokeKey(ctx context.Context, tenantID string, id uuid.UUID) (*repository.APIKey, error) {
+	k, err := s.repo.FindKeyByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && k.TenantID != tenantID) {
+		return nil, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.RevokeKey(ctx, k.ID); err != nil {
+		return nil, err
+	}
+	// Tokens are minted per account rather than per key, so the account's
+	// other keys are simply exchanged again
+	if s.tokens != nil {
+		if err := s.tokens.RevokeUser(ctx, k.ServiceAccountID); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// Verify resolves key to its API key and the service account's user, and
+// records the use. Every failure is reported as ErrAPIKeyInvalid.
+func (s *serviceAccountsUC) Verify(ctx context.Context, key string) (*repository.APIKey, *repository.User, error) {
+	prefix, ok := apiKeyPrefixOf(key)
+	if !ok {
+		return nil, nil, ErrAPIKeyInvalid
+	}
+	k, err := s.repo.FindKeyByPrefix(ctx, prefix)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, ErrAPIKeyInvalid
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	now := s.now().UTC()
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(key)), []byte(k.KeyHash)) != 1 ||
+		k.RevokedAt != nil || (k.ExpiresAt != nil && !k.ExpiresAt.After(now)) {
+		return nil, nil, ErrAPIKeyInvalid
+	}
+	u, err := s.users.FindByID(ctx, k.ServiceAccountID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !u.IsActive) {
+		return nil, nil, ErrAPIKeyInvalid
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.repo.TouchKey(ctx, k.ID, now); err != nil {
+		return nil, nil, err
+	}
+	k.LastUsedAt = &now
+	return k, u, nil
+}
+
+// validateScopes accepts catalogue permissions and "resource:*" for a
+// resource of the catalogue.
+func validateScopes(in []string) ([]string, error) {
+	known := map[string]bool{}
+	for _, p := range authz.Catalogue() {
+		resource, _, _ := strings.Cut(p, ":")
+		known[p] = true
+		known[resource+":*"] = true
+	}
+	out := make([]string, 0, len(in))
+	seen := map[string]bool{}
+	for _, s := range in {
+		s = strings.TrimSpace(s)
+		if !known[s] {
+			return nil, repository.ErrValidation("unknown scope: " + s)
+		}
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// newAPIKey returns a key of the form sk_<prefix>_<secret>. The prefix is
+// stored in clear to find the key; the secret is only ever hashed.
+func newAPIKey() (key, prefix string, err error) {
+	var p [6]byte
+	var secret [32]byte
+	if _, err := rand.Read(p[:]); err != nil {
+		return "", "", err
+	}
+	if _, err := rand.Read(secret[:]); err != nil {
+		return "", "", err
+	}
+	prefix = hex.EncodeToString(p[:])
+	return apiKeyPrefix + prefix + "_" + hex.EncodeToString(secret[:]), prefix, nil
+}
+
+func apiKeyPrefixOf(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, apiKeyPrefix)
+	if !ok {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || prefix == "" || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
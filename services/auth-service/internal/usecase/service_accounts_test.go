@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+)
+
+func TestAPIKeyFormat(t *testing.T) {
+	key, prefix, err := newAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(key, "sk_"+prefix+"_") {
+		t.Fatalf("key %q does not start with its prefix %q", key, prefix)
+	}
+	if got, ok := apiKeyPrefixOf(key); !ok || got != prefix {
+		t.Fatalf("prefix not recovered: %q %v", got, ok)
+	}
+	for _, bad := range []string{"", "sk_", "sk_abc", "sk__secret", "pk_abc_secret"} {
+		if _, ok := apiKeyPrefixOf(bad); ok {
+			t.Fatalf("%q must be rejected", bad)
+		}
+	}
+	if _, err := validateScopes([]string{"student:read", "invoice:*"}); err != nil {
+		t.Fatalf("catalogue scopes rejected: %v", err)
+	}
+	if _, err := validateScopes([]string{"student:fly"}); err == nil {
+		t.Fatalf("unknown scope accepted")
+	}
+}
+
+func TestServiceAccounts_KeyLifecycle(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	users := repository.NewUsersRepo(db)
+	roles := repository.NewRolesRepo(db)
+	tokens := NewTokens(users, roles, repository.NewPermissionsRepo(db), &memKV{m: map[string]string{}}, false)
+	uc := NewServiceAccounts(repository.NewServiceAccountRepo(db), users, roles, tokens).(*serviceAccountsUC)
+
+	tenant := "t-" + uuid.NewString()
+	if _, err := roles.CreateRole(ctx, tenant, "dapodik", false); err != nil {
+		t.Fatalf("create role err: %v", err)
+	}
+	if _, err := uc.Create(ctx, ServiceAccountInput{TenantID: tenant, Name: "sync", Roles: []string{"missing"}}); err == nil {
+		t.Fatalf("unknown role must be rejected")
+	}
+	sa, err := uc.Create(ctx, ServiceAccountInput{TenantID: tenant, Name: "Dapodik sync", Roles: []string{"Dapodik"}})
+	if err != nil {
+		t.Fatalf("create err: %v", err)
+	}
+	if _, err := uc.Create(ctx, ServiceAccountInput{TenantID: tenant, Name: "Dapodik sync"}); !errors.Is(err, repository.ErrDuplicate) {
+		t.Fatalf("expected ErrDuplicate, got %v", err)
+	}
+	assigned, _ := roles.ListUserRoles(ctx, sa.ID)
+	if len(assigned) != 1 || assigned[0].Name != "dapodik" {
+		t.Fatalf("role not assigned: %+v", assigned)
+	}
+
+	key, k, err := uc.CreateKey(ctx, APIKeyInput{TenantID: tenant, ServiceAccountID: sa.ID, Name: "prod", Scopes: []string{"student:read"}})
+	if err != nil {
+		t.Fatalf("create key err: %v", err)
+	}
+	if k.RateLimit != defaultKeyRateLimit || k.KeyHash == key {
+		t.Fatalf("unexpected key %+v", k)
+	}
+	if _, _, err := uc.CreateKey(ctx, APIKeyInput{TenantID: "other-" + tenant, ServiceAccountID: sa.ID, Name: "x"}); !errors.Is(err, ErrServiceAccountNotFound) {
+		t.Fatalf("other tenants must not mint keys, got %v", err)
+	}
+
+	got, u, err := uc.Verify(ctx, key)
+	if err != nil || got.ID != k.ID || u.ID != sa.ID || got.LastUsedAt == nil {
+		t.Fatalf("verify err=%v key=%+v", err, got)
+	}
+	if _, _, err := uc.Verify(ctx, key+"x"); !errors.Is(err, ErrAPIKeyInvalid) {
+		t.Fatalf("tampered key must be rejected, got %v", err)
+	}
+	uc.now = func() time.Time { return time.Now().Add(time.Hour) }
+	past := time.Now().Add(time.Minute)
+	_, expiring, err := uc.CreateKey(ctx, APIKeyInput{TenantID: tenant, ServiceAccountID: sa.ID, Name: "short", ExpiresAt: &past})
+	if err == nil || expiring != nil {
+		t.Fatalf("expiry in the past must be rejected")
+	}
+	uc.now = time.Now
+
+	before, _ := users.TokenVersion(ctx, sa.ID)
+	if _, err := uc.RevokeKey(ctx, tenant, k.ID); err != nil {
+		t.Fatalf("revoke err: %v", err)
+	}
+	// Tokens already minted for the key must stop working too
+	if after, _ := users.TokenVersion(ctx, sa.ID); after <= before {
+		t.Fatalf("revoke must bump the token version: before=%d after=%d", before, after)
+	}
+	if _, _, err := uc.Verify(ctx, key); !errors.Is(err, ErrAPIKeyInvalid) {
+		t.Fatalf("revoked key must be rejected, got %v", err)
+	}
+
+	other, _, err := uc.CreateKey(ctx, APIKeyInput{TenantID: tenant, ServiceAccountID: sa.ID, Name: "second"})
+	if err != nil {
+		t.Fatalf("create key err: %v", err)
+	}
+	if _, err := uc.Delete(ctx, tenant, sa.ID); err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if _, _, err := uc.Verify(ctx, other); !errors.Is(err, ErrAPIKeyInvalid) {
+		t.Fatalf("keys of a deleted account must be rejected, got %v", err)
+	}
+	if items, _ := uc.List(ctx, tenant); len(items) != 0 {
+		t.Fatalf("deleted account still listed: %+v", items)
+	}
+}
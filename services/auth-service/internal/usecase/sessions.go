@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrSessionRevoked  = errors.New("session revoked")
+	ErrRefreshReuse    = errors.New("refresh token reuse detected")
+)
+
+// Sessions tracks refresh-token families so users can see and revoke where
+// they are signed in.
+type Sessions interface {
+	Start(ctx context.Context, u *repository.User, jti, userAgent, ip string, expiresAt time.Time) (*repository.Session, error)
+	Rotate(ctx context.Context, sessionID, userID uuid.UUID, presentedJTI, nextJTI, userAgent, ip string, expiresAt time.Time) (*repository.Session, error)
+	List(ctx context.Context, tenantID string, userID uuid.UUID) ([]repository.Session, error)
+	Revoke(ctx context.Context, tenantID string, userID, sessionID uuid.UUID, reason string) error
+	RevokeAll(ctx context.Context, tenantID string, userID uuid.UUID, reason string) (int64, error)
+}
+
+type sessionsUC struct {
+	repo   *repository.SessionsRepo
+	users  *repository.UsersRepo
+	tokens *Tokens
+}
+
+// NewSessions wires the session registry. tokens may be nil, in which case
+// revoking every session leaves outstanding access tokens valid until expiry.
+func NewSessions(repo *repository.SessionsRepo, users *repository.UsersRepo, tokens *Tokens) Sessions {
+	return &sessionsUC{repo: repo, users: users, tokens: tokens}
+}
+
+func (s *sessionsUC) Start(ctx context.Context, u *repository.User, jti, userAgent, ip string, expiresAt time.Time) (*repository.Session, error) {
+	return s.repo.Create(ctx, repository.CreateSessionParams{
+		TenantID:   u.TenantID,
+		UserID:     u.ID,
+		RefreshJTI: jti,
+		UserAgent:  userAgent,
+		IP:         ip,
+		ExpiresAt:  expiresAt,
+	})
+}
+
+// Rotate exchanges presentedJTI for nextJTI. Presenting a jti that was already
+// rotated out means the token leaked, so the whole family is revoked.
+func (s *sessionsUC) Rotate(ctx context.Context, sessionID, userID uuid.UUID, presentedJTI, nextJTI, userAgent, ip string, expiresAt time.Time) (*repository.Session, error) {
+	sess, err := s.repo.FindByID(ctx, sessionID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && sess.UserID != userID) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if sess.RevokedAt != nil || !sess.ExpiresAt.After(now) {
+		return nil, ErrSessionRevoked
+	}
+	ok, err := s.repo.Rotate(ctx, sess.ID, presentedJTI, nextJTI, userAgent, ip, now, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := s.repo.Revoke(ctx, sess.ID, "refresh_reuse"); err != nil {
+			return nil, err
+		}
+		if err := s.revokeAccess(ctx, sess.UserID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshReuse
+	}
+	sess.RefreshJTI = nextJTI
+	sess.UserAgent = userAgent
+	sess.IP = ip
+	sess.LastUsedAt = now
+	sess.ExpiresAt = expiresAt
+	return sess, nil
+}
+
+func (s *sessionsUC) List(ctx context.Context, tenantID string, userID uuid.UUID) ([]repository.Session, error) {
+	return s.repo.ListActive(ctx, tenantID, userID, time.Now().UTC())
+}
+
+func (s *sessionsUC) Revoke(ctx context.Context, tenantID string, userID, sessionID uuid.UUID, reason string) error {
+	sess, err := s.repo.FindByID(ctx, sessionID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && (sess.TenantID != tenantID || sess.UserID != userID)) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return s.repo.Revoke(ctx, sess.ID, reason)
+}
+
+// RevokeAll ends every session of the user and, through the token version,
+// every access token issued to them.
+func (s *sessionsUC) RevokeAll(ctx context.Context, tenantID string, userID uuid.UUID, reason string) (int64, error) {
+	u, err := s.users.FindByID(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && u.TenantID != tenantID) {
+		return 0, ErrUserNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	n, err := s.repo.RevokeAllForUser(ctx, tenantID, userID, reason)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.revokeAccess(ctx, userID); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (s *sessionsUC) revokeAccess(ctx context.Context, userID uuid.UUID) error {
+	if s.tokens == nil {
+		return nil
+	}
+	return s.tokens.RevokeUser(ctx, userID)
+}
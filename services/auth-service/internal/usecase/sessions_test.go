@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+)
+
+func TestSessions_RotateReuseRevokesFamily(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	users := repository.NewUsersRepo(db)
+	uc := NewSessions(repository.NewSessionsRepo(db), users, nil)
+
+	tenant := "t-" + uuid.NewString()
+	u, err := users.Create(ctx, repository.CreateUserParams{TenantID: tenant, Email: "sessions@test.local", Password: "password123"})
+	if err != nil {
+		t.Fatalf("create user err: %v", err)
+	}
+	exp := time.Now().Add(time.Hour)
+	sess, err := uc.Start(ctx, u, "jti-1", "ua", "10.0.0.1", exp)
+	if err != nil {
+		t.Fatalf("start err: %v", err)
+	}
+	if _, err := uc.Rotate(ctx, sess.ID, u.ID, "jti-1", "jti-2", "ua", "10.0.0.2", exp); err != nil {
+		t.Fatalf("rotate err: %v", err)
+	}
+	// Replaying the rotated token kills the family, including jti-2.
+	if _, err := uc.Rotate(ctx, sess.ID, u.ID, "jti-1", "jti-3", "ua", "10.0.0.3", exp); !errors.Is(err, ErrRefreshReuse) {
+		t.Fatalf("expected ErrRefreshReuse, got %v", err)
+	}
+	if _, err := uc.Rotate(ctx, sess.ID, u.ID, "jti-2", "jti-4", "ua", "10.0.0.2", exp); !errors.Is(err, ErrSessionRevoked) {
+		t.Fatalf("expected ErrSessionRevoked, got %v", err)
+	}
+	list, err := uc.List(ctx, tenant, u.ID)
+	if err != nil || len(list) != 0 {
+		t.Fatalf("expected no active sessions, err=%v n=%d", err, len(list))
+	}
+}
+
+func TestSessions_RevokeOwnAndAll(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	users := repository.NewUsersRepo(db)
+	uc := NewSessions(repository.NewSessionsRepo(db), users, nil)
+
+	tenant := "t-" + uuid.NewString()
+	u, err := users.Create(ctx, repository.CreateUserParams{TenantID: tenant, Email: "sessions2@test.local", Password: "password123"})
+	if err != nil {
+		t.Fatalf("create user err: %v", err)
+	}
+	exp := time.Now().Add(time.Hour)
+	s1, _ := uc.Start(ctx, u, "a", "phone", "1.1.1.1", exp)
+	if _, err := uc.Start(ctx, u, "b", "laptop", "2.2.2.2", exp); err != nil {
+		t.Fatalf("start err: %v", err)
+	}
+	if err := uc.Revoke(ctx, tenant, uuid.New(), s1.ID, "user_revoked"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("other users cannot revoke the session, got %v", err)
+	}
+	if err := uc.Revoke(ctx, tenant, u.ID, s1.ID, "user_revoked"); err != nil {
+		t.Fatalf("revoke err: %v", err)
+	}
+	if list, _ := uc.List(ctx, tenant, u.ID); len(list) != 1 {
+		t.Fatalf("expected 1 active session, got %d", len(list))
+	}
+	if _, err := uc.RevokeAll(ctx, "other-"+tenant, u.ID, "admin_revoked"); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound across tenants, got %v", err)
+	}
+	n, err := uc.RevokeAll(ctx, tenant, u.ID, "logout_all")
+	if err != nil || n != 1 {
+		t.Fatalf("revoke all err=%v n=%d", err, n)
+	}
+}
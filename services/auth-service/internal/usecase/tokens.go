@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+)
+
+// Tokens builds access-token claims from the user's roles and keeps the token
+// version published in Redis, where every service checks it.
+type Tokens struct {
+	users            *repository.UsersRepo
+	roles            *repository.RolesRepo
+	perms            *repository.PermissionsRepo
+	kv               redisutil.KV
+	embedPermissions bool
+}
+
+func NewTokens(users *repository.UsersRepo, roles *repository.RolesRepo, perms *repository.PermissionsRepo, kv redisutil.KV, embedPermissions bool) *Tokens {
+	return &Tokens{users: users, roles: roles, perms: perms, kv: kv, embedPermissions: embedPermissions}
+}
+
+func (t *Tokens) Claims(ctx context.Context, u *repository.User) (jwtutil.Claims, error) {
+	c := jwtutil.Claims{UserID: u.ID, TenantID: u.TenantID, Roles: []string{}}
+	roles, err := t.roles.ListUserRoles(ctx, u.ID)
+	if err != nil {
+		return c, err
+	}
+	for _, r := range roles {
+		if r.TenantID == u.TenantID {
+			c.Roles = append(c.Roles, r.Name)
+		}
+	}
+	if t.embedPermissions {
+		perms, err := t.perms.GetByUser(ctx, u.ID, u.TenantID)
+		if err != nil {
+			return c, err
+		}
+		c.Permissions = make([]string, 0, len(perms))
+		for _, p := range perms {
+			c.Permissions = append(c.Permissions, p.Resource+":"+p.Action)
+		}
+	}
+	v, err := t.users.TokenVersion(ctx, u.ID)
+	if err != nil {
+		return c, err
+	}
+	c.TokenVersion = v
+	// Republish so a flushed Redis does not silently accept stale tokens.
+	if v > 0 {
+		t.publish(ctx, map[uuid.UUID]int64{u.ID: v})
+	}
+	return c, nil
+}
+
+// RevokeUser invalidates every outstanding access token of userID.
+func (t *Tokens) RevokeUser(ctx context.Context, userID uuid.UUID) error {
+	versions, err := t.users.BumpTokenVersion(ctx, userID)
+	if err != nil {
+		return err
+	}
+	t.publish(ctx, versions)
+	return nil
+}
+
+// RevokeRole invalidates the access tokens of every user holding roleID.
+func (t *Tokens) RevokeRole(ctx context.Context, roleID uuid.UUID) error {
+	versions, err := t.users.BumpTokenVersionForRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	t.publish(ctx, versions)
+	return nil
+}
+
+func (t *Tokens) publish(ctx context.Context, versions map[uuid.UUID]int64) {
+	if t.kv == nil {
+		return
+	}
+	for id, v := range versions {
+		_ = redisutil.Set(ctx, t.kv, middleware.TokenVersionKey(id), strconv.FormatInt(v, 10), 0)
+	}
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+)
+
+func TestTokens_ClaimsAndRevokeRole(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	users := repository.NewUsersRepo(db)
+	roles := repository.NewRolesRepo(db)
+	perms := repository.NewPermissionsRepo(db)
+	tokens := NewTokens(users, roles, perms, nil, true)
+
+	tenant := "t-" + uuid.NewString()
+	resource := "res" + uuid.NewString()[:8]
+	p, err := perms.Create(ctx, resource, "read")
+	if err != nil {
+		t.Fatalf("create permission err: %v", err)
+	}
+	role, err := roles.CreateRole(ctx, tenant, "teacher", false)
+	if err != nil {
+		t.Fatalf("create role err: %v", err)
+	}
+	if err := perms.AssignToRole(ctx, role.ID, p.ID); err != nil {
+		t.Fatalf("assign permission err: %v", err)
+	}
+	u, err := users.Create(ctx, repository.CreateUserParams{TenantID: tenant, Email: "tokens@test.local", Password: "password123"})
+	if err != nil {
+		t.Fatalf("create user err: %v", err)
+	}
+	if err := roles.AssignUserRole(ctx, u.ID, role.ID); err != nil {
+		t.Fatalf("assign role err: %v", err)
+	}
+
+	c, err := tokens.Claims(ctx, u)
+	if err != nil {
+		t.Fatalf("claims err: %v", err)
+	}
+	if len(c.Roles) != 1 || c.Roles[0] != "teacher" {
+		t.Fatalf("roles=%v", c.Roles)
+	}
+	if len(c.Permissions) != 1 || c.Permissions[0] != resource+":read" {
+		t.Fatalf("permissions=%v", c.Permissions)
+	}
+
+	if err := tokens.RevokeRole(ctx, role.ID); err != nil {
+		t.Fatalf("revoke role err: %v", err)
+	}
+	c2, err := tokens.Claims(ctx, u)
+	if err != nil {
+		t.Fatalf("claims err: %v", err)
+	}
+	if c2.TokenVersion <= c.TokenVersion {
+		t.Fatalf("expected token version bump, got %d -> %d", c.TokenVersion, c2.TokenVersion)
+	}
+}
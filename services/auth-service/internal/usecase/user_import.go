@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/validator"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const MaxImportRows = 2000
+
+// ErrImportInvalid is returned with a report when rows failed validation and
+// nothing was created.
+var ErrImportInvalid = errors.New("import contains invalid rows")
+
+type ImportInput struct {
+	TenantID string
+	// Rows holds the parsed file, header row first.
+	Rows            [][]string
+	DryRun          bool
+	Invite          bool
+	DefaultPassword string
+}
+
+type ImportRow struct {
+	Row    int        `json:"row"`
+	Email  string     `json:"email"`
+	Roles  []string   `json:"roles"`
+	UserID *uuid.UUID `json:"user_id,omitempty"`
+	Errors []string   `json:"errors,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool        `json:"dry_run"`
+	Invite  bool        `json:"invite"`
+	Total   int         `json:"total"`
+	Valid   int         `json:"valid"`
+	Invalid int         `json:"invalid"`
+	Created int         `json:"created"`
+	Rows    []ImportRow `json:"rows"`
+}
+
+// UserImport provisions many users of a tenant from a spreadsheet.
+type UserImport interface {
+	Import(ctx context.Context, in ImportInput) (*ImportReport, error)
+}
+
+type userImportUC struct {
+	users    *repository.UsersRepo
+	roles    *repository.RolesRepo
+	policies PasswordPolicies
+	events   outbox.Events
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// NewUserImport wires the bulk import usecase. Invited users stay inactive
+// until they accept an invitation valid for inviteTTL, enqueued with the
+// users they belong to.
+func NewUserImport(users *repository.UsersRepo, roles *repository.RolesRepo, policies PasswordPolicies, events outbox.Events, inviteTTL time.Duration) UserImport {
+	if inviteTTL <= 0 {
+		inviteTTL = defaultInvitationTTL
+	}
+	return &userImportUC{users: users, roles: roles, policies: policies, events: events, ttl: inviteTTL, now: time.Now}
+}
+
+// Import validates every row before writing anything. Without DryRun the
+// valid file is created in one transaction; if any row is invalid the report
+// is returned together with ErrImportInvalid.
+func (u *userImportUC) Import(ctx context.Context, in ImportInput) (*ImportReport, error) {
+	if !in.Invite && strings.TrimSpace(in.DefaultPassword) == "" {
+		return nil, repository.ErrValidation("either invite or default_password is required")
+	}
+	rows, err := parseImportRows(in.Rows)
+	if err != nil {
+		return nil, err
+	}
+	if !in.Invite {
+		if err := validatePassword(ctx, u.policies, in.TenantID, strings.TrimSpace(in.DefaultPassword)); err != nil {
+			return nil, err
+		}
+	}
+	known, err := u.validate(ctx, in.TenantID, rows)
+	if err != nil {
+		return nil, err
+	}
+	report := &ImportReport{DryRun: in.DryRun, Invite: in.Invite, Total: len(rows), Rows: rows}
+	for _, r := range rows {
+		if len(r.Errors) == 0 {
+			report.Valid++
+		}
+	}
+	report.Invalid = report.Total - report.Valid
+	if in.DryRun {
+		return report, nil
+	}
+	if report.Invalid > 0 {
+		return report, ErrImportInvalid
+	}
+	return report, u.create(ctx, in, known, report)
+}
+
+// parseImportRows reads the header to locate the email and roles columns and
+// turns each non-blank line into a report row. Row numbers match the file.
+func parseImportRows(lines [][]string) ([]ImportRow, error) {
+	if len(lines) == 0 {
+		return nil, repository.ErrValidation("file is empty")
+	}
+	emailCol, rolesCol := -1, -1
+	for i, h := range lines[0] {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))) {
+		case "email":
+			emailCol = i
+		case "roles", "role":
+			rolesCol = i
+		}
+	}
+	if emailCol < 0 {
+		return nil, repository.ErrValidation("header must contain an email column")
+	}
+	cell := func(line []string, i int) string {
+		if i < 0 || i >= len(line) {
+			return ""
+		}
+		return strings.TrimSpace(line[i])
+	}
+	var rows []ImportRow
+	for n, line := range lines[1:] {
+		if strings.TrimSpace(strings.Join(line, "")) == "" {
+			continue
+		}
+		roles := strings.FieldsFunc(cell(line, rolesCol), func(r rune) bool { return r == ';' || r == '|' || r == ',' })
+		for i := range roles {
+			roles[i] = strings.TrimSpace(roles[i])
+		}
+		rows = append(rows, ImportRow{Row: n + 2, Email: strings.ToLower(cell(line, emailCol)), Roles: roles})
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrValidation("file has no data rows")
+	}
+	if len(rows) > MaxImportRows {
+		return nil, repository.ErrValidation(fmt.Sprintf("at most %d rows can be imported at once", MaxImportRows))
+	}
+	return rows, nil
+}
+
+// validate records every problem of every row so the whole file can be fixed
+// in one pass. It returns the tenant roles the rows refer to.
+func (u *userImportUC) validate(ctx context.Context, tenantID string, rows []ImportRow) (map[string]repository.Role, error) {
+	seen := map[string]int{}
+	var emails, roleNames []string
+	for i := range rows {
+		r := &rows[i]
+		payload := struct {
+			Email string `validate:"required,email"`
+		}{Email: r.Email}
+		if err := validator.Validate(payload); err != nil {
+			r.Errors = append(r.Errors, "invalid email format")
+		} else {
+			emails = append(emails, r.Email)
+		}
+		if first, ok := seen[r.Email]; ok && r.Email != "" {
+			r.Errors = append(r.Errors, fmt.Sprintf("duplicate of row %d", first))
+		} else {
+			seen[r.Email] = r.Row
+		}
+		for _, name := range r.Roles {
+			roleNames = append(roleNames, strings.ToLower(name))
+		}
+	}
+	existing := map[string]bool{}
+	if len(emails) > 0 {
+		var err error
+		if existing, err = u.users.ExistingEmails(ctx, tenantID, emails); err != nil {
+			return nil, err
+		}
+	}
+	known := map[string]repository.Role{}
+	if len(roleNames) > 0 {
+		var err error
+		if known, err = u.roles.FindRolesByNames(ctx, tenantID, roleNames); err != nil {
+			return nil, err
+		}
+	}
+	for i := range rows {
+		r := &rows[i]
+		if existing[r.Email] {
+			r.Errors = append(r.Errors, "email already registered")
+		}
+		for _, name := range r.Roles {
+			if _, ok := known[strings.ToLower(name)]; !ok {
+				r.Errors = append(r.Errors, fmt.Sprintf("unknown role %q", name))
+			}
+		}
+	}
+	return known, nil
+}
+
+func (u *userImportUC) create(ctx context.Context, in ImportInput, known map[string]repository.Role, report *ImportReport) error {
+	passwordHash := invitedPasswordHash
+	if !in.Invite {
+		hash, err := bcrypt.GenerateFromPassword([]byte(strings.TrimSpace(in.DefaultPassword)), 12)
+		if err != nil {
+			return err
+		}
+		passwordHash = string(hash)
+	}
+	expires := u.now().UTC().Add(u.ttl)
+	tokens := make([]string, len(report.Rows))
+	items := make([]repository.ImportUser, len(report.Rows))
+	for i, r := range report.Rows {
+		it := repository.ImportUser{Email: r.Email, PasswordHash: passwordHash}
+		for _, name := range r.Roles {
+			it.RoleIDs = append(it.RoleIDs, known[strings.ToLower(name)].ID)
+		}
+		if in.Invite {
+			token, hash, err := newInvitationToken()
+			if err != nil {
+				return err
+			}
+			tokens[i] = token
+			it.InviteTokenHash = hash
+			it.InviteExpiresAt = expires
+		}
+		items[i] = it
+	}
+	var created []repository.User
+	err := u.events.Transact(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = u.users.CreateBatch(ctx, in.TenantID, items); err != nil {
+			return err
+		}
+		if !in.Invite {
+			return nil
+		}
+		for i := range created {
+			if err := enqueueInvitation(ctx, u.events, &created[i], tokens[i], expires); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i := range created {
+		id := created[i].ID
+		report.Rows[i].UserID = &id
+	}
+	report.Created = len(created)
+	return nil
+}
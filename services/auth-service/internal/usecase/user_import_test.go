@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
+)
+
+func TestParseImportRows(t *testing.T) {
+	rows, err := parseImportRows([][]string{
+		{"\ufeffEmail", "Name", "Roles"},
+		{" Guru@School.ID ", "Guru", "teacher; staff|Teacher"},
+		{"", "", ""},
+		{"tu@school.id"},
+	})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []ImportRow{
+		{Row: 2, Email: "guru@school.id", Roles: []string{"teacher", "staff", "Teacher"}},
+		{Row: 4, Email: "tu@school.id", Roles: []string{}},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows=%+v", rows)
+	}
+	for i := range want {
+		if rows[i].Row != want[i].Row || rows[i].Email != want[i].Email || len(rows[i].Roles) != len(want[i].Roles) {
+			t.Fatalf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+		if len(want[i].Roles) > 0 && !reflect.DeepEqual(rows[i].Roles, want[i].Roles) {
+			t.Fatalf("row %d roles = %q", i, rows[i].Roles)
+		}
+	}
+
+	if _, err := parseImportRows([][]string{{"name"}, {"x"}}); err == nil {
+		t.Fatalf("missing email column must fail")
+	}
+	if _, err := parseImportRows([][]string{{"email"}}); err == nil {
+		t.Fatalf("header only must fail")
+	}
+}
+
+func TestUserImport_DryRunAndInvite(t *testing.T) {
+	db := testDBRolesUC(t)
+	ensureMigrationsRolesUC(t, db)
+	ctx := context.Background()
+	users := repository.NewUsersRepo(db)
+	roles := repository.NewRolesRepo(db)
+	uc := NewUserImport(users, roles, nil, outbox.New(db), 0)
+
+	tenant := "t-" + uuid.NewString()
+	teacher, err := roles.CreateRole(ctx, tenant, "Teacher", false)
+	if err != nil {
+		t.Fatalf("create role err: %v", err)
+	}
+	if _, err := users.Create(ctx, repository.CreateUserParams{TenantID: tenant, Email: "existing@school.id", Password: "Existing#1"}); err != nil {
+		t.Fatalf("create user err: %v", err)
+	}
+	lines := [][]string{
+		{"email", "roles"},
+		{"guru1@school.id", "teacher"},
+		{"existing@school.id", ""},
+		{"guru1@school.id", "principal"},
+		{"bad-email", ""},
+	}
+	report, err := uc.Import(ctx, ImportInput{TenantID: tenant, Rows: lines, DryRun: true, Invite: true})
+	if err != nil {
+		t.Fatalf("dry run err: %v", err)
+	}
+	if report.Valid != 1 || report.Invalid != 3 || report.Created != 0 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if got := report.Rows[2].Errors; len(got) != 2 {
+		t.Fatalf("row 4 should be a duplicate with an unknown role, got %q", got)
+	}
+
+	report, err = uc.Import(ctx, ImportInput{TenantID: tenant, Rows: lines, Invite: true})
+	if !errors.Is(err, ErrImportInvalid) || report == nil {
+		t.Fatalf("expected ErrImportInvalid, got %v", err)
+	}
+	if _, err := users.FindByEmail(ctx, tenant, "guru1@school.id"); err == nil {
+		t.Fatalf("nothing may be created when a row is invalid")
+	}
+
+	report, err = uc.Import(ctx, ImportInput{TenantID: tenant, Rows: [][]string{
+		{"email", "roles"},
+		{"guru1@school.id", "teacher"},
+		{"guru2@school.id", ""},
+	}, Invite: true})
+	if err != nil || report.Created != 2 || report.Rows[0].UserID == nil {
+		t.Fatalf("import err=%v report=%+v", err, report)
+	}
+	assigned, err := roles.ListUserRoles(ctx, *report.Rows[0].UserID)
+	if err != nil || len(assigned) != 1 || assigned[0].ID != teacher.ID {
+		t.Fatalf("role not assigned: %+v err=%v", assigned, err)
+	}
+	if u, _ := users.FindByID(ctx, *report.Rows[0].UserID); u == nil || u.IsActive {
+		t.Fatalf("invited users must stay inactive until activation")
+	}
+	var invites int
+	if err := db.QueryRow(ctx, `SELECT COUNT(1) FROM user_invitations WHERE tenant_id=$1 AND accepted_at IS NULL`, tenant).Scan(&invites); err != nil || invites != 2 {
+		t.Fatalf("expected 2 invitations, got %d err=%v", invites, err)
+	}
+}
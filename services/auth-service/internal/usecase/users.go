@@ -4,7 +4,6 @@ import (
 	"context"
 	"strings"
 	"time"
-	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/auth-service/internal/repository"
@@ -32,11 +31,14 @@ type Users interface {
 }
 
 type users struct {
-	repo *repository.UsersRepo
+	repo     *repository.UsersRepo
+	policies PasswordPolicies
 }
 
-func NewUsers(repo *repository.UsersRepo) Users {
-	return &users{repo: repo}
+// NewUsers wires the user management usecase. Passwords are checked against
+// the tenant's policy, or the default policy when policies is nil.
+func NewUsers(repo *repository.UsersRepo, policies PasswordPolicies) Users {
+	return &users{repo: repo, policies: policies}
 }
 
 func (u *users) Register(ctx context.Context, in UserRegisterInput) (*repository.User, error) {
@@ -51,14 +53,8 @@ func (u *users) Register(ctx context.Context, in UserRegisterInput) (*repository
 	if err := validator.Validate(payload); err != nil {
 		return nil, repository.ErrValidation("invalid email format")
 	}
-	if len(pwd) < 8 {
-		return nil, repository.ErrValidation("password must be at least 8 characters")
-	}
-	if !isStrongPassword(pwd) {
-		return nil, repository.ErrValidation("password must include uppercase, lowercase, number, and symbol")
-	}
-	if isCommonPassword(pwd) {
-		return nil, repository.ErrValidation("password too common")
+	if err := validatePassword(ctx, u.policies, in.TenantID, pwd); err != nil {
+		return nil, err
 	}
 	return u.repo.Create(ctx, repository.CreateUserParams{
 		TenantID: in.TenantID,
@@ -102,14 +98,18 @@ func (u *users) Update(ctx context.Context, id uuid.UUID, in UserUpdateInput) (*
 	}
 	if in.Password != nil {
 		p := strings.TrimSpace(*in.Password)
-		if p != "" && len(p) < 8 {
-			return nil, repository.ErrValidation("password must be at least 8 characters")
-		}
-		if p != "" && !isStrongPassword(p) {
-			return nil, repository.ErrValidation("password must include uppercase, lowercase, number, and symbol")
-		}
-		if p != "" && isCommonPassword(p) {
-			return nil, repository.ErrValidation("password too common")
+		if p != "" {
+			tenantID := ""
+			if u.policies != nil {
+				cur, err := u.repo.FindByID(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+				tenantID = cur.TenantID
+			}
+			if err := validatePassword(ctx, u.policies, tenantID, p); err != nil {
+				return nil, err
+			}
 		}
 		params.Password = &p
 	}
@@ -119,39 +119,3 @@ func (u *users) Update(ctx context.Context, id uuid.UUID, in UserUpdateInput) (*
 func (u *users) Delete(ctx context.Context, id uuid.UUID) error {
 	return u.repo.SoftDelete(ctx, id)
 }
-
-func isStrongPassword(s string) bool {
-	hasUpper := false
-	hasLower := false
-	hasDigit := false
-	hasSymbol := false
-	for _, r := range s {
-		if unicode.IsUpper(r) {
-			hasUpper = true
-		} else if unicode.IsLower(r) {
-			hasLower = true
-		} else if unicode.IsDigit(r) {
-			hasDigit = true
-		} else {
-			hasSymbol = true
-		}
-	}
-	return hasUpper && hasLower && hasDigit && hasSymbol
-}
-
-func isCommonPassword(s string) bool {
-	l := strings.ToLower(s)
-	common := map[string]struct{}{
-		"password":  {},
-		"password1": {},
-		"passw0rd":  {},
-		"p@ssw0rd":  {},
-		"123456":    {},
-		"qwerty":    {},
-		"admin":     {},
-		"welcome":   {},
-		"letmein":   {},
-	}
-	_, ok := common[l]
-	return ok
-}
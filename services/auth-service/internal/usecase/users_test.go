@@ -34,13 +34,17 @@ func ensureMigrationsUC(t *testing.T, db *pgxpool.Pool) {
 	if err == nil {
 		_, _ = db.Exec(context.Background(), string(b1))
 	}
+	b9, err := os.ReadFile("../../migrations/009_password_policy.up.sql")
+	if err == nil {
+		_, _ = db.Exec(context.Background(), string(b9))
+	}
 }
 
 func TestUsersUsecase_RegisterGetListUpdateDelete(t *testing.T) {
 	db := testDBUC(t)
 	ensureMigrationsUC(t, db)
 	repo := repository.NewUsersRepo(db)
-	uc := NewUsers(repo)
+	uc := NewUsers(repo, nil)
 	tenant := "t-" + uuid.NewString()
 	// invalid password
 	if _, err := uc.Register(context.Background(), UserRegisterInput{
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS oidc_providers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    issuer TEXT NOT NULL,
+    client_id TEXT NOT NULL,
+    client_secret TEXT NOT NULL DEFAULT '',
+    scopes TEXT[] NOT NULL DEFAULT ARRAY['openid', 'email', 'profile'],
+    allowed_domains TEXT[] NOT NULL DEFAULT '{}',
+    groups_claim TEXT NOT NULL DEFAULT 'groups',
+    group_roles JSONB NOT NULL DEFAULT '{}',
+    default_role TEXT NOT NULL DEFAULT '',
+    jit_provisioning BOOLEAN NOT NULL DEFAULT FALSE,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    provider_id UUID NOT NULL REFERENCES oidc_providers(id) ON DELETE CASCADE,
+    subject TEXT NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email TEXT NOT NULL DEFAULT '',
+    last_login_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider_id, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS password_policies (
+    tenant_id TEXT PRIMARY KEY,
+    min_length INT NOT NULL DEFAULT 8,
+    require_upper BOOLEAN NOT NULL DEFAULT TRUE,
+    require_lower BOOLEAN NOT NULL DEFAULT TRUE,
+    require_digit BOOLEAN NOT NULL DEFAULT TRUE,
+    require_symbol BOOLEAN NOT NULL DEFAULT TRUE,
+    history_depth INT NOT NULL DEFAULT 5,
+    max_age_days INT NOT NULL DEFAULT 0,
+    rotate_before TIMESTAMPTZ,
+    lockout_threshold INT NOT NULL DEFAULT 5,
+    lockout_seconds INT NOT NULL DEFAULT 900,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS audit_chain_archives;
+DROP TABLE IF EXISTS audit_chain_heads;
+DROP INDEX IF EXISTS idx_audit_logs_tenant_seq;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS hash;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS prev_hash;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS seq;
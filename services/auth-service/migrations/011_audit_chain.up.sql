@@ -0,0 +1,24 @@
+-- Rows written before the chain existed keep a NULL seq and are not verified.
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS seq BIGINT;
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS prev_hash TEXT;
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS hash TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_logs_tenant_seq ON audit_logs(tenant_id, seq);
+
+-- Last link of each tenant's chain, locked while appending.
+CREATE TABLE IF NOT EXISTS audit_chain_heads (
+    tenant_id TEXT PRIMARY KEY,
+    seq BIGINT NOT NULL DEFAULT 0,
+    hash TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Last link removed by each retention cleanup; verification resumes from it.
+CREATE TABLE IF NOT EXISTS audit_chain_archives (
+    tenant_id TEXT NOT NULL,
+    seq BIGINT NOT NULL,
+    hash TEXT NOT NULL,
+    rows_deleted BIGINT NOT NULL DEFAULT 0,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, seq)
+);
@@ -5,15 +5,19 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jjaenal/sisfo-akademik-backend/services/file-service/internal/handler"
 	imiddleware "github.com/jjaenal/sisfo-akademik-backend/services/file-service/internal/middleware"
 	"github.com/jjaenal/sisfo-akademik-backend/services/file-service/internal/repository"
 	"github.com/jjaenal/sisfo-akademik-backend/services/file-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/database"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/logger"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
 	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -58,10 +62,12 @@ func main() {
 		log.Fatal("failed to init storage", zap.Error(err))
 	}
 
+	redis := redisutil.New(cfg.RedisAddr)
+
 	// Layers
 	repo := repository.NewPostgresFileRepository(db)
 	uc := usecase.NewFileUseCase(repo, storage)
-	h := handler.NewFileHandler(uc)
+	h := handler.NewFileHandler(uc, metering.New(metering.NewRedisStore(redis.Raw())))
 
 	// Router
 	if cfg.Env == "production" {
@@ -71,19 +77,21 @@ func main() {
 	r.Use(otelgin.Middleware("file-service"))
 	r.Use(gin.Recovery())
 
-	redis := redisutil.New(cfg.RedisAddr)
 	r.Use(imiddleware.SecurityHeaders())
-	r.Use(imiddleware.RateLimitByPolicy(redisutil.NewLimiterFromCounter(redis.Raw()), 100, 30, nil))
+	// Anonymous routes are limited per client address; protected routes run
+	// the limiter after Auth so each user has their own budget
+	limit := imiddleware.RateLimitByPolicy(redisutil.NewTokenBucketLimiter(redis.Raw()), 100, 30, nil, cfg.TrustedProxies)
 
 	// Adapt shared net/http middleware to gin
 	r.Use(toGinLogging(log))
 	r.Use(toGinCORS(cfg.CORSAllowedOrigins))
+	public := r.Group("/", limit)
 
 	// Serve static files
-	r.Static("/uploads", storagePath)
+	public.Static("/uploads", storagePath)
 
 	// Health Check
-	r.GET("/api/v1/health", func(c *gin.Context) {
+	public.GET("/api/v1/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"status": "up",
 			"service": "file-service",
@@ -93,12 +101,16 @@ func main() {
 	// Routes
 	// Protected routes need Auth middleware
 	protected := r.Group("/")
-	protected.Use(toGinAuthWith(cfg.JWTAccessSecret, cfg.JWTIssuer, cfg.JWTAudience))
-	
-	h.RegisterRoutes(protected)
+	keys := jwtutil.NewKeySet(cfg.JWTSigningAlg, cfg.JWTAccessSecret, cfg.JWKSURL)
+	protected.Use(imiddleware.Auth(keys, cfg.JWTIssuer, cfg.JWTAudience, redis.Raw()), limit)
+
+	authorizer := authz.NewClient(cfg.AuthServiceURL, redis.Raw(), authz.DefaultCacheTTL, 5*time.Second)
+	h.RegisterRoutes(protected, func(permission string) gin.HandlerFunc {
+		return imiddleware.Authorization(authorizer, permission)
+	})
 
 	// Prometheus metrics
-	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	public.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	if err := r.Run(fmt.Sprintf(":%d", cfg.HTTPPort)); err != nil {
 		log.Fatal("failed to start server", zap.Error(err))
@@ -121,17 +133,3 @@ func toGinCORS(allowed []string) gin.HandlerFunc {
 		h.ServeHTTP(c.Writer, c.Request)
 	}
 }
-
-func toGinAuthWith(secret, issuer, audience string) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Propagate claims from request context into gin context for handlers
-			if val := r.Context().Value(middleware.ClaimsKey); val != nil {
-				c.Set("claims", val)
-			}
-			c.Next()
-		})
-		h := middleware.AuthWith(secret, issuer, audience, next)
-		h.ServeHTTP(c.Writer, c.Request)
-	}
-}
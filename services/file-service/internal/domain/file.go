@@ -2,11 +2,13 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"io"
 	"mime/multipart"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
 )
 
 type File struct {
@@ -25,6 +27,10 @@ type File struct {
 	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
 }
 
+// ErrFileNotFound is returned when the file is missing or belongs to another
+// tenant; callers cannot tell the two apart.
+var ErrFileNotFound = errors.New("file not found")
+
 type FileRepository interface {
 	Create(ctx context.Context, file *File) error
 	GetByID(ctx context.Context, id uuid.UUID) (*File, error)
@@ -41,8 +47,15 @@ type StorageProvider interface {
 
 type FileUseCase interface {
 	Upload(ctx context.Context, file multipart.File, header *multipart.FileHeader, tenantID, uploadedBy uuid.UUID, bucket string) (*File, error)
-	Get(ctx context.Context, id uuid.UUID) (*File, error)
-	Download(ctx context.Context, id uuid.UUID) (*File, io.ReadCloser, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Get(ctx context.Context, tenantID, id uuid.UUID) (*File, error)
+	Download(ctx context.Context, tenantID, id uuid.UUID) (*File, io.ReadCloser, error)
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
 	List(ctx context.Context, tenantID uuid.UUID, page, limit int) ([]*File, int64, error)
 }
+
+
+// UsageMeter meters uploads against the tenant's plan.
+type UsageMeter interface {
+	Consume(ctx context.Context, tenant string, metric metering.Metric, n int64) (metering.Result, error)
+	Release(ctx context.Context, tenant string, r metering.Result) error
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,30 +10,40 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/file-service/internal/domain"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	httputil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
 	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
 )
 
 type FileHandler struct {
-	uc domain.FileUseCase
+	uc    domain.FileUseCase
+	meter domain.UsageMeter
 }
 
-func NewFileHandler(uc domain.FileUseCase) *FileHandler {
-	return &FileHandler{uc: uc}
+// NewFileHandler meters uploaded bytes with meter, which may be nil.
+func NewFileHandler(uc domain.FileUseCase, meter domain.UsageMeter) *FileHandler {
+	return &FileHandler{uc: uc, meter: meter}
 }
 
-func (h *FileHandler) RegisterRoutes(r gin.IRouter) {
+// RegisterRoutes expects r to already authenticate the caller; perm builds the
+// permission check placed in front of each route.
+func (h *FileHandler) RegisterRoutes(r gin.IRouter, perm func(permission string) gin.HandlerFunc) {
 	api := r.Group("/api/v1/files")
-	// Protected routes should be handled by middleware in main
-	api.POST("/upload", h.upload)
-	api.GET("/:id", h.get)
-	api.GET("/:id/download", h.download)
-	api.DELETE("/:id", h.delete)
-	api.GET("", h.list)
+	api.POST("/upload", perm(authz.FileWrite), h.upload)
+	api.GET("/:id", perm(authz.FileRead), h.get)
+	api.GET("/:id/download", perm(authz.FileRead), h.download)
+	api.DELETE("/:id", perm(authz.FileDelete), h.delete)
+	api.GET("", perm(authz.FileRead), h.list)
 }
 
 func (h *FileHandler) download(c *gin.Context) {
+	_, tenantID, ok := requestClaims(c)
+	if !ok {
+		return
+	}
+
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -40,7 +51,7 @@ func (h *FileHandler) download(c *gin.Context) {
 		return
 	}
 
-	fileMeta, fileContent, err := h.uc.Download(c.Request.Context(), id)
+	fileMeta, fileContent, err := h.uc.Download(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -59,14 +70,8 @@ func (h *FileHandler) download(c *gin.Context) {
 }
 
 func (h *FileHandler) upload(c *gin.Context) {
-	val := c.Request.Context().Value(middleware.ClaimsKey)
-	if val == nil {
-		httputil.Error(c.Writer, http.StatusUnauthorized, "4001", "Unauthorized", "missing claims")
-		return
-	}
-	claims, ok := val.(jwtutil.Claims)
+	claims, tenantID, ok := requestClaims(c)
 	if !ok {
-		httputil.Error(c.Writer, http.StatusUnauthorized, "4001", "Unauthorized", "invalid claims")
 		return
 	}
 
@@ -81,14 +86,22 @@ func (h *FileHandler) upload(c *gin.Context) {
 
 	bucket := c.DefaultPostForm("bucket", "uploads")
 
-	tenantID, err := uuid.Parse(claims.TenantID)
-	if err != nil {
-		httputil.Error(c.Writer, http.StatusUnauthorized, "4001", "Unauthorized", "invalid tenant id")
-		return
+	var usage metering.Result
+	if h.meter != nil {
+		// Metering fails open; only a known overrun refuses the upload
+		usage, err = h.meter.Consume(c.Request.Context(), claims.TenantID, metering.StorageBytes, header.Size)
+		if err == nil && usage.State == metering.Exceeded {
+			metering.Reject(c.Writer, usage)
+			return
+		}
+		metering.SetHeaders(c.Writer, usage)
 	}
 
 	res, err := h.uc.Upload(c.Request.Context(), file, header, tenantID, claims.UserID, bucket)
 	if err != nil {
+		if h.meter != nil {
+			_ = h.meter.Release(c.Request.Context(), claims.TenantID, usage)
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -97,6 +110,11 @@ func (h *FileHandler) upload(c *gin.Context) {
 }
 
 func (h *FileHandler) get(c *gin.Context) {
+	_, tenantID, ok := requestClaims(c)
+	if !ok {
+		return
+	}
+
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -104,7 +122,7 @@ func (h *FileHandler) get(c *gin.Context) {
 		return
 	}
 
-	res, err := h.uc.Get(c.Request.Context(), id)
+	res, err := h.uc.Get(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
@@ -118,6 +136,11 @@ func (h *FileHandler) get(c *gin.Context) {
 }
 
 func (h *FileHandler) delete(c *gin.Context) {
+	_, tenantID, ok := requestClaims(c)
+	if !ok {
+		return
+	}
+
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -125,7 +148,11 @@ func (h *FileHandler) delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.uc.Delete(c.Request.Context(), id); err != nil {
+	if err := h.uc.Delete(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, domain.ErrFileNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Not Found", "file not found")
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
 		return
 	}
@@ -134,26 +161,14 @@ func (h *FileHandler) delete(c *gin.Context) {
 }
 
 func (h *FileHandler) list(c *gin.Context) {
-	val := c.Request.Context().Value(middleware.ClaimsKey)
-	if val == nil {
-		httputil.Error(c.Writer, http.StatusUnauthorized, "4001", "Unauthorized", "missing claims")
-		return
-	}
-	claims, ok := val.(jwtutil.Claims)
+	_, tenantID, ok := requestClaims(c)
 	if !ok {
-		httputil.Error(c.Writer, http.StatusUnauthorized, "4001", "Unauthorized", "invalid claims")
 		return
 	}
 
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	tenantID, err := uuid.Parse(claims.TenantID)
-	if err != nil {
-		httputil.Error(c.Writer, http.StatusUnauthorized, "4001", "Unauthorized", "invalid tenant id")
-		return
-	}
-
 	res, _, err := h.uc.List(c.Request.Context(), tenantID, page, limit)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Internal Server Error", err.Error())
@@ -162,3 +177,19 @@ func (h *FileHandler) list(c *gin.Context) {
 
 	httputil.Success(c.Writer, res)
 }
+
+// requestClaims returns the caller's claims and tenant, answering 401 when the
+// request is not authenticated or carries no valid tenant.
+func requestClaims(c *gin.Context) (jwtutil.Claims, uuid.UUID, bool) {
+	claims, ok := c.Request.Context().Value(middleware.ClaimsKey).(jwtutil.Claims)
+	if !ok {
+		httputil.Error(c.Writer, http.StatusUnauthorized, "4001", "Unauthorized", "missing claims")
+		return jwtutil.Claims{}, uuid.Nil, false
+	}
+	tenantID, err := uuid.Parse(claims.TenantID)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusUnauthorized, "4001", "Unauthorized", "invalid tenant id")
+		return jwtutil.Claims{}, uuid.Nil, false
+	}
+	return claims, tenantID, true
+}
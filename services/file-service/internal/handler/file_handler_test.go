@@ -9,11 +9,14 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/file-service/internal/domain"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
 )
 
@@ -40,15 +43,15 @@ func (m *mockUC) Upload(ctx context.Context, file multipart.File, header *multip
 	m.uploadCalled = true
 	return m.fileResp, m.uploadErr
 }
-func (m *mockUC) Get(ctx context.Context, id uuid.UUID) (*domain.File, error) {
+func (m *mockUC) Get(ctx context.Context, tenantID, id uuid.UUID) (*domain.File, error) {
 	m.getCalled = true
 	return m.fileResp, m.getErr
 }
-func (m *mockUC) Download(ctx context.Context, id uuid.UUID) (*domain.File, io.ReadCloser, error) {
+func (m *mockUC) Download(ctx context.Context, tenantID, id uuid.UUID) (*domain.File, io.ReadCloser, error) {
 	m.downloadCalled = true
 	return m.fileResp, m.reader, m.downloadErr
 }
-func (m *mockUC) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *mockUC) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
 	m.deleteCalled = true
 	return m.deleteErr
 }
@@ -60,7 +63,7 @@ func (m *mockUC) List(ctx context.Context, tenantID uuid.UUID, page, limit int)
 func setupRouter(h *FileHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	h.RegisterRoutes(r)
+	h.RegisterRoutes(r, func(string) gin.HandlerFunc { return func(c *gin.Context) { c.Next() } })
 	return r
 }
 
@@ -84,7 +87,7 @@ func TestUpload_Success(t *testing.T) {
 			UploadedBy:   userID,
 		},
 	}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 
 	body := &bytes.Buffer{}
@@ -110,7 +113,7 @@ func TestUpload_Success(t *testing.T) {
 
 func TestUpload_MissingClaims(t *testing.T) {
 	mock := &mockUC{fileResp: &domain.File{ID: uuid.New()}}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -128,7 +131,7 @@ func TestUpload_MissingClaims(t *testing.T) {
 
 func TestUpload_InvalidTenantID(t *testing.T) {
 	mock := &mockUC{fileResp: &domain.File{ID: uuid.New()}}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -145,11 +148,67 @@ func TestUpload_InvalidTenantID(t *testing.T) {
 	}
 }
 
+type fakeMeter struct {
+	state    metering.State
+	consumed int64
+	released bool
+}
+
+func (m *fakeMeter) Consume(ctx context.Context, tenant string, metric metering.Metric, n int64) (metering.Result, error) {
+	if m.state == metering.Exceeded {
+		return metering.Result{Metric: metric, State: m.state, Reset: time.Hour}, nil
+	}
+	m.consumed += n
+	return metering.Result{Metric: metric, Amount: n, Used: m.consumed, State: m.state}, nil
+}
+
+func (m *fakeMeter) Release(ctx context.Context, tenant string, r metering.Result) error {
+	m.released = true
+	m.consumed -= r.Amount
+	return nil
+}
+
+func TestUpload_Quota(t *testing.T) {
+	upload := func(mock *mockUC, meter *fakeMeter) *httptest.ResponseRecorder {
+		r := setupRouter(NewFileHandler(mock, meter))
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("file", "test.txt")
+		_, _ = io.Copy(part, strings.NewReader("data"))
+		_ = writer.Close()
+		req := httptest.NewRequest("POST", "/api/v1/files/upload", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req = withClaims(req, jwtutil.Claims{TenantID: uuid.NewString(), UserID: uuid.New()})
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	meter := &fakeMeter{state: metering.Warning}
+	rr := upload(&mockUC{fileResp: &domain.File{ID: uuid.New()}}, meter)
+	if rr.Code != http.StatusOK || meter.consumed != 4 || rr.Header().Get(metering.WarningHeader) == "" {
+		t.Fatalf("code=%d consumed=%d", rr.Code, meter.consumed)
+	}
+
+	mock := &mockUC{}
+	rr = upload(mock, &fakeMeter{state: metering.Exceeded})
+	if rr.Code != http.StatusTooManyRequests || mock.uploadCalled {
+		t.Fatalf("over quota code=%d uploaded=%v", rr.Code, mock.uploadCalled)
+	}
+
+	// A failed upload does not count
+	meter = &fakeMeter{state: metering.OK}
+	rr = upload(&mockUC{uploadErr: io.EOF}, meter)
+	if rr.Code != http.StatusInternalServerError || !meter.released || meter.consumed != 0 {
+		t.Fatalf("code=%d released=%v consumed=%d", rr.Code, meter.released, meter.consumed)
+	}
+}
+
 func TestUpload_MissingFile(t *testing.T) {
 	tenantID := uuid.New()
 	userID := uuid.New()
 	mock := &mockUC{}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	req := httptest.NewRequest("POST", "/api/v1/files/upload", nil)
 	req = withClaims(req, jwtutil.Claims{TenantID: tenantID.String(), UserID: userID})
@@ -164,7 +223,7 @@ func TestUpload_InternalError(t *testing.T) {
 	tenantID := uuid.New()
 	userID := uuid.New()
 	mock := &mockUC{uploadErr: io.EOF}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -190,11 +249,12 @@ func TestGet_Success(t *testing.T) {
 			Size:         10,
 		},
 	}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 
 	id := uuid.New()
 	req := httptest.NewRequest("GET", "/api/v1/files/"+id.String(), nil)
+	req = withClaims(req, jwtutil.Claims{TenantID: uuid.NewString(), UserID: uuid.New()})
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 
@@ -208,9 +268,10 @@ func TestGet_Success(t *testing.T) {
 
 func TestGet_InvalidID(t *testing.T) {
 	mock := &mockUC{}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	req := httptest.NewRequest("GET", "/api/v1/files/invalid-id", nil)
+	req = withClaims(req, jwtutil.Claims{TenantID: uuid.NewString(), UserID: uuid.New()})
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 	if rr.Code != http.StatusBadRequest {
@@ -220,10 +281,11 @@ func TestGet_InvalidID(t *testing.T) {
 
 func TestGet_InternalError(t *testing.T) {
 	mock := &mockUC{getErr: io.EOF}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	id := uuid.New()
 	req := httptest.NewRequest("GET", "/api/v1/files/"+id.String(), nil)
+	req = withClaims(req, jwtutil.Claims{TenantID: uuid.NewString(), UserID: uuid.New()})
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 	if rr.Code != http.StatusInternalServerError {
@@ -240,7 +302,7 @@ func TestList_Success(t *testing.T) {
 		},
 		totalCount: 2,
 	}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 
 	req := httptest.NewRequest("GET", "/api/v1/files?page=1&limit=10", nil)
@@ -258,7 +320,7 @@ func TestList_Success(t *testing.T) {
 
 func TestList_MissingClaims(t *testing.T) {
 	mock := &mockUC{}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	req := httptest.NewRequest("GET", "/api/v1/files?page=1&limit=10", nil)
 	rr := httptest.NewRecorder()
@@ -270,7 +332,7 @@ func TestList_MissingClaims(t *testing.T) {
 
 func TestList_InvalidTenantID(t *testing.T) {
 	mock := &mockUC{}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	req := httptest.NewRequest("GET", "/api/v1/files?page=1&limit=10", nil)
 	req = withClaims(req, jwtutil.Claims{TenantID: "bad-uuid", UserID: uuid.New()})
@@ -284,7 +346,7 @@ func TestList_InvalidTenantID(t *testing.T) {
 func TestList_InternalError(t *testing.T) {
 	tenantID := uuid.New()
 	mock := &mockUC{listErr: io.EOF}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	req := httptest.NewRequest("GET", "/api/v1/files?page=1&limit=10", nil)
 	req = withClaims(req, jwtutil.Claims{TenantID: tenantID.String(), UserID: uuid.New()})
@@ -297,11 +359,12 @@ func TestList_InternalError(t *testing.T) {
 
 func TestDelete_Success(t *testing.T) {
 	mock := &mockUC{}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 
 	id := uuid.New()
 	req := httptest.NewRequest("DELETE", "/api/v1/files/"+id.String(), nil)
+	req = withClaims(req, jwtutil.Claims{TenantID: uuid.NewString(), UserID: uuid.New()})
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 
@@ -313,11 +376,37 @@ func TestDelete_Success(t *testing.T) {
 	}
 }
 
+func TestDelete_OtherTenant(t *testing.T) {
+	mock := &mockUC{deleteErr: domain.ErrFileNotFound}
+	h := NewFileHandler(mock, nil)
+	r := setupRouter(h)
+	req := httptest.NewRequest("DELETE", "/api/v1/files/"+uuid.New().String(), nil)
+	req = withClaims(req, jwtutil.Claims{TenantID: uuid.NewString(), UserID: uuid.New()})
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("code=%d want 404", rr.Code)
+	}
+}
+
+func TestGet_MissingClaims(t *testing.T) {
+	mock := &mockUC{}
+	h := NewFileHandler(mock, nil)
+	r := setupRouter(h)
+	req := httptest.NewRequest("GET", "/api/v1/files/"+uuid.New().String(), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized || mock.getCalled {
+		t.Fatalf("code=%d want 401 without calling Get", rr.Code)
+	}
+}
+
 func TestDelete_InvalidID(t *testing.T) {
 	mock := &mockUC{}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	req := httptest.NewRequest("DELETE", "/api/v1/files/invalid-id", nil)
+	req = withClaims(req, jwtutil.Claims{TenantID: uuid.NewString(), UserID: uuid.New()})
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 	if rr.Code != http.StatusBadRequest {
@@ -327,10 +416,11 @@ func TestDelete_InvalidID(t *testing.T) {
 
 func TestDelete_InternalError(t *testing.T) {
 	mock := &mockUC{deleteErr: io.EOF}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	id := uuid.New()
 	req := httptest.NewRequest("DELETE", "/api/v1/files/"+id.String(), nil)
+	req = withClaims(req, jwtutil.Claims{TenantID: uuid.NewString(), UserID: uuid.New()})
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 	if rr.Code != http.StatusInternalServerError {
@@ -349,11 +439,12 @@ func TestDownload_Success(t *testing.T) {
 		},
 		reader: io.NopCloser(content),
 	}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 
 	id := uuid.New()
 	req := httptest.NewRequest("GET", "/api/v1/files/"+id.String()+"/download", nil)
+	req = withClaims(req, jwtutil.Claims{TenantID: uuid.NewString(), UserID: uuid.New()})
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 
@@ -373,9 +464,10 @@ func TestDownload_NotFound(t *testing.T) {
 		fileResp: nil,
 		reader:   nil,
 	}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	req := httptest.NewRequest("GET", "/api/v1/files/"+uuid.New().String()+"/download", nil)
+	req = withClaims(req, jwtutil.Claims{TenantID: uuid.NewString(), UserID: uuid.New()})
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 	if rr.Code != http.StatusNotFound {
@@ -385,9 +477,10 @@ func TestDownload_NotFound(t *testing.T) {
 
 func TestDownload_InvalidID(t *testing.T) {
 	mock := &mockUC{}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	req := httptest.NewRequest("GET", "/api/v1/files/invalid-id/download", nil)
+	req = withClaims(req, jwtutil.Claims{TenantID: uuid.NewString(), UserID: uuid.New()})
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 	if rr.Code != http.StatusBadRequest {
@@ -397,13 +490,42 @@ func TestDownload_InvalidID(t *testing.T) {
 
 func TestDownload_InternalError(t *testing.T) {
 	mock := &mockUC{downloadErr: io.EOF, fileResp: &domain.File{ID: uuid.New(), OriginalName: "x", MimeType: "text/plain", Size: 1}}
-	h := NewFileHandler(mock)
+	h := NewFileHandler(mock, nil)
 	r := setupRouter(h)
 	id := uuid.New()
 	req := httptest.NewRequest("GET", "/api/v1/files/"+id.String()+"/download", nil)
+	req = withClaims(req, jwtutil.Claims{TenantID: uuid.NewString(), UserID: uuid.New()})
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 	if rr.Code != http.StatusInternalServerError {
 		t.Fatalf("code=%d want 500", rr.Code)
 	}
 }
+
+func TestRegisterRoutes_Permissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	var got []string
+	NewFileHandler(&mockUC{}, nil).RegisterRoutes(r, func(permission string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			got = append(got, permission)
+			c.AbortWithStatus(http.StatusForbidden)
+		}
+	})
+	cases := []struct {
+		method, path, want string
+	}{
+		{"POST", "/api/v1/files/upload", authz.FileWrite},
+		{"GET", "/api/v1/files/" + uuid.NewString(), authz.FileRead},
+		{"GET", "/api/v1/files", authz.FileRead},
+		{"DELETE", "/api/v1/files/" + uuid.NewString(), authz.FileDelete},
+	}
+	for _, tc := range cases {
+		got = nil
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(tc.method, tc.path, nil))
+		if rr.Code != http.StatusForbidden || len(got) != 1 || got[0] != tc.want {
+			t.Fatalf("%s %s: code=%d perms=%v want %s", tc.method, tc.path, rr.Code, got, tc.want)
+		}
+	}
+}
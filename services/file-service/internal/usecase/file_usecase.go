@@ -68,12 +68,12 @@ func (u *FileUseCase) Upload(ctx context.Context, file multipart.File, header *m
 	return f, nil
 }
 
-func (u *FileUseCase) Get(ctx context.Context, id uuid.UUID) (*domain.File, error) {
+func (u *FileUseCase) Get(ctx context.Context, tenantID, id uuid.UUID) (*domain.File, error) {
 	f, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	if f == nil {
+	if f == nil || f.TenantID != tenantID {
 		return nil, nil
 	}
 
@@ -86,12 +86,12 @@ func (u *FileUseCase) Get(ctx context.Context, id uuid.UUID) (*domain.File, erro
 	return f, nil
 }
 
-func (u *FileUseCase) Download(ctx context.Context, id uuid.UUID) (*domain.File, io.ReadCloser, error) {
+func (u *FileUseCase) Download(ctx context.Context, tenantID, id uuid.UUID) (*domain.File, io.ReadCloser, error) {
 	f, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, nil, err
 	}
-	if f == nil {
+	if f == nil || f.TenantID != tenantID {
 		return nil, nil, nil
 	}
 
@@ -103,13 +103,13 @@ func (u *FileUseCase) Download(ctx context.Context, id uuid.UUID) (*domain.File,
 	return f, reader, nil
 }
 
-func (u *FileUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *FileUseCase) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
 	f, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if f == nil {
-		return nil
+	if f == nil || f.TenantID != tenantID {
+		return domain.ErrFileNotFound
 	}
 
 	// Delete from storage
@@ -74,11 +74,12 @@ func (m *MockStorageProvider) Get(ctx context.Context, path string) (io.ReadClos
 
 func TestFileUseCase_Download(t *testing.T) {
 	ctx := context.Background()
+	tenantID := uuid.New()
 	id := uuid.New()
 	path := "test/file.txt"
 
 	t.Run("success", func(t *testing.T) {
-		file := &domain.File{ID: id, Path: path}
+		file := &domain.File{ID: id, TenantID: tenantID, Path: path}
 		content := io.NopCloser(strings.NewReader("content"))
 		
 		// Create new mock instances for this run to avoid call count issues if sharing
@@ -89,7 +90,7 @@ func TestFileUseCase_Download(t *testing.T) {
 		repo.On("GetByID", ctx, id).Return(file, nil)
 		storage.On("Get", ctx, path).Return(content, nil)
 
-		f, r, err := uc.Download(ctx, id)
+		f, r, err := uc.Download(ctx, tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, file, f)
 		assert.NotNil(t, r)
@@ -101,7 +102,7 @@ func TestFileUseCase_Download(t *testing.T) {
 		uc := NewFileUseCase(repo, storage)
 
 		repo.On("GetByID", ctx, id).Return(nil, nil)
-		f, r, err := uc.Download(ctx, id)
+		f, r, err := uc.Download(ctx, tenantID, id)
 		assert.NoError(t, err)
 		assert.Nil(t, f)
 		assert.Nil(t, r)
@@ -110,6 +111,7 @@ func TestFileUseCase_Download(t *testing.T) {
 
 func TestFileUseCase_Delete(t *testing.T) {
 	ctx := context.Background()
+	tenantID := uuid.New()
 	id := uuid.New()
 	path := "test/file.txt"
 
@@ -118,12 +120,12 @@ func TestFileUseCase_Delete(t *testing.T) {
 		storage := new(MockStorageProvider)
 		uc := NewFileUseCase(repo, storage)
 
-		file := &domain.File{ID: id, Path: path}
+		file := &domain.File{ID: id, TenantID: tenantID, Path: path}
 		repo.On("GetByID", ctx, id).Return(file, nil)
 		storage.On("Delete", ctx, path).Return(nil)
 		repo.On("Delete", ctx, id).Return(nil)
 
-		err := uc.Delete(ctx, id)
+		err := uc.Delete(ctx, tenantID, id)
 		assert.NoError(t, err)
 	})
 
@@ -134,8 +136,21 @@ func TestFileUseCase_Delete(t *testing.T) {
 
 		repo.On("GetByID", ctx, id).Return(nil, nil)
 
-		err := uc.Delete(ctx, id)
-		assert.NoError(t, err)
+		err := uc.Delete(ctx, tenantID, id)
+		assert.ErrorIs(t, err, domain.ErrFileNotFound)
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		repo := new(MockFileRepository)
+		storage := new(MockStorageProvider)
+		uc := NewFileUseCase(repo, storage)
+
+		file := &domain.File{ID: id, TenantID: uuid.New(), Path: path}
+		repo.On("GetByID", ctx, id).Return(file, nil)
+
+		err := uc.Delete(ctx, tenantID, id)
+		assert.ErrorIs(t, err, domain.ErrFileNotFound)
+		storage.AssertNotCalled(t, "Delete", ctx, path)
 	})
 
 	t.Run("storage error", func(t *testing.T) {
@@ -143,11 +158,11 @@ func TestFileUseCase_Delete(t *testing.T) {
 		storage := new(MockStorageProvider)
 		uc := NewFileUseCase(repo, storage)
 
-		file := &domain.File{ID: id, Path: path}
+		file := &domain.File{ID: id, TenantID: tenantID, Path: path}
 		repo.On("GetByID", ctx, id).Return(file, nil)
 		storage.On("Delete", ctx, path).Return(assert.AnError)
 
-		err := uc.Delete(ctx, id)
+		err := uc.Delete(ctx, tenantID, id)
 		assert.Error(t, err)
 		assert.Equal(t, assert.AnError, err)
 	})
@@ -211,6 +226,7 @@ func TestFileUseCase_List(t *testing.T) {
 
 func TestFileUseCase_Get(t *testing.T) {
 	ctx := context.Background()
+	tenantID := uuid.New()
 	id := uuid.New()
 	path := "bucket/tenant/2025/12/file.ext"
 
@@ -219,11 +235,11 @@ func TestFileUseCase_Get(t *testing.T) {
 		storage := new(MockStorageProvider)
 		uc := NewFileUseCase(repo, storage)
 
-		file := &domain.File{ID: id, Path: path}
+		file := &domain.File{ID: id, TenantID: tenantID, Path: path}
 		repo.On("GetByID", ctx, id).Return(file, nil)
 		storage.On("GetURL", ctx, path).Return("http://example.com/file.ext", nil)
 
-		res, err := uc.Get(ctx, id)
+		res, err := uc.Get(ctx, tenantID, id)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 		assert.Equal(t, "http://example.com/file.ext", res.URL)
@@ -236,7 +252,20 @@ func TestFileUseCase_Get(t *testing.T) {
 
 		repo.On("GetByID", ctx, id).Return(nil, nil)
 
-		res, err := uc.Get(ctx, id)
+		res, err := uc.Get(ctx, tenantID, id)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		repo := new(MockFileRepository)
+		storage := new(MockStorageProvider)
+		uc := NewFileUseCase(repo, storage)
+
+		file := &domain.File{ID: id, TenantID: uuid.New(), Path: path}
+		repo.On("GetByID", ctx, id).Return(file, nil)
+
+		res, err := uc.Get(ctx, tenantID, id)
 		assert.NoError(t, err)
 		assert.Nil(t, res)
 	})
@@ -246,11 +275,11 @@ func TestFileUseCase_Get(t *testing.T) {
 		storage := new(MockStorageProvider)
 		uc := NewFileUseCase(repo, storage)
 
-		file := &domain.File{ID: id, Path: path}
+		file := &domain.File{ID: id, TenantID: tenantID, Path: path}
 		repo.On("GetByID", ctx, id).Return(file, nil)
 		storage.On("GetURL", ctx, path).Return("", assert.AnError)
 
-		res, err := uc.Get(ctx, id)
+		res, err := uc.Get(ctx, tenantID, id)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -13,9 +13,16 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/middleware"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/repository/postgres"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/audit"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/authz"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/config"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/database"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/guardian"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/identity"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/logger"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/rabbit"
 	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/tracer"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -76,11 +83,20 @@ func main() {
 	studentRepo := postgres.NewStudentRepository(dbPool)
 	reportRepo := postgres.NewReportRepository(dbPool)
 
+	// Events are stored with the change they describe and relayed to RabbitMQ
+	rb := rabbit.New(cfg.RabbitURL)
+	events := outbox.New(dbPool)
+	if rb != nil {
+		go outbox.NewRelay(dbPool, rb).Run(context.Background())
+	}
+	// Invoice changes go to the platform audit trail
+	auditor := audit.NewPublisher(events)
+
 	// Init UseCases
 	timeout := 5 * time.Second
 	billingUC := usecase.NewBillingConfigUseCase(billingRepo, timeout)
-	invoiceUC := usecase.NewInvoiceUseCase(invoiceRepo, billingRepo, studentRepo, timeout)
-	paymentUC := usecase.NewPaymentUseCase(paymentRepo, invoiceRepo, timeout)
+	invoiceUC := usecase.NewInvoiceUseCase(invoiceRepo, billingRepo, studentRepo, events, auditor, timeout)
+	paymentUC := usecase.NewPaymentUseCase(paymentRepo, invoiceRepo, events, auditor, timeout)
 	reportUC := usecase.NewReportUseCase(reportRepo, invoiceRepo, paymentRepo, timeout)
 
 	// Start Scheduler
@@ -127,10 +143,17 @@ func main() {
 
 	redis := redisutil.New(cfg.RedisAddr)
 	r.Use(middleware.SecurityHeaders())
-	r.Use(middleware.RateLimitByPolicy(redisutil.NewLimiterFromCounter(redis.Raw()), 100, 30, nil))
+	// Anonymous routes are limited per client address; the authenticated
+	// group runs the limiter after authn so each user has their own budget
+	limit := middleware.RateLimitByPolicy(redisutil.NewTokenBucketLimiter(redis.Raw()), 100, 30, nil, cfg.TrustedProxies)
+	public := r.Group("/", limit)
 
+	authorizer := authz.NewClient(cfg.AuthServiceURL, redis.Raw(), authz.DefaultCacheTTL, 5*time.Second)
+	perm := func(permission string) gin.HandlerFunc { return middleware.Authorization(authorizer, permission) }
+	guardians := guardian.NewClient(cfg.AcademicServiceURL, redis.Raw(), guardian.DefaultCacheTTL, 5*time.Second)
 
-	r.GET("/api/v1/health", func(c *gin.Context) {
+
+	public.GET("/api/v1/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"success": true,
 			"data": gin.H{
@@ -147,34 +170,48 @@ func main() {
 
 	// Routes
 	v1 := r.Group("/api/v1")
+	keys := jwtutil.NewKeySet(cfg.JWTSigningAlg, cfg.JWTAccessSecret, cfg.JWKSURL)
+	authn := middleware.Auth(keys, cfg.JWTIssuer, cfg.JWTAudience, redis.Raw())
+	if cfg.TrustGateway {
+		// Behind the gateway the signed identity headers replace the bearer token
+		authn = identity.Gin([]byte(cfg.GatewaySecret), redis.Raw())
+	}
+	v1.Use(authn, limit)
 	finance := v1.Group("/finance")
 	{
 		// Billing Configs
-		finance.POST("/billing-configs", billingHandler.Create)
-		finance.GET("/billing-configs", billingHandler.List)
-		finance.GET("/billing-configs/:id", billingHandler.GetByID)
-		finance.PUT("/billing-configs/:id", billingHandler.Update)
-		finance.DELETE("/billing-configs/:id", billingHandler.Delete)
+		finance.POST("/billing-configs", perm(authz.BillingConfigWrite), billingHandler.Create)
+		finance.GET("/billing-configs", perm(authz.BillingConfigRead), billingHandler.List)
+		finance.GET("/billing-configs/:id", perm(authz.BillingConfigRead), billingHandler.GetByID)
+		finance.PUT("/billing-configs/:id", perm(authz.BillingConfigWrite), billingHandler.Update)
+		finance.DELETE("/billing-configs/:id", perm(authz.BillingConfigDelete), billingHandler.Delete)
 
 		// Invoices
-		finance.POST("/invoices/generate", invoiceHandler.Generate)
-		finance.GET("/invoices", invoiceHandler.List)
-		finance.GET("/invoices/:id", invoiceHandler.GetByID)
+		finance.POST("/invoices/generate", perm(authz.InvoiceGenerate), invoiceHandler.Generate)
+		finance.GET("/invoices", perm(authz.InvoiceRead), invoiceHandler.List)
+		finance.GET("/invoices/:id", perm(authz.InvoiceRead), invoiceHandler.GetByID)
 
 		// Payments
-		finance.POST("/payments", paymentHandler.Record)
-		finance.GET("/payments/:id", paymentHandler.GetByID)
-		finance.GET("/invoices/:id/payments", paymentHandler.ListByInvoice)
+		finance.POST("/payments", perm(authz.PaymentWrite), paymentHandler.Record)
+		finance.GET("/payments/:id", perm(authz.PaymentRead), paymentHandler.GetByID)
+		finance.GET("/invoices/:id/payments", perm(authz.PaymentRead), paymentHandler.ListByInvoice)
 
 		// Reports
-		finance.GET("/reports/revenue/daily", reportHandler.GetDailyRevenue)
-		finance.GET("/reports/revenue/monthly", reportHandler.GetMonthlyRevenue)
-		finance.GET("/reports/outstanding", reportHandler.GetOutstandingInvoices)
-		finance.GET("/reports/student/:student_id/history", reportHandler.GetStudentHistory)
+		finance.GET("/reports/revenue/daily", perm(authz.FinanceReportRead), reportHandler.GetDailyRevenue)
+		finance.GET("/reports/revenue/monthly", perm(authz.FinanceReportRead), reportHandler.GetMonthlyRevenue)
+		finance.GET("/reports/outstanding", perm(authz.FinanceReportRead), reportHandler.GetOutstandingInvoices)
+		finance.GET("/reports/student/:student_id/history", perm(authz.FinanceReportRead), reportHandler.GetStudentHistory)
+	}
+
+	// Guardian portal, scoped to the caller's own children. The gateway routes
+	// /api/v1/guardian/finance/ here
+	guardianPortal := v1.Group("/guardian/finance/students", perm(authz.GuardianChildRead))
+	{
+		guardianPortal.GET("/:student_id/invoices", middleware.GuardianScope(guardians, "student_id"), invoiceHandler.ListForStudent)
 	}
 
 	// Prometheus metrics
-	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	public.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	port := os.Getenv("APP_HTTP_PORT")
 	if port == "" {
@@ -18,6 +18,9 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/handler"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/repository/postgres"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/identity"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -59,7 +62,18 @@ func ensureMigrations(t *testing.T, db *pgxpool.Pool) {
 	}
 }
 
-func setupServer(t *testing.T) (*gin.Engine, *pgxpool.Pool) {
+// gatewaySecret signs the identity headers the gateway would send.
+var gatewaySecret = []byte("test-gateway-secret")
+
+// asGateway signs each request as the gateway does for a user of tenantID.
+func asGateway(tenantID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity.Sign(c.Request, gatewaySecret, jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID}, time.Now())
+		c.Next()
+	}
+}
+
+func setupServer(t *testing.T, tenantID string) (*gin.Engine, *pgxpool.Pool) {
 	gin.SetMode(gin.TestMode)
 	db := testDB(t)
 	ensureMigrations(t, db)
@@ -73,9 +87,10 @@ func setupServer(t *testing.T) (*gin.Engine, *pgxpool.Pool) {
 
 	// Init UseCases
 	timeout := 5 * time.Second
+	events := outbox.New(db)
 	billingUC := usecase.NewBillingConfigUseCase(billingRepo, timeout)
-	invoiceUC := usecase.NewInvoiceUseCase(invoiceRepo, billingRepo, studentRepo, timeout)
-	paymentUC := usecase.NewPaymentUseCase(paymentRepo, invoiceRepo, timeout)
+	invoiceUC := usecase.NewInvoiceUseCase(invoiceRepo, billingRepo, studentRepo, events, nil, timeout)
+	paymentUC := usecase.NewPaymentUseCase(paymentRepo, invoiceRepo, events, nil, timeout)
 	reportUC := usecase.NewReportUseCase(reportRepo, invoiceRepo, paymentRepo, timeout)
 
 	// Init Handlers
@@ -85,9 +100,10 @@ func setupServer(t *testing.T) (*gin.Engine, *pgxpool.Pool) {
 	reportHandler := handler.NewReportHandler(reportUC)
 
 	r := gin.New()
-	r.Use(gin.Logger(), gin.Recovery())
+	r.Use(gin.Logger(), gin.Recovery(), asGateway(tenantID))
 
 	v1 := r.Group("/api/v1")
+	v1.Use(identity.Gin(gatewaySecret, nil))
 	finance := v1.Group("/finance")
 	{
 		// Billing Configs
@@ -115,10 +131,10 @@ func setupServer(t *testing.T) (*gin.Engine, *pgxpool.Pool) {
 }
 
 func TestIntegration_Finance(t *testing.T) {
-	r, db := setupServer(t)
+	tenantID := uuid.New()
+	r, db := setupServer(t, tenantID.String())
 	defer db.Close()
 
-	tenantID := uuid.New()
 	studentID := uuid.New()
 	
 	// Setup: Create a student
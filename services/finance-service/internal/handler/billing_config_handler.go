@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -30,8 +31,11 @@ func NewBillingConfigHandler(useCase usecase.BillingConfigUseCase) *BillingConfi
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /finance/billing-configs [post]
 func (h *BillingConfigHandler) Create(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID  uuid.UUID               `json:"tenant_id" binding:"required"`
 		Name      string                  `json:"name" binding:"required"`
 		Amount    float64                 `json:"amount" binding:"required,gt=0"`
 		Frequency entity.BillingFrequency `json:"frequency" binding:"required,oneof=MONTHLY ONCE YEARLY"`
@@ -43,7 +47,7 @@ func (h *BillingConfigHandler) Create(c *gin.Context) {
 	}
 
 	config := &entity.BillingConfig{
-		TenantID:  req.TenantID,
+		TenantID:  tenantID,
 		Name:      req.Name,
 		Amount:    req.Amount,
 		Frequency: req.Frequency,
@@ -71,6 +75,10 @@ func (h *BillingConfigHandler) Create(c *gin.Context) {
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /finance/billing-configs/{id} [put]
 func (h *BillingConfigHandler) Update(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID format", err.Error())
@@ -91,6 +99,7 @@ func (h *BillingConfigHandler) Update(c *gin.Context) {
 
 	config := &entity.BillingConfig{
 		ID:        id,
+		TenantID:  tenantID,
 		Name:      req.Name,
 		Amount:    req.Amount,
 		Frequency: req.Frequency,
@@ -98,6 +107,10 @@ func (h *BillingConfigHandler) Update(c *gin.Context) {
 	}
 
 	if err := h.useCase.Update(c.Request.Context(), config); err != nil {
+		if errors.Is(err, usecase.ErrBillingConfigNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Billing config not found", err.Error())
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to update billing config", err.Error())
 		return
 	}
@@ -117,13 +130,17 @@ func (h *BillingConfigHandler) Update(c *gin.Context) {
 // @Failure      404  {object}  map[string]interface{}
 // @Router       /finance/billing-configs/{id} [get]
 func (h *BillingConfigHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID format", err.Error())
 		return
 	}
 
-	config, err := h.useCase.GetByID(c.Request.Context(), id)
+	config, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Billing config not found", err.Error())
 		return
@@ -138,15 +155,13 @@ func (h *BillingConfigHandler) GetByID(c *gin.Context) {
 // @Tags         billing-configs
 // @Accept       json
 // @Produce      json
-// @Param        tenant_id  query     string  true  "Tenant ID"
 // @Success      200  {object}  []entity.BillingConfig
 // @Failure      400  {object}  map[string]interface{}
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /finance/billing-configs [get]
 func (h *BillingConfigHandler) List(c *gin.Context) {
-	tenantID, err := uuid.Parse(c.Query("tenant_id"))
-	if err != nil {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid tenant_id format", err.Error())
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -171,13 +186,21 @@ func (h *BillingConfigHandler) List(c *gin.Context) {
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /finance/billing-configs/{id} [delete]
 func (h *BillingConfigHandler) Delete(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID format", err.Error())
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+	if err := h.useCase.Delete(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, usecase.ErrBillingConfigNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Billing config not found", err.Error())
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to delete billing config", err.Error())
 		return
 	}
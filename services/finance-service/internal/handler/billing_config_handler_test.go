@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/handler"
+	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/usecase/mocks"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -30,7 +31,6 @@ func TestBillingConfigHandler_Create(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": tenantID.String(),
 			"name":      "SPP Monthly",
 			"amount":    100000,
 			"frequency": "MONTHLY",
@@ -47,6 +47,7 @@ func TestBillingConfigHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/billing-configs", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -56,13 +57,12 @@ func TestBillingConfigHandler_Create(t *testing.T) {
 	})
 
 	t.Run("bad request", func(t *testing.T) {
-		reqBody := map[string]interface{}{
-			"tenant_id": "invalid",
-		}
+		reqBody := map[string]interface{}{}
 		body, _ := json.Marshal(reqBody)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/billing-configs", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -73,7 +73,6 @@ func TestBillingConfigHandler_Create(t *testing.T) {
 
 	t.Run("usecase error", func(t *testing.T) {
 		reqBody := map[string]interface{}{
-			"tenant_id": tenantID.String(),
 			"name":      "SPP Monthly",
 			"amount":    100000,
 			"frequency": "MONTHLY",
@@ -84,6 +83,7 @@ func TestBillingConfigHandler_Create(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/billing-configs", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -103,6 +103,7 @@ func TestBillingConfigHandler_Update(t *testing.T) {
 	h := handler.NewBillingConfigHandler(mockUseCase)
 
 	id := uuid.New()
+	tenantID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
 		reqBody := map[string]interface{}{
@@ -115,12 +116,14 @@ func TestBillingConfigHandler_Update(t *testing.T) {
 
 		mockUseCase.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, config *entity.BillingConfig) error {
 			assert.Equal(t, id, config.ID)
+			assert.Equal(t, tenantID, config.TenantID)
 			assert.Equal(t, "SPP Updated", config.Name)
 			return nil
 		})
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPut, "/billing-configs/"+id.String(), bytes.NewBuffer(body))
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -132,6 +135,7 @@ func TestBillingConfigHandler_Update(t *testing.T) {
 	t.Run("bad request - invalid id", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPut, "/billing-configs/invalid", nil)
 		c.Params = gin.Params{{Key: "id", Value: "invalid"}}
 
@@ -151,13 +155,15 @@ func TestBillingConfigHandler_GetByID(t *testing.T) {
 	h := handler.NewBillingConfigHandler(mockUseCase)
 
 	id := uuid.New()
+	tenantID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		expected := &entity.BillingConfig{ID: id}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(expected, nil)
+		expected := &entity.BillingConfig{ID: id, TenantID: tenantID}
+		mockUseCase.EXPECT().GetByID(gomock.Any(), tenantID, id).Return(expected, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodGet, "/billing-configs/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -167,10 +173,11 @@ func TestBillingConfigHandler_GetByID(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(nil, errors.New("not found"))
+		mockUseCase.EXPECT().GetByID(gomock.Any(), tenantID, id).Return(nil, errors.New("not found"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodGet, "/billing-configs/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -197,21 +204,22 @@ func TestBillingConfigHandler_List(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/billing-configs?tenant_id="+tenantID.String(), nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/billing-configs", nil)
 
 		h.List(c)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
-	t.Run("bad request - invalid tenant_id", func(t *testing.T) {
+	t.Run("unauthorized - tenant in query is ignored", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/billing-configs?tenant_id=invalid", nil)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/billing-configs?tenant_id="+tenantID.String(), nil)
 
 		h.List(c)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
 
@@ -225,12 +233,14 @@ func TestBillingConfigHandler_Delete(t *testing.T) {
 	h := handler.NewBillingConfigHandler(mockUseCase)
 
 	id := uuid.New()
+	tenantID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		mockUseCase.EXPECT().Delete(gomock.Any(), id).Return(nil)
+		mockUseCase.EXPECT().Delete(gomock.Any(), tenantID, id).Return(nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodDelete, "/billing-configs/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -239,9 +249,25 @@ func TestBillingConfigHandler_Delete(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	t.Run("not found - other tenant", func(t *testing.T) {
+		otherTenant := uuid.New()
+		mockUseCase.EXPECT().Delete(gomock.Any(), otherTenant, id).Return(usecase.ErrBillingConfigNotFound)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		withTenant(c, otherTenant.String())
+		c.Request, _ = http.NewRequest(http.MethodDelete, "/billing-configs/"+id.String(), nil)
+		c.Params = gin.Params{{Key: "id", Value: id.String()}}
+
+		h.Delete(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
 	t.Run("bad request - invalid id", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodDelete, "/billing-configs/invalid", nil)
 		c.Params = gin.Params{{Key: "id", Value: "invalid"}}
 
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -30,8 +31,11 @@ func NewInvoiceHandler(useCase usecase.InvoiceUseCase) *InvoiceHandler {
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /finance/invoices/generate [post]
 func (h *InvoiceHandler) Generate(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID        uuid.UUID `json:"tenant_id" binding:"required"`
 		StudentID       uuid.UUID `json:"student_id" binding:"required"`
 		BillingConfigID uuid.UUID `json:"billing_config_id" binding:"required"`
 	}
@@ -41,8 +45,12 @@ func (h *InvoiceHandler) Generate(c *gin.Context) {
 		return
 	}
 
-	invoice, err := h.useCase.Generate(c.Request.Context(), req.TenantID, req.StudentID, req.BillingConfigID)
+	invoice, err := h.useCase.Generate(c.Request.Context(), tenantID, req.StudentID, req.BillingConfigID)
 	if err != nil {
+		if errors.Is(err, usecase.ErrBillingConfigNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Billing config not found", err.Error())
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to generate invoice", err.Error())
 		return
 	}
@@ -62,13 +70,17 @@ func (h *InvoiceHandler) Generate(c *gin.Context) {
 // @Failure      404  {object}  map[string]interface{}
 // @Router       /finance/invoices/{id} [get]
 func (h *InvoiceHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID format", err.Error())
 		return
 	}
 
-	invoice, err := h.useCase.GetByID(c.Request.Context(), id)
+	invoice, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Invoice not found", err.Error())
 		return
@@ -83,7 +95,6 @@ func (h *InvoiceHandler) GetByID(c *gin.Context) {
 // @Tags         invoices
 // @Accept       json
 // @Produce      json
-// @Param        tenant_id  query     string  true  "Tenant ID"
 // @Param        student_id query     string  false "Student ID"
 // @Param        status     query     string  false "Invoice Status"
 // @Success      200  {object}  []entity.Invoice
@@ -91,9 +102,8 @@ func (h *InvoiceHandler) GetByID(c *gin.Context) {
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /finance/invoices [get]
 func (h *InvoiceHandler) List(c *gin.Context) {
-	tenantID, err := uuid.Parse(c.Query("tenant_id"))
-	if err != nil {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid tenant_id format", err.Error())
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -117,3 +127,28 @@ func (h *InvoiceHandler) List(c *gin.Context) {
 
 	httputil.Success(c.Writer, invoices)
 }
+
+// ListForStudent lists a student's invoices within the caller's own tenant.
+// It backs the guardian portal, where the route is guarded by GuardianScope.
+func (h *InvoiceHandler) ListForStudent(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("student_id"))
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid student_id format", err.Error())
+		return
+	}
+
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
+
+	status := entity.InvoiceStatus(c.Query("status"))
+
+	invoices, err := h.useCase.List(c.Request.Context(), tenantID, studentID, status)
+	if err != nil {
+		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to list invoices", err.Error())
+		return
+	}
+
+	httputil.Success(c.Writer, invoices)
+}
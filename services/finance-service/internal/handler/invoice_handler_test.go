@@ -13,6 +13,7 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/handler"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/usecase/mocks"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -32,7 +33,6 @@ func TestInvoiceHandler_Generate(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		reqBody := map[string]string{
-			"tenant_id":         tenantID.String(),
 			"student_id":        studentID.String(),
 			"billing_config_id": billingConfigID.String(),
 		}
@@ -50,6 +50,7 @@ func TestInvoiceHandler_Generate(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/invoices/generate", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -63,13 +64,12 @@ func TestInvoiceHandler_Generate(t *testing.T) {
 	})
 
 	t.Run("bad request", func(t *testing.T) {
-		reqBody := map[string]string{
-			"tenant_id": "invalid",
-		}
+		reqBody := map[string]string{}
 		body, _ := json.Marshal(reqBody)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/invoices/generate", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -80,7 +80,6 @@ func TestInvoiceHandler_Generate(t *testing.T) {
 
 	t.Run("usecase error", func(t *testing.T) {
 		reqBody := map[string]string{
-			"tenant_id":         tenantID.String(),
 			"student_id":        studentID.String(),
 			"billing_config_id": billingConfigID.String(),
 		}
@@ -90,6 +89,7 @@ func TestInvoiceHandler_Generate(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodPost, "/invoices/generate", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
 
@@ -109,13 +109,15 @@ func TestInvoiceHandler_GetByID(t *testing.T) {
 	h := handler.NewInvoiceHandler(mockUseCase)
 
 	id := uuid.New()
+	tenantID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		expectedInvoice := &entity.Invoice{ID: id}
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(expectedInvoice, nil)
+		expectedInvoice := &entity.Invoice{ID: id, TenantID: tenantID}
+		mockUseCase.EXPECT().GetByID(gomock.Any(), tenantID, id).Return(expectedInvoice, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodGet, "/invoices/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -127,6 +129,7 @@ func TestInvoiceHandler_GetByID(t *testing.T) {
 	t.Run("bad request - invalid id", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodGet, "/invoices/invalid", nil)
 		c.Params = gin.Params{{Key: "id", Value: "invalid"}}
 
@@ -136,10 +139,11 @@ func TestInvoiceHandler_GetByID(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mockUseCase.EXPECT().GetByID(gomock.Any(), id).Return(nil, errors.New("not found"))
+		mockUseCase.EXPECT().GetByID(gomock.Any(), tenantID, id).Return(nil, errors.New("not found"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Request, _ = http.NewRequest(http.MethodGet, "/invoices/"+id.String(), nil)
 		c.Params = gin.Params{{Key: "id", Value: id.String()}}
 
@@ -167,27 +171,29 @@ func TestInvoiceHandler_List(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/invoices?tenant_id="+tenantID.String()+"&student_id="+studentID.String()+"&status=UNPAID", nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/invoices?student_id="+studentID.String()+"&status=UNPAID", nil)
 
 		h.List(c)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
-	t.Run("bad request - invalid tenant_id", func(t *testing.T) {
+	t.Run("unauthorized - tenant in query is ignored", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/invoices?tenant_id=invalid", nil)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/invoices?tenant_id="+tenantID.String(), nil)
 
 		h.List(c)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 
 	t.Run("bad request - invalid student_id", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/invoices?tenant_id="+tenantID.String()+"&student_id=invalid", nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/invoices?student_id=invalid", nil)
 
 		h.List(c)
 
@@ -199,10 +205,54 @@ func TestInvoiceHandler_List(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/invoices?tenant_id="+tenantID.String(), nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/invoices", nil)
 
 		h.List(c)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 }
+
+func TestInvoiceHandler_ListForStudent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockInvoiceUseCase(ctrl)
+	h := handler.NewInvoiceHandler(mockUseCase)
+
+	tenantID := uuid.New()
+	studentID := uuid.New()
+
+	t.Run("success - tenant taken from token", func(t *testing.T) {
+		mockUseCase.EXPECT().List(gomock.Any(), tenantID, studentID, entity.InvoiceStatus("")).Return([]*entity.Invoice{}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "student_id", Value: studentID.String()}}
+		c.Request, _ = http.NewRequest(http.MethodGet, "/guardian/finance/students/"+studentID.String()+"/invoices?tenant_id="+uuid.NewString(), nil)
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID.String()})
+
+		h.ListForStudent(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("unauthorized - no claims", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "student_id", Value: studentID.String()}}
+		c.Request, _ = http.NewRequest(http.MethodGet, "/guardian/finance/students/"+studentID.String()+"/invoices", nil)
+
+		h.ListForStudent(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+// withTenant authenticates c the way the auth middleware would.
+func withTenant(c *gin.Context, tenantID string) {
+	c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -30,8 +31,11 @@ func NewPaymentHandler(useCase usecase.PaymentUseCase) *PaymentHandler {
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /finance/payments [post]
 func (h *PaymentHandler) Record(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	var req struct {
-		TenantID        uuid.UUID            `json:"tenant_id" binding:"required"`
 		InvoiceID       uuid.UUID            `json:"invoice_id" binding:"required"`
 		Amount          float64              `json:"amount" binding:"required,gt=0"`
 		PaymentMethod   entity.PaymentMethod `json:"payment_method" binding:"required"`
@@ -44,7 +48,7 @@ func (h *PaymentHandler) Record(c *gin.Context) {
 	}
 
 	payment := &entity.Payment{
-		TenantID:        req.TenantID,
+		TenantID:        tenantID,
 		InvoiceID:       req.InvoiceID,
 		Amount:          req.Amount,
 		PaymentMethod:   req.PaymentMethod,
@@ -53,6 +57,10 @@ func (h *PaymentHandler) Record(c *gin.Context) {
 	}
 
 	if err := h.useCase.RecordPayment(c.Request.Context(), payment); err != nil {
+		if errors.Is(err, usecase.ErrInvoiceNotFound) {
+			httputil.Error(c.Writer, http.StatusNotFound, "4004", "Invoice not found", err.Error())
+			return
+		}
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to record payment", err.Error())
 		return
 	}
@@ -72,13 +80,17 @@ func (h *PaymentHandler) Record(c *gin.Context) {
 // @Failure      404  {object}  map[string]interface{}
 // @Router       /finance/payments/{id} [get]
 func (h *PaymentHandler) GetByID(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid ID format", err.Error())
 		return
 	}
 
-	payment, err := h.useCase.GetByID(c.Request.Context(), id)
+	payment, err := h.useCase.GetByID(c.Request.Context(), tenantID, id)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusNotFound, "4004", "Payment not found", err.Error())
 		return
@@ -99,13 +111,17 @@ func (h *PaymentHandler) GetByID(c *gin.Context) {
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /finance/invoices/{invoice_id}/payments [get]
 func (h *PaymentHandler) ListByInvoice(c *gin.Context) {
+	tenantID, ok := requestTenant(c)
+	if !ok {
+		return
+	}
 	invoiceID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid invoice_id format", err.Error())
 		return
 	}
 
-	payments, err := h.useCase.ListByInvoiceID(c.Request.Context(), invoiceID)
+	payments, err := h.useCase.ListByInvoiceID(c.Request.Context(), tenantID, invoiceID)
 	if err != nil {
 		httputil.Error(c.Writer, http.StatusInternalServerError, "5001", "Failed to list payments", err.Error())
 		return
@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/usecase/mocks"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -28,13 +29,11 @@ func TestPaymentHandler_Record(t *testing.T) {
 		invoiceID := uuid.New()
 		
 		req := struct {
-			TenantID        uuid.UUID            `json:"tenant_id"`
 			InvoiceID       uuid.UUID            `json:"invoice_id"`
 			Amount          float64              `json:"amount"`
 			PaymentMethod   entity.PaymentMethod `json:"payment_method"`
 			ReferenceNumber string               `json:"reference_number"`
 		}{
-			TenantID:        tenantID,
 			InvoiceID:       invoiceID,
 			Amount:          100000,
 			PaymentMethod:   entity.PaymentMethodTransfer,
@@ -47,6 +46,7 @@ func TestPaymentHandler_Record(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID.String()})
 		body, _ := json.Marshal(req)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/payments", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
@@ -69,6 +69,7 @@ func TestPaymentHandler_Record(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: uuid.NewString()})
 		body, _ := json.Marshal(req)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/payments", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
@@ -86,13 +87,11 @@ func TestPaymentHandler_Record(t *testing.T) {
 		invoiceID := uuid.New()
 		
 		req := struct {
-			TenantID        uuid.UUID            `json:"tenant_id"`
 			InvoiceID       uuid.UUID            `json:"invoice_id"`
 			Amount          float64              `json:"amount"`
 			PaymentMethod   entity.PaymentMethod `json:"payment_method"`
 			ReferenceNumber string               `json:"reference_number"`
 		}{
-			TenantID:        tenantID,
 			InvoiceID:       invoiceID,
 			Amount:          100000,
 			PaymentMethod:   entity.PaymentMethodTransfer,
@@ -103,6 +102,7 @@ func TestPaymentHandler_Record(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID.String()})
 		body, _ := json.Marshal(req)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/payments", bytes.NewBuffer(body))
 		c.Request.Header.Set("Content-Type", "application/json")
@@ -121,17 +121,20 @@ func TestPaymentHandler_GetByID(t *testing.T) {
 		handler := NewPaymentHandler(mockUseCase)
 
 		paymentID := uuid.New()
+		tenantID := uuid.New()
 		payment := &entity.Payment{
 			ID:              paymentID,
+			TenantID:        tenantID,
 			Amount:          100000,
 			Status:          entity.PaymentStatusSuccess,
 			CreatedAt:       time.Now(),
 		}
 
-		mockUseCase.On("GetByID", mock.Anything, paymentID).Return(payment, nil)
+		mockUseCase.On("GetByID", mock.Anything, tenantID, paymentID).Return(payment, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID.String()})
 		c.Params = gin.Params{{Key: "id", Value: paymentID.String()}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/payments/"+paymentID.String(), nil)
 
@@ -146,11 +149,13 @@ func TestPaymentHandler_GetByID(t *testing.T) {
 		handler := NewPaymentHandler(mockUseCase)
 
 		paymentID := uuid.New()
+		tenantID := uuid.New()
 
-		mockUseCase.On("GetByID", mock.Anything, paymentID).Return(nil, errors.New("not found"))
+		mockUseCase.On("GetByID", mock.Anything, tenantID, paymentID).Return(nil, errors.New("not found"))
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		c.Set("claims", jwtutil.Claims{UserID: uuid.New(), TenantID: tenantID.String()})
 		c.Params = gin.Params{{Key: "id", Value: paymentID.String()}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/payments/"+paymentID.String(), nil)
 
@@ -25,7 +25,6 @@ func NewReportHandler(useCase usecase.ReportUseCase) *ReportHandler {
 // @Tags         reports
 // @Accept       json
 // @Produce      json
-// @Param        tenant_id  query     string  true  "Tenant ID"
 // @Param        start_date query     string  true  "Start Date (YYYY-MM-DD)"
 // @Param        end_date   query     string  true  "End Date (YYYY-MM-DD)"
 // @Success      200  {object}  []map[string]interface{}
@@ -33,9 +32,8 @@ func NewReportHandler(useCase usecase.ReportUseCase) *ReportHandler {
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /finance/reports/revenue/daily [get]
 func (h *ReportHandler) GetDailyRevenue(c *gin.Context) {
-	tenantID, err := uuid.Parse(c.Query("tenant_id"))
-	if err != nil {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid tenant_id format", err.Error())
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -74,16 +72,14 @@ func (h *ReportHandler) GetDailyRevenue(c *gin.Context) {
 // @Tags         reports
 // @Accept       json
 // @Produce      json
-// @Param        tenant_id  query     string  true  "Tenant ID"
 // @Param        year       query     int     true  "Year"
 // @Success      200  {object}  []map[string]interface{}
 // @Failure      400  {object}  map[string]interface{}
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /finance/reports/revenue/monthly [get]
 func (h *ReportHandler) GetMonthlyRevenue(c *gin.Context) {
-	tenantID, err := uuid.Parse(c.Query("tenant_id"))
-	if err != nil {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid tenant_id format", err.Error())
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -114,15 +110,13 @@ func (h *ReportHandler) GetMonthlyRevenue(c *gin.Context) {
 // @Tags         reports
 // @Accept       json
 // @Produce      json
-// @Param        tenant_id  query     string  true  "Tenant ID"
 // @Success      200  {object}  []map[string]interface{}
 // @Failure      400  {object}  map[string]interface{}
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /finance/reports/outstanding [get]
 func (h *ReportHandler) GetOutstandingInvoices(c *gin.Context) {
-	tenantID, err := uuid.Parse(c.Query("tenant_id"))
-	if err != nil {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid tenant_id format", err.Error())
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -142,7 +136,6 @@ func (h *ReportHandler) GetOutstandingInvoices(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        student_id path      string  true  "Student ID"
-// @Param        tenant_id  query     string  true  "Tenant ID"
 // @Success      200  {object}  map[string]interface{}
 // @Failure      400  {object}  map[string]interface{}
 // @Failure      500  {object}  map[string]interface{}
@@ -154,9 +147,8 @@ func (h *ReportHandler) GetStudentHistory(c *gin.Context) {
 		return
 	}
 
-	tenantID, err := uuid.Parse(c.Query("tenant_id"))
-	if err != nil {
-		httputil.Error(c.Writer, http.StatusBadRequest, "4001", "Invalid tenant_id format", err.Error())
+	tenantID, ok := requestTenant(c)
+	if !ok {
 		return
 	}
 
@@ -36,7 +36,8 @@ func TestReportHandler_GetDailyRevenue(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/daily?tenant_id="+tenantID.String()+"&start_date="+startDate+"&end_date="+endDate, nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/daily?start_date="+startDate+"&end_date="+endDate, nil)
 
 		h.GetDailyRevenue(c)
 
@@ -46,7 +47,8 @@ func TestReportHandler_GetDailyRevenue(t *testing.T) {
 	t.Run("bad request - missing params", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/daily?tenant_id="+tenantID.String(), nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/daily", nil)
 
 		h.GetDailyRevenue(c)
 
@@ -56,7 +58,8 @@ func TestReportHandler_GetDailyRevenue(t *testing.T) {
 	t.Run("bad request - invalid date", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/daily?tenant_id="+tenantID.String()+"&start_date=invalid&end_date="+endDate, nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/daily?start_date=invalid&end_date="+endDate, nil)
 
 		h.GetDailyRevenue(c)
 
@@ -68,7 +71,8 @@ func TestReportHandler_GetDailyRevenue(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/daily?tenant_id="+tenantID.String()+"&start_date="+startDate+"&end_date="+endDate, nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/daily?start_date="+startDate+"&end_date="+endDate, nil)
 
 		h.GetDailyRevenue(c)
 
@@ -96,7 +100,8 @@ func TestReportHandler_GetMonthlyRevenue(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/monthly?tenant_id="+tenantID.String()+"&year="+year, nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/monthly?year="+year, nil)
 
 		h.GetMonthlyRevenue(c)
 
@@ -106,7 +111,8 @@ func TestReportHandler_GetMonthlyRevenue(t *testing.T) {
 	t.Run("bad request - missing year", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/monthly?tenant_id="+tenantID.String(), nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/monthly", nil)
 
 		h.GetMonthlyRevenue(c)
 
@@ -116,7 +122,8 @@ func TestReportHandler_GetMonthlyRevenue(t *testing.T) {
 	t.Run("bad request - invalid year", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/monthly?tenant_id="+tenantID.String()+"&year=invalid", nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/revenue/monthly?year=invalid", nil)
 
 		h.GetMonthlyRevenue(c)
 
@@ -143,7 +150,8 @@ func TestReportHandler_GetOutstandingInvoices(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/outstanding?tenant_id="+tenantID.String(), nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/outstanding", nil)
 
 		h.GetOutstandingInvoices(c)
 
@@ -155,7 +163,8 @@ func TestReportHandler_GetOutstandingInvoices(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/outstanding?tenant_id="+tenantID.String(), nil)
+		withTenant(c, tenantID.String())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/outstanding", nil)
 
 		h.GetOutstandingInvoices(c)
 
@@ -184,8 +193,9 @@ func TestReportHandler_GetStudentHistory(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Params = gin.Params{{Key: "student_id", Value: studentID.String()}}
-		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/student/"+studentID.String()+"/history?tenant_id="+tenantID.String(), nil)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/student/"+studentID.String()+"/history", nil)
 
 		h.GetStudentHistory(c)
 
@@ -195,8 +205,9 @@ func TestReportHandler_GetStudentHistory(t *testing.T) {
 	t.Run("bad request - invalid student id", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Params = gin.Params{{Key: "student_id", Value: "invalid"}}
-		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/student/invalid/history?tenant_id="+tenantID.String(), nil)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/student/invalid/history", nil)
 
 		h.GetStudentHistory(c)
 
@@ -208,8 +219,9 @@ func TestReportHandler_GetStudentHistory(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		withTenant(c, tenantID.String())
 		c.Params = gin.Params{{Key: "student_id", Value: studentID.String()}}
-		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/student/"+studentID.String()+"/history?tenant_id="+tenantID.String(), nil)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/finance/reports/student/"+studentID.String()+"/history", nil)
 
 		h.GetStudentHistory(c)
 
@@ -11,6 +11,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 )
 
 type invoiceRepository struct {
@@ -42,7 +43,7 @@ func (r *invoiceRepository) Create(ctx context.Context, invoice *entity.Invoice)
 		invoice.UpdatedAt = now
 	}
 
-	_, err := r.db.Exec(ctx, query,
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query,
 		invoice.ID, invoice.TenantID, invoice.StudentID, invoice.BillingConfigID, invoice.InvoiceNumber,
 		invoice.Amount, invoice.Status, invoice.DueDate, invoice.PaidAmount, invoice.CreatedAt, invoice.UpdatedAt,
 	)
@@ -56,7 +57,7 @@ func (r *invoiceRepository) Update(ctx context.Context, invoice *entity.Invoice)
 		WHERE id = $4
 	`
 	invoice.UpdatedAt = time.Now()
-	tag, err := r.db.Exec(ctx, query,
+	tag, err := outbox.Conn(ctx, r.db).Exec(ctx, query,
 		invoice.Status, invoice.PaidAmount, invoice.UpdatedAt, invoice.ID,
 	)
 	if err != nil {
@@ -76,7 +77,7 @@ func (r *invoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.
 		WHERE id = $1
 	`
 	var invoice entity.Invoice
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
 		&invoice.ID, &invoice.TenantID, &invoice.StudentID, &invoice.BillingConfigID, &invoice.InvoiceNumber,
 		&invoice.Amount, &invoice.Status, &invoice.DueDate, &invoice.PaidAmount, &invoice.CreatedAt, &invoice.UpdatedAt,
 	)
@@ -97,7 +98,7 @@ func (r *invoiceRepository) GetByInvoiceNumber(ctx context.Context, invoiceNumbe
 		WHERE invoice_number = $1
 	`
 	var invoice entity.Invoice
-	err := r.db.QueryRow(ctx, query, invoiceNumber).Scan(
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, invoiceNumber).Scan(
 		&invoice.ID, &invoice.TenantID, &invoice.StudentID, &invoice.BillingConfigID, &invoice.InvoiceNumber,
 		&invoice.Amount, &invoice.Status, &invoice.DueDate, &invoice.PaidAmount, &invoice.CreatedAt, &invoice.UpdatedAt,
 	)
@@ -134,7 +135,7 @@ func (r *invoiceRepository) List(ctx context.Context, tenantID uuid.UUID, studen
 
 	query += " ORDER BY created_at DESC"
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -165,7 +166,7 @@ func (r *invoiceRepository) Exists(ctx context.Context, studentID, billingConfig
 		)
 	`
 	var exists bool
-	err := r.db.QueryRow(ctx, query, studentID, billingConfigID, month, year).Scan(&exists)
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, studentID, billingConfigID, month, year).Scan(&exists)
 	if err != nil {
 		return false, err
 	}
@@ -178,7 +179,7 @@ func (r *invoiceRepository) UpdateOverdueStatus(ctx context.Context) (int64, err
 		SET status = $1, updated_at = NOW()
 		WHERE status = $2 AND due_date < NOW()
 	`
-	tag, err := r.db.Exec(ctx, query, entity.InvoiceStatusOverdue, entity.InvoiceStatusUnpaid)
+	tag, err := outbox.Conn(ctx, r.db).Exec(ctx, query, entity.InvoiceStatusOverdue, entity.InvoiceStatusUnpaid)
 	if err != nil {
 		return 0, err
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 )
 
 type paymentRepository struct {
@@ -44,7 +45,7 @@ func (r *paymentRepository) Create(ctx context.Context, payment *entity.Payment)
 		payment.TransactionDate = now
 	}
 
-	_, err := r.db.Exec(ctx, query,
+	_, err := outbox.Conn(ctx, r.db).Exec(ctx, query,
 		payment.ID, payment.TenantID, payment.InvoiceID, payment.Amount, payment.PaymentMethod,
 		payment.ReferenceNumber, payment.TransactionDate, payment.Status, payment.CreatedAt, payment.UpdatedAt,
 	)
@@ -59,7 +60,7 @@ func (r *paymentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.
 		WHERE id = $1
 	`
 	var payment entity.Payment
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := outbox.Conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
 		&payment.ID, &payment.TenantID, &payment.InvoiceID, &payment.Amount, &payment.PaymentMethod,
 		&payment.ReferenceNumber, &payment.TransactionDate, &payment.Status, &payment.CreatedAt, &payment.UpdatedAt,
 	)
@@ -80,7 +81,7 @@ func (r *paymentRepository) ListByInvoiceID(ctx context.Context, invoiceID uuid.
 		WHERE invoice_id = $1
 		ORDER BY transaction_date DESC
 	`
-	rows, err := r.db.Query(ctx, query, invoiceID)
+	rows, err := outbox.Conn(ctx, r.db).Query(ctx, query, invoiceID)
 	if err != nil {
 		return nil, err
 	}
@@ -13,9 +13,9 @@ import (
 type BillingConfigUseCase interface {
 	Create(ctx context.Context, config *entity.BillingConfig) error
 	Update(ctx context.Context, config *entity.BillingConfig) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.BillingConfig, error)
+	GetByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.BillingConfig, error)
 	List(ctx context.Context, tenantID uuid.UUID) ([]*entity.BillingConfig, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
 }
 
 type billingConfigUseCase struct {
@@ -48,15 +48,25 @@ func (u *billingConfigUseCase) Update(ctx context.Context, config *entity.Billin
 	if errs := config.Validate(); len(errs) > 0 {
 		return errors.New("invalid input data")
 	}
+	if _, err := u.GetByID(ctx, config.TenantID, config.ID); err != nil {
+		return err
+	}
 
 	return u.repo.Update(ctx, config)
 }
 
-func (u *billingConfigUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.BillingConfig, error) {
+func (u *billingConfigUseCase) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.BillingConfig, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.timeout)
 	defer cancel()
 
-	return u.repo.GetByID(ctx, id)
+	config, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if config.TenantID != tenantID {
+		return nil, ErrBillingConfigNotFound
+	}
+	return config, nil
 }
 
 func (u *billingConfigUseCase) List(ctx context.Context, tenantID uuid.UUID) ([]*entity.BillingConfig, error) {
@@ -66,9 +76,12 @@ func (u *billingConfigUseCase) List(ctx context.Context, tenantID uuid.UUID) ([]
 	return u.repo.List(ctx, tenantID)
 }
 
-func (u *billingConfigUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *billingConfigUseCase) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.timeout)
 	defer cancel()
 
+	if _, err := u.GetByID(ctx, tenantID, id); err != nil {
+		return err
+	}
 	return u.repo.Delete(ctx, id)
 }
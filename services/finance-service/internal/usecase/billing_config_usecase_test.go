@@ -68,24 +68,34 @@ func TestBillingConfigUseCase_GetByID(t *testing.T) {
 	u := usecase.NewBillingConfigUseCase(mockRepo, timeout)
 
 	id := uuid.New()
+	tenantID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
 		config := &entity.BillingConfig{
-			ID:   id,
-			Name: "SPP Monthly",
+			ID:       id,
+			TenantID: tenantID,
+			Name:     "SPP Monthly",
 		}
 
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(config, nil)
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, config, res)
 	})
 
+	t.Run("other tenant", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.BillingConfig{ID: id, TenantID: tenantID}, nil)
+
+		res, err := u.GetByID(context.Background(), uuid.New(), id)
+		assert.ErrorIs(t, err, usecase.ErrBillingConfigNotFound)
+		assert.Nil(t, res)
+	})
+
 	t.Run("not found", func(t *testing.T) {
 		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, errors.New("not found"))
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
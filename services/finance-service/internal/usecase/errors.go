@@ -0,0 +1,11 @@
+package usecase
+
+import "errors"
+
+// Lookups answer these when the record is missing or belongs to another
+// tenant, so callers cannot probe for IDs outside their own tenant.
+var (
+	ErrBillingConfigNotFound = errors.New("billing config not found")
+	ErrInvoiceNotFound       = errors.New("invoice not found")
+	ErrPaymentNotFound       = errors.New("payment not found")
+)
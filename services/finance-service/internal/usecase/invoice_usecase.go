@@ -10,13 +10,15 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/audit"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 )
 
 type InvoiceUseCase interface {
 	Generate(ctx context.Context, tenantID, studentID, billingConfigID uuid.UUID) (*entity.Invoice, error)
 	GenerateAllMonthlyInvoices(ctx context.Context) error
 	CheckOverdueInvoices(ctx context.Context) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.Invoice, error)
+	GetByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.Invoice, error)
 	List(ctx context.Context, tenantID, studentID uuid.UUID, status entity.InvoiceStatus) ([]*entity.Invoice, error)
 }
 
@@ -24,6 +26,8 @@ type invoiceUseCase struct {
 	invoiceRepo       repository.InvoiceRepository
 	billingConfigRepo repository.BillingConfigRepository
 	studentRepo       repository.StudentRepository
+	events            outbox.Events
+	auditor           *audit.Publisher
 	timeout           time.Duration
 }
 
@@ -31,12 +35,16 @@ func NewInvoiceUseCase(
 	invoiceRepo repository.InvoiceRepository,
 	billingConfigRepo repository.BillingConfigRepository,
 	studentRepo repository.StudentRepository,
+	events outbox.Events,
+	auditor *audit.Publisher,
 	timeout time.Duration,
 ) InvoiceUseCase {
 	return &invoiceUseCase{
 		invoiceRepo:       invoiceRepo,
 		billingConfigRepo: billingConfigRepo,
 		studentRepo:       studentRepo,
+		events:            events,
+		auditor:           auditor,
 		timeout:           timeout,
 	}
 }
@@ -50,6 +58,9 @@ func (u *invoiceUseCase) Generate(ctx context.Context, tenantID, studentID, bill
 	if err != nil {
 		return nil, fmt.Errorf("failed to get billing config: %w", err)
 	}
+	if config.TenantID != tenantID {
+		return nil, ErrBillingConfigNotFound
+	}
 
 	// 2. Create Invoice
 	now := time.Now()
@@ -73,7 +84,19 @@ func (u *invoiceUseCase) Generate(ctx context.Context, tenantID, studentID, bill
 		UpdatedAt:       now,
 	}
 
-	if err := u.invoiceRepo.Create(ctx, invoice); err != nil {
+	err = u.events.Transact(ctx, func(ctx context.Context) error {
+		if err := u.invoiceRepo.Create(ctx, invoice); err != nil {
+			return err
+		}
+		return u.auditor.Record(ctx, audit.Entry{
+			TenantID:     tenantID.String(),
+			Action:       "invoice.create",
+			ResourceType: "invoice",
+			ResourceID:   &invoice.ID,
+			NewValues:    invoice,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -152,11 +175,18 @@ func (u *invoiceUseCase) CheckOverdueInvoices(ctx context.Context) error {
 	return nil
 }
 
-func (u *invoiceUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Invoice, error) {
+func (u *invoiceUseCase) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.Invoice, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.timeout)
 	defer cancel()
 
-	return u.invoiceRepo.GetByID(ctx, id)
+	invoice, err := u.invoiceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.TenantID != tenantID {
+		return nil, ErrInvoiceNotFound
+	}
+	return invoice, nil
 }
 
 func (u *invoiceUseCase) List(ctx context.Context, tenantID, studentID uuid.UUID, status entity.InvoiceStatus) ([]*entity.Invoice, error) {
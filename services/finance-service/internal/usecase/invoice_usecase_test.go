@@ -10,6 +10,7 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/mocks"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox/outboxtest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -22,7 +23,7 @@ func TestInvoiceUseCase_Generate(t *testing.T) {
 	mockBillingConfigRepo := mocks.NewMockBillingConfigRepository(ctrl)
 	mockStudentRepo := mocks.NewMockStudentRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewInvoiceUseCase(mockInvoiceRepo, mockBillingConfigRepo, mockStudentRepo, timeout)
+	u := usecase.NewInvoiceUseCase(mockInvoiceRepo, mockBillingConfigRepo, mockStudentRepo, &outboxtest.Recorder{}, nil, timeout)
 
 	tenantID := uuid.New()
 	studentID := uuid.New()
@@ -30,8 +31,9 @@ func TestInvoiceUseCase_Generate(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		config := &entity.BillingConfig{
-			ID:     billingConfigID,
-			Amount: 100000,
+			ID:       billingConfigID,
+			TenantID: tenantID,
+			Amount:   100000,
 		}
 
 		mockBillingConfigRepo.EXPECT().GetByID(gomock.Any(), billingConfigID).Return(config, nil)
@@ -58,6 +60,15 @@ func TestInvoiceUseCase_Generate(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, invoice)
 	})
+
+	t.Run("billing config of another tenant", func(t *testing.T) {
+		config := &entity.BillingConfig{ID: billingConfigID, TenantID: uuid.New(), Amount: 100000}
+		mockBillingConfigRepo.EXPECT().GetByID(gomock.Any(), billingConfigID).Return(config, nil)
+
+		invoice, err := u.Generate(context.Background(), tenantID, studentID, billingConfigID)
+		assert.ErrorIs(t, err, usecase.ErrBillingConfigNotFound)
+		assert.Nil(t, invoice)
+	})
 }
 
 func TestInvoiceUseCase_GenerateAllMonthlyInvoices(t *testing.T) {
@@ -68,7 +79,7 @@ func TestInvoiceUseCase_GenerateAllMonthlyInvoices(t *testing.T) {
 	mockBillingConfigRepo := mocks.NewMockBillingConfigRepository(ctrl)
 	mockStudentRepo := mocks.NewMockStudentRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewInvoiceUseCase(mockInvoiceRepo, mockBillingConfigRepo, mockStudentRepo, timeout)
+	u := usecase.NewInvoiceUseCase(mockInvoiceRepo, mockBillingConfigRepo, mockStudentRepo, &outboxtest.Recorder{}, nil, timeout)
 
 	t.Run("success", func(t *testing.T) {
 		tenantID := uuid.New()
@@ -122,7 +133,7 @@ func TestInvoiceUseCase_CheckOverdueInvoices(t *testing.T) {
 
 	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewInvoiceUseCase(mockInvoiceRepo, nil, nil, timeout)
+	u := usecase.NewInvoiceUseCase(mockInvoiceRepo, nil, nil, &outboxtest.Recorder{}, nil, timeout)
 
 	t.Run("success", func(t *testing.T) {
 		mockInvoiceRepo.EXPECT().UpdateOverdueStatus(gomock.Any()).Return(int64(5), nil)
@@ -145,23 +156,34 @@ func TestInvoiceUseCase_GetByID(t *testing.T) {
 
 	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewInvoiceUseCase(mockInvoiceRepo, nil, nil, timeout)
+	u := usecase.NewInvoiceUseCase(mockInvoiceRepo, nil, nil, &outboxtest.Recorder{}, nil, timeout)
+
+	tenantID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
 		id := uuid.New()
-		expected := &entity.Invoice{ID: id}
+		expected := &entity.Invoice{ID: id, TenantID: tenantID}
 		mockInvoiceRepo.EXPECT().GetByID(gomock.Any(), id).Return(expected, nil)
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, expected, res)
 	})
 
+	t.Run("other tenant", func(t *testing.T) {
+		id := uuid.New()
+		mockInvoiceRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.Invoice{ID: id, TenantID: tenantID}, nil)
+
+		res, err := u.GetByID(context.Background(), uuid.New(), id)
+		assert.ErrorIs(t, err, usecase.ErrInvoiceNotFound)
+		assert.Nil(t, res)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		id := uuid.New()
 		mockInvoiceRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, errors.New("db error"))
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -173,7 +195,7 @@ func TestInvoiceUseCase_List(t *testing.T) {
 
 	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewInvoiceUseCase(mockInvoiceRepo, nil, nil, timeout)
+	u := usecase.NewInvoiceUseCase(mockInvoiceRepo, nil, nil, &outboxtest.Recorder{}, nil, timeout)
 
 	t.Run("success", func(t *testing.T) {
 		tenantID := uuid.New()
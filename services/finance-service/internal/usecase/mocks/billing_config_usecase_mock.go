@@ -57,32 +57,32 @@ func (mr *MockBillingConfigUseCaseMockRecorder) Create(ctx, config any) *gomock.
 }
 
 // Delete mocks base method.
-func (m *MockBillingConfigUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockBillingConfigUseCase) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockBillingConfigUseCaseMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockBillingConfigUseCaseMockRecorder) Delete(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBillingConfigUseCase)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBillingConfigUseCase)(nil).Delete), ctx, tenantID, id)
 }
 
 // GetByID mocks base method.
-func (m *MockBillingConfigUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.BillingConfig, error) {
+func (m *MockBillingConfigUseCase) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.BillingConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
 	ret0, _ := ret[0].(*entity.BillingConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockBillingConfigUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockBillingConfigUseCaseMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBillingConfigUseCase)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBillingConfigUseCase)(nil).GetByID), ctx, tenantID, id)
 }
 
 // List mocks base method.
@@ -86,18 +86,18 @@ func (mr *MockInvoiceUseCaseMockRecorder) GenerateAllMonthlyInvoices(ctx any) *g
 }
 
 // GetByID mocks base method.
-func (m *MockInvoiceUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Invoice, error) {
+func (m *MockInvoiceUseCase) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
 	ret0, _ := ret[0].(*entity.Invoice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockInvoiceUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockInvoiceUseCaseMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockInvoiceUseCase)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockInvoiceUseCase)(nil).GetByID), ctx, tenantID, id)
 }
 
 // List mocks base method.
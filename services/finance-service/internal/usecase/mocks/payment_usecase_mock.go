@@ -17,16 +17,16 @@ func (m *PaymentUseCaseMock) RecordPayment(ctx context.Context, payment *entity.
 	return args.Error(0)
 }
 
-func (m *PaymentUseCaseMock) GetByID(ctx context.Context, id uuid.UUID) (*entity.Payment, error) {
-	args := m.Called(ctx, id)
+func (m *PaymentUseCaseMock) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.Payment, error) {
+	args := m.Called(ctx, tenantID, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.Payment), args.Error(1)
 }
 
-func (m *PaymentUseCaseMock) ListByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]*entity.Payment, error) {
-	args := m.Called(ctx, invoiceID)
+func (m *PaymentUseCaseMock) ListByInvoiceID(ctx context.Context, tenantID, invoiceID uuid.UUID) ([]*entity.Payment, error) {
+	args := m.Called(ctx, tenantID, invoiceID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
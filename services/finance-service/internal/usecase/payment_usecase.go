@@ -9,28 +9,36 @@ import (
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/repository"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/audit"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
 )
 
 type PaymentUseCase interface {
 	RecordPayment(ctx context.Context, payment *entity.Payment) error
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.Payment, error)
-	ListByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]*entity.Payment, error)
+	GetByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.Payment, error)
+	ListByInvoiceID(ctx context.Context, tenantID, invoiceID uuid.UUID) ([]*entity.Payment, error)
 }
 
 type paymentUseCase struct {
 	paymentRepo repository.PaymentRepository
 	invoiceRepo repository.InvoiceRepository
+	events      outbox.Events
+	auditor     *audit.Publisher
 	timeout     time.Duration
 }
 
 func NewPaymentUseCase(
 	paymentRepo repository.PaymentRepository,
 	invoiceRepo repository.InvoiceRepository,
+	events outbox.Events,
+	auditor *audit.Publisher,
 	timeout time.Duration,
 ) PaymentUseCase {
 	return &paymentUseCase{
 		paymentRepo: paymentRepo,
 		invoiceRepo: invoiceRepo,
+		events: events,
+		auditor: auditor,
 		timeout: timeout,
 	}
 }
@@ -44,6 +52,9 @@ func (u *paymentUseCase) RecordPayment(ctx context.Context, payment *entity.Paym
 	if err != nil {
 		return fmt.Errorf("failed to get invoice: %w", err)
 	}
+	if invoice.TenantID != payment.TenantID {
+		return ErrInvoiceNotFound
+	}
 
 	// 2. Validate Payment
 	if invoice.Status == entity.InvoiceStatusPaid {
@@ -58,37 +69,63 @@ func (u *paymentUseCase) RecordPayment(ctx context.Context, payment *entity.Paym
 		return fmt.Errorf("payment amount %.2f exceeds remaining amount %.2f", payment.Amount, remainingAmount)
 	}
 
-	// 3. Create Payment
-	if err := u.paymentRepo.Create(ctx, payment); err != nil {
-		return fmt.Errorf("failed to create payment: %w", err)
-	}
-
-	// 4. Update Invoice Status
-	invoice.PaidAmount += payment.Amount
-	if invoice.PaidAmount >= invoice.Amount {
-		invoice.Status = entity.InvoiceStatusPaid
-	} else {
-		invoice.Status = entity.InvoiceStatusPartial
-	}
-
-	if err := u.invoiceRepo.Update(ctx, invoice); err != nil {
-		// Note: In a real system, we should rollback payment creation here or use transaction
-		return fmt.Errorf("failed to update invoice status: %w", err)
-	}
-
-	return nil
+	// The payment, the invoice status and the audit entry commit together
+	return u.events.Transact(ctx, func(ctx context.Context) error {
+		// 3. Create Payment
+		if err := u.paymentRepo.Create(ctx, payment); err != nil {
+			return fmt.Errorf("failed to create payment: %w", err)
+		}
+
+		// 4. Update Invoice Status
+		before := map[string]any{"status": invoice.Status, "paid_amount": invoice.PaidAmount}
+		invoice.PaidAmount += payment.Amount
+		if invoice.PaidAmount >= invoice.Amount {
+			invoice.Status = entity.InvoiceStatusPaid
+		} else {
+			invoice.Status = entity.InvoiceStatusPartial
+		}
+
+		if err := u.invoiceRepo.Update(ctx, invoice); err != nil {
+			return fmt.Errorf("failed to update invoice status: %w", err)
+		}
+		return u.auditor.Record(ctx, audit.Entry{
+			TenantID:     invoice.TenantID.String(),
+			Action:       "invoice.payment",
+			ResourceType: "invoice",
+			ResourceID:   &invoice.ID,
+			OldValues:    before,
+			NewValues:    map[string]any{"status": invoice.Status, "paid_amount": invoice.PaidAmount, "payment_id": payment.ID, "amount": payment.Amount},
+		})
+	})
 }
 
-func (u *paymentUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Payment, error) {
+func (u *paymentUseCase) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.Payment, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.timeout)
 	defer cancel()
 
-	return u.paymentRepo.GetByID(ctx, id)
+	payment, err := u.paymentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if payment.TenantID != tenantID {
+		return nil, ErrPaymentNotFound
+	}
+	return payment, nil
 }
 
-func (u *paymentUseCase) ListByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]*entity.Payment, error) {
+func (u *paymentUseCase) ListByInvoiceID(ctx context.Context, tenantID, invoiceID uuid.UUID) ([]*entity.Payment, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.timeout)
 	defer cancel()
 
-	return u.paymentRepo.ListByInvoiceID(ctx, invoiceID)
+	payments, err := u.paymentRepo.ListByInvoiceID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	owned := payments[:0]
+	for _, p := range payments {
+		if p.TenantID == tenantID {
+			owned = append(owned, p)
+		}
+	}
+	return owned, nil
 }
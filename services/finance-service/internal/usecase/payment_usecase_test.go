@@ -10,6 +10,7 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/domain/mocks"
 	"github.com/jjaenal/sisfo-akademik-backend/services/finance-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox/outboxtest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -21,7 +22,7 @@ func TestPaymentUseCase_RecordPayment(t *testing.T) {
 	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
 	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewPaymentUseCase(mockPaymentRepo, mockInvoiceRepo, timeout)
+	u := usecase.NewPaymentUseCase(mockPaymentRepo, mockInvoiceRepo, &outboxtest.Recorder{}, nil, timeout)
 
 	invoiceID := uuid.New()
 	payment := &entity.Payment{
@@ -81,6 +82,20 @@ func TestPaymentUseCase_RecordPayment(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("invoice of another tenant", func(t *testing.T) {
+		invoice := &entity.Invoice{
+			ID:       invoiceID,
+			TenantID: uuid.New(),
+			Amount:   50000,
+			Status:   entity.InvoiceStatusUnpaid,
+		}
+
+		mockInvoiceRepo.EXPECT().GetByID(gomock.Any(), invoiceID).Return(invoice, nil)
+
+		err := u.RecordPayment(context.Background(), payment)
+		assert.ErrorIs(t, err, usecase.ErrInvoiceNotFound)
+	})
+
 	t.Run("invoice already paid", func(t *testing.T) {
 		invoice := &entity.Invoice{
 			ID:     invoiceID,
@@ -122,23 +137,32 @@ func TestPaymentUseCase_GetByID(t *testing.T) {
 	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
 	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewPaymentUseCase(mockPaymentRepo, mockInvoiceRepo, timeout)
+	u := usecase.NewPaymentUseCase(mockPaymentRepo, mockInvoiceRepo, &outboxtest.Recorder{}, nil, timeout)
 
 	id := uuid.New()
+	tenantID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		payment := &entity.Payment{ID: id}
+		payment := &entity.Payment{ID: id, TenantID: tenantID}
 		mockPaymentRepo.EXPECT().GetByID(gomock.Any(), id).Return(payment, nil)
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.NoError(t, err)
 		assert.Equal(t, payment, res)
 	})
 
+	t.Run("other tenant", func(t *testing.T) {
+		mockPaymentRepo.EXPECT().GetByID(gomock.Any(), id).Return(&entity.Payment{ID: id, TenantID: tenantID}, nil)
+
+		res, err := u.GetByID(context.Background(), uuid.New(), id)
+		assert.ErrorIs(t, err, usecase.ErrPaymentNotFound)
+		assert.Nil(t, res)
+	})
+
 	t.Run("not found", func(t *testing.T) {
 		mockPaymentRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, errors.New("not found"))
 
-		res, err := u.GetByID(context.Background(), id)
+		res, err := u.GetByID(context.Background(), tenantID, id)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -151,23 +175,33 @@ func TestPaymentUseCase_ListByInvoiceID(t *testing.T) {
 	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
 	mockInvoiceRepo := mocks.NewMockInvoiceRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewPaymentUseCase(mockPaymentRepo, mockInvoiceRepo, timeout)
+	u := usecase.NewPaymentUseCase(mockPaymentRepo, mockInvoiceRepo, &outboxtest.Recorder{}, nil, timeout)
 
 	invoiceID := uuid.New()
+	tenantID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		payments := []*entity.Payment{{InvoiceID: invoiceID}}
+		payments := []*entity.Payment{{TenantID: tenantID, InvoiceID: invoiceID}}
 		mockPaymentRepo.EXPECT().ListByInvoiceID(gomock.Any(), invoiceID).Return(payments, nil)
 
-		res, err := u.ListByInvoiceID(context.Background(), invoiceID)
+		res, err := u.ListByInvoiceID(context.Background(), tenantID, invoiceID)
 		assert.NoError(t, err)
 		assert.Equal(t, payments, res)
 	})
 
+	t.Run("skips other tenants", func(t *testing.T) {
+		payments := []*entity.Payment{{TenantID: uuid.New(), InvoiceID: invoiceID}}
+		mockPaymentRepo.EXPECT().ListByInvoiceID(gomock.Any(), invoiceID).Return(payments, nil)
+
+		res, err := u.ListByInvoiceID(context.Background(), tenantID, invoiceID)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		mockPaymentRepo.EXPECT().ListByInvoiceID(gomock.Any(), invoiceID).Return(nil, errors.New("db error"))
 
-		res, err := u.ListByInvoiceID(context.Background(), invoiceID)
+		res, err := u.ListByInvoiceID(context.Background(), tenantID, invoiceID)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
@@ -95,7 +95,7 @@ func setupServer(t *testing.T) (*gin.Engine, *pgxpool.Pool) {
 	// Init UseCases
 	timeout := 5 * time.Second
 	templateUC := usecase.NewNotificationTemplateUseCase(templateRepo, timeout)
-	notifUC := usecase.NewNotificationUseCase(notifRepo, templateRepo, emailService, waService, nil, timeout)
+	notifUC := usecase.NewNotificationUseCase(notifRepo, templateRepo, emailService, waService, nil, nil, timeout)
 
 	// Init Handlers
 	templateHandler := handler.NewNotificationTemplateHandler(templateUC)
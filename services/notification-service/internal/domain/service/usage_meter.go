@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
+)
+
+// UsageMeter meters messages sent against the tenant's plan.
+type UsageMeter interface {
+	Consume(ctx context.Context, tenant string, metric metering.Metric, n int64) (metering.Result, error)
+	Record(ctx context.Context, tenant string, metric metering.Metric, n int64) (metering.Result, error)
+	Release(ctx context.Context, tenant string, r metering.Result) error
+}
@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jjaenal/sisfo-akademik-backend/services/notification-service/internal/usecase"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
 )
 
 type NotificationHandler struct {
@@ -25,6 +29,7 @@ func NewNotificationHandler(useCase usecase.NotificationUseCase) *NotificationHa
 // @Param request body usecase.SendNotificationRequest true "Notification Request"
 // @Success 202 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /notifications/send [post]
 func (h *NotificationHandler) Send(c *gin.Context) {
@@ -34,7 +39,16 @@ func (h *NotificationHandler) Send(c *gin.Context) {
 		return
 	}
 
+	if claims, ok := c.Request.Context().Value(middleware.ClaimsKey).(jwtutil.Claims); ok {
+		req.TenantID = claims.TenantID
+	}
+
 	if err := h.useCase.Send(c.Request.Context(), &req); err != nil {
+		var quota *usecase.QuotaExceededError
+		if errors.As(err, &quota) {
+			metering.Reject(c.Writer, quota.Usage)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -2,6 +2,7 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -14,6 +15,9 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/notification-service/internal/handler"
 	"github.com/jjaenal/sisfo-akademik-backend/services/notification-service/internal/usecase"
 	"github.com/jjaenal/sisfo-akademik-backend/services/notification-service/internal/usecase/mocks"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -75,6 +79,24 @@ func TestNotificationHandler_Send(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
+
+	t.Run("quota exceeded", func(t *testing.T) {
+		mockUseCase.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *usecase.SendNotificationRequest) error {
+			assert.Equal(t, "t1", req.TenantID)
+			return &usecase.QuotaExceededError{Usage: metering.Result{Metric: metering.MessagesSent, State: metering.Exceeded}}
+		})
+
+		body, _ := json.Marshal(usecase.SendNotificationRequest{Channel: entity.NotificationChannelEmail, Recipient: "test@example.com", Body: "Body"})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/notifications/send", bytes.NewBuffer(body))
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), middleware.ClaimsKey, jwtutil.Claims{TenantID: "t1"}))
+
+		h.Send(c)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	})
 }
 
 func TestNotificationHandler_GetByID(t *testing.T) {
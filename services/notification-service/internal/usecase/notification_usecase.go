@@ -12,6 +12,7 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/notification-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/notification-service/internal/domain/repository"
 	"github.com/jjaenal/sisfo-akademik-backend/services/notification-service/internal/domain/service"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/rabbit"
 )
 
@@ -30,6 +31,21 @@ type SendNotificationRequest struct {
 	Body         string
 	TemplateName string
 	Data         map[string]string
+	// TenantID is metered for the message; set by the caller, never bound
+	// from a request body.
+	TenantID string `json:"-"`
+	// Essential messages such as password resets are metered but never
+	// refused for quota.
+	Essential bool `json:"-"`
+}
+
+// QuotaExceededError refuses a message past the tenant's daily quota.
+type QuotaExceededError struct {
+	Usage metering.Result
+}
+
+func (e *QuotaExceededError) Error() string {
+	return "message quota exceeded"
 }
 
 type notificationUseCase struct {
@@ -38,6 +54,7 @@ type notificationUseCase struct {
 	emailService service.EmailService
 	waService    service.WhatsAppService
 	rabbitClient *rabbit.Client
+	meter        service.UsageMeter
 	timeout      time.Duration
 }
 
@@ -47,6 +64,7 @@ func NewNotificationUseCase(
 	emailService service.EmailService,
 	waService service.WhatsAppService,
 	rabbitClient *rabbit.Client,
+	meter service.UsageMeter,
 	timeout time.Duration,
 ) NotificationUseCase {
 	return &notificationUseCase{
@@ -55,6 +73,7 @@ func NewNotificationUseCase(
 		emailService: emailService,
 		waService:    waService,
 		rabbitClient: rabbitClient,
+		meter:        meter,
 		timeout:      timeout,
 	}
 }
@@ -106,7 +125,14 @@ func (u *notificationUseCase) Send(ctx context.Context, req *SendNotificationReq
 		notification.TemplateID = &template.ID
 	}
 
+	usage, err := u.meterMessage(ctx, req)
+	if err != nil {
+		return err
+	}
 	if err := u.notifRepo.Create(ctx, notification); err != nil {
+		if u.meter != nil {
+			_ = u.meter.Release(ctx, req.TenantID, usage)
+		}
 		return fmt.Errorf("failed to create notification record: %w", err)
 	}
 
@@ -125,6 +151,30 @@ func (u *notificationUseCase) Send(ctx context.Context, req *SendNotificationReq
 	return nil
 }
 
+// meterMessage counts the message against the tenant's quota. Metering fails
+// open so a Redis outage does not stop notifications.
+func (u *notificationUseCase) meterMessage(ctx context.Context, req *SendNotificationRequest) (metering.Result, error) {
+	if u.meter == nil || req.TenantID == "" {
+		return metering.Result{}, nil
+	}
+	meter := u.meter.Consume
+	if req.Essential {
+		meter = u.meter.Record
+	}
+	usage, err := meter(ctx, req.TenantID, metering.MessagesSent, 1)
+	if err != nil {
+		log.Printf("Failed to meter message for tenant %s: %v", req.TenantID, err)
+		return metering.Result{}, nil
+	}
+	switch {
+	case usage.State == metering.Exceeded && !req.Essential:
+		return usage, &QuotaExceededError{Usage: usage}
+	case usage.State != metering.OK:
+		log.Printf("Tenant %s is past its message quota: %d of %d sent today", req.TenantID, usage.Used, usage.Quota.Soft)
+	}
+	return usage, nil
+}
+
 func (u *notificationUseCase) Process(ctx context.Context, id uuid.UUID) error {
 	n, err := u.notifRepo.GetByID(ctx, id)
 	if err != nil {
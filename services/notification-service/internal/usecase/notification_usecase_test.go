@@ -9,6 +9,7 @@ import (
 	"github.com/jjaenal/sisfo-akademik-backend/services/notification-service/internal/domain/entity"
 	"github.com/jjaenal/sisfo-akademik-backend/services/notification-service/internal/domain/mocks"
 	"github.com/jjaenal/sisfo-akademik-backend/services/notification-service/internal/usecase"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/metering"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -33,6 +34,7 @@ func TestNotificationUseCase_Send(t *testing.T) {
 		mockEmailService,
 		mockWAService,
 		nil, // rabbitClient
+		nil, // meter
 		timeout,
 	)
 
@@ -145,6 +147,7 @@ func TestNotificationUseCase_Process(t *testing.T) {
 		mockEmailService,
 		mockWAService,
 		nil,
+		nil,
 		timeout,
 	)
 
@@ -182,13 +185,62 @@ func TestNotificationUseCase_Process(t *testing.T) {
 	})
 }
 
+type fakeMeter struct {
+	state    metering.State
+	recorded int
+	released bool
+}
+
+func (m *fakeMeter) Consume(ctx context.Context, tenant string, metric metering.Metric, n int64) (metering.Result, error) {
+	return metering.Result{Metric: metric, Amount: n, State: m.state}, nil
+}
+
+func (m *fakeMeter) Record(ctx context.Context, tenant string, metric metering.Metric, n int64) (metering.Result, error) {
+	m.recorded++
+	return metering.Result{Metric: metric, Amount: n, State: m.state}, nil
+}
+
+func (m *fakeMeter) Release(ctx context.Context, tenant string, r metering.Result) error {
+	m.released = true
+	return nil
+}
+
+func TestNotificationUseCase_SendQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNotifRepo := mocks.NewMockNotificationRepository(ctrl)
+	meter := &fakeMeter{state: metering.Exceeded}
+	u := usecase.NewNotificationUseCase(mockNotifRepo, nil, nil, nil, nil, meter, 2*time.Second)
+
+	t.Run("refused past the hard limit", func(t *testing.T) {
+		err := u.Send(context.Background(), &usecase.SendNotificationRequest{
+			Channel: entity.NotificationChannelWhatsApp, Recipient: "0812", Body: "Hi", TenantID: "t1",
+		})
+		var quota *usecase.QuotaExceededError
+		assert.ErrorAs(t, err, &quota)
+	})
+
+	t.Run("essential messages are recorded and sent", func(t *testing.T) {
+		mockNotifRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+		err := u.Send(context.Background(), &usecase.SendNotificationRequest{
+			Channel: entity.NotificationChannelWhatsApp, Recipient: "0812", Body: "Hi", TenantID: "t1", Essential: true,
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 1, meter.recorded)
+		// Nothing was stored, so the message does not count
+		assert.True(t, meter.released)
+	})
+}
+
 func TestNotificationUseCase_GetByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockNotifRepo := mocks.NewMockNotificationRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewNotificationUseCase(mockNotifRepo, nil, nil, nil, nil, timeout)
+	u := usecase.NewNotificationUseCase(mockNotifRepo, nil, nil, nil, nil, nil, timeout)
 
 	t.Run("success", func(t *testing.T) {
 		id := uuid.New()
@@ -216,7 +268,7 @@ func TestNotificationUseCase_ListByRecipient(t *testing.T) {
 
 	mockNotifRepo := mocks.NewMockNotificationRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewNotificationUseCase(mockNotifRepo, nil, nil, nil, nil, timeout)
+	u := usecase.NewNotificationUseCase(mockNotifRepo, nil, nil, nil, nil, nil, timeout)
 
 	t.Run("success", func(t *testing.T) {
 		recipient := "user@example.com"
@@ -244,7 +296,7 @@ func TestNotificationUseCase_UpdateStatus(t *testing.T) {
 
 	mockNotifRepo := mocks.NewMockNotificationRepository(ctrl)
 	timeout := 2 * time.Second
-	u := usecase.NewNotificationUseCase(mockNotifRepo, nil, nil, nil, nil, timeout)
+	u := usecase.NewNotificationUseCase(mockNotifRepo, nil, nil, nil, nil, nil, timeout)
 
 	t.Run("success", func(t *testing.T) {
 		id := uuid.New()
@@ -0,0 +1,78 @@
+// Package audit lets any service record changes in the platform audit trail.
+// Entries go through the service's outbox to RabbitMQ and are appended to
+// audit_logs by auth-service.
+package audit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+)
+
+const (
+	Exchange   = "sisfo.events"
+	RoutingKey = "audit.recorded"
+)
+
+var ErrMissingTenant = errors.New("audit entry without tenant")
+
+// EventPublisher is satisfied by *outbox.Outbox. Recorded inside its
+// Transact, an entry commits with the change it describes.
+type EventPublisher interface {
+	Enqueue(ctx context.Context, exchange, routingKey string, payload map[string]any) error
+}
+
+type Entry struct {
+	TenantID     string
+	Action       string
+	ResourceType string
+	ResourceID   *uuid.UUID
+	OldValues    any
+	NewValues    any
+}
+
+// Publisher records entries on behalf of the user in the request claims. A
+// nil *Publisher records nothing, so usecases can be built without one.
+type Publisher struct {
+	pub EventPublisher
+	now func() time.Time
+}
+
+func NewPublisher(pub EventPublisher) *Publisher {
+	return &Publisher{pub: pub, now: time.Now}
+}
+
+// Record enqueues e. The tenant defaults to the caller's; entries made by
+// background jobs carry no user.
+func (p *Publisher) Record(ctx context.Context, e Entry) error {
+	if p == nil || p.pub == nil {
+		return nil
+	}
+	payload := map[string]any{
+		"action":        e.Action,
+		"resource_type": e.ResourceType,
+		"new_values":    e.NewValues,
+		"timestamp":     p.now().UTC(),
+	}
+	if claims, ok := ctx.Value(middleware.ClaimsKey).(jwtutil.Claims); ok {
+		payload["user_id"] = claims.UserID.String()
+		if e.TenantID == "" {
+			e.TenantID = claims.TenantID
+		}
+	}
+	if e.TenantID == "" {
+		return ErrMissingTenant
+	}
+	payload["tenant_id"] = e.TenantID
+	if e.ResourceID != nil {
+		payload["resource_id"] = e.ResourceID.String()
+	}
+	if e.OldValues != nil {
+		payload["old_values"] = e.OldValues
+	}
+	return p.pub.Enqueue(ctx, Exchange, RoutingKey, payload)
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+)
+
+type fakePublisher struct {
+	keys     []string
+	payloads []map[string]any
+}
+
+func (f *fakePublisher) Enqueue(_ context.Context, exchange, routingKey string, payload map[string]any) error {
+	f.keys = append(f.keys, routingKey)
+	f.payloads = append(f.payloads, payload)
+	return nil
+}
+
+func TestRecord(t *testing.T) {
+	pub := &fakePublisher{}
+	p := NewPublisher(pub)
+	user := uuid.New()
+	ctx := context.WithValue(context.Background(), middleware.ClaimsKey, jwtutil.Claims{UserID: user, TenantID: "t1"})
+	grade := uuid.New()
+	if err := p.Record(ctx, Entry{Action: "grade.approve", ResourceType: "grade", ResourceID: &grade, OldValues: map[string]any{"status": "draft"}, NewValues: map[string]any{"status": "final"}}); err != nil {
+		t.Fatalf("record err: %v", err)
+	}
+	if len(pub.payloads) != 1 || pub.keys[0] != RoutingKey {
+		t.Fatalf("expected one audit event, got %v", pub.keys)
+	}
+	got := pub.payloads[0]
+	if got["tenant_id"] != "t1" || got["user_id"] != user.String() || got["resource_id"] != grade.String() || got["old_values"] == nil {
+		t.Fatalf("unexpected payload %v", got)
+	}
+
+	// Background jobs have no claims and must name the tenant themselves.
+	if err := p.Record(context.Background(), Entry{Action: "invoice.overdue", ResourceType: "invoice"}); !errors.Is(err, ErrMissingTenant) {
+		t.Fatalf("expected ErrMissingTenant, got %v", err)
+	}
+	if err := p.Record(context.Background(), Entry{TenantID: "t2", Action: "invoice.create", ResourceType: "invoice"}); err != nil {
+		t.Fatalf("record err: %v", err)
+	}
+	if _, ok := pub.payloads[1]["user_id"]; ok || pub.payloads[1]["tenant_id"] != "t2" {
+		t.Fatalf("unexpected payload %v", pub.payloads[1])
+	}
+
+	var none *Publisher
+	if err := none.Record(ctx, Entry{Action: "x", ResourceType: "y"}); err != nil {
+		t.Fatalf("nil publisher must be a no-op, got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package authz
+
+import "github.com/google/uuid"
+
+// APIKeyVerifyPath exchanges an API key for a short-lived access token. Like
+// CheckPath it is internal and only the API gateway calls it.
+const APIKeyVerifyPath = "/internal/v1/api-keys/verify"
+
+// APIKeyHeader carries the key of a machine client instead of a bearer token.
+const APIKeyHeader = "X-API-Key"
+
+type APIKeyVerifyRequest struct {
+	Key string `json:"key"`
+}
+
+// APIKeyVerifyResponse carries the token to forward upstream and the per-key
+// request budget the gateway enforces. UserID and TokenVersion repeat the
+// token's claims so the gateway can drop a cached token once the service
+// account's version moves on.
+type APIKeyVerifyResponse struct {
+	AccessToken  string    `json:"access_token"`
+	ExpiresIn    int       `json:"expires_in"`
+	KeyID        uuid.UUID `json:"key_id"`
+	RateLimit    int       `json:"rate_limit"`
+	UserID       uuid.UUID `json:"user_id"`
+	TokenVersion int64     `json:"token_version"`
+}
@@ -0,0 +1,163 @@
+package authz
+
+// Permission catalogue in resource:action format. Routes in each service
+// declare one of these and auth-service resolves them through role_permissions.
+const (
+	// auth-service
+	RoleRead             = "role:read"
+	RoleWrite            = "role:write"
+	RoleDelete           = "role:delete"
+	UserRoleAssign       = "user_role:assign"
+	SessionRead          = "session:read"
+	SessionRevoke        = "session:revoke"
+	MFAPolicyRead        = "mfa_policy:read"
+	MFAPolicyWrite       = "mfa_policy:write"
+	MFAReset             = "mfa:reset"
+	OIDCProviderRead     = "oidc_provider:read"
+	OIDCProviderWrite    = "oidc_provider:write"
+	PasswordPolicyRead   = "password_policy:read"
+	PasswordPolicyWrite  = "password_policy:write"
+	UserImport           = "user:import"
+	UserInvite           = "user:invite"
+	UserImpersonate      = "user:impersonate"
+	UserImpersonateWrite = "user:impersonate_write"
+	ServiceAccountRead   = "service_account:read"
+	ServiceAccountWrite  = "service_account:write"
+	APIKeyWrite          = "api_key:write"
+	UsageRead            = "usage:read"
+	TenantUsageRead      = "tenant_usage:read"
+	TenantPlanWrite      = "tenant_plan:write"
+	AuditLogRead         = "audit_log:read"
+
+	// academic-service
+	SchoolRead         = "school:read"
+	SchoolWrite        = "school:write"
+	SchoolDelete       = "school:delete"
+	AcademicYearRead   = "academic_year:read"
+	AcademicYearWrite  = "academic_year:write"
+	AcademicYearDelete = "academic_year:delete"
+	SemesterRead       = "semester:read"
+	SemesterWrite      = "semester:write"
+	SemesterDelete     = "semester:delete"
+	StudentRead        = "student:read"
+	StudentWrite       = "student:write"
+	StudentDelete      = "student:delete"
+	TeacherRead        = "teacher:read"
+	TeacherWrite       = "teacher:write"
+	TeacherDelete      = "teacher:delete"
+	ClassRead          = "class:read"
+	ClassWrite         = "class:write"
+	ClassDelete        = "class:delete"
+	ClassAssignTeacher = "class:assign_teacher"
+	SubjectRead        = "subject:read"
+	SubjectWrite       = "subject:write"
+	SubjectDelete      = "subject:delete"
+	ScheduleRead       = "schedule:read"
+	ScheduleWrite      = "schedule:write"
+	ScheduleDelete     = "schedule:delete"
+	CurriculumRead     = "curriculum:read"
+	CurriculumWrite    = "curriculum:write"
+	CurriculumDelete   = "curriculum:delete"
+	EnrollmentRead     = "enrollment:read"
+	EnrollmentWrite    = "enrollment:write"
+	EnrollmentDelete   = "enrollment:delete"
+	GuardianRead       = "guardian:read"
+	GuardianWrite      = "guardian:write"
+	GuardianDelete     = "guardian:delete"
+	GuardianChildRead  = "guardian_child:read"
+
+	// assessment-service
+	AssessmentWrite     = "assessment:write"
+	GradeRead           = "grade:read"
+	GradeWrite          = "grade:write"
+	GradeApprove        = "grade:approve"
+	GradeCategoryRead   = "grade_category:read"
+	GradeCategoryWrite  = "grade_category:write"
+	GradeCategoryDelete = "grade_category:delete"
+	ReportCardRead      = "report_card:read"
+	ReportCardGenerate  = "report_card:generate"
+	TemplateRead        = "template:read"
+	TemplateWrite       = "template:write"
+	TemplateDelete      = "template:delete"
+
+	// attendance-service
+	AttendanceRead    = "attendance:read"
+	AttendanceWrite   = "attendance:write"
+	AttendanceReport  = "attendance:report"
+	AttendanceCheckin = "attendance:checkin"
+
+	// admission-service
+	AdmissionPeriodRead   = "admission_period:read"
+	AdmissionPeriodWrite  = "admission_period:write"
+	AdmissionPeriodDelete = "admission_period:delete"
+	AdmissionAnnounce     = "admission_period:announce"
+	ApplicationRead       = "application:read"
+	ApplicationVerify     = "application:verify"
+	ApplicationScore      = "application:score"
+	ApplicationRegister   = "application:register"
+	ApplicationDocDelete  = "application_document:delete"
+
+	// finance-service
+	BillingConfigRead   = "billing_config:read"
+	BillingConfigWrite  = "billing_config:write"
+	BillingConfigDelete = "billing_config:delete"
+	InvoiceRead         = "invoice:read"
+	InvoiceGenerate     = "invoice:generate"
+	PaymentRead         = "payment:read"
+	PaymentWrite        = "payment:write"
+	FinanceReportRead   = "finance_report:read"
+
+	// notification-service
+	NotificationTemplateRead   = "notification_template:read"
+	NotificationTemplateWrite  = "notification_template:write"
+	NotificationTemplateDelete = "notification_template:delete"
+	NotificationRead           = "notification:read"
+	NotificationSend           = "notification:send"
+
+	// file-service
+	FileRead   = "file:read"
+	FileWrite  = "file:write"
+	FileDelete = "file:delete"
+)
+
+// Catalogue returns every permission declared above, used by auth-service to
+// seed the permissions table.
+func Catalogue() []string {
+	return []string{
+		RoleRead, RoleWrite, RoleDelete, UserRoleAssign,
+		SessionRead, SessionRevoke,
+		MFAPolicyRead, MFAPolicyWrite, MFAReset,
+		OIDCProviderRead, OIDCProviderWrite,
+		PasswordPolicyRead, PasswordPolicyWrite,
+		UserImport, UserInvite, UserImpersonate, UserImpersonateWrite,
+		ServiceAccountRead, ServiceAccountWrite, APIKeyWrite,
+		UsageRead, TenantUsageRead, TenantPlanWrite,
+		AuditLogRead,
+		SchoolRead, SchoolWrite, SchoolDelete,
+		AcademicYearRead, AcademicYearWrite, AcademicYearDelete,
+		SemesterRead, SemesterWrite, SemesterDelete,
+		StudentRead, StudentWrite, StudentDelete,
+		TeacherRead, TeacherWrite, TeacherDelete,
+		ClassRead, ClassWrite, ClassDelete, ClassAssignTeacher,
+		SubjectRead, SubjectWrite, SubjectDelete,
+		ScheduleRead, ScheduleWrite, ScheduleDelete,
+		CurriculumRead, CurriculumWrite, CurriculumDelete,
+		EnrollmentRead, EnrollmentWrite, EnrollmentDelete,
+		GuardianRead, GuardianWrite, GuardianDelete, GuardianChildRead,
+		AssessmentWrite,
+		GradeRead, GradeWrite, GradeApprove,
+		GradeCategoryRead, GradeCategoryWrite, GradeCategoryDelete,
+		ReportCardRead, ReportCardGenerate,
+		TemplateRead, TemplateWrite, TemplateDelete,
+		AttendanceRead, AttendanceWrite, AttendanceReport, AttendanceCheckin,
+		AdmissionPeriodRead, AdmissionPeriodWrite, AdmissionPeriodDelete, AdmissionAnnounce,
+		ApplicationRead, ApplicationVerify, ApplicationScore, ApplicationRegister, ApplicationDocDelete,
+		BillingConfigRead, BillingConfigWrite, BillingConfigDelete,
+		InvoiceRead, InvoiceGenerate,
+		PaymentRead, PaymentWrite,
+		FinanceReportRead,
+		NotificationTemplateRead, NotificationTemplateWrite, NotificationTemplateDelete,
+		NotificationRead, NotificationSend,
+		FileRead, FileWrite, FileDelete,
+	}
+}
@@ -0,0 +1,126 @@
+package authz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	CheckPath       = "/internal/v1/authz/check"
+	DefaultCacheTTL = time.Minute
+	cacheKeyPrefix  = "authz:"
+)
+
+type CheckRequest struct {
+	UserID     uuid.UUID `json:"user_id"`
+	TenantID   string    `json:"tenant_id"`
+	Permission string    `json:"permission"`
+}
+
+type CheckResponse struct {
+	Allowed bool `json:"allowed"`
+}
+
+// Client implements middleware.Authorizer by asking auth-service and caching
+// each decision in Redis for cacheTTL. Decisions are cached under the user's
+// token version, which auth-service bumps on every change to the user's roles
+// or to the permissions of a role they hold, so no stale decision is read
+// after a grant, revoke, role delete or unassignment.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	cache      redisutil.KV
+	cacheTTL   time.Duration
+}
+
+func NewClient(baseURL string, cache redisutil.KV, cacheTTL time.Duration, timeout time.Duration) *Client {
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultCacheTTL
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+		cache:      cache,
+		cacheTTL:   cacheTTL,
+	}
+}
+
+func CacheKey(tenantID string, userID uuid.UUID, tokenVersion, permission string) string {
+	return cacheKeyPrefix + tenantID + ":" + userID.String() + ":v" + tokenVersion + ":" + permission
+}
+
+func (c *Client) Allow(subjectID uuid.UUID, tenantID string, permission string) (bool, error) {
+	ctx := context.Background()
+	var key string
+	if c.cache != nil {
+		version, err := redisutil.Get(ctx, c.cache, middleware.TokenVersionKey(subjectID))
+		switch {
+		case err == nil:
+		case errors.Is(err, redis.Nil):
+			version = "0"
+		default:
+			// Without the version a cached decision cannot be trusted
+			return c.check(ctx, CheckRequest{UserID: subjectID, TenantID: tenantID, Permission: permission})
+		}
+		key = CacheKey(tenantID, subjectID, version, permission)
+		if v, err := redisutil.Get(ctx, c.cache, key); err == nil && v != "" {
+			return v == "1", nil
+		}
+	}
+	allowed, err := c.check(ctx, CheckRequest{UserID: subjectID, TenantID: tenantID, Permission: permission})
+	if err != nil {
+		return false, err
+	}
+	if c.cache != nil {
+		v := "0"
+		if allowed {
+			v = "1"
+		}
+		_ = redisutil.Set(ctx, c.cache, key, v, c.cacheTTL)
+	}
+	return allowed, nil
+}
+
+func (c *Client) check(ctx context.Context, in CheckRequest) (bool, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+CheckPath, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	var out struct {
+		Success bool          `json:"success"`
+		Data    CheckResponse `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !out.Success {
+		return false, fmt.Errorf("api returned failure")
+	}
+	return out.Data.Allowed, nil
+}
@@ -0,0 +1,134 @@
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	"github.com/redis/go-redis/v9"
+)
+
+type fakeKV struct {
+	store map[string]string
+}
+
+func (f *fakeKV) Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	if f.store == nil {
+		f.store = map[string]string{}
+	}
+	f.store[key] = value.(string)
+	return redis.NewStatusCmd(ctx)
+}
+
+func (f *fakeKV) Get(ctx context.Context, key string) *redis.StringCmd {
+	cmd := redis.NewStringCmd(ctx)
+	v, ok := f.store[key]
+	if !ok {
+		cmd.SetErr(redis.Nil)
+		return cmd
+	}
+	cmd.SetVal(v)
+	return cmd
+}
+
+func (f *fakeKV) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	for _, k := range keys {
+		delete(f.store, k)
+	}
+	return redis.NewIntCmd(ctx)
+}
+
+func TestClient_Allow_CachesDecision(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != CheckPath || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var in CheckRequest
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"success": true,
+			"data":    CheckResponse{Allowed: in.Permission == GradeApprove},
+		})
+	}))
+	defer ts.Close()
+	kv := &fakeKV{}
+	c := NewClient(ts.URL, kv, time.Minute, time.Second)
+	uid := uuid.New()
+	for i := 0; i < 2; i++ {
+		ok, err := c.Allow(uid, "t1", GradeApprove)
+		if err != nil || !ok {
+			t.Fatalf("expected allow, got ok=%v err=%v", ok, err)
+		}
+	}
+	ok, err := c.Allow(uid, "t1", SchoolDelete)
+	if err != nil || ok {
+		t.Fatalf("expected deny, got ok=%v err=%v", ok, err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 upstream calls, got %d", calls)
+	}
+	if kv.store[CacheKey("t1", uid, "0", SchoolDelete)] != "0" {
+		t.Fatalf("deny decision should be cached")
+	}
+}
+
+func TestClient_Allow_ForgetsDecisionsOnTokenVersionBump(t *testing.T) {
+	allowed := true
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"success": true,
+			"data":    CheckResponse{Allowed: allowed},
+		})
+	}))
+	defer ts.Close()
+	kv := &fakeKV{}
+	c := NewClient(ts.URL, kv, time.Minute, time.Second)
+	uid := uuid.New()
+	if ok, err := c.Allow(uid, "t1", GradeApprove); err != nil || !ok {
+		t.Fatalf("expected allow, got ok=%v err=%v", ok, err)
+	}
+	// auth-service revokes the permission and bumps the user's version
+	allowed = false
+	kv.Set(context.Background(), middleware.TokenVersionKey(uid), "1", 0)
+	if ok, err := c.Allow(uid, "t1", GradeApprove); err != nil || ok {
+		t.Fatalf("expected deny after revoke, got ok=%v err=%v", ok, err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 upstream calls, got %d", calls)
+	}
+}
+
+func TestClient_Allow_UpstreamError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+	kv := &fakeKV{}
+	c := NewClient(ts.URL, kv, time.Minute, time.Second)
+	if _, err := c.Allow(uuid.New(), "t1", GradeRead); err == nil {
+		t.Fatalf("expected error")
+	}
+	if len(kv.store) != 0 {
+		t.Fatalf("errors must not be cached")
+	}
+}
+
+func TestCatalogue_Unique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, p := range Catalogue() {
+		if seen[p] {
+			t.Fatalf("duplicate permission %s", p)
+		}
+		seen[p] = true
+	}
+}
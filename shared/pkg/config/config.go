@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -20,11 +22,20 @@ type Config struct {
 	JWTRefreshTTL       time.Duration
 	JWTIssuer           string
 	JWTAudience         string
+	EmbedPermissions    bool
+	JWTSigningAlg       string
+	JWTPrivateKeyPath   string
+	JWTPreviousKeys     []string
+	JWKSURL             string
 	CORSAllowedOrigins  []string
 	RateLimitPerMinute  int
 	LockoutThreshold    int
 	LockoutTTL          time.Duration
 	FailWindowTTL       time.Duration
+	MFAChallengeTTL     time.Duration
+	OIDCRedirectURL     string
+	OIDCStateTTL        time.Duration
+	InvitationTTL       time.Duration
 	AuditRetentionDays  int
 	SMTPHost            string
 	SMTPPort            int
@@ -35,7 +46,13 @@ type Config struct {
 	WhatsAppAPIURL      string
 	WhatsAppAPIKey      string
 	AcademicServiceURL  string
+	AuthServiceURL      string
 	JaegerEndpoint      string
+	GatewaySecret       string
+	TrustGateway        bool
+	GatewayRoutesFile   string
+	TrustedProxies      []netip.Prefix
+	OperatorTenantID    string
 }
 
 func Load() (Config, error) {
@@ -58,13 +75,23 @@ func Load() (Config, error) {
 	v.SetDefault("CORS_ALLOWED_ORIGINS", []string{"*"})
 	v.SetDefault("JWT_ISSUER", "sisfo-akademik")
 	v.SetDefault("JWT_AUDIENCE", "api")
+	v.SetDefault("JWT_SIGNING_ALG", "HS256")
 	v.SetDefault("LOCKOUT_THRESHOLD", 5)
 	v.SetDefault("LOCKOUT_TTL", "15m")
 	v.SetDefault("FAIL_WINDOW_TTL", "15m")
+	v.SetDefault("MFA_CHALLENGE_TTL", "5m")
+	v.SetDefault("OIDC_STATE_TTL", "10m")
+	v.SetDefault("INVITATION_TTL", "72h")
 	v.SetDefault("AUDIT_RETENTION_DAYS", 90)
 	v.SetDefault("SMTP_PORT", 587)
 	v.SetDefault("ACADEMIC_SERVICE_URL", "http://localhost:9092")
+	v.SetDefault("AUTH_SERVICE_URL", "http://localhost:9091")
 	v.SetDefault("JAEGER_ENDPOINT", "http://localhost:4318/v1/traces")
+	// Peers whose X-Forwarded-For is believed: the gateway and load balancers
+	// on the cluster network
+	// The tenant whose staff run the platform and may act across tenants
+	v.SetDefault("OPERATOR_TENANT_ID", "default")
+	v.SetDefault("TRUSTED_PROXIES", []string{"127.0.0.0/8", "::1/128", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"})
 
 	cfg := Config{
 		Env:                v.GetString("ENV"),
@@ -79,11 +106,20 @@ func Load() (Config, error) {
 		JWTRefreshTTL:      mustParseDuration(v.GetString("JWT_REFRESH_TTL")),
 		JWTIssuer:          v.GetString("JWT_ISSUER"),
 		JWTAudience:        v.GetString("JWT_AUDIENCE"),
+		EmbedPermissions:   v.GetBool("JWT_EMBED_PERMISSIONS"),
+		JWTSigningAlg:      v.GetString("JWT_SIGNING_ALG"),
+		JWTPrivateKeyPath:  v.GetString("JWT_PRIVATE_KEY_PATH"),
+		JWTPreviousKeys:    v.GetStringSlice("JWT_PREVIOUS_KEY_PATHS"),
+		JWKSURL:            v.GetString("JWKS_URL"),
 		CORSAllowedOrigins: v.GetStringSlice("CORS_ALLOWED_ORIGINS"),
 		RateLimitPerMinute: v.GetInt("RATE_LIMIT_PER_MINUTE"),
 		LockoutThreshold:   v.GetInt("LOCKOUT_THRESHOLD"),
 		LockoutTTL:         mustParseDuration(v.GetString("LOCKOUT_TTL")),
 		FailWindowTTL:      mustParseDuration(v.GetString("FAIL_WINDOW_TTL")),
+		MFAChallengeTTL:    mustParseDuration(v.GetString("MFA_CHALLENGE_TTL")),
+		OIDCRedirectURL:    v.GetString("OIDC_REDIRECT_URL"),
+		OIDCStateTTL:       mustParseDuration(v.GetString("OIDC_STATE_TTL")),
+		InvitationTTL:      mustParseDuration(v.GetString("INVITATION_TTL")),
 		AuditRetentionDays: v.GetInt("AUDIT_RETENTION_DAYS"),
 		SMTPHost:           v.GetString("SMTP_HOST"),
 		SMTPPort:           v.GetInt("SMTP_PORT"),
@@ -94,7 +130,23 @@ func Load() (Config, error) {
 		WhatsAppAPIURL:     v.GetString("WHATSAPP_API_URL"),
 		WhatsAppAPIKey:     v.GetString("WHATSAPP_API_KEY"),
 		AcademicServiceURL: v.GetString("ACADEMIC_SERVICE_URL"),
+		AuthServiceURL:     v.GetString("AUTH_SERVICE_URL"),
 		JaegerEndpoint:     v.GetString("JAEGER_ENDPOINT"),
+		GatewaySecret:      v.GetString("GATEWAY_SECRET"),
+		TrustGateway:       v.GetBool("TRUST_GATEWAY"),
+		GatewayRoutesFile:  v.GetString("GATEWAY_ROUTES_FILE"),
+		OperatorTenantID:   v.GetString("OPERATOR_TENANT_ID"),
+	}
+	proxies, err := parsePrefixes(v.GetStringSlice("TRUSTED_PROXIES"))
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.TrustedProxies = proxies
+	if cfg.JWKSURL == "" {
+		cfg.JWKSURL = strings.TrimRight(cfg.AuthServiceURL, "/") + "/.well-known/jwks.json"
+	}
+	if cfg.OIDCRedirectURL == "" {
+		cfg.OIDCRedirectURL = strings.TrimRight(cfg.AuthServiceURL, "/") + "/api/v1/auth/oidc/callback"
 	}
 	if err := cfg.Validate(); err != nil {
 		return Config{}, err
@@ -110,15 +162,49 @@ func mustParseDuration(s string) time.Duration {
 	return d
 }
 
+// parsePrefixes reads CIDRs or single addresses, separated by commas or
+// spaces.
+func parsePrefixes(in []string) ([]netip.Prefix, error) {
+	var out []netip.Prefix
+	for _, s := range strings.Split(strings.Join(in, ","), ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "/") {
+			addr, err := netip.ParseAddr(s)
+			if err != nil {
+				return nil, fmt.Errorf("trusted proxy %q: %w", s, err)
+			}
+			out = append(out, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q: %w", s, err)
+		}
+		out = append(out, p.Masked())
+	}
+	return out, nil
+}
+
 func (c Config) Validate() error {
 	if c.JWTAccessSecret == "" || c.JWTRefreshSecret == "" {
 		return fmt.Errorf("jwt secrets required")
 	}
+	switch c.JWTSigningAlg {
+	case "", "HS256", "RS256", "EdDSA":
+	default:
+		return fmt.Errorf("unsupported jwt signing alg %q", c.JWTSigningAlg)
+	}
 	if c.JWTIssuer == "" || c.JWTAudience == "" {
 		return fmt.Errorf("jwt issuer/audience required")
 	}
 	if c.PostgresURL == "" || c.RedisAddr == "" || c.RabbitURL == "" {
 		return fmt.Errorf("infrastructure urls required")
 	}
+	if c.TrustGateway && c.GatewaySecret == "" {
+		return fmt.Errorf("gateway secret required to trust gateway identity")
+	}
 	return nil
 }
@@ -36,6 +36,22 @@ func TestLoad(t *testing.T) {
 	if cfg.ServiceName == "" || cfg.HTTPPort == 0 {
 		t.Fatalf("defaults not applied")
 	}
+	if len(cfg.TrustedProxies) == 0 {
+		t.Fatalf("trusted proxies default not applied")
+	}
+	if cfg.OperatorTenantID != "default" {
+		t.Fatalf("operator tenant default not applied: %q", cfg.OperatorTenantID)
+	}
+}
+
+func TestParsePrefixes(t *testing.T) {
+	got, err := parsePrefixes([]string{"10.0.0.0/8, 192.168.1.7", "fd00::/8"})
+	if err != nil || len(got) != 3 || got[1].String() != "192.168.1.7/32" {
+		t.Fatalf("got %v err=%v", got, err)
+	}
+	if _, err := parsePrefixes([]string{"10.0.0.0/33"}); err == nil {
+		t.Fatal("expected error for invalid prefix")
+	}
 }
 
 func TestLoadMissingSecrets(t *testing.T) {
@@ -72,3 +88,23 @@ func TestValidateMissingInfra(t *testing.T) {
 		t.Fatalf("expected infra validation error")
 	}
 }
+
+func TestValidateTrustGatewayNeedsSecret(t *testing.T) {
+	cfg := Config{
+		JWTAccessSecret:  "a",
+		JWTRefreshSecret: "b",
+		JWTIssuer:        "i",
+		JWTAudience:      "a",
+		PostgresURL:      "postgres://u:p@host/db",
+		RedisAddr:        "localhost:6379",
+		RabbitURL:        "amqp://u:p@host/",
+		TrustGateway:     true,
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected gateway secret validation error")
+	}
+	cfg.GatewaySecret = "s"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package guardian
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+)
+
+const (
+	CheckPath       = "/internal/v1/guardians/check"
+	DefaultCacheTTL = time.Minute
+	cacheKeyPrefix  = "guardian:"
+)
+
+type CheckRequest struct {
+	UserID    uuid.UUID `json:"user_id"`
+	TenantID  string    `json:"tenant_id"`
+	StudentID uuid.UUID `json:"student_id"`
+}
+
+type CheckResponse struct {
+	Allowed bool `json:"allowed"`
+}
+
+// Client implements middleware.GuardianChecker by asking academic-service
+// whether a user is linked to a student, caching each answer in Redis.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	cache      redisutil.KV
+	cacheTTL   time.Duration
+}
+
+func NewClient(baseURL string, cache redisutil.KV, cacheTTL time.Duration, timeout time.Duration) *Client {
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultCacheTTL
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+		cache:      cache,
+		cacheTTL:   cacheTTL,
+	}
+}
+
+func CacheKey(tenantID string, userID, studentID uuid.UUID) string {
+	return cacheKeyPrefix + tenantID + ":" + userID.String() + ":" + studentID.String()
+}
+
+func (c *Client) IsGuardianOf(userID uuid.UUID, tenantID string, studentID uuid.UUID) (bool, error) {
+	ctx := context.Background()
+	key := CacheKey(tenantID, userID, studentID)
+	if c.cache != nil {
+		if v, err := redisutil.Get(ctx, c.cache, key); err == nil && v != "" {
+			return v == "1", nil
+		}
+	}
+	allowed, err := c.check(ctx, CheckRequest{UserID: userID, TenantID: tenantID, StudentID: studentID})
+	if err != nil {
+		return false, err
+	}
+	if c.cache != nil {
+		v := "0"
+		if allowed {
+			v = "1"
+		}
+		_ = redisutil.Set(ctx, c.cache, key, v, c.cacheTTL)
+	}
+	return allowed, nil
+}
+
+func (c *Client) check(ctx context.Context, in CheckRequest) (bool, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+CheckPath, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	var out struct {
+		Success bool          `json:"success"`
+		Data    CheckResponse `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !out.Success {
+		return false, fmt.Errorf("api returned failure")
+	}
+	return out.Data.Allowed, nil
+}
@@ -0,0 +1,97 @@
+package guardian
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+type fakeKV struct {
+	store map[string]string
+}
+
+func (f *fakeKV) Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	if f.store == nil {
+		f.store = map[string]string{}
+	}
+	f.store[key] = value.(string)
+	return redis.NewStatusCmd(ctx)
+}
+
+func (f *fakeKV) Get(ctx context.Context, key string) *redis.StringCmd {
+	cmd := redis.NewStringCmd(ctx)
+	v, ok := f.store[key]
+	if !ok {
+		cmd.SetErr(redis.Nil)
+		return cmd
+	}
+	cmd.SetVal(v)
+	return cmd
+}
+
+func (f *fakeKV) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	for _, k := range keys {
+		delete(f.store, k)
+	}
+	return redis.NewIntCmd(ctx)
+}
+
+func TestClient_IsGuardianOf_CachesAnswer(t *testing.T) {
+	child := uuid.New()
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != CheckPath || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var in CheckRequest
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"success": true,
+			"data":    CheckResponse{Allowed: in.StudentID == child},
+		})
+	}))
+	defer ts.Close()
+	kv := &fakeKV{}
+	c := NewClient(ts.URL, kv, time.Minute, time.Second)
+	uid := uuid.New()
+	for i := 0; i < 2; i++ {
+		ok, err := c.IsGuardianOf(uid, "t1", child)
+		if err != nil || !ok {
+			t.Fatalf("expected guardian, got ok=%v err=%v", ok, err)
+		}
+	}
+	other := uuid.New()
+	ok, err := c.IsGuardianOf(uid, "t1", other)
+	if err != nil || ok {
+		t.Fatalf("expected not guardian, got ok=%v err=%v", ok, err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 upstream calls, got %d", calls)
+	}
+	if kv.store[CacheKey("t1", uid, other)] != "0" {
+		t.Fatalf("negative answer should be cached")
+	}
+}
+
+func TestClient_IsGuardianOf_UpstreamError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+	kv := &fakeKV{}
+	c := NewClient(ts.URL, kv, time.Minute, time.Second)
+	if _, err := c.IsGuardianOf(uuid.New(), "t1", uuid.New()); err == nil {
+		t.Fatalf("expected error")
+	}
+	if len(kv.store) != 0 {
+		t.Fatalf("errors must not be cached")
+	}
+}
@@ -0,0 +1,54 @@
+package identity
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+)
+
+// Gin is the gin counterpart of Trust for services deployed behind the
+// gateway. Like the services' own Auth it also enforces the token version and
+// read-only impersonation, then exposes the claims both on the gin context
+// ("claims") and on the request context (middleware.ClaimsKey).
+func Gin(secret []byte, kv redisutil.KV) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		passed := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			passed = true
+			c.Request = r
+			if val := r.Context().Value(middleware.ClaimsKey); val != nil {
+				c.Set("claims", val)
+			}
+			c.Next()
+		})
+		Trust(secret, middleware.RequireTokenVersion(kv, middleware.RestrictImpersonation(next))).ServeHTTP(c.Writer, c.Request)
+		if !passed {
+			c.Abort()
+		}
+	}
+}
+
+// Claims returns the verified caller of the request, whichever middleware
+// authenticated it.
+func Claims(c *gin.Context) (jwtutil.Claims, bool) {
+	if v, ok := c.Get("claims"); ok {
+		if claims, ok := v.(jwtutil.Claims); ok {
+			return claims, true
+		}
+	}
+	claims, ok := c.Request.Context().Value(middleware.ClaimsKey).(jwtutil.Claims)
+	return claims, ok
+}
+
+// TenantID returns the caller's tenant, or "" for unauthenticated requests.
+// Handlers must use it instead of a tenant_id supplied by the client.
+func TenantID(c *gin.Context) string {
+	claims, ok := Claims(c)
+	if !ok {
+		return ""
+	}
+	return claims.TenantID
+}
@@ -0,0 +1,189 @@
+// Package identity carries the caller's identity from the API gateway to the
+// services in signed headers, so services behind the gateway can trust it
+// without validating the bearer token again.
+package identity
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+)
+
+const (
+	HeaderUserID       = "X-User-ID"
+	HeaderTenantID     = "X-Tenant-ID"
+	HeaderRoles        = "X-Roles"
+	HeaderScopes       = "X-Scopes"
+	HeaderActorID      = "X-Actor-ID"
+	HeaderActorWrite   = "X-Actor-Allow-Write"
+	HeaderTokenVersion = "X-Token-Version"
+	HeaderTimestamp    = "X-Identity-Timestamp"
+	HeaderSignature    = "X-Identity-Signature"
+
+	// MaxSkew is how old a signature may be, covering clock drift between the
+	// gateway and the services plus time spent in the proxy.
+	MaxSkew = time.Minute
+)
+
+// Headers lists every header the gateway owns. Client-supplied values are
+// always removed before the request is forwarded.
+var Headers = []string{
+	HeaderUserID, HeaderTenantID, HeaderRoles, HeaderScopes, HeaderActorID,
+	HeaderActorWrite, HeaderTokenVersion, HeaderTimestamp, HeaderSignature,
+}
+
+var (
+	ErrMissing   = errors.New("identity headers missing")
+	ErrSignature = errors.New("identity signature invalid")
+	ErrExpired   = errors.New("identity signature expired")
+)
+
+// Strip removes identity headers from h.
+func Strip(h http.Header) {
+	for _, k := range Headers {
+		h.Del(k)
+	}
+}
+
+// Sign replaces the identity headers of r with c and signs them together with
+// the method and path, so a captured set cannot be replayed on another route.
+func Sign(r *http.Request, secret []byte, c jwtutil.Claims, now time.Time) {
+	Strip(r.Header)
+	h := r.Header
+	h.Set(HeaderUserID, c.UserID.String())
+	h.Set(HeaderTenantID, c.TenantID)
+	h.Set(HeaderRoles, joinList(c.Roles))
+	h.Set(HeaderTokenVersion, strconv.FormatInt(c.TokenVersion, 10))
+	if len(c.Scopes) > 0 {
+		h.Set(HeaderScopes, joinList(c.Scopes))
+	}
+	if c.Actor != nil {
+		h.Set(HeaderActorID, c.Actor.UserID.String())
+		if c.Actor.AllowWrite {
+			h.Set(HeaderActorWrite, "true")
+		}
+	}
+	h.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	h.Set(HeaderSignature, signature(secret, r))
+}
+
+// Verify checks the signed identity headers of r and returns the claims they
+// carry.
+func Verify(r *http.Request, secret []byte, now time.Time) (jwtutil.Claims, error) {
+	var c jwtutil.Claims
+	h := r.Header
+	if h.Get(HeaderSignature) == "" || h.Get(HeaderUserID) == "" || h.Get(HeaderTenantID) == "" {
+		return c, ErrMissing
+	}
+	if !hmac.Equal([]byte(signature(secret, r)), []byte(h.Get(HeaderSignature))) {
+		return c, ErrSignature
+	}
+	ts, err := strconv.ParseInt(h.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return c, ErrSignature
+	}
+	if d := now.Sub(time.Unix(ts, 0)); d > MaxSkew || d < -MaxSkew {
+		return c, ErrExpired
+	}
+	if c.UserID, err = uuid.Parse(h.Get(HeaderUserID)); err != nil {
+		return c, ErrSignature
+	}
+	c.TenantID = h.Get(HeaderTenantID)
+	c.Roles = splitList(h.Get(HeaderRoles))
+	c.Scopes = splitList(h.Get(HeaderScopes))
+	if c.TokenVersion, err = strconv.ParseInt(h.Get(HeaderTokenVersion), 10, 64); err != nil {
+		return c, ErrSignature
+	}
+	if v := h.Get(HeaderActorID); v != "" {
+		actor, err := uuid.Parse(v)
+		if err != nil {
+			return c, ErrSignature
+		}
+		c.Actor = &jwtutil.Actor{UserID: actor, AllowWrite: h.Get(HeaderActorWrite) == "true"}
+	}
+	return c, nil
+}
+
+// Inject strips client-supplied identity headers and, when the request was
+// authenticated and secret is set, signs the caller's claims onto it. It
+// must run after Auth/AuthWith, right before the request is proxied.
+func Inject(secret []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.Clone(r.Context())
+		Strip(r.Header)
+		if claims, ok := r.Context().Value(middleware.ClaimsKey).(jwtutil.Claims); ok && len(secret) > 0 {
+			Sign(r, secret, claims, time.Now())
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Trust verifies the gateway's identity headers and exposes the claims under
+// middleware.ClaimsKey, taking the place of Auth/AuthWith behind the gateway.
+func Trust(secret []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := Verify(r, secret, time.Now())
+		if err != nil {
+			httputil.Error(w, http.StatusUnauthorized, "2001", "Unauthorized", err.Error())
+			return
+		}
+		ctx := context.WithValue(r.Context(), middleware.ClaimsKey, claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func signature(secret []byte, r *http.Request) string {
+	h := r.Header
+	mac := hmac.New(sha256.New, secret)
+	for _, f := range []string{
+		r.Method,
+		r.URL.Path,
+		h.Get(HeaderTimestamp),
+		h.Get(HeaderUserID),
+		h.Get(HeaderTenantID),
+		h.Get(HeaderRoles),
+		h.Get(HeaderScopes),
+		h.Get(HeaderActorID),
+		h.Get(HeaderActorWrite),
+		h.Get(HeaderTokenVersion),
+	} {
+		mac.Write([]byte(f))
+		mac.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// joinList escapes each item so role names containing commas survive.
+func joinList(items []string) string {
+	out := make([]string, len(items))
+	for i, s := range items {
+		out[i] = url.QueryEscape(s)
+	}
+	return strings.Join(out, ",")
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if v, err := url.QueryUnescape(p); err == nil && v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/middleware"
+)
+
+var secret = []byte("gateway-secret")
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	now := time.Now()
+	in := jwtutil.Claims{
+		UserID:       uuid.New(),
+		TenantID:     "t1",
+		Roles:        []string{"admin", "odd,role"},
+		Scopes:       []string{"student:read"},
+		TokenVersion: 3,
+		Actor:        &jwtutil.Actor{UserID: uuid.New()},
+	}
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/subjects?tenant_id=t2", nil)
+	Sign(r, secret, in, now)
+
+	got, err := Verify(r, secret, now.Add(10*time.Second))
+	if err != nil {
+		t.Fatalf("verify err: %v", err)
+	}
+	if got.UserID != in.UserID || got.TenantID != "t1" || got.TokenVersion != 3 ||
+		!reflect.DeepEqual(got.Roles, in.Roles) || !reflect.DeepEqual(got.Scopes, in.Scopes) ||
+		got.Actor == nil || got.Actor.UserID != in.Actor.UserID || got.Actor.AllowWrite {
+		t.Fatalf("claims not preserved: %+v", got)
+	}
+
+	if _, err := Verify(r, []byte("other"), now); !errors.Is(err, ErrSignature) {
+		t.Fatalf("wrong secret: %v", err)
+	}
+	if _, err := Verify(r, secret, now.Add(2*MaxSkew)); !errors.Is(err, ErrExpired) {
+		t.Fatalf("stale signature: %v", err)
+	}
+	tampered := r.Clone(context.Background())
+	tampered.Header.Set(HeaderTenantID, "t2")
+	if _, err := Verify(tampered, secret, now); !errors.Is(err, ErrSignature) {
+		t.Fatalf("tampered tenant: %v", err)
+	}
+	moved := r.Clone(context.Background())
+	moved.Method = http.MethodDelete
+	if _, err := Verify(moved, secret, now); !errors.Is(err, ErrSignature) {
+		t.Fatalf("replayed on another method: %v", err)
+	}
+	if _, err := Verify(httptest.NewRequest(http.MethodGet, "/", nil), secret, now); !errors.Is(err, ErrMissing) {
+		t.Fatalf("unsigned request: %v", err)
+	}
+}
+
+func TestInjectStripsSpoofedHeaders(t *testing.T) {
+	var seen http.Header
+	h := Inject(secret, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { seen = r.Header }))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/subjects", nil)
+	req.Header.Set(HeaderTenantID, "spoofed")
+	req.Header.Set(HeaderSignature, "spoofed")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	for _, k := range Headers {
+		if seen.Get(k) != "" {
+			t.Fatalf("anonymous request kept %s", k)
+		}
+	}
+
+	claims := jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"}
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/subjects", nil)
+	req.Header.Set(HeaderTenantID, "spoofed")
+	req = req.WithContext(context.WithValue(req.Context(), middleware.ClaimsKey, claims))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if seen.Get(HeaderTenantID) != "t1" || seen.Get(HeaderSignature) == "" {
+		t.Fatalf("identity not injected: %v", seen)
+	}
+}
+
+func TestGin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Gin(secret, nil))
+	r.Any("/items", func(c *gin.Context) { c.String(http.StatusOK, TenantID(c)) })
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items", nil))
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("unsigned code=%d", rr.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/items?tenant_id=t2", nil)
+	Sign(req, secret, jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"}, time.Now())
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK || rr.Body.String() != "t1" {
+		t.Fatalf("code=%d body=%s", rr.Code, rr.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/items", nil)
+	Sign(req, secret, jwtutil.Claims{UserID: uuid.New(), TenantID: "t1", Actor: &jwtutil.Actor{UserID: uuid.New()}}, time.Now())
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("read-only impersonation write code=%d", rr.Code)
+	}
+}
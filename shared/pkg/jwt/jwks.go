@@ -0,0 +1,113 @@
+package jwtutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const DefaultJWKSCacheTTL = 5 * time.Minute
+
+// RemoteKeySet fetches a JWKS over HTTP and caches it for ttl. An unknown kid
+// triggers an early refresh so newly rotated keys are picked up immediately,
+// but refreshes are throttled to minRefresh to bound load on the issuer.
+// At most one fetch is in flight; it runs outside the lock, so lookups of
+// cached keys are never held up by a slow issuer.
+type RemoteKeySet struct {
+	url        string
+	client     *http.Client
+	ttl        time.Duration
+	minRefresh time.Duration
+
+	mu         sync.Mutex
+	keys       StaticKeySet
+	fetched    time.Time
+	attempted  time.Time
+	refreshing chan struct{}
+	fetchErr   error
+}
+
+func NewRemoteKeySet(url string, ttl, timeout time.Duration) *RemoteKeySet {
+	return &RemoteKeySet{
+		url:        url,
+		client:     &http.Client{Timeout: timeout},
+		ttl:        ttl,
+		minRefresh: 10 * time.Second,
+	}
+}
+
+func (s *RemoteKeySet) Lookup(kid string) (VerifyKey, error) {
+	s.mu.Lock()
+	k, ok := s.keys[kid]
+	if ok && time.Since(s.fetched) < s.ttl {
+		s.mu.Unlock()
+		return k, nil
+	}
+	done := s.refreshLocked()
+	s.mu.Unlock()
+	// Keep serving a cached key while the refresh runs or the issuer is
+	// unreachable; only a kid we have never seen waits for the fetch.
+	if ok {
+		return k, nil
+	}
+	if done == nil {
+		return VerifyKey{}, ErrUnknownKey
+	}
+	<-done
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if k, ok := s.keys[kid]; ok {
+		return k, nil
+	}
+	if s.fetchErr != nil {
+		return VerifyKey{}, s.fetchErr
+	}
+	return VerifyKey{}, ErrUnknownKey
+}
+
+// refreshLocked starts a fetch unless one is already running or the last
+// attempt was under minRefresh ago, and returns a channel closed when the
+// running fetch finishes, or nil when there is none. s.mu must be held.
+func (s *RemoteKeySet) refreshLocked() chan struct{} {
+	if s.refreshing != nil {
+		return s.refreshing
+	}
+	if time.Since(s.attempted) < s.minRefresh {
+		return nil
+	}
+	s.attempted = time.Now()
+	done := make(chan struct{})
+	s.refreshing = done
+	go func() {
+		keys, err := s.fetch()
+		s.mu.Lock()
+		if err == nil {
+			s.keys = keys
+			s.fetched = time.Now()
+		}
+		s.fetchErr = err
+		s.refreshing = nil
+		s.mu.Unlock()
+		close(done)
+	}()
+	return done
+}
+
+func (s *RemoteKeySet) fetch() (StaticKeySet, error) {
+	res, err := s.client.Get(s.url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks fetch: unexpected status %d", res.StatusCode)
+	}
+	var set JWKS
+	if err := json.NewDecoder(res.Body).Decode(&set); err != nil {
+		return nil, err
+	}
+	return set.KeySet(), nil
+}
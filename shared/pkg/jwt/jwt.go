@@ -1,6 +1,7 @@
 package jwtutil
 
 import (
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -8,9 +9,53 @@ import (
 )
 
 type Claims struct {
-	UserID   uuid.UUID `json:"user_id"`
-	TenantID string    `json:"tenant_id"`
-	Roles    []string  `json:"roles"`
+	UserID       uuid.UUID `json:"user_id"`
+	TenantID     string    `json:"tenant_id"`
+	Roles        []string  `json:"roles"`
+	Permissions  []string  `json:"permissions,omitempty"`
+	TokenVersion int64     `json:"token_version"`
+	SessionID    string    `json:"sid,omitempty"`
+	Actor        *Actor    `json:"act,omitempty"`
+	Scopes       []string  `json:"scp,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Actor is the user acting on behalf of the token subject while
+// impersonating them, as in the RFC 8693 "act" claim. Impersonated tokens are
+// read-only unless AllowWrite is set.
+type Actor struct {
+	UserID     uuid.UUID `json:"sub"`
+	AllowWrite bool      `json:"allow_write,omitempty"`
+}
+
+// Impersonated reports whether the token was issued to someone other than
+// its subject.
+func (c Claims) Impersonated() bool {
+	return c.Actor != nil
+}
+
+// AllowsScope reports whether the token's scopes cover permission. Tokens
+// without scopes, which is every user token, are limited by roles alone;
+// API key tokens carry the scopes of their key, either exact permissions or
+// "resource:*".
+func (c Claims) AllowsScope(permission string) bool {
+	if len(c.Scopes) == 0 {
+		return true
+	}
+	for _, s := range c.Scopes {
+		if s == permission {
+			return true
+		}
+		if resource, ok := strings.CutSuffix(s, ":*"); ok && strings.HasPrefix(permission, resource+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// RefreshClaims ties a refresh token to its session (token family).
+type RefreshClaims struct {
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -66,6 +111,24 @@ func GenerateRefreshWith(secret string, ttl time.Duration, subject string, issue
 	return token.SignedString([]byte(secret))
 }
 
+func GenerateRefreshSession(secret string, ttl time.Duration, subject, sessionID, jti string, issuer string, audience string) (string, error) {
+	now := time.Now()
+	rc := RefreshClaims{
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    issuer,
+			Audience:  jwt.ClaimStrings{audience},
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, rc)
+	return token.SignedString([]byte(secret))
+}
+
 func Validate(secret string, tokenString string, out *Claims) error {
 	tkn, err := jwt.ParseWithClaims(tokenString, out, func(token *jwt.Token) (any, error) {
 		return []byte(secret), nil
@@ -89,6 +152,33 @@ func ValidateWith(secret string, tokenString string, out *Claims, issuer string,
 	if !tkn.Valid {
 		return jwt.ErrTokenInvalidClaims
 	}
+	return checkIssuerAudience(out, issuer, audience)
+}
+
+// ValidateWithKeySet validates a token signed by any key in ks. The token's
+// alg header must match the algorithm of the key its kid resolves to.
+func ValidateWithKeySet(ks KeySet, tokenString string, out *Claims, issuer string, audience string) error {
+	tkn, err := jwt.ParseWithClaims(tokenString, out, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		k, err := ks.Lookup(kid)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != k.Alg {
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+		return k.Key, nil
+	}, jwt.WithValidMethods([]string{AlgHS256, AlgRS256, AlgEdDSA}))
+	if err != nil {
+		return err
+	}
+	if !tkn.Valid {
+		return jwt.ErrTokenInvalidClaims
+	}
+	return checkIssuerAudience(out, issuer, audience)
+}
+
+func checkIssuerAudience(out *Claims, issuer string, audience string) error {
 	if out.Issuer != "" && out.Issuer != issuer {
 		return jwt.ErrTokenInvalidClaims
 	}
@@ -24,6 +24,46 @@ func TestGenerateAndValidate(t *testing.T) {
 	}
 }
 
+func TestActorClaimRoundTrip(t *testing.T) {
+	actor := uuid.New()
+	c := Claims{UserID: uuid.New(), TenantID: "t1", Actor: &Actor{UserID: actor}}
+	s, err := GenerateAccess("secret", time.Minute, c)
+	if err != nil {
+		t.Fatalf("GenerateAccess failed: %v", err)
+	}
+	var out Claims
+	if err := Validate("secret", s, &out); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if !out.Impersonated() || out.Actor.UserID != actor || out.Actor.AllowWrite {
+		t.Fatalf("actor claim lost: %+v", out.Actor)
+	}
+	var plain Claims
+	s, _ = GenerateAccess("secret", time.Minute, Claims{UserID: uuid.New()})
+	if err := Validate("secret", s, &plain); err != nil || plain.Impersonated() {
+		t.Fatalf("ordinary tokens must not carry an actor")
+	}
+}
+
+func TestAllowsScope(t *testing.T) {
+	if !(Claims{}).AllowsScope("grade:write") {
+		t.Fatalf("unscoped tokens must not be narrowed")
+	}
+	c := Claims{Scopes: []string{"student:read", "invoice:*"}}
+	cases := map[string]bool{
+		"student:read":     true,
+		"student:write":    false,
+		"invoice:read":     true,
+		"invoice:generate": true,
+		"invoice_x:read":   false,
+	}
+	for perm, want := range cases {
+		if got := c.AllowsScope(perm); got != want {
+			t.Fatalf("%s: want %v got %v", perm, want, got)
+		}
+	}
+}
+
 func TestValidateWrongSecret(t *testing.T) {
 	secret := "s1"
 	c := Claims{UserID: uuid.New(), TenantID: "t1"}
@@ -79,6 +119,20 @@ func TestGenerateRefreshAndValidate(t *testing.T) {
 	}
 }
 
+func TestGenerateRefreshSession(t *testing.T) {
+	s, err := GenerateRefreshSession("s", time.Minute, "user-123", "sid-1", "jti-1", "iss", "aud")
+	if err != nil {
+		t.Fatalf("GenerateRefreshSession failed: %v", err)
+	}
+	var out RefreshClaims
+	if _, err := jwt.ParseWithClaims(s, &out, func(*jwt.Token) (any, error) { return []byte("s"), nil }); err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if out.SessionID != "sid-1" || out.ID != "jti-1" || out.Subject != "user-123" {
+		t.Fatalf("claims mismatch: %+v", out)
+	}
+}
+
 func TestMalformedToken(t *testing.T) {
 	var out Claims
 	if err := Validate("s", "not-a-jwt", &out); err == nil {
@@ -0,0 +1,360 @@
+package jwtutil
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+
+	// JWKSPath is where auth-service publishes its verification keys.
+	JWKSPath = "/.well-known/jwks.json"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// VerifyKey is a key that may verify tokens carrying the matching kid header.
+type VerifyKey struct {
+	ID  string
+	Alg string
+	Key any
+}
+
+// KeySet resolves the key a token was signed with from its kid header.
+type KeySet interface {
+	Lookup(kid string) (VerifyKey, error)
+}
+
+// StaticKeySet is a fixed set of keys indexed by kid.
+type StaticKeySet map[string]VerifyKey
+
+func (s StaticKeySet) Lookup(kid string) (VerifyKey, error) {
+	k, ok := s[kid]
+	if !ok {
+		return VerifyKey{}, ErrUnknownKey
+	}
+	return k, nil
+}
+
+type secretKeySet []byte
+
+func (s secretKeySet) Lookup(string) (VerifyKey, error) {
+	return VerifyKey{Alg: AlgHS256, Key: []byte(s)}, nil
+}
+
+// NewSecretKeySet verifies HS256 tokens with a shared secret, ignoring kid.
+func NewSecretKeySet(secret string) KeySet {
+	return secretKeySet(secret)
+}
+
+// NewKeySet returns the key set a service should validate access tokens with:
+// the shared secret for HS256, otherwise the JWKS published by auth-service.
+func NewKeySet(alg, secret, jwksURL string) KeySet {
+	if alg == "" || alg == AlgHS256 {
+		return NewSecretKeySet(secret)
+	}
+	return NewRemoteKeySet(jwksURL, DefaultJWKSCacheTTL, 5*time.Second)
+}
+
+// SigningKey is the private half of an asymmetric key pair.
+type SigningKey struct {
+	ID  string
+	Alg string
+	Key crypto.Signer
+}
+
+func (k *SigningKey) Verify() VerifyKey {
+	return VerifyKey{ID: k.ID, Alg: k.Alg, Key: k.Key.Public()}
+}
+
+// KeyRing signs with its current key and verifies with the current key plus
+// any previous keys kept for the rotation overlap window.
+type KeyRing struct {
+	current *SigningKey
+	keys    StaticKeySet
+}
+
+func NewKeyRing(current *SigningKey, previous ...VerifyKey) *KeyRing {
+	keys := StaticKeySet{current.ID: current.Verify()}
+	for _, k := range previous {
+		if _, ok := keys[k.ID]; !ok {
+			keys[k.ID] = k
+		}
+	}
+	return &KeyRing{current: current, keys: keys}
+}
+
+// LoadKeyRing reads the current private key and the previous keys (private or
+// public PEM) from disk. Key IDs are RFC 7638 thumbprints.
+func LoadKeyRing(privateKeyPath string, previousKeyPaths []string) (*KeyRing, error) {
+	b, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	current, err := ParsePrivateKeyPEM(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", privateKeyPath, err)
+	}
+	previous := make([]VerifyKey, 0, len(previousKeyPaths))
+	for _, p := range previousKeyPaths {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		k, err := ParsePublicKeyPEM(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		previous = append(previous, k)
+	}
+	return NewKeyRing(current, previous...), nil
+}
+
+func (r *KeyRing) Lookup(kid string) (VerifyKey, error) {
+	return r.keys.Lookup(kid)
+}
+
+func (r *KeyRing) Alg() string {
+	return r.current.Alg
+}
+
+func (r *KeyRing) JWKS() JWKS {
+	out := JWKS{Keys: make([]JWK, 0, len(r.keys))}
+	// Current key first so clients that only take the first key still work.
+	if j, err := NewJWK(r.current.Verify()); err == nil {
+		out.Keys = append(out.Keys, j)
+	}
+	for kid, k := range r.keys {
+		if kid == r.current.ID {
+			continue
+		}
+		if j, err := NewJWK(k); err == nil {
+			out.Keys = append(out.Keys, j)
+		}
+	}
+	return out
+}
+
+func (r *KeyRing) GenerateAccessWith(ttl time.Duration, c Claims, issuer string, audience string) (string, error) {
+	now := time.Now()
+	c.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		Issuer:    issuer,
+		Audience:  jwt.ClaimStrings{audience},
+	}
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(r.current.Alg), c)
+	token.Header["kid"] = r.current.ID
+	return token.SignedString(r.current.Key)
+}
+
+func ParsePrivateKeyPEM(b []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var sk *SigningKey
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		sk = &SigningKey{Alg: AlgRS256, Key: k}
+	case ed25519.PrivateKey:
+		sk = &SigningKey{Alg: AlgEdDSA, Key: k}
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	vk, err := newVerifyKey(sk.Key.Public())
+	if err != nil {
+		return nil, err
+	}
+	sk.ID = vk.ID
+	return sk, nil
+}
+
+// ParsePublicKeyPEM accepts a public key or a private key, returning only the
+// public half.
+func ParsePublicKeyPEM(b []byte) (VerifyKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return VerifyKey{}, errors.New("no PEM block found")
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return VerifyKey{}, err
+		}
+		return newVerifyKey(pub)
+	case "RSA PUBLIC KEY":
+		pub, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return VerifyKey{}, err
+		}
+		return newVerifyKey(pub)
+	}
+	sk, err := ParsePrivateKeyPEM(b)
+	if err != nil {
+		return VerifyKey{}, err
+	}
+	return sk.Verify(), nil
+}
+
+func newVerifyKey(pub crypto.PublicKey) (VerifyKey, error) {
+	var vk VerifyKey
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < 2048 {
+			return VerifyKey{}, errors.New("rsa keys must be at least 2048 bits")
+		}
+		vk = VerifyKey{Alg: AlgRS256, Key: k}
+	case ed25519.PublicKey:
+		vk = VerifyKey{Alg: AlgEdDSA, Key: k}
+	default:
+		return VerifyKey{}, fmt.Errorf("unsupported public key type %T", pub)
+	}
+	j, err := NewJWK(vk)
+	if err != nil {
+		return VerifyKey{}, err
+	}
+	vk.ID = j.Thumbprint()
+	return vk, nil
+}
+
+// JWK is the subset of RFC 7517 needed for RSA and Ed25519 public keys.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+var b64 = base64.RawURLEncoding
+
+func NewJWK(k VerifyKey) (JWK, error) {
+	switch pub := k.Key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Kid: k.ID,
+			Use: "sig",
+			Alg: AlgRS256,
+			N:   b64.EncodeToString(pub.N.Bytes()),
+			E:   b64.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}, nil
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Kid: k.ID,
+			Use: "sig",
+			Alg: AlgEdDSA,
+			Crv: "Ed25519",
+			X:   b64.EncodeToString(pub),
+		}, nil
+	}
+	return JWK{}, fmt.Errorf("unsupported public key type %T", k.Key)
+}
+
+// Thumbprint is the RFC 7638 SHA-256 thumbprint of the key.
+func (j JWK) Thumbprint() string {
+	var canonical []byte
+	switch j.Kty {
+	case "RSA":
+		canonical, _ = json.Marshal(struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{j.E, j.Kty, j.N})
+	case "OKP":
+		canonical, _ = json.Marshal(struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{j.Crv, j.Kty, j.X})
+	}
+	sum := sha256.Sum256(canonical)
+	return b64.EncodeToString(sum[:])
+}
+
+// VerifyKey converts the JWK back into a public key. Symmetric ("oct") keys
+// are rejected so a published key set can never downgrade to HS256.
+func (j JWK) VerifyKey() (VerifyKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := b64.DecodeString(j.N)
+		if err != nil {
+			return VerifyKey{}, err
+		}
+		e, err := b64.DecodeString(j.E)
+		if err != nil {
+			return VerifyKey{}, err
+		}
+		pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		return VerifyKey{ID: j.Kid, Alg: AlgRS256, Key: pub}, nil
+	case "OKP":
+		if j.Crv != "Ed25519" {
+			return VerifyKey{}, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+		x, err := b64.DecodeString(j.X)
+		if err != nil {
+			return VerifyKey{}, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return VerifyKey{}, errors.New("invalid ed25519 key size")
+		}
+		return VerifyKey{ID: j.Kid, Alg: AlgEdDSA, Key: ed25519.PublicKey(x)}, nil
+	}
+	return VerifyKey{}, fmt.Errorf("unsupported key type %q", j.Kty)
+}
+
+// KeySet converts every usable key; keys of unknown type are skipped.
+func (s JWKS) KeySet() StaticKeySet {
+	out := StaticKeySet{}
+	for _, j := range s.Keys {
+		if j.Use != "" && j.Use != "sig" {
+			continue
+		}
+		k, err := j.VerifyKey()
+		if err != nil {
+			continue
+		}
+		out[k.ID] = k
+	}
+	return out
+}
@@ -0,0 +1,212 @@
+package jwtutil
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func rsaKey(t *testing.T) *SigningKey {
+	t.Helper()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa key: %v", err)
+	}
+	b := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)})
+	sk, err := ParsePrivateKeyPEM(b)
+	if err != nil {
+		t.Fatalf("parse rsa: %v", err)
+	}
+	return sk
+}
+
+func edKey(t *testing.T) *SigningKey {
+	t.Helper()
+	_, k, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519 key: %v", err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(k)
+	sk, err := ParsePrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("parse ed25519: %v", err)
+	}
+	return sk
+}
+
+func TestKeyRing_SignAndValidate(t *testing.T) {
+	for name, sk := range map[string]*SigningKey{"rs256": rsaKey(t), "eddsa": edKey(t)} {
+		t.Run(name, func(t *testing.T) {
+			ring := NewKeyRing(sk)
+			c := Claims{UserID: uuid.New(), TenantID: "t1"}
+			s, err := ring.GenerateAccessWith(time.Minute, c, "iss", "aud")
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+			var out Claims
+			if err := ValidateWithKeySet(ring, s, &out, "iss", "aud"); err != nil {
+				t.Fatalf("validate: %v", err)
+			}
+			if out.UserID != c.UserID {
+				t.Fatalf("claims mismatch")
+			}
+			// A published JWKS must verify the same token.
+			if err := ValidateWithKeySet(ring.JWKS().KeySet(), s, &Claims{}, "iss", "aud"); err != nil {
+				t.Fatalf("validate via jwks: %v", err)
+			}
+			if err := ValidateWithKeySet(ring, s, &Claims{}, "iss", "other"); err == nil {
+				t.Fatalf("expected audience mismatch")
+			}
+		})
+	}
+}
+
+func TestKeyRing_RotationOverlap(t *testing.T) {
+	old := rsaKey(t)
+	next := edKey(t)
+	oldToken, _ := NewKeyRing(old).GenerateAccessWith(time.Minute, Claims{UserID: uuid.New()}, "iss", "aud")
+
+	rotated := NewKeyRing(next, old.Verify())
+	if err := ValidateWithKeySet(rotated, oldToken, &Claims{}, "iss", "aud"); err != nil {
+		t.Fatalf("old token should validate during overlap: %v", err)
+	}
+	newToken, _ := rotated.GenerateAccessWith(time.Minute, Claims{UserID: uuid.New()}, "iss", "aud")
+	if err := ValidateWithKeySet(rotated, newToken, &Claims{}, "iss", "aud"); err != nil {
+		t.Fatalf("new token: %v", err)
+	}
+	if got := rotated.JWKS().Keys; len(got) != 2 || got[0].Kid != next.ID {
+		t.Fatalf("jwks should list current key first, got %+v", got)
+	}
+
+	retired := NewKeyRing(next)
+	if err := ValidateWithKeySet(retired, oldToken, &Claims{}, "iss", "aud"); err == nil {
+		t.Fatalf("old token should fail once its key is retired")
+	}
+}
+
+func TestValidateWithKeySet_RejectsAlgConfusion(t *testing.T) {
+	sk := rsaKey(t)
+	ring := NewKeyRing(sk)
+	// HS256 token keyed with the public key bytes and the RSA kid.
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{UserID: uuid.New()})
+	token.Header["kid"] = sk.ID
+	der, _ := x509.MarshalPKIXPublicKey(sk.Key.Public())
+	s, _ := token.SignedString(der)
+	if err := ValidateWithKeySet(ring, s, &Claims{}, "", ""); err == nil {
+		t.Fatalf("expected alg mismatch to be rejected")
+	}
+}
+
+func TestSecretKeySet_MatchesValidateWith(t *testing.T) {
+	s, _ := GenerateAccessWith("secret", time.Minute, Claims{UserID: uuid.New()}, "iss", "aud")
+	if err := ValidateWithKeySet(NewSecretKeySet("secret"), s, &Claims{}, "iss", "aud"); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if err := ValidateWithKeySet(NewSecretKeySet("other"), s, &Claims{}, "iss", "aud"); err == nil {
+		t.Fatalf("expected wrong secret to fail")
+	}
+}
+
+func TestRemoteKeySet_RefreshesOnUnknownKid(t *testing.T) {
+	first := rsaKey(t)
+	second := edKey(t)
+	var ring atomic.Pointer[KeyRing]
+	ring.Store(NewKeyRing(first))
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		_ = json.NewEncoder(w).Encode(ring.Load().JWKS())
+	}))
+	defer srv.Close()
+
+	ks := NewRemoteKeySet(srv.URL, time.Hour, time.Second)
+	ks.minRefresh = 0
+	t1, _ := ring.Load().GenerateAccessWith(time.Minute, Claims{UserID: uuid.New()}, "iss", "aud")
+	for i := 0; i < 3; i++ {
+		if err := ValidateWithKeySet(ks, t1, &Claims{}, "iss", "aud"); err != nil {
+			t.Fatalf("validate: %v", err)
+		}
+	}
+	if hits != 1 {
+		t.Fatalf("expected cached jwks, got %d fetches", hits)
+	}
+
+	ring.Store(NewKeyRing(second, first.Verify()))
+	t2, _ := ring.Load().GenerateAccessWith(time.Minute, Claims{UserID: uuid.New()}, "iss", "aud")
+	if err := ValidateWithKeySet(ks, t2, &Claims{}, "iss", "aud"); err != nil {
+		t.Fatalf("rotated key should be fetched: %v", err)
+	}
+	if err := ValidateWithKeySet(ks, t1, &Claims{}, "iss", "aud"); err != nil {
+		t.Fatalf("previous key should still validate: %v", err)
+	}
+	if hits != 2 {
+		t.Fatalf("expected one refresh, got %d fetches", hits)
+	}
+}
+
+func TestRemoteKeySet_ServesCacheDuringRefresh(t *testing.T) {
+	key := rsaKey(t)
+	ring := NewKeyRing(key)
+	var hits int32
+	refreshing := make(chan struct{}, 1)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) > 1 {
+			refreshing <- struct{}{}
+			<-release
+		}
+		_ = json.NewEncoder(w).Encode(ring.JWKS())
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ks := NewRemoteKeySet(srv.URL, time.Hour, 5*time.Second)
+	ks.minRefresh = time.Hour
+	kid := key.Verify().ID
+	if _, err := ks.Lookup(kid); err != nil {
+		t.Fatalf("lookup: %v", err)
+	}
+
+	// Expire the cache; the next refresh hangs until release is closed.
+	ks.mu.Lock()
+	ks.fetched = time.Time{}
+	ks.attempted = time.Time{}
+	ks.mu.Unlock()
+
+	got := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		go func() {
+			_, err := ks.Lookup(kid)
+			got <- err
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		select {
+		case err := <-got:
+			if err != nil {
+				t.Fatalf("cached key during refresh: %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("lookup blocked on the in-flight fetch")
+		}
+	}
+	select {
+	case <-refreshing:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stale cache did not trigger a refresh")
+	}
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Fatalf("expected a single in-flight refresh, got %d fetches", n)
+	}
+}
@@ -0,0 +1,303 @@
+// Package metering counts what each tenant uses per day and enforces the
+// quotas of the plan the tenant is billed on.
+package metering
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+)
+
+// Metric is a usage counter kept per tenant and day.
+type Metric string
+
+const (
+	// Requests counts authenticated requests through the gateway.
+	Requests Metric = "requests"
+	// StorageBytes counts bytes uploaded to file-service.
+	StorageBytes Metric = "storage_bytes"
+	// MessagesSent counts notifications sent.
+	MessagesSent Metric = "messages_sent"
+)
+
+// Metrics lists every metric, in the order usage is reported.
+var Metrics = []Metric{Requests, StorageBytes, MessagesSent}
+
+// Quota bounds a metric per day. Past Soft callers are warned, past Hard they
+// are refused; zero means no bound.
+type Quota struct {
+	Soft int64 `json:"soft"`
+	Hard int64 `json:"hard"`
+}
+
+// Plan is what a tenant is billed on.
+type Plan struct {
+	Name   string           `json:"name"`
+	Quotas map[Metric]Quota `json:"quotas"`
+}
+
+// DefaultPlan applies to tenants that were never assigned one.
+const DefaultPlan = "standard"
+
+const mib = 1 << 20
+
+// Plans are the plans tenants can be assigned, by name.
+var Plans = map[string]Plan{
+	"free": {Name: "free", Quotas: map[Metric]Quota{
+		Requests:     {Soft: 8_000, Hard: 10_000},
+		StorageBytes: {Soft: 80 * mib, Hard: 100 * mib},
+		MessagesSent: {Soft: 400, Hard: 500},
+	}},
+	"standard": {Name: "standard", Quotas: map[Metric]Quota{
+		Requests:     {Soft: 80_000, Hard: 100_000},
+		StorageBytes: {Soft: 800 * mib, Hard: 1024 * mib},
+		MessagesSent: {Soft: 4_000, Hard: 5_000},
+	}},
+	"premium": {Name: "premium", Quotas: map[Metric]Quota{
+		Requests:     {Soft: 800_000},
+		StorageBytes: {Soft: 8192 * mib, Hard: 10240 * mib},
+		MessagesSent: {Soft: 40_000},
+	}},
+}
+
+var (
+	ErrUnknownPlan = errors.New("unknown plan")
+	ErrRange       = errors.New("invalid usage range")
+)
+
+// MaxUsageDays bounds a usage history query.
+const MaxUsageDays = 366
+
+// State is where a tenant stands against a quota.
+type State string
+
+const (
+	OK       State = "ok"
+	Warning  State = "warning"
+	Exceeded State = "exceeded"
+)
+
+// Result is the outcome of metering some usage.
+type Result struct {
+	Metric Metric    `json:"metric"`
+	Day    time.Time `json:"day"`
+	// Amount is what was metered, zero when Consume refused it.
+	Amount int64 `json:"amount"`
+	Used   int64 `json:"used"`
+	Quota  Quota `json:"quota"`
+	State  State `json:"state"`
+	// Reset is how long until the day's counters start over.
+	Reset time.Duration `json:"-"`
+}
+
+// Store keeps the daily counters and plan assignments.
+type Store interface {
+	// Add adds n to a counter unless that takes it past limit (when limit >
+	// 0), and returns the counter and whether n was added.
+	Add(ctx context.Context, tenant string, metric Metric, day time.Time, n, limit int64) (int64, bool, error)
+	Get(ctx context.Context, tenant string, metric Metric, days []time.Time) ([]int64, error)
+	// Plan returns the name of the tenant's plan, "" when none was assigned.
+	Plan(ctx context.Context, tenant string) (string, error)
+	SetPlan(ctx context.Context, tenant, plan string) error
+}
+
+// planCacheTTL is how long a replica keeps using a tenant's plan before
+// reading it again.
+const planCacheTTL = time.Minute
+
+// Meter records usage and checks it against the tenant's plan. Days are UTC.
+type Meter struct {
+	store Store
+	now   func() time.Time
+
+	mu    sync.Mutex
+	plans map[string]cachedPlan
+}
+
+type cachedPlan struct {
+	name  string
+	until time.Time
+}
+
+func New(store Store) *Meter {
+	return &Meter{store: store, now: time.Now, plans: map[string]cachedPlan{}}
+}
+
+// Consume meters n unless it takes the tenant past the plan's hard limit, in
+// which case nothing is recorded and the result is Exceeded.
+func (m *Meter) Consume(ctx context.Context, tenant string, metric Metric, n int64) (Result, error) {
+	return m.add(ctx, tenant, metric, n, true)
+}
+
+// Record meters n even past the hard limit, for usage that must not be
+// refused.
+func (m *Meter) Record(ctx context.Context, tenant string, metric Metric, n int64) (Result, error) {
+	return m.add(ctx, tenant, metric, n, false)
+}
+
+// Release gives back what a Consume metered when the work it paid for failed.
+func (m *Meter) Release(ctx context.Context, tenant string, r Result) error {
+	if r.Amount == 0 {
+		return nil
+	}
+	_, _, err := m.store.Add(ctx, tenant, r.Metric, r.Day, -r.Amount, 0)
+	return err
+}
+
+func (m *Meter) add(ctx context.Context, tenant string, metric Metric, n int64, enforce bool) (Result, error) {
+	plan, err := m.Plan(ctx, tenant)
+	if err != nil {
+		return Result{}, err
+	}
+	now := m.now().UTC()
+	day := now.Truncate(24 * time.Hour)
+	r := Result{Metric: metric, Day: day, Quota: plan.Quotas[metric], Reset: day.Add(24 * time.Hour).Sub(now)}
+	limit := int64(0)
+	if enforce {
+		limit = r.Quota.Hard
+	}
+	used, added, err := m.store.Add(ctx, tenant, metric, day, n, limit)
+	if err != nil {
+		return Result{}, err
+	}
+	r.Used = used
+	if added {
+		r.Amount = n
+	}
+	switch {
+	case !added || (r.Quota.Hard > 0 && used > r.Quota.Hard):
+		r.State = Exceeded
+	case r.Quota.Soft > 0 && used > r.Quota.Soft:
+		r.State = Warning
+	default:
+		r.State = OK
+	}
+	return r, nil
+}
+
+// Plan returns the plan the tenant is billed on.
+func (m *Meter) Plan(ctx context.Context, tenant string) (Plan, error) {
+	now := m.now()
+	m.mu.Lock()
+	cached, ok := m.plans[tenant]
+	m.mu.Unlock()
+	if !ok || !now.Before(cached.until) {
+		name, err := m.store.Plan(ctx, tenant)
+		if err != nil {
+			return Plan{}, err
+		}
+		cached = cachedPlan{name: name, until: now.Add(planCacheTTL)}
+		m.mu.Lock()
+		m.plans[tenant] = cached
+		m.mu.Unlock()
+	}
+	if p, ok := Plans[cached.name]; ok {
+		return p, nil
+	}
+	return Plans[DefaultPlan], nil
+}
+
+// SetPlan moves the tenant onto the named plan. Other replicas pick it up
+// within planCacheTTL.
+func (m *Meter) SetPlan(ctx context.Context, tenant, name string) (Plan, error) {
+	p, ok := Plans[name]
+	if !ok {
+		return Plan{}, ErrUnknownPlan
+	}
+	if err := m.store.SetPlan(ctx, tenant, name); err != nil {
+		return Plan{}, err
+	}
+	m.mu.Lock()
+	m.plans[tenant] = cachedPlan{name: name, until: m.now().Add(planCacheTTL)}
+	m.mu.Unlock()
+	return p, nil
+}
+
+// DailyUsage is a tenant's usage on one day.
+type DailyUsage struct {
+	Date  string           `json:"date"`
+	Usage map[Metric]int64 `json:"usage"`
+}
+
+// Usage returns the tenant's usage for every day from from to to, inclusive.
+func (m *Meter) Usage(ctx context.Context, tenant string, from, to time.Time) ([]DailyUsage, error) {
+	from, to = from.UTC().Truncate(24*time.Hour), to.UTC().Truncate(24*time.Hour)
+	if to.Before(from) || to.Sub(from) >= MaxUsageDays*24*time.Hour {
+		return nil, ErrRange
+	}
+	var days []time.Time
+	for d := from; !d.After(to); d = d.Add(24 * time.Hour) {
+		days = append(days, d)
+	}
+	out := make([]DailyUsage, len(days))
+	for i, d := range days {
+		out[i] = DailyUsage{Date: d.Format(time.DateOnly), Usage: map[Metric]int64{}}
+	}
+	for _, metric := range Metrics {
+		counts, err := m.store.Get(ctx, tenant, metric, days)
+		if err != nil {
+			return nil, err
+		}
+		for i, n := range counts {
+			out[i].Usage[metric] = n
+		}
+	}
+	return out, nil
+}
+
+// Today returns where the tenant stands against each quota today.
+func (m *Meter) Today(ctx context.Context, tenant string) ([]Result, error) {
+	plan, err := m.Plan(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	now := m.now().UTC()
+	day := now.Truncate(24 * time.Hour)
+	out := make([]Result, 0, len(Metrics))
+	for _, metric := range Metrics {
+		counts, err := m.store.Get(ctx, tenant, metric, []time.Time{day})
+		if err != nil {
+			return nil, err
+		}
+		r := Result{Metric: metric, Day: day, Used: counts[0], Quota: plan.Quotas[metric], State: OK, Reset: day.Add(24 * time.Hour).Sub(now)}
+		switch {
+		case r.Quota.Hard > 0 && r.Used >= r.Quota.Hard:
+			r.State = Exceeded
+		case r.Quota.Soft > 0 && r.Used > r.Quota.Soft:
+			r.State = Warning
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// WarningHeader tells callers they are past a soft limit.
+const WarningHeader = "X-Quota-Warning"
+
+// SetHeaders warns the caller when r is past the soft limit.
+func SetHeaders(w http.ResponseWriter, r Result) {
+	if r.State != Warning && r.State != Exceeded {
+		return
+	}
+	v := string(r.Metric) + "; used=" + strconv.FormatInt(r.Used, 10) + "; soft=" + strconv.FormatInt(r.Quota.Soft, 10)
+	if r.Quota.Hard > 0 {
+		v += "; hard=" + strconv.FormatInt(r.Quota.Hard, 10)
+	}
+	w.Header().Set(WarningHeader, v)
+}
+
+// Reject refuses a request r did not admit until the quota resets.
+func Reject(w http.ResponseWriter, r Result) {
+	SetHeaders(w, r)
+	w.Header().Set("Retry-After", strconv.Itoa(max(1, int(r.Reset.Seconds()))))
+	httputil.Error(w, http.StatusTooManyRequests, "QUOTA_EXCEEDED", "Quota exceeded", map[string]any{
+		"metric": r.Metric,
+		"used":   r.Used,
+		"limit":  r.Quota.Hard,
+	})
+}
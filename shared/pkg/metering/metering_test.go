@@ -0,0 +1,153 @@
+package metering
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type fakeStore struct {
+	counts map[string]int64
+	plans  map[string]string
+	reads  int
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{counts: map[string]int64{}, plans: map[string]string{}}
+}
+
+func (f *fakeStore) Add(ctx context.Context, tenant string, metric Metric, day time.Time, n, limit int64) (int64, bool, error) {
+	k := counterKey(tenant, metric, day)
+	if limit > 0 && n > 0 && f.counts[k]+n > limit {
+		return f.counts[k], false, nil
+	}
+	f.counts[k] += n
+	return f.counts[k], true, nil
+}
+
+func (f *fakeStore) Get(ctx context.Context, tenant string, metric Metric, days []time.Time) ([]int64, error) {
+	out := make([]int64, len(days))
+	for i, d := range days {
+		out[i] = f.counts[counterKey(tenant, metric, d)]
+	}
+	return out, nil
+}
+
+func (f *fakeStore) Plan(ctx context.Context, tenant string) (string, error) {
+	f.reads++
+	return f.plans[tenant], nil
+}
+
+func (f *fakeStore) SetPlan(ctx context.Context, tenant, plan string) error {
+	f.plans[tenant] = plan
+	return nil
+}
+
+func testMeter(store Store, now time.Time) *Meter {
+	m := New(store)
+	m.now = func() time.Time { return now }
+	return m
+}
+
+func TestMeter_Consume(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+	store.plans["t1"] = "free"
+	m := testMeter(store, time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC))
+
+	r, err := m.Consume(ctx, "t1", MessagesSent, 400)
+	if err != nil || r.State != OK || r.Used != 400 {
+		t.Fatalf("r=%+v err=%v", r, err)
+	}
+	if r.Reset != time.Hour {
+		t.Fatalf("reset=%v", r.Reset)
+	}
+	r, _ = m.Consume(ctx, "t1", MessagesSent, 50)
+	if r.State != Warning {
+		t.Fatalf("past soft limit: %+v", r)
+	}
+	r, _ = m.Consume(ctx, "t1", MessagesSent, 51)
+	if r.State != Exceeded || r.Amount != 0 || r.Used != 450 {
+		t.Fatalf("past hard limit: %+v", r)
+	}
+	// Essential usage is recorded anyway
+	r, _ = m.Record(ctx, "t1", MessagesSent, 100)
+	if r.State != Exceeded || r.Used != 550 {
+		t.Fatalf("record: %+v", r)
+	}
+	// Other tenants and metrics are counted apart
+	if r, _ := m.Consume(ctx, "t2", MessagesSent, 1); r.State != OK || r.Used != 1 {
+		t.Fatalf("t2: %+v", r)
+	}
+}
+
+func TestMeter_Release(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+	m := testMeter(store, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	r, _ := m.Consume(ctx, "t1", StorageBytes, 1000)
+	if err := m.Release(ctx, "t1", r); err != nil {
+		t.Fatal(err)
+	}
+	today, _ := m.Today(ctx, "t1")
+	if today[1].Metric != StorageBytes || today[1].Used != 0 {
+		t.Fatalf("today=%+v", today)
+	}
+}
+
+func TestMeter_Plan(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+	m := testMeter(store, time.Now())
+	if p, _ := m.Plan(ctx, "t1"); p.Name != DefaultPlan {
+		t.Fatalf("plan=%s", p.Name)
+	}
+	if _, err := m.SetPlan(ctx, "t1", "gold"); !errors.Is(err, ErrUnknownPlan) {
+		t.Fatalf("err=%v", err)
+	}
+	if _, err := m.SetPlan(ctx, "t1", "premium"); err != nil {
+		t.Fatal(err)
+	}
+	reads := store.reads
+	if p, _ := m.Plan(ctx, "t1"); p.Name != "premium" || store.reads != reads {
+		t.Fatalf("plan=%s reads=%d", p.Name, store.reads-reads)
+	}
+}
+
+func TestMeter_Usage(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := testMeter(store, day)
+	_, _ = m.Consume(ctx, "t1", Requests, 3)
+	m.now = func() time.Time { return day.Add(48 * time.Hour) }
+	_, _ = m.Consume(ctx, "t1", Requests, 5)
+
+	usage, err := m.Usage(ctx, "t1", day, day.Add(48*time.Hour))
+	if err != nil || len(usage) != 3 {
+		t.Fatalf("usage=%+v err=%v", usage, err)
+	}
+	if usage[0].Date != "2026-03-01" || usage[0].Usage[Requests] != 3 || usage[1].Usage[Requests] != 0 || usage[2].Usage[Requests] != 5 {
+		t.Fatalf("usage=%+v", usage)
+	}
+	if _, err := m.Usage(ctx, "t1", day, day.Add(-time.Hour*24)); !errors.Is(err, ErrRange) {
+		t.Fatalf("err=%v", err)
+	}
+	if _, err := m.Usage(ctx, "t1", day, day.Add(MaxUsageDays*24*time.Hour)); !errors.Is(err, ErrRange) {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestReject(t *testing.T) {
+	rr := httptest.NewRecorder()
+	Reject(rr, Result{Metric: Requests, Used: 10, Quota: Quota{Soft: 8, Hard: 10}, State: Exceeded, Reset: 90 * time.Second})
+	if rr.Code != http.StatusTooManyRequests || rr.Header().Get("Retry-After") != "90" {
+		t.Fatalf("code=%d retry=%q", rr.Code, rr.Header().Get("Retry-After"))
+	}
+	if got := rr.Header().Get(WarningHeader); got != "requests; used=10; soft=8; hard=10" {
+		t.Fatalf("warning=%q", got)
+	}
+}
@@ -0,0 +1,88 @@
+package metering
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix = "metering:"
+	plansKey  = "metering:plans"
+	// retention keeps a little over a year of daily counters for reporting.
+	retention = 400 * 24 * time.Hour
+)
+
+// addScript adds ARGV[1] to a counter unless a positive limit ARGV[2] would
+// be passed. Returns {counter, added}.
+var addScript = redis.NewScript(`
+local n = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local cur = tonumber(redis.call('GET', KEYS[1]) or '0')
+if limit > 0 and n > 0 and cur + n > limit then
+  return {cur, 0}
+end
+cur = redis.call('INCRBY', KEYS[1], n)
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+return {cur, 1}
+`)
+
+type redisStore struct {
+	c redis.Cmdable
+}
+
+// NewRedisStore keeps counters in keys metering:<tenant>:<metric>:<date> and
+// plan assignments in the metering:plans hash. Redis must persist to disk
+// for usage history to survive a restart.
+func NewRedisStore(c redis.Cmdable) Store {
+	return &redisStore{c: c}
+}
+
+func counterKey(tenant string, metric Metric, day time.Time) string {
+	return keyPrefix + tenant + ":" + string(metric) + ":" + day.Format(time.DateOnly)
+}
+
+func (s *redisStore) Add(ctx context.Context, tenant string, metric Metric, day time.Time, n, limit int64) (int64, bool, error) {
+	res, err := addScript.Run(ctx, s.c, []string{counterKey(tenant, metric, day)}, n, limit, int64(retention.Seconds())).Int64Slice()
+	if err != nil {
+		return 0, false, err
+	}
+	return res[0], res[1] == 1, nil
+}
+
+func (s *redisStore) Get(ctx context.Context, tenant string, metric Metric, days []time.Time) ([]int64, error) {
+	keys := make([]string, len(days))
+	for i, d := range days {
+		keys[i] = counterKey(tenant, metric, d)
+	}
+	vals, err := s.c.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]int64, len(vals))
+	for i, v := range vals {
+		if v == nil {
+			continue
+		}
+		str, _ := v.(string)
+		if out[i], err = strconv.ParseInt(str, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (s *redisStore) Plan(ctx context.Context, tenant string) (string, error) {
+	name, err := s.c.HGet(ctx, plansKey, tenant).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return name, err
+}
+
+func (s *redisStore) SetPlan(ctx context.Context, tenant, plan string) error {
+	return s.c.HSet(ctx, plansKey, tenant, plan).Err()
+}
@@ -0,0 +1,55 @@
+package metering
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestRedisStore_WithRealContainer(t *testing.T) {
+	ctx := context.Background()
+	rc, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7-alpine",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForListeningPort("6379/tcp").WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Skipf("skip: cannot start redis container: %v", err)
+		return
+	}
+	defer func() { _ = rc.Terminate(ctx) }()
+	endpoint, err := rc.Endpoint(ctx, "")
+	if err != nil {
+		t.Skipf("skip: cannot get container endpoint: %v", err)
+		return
+	}
+
+	store := NewRedisStore(redis.NewClient(&redis.Options{Addr: endpoint}))
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if n, added, err := store.Add(ctx, "t1", Requests, day, 8, 10); err != nil || !added || n != 8 {
+		t.Fatalf("n=%d added=%v err=%v", n, added, err)
+	}
+	if n, added, _ := store.Add(ctx, "t1", Requests, day, 3, 10); added || n != 8 {
+		t.Fatalf("past limit: n=%d added=%v", n, added)
+	}
+	counts, err := store.Get(ctx, "t1", Requests, []time.Time{day, day.Add(24 * time.Hour)})
+	if err != nil || counts[0] != 8 || counts[1] != 0 {
+		t.Fatalf("counts=%v err=%v", counts, err)
+	}
+	if name, err := store.Plan(ctx, "t1"); err != nil || name != "" {
+		t.Fatalf("plan=%q err=%v", name, err)
+	}
+	if err := store.SetPlan(ctx, "t1", "free"); err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := store.Plan(ctx, "t1"); name != "free" {
+		t.Fatalf("plan=%q", name)
+	}
+}
@@ -32,6 +32,10 @@ func Auth(secret string, next http.Handler) http.Handler {
 }
 
 func AuthWith(secret, issuer, audience string, next http.Handler) http.Handler {
+	return AuthWithKeySet(jwtutil.NewSecretKeySet(secret), issuer, audience, next)
+}
+
+func AuthWithKeySet(keys jwtutil.KeySet, issuer, audience string, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		h := r.Header.Get("Authorization")
 		if h == "" || !strings.HasPrefix(h, "Bearer ") {
@@ -44,7 +48,7 @@ func AuthWith(secret, issuer, audience string, next http.Handler) http.Handler {
 			return
 		}
 		var c jwtutil.Claims
-		if err := jwtutil.ValidateWith(secret, token, &c, issuer, audience); err != nil {
+		if err := jwtutil.ValidateWithKeySet(keys, token, &c, issuer, audience); err != nil {
 			httputil.Error(w, http.StatusUnauthorized, "2001", "Unauthorized", nil)
 			return
 		}
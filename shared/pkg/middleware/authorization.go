@@ -19,6 +19,10 @@ func Authorization(authz Authorizer, permission string, next http.Handler) http.
 			httputil.Error(w, http.StatusUnauthorized, "2001", "Unauthorized", nil)
 			return
 		}
+		if !claims.AllowsScope(permission) {
+			httputil.Error(w, http.StatusForbidden, "3001", "Forbidden", "outside token scope")
+			return
+		}
 		okay, err := authz.Allow(claims.UserID, claims.TenantID, permission)
 		if err != nil {
 			httputil.Error(w, http.StatusInternalServerError, "1001", "Internal error", nil)
@@ -71,6 +71,23 @@ func TestAuthorization_Allowed(t *testing.T) {
 	}
 }
 
+func TestAuthorization_OutsideScope(t *testing.T) {
+	authz := &fakeAuthz{allow: true}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(204) })
+	h := Authorization(authz, "user:write", next)
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", nil)
+	claims := jwtutil.Claims{TenantID: "t1", UserID: uuid.New(), Scopes: []string{"user:read"}}
+	req = req.WithContext(withClaims(req.Context(), claims))
+	h.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rr.Code)
+	}
+	if authz.last.perm != "" {
+		t.Fatalf("scope check must run before asking the authorizer")
+	}
+}
+
 func withClaims(ctx context.Context, c jwtutil.Claims) context.Context {
 	return context.WithValue(ctx, ClaimsKey, c)
 }
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+type fakeGuardianChecker struct {
+	children map[uuid.UUID]bool
+	err      error
+}
+
+func (f *fakeGuardianChecker) IsGuardianOf(userID uuid.UUID, tenantID string, studentID uuid.UUID) (bool, error) {
+	return f.children[studentID], f.err
+}
+
+func TestGuardianScope(t *testing.T) {
+	child := uuid.New()
+	checker := &fakeGuardianChecker{children: map[uuid.UUID]bool{child: true}}
+	claims := jwtutil.Claims{TenantID: "t1", UserID: uuid.New()}
+
+	serve := func(checker GuardianChecker, studentID string, withAuth bool) int {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+		h := GuardianScope(checker, func(r *http.Request) string { return r.URL.Query().Get("student_id") }, next)
+		req := httptest.NewRequest("GET", "/?student_id="+studentID, nil)
+		if withAuth {
+			req = req.WithContext(withClaims(req.Context(), claims))
+		}
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	if code := serve(checker, child.String(), false); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", code)
+	}
+	if code := serve(checker, "not-a-uuid", true); code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", code)
+	}
+	if code := serve(checker, uuid.NewString(), true); code != http.StatusForbidden {
+		t.Fatalf("expected 403 for another student, got %d", code)
+	}
+	if code := serve(checker, child.String(), true); code != http.StatusNoContent {
+		t.Fatalf("expected 204 for own child, got %d", code)
+	}
+	if code := serve(&fakeGuardianChecker{err: errors.New("down")}, child.String(), true); code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", code)
+	}
+}
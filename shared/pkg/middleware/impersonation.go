@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+// ImpersonationRoutingKey is published on the sisfo.events exchange for every
+// request made with an impersonation token; auth-service records it in
+// audit_logs.
+const ImpersonationRoutingKey = "auth.impersonation.request"
+
+// EventPublisher is satisfied by *rabbit.Client.
+type EventPublisher interface {
+	PublishJSON(exchange, routingKey string, payload map[string]any) error
+}
+
+// ImpersonationAllowed reports whether c may be used for a request with the
+// given method. Impersonated tokens only read unless writes were granted.
+func ImpersonationAllowed(c jwtutil.Claims, method string) bool {
+	if !c.Impersonated() || c.Actor.AllowWrite {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// RestrictImpersonation refuses writes made with a read-only impersonation
+// token. It must run after Auth/AuthWith.
+func RestrictImpersonation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value(ClaimsKey).(jwtutil.Claims)
+		if ok && !ImpersonationAllowed(claims, r.Method) {
+			httputil.Error(w, http.StatusForbidden, "3001", "Forbidden", "impersonated session is read-only")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RecordImpersonation publishes every impersonated request with both the
+// subject and the actor once it has been served. It must run after
+// Auth/AuthWith. A nil pub disables it.
+func RecordImpersonation(pub EventPublisher, next http.Handler) http.Handler {
+	if pub == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value(ClaimsKey).(jwtutil.Claims)
+		if !ok || !claims.Impersonated() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ww := &respWriter{ResponseWriter: w, status: 200}
+		next.ServeHTTP(ww, r)
+		_ = pub.PublishJSON("sisfo.events", ImpersonationRoutingKey, map[string]any{
+			"tenant_id":  claims.TenantID,
+			"user_id":    claims.UserID.String(),
+			"actor_id":   claims.Actor.UserID.String(),
+			"method":     r.Method,
+			"path":       r.URL.Path,
+			"status":     ww.status,
+			"request_id": r.Header.Get("X-Request-ID"),
+			"timestamp":  time.Now().UTC(),
+		})
+	})
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+type fakePublisher struct {
+	keys     []string
+	payloads []map[string]any
+}
+
+func (f *fakePublisher) PublishJSON(exchange, routingKey string, payload map[string]any) error {
+	f.keys = append(f.keys, routingKey)
+	f.payloads = append(f.payloads, payload)
+	return nil
+}
+
+func TestRestrictImpersonation(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	h := RestrictImpersonation(next)
+	do := func(method string, c jwtutil.Claims) int {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/", nil)
+		req = req.WithContext(withClaims(req.Context(), c))
+		h.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	plain := jwtutil.Claims{UserID: uuid.New()}
+	readOnly := jwtutil.Claims{UserID: uuid.New(), Actor: &jwtutil.Actor{UserID: uuid.New()}}
+	writable := jwtutil.Claims{UserID: uuid.New(), Actor: &jwtutil.Actor{UserID: uuid.New(), AllowWrite: true}}
+
+	cases := []struct {
+		method string
+		claims jwtutil.Claims
+		want   int
+	}{
+		{http.MethodPost, plain, http.StatusNoContent},
+		{http.MethodGet, readOnly, http.StatusNoContent},
+		{http.MethodPost, readOnly, http.StatusForbidden},
+		{http.MethodDelete, readOnly, http.StatusForbidden},
+		{http.MethodPut, writable, http.StatusNoContent},
+	}
+	for _, tc := range cases {
+		if got := do(tc.method, tc.claims); got != tc.want {
+			t.Fatalf("%s impersonated=%v: got %d want %d", tc.method, tc.claims.Impersonated(), got, tc.want)
+		}
+	}
+}
+
+func TestRecordImpersonation(t *testing.T) {
+	pub := &fakePublisher{}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusAccepted) })
+	h := RecordImpersonation(pub, next)
+	actor := uuid.New()
+	for _, c := range []jwtutil.Claims{
+		{UserID: uuid.New(), TenantID: "t1"},
+		{UserID: uuid.New(), TenantID: "t1", Actor: &jwtutil.Actor{UserID: actor}},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/grades/x", nil)
+		h.ServeHTTP(httptest.NewRecorder(), req.WithContext(withClaims(req.Context(), c)))
+	}
+	if len(pub.payloads) != 1 || pub.keys[0] != ImpersonationRoutingKey {
+		t.Fatalf("only impersonated requests are published, got %v", pub.keys)
+	}
+	p := pub.payloads[0]
+	if p["actor_id"] != actor.String() || p["status"] != http.StatusAccepted || p["path"] != "/api/v1/grades/x" {
+		t.Fatalf("unexpected payload %v", p)
+	}
+}
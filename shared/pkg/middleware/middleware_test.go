@@ -5,11 +5,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"strings"
+	"net/netip"
 	"testing"
 	"time"
 
 	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -207,7 +208,7 @@ func TestAuthWithInvalidIssuer(t *testing.T) {
 func TestRateLimit(t *testing.T) {
 	f := &fakeRedis{count: map[string]int64{}}
 	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(200) })
-	h := RateLimit(&fakeLimiter{r: f}, 1, fn)
+	h := RateLimit(redisutil.NewFixedWindowLimiter(&fakeLimiter{r: f}), 1, fn)
 	rr := httptest.NewRecorder()
 	req := httptest.NewRequest("GET", "/", nil)
 	h.ServeHTTP(rr, req)
@@ -225,7 +226,7 @@ func TestRateLimitErrorPath(t *testing.T) {
 	f := &fakeRedis{count: map[string]int64{}}
 	rl := &recordingLimiter{fail: true, r: f}
 	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(200) })
-	h := RateLimit(rl, 1, fn)
+	h := RateLimit(redisutil.NewFixedWindowLimiter(rl), 1, fn)
 	rr := httptest.NewRecorder()
 	req := httptest.NewRequest("GET", "/", nil)
 	h.ServeHTTP(rr, req)
@@ -235,23 +236,68 @@ func TestRateLimitErrorPath(t *testing.T) {
 }
 
 func TestClientIPForwardedHeader(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	cases := []struct {
+		remote, xff, want string
+	}{
+		{"192.0.2.1:1234", "1.2.3.4", "192.0.2.1"},
+		{"10.0.0.5:1234", "1.2.3.4", "1.2.3.4"},
+		{"10.0.0.5:1234", "6.6.6.6, 1.2.3.4, 10.0.0.9", "1.2.3.4"},
+		{"10.0.0.5:1234", "", "10.0.0.5"},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest("GET", "/path", nil)
+		req.RemoteAddr = c.remote
+		if c.xff != "" {
+			req.Header.Set("X-Forwarded-For", c.xff)
+		}
+		if got := ClientIP(req, trusted); got != c.want {
+			t.Errorf("remote=%s xff=%q: got %s want %s", c.remote, c.xff, got, c.want)
+		}
+	}
+}
+
+func TestRateLimitWith(t *testing.T) {
 	f := &fakeRedis{count: map[string]int64{}}
 	rl := &recordingLimiter{r: f}
 	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(200) })
-	h := RateLimit(rl, 10, fn)
-	rr := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/path", nil)
-	req.Header.Set("X-Forwarded-For", "1.2.3.4")
-	h.ServeHTTP(rr, req)
-	if !strings.Contains(rl.lastKey, "ratelimit:1.2.3.4:/path") {
-		t.Fatalf("key should include forwarded IP and path")
+	// Limits apply to the matched pattern, not the raw path
+	mux := http.NewServeMux()
+	mux.Handle("/api/v1/students/{id}", RateLimitWith(redisutil.NewFixedWindowLimiter(rl), RateLimitOptions{Read: 2}, fn))
+	serve := func(path string, claims *jwtutil.Claims) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if claims != nil {
+			req = req.WithContext(context.WithValue(req.Context(), ClaimsKey, *claims))
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+	serve("/api/v1/students/1", nil)
+	if rl.lastKey != "ratelimit:ip:192.0.2.1:read:/api/v1/students/{id}" {
+		t.Fatalf("key=%s", rl.lastKey)
+	}
+	if rr := serve("/api/v1/students/2", nil); rr.Header().Get("RateLimit-Remaining") != "0" || rr.Header().Get("RateLimit-Limit") != "2" {
+		t.Fatalf("headers=%v", rr.Header())
+	}
+	rr := serve("/api/v1/students/3", nil)
+	if rr.Code != http.StatusTooManyRequests || rr.Header().Get("Retry-After") != "60" {
+		t.Fatalf("code=%d retry-after=%s", rr.Code, rr.Header().Get("Retry-After"))
+	}
+	// Authenticated callers behind the same address have their own budget
+	user := &jwtutil.Claims{UserID: uuid.New(), TenantID: "t1"}
+	if rr := serve("/api/v1/students/1", user); rr.Code != 200 {
+		t.Fatalf("user code=%d", rr.Code)
+	}
+	if rl.lastKey != "ratelimit:user:"+user.UserID.String()+":read:/api/v1/students/{id}" {
+		t.Fatalf("key=%s", rl.lastKey)
 	}
 }
 
 func TestRateLimitByPrefix(t *testing.T) {
 	f := &fakeRedis{count: map[string]int64{}}
 	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(200) })
-	h := RateLimitByPrefix(&fakeLimiter{r: f}, 10, map[string]int{"/api/v1/auth/": 2}, fn)
+	h := RateLimitByPrefix(redisutil.NewFixedWindowLimiter(&fakeLimiter{r: f}), 10, map[string]int{"/api/v1/auth/": 2}, fn)
 	req := httptest.NewRequest("GET", "/api/v1/auth/login", nil)
 	req.Header.Set("X-Forwarded-For", "9.9.9.9")
 	rr1 := httptest.NewRecorder()
@@ -265,10 +311,32 @@ func TestRateLimitByPrefix(t *testing.T) {
  }
 }
 
+func TestRateLimitNamed(t *testing.T) {
+	f := &fakeRedis{count: map[string]int64{}}
+	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(200) })
+	lim := redisutil.NewFixedWindowLimiter(&fakeLimiter{r: f})
+	h := RateLimit(lim, 10, RateLimitNamed(lim, "route", 1, fn))
+	req := httptest.NewRequest("GET", "/api/v1/auth/login", nil)
+	req.Header.Set("X-Forwarded-For", "8.8.8.8")
+	rr1 := httptest.NewRecorder()
+	h.ServeHTTP(rr1, req)
+	if rr1.Code != 200 {
+		t.Fatalf("first code=%d want 200", rr1.Code)
+	}
+	if f.count["ratelimit:route:ip:192.0.2.1:read:"] != 1 || f.count["ratelimit:ip:192.0.2.1:read:"] != 1 {
+		t.Fatalf("counters must be kept apart: %v", f.count)
+	}
+	rr2 := httptest.NewRecorder()
+	h.ServeHTTP(rr2, req)
+	if rr2.Code != http.StatusTooManyRequests {
+		t.Fatalf("second code=%d want 429", rr2.Code)
+	}
+}
+
 func TestRateLimitByPolicy(t *testing.T) {
 	f := &fakeRedis{count: map[string]int64{}}
 	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(200) })
-	h := RateLimitByPolicy(&fakeLimiter{r: f}, 3, 2, map[string]int{"/api/v1/auth/": 1}, fn)
+	h := RateLimitByPolicy(redisutil.NewFixedWindowLimiter(&fakeLimiter{r: f}), 3, 2, map[string]int{"/api/v1/auth/": 1}, fn)
 	// GET should use defaultRead=3 unless prefix override
 	reqGet := httptest.NewRequest("GET", "/api/v1/data", nil)
 	reqGet.Header.Set("X-Forwarded-For", "7.7.7.7")
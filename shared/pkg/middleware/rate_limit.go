@@ -2,94 +2,198 @@ package middleware
 
 import (
 	"context"
-	"fmt"
+	"math"
 	"net"
 	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
 	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+
+	"github.com/google/uuid"
 )
 
-func clientIP(r *http.Request) string {
-	ip := r.Header.Get("X-Forwarded-For")
-	if ip != "" {
-		return ip
-	}
-	host, _, _ := net.SplitHostPort(r.RemoteAddr)
-	return host
+// RateLimitBy selects what a limit is counted against.
+type RateLimitBy string
+
+const (
+	// ByCaller, the default, counts per API key, else per user, else per
+	// client IP.
+	ByCaller RateLimitBy = "caller"
+	// ByTenant counts per tenant, falling back to ByCaller.
+	ByTenant RateLimitBy = "tenant"
+	// ByIP counts per client IP only.
+	ByIP RateLimitBy = "ip"
+)
+
+type rateLimitSubjectKey struct{}
+
+// WithRateLimitSubject names the caller limits count against, taking
+// precedence over the authenticated user, e.g. "apikey:<id>".
+func WithRateLimitSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, rateLimitSubjectKey{}, subject)
+}
+
+// RateLimitOptions configures RateLimitWith.
+type RateLimitOptions struct {
+	// Name keeps the counters of layered limiters apart.
+	Name string
+	// Read is the limit for GET and HEAD, Write for other methods; Write
+	// defaults to Read.
+	Read, Write int
+	// Window defaults to a minute.
+	Window time.Duration
+	// Overrides sets both limits for routes starting with a prefix.
+	Overrides map[string]int
+	By        RateLimitBy
+	// Route is the route template limits apply to, so /students/<id> paths
+	// share a counter. It defaults to the pattern the request matched
+	// (r.Pattern); requests without one share a single counter.
+	Route string
+	// TrustedProxies are the peers whose X-Forwarded-For is believed.
+	TrustedProxies []netip.Prefix
 }
 
-func RateLimit(lim redisutil.Limiter, limitPerMin int, next http.Handler) http.Handler {
+// RateLimitWith limits requests per caller and route template, and reports
+// the most restrictive limit seen so far in RateLimit-* headers.
+func RateLimitWith(lim redisutil.RateLimiter, opts RateLimitOptions, next http.Handler) http.Handler {
+	if opts.Write == 0 {
+		opts.Write = opts.Read
+	}
+	if opts.Window == 0 {
+		opts.Window = time.Minute
+	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		key := fmt.Sprintf("ratelimit:%s:%s", clientIP(r), r.URL.Path)
-		n, err := lim.Incr(context.Background(), key)
-		if err != nil {
-			httputil.Error(w, http.StatusInternalServerError, "1001", "Internal error", nil)
-			return
+		route := opts.Route
+		if route == "" {
+			route = r.Pattern
 		}
-		if n == 1 {
-			_ = lim.Expire(context.Background(), key, time.Minute)
+		limit, class := opts.Write, "write"
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			limit, class = opts.Read, "read"
 		}
-		if n > int64(limitPerMin) {
-			httputil.Error(w, http.StatusTooManyRequests, "RATE_LIMITED", "Too many requests", nil)
-			return
+		match := route
+		if match == "" {
+			match = r.URL.Path
 		}
-		next.ServeHTTP(w, r)
-	})
-}
-
-func RateLimitByPrefix(lim redisutil.Limiter, defaultLimit int, perPrefix map[string]int, next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		limit := defaultLimit
-		path := r.URL.Path
-		for p, v := range perPrefix {
-			if len(p) > 0 && len(path) >= len(p) && path[:len(p)] == p {
+		for p, v := range opts.Overrides {
+			if p != "" && strings.HasPrefix(match, p) {
 				limit = v
+				break
 			}
 		}
-		key := fmt.Sprintf("ratelimit:%s:%s", clientIP(r), path)
-		n, err := lim.Incr(context.Background(), key)
+		parts := []string{"ratelimit"}
+		if opts.Name != "" {
+			parts = append(parts, opts.Name)
+		}
+		key := strings.Join(append(parts, rateLimitSubject(r, opts.By, opts.TrustedProxies), class, route), ":")
+		rl, err := lim.Allow(r.Context(), key, limit, opts.Window)
 		if err != nil {
 			httputil.Error(w, http.StatusInternalServerError, "1001", "Internal error", nil)
 			return
 		}
-		if n == 1 {
-			_ = lim.Expire(context.Background(), key, time.Minute)
-		}
-		if n > int64(limit) {
-			httputil.Error(w, http.StatusTooManyRequests, "RATE_LIMITED", "Too many requests", nil)
+		SetRateLimitHeaders(w, rl, opts.Window)
+		if !rl.Allowed {
+			TooManyRequests(w, rl)
 			return
 		}
 		next.ServeHTTP(w, r)
 	})
 }
 
-func RateLimitByPolicy(lim redisutil.Limiter, defaultRead int, defaultWrite int, perPrefixOverride map[string]int, next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		limit := defaultWrite
-		if r.Method == http.MethodGet || r.Method == http.MethodHead {
-			limit = defaultRead
+// SetRateLimitHeaders reports rl in RateLimit-* headers unless an outer
+// limiter already reported a lower remaining budget.
+func SetRateLimitHeaders(w http.ResponseWriter, rl redisutil.RateLimit, window time.Duration) {
+	h := w.Header()
+	if prev, err := strconv.Atoi(h.Get("RateLimit-Remaining")); err == nil && prev < rl.Remaining {
+		return
+	}
+	h.Set("RateLimit-Limit", strconv.Itoa(rl.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(rl.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(seconds(rl.Reset)))
+	h.Set("RateLimit-Policy", strconv.Itoa(rl.Limit)+";w="+strconv.Itoa(seconds(window)))
+}
+
+// TooManyRequests rejects a request rl did not admit.
+func TooManyRequests(w http.ResponseWriter, rl redisutil.RateLimit) {
+	w.Header().Set("Retry-After", strconv.Itoa(max(1, seconds(rl.RetryAfter))))
+	httputil.Error(w, http.StatusTooManyRequests, "RATE_LIMITED", "Too many requests", nil)
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+func rateLimitSubject(r *http.Request, by RateLimitBy, trusted []netip.Prefix) string {
+	if by != ByIP {
+		claims, _ := r.Context().Value(ClaimsKey).(jwtutil.Claims)
+		if by == ByTenant && claims.TenantID != "" {
+			return "tenant:" + claims.TenantID
 		}
-		for p, v := range perPrefixOverride {
-			if len(p) > 0 && len(r.URL.Path) >= len(p) && r.URL.Path[:len(p)] == p {
-				limit = v
-				break
-			}
+		if s, _ := r.Context().Value(rateLimitSubjectKey{}).(string); s != "" {
+			return s
 		}
-		key := fmt.Sprintf("ratelimit:%s:%s:%s", clientIP(r), r.Method, r.URL.Path)
-		n, err := lim.Incr(context.Background(), key)
+		if claims.UserID != uuid.Nil {
+			return "user:" + claims.UserID.String()
+		}
+	}
+	return "ip:" + ClientIP(r, trusted)
+}
+
+// ClientIP returns the address of the client that sent r. X-Forwarded-For
+// is only believed when the peer is a trusted proxy, and then only up to the
+// first address not itself a trusted proxy, so clients cannot spoof it.
+func ClientIP(r *http.Request, trusted []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil || !isTrusted(ip, trusted) {
+		return host
+	}
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
 		if err != nil {
-			httputil.Error(w, http.StatusInternalServerError, "1001", "Internal error", nil)
-			return
+			break
 		}
-		if n == 1 {
-			_ = lim.Expire(context.Background(), key, time.Minute)
+		ip = hop.Unmap()
+		if !isTrusted(ip, trusted) {
+			break
 		}
-		if n > int64(limit) {
-			httputil.Error(w, http.StatusTooManyRequests, "RATE_LIMITED", "Too many requests", nil)
-			return
+	}
+	return ip.String()
+}
+
+func isTrusted(ip netip.Addr, trusted []netip.Prefix) bool {
+	ip = ip.Unmap()
+	for _, p := range trusted {
+		if p.Contains(ip) {
+			return true
 		}
-		next.ServeHTTP(w, r)
-	})
+	}
+	return false
+}
+
+func RateLimit(lim redisutil.RateLimiter, limitPerMin int, next http.Handler) http.Handler {
+	return RateLimitWith(lim, RateLimitOptions{Read: limitPerMin}, next)
+}
+
+// RateLimitNamed is RateLimit with its own key namespace, so it can be layered
+// on top of another limiter without sharing its counters.
+func RateLimitNamed(lim redisutil.RateLimiter, name string, limitPerMin int, next http.Handler) http.Handler {
+	return RateLimitWith(lim, RateLimitOptions{Name: name, Read: limitPerMin}, next)
+}
+
+func RateLimitByPrefix(lim redisutil.RateLimiter, defaultLimit int, perPrefix map[string]int, next http.Handler) http.Handler {
+	return RateLimitWith(lim, RateLimitOptions{Read: defaultLimit, Overrides: perPrefix}, next)
+}
+
+func RateLimitByPolicy(lim redisutil.RateLimiter, defaultRead int, defaultWrite int, perPrefixOverride map[string]int, next http.Handler) http.Handler {
+	return RateLimitWith(lim, RateLimitOptions{Read: defaultRead, Write: defaultWrite, Overrides: perPrefixOverride}, next)
 }
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/httputil"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	redisutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/redis"
+	"github.com/redis/go-redis/v9"
+)
+
+// TokenVersionKey is where auth-service publishes a user's current token
+// version. Tokens carrying an older version were issued before a role change.
+func TokenVersionKey(userID uuid.UUID) string {
+	return "tokenver:" + userID.String()
+}
+
+// TokenVersionValid reports whether c is at least the published version. A
+// nil kv disables the check.
+func TokenVersionValid(ctx context.Context, kv redisutil.KV, c jwtutil.Claims) (bool, error) {
+	if kv == nil {
+		return true, nil
+	}
+	v, err := redisutil.Get(ctx, kv, TokenVersionKey(c.UserID))
+	if errors.Is(err, redis.Nil) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	current, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	return c.TokenVersion >= current, nil
+}
+
+// RequireTokenVersion rejects tokens whose version is older than the one
+// published for the user. It must run after Auth/AuthWith.
+func RequireTokenVersion(kv redisutil.KV, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value(ClaimsKey).(jwtutil.Claims)
+		if !ok {
+			httputil.Error(w, http.StatusUnauthorized, "2001", "Unauthorized", nil)
+			return
+		}
+		valid, err := TokenVersionValid(r.Context(), kv, claims)
+		if err != nil {
+			httputil.Error(w, http.StatusInternalServerError, "1001", "Internal error", nil)
+			return
+		}
+		if !valid {
+			httputil.Error(w, http.StatusUnauthorized, "2001", "Unauthorized", "token revoked")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+	"github.com/redis/go-redis/v9"
+)
+
+type fakeKV map[string]string
+
+func (f fakeKV) Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	f[key] = value.(string)
+	return redis.NewStatusCmd(ctx)
+}
+
+func (f fakeKV) Get(ctx context.Context, key string) *redis.StringCmd {
+	cmd := redis.NewStringCmd(ctx)
+	if v, ok := f[key]; ok {
+		cmd.SetVal(v)
+	} else {
+		cmd.SetErr(redis.Nil)
+	}
+	return cmd
+}
+
+func (f fakeKV) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	for _, k := range keys {
+		delete(f, k)
+	}
+	return redis.NewIntCmd(ctx)
+}
+
+func TestRequireTokenVersion(t *testing.T) {
+	uid := uuid.New()
+	kv := fakeKV{}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(204) })
+	h := RequireTokenVersion(kv, next)
+	do := func(ver int64) int {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/", nil)
+		req = req.WithContext(withClaims(req.Context(), jwtutil.Claims{UserID: uid, TokenVersion: ver}))
+		h.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	if code := do(0); code != http.StatusNoContent {
+		t.Fatalf("no published version should pass, got %d", code)
+	}
+	kv[TokenVersionKey(uid)] = "2"
+	if code := do(1); code != http.StatusUnauthorized {
+		t.Fatalf("stale token should be rejected, got %d", code)
+	}
+	if code := do(2); code != http.StatusNoContent {
+		t.Fatalf("current token should pass, got %d", code)
+	}
+}
@@ -0,0 +1,260 @@
+// Package oidc is a minimal OpenID Connect relying party: discovery, the
+// authorization-code flow with PKCE and ID token verification.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	jwtutil "github.com/jjaenal/sisfo-akademik-backend/shared/pkg/jwt"
+)
+
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+var (
+	ErrInvalidIDToken = errors.New("invalid id token")
+	ErrNonceMismatch  = errors.New("id token nonce mismatch")
+)
+
+// Discovery is the subset of the provider metadata document the relying
+// party needs.
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint,omitempty"`
+}
+
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// TokenResponse is the token endpoint's answer to an authorization code.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// IDToken carries the verified claims of an ID token.
+type IDToken struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Claims        jwt.MapClaims
+}
+
+// Groups reads a string or string-array claim, e.g. "groups" or "roles".
+func (t *IDToken) Groups(claim string) []string {
+	switch v := t.Claims[claim].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// Client talks to a single provider. Discovery and the provider's key set are
+// fetched lazily and cached for the lifetime of the client.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+
+	mu   sync.Mutex
+	disc *Discovery
+	keys *jwtutil.RemoteKeySet
+}
+
+func NewClient(cfg Config, timeout time.Duration) *Client {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	cfg.Issuer = strings.TrimRight(cfg.Issuer, "/")
+	return &Client{cfg: cfg, httpClient: &http.Client{Timeout: timeout}}
+}
+
+func (c *Client) Discover(ctx context.Context) (*Discovery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.disc != nil {
+		return c.disc, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.Issuer+DiscoveryPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc discovery: unexpected status %d", resp.StatusCode)
+	}
+	var d Discovery
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if strings.TrimRight(d.Issuer, "/") != c.cfg.Issuer {
+		return nil, fmt.Errorf("oidc discovery: issuer %q does not match %q", d.Issuer, c.cfg.Issuer)
+	}
+	c.disc = &d
+	c.keys = jwtutil.NewRemoteKeySet(d.JWKSURI, jwtutil.DefaultJWKSCacheTTL, c.httpClient.Timeout)
+	return c.disc, nil
+}
+
+// AuthCodeURL is where the user agent is sent to authenticate. challenge is
+// the S256 PKCE challenge of the verifier later passed to Exchange.
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, challenge string) (string, error) {
+	d, err := c.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.cfg.ClientID},
+		"redirect_uri":          {c.cfg.RedirectURL},
+		"scope":                 {strings.Join(c.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return d.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+func (c *Client) Exchange(ctx context.Context, code, verifier string) (*TokenResponse, error) {
+	d, err := c.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.cfg.RedirectURL},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(c.cfg.ClientID), url.QueryEscape(c.cfg.ClientSecret))
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc token exchange: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc token exchange: unexpected status %d", resp.StatusCode)
+	}
+	var tok TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return nil, fmt.Errorf("oidc token exchange: %w", err)
+	}
+	if tok.IDToken == "" {
+		return nil, fmt.Errorf("oidc token exchange: %w", ErrInvalidIDToken)
+	}
+	return &tok, nil
+}
+
+// VerifyIDToken checks the signature against the provider's JWKS, the issuer,
+// the audience, expiry and the nonce bound to the authorization request.
+func (c *Client) VerifyIDToken(ctx context.Context, raw, nonce string) (*IDToken, error) {
+	d, err := c.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		k, err := c.keys.Lookup(kid)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != k.Alg {
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+		return k.Key, nil
+	},
+		jwt.WithValidMethods([]string{jwtutil.AlgRS256, jwtutil.AlgEdDSA}),
+		jwt.WithIssuer(d.Issuer),
+		jwt.WithAudience(c.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	if got, _ := claims["nonce"].(string); got != nonce {
+		return nil, ErrNonceMismatch
+	}
+	t := &IDToken{Claims: claims}
+	t.Subject, _ = claims["sub"].(string)
+	t.Name, _ = claims["name"].(string)
+	t.Email, _ = claims["email"].(string)
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		t.EmailVerified = v
+	case string:
+		t.EmailVerified = v == "true"
+	}
+	if t.Email == "" {
+		// Microsoft Entra ID omits email for some account types.
+		if upn, _ := claims["preferred_username"].(string); strings.Contains(upn, "@") {
+			t.Email = upn
+		}
+	}
+	if t.Subject == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidIDToken)
+	}
+	return t, nil
+}
+
+// RandomToken returns a URL-safe random string suitable for state, nonce and
+// PKCE verifiers.
+func RandomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// PKCEChallenge is the S256 code challenge of verifier.
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
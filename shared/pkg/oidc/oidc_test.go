@@ -0,0 +1,86 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/oidc"
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/oidc/oidctest"
+)
+
+func TestAuthorizationCodeFlowWithPKCE(t *testing.T) {
+	idp := oidctest.NewServer("client-1", "secret-1")
+	defer idp.Close()
+	idp.SetUser(oidctest.User{Subject: "u-42", Email: "guru@school.sch.id", EmailVerified: true, Name: "Guru", Groups: []string{"staff", "teachers"}})
+
+	c := oidc.NewClient(oidc.Config{
+		Issuer:       idp.Issuer(),
+		ClientID:     "client-1",
+		ClientSecret: "secret-1",
+		RedirectURL:  "http://localhost/callback",
+	}, time.Second)
+	ctx := context.Background()
+
+	verifier, _ := oidc.RandomToken()
+	authURL, err := c.AuthCodeURL(ctx, "state-1", "nonce-1", oidc.PKCEChallenge(verifier))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, state, err := idp.Authorize(authURL)
+	if err != nil || state != "state-1" {
+		t.Fatalf("authorize: code=%q state=%q err=%v", code, state, err)
+	}
+
+	if _, err := c.Exchange(ctx, code, "wrong-verifier"); err == nil {
+		t.Fatalf("exchange with wrong verifier must fail")
+	}
+
+	code, _, _ = idp.Authorize(authURL)
+	tok, err := c.Exchange(ctx, code, verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Exchange(ctx, code, verifier); err == nil {
+		t.Fatalf("code must be single use")
+	}
+
+	if _, err := c.VerifyIDToken(ctx, tok.IDToken, "other-nonce"); !errors.Is(err, oidc.ErrNonceMismatch) {
+		t.Fatalf("expected nonce mismatch, got %v", err)
+	}
+	id, err := c.VerifyIDToken(ctx, tok.IDToken, "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Subject != "u-42" || id.Email != "guru@school.sch.id" || !id.EmailVerified {
+		t.Fatalf("unexpected claims: %+v", id)
+	}
+	if g := id.Groups("groups"); len(g) != 2 || g[1] != "teachers" {
+		t.Fatalf("unexpected groups: %v", g)
+	}
+}
+
+func TestVerifyIDToken_WrongAudience(t *testing.T) {
+	idp := oidctest.NewServer("client-1", "secret-1")
+	defer idp.Close()
+	idp.SetUser(oidctest.User{Subject: "u-1", Email: "a@b.c"})
+
+	issuing := oidc.NewClient(oidc.Config{Issuer: idp.Issuer(), ClientID: "client-1", ClientSecret: "secret-1", RedirectURL: "http://localhost/cb"}, time.Second)
+	other := oidc.NewClient(oidc.Config{Issuer: idp.Issuer(), ClientID: "client-2", RedirectURL: "http://localhost/cb"}, time.Second)
+	ctx := context.Background()
+
+	verifier, _ := oidc.RandomToken()
+	authURL, _ := issuing.AuthCodeURL(ctx, "s", "n", oidc.PKCEChallenge(verifier))
+	code, _, err := idp.Authorize(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok, err := issuing.Exchange(ctx, code, verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.VerifyIDToken(ctx, tok.IDToken, "n"); !errors.Is(err, oidc.ErrInvalidIDToken) {
+		t.Fatalf("expected invalid id token for foreign audience, got %v", err)
+	}
+}
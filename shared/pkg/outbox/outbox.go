@@ -0,0 +1,283 @@
+// Package outbox stores domain events in the transaction of the change they
+// describe. A Relay publishes them to RabbitMQ once committed, so an event is
+// neither lost after a commit nor sent for a change that was rolled back.
+//
+// Each service using it keeps an outbox_events table created by its own
+// migrations (see Schema).
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Schema creates the table the outbox writes to and the relay drains.
+const Schema = `
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id UUID PRIMARY KEY,
+    exchange VARCHAR(255) NOT NULL,
+    routing_key VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP WITH TIME ZONE,
+    sent_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events(created_at) WHERE sent_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_sent ON outbox_events(sent_at) WHERE sent_at IS NOT NULL;
+`
+
+// Querier is satisfied by *pgxpool.Pool, pgx.Tx and pgxmock.
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type txKey struct{}
+
+// Conn returns the transaction Transact opened on ctx, or db outside one.
+// Repositories write through it so their changes commit with the events
+// enqueued alongside.
+func Conn(ctx context.Context, db Querier) Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// ErrNoOutbox is returned by a nil *Outbox rather than writing without a
+// transaction or dropping the event.
+var ErrNoOutbox = errors.New("outbox: not configured")
+
+// Events is what usecases need of an Outbox. outboxtest.Recorder stands in
+// for it in tests without a database.
+type Events interface {
+	Transact(ctx context.Context, fn func(ctx context.Context) error) error
+	Enqueue(ctx context.Context, exchange, routingKey string, payload map[string]any) error
+}
+
+// Outbox enqueues events in the service database.
+type Outbox struct {
+	db Querier
+}
+
+func New(db Querier) *Outbox {
+	return &Outbox{db: db}
+}
+
+// Transact runs fn in a transaction that Conn and Enqueue join through the
+// context fn is given. Nested calls join the outer transaction.
+func (o *Outbox) Transact(ctx context.Context, fn func(ctx context.Context) error) error {
+	if o == nil {
+		return ErrNoOutbox
+	}
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := o.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Enqueue stores an event for the relay. Within Transact it is published
+// only if the transaction commits.
+func (o *Outbox) Enqueue(ctx context.Context, exchange, routingKey string, payload map[string]any) error {
+	if o == nil {
+		return ErrNoOutbox
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = Conn(ctx, o.db).Exec(ctx,
+		`INSERT INTO outbox_events (id, exchange, routing_key, payload) VALUES ($1, $2, $3, $4)`,
+		uuid.New(), exchange, routingKey, body)
+	return err
+}
+
+// Publisher is satisfied by *rabbit.Client, which waits for the broker to
+// confirm each message.
+type Publisher interface {
+	Publish(ctx context.Context, exchange, routingKey, messageID string, body []byte) error
+}
+
+// Relay publishes committed events and marks them sent. Delivery is at least
+// once: an event is published again if marking it fails or its lease runs
+// out, with the same message ID so consumers can tell.
+type Relay struct {
+	db  Querier
+	pub Publisher
+
+	BatchSize int
+	Interval  time.Duration
+	// Publishes not confirmed within Timeout are retried on the next pass
+	Timeout time.Duration
+	// Claimed events are left to this relay for Lease, after which another
+	// may take them over
+	Lease time.Duration
+	// Sent events are kept this long for troubleshooting
+	Retention time.Duration
+}
+
+func NewRelay(db Querier, pub Publisher) *Relay {
+	return &Relay{db: db, pub: pub, BatchSize: 100, Interval: time.Second, Timeout: 5 * time.Second, Lease: time.Minute, Retention: 7 * 24 * time.Hour}
+}
+
+// Run relays events until ctx is done. Replicas can run it side by side, as
+// each batch leases the rows it publishes.
+func (r *Relay) Run(ctx context.Context) {
+	var purged time.Time
+	for {
+		n, err := r.RelayOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("outbox: relay stopped after %d events: %v", n, err)
+		}
+		if time.Since(purged) > time.Hour {
+			if err := r.Purge(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("outbox: purge failed: %v", err)
+			}
+			purged = time.Now()
+		}
+		// A full batch means more are waiting
+		if err == nil && n == r.BatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.Interval):
+		}
+	}
+}
+
+type pending struct {
+	id                   uuid.UUID
+	exchange, routingKey string
+	payload              []byte
+	createdAt            time.Time
+}
+
+// RelayOnce publishes up to BatchSize pending events in the order they were
+// stored and returns how many were sent. It stops at the first failure, so
+// later events are not published ahead of it.
+//
+// The batch is claimed with a lease and committed before publishing, so no
+// row lock or transaction is held while waiting for the broker.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	deadline := time.Now().Add(r.Lease)
+	events, err := r.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Bookkeeping still runs if ctx ends mid batch, or the claimed rows would
+	// wait out the lease
+	bctx := context.WithoutCancel(ctx)
+	var sent, unsent []uuid.UUID
+	var failed error
+	for i, e := range events {
+		if ctx.Err() != nil || time.Now().Add(r.Timeout).After(deadline) {
+			for _, e := range events[i:] {
+				unsent = append(unsent, e.id)
+			}
+			break
+		}
+		pctx, cancel := context.WithTimeout(ctx, r.Timeout)
+		err := r.pub.Publish(pctx, e.exchange, e.routingKey, e.id.String(), e.payload)
+		cancel()
+		if err != nil {
+			failed = err
+			if _, err := r.db.Exec(bctx, `UPDATE outbox_events SET attempts = attempts + 1, last_error = $2, locked_until = NULL WHERE id = $1`, e.id, failed.Error()); err != nil {
+				failed = errors.Join(failed, err)
+			}
+			for _, e := range events[i+1:] {
+				unsent = append(unsent, e.id)
+			}
+			break
+		}
+		sent = append(sent, e.id)
+	}
+	if err := r.markSent(bctx, sent); err != nil {
+		return 0, errors.Join(failed, err)
+	}
+	if len(unsent) > 0 {
+		if _, err := r.db.Exec(bctx, `UPDATE outbox_events SET locked_until = NULL WHERE id = ANY($1)`, unsent); err != nil {
+			return len(sent), errors.Join(failed, err)
+		}
+	}
+	if failed == nil && ctx.Err() != nil {
+		failed = ctx.Err()
+	}
+	return len(sent), failed
+}
+
+// claim leases the next batch of pending events that no other relay holds.
+func (r *Relay) claim(ctx context.Context) ([]pending, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE outbox_events
+		SET locked_until = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id
+			FROM outbox_events
+			WHERE sent_at IS NULL AND (locked_until IS NULL OR locked_until < NOW())
+			ORDER BY created_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED)
+		RETURNING id, exchange, routing_key, payload, created_at`, r.BatchSize, r.Lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var events []pending
+	for rows.Next() {
+		var e pending
+		if err := rows.Scan(&e.id, &e.exchange, &e.routingKey, &e.payload, &e.createdAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// RETURNING does not keep the order of the subquery
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].createdAt.Equal(events[j].createdAt) {
+			return events[i].createdAt.Before(events[j].createdAt)
+		}
+		return bytes.Compare(events[i].id[:], events[j].id[:]) < 0
+	})
+	return events, nil
+}
+
+func (r *Relay) markSent(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := r.db.Exec(ctx, `UPDATE outbox_events SET sent_at = NOW(), locked_until = NULL WHERE id = ANY($1)`, ids)
+	return err
+}
+
+// Purge deletes events sent longer than Retention ago.
+func (r *Relay) Purge(ctx context.Context) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM outbox_events WHERE sent_at < $1`, time.Now().Add(-r.Retention))
+	return err
+}
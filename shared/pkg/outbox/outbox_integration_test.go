@@ -0,0 +1,146 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+type published struct {
+	routingKey, messageID, body string
+}
+
+type fakePublisher struct {
+	sent   []published
+	failOn string
+	// during runs inside the next Publish, while its batch is claimed
+	during func()
+}
+
+func (f *fakePublisher) Publish(_ context.Context, _, routingKey, messageID string, body []byte) error {
+	if during := f.during; during != nil {
+		f.during = nil
+		during()
+	}
+	if routingKey == f.failOn {
+		return errors.New("nacked")
+	}
+	f.sent = append(f.sent, published{routingKey, messageID, string(body)})
+	return nil
+}
+
+func TestOutbox_WithRealPostgres(t *testing.T) {
+	ctx := context.Background()
+	pg, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "postgres:16-alpine",
+			Env:          map[string]string{"POSTGRES_DB": "testdb", "POSTGRES_USER": "test", "POSTGRES_PASSWORD": "test"},
+			ExposedPorts: []string{"5432/tcp"},
+			WaitingFor:   wait.ForLog("database system is ready to accept connections").WithOccurrence(2).WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Skipf("skip: cannot start postgres container: %v", err)
+		return
+	}
+	defer func() { _ = pg.Terminate(ctx) }()
+	endpoint, err := pg.Endpoint(ctx, "")
+	if err != nil {
+		t.Skipf("skip: cannot get container endpoint: %v", err)
+		return
+	}
+	db, err := pgxpool.New(ctx, "postgres://test:test@"+endpoint+"/testdb?sslmode=disable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(ctx, Schema+`CREATE TABLE things (name TEXT);`); err != nil {
+		t.Fatal(err)
+	}
+
+	o := New(db)
+	write := func(name, routingKey string, fail error) error {
+		return o.Transact(ctx, func(ctx context.Context) error {
+			if _, err := Conn(ctx, db).Exec(ctx, `INSERT INTO things (name) VALUES ($1)`, name); err != nil {
+				return err
+			}
+			if err := o.Enqueue(ctx, "sisfo.events", routingKey, map[string]any{"name": name}); err != nil {
+				return err
+			}
+			return fail
+		})
+	}
+	if err := write("a", "thing.created", nil); err != nil {
+		t.Fatal(err)
+	}
+	// A rolled back change leaves no event behind
+	if err := write("b", "thing.created", errors.New("rollback")); err == nil {
+		t.Fatal("expected the transaction to fail")
+	}
+	if err := write("c", "thing.deleted", nil); err != nil {
+		t.Fatal(err)
+	}
+	var things int
+	_ = db.QueryRow(ctx, `SELECT COUNT(*) FROM things`).Scan(&things)
+	if things != 2 {
+		t.Fatalf("things=%d", things)
+	}
+
+	// A refused publish stops the batch and is retried on the next pass.
+	// While the batch is out, another relay finds it leased rather than
+	// blocking on it.
+	pub := &fakePublisher{failOn: "thing.deleted"}
+	r := NewRelay(db, pub)
+	other := &fakePublisher{}
+	pub.during = func() {
+		if n, err := NewRelay(db, other).RelayOnce(ctx); n != 0 || err != nil {
+			t.Errorf("leased batch relayed twice: n=%d err=%v", n, err)
+		}
+	}
+	if n, err := r.RelayOnce(ctx); n != 1 || err == nil {
+		t.Fatalf("n=%d err=%v", n, err)
+	}
+	var attempts int
+	var lastError string
+	var leased bool
+	_ = db.QueryRow(ctx, `SELECT attempts, last_error, locked_until IS NOT NULL FROM outbox_events WHERE routing_key = 'thing.deleted'`).Scan(&attempts, &lastError, &leased)
+	if attempts != 1 || lastError != "nacked" || leased {
+		t.Fatalf("attempts=%d last_error=%q leased=%v", attempts, lastError, leased)
+	}
+	// A lease left by a relay that died is taken over once it runs out
+	if _, err := db.Exec(ctx, `UPDATE outbox_events SET locked_until = NOW() + INTERVAL '1 hour' WHERE sent_at IS NULL`); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := r.RelayOnce(ctx); n != 0 || err != nil {
+		t.Fatalf("leased: n=%d err=%v", n, err)
+	}
+	if _, err := db.Exec(ctx, `UPDATE outbox_events SET locked_until = NOW() - INTERVAL '1 second' WHERE sent_at IS NULL`); err != nil {
+		t.Fatal(err)
+	}
+	pub.failOn = ""
+	if n, err := r.RelayOnce(ctx); n != 1 || err != nil {
+		t.Fatalf("n=%d err=%v", n, err)
+	}
+	if n, err := r.RelayOnce(ctx); n != 0 || err != nil {
+		t.Fatalf("nothing left: n=%d err=%v", n, err)
+	}
+	if len(pub.sent) != 2 || pub.sent[0].routingKey != "thing.created" || pub.sent[0].body != `{"name":"a"}` || pub.sent[1].routingKey != "thing.deleted" || pub.sent[0].messageID == "" {
+		t.Fatalf("sent=%+v", pub.sent)
+	}
+
+	r.Retention = 0
+	if err := r.Purge(ctx); err != nil {
+		t.Fatal(err)
+	}
+	var left int
+	_ = db.QueryRow(ctx, `SELECT COUNT(*) FROM outbox_events`).Scan(&left)
+	if left != 0 {
+		t.Fatalf("left=%d after purge", left)
+	}
+}
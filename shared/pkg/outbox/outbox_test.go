@@ -0,0 +1,22 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestOutbox_NilRefusesToWrite(t *testing.T) {
+	var o *Outbox
+	called := false
+	err := o.Transact(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, ErrNoOutbox) || called {
+		t.Fatalf("called=%v err=%v", called, err)
+	}
+	if err := o.Enqueue(context.Background(), "sisfo.events", "rk", map[string]any{"a": 1}); !errors.Is(err, ErrNoOutbox) {
+		t.Fatalf("err=%v want %v", err, ErrNoOutbox)
+	}
+}
@@ -0,0 +1,75 @@
+// Package outboxtest records outbox events in memory, for testing usecases
+// without a database.
+package outboxtest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jjaenal/sisfo-akademik-backend/shared/pkg/outbox"
+)
+
+// Event is an event passed to Enqueue.
+type Event struct {
+	Exchange   string
+	RoutingKey string
+	Payload    map[string]any
+}
+
+// Recorder implements outbox.Events. Transact runs fn directly and keeps the
+// events it enqueued only if fn succeeds, as a rolled back transaction would.
+type Recorder struct {
+	// Err, when set, fails every Enqueue
+	Err error
+
+	mu      sync.Mutex
+	pending map[*tx][]Event
+	events  []Event
+}
+
+var _ outbox.Events = (*Recorder)(nil)
+
+type tx struct{}
+
+type txKey struct{}
+
+func (r *Recorder) Transact(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*tx); ok {
+		return fn(ctx)
+	}
+	t := &tx{}
+	err := fn(context.WithValue(ctx, txKey{}, t))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err == nil {
+		r.events = append(r.events, r.pending[t]...)
+	}
+	delete(r.pending, t)
+	return err
+}
+
+func (r *Recorder) Enqueue(ctx context.Context, exchange, routingKey string, payload map[string]any) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	e := Event{Exchange: exchange, RoutingKey: routingKey, Payload: payload}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := ctx.Value(txKey{}).(*tx)
+	if !ok {
+		r.events = append(r.events, e)
+		return nil
+	}
+	if r.pending == nil {
+		r.pending = map[*tx][]Event{}
+	}
+	r.pending[t] = append(r.pending[t], e)
+	return nil
+}
+
+// Events returns the events committed so far.
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
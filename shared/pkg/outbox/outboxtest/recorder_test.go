@@ -0,0 +1,34 @@
+package outboxtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRecorder_KeepsCommittedEvents(t *testing.T) {
+	r := &Recorder{}
+	ctx := context.Background()
+	write := func(rk string, fail error) error {
+		return r.Transact(ctx, func(ctx context.Context) error {
+			if err := r.Enqueue(ctx, "sisfo.events", rk, map[string]any{"n": 1}); err != nil {
+				return err
+			}
+			return fail
+		})
+	}
+	if err := write("thing.created", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := write("thing.deleted", errors.New("rollback")); err == nil {
+		t.Fatal("expected the transaction to fail")
+	}
+	got := r.Events()
+	if len(got) != 1 || got[0].RoutingKey != "thing.created" || got[0].Exchange != "sisfo.events" {
+		t.Fatalf("events=%+v", got)
+	}
+	r.Err = errors.New("db down")
+	if err := write("thing.updated", nil); !errors.Is(err, r.Err) {
+		t.Fatalf("err=%v", err)
+	}
+}
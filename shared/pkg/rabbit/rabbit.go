@@ -1,78 +1,564 @@
+// Package rabbit publishes and consumes JSON events on RabbitMQ topic
+// exchanges. A Client keeps one connection and redials it with backoff
+// whenever it drops. Publishes go out persistent on a pool of confirm-mode
+// channels and wait for the broker's confirm; subscriptions ack a delivery
+// only once its handler has dealt with it.
+//
+// A delivery whose handler fails is moved to <queue>.retry, which hands it
+// back to <queue> after Options.RetryDelay. After Options.MaxRetries it is
+// parked in <queue>.dead for someone to look at. Only deliveries failed with
+// a Permanent error are dropped.
 package rabbit
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+var (
+	// ErrNotConnected is returned by publishes while the broker cannot be
+	// reached. They fail at once rather than hold up the caller.
+	ErrNotConnected = errors.New("rabbit: not connected")
+	// ErrNacked is returned when the broker did not accept a publish.
+	ErrNacked = errors.New("rabbit: publish nacked by broker")
+)
+
+// Options tune a Client. Zero fields take the defaults shown.
+type Options struct {
+	// PublishChannels is how many confirm-mode channels publishes share (4).
+	PublishChannels int
+	// PublishTimeout bounds PublishJSON, the broker's confirm included (5s).
+	PublishTimeout time.Duration
+	// Prefetch is how many unacked deliveries a subscription holds (10).
+	Prefetch int
+	// MinBackoff and MaxBackoff bound the wait between redials (500ms, 30s).
+	MinBackoff, MaxBackoff time.Duration
+	// RetryDelay is how long a failed delivery waits before its next try
+	// (10s). It is the TTL of the retry queue, so changing it for an
+	// existing subscription means deleting that queue first.
+	RetryDelay time.Duration
+	// MaxRetries is how many times a failed delivery is retried before it is
+	// parked (10).
+	MaxRetries int
+}
+
+func (o Options) withDefaults() Options {
+	if o.PublishChannels <= 0 {
+		o.PublishChannels = 4
+	}
+	if o.PublishTimeout <= 0 {
+		o.PublishTimeout = 5 * time.Second
+	}
+	if o.Prefetch <= 0 {
+		o.Prefetch = 10
+	}
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = 500 * time.Millisecond
+	}
+	if o.MaxBackoff < o.MinBackoff {
+		o.MaxBackoff = max(30*time.Second, o.MinBackoff)
+	}
+	if o.RetryDelay <= 0 {
+		o.RetryDelay = 10 * time.Second
+	}
+	if o.MaxRetries <= 0 {
+		o.MaxRetries = 10
+	}
+	return o
+}
+
+// backoff returns the wait before retry attempt n (from 0): doubling from
+// MinBackoff up to MaxBackoff, with jitter so replicas do not redial in step.
+func (o Options) backoff(n int) time.Duration {
+	d := o.MaxBackoff
+	if n < 30 && o.MinBackoff<<n < o.MaxBackoff {
+		d = o.MinBackoff << n
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
 type Client struct {
-	conn *amqp.Connection
-	ch   *amqp.Channel
+	url  string
+	opts Options
+
+	mu       sync.Mutex
+	conn     *amqp.Connection
+	ready    chan struct{} // closed while conn is up
+	declared map[string]bool
+
+	// pool holds the publish channels; nil entries are opened on use
+	pool   chan *amqp.Channel
+	closed chan struct{}
+	once   sync.Once
 }
 
+// New returns a client for url, or nil when url is empty. A nil client
+// publishes nothing and has nothing to consume. The first dial is made
+// before New returns; when it fails the client keeps redialling in the
+// background.
 func New(url string) *Client {
+	return NewWithOptions(url, Options{})
+}
+
+func NewWithOptions(url string, opts Options) *Client {
+	if url == "" {
+		return nil
+	}
+	opts = opts.withDefaults()
+	c := &Client{
+		url:      url,
+		opts:     opts,
+		ready:    make(chan struct{}),
+		declared: map[string]bool{},
+		pool:     make(chan *amqp.Channel, opts.PublishChannels),
+		closed:   make(chan struct{}),
+	}
+	for range opts.PublishChannels {
+		c.pool <- nil
+	}
 	conn, err := amqp.Dial(url)
 	if err != nil {
+		log.Printf("rabbit: dial failed, retrying in the background: %v", err)
+	}
+	go c.run(conn)
+	return c
+}
+
+// run keeps the connection up until the client is closed.
+func (c *Client) run(conn *amqp.Connection) {
+	for attempt := 0; ; attempt++ {
+		if conn == nil {
+			select {
+			case <-c.closed:
+				return
+			case <-time.After(c.opts.backoff(attempt)):
+			}
+			var err error
+			if conn, err = amqp.Dial(c.url); err != nil {
+				log.Printf("rabbit: dial failed: %v", err)
+				continue
+			}
+			log.Printf("rabbit: reconnected")
+		}
+		attempt = -1
+		lost := conn.NotifyClose(make(chan *amqp.Error, 1))
+		c.setConn(conn)
+		select {
+		case err := <-lost:
+			log.Printf("rabbit: connection lost: %v", err)
+			c.setConn(nil)
+			conn = nil
+		case <-c.closed:
+			_ = conn.Close()
+			return
+		}
+	}
+}
+
+func (c *Client) setConn(conn *amqp.Connection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case conn != nil:
+		c.conn = conn
+		c.declared = map[string]bool{}
+		close(c.ready)
+	case c.conn != nil:
+		c.conn = nil
+		c.ready = make(chan struct{})
+	}
+}
+
+func (c *Client) current() (*amqp.Connection, <-chan struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn, c.ready
+}
+
+// Connected reports whether the client is connected to the broker now.
+func (c *Client) Connected() bool {
+	if c == nil || c.url == "" {
+		return false
+	}
+	conn, _ := c.current()
+	return conn != nil
+}
+
+// connection waits for the broker to be reachable.
+func (c *Client) connection(ctx context.Context) (*amqp.Connection, error) {
+	for {
+		conn, ready := c.current()
+		if conn != nil {
+			return conn, nil
+		}
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-c.closed:
+			return nil, errors.New("rabbit: client closed")
+		}
+	}
+}
+
+// PublishJSON publishes payload as JSON and waits for the broker to confirm
+// it, for at most Options.PublishTimeout.
+func (c *Client) PublishJSON(exchange, routingKey string, payload map[string]any) error {
+	if c == nil || c.url == "" {
 		return nil
 	}
-	ch, err := conn.Channel()
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.PublishTimeout)
+	defer cancel()
+	b, err := json.Marshal(payload)
 	if err != nil {
-		_ = conn.Close()
-		return nil
+		return err
 	}
-	return &Client{conn: conn, ch: ch}
+	return c.Publish(ctx, exchange, routingKey, uuid.NewString(), b)
 }
 
-func (c *Client) PublishJSON(exchange, routingKey string, payload map[string]any) error {
-	if c == nil || c.ch == nil {
+// Publish sends a persistent JSON message with the given ID to a topic
+// exchange, declaring it the first time, and waits for the broker to confirm
+// it. Consumers can use the ID to drop redeliveries.
+func (c *Client) Publish(ctx context.Context, exchange, routingKey, messageID string, body []byte) (err error) {
+	if c == nil || c.url == "" {
 		return nil
 	}
-	if err := c.ch.ExchangeDeclare(exchange, "topic", true, false, false, false, nil); err != nil {
+	conn, _ := c.current()
+	if conn == nil {
+		return ErrNotConnected
+	}
+	var ch *amqp.Channel
+	select {
+	case ch = <-c.pool:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() {
+		// A channel is closed by the broker on any channel error
+		if ch != nil && ch.IsClosed() {
+			ch = nil
+		}
+		c.pool <- ch
+	}()
+	if ch == nil || ch.IsClosed() {
+		if ch, err = openConfirmChannel(conn); err != nil {
+			return err
+		}
+	}
+	if err := c.declare(ch, exchange); err != nil {
 		return err
 	}
-	b, err := json.Marshal(payload)
+	conf, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    messageID,
+		Timestamp:    time.Now().UTC(),
+		Body:         body,
+	})
 	if err != nil {
 		return err
 	}
-	return c.ch.PublishWithContext(context.Background(), exchange, routingKey, false, false, amqp.Publishing{
-		ContentType: "application/json",
-		Body:        b,
-	})
+	acked, err := conf.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return ErrNacked
+	}
+	return nil
 }
 
-func (c *Client) Consume(exchange, queue string, bindings []string) (<-chan amqp.Delivery, error) {
-	if c == nil || c.ch == nil {
-		return nil, nil
+func openConfirmChannel(conn *amqp.Connection) (*amqp.Channel, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, err
 	}
-	if err := c.ch.ExchangeDeclare(exchange, "topic", true, false, false, false, nil); err != nil {
+	if err := ch.Confirm(false); err != nil {
+		_ = ch.Close()
 		return nil, err
 	}
-	q, err := c.ch.QueueDeclare(queue, true, false, false, false, nil)
+	return ch, nil
+}
+
+// declare declares a durable topic exchange once per connection.
+func (c *Client) declare(ch *amqp.Channel, exchange string) error {
+	c.mu.Lock()
+	done := c.declared[exchange]
+	c.mu.Unlock()
+	if done {
+		return nil
+	}
+	if err := ch.ExchangeDeclare(exchange, "topic", true, false, false, false, nil); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.declared[exchange] = true
+	c.mu.Unlock()
+	return nil
+}
+
+// Subscription is a durable queue bound to a topic exchange.
+type Subscription struct {
+	Exchange string
+	Queue    string
+	Bindings []string
+}
+
+// Delivery is a message received on a subscription. Retries counts the
+// earlier tries that failed.
+type Delivery struct {
+	RoutingKey  string
+	MessageID   string
+	Body        []byte
+	Redelivered bool
+	Retries     int
+}
+
+// Handler deals with one delivery. Returning nil acks it. A Permanent error
+// drops it; any other error retries it after Options.RetryDelay, up to
+// Options.MaxRetries times before it is parked in the dead queue.
+type Handler func(ctx context.Context, d Delivery) error
+
+const (
+	// retriesHeader counts the failed tries of a delivery
+	retriesHeader = "x-retries"
+	// routingKeyHeader keeps the original routing key of a retried
+	// delivery, which comes back through the default exchange
+	routingKeyHeader = "x-routing-key"
+)
+
+func retryQueue(queue string) string { return queue + ".retry" }
+func deadQueue(queue string) string  { return queue + ".dead" }
+
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks a handler error that retrying cannot fix, such as a
+// malformed message.
+func Permanent(err error) error {
+	return permanentError{err}
+}
+
+// Subscribe consumes sub until ctx is done or the client is closed, calling
+// h for one delivery at a time with up to Options.Prefetch more waiting. It
+// subscribes again whenever the connection or channel drops, and returns
+// once h has finished with the delivery in hand. It is meant to run in its
+// own goroutine.
+func (c *Client) Subscribe(ctx context.Context, sub Subscription, h Handler) {
+	if c == nil || c.url == "" {
+		return
+	}
+	for attempt := 0; ; attempt++ {
+		conn, err := c.connection(ctx)
+		if err != nil {
+			return
+		}
+		err = c.consume(ctx, conn, sub, h, func() { attempt = -1 })
+		if ctx.Err() != nil || c.isClosed() {
+			return
+		}
+		log.Printf("rabbit: subscription %s interrupted: %v", sub.Queue, err)
+		select {
+		case <-time.After(c.opts.backoff(attempt)):
+		case <-ctx.Done():
+			return
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+func (c *Client) consume(ctx context.Context, conn *amqp.Connection, sub Subscription, h Handler, started func()) error {
+	ch, err := conn.Channel()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	defer func() { _ = ch.Close() }()
+	if err := ch.Qos(c.opts.Prefetch, 0, false); err != nil {
+		return err
+	}
+	if err := ch.ExchangeDeclare(sub.Exchange, "topic", true, false, false, false, nil); err != nil {
+		return err
 	}
-	for _, key := range bindings {
-		if e := c.ch.QueueBind(q.Name, key, exchange, false, nil); e != nil {
-			return nil, e
+	q, err := ch.QueueDeclare(sub.Queue, true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+	for _, key := range sub.Bindings {
+		if err := ch.QueueBind(q.Name, key, sub.Exchange, false, nil); err != nil {
+			return err
 		}
 	}
-	msgs, err := c.ch.Consume(q.Name, "", true, false, false, false, nil)
+	// Failed deliveries expire from the retry queue back into the queue
+	if _, err := ch.QueueDeclare(retryQueue(q.Name), true, false, false, false, amqp.Table{
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": q.Name,
+		"x-message-ttl":             c.opts.RetryDelay.Milliseconds(),
+	}); err != nil {
+		return err
+	}
+	if _, err := ch.QueueDeclare(deadQueue(q.Name), true, false, false, false, nil); err != nil {
+		return err
+	}
+	// Moving a delivery is confirmed before the original is acked
+	if err := ch.Confirm(false); err != nil {
+		return err
+	}
+	p := retryPolicy{queue: q.Name, max: c.opts.MaxRetries, forward: c.forwarder(ch)}
+	tag := sub.Queue + "." + uuid.NewString()
+	deliveries, err := ch.Consume(q.Name, tag, false, false, false, false, nil)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	started()
+	for {
+		select {
+		case d, ok := <-deliveries:
+			if !ok {
+				return errors.New("delivery channel closed")
+			}
+			p.handle(ctx, d, h)
+		case <-ctx.Done():
+			// Deliveries prefetched but not handled go back to the queue
+			// when the channel closes
+			_ = ch.Cancel(tag, false)
+			return ctx.Err()
+		case <-c.closed:
+			return errors.New("client closed")
+		}
 	}
-	return msgs, nil
 }
 
-func (c *Client) Close() {
-	if c == nil {
-		return
+// retryPolicy decides what becomes of the deliveries of one queue.
+type retryPolicy struct {
+	queue string
+	max   int
+	// forward republishes d to queue with its retry count set
+	forward func(ctx context.Context, queue string, d amqp.Delivery, retries int) error
+}
+
+// handle runs h on d and acks, moves or drops d by the outcome.
+func (p retryPolicy) handle(ctx context.Context, d amqp.Delivery, h Handler) {
+	err := safeHandle(ctx, d, h)
+	switch {
+	case err == nil:
+		_ = d.Ack(false)
+	case ctx.Err() != nil:
+		// Shutting down: whoever consumes next gets another try
+		_ = d.Nack(false, true)
+	case errors.As(err, new(permanentError)):
+		log.Printf("rabbit: dropping %s message %s: %v", routingKey(d), d.MessageId, err)
+		_ = d.Nack(false, false)
+	default:
+		n := retries(d)
+		target := retryQueue(p.queue)
+		if n >= p.max {
+			target = deadQueue(p.queue)
+		}
+		log.Printf("rabbit: moving %s message %s to %s after %d retries: %v", routingKey(d), d.MessageId, target, n, err)
+		if err := p.forward(ctx, target, d, n+1); err != nil {
+			// Requeued as is rather than lost
+			log.Printf("rabbit: cannot move message %s to %s, requeueing: %v", d.MessageId, target, err)
+			_ = d.Nack(false, true)
+			return
+		}
+		_ = d.Ack(false)
+	}
+}
+
+// forwarder republishes deliveries on ch, a confirm-mode channel, through
+// the default exchange.
+func (c *Client) forwarder(ch *amqp.Channel) func(ctx context.Context, queue string, d amqp.Delivery, retries int) error {
+	return func(ctx context.Context, queue string, d amqp.Delivery, retries int) error {
+		headers := amqp.Table{}
+		for k, v := range d.Headers {
+			headers[k] = v
+		}
+		headers[retriesHeader] = int32(retries)
+		headers[routingKeyHeader] = routingKey(d)
+		ctx, cancel := context.WithTimeout(ctx, c.opts.PublishTimeout)
+		defer cancel()
+		confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, "", queue, false, false, amqp.Publishing{
+			Headers:      headers,
+			ContentType:  d.ContentType,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    d.MessageId,
+			Timestamp:    d.Timestamp,
+			Body:         d.Body,
+		})
+		if err != nil {
+			return err
+		}
+		ok, err := confirm.WaitContext(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrNacked
+		}
+		return nil
 	}
-	if c.ch != nil {
-		_ = c.ch.Close()
+}
+
+// retries returns how many times d has failed before.
+func retries(d amqp.Delivery) int {
+	switch n := d.Headers[retriesHeader].(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 0
+}
+
+// routingKey returns the key d was first published with.
+func routingKey(d amqp.Delivery) string {
+	if key, ok := d.Headers[routingKeyHeader].(string); ok && key != "" {
+		return key
+	}
+	return d.RoutingKey
+}
+
+func safeHandle(ctx context.Context, d amqp.Delivery, h Handler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return h(ctx, Delivery{RoutingKey: routingKey(d), MessageID: d.MessageId, Body: d.Body, Redelivered: d.Redelivered, Retries: retries(d)})
+}
+
+func (c *Client) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
 	}
-	if c.conn != nil {
-		_ = c.conn.Close()
+}
+
+// Close stops redialling and closes the connection, ending subscriptions.
+func (c *Client) Close() {
+	if c == nil || c.closed == nil {
+		return
 	}
+	c.once.Do(func() {
+		close(c.closed)
+		if conn, _ := c.current(); conn != nil {
+			_ = conn.Close()
+		}
+	})
 }
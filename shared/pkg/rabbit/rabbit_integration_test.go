@@ -0,0 +1,89 @@
+package rabbit
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestClient_WithRealBroker(t *testing.T) {
+	ctx := context.Background()
+	rc, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "rabbitmq:3-alpine",
+			ExposedPorts: []string{"5672/tcp"},
+			WaitingFor:   wait.ForLog("Server startup complete").WithStartupTimeout(90 * time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Skipf("skip: cannot start rabbitmq container: %v", err)
+		return
+	}
+	defer func() { _ = rc.Terminate(ctx) }()
+	endpoint, err := rc.PortEndpoint(ctx, "5672/tcp", "amqp")
+	if err != nil {
+		t.Skipf("skip: cannot get container endpoint: %v", err)
+		return
+	}
+
+	c := NewWithOptions(endpoint, Options{MinBackoff: 50 * time.Millisecond, MaxBackoff: 200 * time.Millisecond, RetryDelay: 200 * time.Millisecond})
+	defer c.Close()
+	if !c.Connected() {
+		t.Fatal("not connected")
+	}
+
+	subCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	var attempts atomic.Int32
+	got := make(chan Delivery, 10)
+	go c.Subscribe(subCtx, Subscription{Exchange: "test.events", Queue: "test.queue", Bindings: []string{"thing.*"}}, func(_ context.Context, d Delivery) error {
+		// The first delivery fails once and comes back after RetryDelay
+		if attempts.Add(1) == 1 {
+			return errors.New("try again")
+		}
+		got <- d
+		return nil
+	})
+	// Publishes only reach queues bound by then
+	time.Sleep(500 * time.Millisecond)
+
+	if err := c.Publish(ctx, "test.events", "thing.created", "m1", []byte(`{"n":1}`)); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	select {
+	case d := <-got:
+		if d.MessageID != "m1" || d.RoutingKey != "thing.created" || d.Retries != 1 || string(d.Body) != `{"n":1}` {
+			t.Fatalf("delivery=%+v", d)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("no delivery")
+	}
+
+	// A dropped connection is redialled and the subscription resumed
+	conn, _ := c.current()
+	_ = conn.Close()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if err := c.PublishJSON("test.events", "thing.updated", map[string]any{"n": 2}); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("did not reconnect")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	select {
+	case d := <-got:
+		if d.RoutingKey != "thing.updated" {
+			t.Fatalf("delivery=%+v", d)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("no delivery after reconnect")
+	}
+}
@@ -1,13 +1,49 @@
 package rabbit
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-func TestNew_ReturnsNil_OnDialError(t *testing.T) {
-	c := New("amqp://invalid-host:5672/")
-	if c != nil {
-		t.Fatalf("expected nil client for invalid url")
+func TestNew_ReturnsNil_WithoutURL(t *testing.T) {
+	if c := New(""); c != nil {
+		t.Fatalf("expected nil client without url")
+	}
+}
+
+func TestNew_KeepsRedialling_OnDialError(t *testing.T) {
+	c := NewWithOptions("amqp://invalid-host:5672/", Options{MinBackoff: 10 * time.Millisecond})
+	if c == nil {
+		t.Fatalf("expected a client that redials")
+	}
+	defer c.Close()
+	if c.Connected() {
+		t.Fatalf("connected to an invalid host")
+	}
+	// Publishes fail at once rather than wait for the broker
+	start := time.Now()
+	if err := c.PublishJSON("events", "rk", map[string]any{"a": 1}); !errors.Is(err, ErrNotConnected) {
+		t.Fatalf("err=%v want ErrNotConnected", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("publish waited %v", time.Since(start))
+	}
+	// Subscriptions wait for the connection until their context ends
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		c.Subscribe(ctx, Subscription{Exchange: "events", Queue: "q"}, func(context.Context, Delivery) error { return nil })
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("subscribe did not return when its context ended")
 	}
 }
 
@@ -16,25 +52,18 @@ func TestPublishJSON_NoOp_WhenClientNil(t *testing.T) {
 	if err := c.PublishJSON("events", "rk", map[string]any{"a": 1}); err != nil {
 		t.Fatalf("expected nil error on nil client got %v", err)
 	}
-	// Also when channel is nil
+	// Also when no broker is configured
 	c2 := &Client{}
 	if err := c2.PublishJSON("events", "rk", map[string]any{"a": 1}); err != nil {
-		t.Fatalf("expected nil error on nil channel got %v", err)
+		t.Fatalf("expected nil error on unconfigured client got %v", err)
 	}
 }
 
-func TestConsume_NoOp_WhenClientNil(t *testing.T) {
+func TestSubscribe_NoOp_WhenClientNil(t *testing.T) {
 	var c *Client
-	msgs, err := c.Consume("events", "q", []string{"rk"})
-	if err != nil || msgs != nil {
-		t.Fatalf("expected nil,nil on nil client got msgs=%v err=%v", msgs, err)
-	}
-	// Also when channel is nil
+	c.Subscribe(context.Background(), Subscription{Exchange: "events", Queue: "q"}, nil)
 	c2 := &Client{}
-	msgs, err = c2.Consume("events", "q", []string{"rk"})
-	if err != nil || msgs != nil {
-		t.Fatalf("expected nil,nil on nil channel got msgs=%v err=%v", msgs, err)
-	}
+	c2.Subscribe(context.Background(), Subscription{Exchange: "events", Queue: "q"}, nil)
 }
 
 func TestClose_Safe_OnNil(t *testing.T) {
@@ -43,3 +72,85 @@ func TestClose_Safe_OnNil(t *testing.T) {
 	c2 := &Client{}
 	c2.Close() // should not panic
 }
+
+type ackRecorder struct {
+	acked, requeued, dropped bool
+}
+
+func (a *ackRecorder) Ack(tag uint64, multiple bool) error { a.acked = true; return nil }
+func (a *ackRecorder) Nack(tag uint64, multiple, requeue bool) error {
+	a.requeued, a.dropped = requeue, !requeue
+	return nil
+}
+func (a *ackRecorder) Reject(tag uint64, requeue bool) error { return a.Nack(tag, false, requeue) }
+
+func TestHandle_AcksByOutcome(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		retries     int
+		err         error
+		panics      bool
+		forwardErr  error
+		want        ackRecorder
+		wantForward string
+	}{
+		{name: "handled", want: ackRecorder{acked: true}},
+		{name: "failed once", err: errors.New("db down"), want: ackRecorder{acked: true}, wantForward: "q.retry"},
+		{name: "failed again", retries: 2, err: errors.New("db down"), want: ackRecorder{acked: true}, wantForward: "q.retry"},
+		{name: "out of retries", retries: 3, err: errors.New("db down"), want: ackRecorder{acked: true}, wantForward: "q.dead"},
+		{name: "cannot forward", err: errors.New("db down"), forwardErr: ErrNacked, want: ackRecorder{requeued: true}, wantForward: "q.retry"},
+		{name: "permanent", err: Permanent(errors.New("bad json")), want: ackRecorder{dropped: true}},
+		{name: "panicked", panics: true, want: ackRecorder{acked: true}, wantForward: "q.retry"},
+		{name: "shutting down", ctx: cancelled, err: context.Canceled, want: ackRecorder{requeued: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			rec := &ackRecorder{}
+			var forwarded string
+			p := retryPolicy{queue: "q", max: 3, forward: func(_ context.Context, queue string, d amqp.Delivery, retries int) error {
+				forwarded = queue
+				if retries != tt.retries+1 {
+					t.Errorf("retries=%d want %d", retries, tt.retries+1)
+				}
+				return tt.forwardErr
+			}}
+			d := amqp.Delivery{Acknowledger: rec, RoutingKey: "rk", MessageId: "m1", Body: []byte(`{}`)}
+			if tt.retries > 0 {
+				// Retried deliveries come back through the default exchange
+				d.RoutingKey = "q"
+				d.Headers = amqp.Table{retriesHeader: int32(tt.retries), routingKeyHeader: "rk"}
+			}
+			p.handle(ctx, d, func(_ context.Context, got Delivery) error {
+				if got.RoutingKey != "rk" || got.MessageID != "m1" || got.Retries != tt.retries || string(got.Body) != `{}` {
+					t.Errorf("delivery=%+v", got)
+				}
+				if tt.panics {
+					panic("boom")
+				}
+				return tt.err
+			})
+			if *rec != tt.want || forwarded != tt.wantForward {
+				t.Fatalf("got %+v forwarded to %q, want %+v forwarded to %q", *rec, forwarded, tt.want, tt.wantForward)
+			}
+		})
+	}
+}
+
+func TestOptions_Backoff(t *testing.T) {
+	o := Options{MinBackoff: time.Second, MaxBackoff: 8 * time.Second}.withDefaults()
+	for n, ceiling := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second} {
+		if d := o.backoff(n); d < ceiling/2 || d > ceiling {
+			t.Fatalf("attempt %d: backoff %v outside [%v, %v]", n, d, ceiling/2, ceiling)
+		}
+	}
+	if d := o.backoff(100); d > 8*time.Second {
+		t.Fatalf("backoff %v past max", d)
+	}
+}
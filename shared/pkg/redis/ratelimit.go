@@ -0,0 +1,107 @@
+package redisutil
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimiter admits requests against a limit per key and window.
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimit, error)
+}
+
+// RateLimit is the outcome of a RateLimiter.Allow call.
+type RateLimit struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long a rejected caller has to wait for its next
+	// request to be admitted.
+	RetryAfter time.Duration
+	// Reset is how long until the full limit is available again.
+	Reset time.Duration
+}
+
+// tokenBucketScript refills limit tokens evenly over window and takes one per
+// request. The Redis clock is used so every replica sees the same bucket.
+// Returns {allowed, remaining, retry_after_ms, reset_ms}.
+var tokenBucketScript = redis.NewScript(`
+redis.replicate_commands()
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local rate = limit / window
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = limit
+  ts = now
+end
+tokens = math.min(limit, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], window)
+local retry = 0
+if allowed == 0 then
+  retry = math.ceil((1 - tokens) / rate)
+end
+return {allowed, math.floor(tokens), retry, math.ceil((limit - tokens) / rate)}
+`)
+
+type tokenBucket struct {
+	s redis.Scripter
+}
+
+// NewTokenBucketLimiter returns a RateLimiter that allows bursts of up to
+// limit requests and refills at limit per window, so there is no window
+// boundary to game.
+func NewTokenBucketLimiter(s redis.Scripter) RateLimiter {
+	return &tokenBucket{s: s}
+}
+
+func (b *tokenBucket) Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimit, error) {
+	res, err := tokenBucketScript.Run(ctx, b.s, []string{key}, limit, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return RateLimit{}, err
+	}
+	return RateLimit{
+		Allowed:    res[0] == 1,
+		Limit:      limit,
+		Remaining:  int(res[1]),
+		RetryAfter: time.Duration(res[2]) * time.Millisecond,
+		Reset:      time.Duration(res[3]) * time.Millisecond,
+	}, nil
+}
+
+type fixedWindow struct {
+	l Limiter
+}
+
+// NewFixedWindowLimiter adapts an INCR/EXPIRE Limiter: a key admits limit
+// requests in the window that starts with its first one.
+func NewFixedWindowLimiter(l Limiter) RateLimiter {
+	return &fixedWindow{l: l}
+}
+
+func (f *fixedWindow) Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimit, error) {
+	n, err := f.l.Incr(ctx, key)
+	if err != nil {
+		return RateLimit{}, err
+	}
+	if n == 1 {
+		_ = f.l.Expire(ctx, key, window)
+	}
+	rl := RateLimit{Allowed: n <= int64(limit), Limit: limit, Remaining: max(0, limit-int(n)), Reset: window}
+	if !rl.Allowed {
+		rl.RetryAfter = window
+	}
+	return rl, nil
+}
@@ -58,4 +58,16 @@ func TestRedis_WithRealContainer(t *testing.T) {
 	if _, err := IncrWithTTL(ctx, raw, "ctr", time.Minute); err != nil {
 		t.Fatalf("incr with ttl failed: %v", err)
 	}
+	// Token bucket: a burst of limit requests, then rejection with a wait
+	lim := NewTokenBucketLimiter(raw)
+	for i := 0; i < 3; i++ {
+		rl, err := lim.Allow(ctx, "bucket", 3, time.Minute)
+		if err != nil || !rl.Allowed || rl.Remaining != 2-i {
+			t.Fatalf("request %d: %+v err=%v", i, rl, err)
+		}
+	}
+	rl, err := lim.Allow(ctx, "bucket", 3, time.Minute)
+	if err != nil || rl.Allowed || rl.RetryAfter <= 0 || rl.RetryAfter > 20*time.Second {
+		t.Fatalf("over limit: %+v err=%v", rl, err)
+	}
 }
@@ -168,3 +168,22 @@ func TestIncrWithTTLError(t *testing.T) {
 		t.Fatalf("expected error from IncrWithTTL")
 	}
 }
+
+func TestFixedWindowLimiter(t *testing.T) {
+	c := &fakeCounter{}
+	lim := NewFixedWindowLimiter(NewLimiterFromCounter(c))
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		rl, err := lim.Allow(ctx, "x", 2, time.Minute)
+		if err != nil || !rl.Allowed || rl.Remaining != 1-i {
+			t.Fatalf("request %d: %+v err=%v", i, rl, err)
+		}
+	}
+	rl, err := lim.Allow(ctx, "x", 2, time.Minute)
+	if err != nil || rl.Allowed || rl.RetryAfter != time.Minute {
+		t.Fatalf("third request: %+v err=%v", rl, err)
+	}
+	if c.expireCalls != 1 {
+		t.Fatalf("expire calls=%d want 1", c.expireCalls)
+	}
+}
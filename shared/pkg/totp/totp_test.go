@@ -0,0 +1,52 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B, SHA-1 vectors truncated to 6 digits.
+func TestCodeAt_RFC6238Vectors(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for ts, want := range cases {
+		got, err := CodeAt(secret, Step(time.Unix(ts, 0)))
+		if err != nil || got != want {
+			t.Fatalf("t=%d got %q err=%v want %q", ts, got, err, want)
+		}
+	}
+}
+
+func TestValidate_Skew(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("secret: %v", err)
+	}
+	now := time.Now()
+	prev, _ := CodeAt(secret, Step(now)-1)
+	if step, ok := Validate(secret, prev, now, 1); !ok || step != Step(now)-1 {
+		t.Fatalf("previous step should validate within skew")
+	}
+	old, _ := CodeAt(secret, Step(now)-3)
+	if _, ok := Validate(secret, old, now, 1); ok {
+		t.Fatalf("code outside skew should fail")
+	}
+	if _, ok := Validate(secret, "12345", now, 1); ok {
+		t.Fatalf("short code should fail")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	u := ProvisioningURI("ABC", "sisfo", "a@b.c")
+	if !strings.HasPrefix(u, "otpauth://totp/sisfo:a@b.c?") || !strings.Contains(u, "secret=ABC") || !strings.Contains(u, "issuer=sisfo") {
+		t.Fatalf("unexpected uri %s", u)
+	}
+}
@@ -0,0 +1,228 @@
+// Package xlsx reads the cell values of the first worksheet of an Office Open
+// XML workbook. It covers what spreadsheet uploads need: shared and inline
+// strings, numbers and booleans. Formulas yield their cached value and styles
+// are ignored.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+var ErrNoSheet = errors.New("xlsx: workbook has no worksheet")
+
+// maxPartSize bounds how much of a single zip entry is decompressed.
+const maxPartSize = 64 << 20
+
+// ReadRows returns the first worksheet as rows of cell values. Missing cells
+// are empty strings and trailing empty cells are trimmed.
+func ReadRows(r io.ReaderAt, size int64) ([][]string, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("xlsx: %w", err)
+	}
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[strings.TrimPrefix(f.Name, "/")] = f
+	}
+	sheet, err := firstSheet(files)
+	if err != nil {
+		return nil, err
+	}
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if shared, err = readSharedStrings(f); err != nil {
+			return nil, err
+		}
+	}
+	return readSheet(sheet, shared)
+}
+
+type relationships struct {
+	Items []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type workbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// firstSheet resolves the first sheet listed in the workbook, falling back to
+// the conventional part name when the relationships cannot be followed.
+func firstSheet(files map[string]*zip.File) (*zip.File, error) {
+	var wb workbook
+	var rels relationships
+	if decodePart(files["xl/workbook.xml"], &wb) == nil && decodePart(files["xl/_rels/workbook.xml.rels"], &rels) == nil && len(wb.Sheets) > 0 {
+		for _, rel := range rels.Items {
+			if rel.ID != wb.Sheets[0].RelID {
+				continue
+			}
+			target := rel.Target
+			if strings.HasPrefix(target, "/") {
+				target = strings.TrimPrefix(target, "/")
+			} else {
+				target = path.Join("xl", target)
+			}
+			if f, ok := files[target]; ok {
+				return f, nil
+			}
+		}
+	}
+	if f, ok := files["xl/worksheets/sheet1.xml"]; ok {
+		return f, nil
+	}
+	return nil, ErrNoSheet
+}
+
+func decodePart(f *zip.File, v any) error {
+	if f == nil {
+		return ErrNoSheet
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+	return xml.NewDecoder(io.LimitReader(rc, maxPartSize)).Decode(v)
+}
+
+// richText is a string item that is either plain <t> or a run of <r><t>.
+type richText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (s richText) String() string {
+	if len(s.Runs) == 0 {
+		return s.T
+	}
+	var b strings.Builder
+	b.WriteString(s.T)
+	for _, r := range s.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+func readSharedStrings(f *zip.File) ([]string, error) {
+	var sst struct {
+		Items []richText `xml:"si"`
+	}
+	if err := decodePart(f, &sst); err != nil {
+		return nil, fmt.Errorf("xlsx: shared strings: %w", err)
+	}
+	out := make([]string, len(sst.Items))
+	for i, it := range sst.Items {
+		out[i] = it.String()
+	}
+	return out, nil
+}
+
+type cell struct {
+	Ref    string    `xml:"r,attr"`
+	Type   string    `xml:"t,attr"`
+	Value  string    `xml:"v"`
+	Inline *richText `xml:"is"`
+}
+
+func readSheet(f *zip.File, shared []string) ([][]string, error) {
+	var ws struct {
+		Rows []struct {
+			Index int    `xml:"r,attr"`
+			Cells []cell `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodePart(f, &ws); err != nil {
+		return nil, fmt.Errorf("xlsx: worksheet: %w", err)
+	}
+	var out [][]string
+	for _, row := range ws.Rows {
+		// Rows may be sparse; keep spreadsheet row numbers aligned with the
+		// returned slice so callers can report them.
+		for row.Index > len(out)+1 {
+			out = append(out, nil)
+		}
+		var vals []string
+		for i, c := range row.Cells {
+			col := i
+			if c.Ref != "" {
+				n, err := columnIndex(c.Ref)
+				if err != nil {
+					return nil, err
+				}
+				col = n
+			}
+			for len(vals) <= col {
+				vals = append(vals, "")
+			}
+			v, err := c.value(shared)
+			if err != nil {
+				return nil, err
+			}
+			vals[col] = v
+		}
+		for len(vals) > 0 && vals[len(vals)-1] == "" {
+			vals = vals[:len(vals)-1]
+		}
+		out = append(out, vals)
+	}
+	return out, nil
+}
+
+func (c cell) value(shared []string) (string, error) {
+	switch c.Type {
+	case "s":
+		if c.Value == "" {
+			return "", nil
+		}
+		var i int
+		if _, err := fmt.Sscan(c.Value, &i); err != nil || i < 0 || i >= len(shared) {
+			return "", fmt.Errorf("xlsx: cell %s: bad shared string index %q", c.Ref, c.Value)
+		}
+		return shared[i], nil
+	case "inlineStr":
+		if c.Inline == nil {
+			return "", nil
+		}
+		return c.Inline.String(), nil
+	case "b":
+		if c.Value == "1" {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	default:
+		return c.Value, nil
+	}
+}
+
+// columnIndex converts the column letters of a cell reference such as "AB12"
+// to a zero-based index.
+func columnIndex(ref string) (int, error) {
+	n := 0
+	i := 0
+	for ; i < len(ref); i++ {
+		ch := ref[i]
+		if ch >= 'a' && ch <= 'z' {
+			ch -= 'a' - 'A'
+		}
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		n = n*26 + int(ch-'A'+1)
+	}
+	if i == 0 || n > 16384 {
+		return 0, fmt.Errorf("xlsx: bad cell reference %q", ref)
+	}
+	return n - 1, nil
+}
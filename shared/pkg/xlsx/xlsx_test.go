@@ -0,0 +1,80 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func buildWorkbook(t *testing.T, parts map[string]string) *bytes.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestReadRows(t *testing.T) {
+	r := buildWorkbook(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Staff" sheetId="1" r:id="rId7"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId7" Type="worksheet" Target="worksheets/staff.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst><si><t>email</t></si><si><t>roles</t></si><si><r><t>guru</t></r><r><t>;staf</t></r></si></sst>`,
+		"xl/worksheets/staff.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+			<row r="3"><c r="A3" t="inlineStr"><is><t>a@school.id</t></is></c><c r="C3"><v>42</v></c><c r="D3" t="b"><v>1</v></c></row>
+			<row r="4"><c r="B4" t="s"><v>2</v></c><c r="C4" t="s"/></row>
+		</sheetData></worksheet>`,
+	})
+	rows, err := ReadRows(r, r.Size())
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	want := [][]string{
+		{"email", "roles"},
+		nil,
+		{"a@school.id", "", "42", "TRUE"},
+		{"", "guru;staf"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows=%q", rows)
+	}
+	for i := range want {
+		if len(want[i]) == 0 && len(rows[i]) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(rows[i], want[i]) {
+			t.Fatalf("row %d = %q, want %q", i+1, rows[i], want[i])
+		}
+	}
+}
+
+func TestReadRows_Errors(t *testing.T) {
+	r := bytes.NewReader([]byte("email,roles\n"))
+	if _, err := ReadRows(r, r.Size()); err == nil {
+		t.Fatalf("csv must not parse as a workbook")
+	}
+	r = buildWorkbook(t, map[string]string{"docProps/app.xml": `<Properties/>`})
+	if _, err := ReadRows(r, r.Size()); err != ErrNoSheet {
+		t.Fatalf("expected ErrNoSheet, got %v", err)
+	}
+	if _, err := columnIndex("12"); err == nil {
+		t.Fatalf("reference without column must fail")
+	}
+	if n, _ := columnIndex("AB7"); n != 27 {
+		t.Fatalf("AB => %d", n)
+	}
+}